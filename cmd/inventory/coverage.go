@@ -0,0 +1,390 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/hibiken/asynq"
+	"github.com/uptrace/bun"
+	"github.com/urfave/cli/v2"
+
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
+	"github.com/gardener/inventory/pkg/core/registry"
+	openstackmodels "github.com/gardener/inventory/pkg/openstack/models"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// NewCoverageCommand returns a new command, which reports the collection
+// coverage for a given account, project or subscription.
+func NewCoverageCommand() *cli.Command {
+	cmd := &cli.Command{
+		Name:    "coverage",
+		Usage:   "show collection coverage for an account, project or subscription",
+		Aliases: []string{"cov"},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "account-id",
+				Usage: "account id for which to report coverage",
+			},
+			&cli.StringFlag{
+				Name:  "project-id",
+				Usage: "project id for which to report coverage",
+			},
+			&cli.StringFlag{
+				Name:  "subscription-id",
+				Usage: "subscription id for which to report coverage",
+			},
+		},
+		Action: execCoverageCmd,
+	}
+
+	return cmd
+}
+
+// coverageScope specifies the scope, for which coverage is reported.
+type coverageScope struct {
+	// Providers specifies the providers, whose tasks and models are
+	// reported.
+	Providers []string
+
+	// Accounts specifies the values of the account column of the
+	// collection runs, which belong to the scope.
+	Accounts []string
+
+	// Column specifies the column of the provider models, which contains
+	// the scope, e.g. the account id.
+	Column string
+
+	// IDs specifies the values of the scope column, which belong to the
+	// scope.
+	IDs []string
+}
+
+// coverageItem represents the collection coverage of a single task type and
+// region for a given scope, as recorded by the collection runs.
+type coverageItem struct {
+	// TaskName is the name of the task.
+	TaskName string `bun:"task_name"`
+
+	// Region is the region of the runs, if any.
+	Region string `bun:"region"`
+
+	// Runs is the number of recorded runs of the task for the scope and
+	// region.
+	Runs int `bun:"runs"`
+
+	// LastSuccess is the completion time of the last successful run.
+	LastSuccess bun.NullTime `bun:"last_success"`
+
+	// Rows is the number of rows collected by the last successful run.
+	Rows *int64 `bun:"rows"`
+
+	// LastStatus is the status of the last run.
+	LastStatus string `bun:"last_status"`
+}
+
+// modelCoverageItem represents the number of rows of a single model, which
+// belong to a given scope.
+type modelCoverageItem struct {
+	// ModelName is the name of the model.
+	ModelName string `bun:"-"`
+
+	// Rows is the number of rows, which are not marked as deleted.
+	Rows int64 `bun:"rows"`
+
+	// Deleted is the number of rows, which are marked as deleted, if
+	// the model tracks deletion.
+	Deleted *int64 `bun:"deleted"`
+}
+
+// execCoverageCmd executes the command for reporting collection coverage.
+func execCoverageCmd(ctx *cli.Context) error {
+	accountID := ctx.String("account-id")
+	projectID := ctx.String("project-id")
+	subscriptionID := ctx.String("subscription-id")
+
+	var scope coverageScope
+	switch {
+	case countNonEmpty(accountID, projectID, subscriptionID) > 1:
+		return errors.New("must specify only one of --account-id, --project-id or --subscription-id")
+	case accountID != "":
+		scope = coverageScope{
+			Providers: []string{"aws"},
+			Accounts:  []string{accountID},
+			Column:    "account_id",
+			IDs:       []string{accountID},
+		}
+	case projectID != "":
+		scope = coverageScope{
+			Providers: []string{"gcp", "openstack"},
+			Accounts:  []string{projectID},
+			Column:    "project_id",
+			IDs:       []string{projectID},
+		}
+	case subscriptionID != "":
+		scope = coverageScope{
+			Providers: []string{"azure"},
+			Accounts:  []string{subscriptionID},
+			Column:    "subscription_id",
+			IDs:       []string{subscriptionID},
+		}
+	default:
+		return errors.New("must specify either --account-id, --project-id or --subscription-id")
+	}
+
+	conf := getConfig(ctx)
+	db, err := newDB(conf)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint: errcheck
+
+	// The collection runs of OpenStack tasks are recorded with the project
+	// name, while the OpenStack models contain the project id, so both of
+	// them are matched.
+	if projectID != "" {
+		if err := resolveOpenStackProject(ctx.Context, db, &scope); err != nil {
+			return err
+		}
+	}
+
+	items, err := getCoverageItems(ctx.Context, db, scope)
+	if err != nil {
+		return err
+	}
+
+	modelItems, err := getModelCoverageItems(ctx.Context, db, scope)
+	if err != nil {
+		return err
+	}
+
+	if err := renderCoverageItems(items); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	return renderModelCoverageItems(modelItems)
+}
+
+// countNonEmpty returns the number of non-empty values.
+func countNonEmpty(values ...string) int {
+	count := 0
+	for _, value := range values {
+		if value != "" {
+			count++
+		}
+	}
+
+	return count
+}
+
+// resolveOpenStackProject adds the names and ids of the OpenStack projects,
+// whose name or id matches the project id of the scope, to the scope.
+func resolveOpenStackProject(ctx context.Context, db *bun.DB, scope *coverageScope) error {
+	projects := make([]openstackmodels.Project, 0)
+	err := db.NewSelect().
+		Model(&projects).
+		Column("name", "project_id").
+		Where("project_id IN (?) OR name IN (?)", bun.In(scope.IDs), bun.In(scope.Accounts)).
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		if !slices.Contains(scope.Accounts, project.Name) {
+			scope.Accounts = append(scope.Accounts, project.Name)
+		}
+		if !slices.Contains(scope.IDs, project.ProjectID) {
+			scope.IDs = append(scope.IDs, project.ProjectID)
+		}
+	}
+
+	return nil
+}
+
+// getCoverageItems returns the coverage of the collection tasks of the scope,
+// which is aggregated from the latest runs of each task and region. Collection
+// tasks, which have never run for the scope, are reported as well, since they
+// indicate a coverage gap.
+func getCoverageItems(ctx context.Context, db *bun.DB, scope coverageScope) ([]coverageItem, error) {
+	items := make([]coverageItem, 0)
+	err := db.NewSelect().
+		Model((*auxmodels.CollectionRun)(nil)).
+		Column("task_name", "region").
+		ColumnExpr("count(*) AS runs").
+		ColumnExpr("max(completed_at) FILTER (WHERE status = ?) AS last_success", asynqutils.TaskStatusSucceeded).
+		ColumnExpr("(array_agg(rows ORDER BY completed_at DESC) FILTER (WHERE status = ?))[1] AS rows", asynqutils.TaskStatusSucceeded).
+		ColumnExpr("(array_agg(status ORDER BY completed_at DESC))[1] AS last_status").
+		Where("account IN (?)", bun.In(scope.Accounts)).
+		Group("task_name", "region").
+		Scan(ctx, &items)
+
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		seen[item.TaskName] = struct{}{}
+	}
+
+	walker := func(name string, _ asynq.Handler) error {
+		if !asynqutils.IsCollectionTask(name) || !slices.Contains(scope.Providers, asynqutils.TaskProvider(name)) {
+			return nil
+		}
+
+		if _, ok := seen[name]; !ok {
+			items = append(items, coverageItem{TaskName: name})
+		}
+
+		return nil
+	}
+
+	if err := registry.TaskRegistry.Range(walker); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(items, func(a, b coverageItem) int {
+		return cmp.Or(
+			cmp.Compare(a.TaskName, b.TaskName),
+			cmp.Compare(a.Region, b.Region),
+		)
+	})
+
+	return items, nil
+}
+
+// getModelCoverageItems returns the number of rows of the models of the scope
+// providers, which have the scope column, e.g. the account id.
+func getModelCoverageItems(ctx context.Context, db *bun.DB, scope coverageScope) ([]modelCoverageItem, error) {
+	items := make([]modelCoverageItem, 0)
+	walker := func(name string, model any) error {
+		if !slices.Contains(scope.Providers, asynqutils.TaskProvider(name)) {
+			return nil
+		}
+
+		table := db.Table(reflect.TypeOf(model))
+		if !table.HasField(scope.Column) {
+			return nil
+		}
+
+		q := db.NewSelect().
+			Model(model).
+			Where("? IN (?)", bun.Ident(scope.Column), bun.In(scope.IDs))
+
+		if table.HasField("deleted_at") {
+			q = q.ColumnExpr("count(*) FILTER (WHERE deleted_at IS NULL) AS rows").
+				ColumnExpr("count(*) FILTER (WHERE deleted_at IS NOT NULL) AS deleted")
+		} else {
+			q = q.ColumnExpr("count(*) AS rows")
+		}
+
+		item := modelCoverageItem{ModelName: name}
+		if err := q.Scan(ctx, &item); err != nil {
+			return err
+		}
+		items = append(items, item)
+
+		return nil
+	}
+
+	if err := registry.ModelRegistry.Range(walker); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(items, func(a, b modelCoverageItem) int {
+		return cmp.Compare(a.ModelName, b.ModelName)
+	})
+
+	return items, nil
+}
+
+// renderCoverageItems renders the coverage of the collection tasks as a table.
+func renderCoverageItems(items []coverageItem) error {
+	headers := []string{
+		"TASK",
+		"REGION",
+		"RUNS",
+		"LAST-SUCCESS",
+		"ROWS",
+		"LAST-STATUS",
+	}
+	table := newTableWriter(os.Stdout, headers)
+
+	for _, item := range items {
+		region := na
+		if item.Region != "" {
+			region = item.Region
+		}
+
+		lastSuccess := na
+		if !item.LastSuccess.IsZero() {
+			lastSuccess = item.LastSuccess.String()
+		}
+
+		rows := na
+		if item.Rows != nil {
+			rows = strconv.FormatInt(*item.Rows, 10)
+		}
+
+		lastStatus := na
+		if item.LastStatus != "" {
+			lastStatus = item.LastStatus
+		}
+
+		row := []string{
+			item.TaskName,
+			region,
+			strconv.Itoa(item.Runs),
+			lastSuccess,
+			rows,
+			lastStatus,
+		}
+		if err := table.Append(row); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
+
+// renderModelCoverageItems renders the number of rows of the models as a
+// table.
+func renderModelCoverageItems(items []modelCoverageItem) error {
+	headers := []string{
+		"MODEL",
+		"ROWS",
+		"DELETED",
+	}
+	table := newTableWriter(os.Stdout, headers)
+
+	for _, item := range items {
+		deleted := na
+		if item.Deleted != nil {
+			deleted = strconv.FormatInt(*item.Deleted, 10)
+		}
+
+		row := []string{
+			item.ModelName,
+			strconv.FormatInt(item.Rows, 10),
+			deleted,
+		}
+		if err := table.Append(row); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
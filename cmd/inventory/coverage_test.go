@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
+	awsmodels "github.com/gardener/inventory/pkg/aws/models"
+	awstasks "github.com/gardener/inventory/pkg/aws/tasks"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// TestCoverageItems verifies that the coverage is aggregated per task and
+// region, and that the models are counted by the scope column. The test is
+// skipped, unless the test database is configured via [dbtest.EnvDSN].
+func TestCoverageItems(t *testing.T) {
+	db := dbtest.New(t)
+	ctx := t.Context()
+
+	now := time.Now()
+	newRun := func(region, status string, rows int64, age time.Duration) auxmodels.CollectionRun {
+		return auxmodels.CollectionRun{
+			TaskID:      region + age.String(),
+			TaskName:    awstasks.TaskCollectInstances,
+			Provider:    "aws",
+			Account:     "123",
+			Region:      region,
+			StartedAt:   now.Add(-age),
+			CompletedAt: now.Add(-age),
+			Rows:        rows,
+			Status:      status,
+		}
+	}
+	runs := []auxmodels.CollectionRun{
+		newRun("eu-west-1", asynqutils.TaskStatusSucceeded, 1, 2*time.Hour),
+		newRun("eu-west-1", asynqutils.TaskStatusSucceeded, 2, time.Hour),
+		newRun("us-east-1", asynqutils.TaskStatusSucceeded, 3, 2*time.Hour),
+		newRun("us-east-1", asynqutils.TaskStatusFailed, 0, time.Hour),
+		newRun("eu-west-1", asynqutils.TaskStatusSucceeded, 4, time.Minute),
+	}
+	// The last run belongs to another account
+	runs[4].Account = "456"
+
+	if _, err := db.NewInsert().Model(&runs).Exec(ctx); err != nil {
+		t.Fatalf("unable to insert collection runs: %s", err)
+	}
+
+	instances := []awsmodels.Instance{
+		{InstanceID: "i-1", AccountID: "123", RegionName: "eu-west-1"},
+		{InstanceID: "i-2", AccountID: "123", RegionName: "us-east-1"},
+		{InstanceID: "i-3", AccountID: "456", RegionName: "eu-west-1"},
+	}
+	instances[1].DeletedAt = now
+	if _, err := db.NewInsert().Model(&instances).Exec(ctx); err != nil {
+		t.Fatalf("unable to insert instances: %s", err)
+	}
+
+	scope := coverageScope{
+		Providers: []string{"aws"},
+		Accounts:  []string{"123"},
+		Column:    "account_id",
+		IDs:       []string{"123"},
+	}
+
+	items, err := getCoverageItems(ctx, db, scope)
+	if err != nil {
+		t.Fatalf("unable to get coverage: %s", err)
+	}
+
+	type result struct {
+		runs       int
+		rows       int64
+		lastStatus string
+	}
+	got := make(map[string]result)
+	for _, item := range items {
+		if item.TaskName != awstasks.TaskCollectInstances {
+			continue
+		}
+
+		var rows int64
+		if item.Rows != nil {
+			rows = *item.Rows
+		}
+		got[item.Region] = result{runs: item.Runs, rows: rows, lastStatus: item.LastStatus}
+	}
+
+	want := map[string]result{
+		"eu-west-1": {runs: 2, rows: 2, lastStatus: asynqutils.TaskStatusSucceeded},
+		"us-east-1": {runs: 2, rows: 3, lastStatus: asynqutils.TaskStatusFailed},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want coverage %v, got %v", want, got)
+	}
+
+	modelItems, err := getModelCoverageItems(ctx, db, scope)
+	if err != nil {
+		t.Fatalf("unable to get model coverage: %s", err)
+	}
+
+	var found bool
+	for _, item := range modelItems {
+		if item.ModelName != awsmodels.InstanceModelName {
+			continue
+		}
+
+		found = true
+		if item.Rows != 1 || item.Deleted == nil || *item.Deleted != 1 {
+			t.Fatalf("want 1 row and 1 deleted row, got %+v", item)
+		}
+	}
+
+	if !found {
+		t.Fatalf("model %s is not reported", awsmodels.InstanceModelName)
+	}
+}
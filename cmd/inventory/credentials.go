@@ -0,0 +1,520 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/urfave/cli/v2"
+
+	"github.com/gardener/inventory/pkg/aws/stscreds/kubesatoken"
+	"github.com/gardener/inventory/pkg/aws/stscreds/tokenfile"
+	"github.com/gardener/inventory/pkg/core/config"
+)
+
+// errUnknownProvider is an error, which is returned when a credentials
+// template is requested for an unknown provider.
+var errUnknownProvider = errors.New("unknown provider")
+
+// errUnsupportedAuthentication is an error, which is returned when a
+// credentials template is requested for an authentication method, which is not
+// supported by the provider.
+var errUnsupportedAuthentication = errors.New("unsupported authentication method")
+
+// credentialsTemplateOptions provides the options for rendering a credentials
+// template.
+type credentialsTemplateOptions struct {
+	// Name specifies the name of the named credentials.
+	Name string
+
+	// Authentication specifies the authentication method of the named
+	// credentials, e.g. `password' for OpenStack, or the token retriever
+	// for AWS.
+	Authentication string
+
+	// DomainScoped specifies whether the OpenStack named credentials are
+	// scoped to a domain instead of a project.
+	DomainScoped bool
+}
+
+// credentialsTemplate describes the credentials template of a provider.
+type credentialsTemplate struct {
+	// Authentication specifies the path of the field, which selects the
+	// authentication method, relative to the provider configuration. The
+	// allowed values are specified in Choices.
+	Authentication string
+
+	// DefaultAuthentication specifies the authentication method, which is
+	// used when none is requested.
+	DefaultAuthentication string
+
+	// New returns the provider configuration, which is rendered as the
+	// template.
+	New func(opts credentialsTemplateOptions) any
+
+	// Required returns the paths of the required fields, relative to the
+	// provider configuration. A `*' matches any key of a map.
+	Required func(opts credentialsTemplateOptions) []string
+
+	// Choices specifies the allowed values for fields, keyed by their
+	// path relative to the provider configuration.
+	Choices map[string][]string
+}
+
+// credentialsTemplates provides the credentials templates of the supported
+// providers.
+var credentialsTemplates = map[string]credentialsTemplate{
+	"aws": {
+		Authentication:        "credentials.*.token_retriever",
+		DefaultAuthentication: config.DefaultAWSTokenRetriever,
+		New: func(opts credentialsTemplateOptions) any {
+			conf := config.AWSConfig{
+				IsEnabled: true,
+				AppID:     config.DefaultAWSAppID,
+				Credentials: map[string]config.AWSCredentialsConfig{
+					opts.Name: {TokenRetriever: opts.Authentication},
+				},
+			}
+			useCredentials(&conf.Services, opts.Name)
+
+			return conf
+		},
+		Required: func(opts credentialsTemplateOptions) []string {
+			required := []string{
+				"region",
+				"services.ec2.use_credentials",
+				"services.elb.use_credentials",
+				"services.elbv2.use_credentials",
+				"services.s3.use_credentials",
+				"credentials.*.token_retriever",
+			}
+
+			switch opts.Authentication {
+			case kubesatoken.TokenRetrieverName:
+				required = append(required,
+					"credentials.*.kube_sa_token.service_account",
+					"credentials.*.kube_sa_token.namespace",
+					"credentials.*.kube_sa_token.role_arn",
+				)
+			case tokenfile.TokenRetrieverName:
+				required = append(required,
+					"credentials.*.token_file.path",
+					"credentials.*.token_file.role_arn",
+				)
+			}
+
+			return required
+		},
+		Choices: map[string][]string{
+			"credentials.*.token_retriever": {
+				config.DefaultAWSTokenRetriever,
+				kubesatoken.TokenRetrieverName,
+				tokenfile.TokenRetrieverName,
+			},
+		},
+	},
+	"gcp": {
+		Authentication:        "credentials.*.authentication",
+		DefaultAuthentication: config.GCPAuthenticationMethodKeyFile,
+		New: func(opts credentialsTemplateOptions) any {
+			conf := config.GCPConfig{
+				IsEnabled: true,
+				Credentials: map[string]config.GCPCredentialsConfig{
+					opts.Name: {Authentication: opts.Authentication},
+				},
+				SoilCluster: config.GCPSoilClusterConfig{
+					UseCredentials: opts.Name,
+				},
+			}
+			useCredentials(&conf.Services, opts.Name)
+
+			return conf
+		},
+		Required: func(opts credentialsTemplateOptions) []string {
+			required := []string{
+				"services.resource_manager.use_credentials",
+				"services.compute.use_credentials",
+				"services.storage.use_credentials",
+				"services.gke.use_credentials",
+				"soil_cluster.use_credentials",
+				"credentials.*.authentication",
+				"credentials.*.projects",
+			}
+
+			if opts.Authentication == config.GCPAuthenticationMethodKeyFile {
+				required = append(required, "credentials.*.key_file.path")
+			}
+
+			return required
+		},
+		Choices: map[string][]string{
+			"credentials.*.authentication": {config.GCPAuthenticationMethodNone, config.GCPAuthenticationMethodKeyFile},
+		},
+	},
+	"azure": {
+		Authentication:        "credentials.*.authentication",
+		DefaultAuthentication: config.AzureAuthenticationMethodWorkloadIdentity,
+		New: func(opts credentialsTemplateOptions) any {
+			conf := config.AzureConfig{
+				IsEnabled: true,
+				Credentials: map[string]config.AzureCredentialsConfig{
+					opts.Name: {Authentication: opts.Authentication},
+				},
+			}
+			useCredentials(&conf.Services, opts.Name)
+
+			return conf
+		},
+		Required: func(opts credentialsTemplateOptions) []string {
+			required := []string{
+				"services.*.use_credentials",
+				"credentials.*.authentication",
+			}
+
+			if opts.Authentication == config.AzureAuthenticationMethodWorkloadIdentity {
+				required = append(required,
+					"credentials.*.workload_identity.client_id",
+					"credentials.*.workload_identity.tenant_id",
+					"credentials.*.workload_identity.token_file",
+				)
+			}
+
+			return required
+		},
+		Choices: map[string][]string{
+			"credentials.*.authentication": {config.AzureAuthenticationMethodDefault, config.AzureAuthenticationMethodWorkloadIdentity},
+		},
+	},
+	"openstack": {
+		Authentication:        "credentials.*.authentication",
+		DefaultAuthentication: config.OpenStackAuthenticationMethodPassword,
+		New: func(opts credentialsTemplateOptions) any {
+			conf := config.OpenStackConfig{
+				IsEnabled: true,
+				Credentials: map[string]config.OpenStackCredentialsConfig{
+					opts.Name: {
+						Authentication: opts.Authentication,
+						DomainScoped:   opts.DomainScoped,
+					},
+				},
+			}
+			useCredentials(&conf.Services, opts.Name)
+
+			return conf
+		},
+		Required: func(opts credentialsTemplateOptions) []string {
+			required := []string{
+				"credentials.*.authentication",
+				"credentials.*.domain",
+				"credentials.*.region",
+				"credentials.*.auth_endpoint",
+			}
+
+			// Domain-scoped credentials discover the projects of the
+			// domain instead.
+			if !opts.DomainScoped {
+				required = append(required, "credentials.*.project")
+			}
+
+			switch opts.Authentication {
+			case config.OpenStackAuthenticationMethodPassword:
+				required = append(required,
+					"credentials.*.password.username",
+					"credentials.*.password.password_file",
+				)
+			case config.OpenStackAuthenticationMethodAppCredentials:
+				required = append(required,
+					"credentials.*.app_credentials.app_credentials_id",
+					"credentials.*.app_credentials.app_credentials_secret_file",
+				)
+			case config.OpenStackAuthenticationMethodVaultSecret:
+				required = append(required,
+					"credentials.*.vault_secret.server",
+					"credentials.*.vault_secret.secret_engine",
+					"credentials.*.vault_secret.secret_path",
+				)
+			case config.OpenStackAuthenticationMethodFederated:
+				required = append(required,
+					"credentials.*.federated.identity_provider",
+					"credentials.*.federated.protocol",
+					"credentials.*.federated.token_file",
+				)
+			}
+
+			return required
+		},
+		Choices: map[string][]string{
+			"credentials.*.authentication": {
+				config.OpenStackAuthenticationMethodPassword,
+				config.OpenStackAuthenticationMethodAppCredentials,
+				config.OpenStackAuthenticationMethodVaultSecret,
+				config.OpenStackAuthenticationMethodFederated,
+			},
+		},
+	},
+}
+
+// newCredentialsTemplateOptions returns the [credentialsTemplateOptions] for
+// the given template, and validates that the requested authentication method
+// is supported by the provider.
+func newCredentialsTemplateOptions(tmpl credentialsTemplate, provider, name, authentication string, domainScoped bool) (credentialsTemplateOptions, error) {
+	if authentication == "" {
+		authentication = tmpl.DefaultAuthentication
+	}
+
+	if !slices.Contains(tmpl.Choices[tmpl.Authentication], authentication) {
+		return credentialsTemplateOptions{}, fmt.Errorf("%w: %s uses %s", errUnsupportedAuthentication, provider, authentication)
+	}
+
+	if domainScoped {
+		if provider != "openstack" {
+			return credentialsTemplateOptions{}, fmt.Errorf("%s: domain-scoped credentials are not supported", provider)
+		}
+		if authentication == config.OpenStackAuthenticationMethodAppCredentials {
+			return credentialsTemplateOptions{}, errDomainScopedAppCredentials
+		}
+	}
+
+	opts := credentialsTemplateOptions{
+		Name:           name,
+		Authentication: authentication,
+		DomainScoped:   domainScoped,
+	}
+
+	return opts, nil
+}
+
+// NewCredentialsCommand returns a new command for interfacing with provider
+// credentials.
+func NewCredentialsCommand() *cli.Command {
+	providers := slices.Sorted(func(yield func(string) bool) {
+		for name := range credentialsTemplates {
+			if !yield(name) {
+				return
+			}
+		}
+	})
+
+	cmd := &cli.Command{
+		Name:    "credentials",
+		Usage:   "credentials operations",
+		Aliases: []string{"creds"},
+		Subcommands: []*cli.Command{
+			{
+				Name:  "template",
+				Usage: "print a config template for the credentials of a provider",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "provider",
+						Aliases:  []string{"p"},
+						Usage:    fmt.Sprintf("provider name, one of: %s", strings.Join(providers, ", ")),
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "name",
+						Usage: "name of the named credentials",
+						Value: "default",
+					},
+					&cli.StringFlag{
+						Name:    "authentication",
+						Aliases: []string{"a"},
+						Usage:   "authentication method or AWS token retriever, defaults to the one of the provider",
+					},
+					&cli.BoolFlag{
+						Name:  "domain-scoped",
+						Usage: "use domain-scoped credentials (OpenStack only)",
+					},
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Usage:   "output format, one of: yaml, json",
+						Value:   "yaml",
+					},
+				},
+				Action: execCredentialsTemplateCmd,
+			},
+		},
+	}
+
+	return cmd
+}
+
+// execCredentialsTemplateCmd prints the credentials template for a provider.
+func execCredentialsTemplateCmd(ctx *cli.Context) error {
+	provider := ctx.String("provider")
+	tmpl, ok := credentialsTemplates[provider]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownProvider, provider)
+	}
+
+	opts, err := newCredentialsTemplateOptions(
+		tmpl,
+		provider,
+		ctx.String("name"),
+		ctx.String("authentication"),
+		ctx.Bool("domain-scoped"),
+	)
+	if err != nil {
+		return err
+	}
+
+	value := map[string]any{
+		provider: tmpl.New(opts),
+	}
+
+	switch format := ctx.String("format"); format {
+	case "yaml":
+		comments := yaml.CommentMap{
+			"$." + provider: {
+				yaml.HeadComment(
+					fmt.Sprintf(" Credentials template for %s, generated by `inventory credentials template'.", provider),
+					" Fields marked as required must be set, all other fields are optional.",
+				),
+			},
+		}
+		required := tmpl.Required(opts)
+		describeFields(reflect.ValueOf(value[provider]), "$."+provider, "", required, tmpl.Choices, comments)
+		data, err := yaml.MarshalWithOptions(value, yaml.WithComment(comments), yaml.IndentSequence(true))
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+
+		return err
+	case "json":
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		out, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, out, "", "  "); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, buf.String())
+
+		return err
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// useCredentials configures each of the services in the given services config
+// struct to use the named credentials.
+func useCredentials(services any, name string) {
+	v := reflect.ValueOf(services).Elem()
+	for i := range v.NumField() {
+		field := v.Field(i).FieldByName("UseCredentials")
+		if field.IsValid() && field.CanSet() && field.Kind() == reflect.Slice {
+			field.Set(reflect.ValueOf([]string{name}))
+		}
+	}
+}
+
+// describeFields walks the fields of the given value and adds a line comment
+// describing each field to the [yaml.CommentMap]. The yamlPath is the path of
+// the value in the document, and relPath is the path relative to the provider
+// configuration, which is used to look up required fields and choices.
+func describeFields(v reflect.Value, yamlPath, relPath string, required []string, choices map[string][]string, comments yaml.CommentMap) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+
+			fieldYAMLPath := yamlPath + "." + name
+			fieldRelPath := name
+			if relPath != "" {
+				fieldRelPath = relPath + "." + name
+			}
+
+			comments[fieldYAMLPath] = []*yaml.Comment{
+				yaml.LineComment(" " + describeField(field.Type, fieldRelPath, required, choices)),
+			}
+			describeFields(v.Field(i), fieldYAMLPath, fieldRelPath, required, choices, comments)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			describeFields(v.MapIndex(key), yamlPath+"."+key.String(), relPath+".*", required, choices, comments)
+		}
+	}
+}
+
+// describeField returns the description of a field with the given type and
+// path relative to the provider configuration.
+func describeField(t reflect.Type, relPath string, required []string, choices map[string][]string) string {
+	desc := typeName(t)
+	for _, pattern := range required {
+		if matchFieldPath(pattern, relPath) {
+			desc += ", required"
+
+			break
+		}
+	}
+
+	for pattern, values := range choices {
+		if matchFieldPath(pattern, relPath) {
+			desc += ", one of: " + strings.Join(values, ", ")
+		}
+	}
+
+	return desc
+}
+
+// matchFieldPath returns true, if the given path matches the pattern, where
+// each `*' component of the pattern matches any single path component.
+func matchFieldPath(pattern, path string) bool {
+	patternParts := strings.Split(pattern, ".")
+	pathParts := strings.Split(path, ".")
+	if len(patternParts) != len(pathParts) {
+		return false
+	}
+
+	for i, part := range patternParts {
+		if part != "*" && part != pathParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// typeName returns a human-readable name of the given type.
+func typeName(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return "duration"
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+
+		return "list of " + typeName(t.Elem())
+	case reflect.Map:
+		return "map of " + typeName(t.Elem())
+	case reflect.Struct:
+		return "object"
+	default:
+		return t.Kind().String()
+	}
+}
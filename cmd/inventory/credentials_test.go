@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gardener/inventory/pkg/aws/stscreds/kubesatoken"
+	"github.com/gardener/inventory/pkg/aws/stscreds/tokenfile"
+	"github.com/gardener/inventory/pkg/core/config"
+)
+
+// templateValue is the value used for required fields, which are not set by
+// the credentials templates.
+const templateValue = "value"
+
+// validateTemplate validates the given provider configuration, as rendered by
+// a credentials template, using the validator of the provider.
+func validateTemplate(provider string, value any) error {
+	conf := &config.Config{
+		Vault: config.VaultConfig{
+			Servers: map[string]config.VaultEndpointConfig{
+				templateValue: {},
+			},
+		},
+	}
+
+	switch provider {
+	case "aws":
+		conf.AWS = value.(config.AWSConfig)
+
+		return validateAWSConfig(conf)
+	case "gcp":
+		conf.GCP = value.(config.GCPConfig)
+
+		return validateGCPConfig(conf)
+	case "azure":
+		conf.Azure = value.(config.AzureConfig)
+
+		return validateAzureConfig(conf)
+	case "openstack":
+		conf.OpenStack = value.(config.OpenStackConfig)
+
+		return validateOpenStackConfig(conf)
+	default:
+		return errUnknownProvider
+	}
+}
+
+// setRequiredFields sets the fields of the given value, which match any of the
+// required paths and are not set yet. The fields matching the cleared path are
+// set to their zero value instead.
+func setRequiredFields(v reflect.Value, relPath string, required []string, cleared string) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			name := yamlFieldName(t.Field(i))
+			if name == "" {
+				continue
+			}
+
+			fieldRelPath := name
+			if relPath != "" {
+				fieldRelPath = relPath + "." + name
+			}
+			setRequiredFields(v.Field(i), fieldRelPath, required, cleared)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			setRequiredFields(elem, relPath+".*", required, cleared)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.String, reflect.Slice:
+		if cleared != "" && matchFieldPath(cleared, relPath) {
+			v.SetZero()
+
+			return
+		}
+
+		isRequired := slices.ContainsFunc(required, func(pattern string) bool {
+			return matchFieldPath(pattern, relPath)
+		})
+		if !isRequired || !v.IsZero() {
+			return
+		}
+
+		if v.Kind() == reflect.String {
+			v.SetString(templateValue)
+		} else {
+			v.Set(reflect.ValueOf([]string{templateValue}))
+		}
+	}
+}
+
+// yamlFieldName returns the YAML name of the given struct field.
+func yamlFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// newTemplateValue renders the credentials template with the given options,
+// and sets the required fields.
+func newTemplateValue(tmpl credentialsTemplate, opts credentialsTemplateOptions, cleared string) any {
+	value := reflect.New(reflect.TypeOf(tmpl.New(opts))).Elem()
+	value.Set(reflect.ValueOf(tmpl.New(opts)))
+	setRequiredFields(value, "", tmpl.Required(opts), cleared)
+
+	return value.Interface()
+}
+
+func TestCredentialsTemplates(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		provider     string
+		opts         credentialsTemplateOptions
+		wantRequired []string
+		notRequired  []string
+	}{
+		{
+			desc:         "aws with default token retriever",
+			provider:     "aws",
+			opts:         credentialsTemplateOptions{Authentication: config.DefaultAWSTokenRetriever},
+			wantRequired: []string{"region", "credentials.*.token_retriever"},
+			notRequired:  []string{"credentials.*.kube_sa_token.role_arn", "credentials.*.token_file.path"},
+		},
+		{
+			desc:         "aws with kube_sa_token retriever",
+			provider:     "aws",
+			opts:         credentialsTemplateOptions{Authentication: kubesatoken.TokenRetrieverName},
+			wantRequired: []string{"credentials.*.kube_sa_token.service_account", "credentials.*.kube_sa_token.role_arn"},
+			notRequired:  []string{"credentials.*.token_file.path"},
+		},
+		{
+			desc:         "aws with token_file retriever",
+			provider:     "aws",
+			opts:         credentialsTemplateOptions{Authentication: tokenfile.TokenRetrieverName},
+			wantRequired: []string{"credentials.*.token_file.path", "credentials.*.token_file.role_arn"},
+			notRequired:  []string{"credentials.*.kube_sa_token.role_arn"},
+		},
+		{
+			desc:         "gcp with key file",
+			provider:     "gcp",
+			opts:         credentialsTemplateOptions{Authentication: config.GCPAuthenticationMethodKeyFile},
+			wantRequired: []string{"soil_cluster.use_credentials", "credentials.*.projects", "credentials.*.key_file.path"},
+		},
+		{
+			desc:         "gcp with application default credentials",
+			provider:     "gcp",
+			opts:         credentialsTemplateOptions{Authentication: config.GCPAuthenticationMethodNone},
+			wantRequired: []string{"credentials.*.projects"},
+			notRequired:  []string{"credentials.*.key_file.path"},
+		},
+		{
+			desc:         "azure with workload identity",
+			provider:     "azure",
+			opts:         credentialsTemplateOptions{Authentication: config.AzureAuthenticationMethodWorkloadIdentity},
+			wantRequired: []string{"credentials.*.workload_identity.client_id", "credentials.*.workload_identity.token_file"},
+		},
+		{
+			desc:        "azure with default credentials",
+			provider:    "azure",
+			opts:        credentialsTemplateOptions{Authentication: config.AzureAuthenticationMethodDefault},
+			notRequired: []string{"credentials.*.workload_identity.client_id"},
+		},
+		{
+			desc:         "openstack project-scoped password",
+			provider:     "openstack",
+			opts:         credentialsTemplateOptions{Authentication: config.OpenStackAuthenticationMethodPassword},
+			wantRequired: []string{"credentials.*.project", "credentials.*.password.username"},
+			notRequired:  []string{"services.*.use_credentials", "credentials.*.app_credentials.app_credentials_id"},
+		},
+		{
+			desc:     "openstack domain-scoped password",
+			provider: "openstack",
+			opts: credentialsTemplateOptions{
+				Authentication: config.OpenStackAuthenticationMethodPassword,
+				DomainScoped:   true,
+			},
+			wantRequired: []string{"credentials.*.domain", "credentials.*.password.password_file"},
+			notRequired:  []string{"credentials.*.project"},
+		},
+		{
+			desc:         "openstack app credentials",
+			provider:     "openstack",
+			opts:         credentialsTemplateOptions{Authentication: config.OpenStackAuthenticationMethodAppCredentials},
+			wantRequired: []string{"credentials.*.project", "credentials.*.app_credentials.app_credentials_secret_file"},
+			notRequired:  []string{"credentials.*.password.username"},
+		},
+		{
+			desc:     "openstack domain-scoped vault secret",
+			provider: "openstack",
+			opts: credentialsTemplateOptions{
+				Authentication: config.OpenStackAuthenticationMethodVaultSecret,
+				DomainScoped:   true,
+			},
+			wantRequired: []string{"credentials.*.vault_secret.server", "credentials.*.vault_secret.secret_path"},
+			notRequired:  []string{"credentials.*.project"},
+		},
+		{
+			desc:         "openstack federated",
+			provider:     "openstack",
+			opts:         credentialsTemplateOptions{Authentication: config.OpenStackAuthenticationMethodFederated},
+			wantRequired: []string{"credentials.*.federated.identity_provider", "credentials.*.federated.token_file"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tmpl, ok := credentialsTemplates[tc.provider]
+			if !ok {
+				t.Fatalf("no credentials template for %s", tc.provider)
+			}
+			tc.opts.Name = "default"
+
+			required := tmpl.Required(tc.opts)
+			for _, path := range tc.wantRequired {
+				if !slices.Contains(required, path) {
+					t.Fatalf("want %s to be required, got %v", path, required)
+				}
+			}
+			for _, path := range tc.notRequired {
+				if slices.Contains(required, path) {
+					t.Fatalf("want %s not to be required, got %v", path, required)
+				}
+			}
+
+			// The template with all required fields set must be
+			// valid.
+			if err := validateTemplate(tc.provider, newTemplateValue(tmpl, tc.opts, "")); err != nil {
+				t.Fatalf("want valid template, got %s", err)
+			}
+
+			// Each of the required fields must be validated.
+			for _, pattern := range required {
+				value := newTemplateValue(tmpl, tc.opts, pattern)
+				if err := validateTemplate(tc.provider, value); err == nil {
+					t.Fatalf("want validation error without %s", pattern)
+				}
+			}
+		})
+	}
+}
+
+func TestNewCredentialsTemplateOptions(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		provider           string
+		authentication     string
+		domainScoped       bool
+		wantAuthentication string
+		wantErr            error
+	}{
+		{
+			desc:               "default authentication",
+			provider:           "openstack",
+			wantAuthentication: config.OpenStackAuthenticationMethodPassword,
+		},
+		{
+			desc:               "default aws token retriever",
+			provider:           "aws",
+			wantAuthentication: config.DefaultAWSTokenRetriever,
+		},
+		{
+			desc:               "domain-scoped openstack credentials",
+			provider:           "openstack",
+			authentication:     config.OpenStackAuthenticationMethodFederated,
+			domainScoped:       true,
+			wantAuthentication: config.OpenStackAuthenticationMethodFederated,
+		},
+		{
+			desc:           "unsupported authentication",
+			provider:       "gcp",
+			authentication: config.OpenStackAuthenticationMethodPassword,
+			wantErr:        errUnsupportedAuthentication,
+		},
+		{
+			desc:           "domain-scoped app credentials",
+			provider:       "openstack",
+			authentication: config.OpenStackAuthenticationMethodAppCredentials,
+			domainScoped:   true,
+			wantErr:        errDomainScopedAppCredentials,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tmpl := credentialsTemplates[tc.provider]
+			opts, err := newCredentialsTemplateOptions(tmpl, tc.provider, "default", tc.authentication, tc.domainScoped)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			if opts.Authentication != tc.wantAuthentication {
+				t.Fatalf("want authentication %s, got %s", tc.wantAuthentication, opts.Authentication)
+			}
+		})
+	}
+}
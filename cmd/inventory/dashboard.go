@@ -5,6 +5,7 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"time"
@@ -16,9 +17,43 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/cli/v2"
 
+	auxutils "github.com/gardener/inventory/pkg/auxiliary/utils"
 	"github.com/gardener/inventory/pkg/core/config"
+	"github.com/gardener/inventory/pkg/health"
+	inventorymetrics "github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/utils"
 )
 
+// Names of the collectors, which can be registered with the metrics endpoint of
+// the Dashboard service.
+const (
+	// dashboardCollectorQueue is the name of the collector, which reports
+	// the queue metrics.
+	dashboardCollectorQueue = "queue"
+
+	// dashboardCollectorRuntime is the name of the collector, which
+	// reports the standard Go and process metrics.
+	dashboardCollectorRuntime = "runtime"
+
+	// dashboardCollectorDB is the name of the collector, which reports the
+	// estimated number of rows of the model tables.
+	dashboardCollectorDB = "db"
+)
+
+// dashboardCollectors specifies the known collector names.
+var dashboardCollectors = []string{
+	dashboardCollectorQueue,
+	dashboardCollectorRuntime,
+	dashboardCollectorDB,
+}
+
+// defaultDashboardCollectors specifies the collectors, which are registered
+// when none are configured.
+var defaultDashboardCollectors = []string{
+	dashboardCollectorQueue,
+	dashboardCollectorRuntime,
+}
+
 // NewDashboardCommand returns a new command for interfacing with the dashboard.
 func NewDashboardCommand() *cli.Command {
 	cmd := &cli.Command{
@@ -46,42 +81,99 @@ func NewDashboardCommand() *cli.Command {
 				Aliases: []string{"s"},
 				Action: func(ctx *cli.Context) error {
 					conf := getConfig(ctx)
-					redisClientOpt := newRedisClientOpt(conf)
+					redisConnOpt := newRedisConnOpt(conf)
 					inspector := newInspector(conf)
 					defer inspector.Close() // nolint: errcheck
 
 					// Asynq UI
 					opts := asynqmon.Options{
 						RootPath:          "/",
-						RedisConnOpt:      redisClientOpt,
+						RedisConnOpt:      redisConnOpt,
 						ReadOnly:          conf.Dashboard.ReadOnly,
 						PrometheusAddress: conf.Dashboard.PrometheusEndpoint,
 					}
 					ui := asynqmon.New(opts)
 
+					metricsConf := conf.Dashboard.Metrics
+					collectorNames := metricsConf.Collectors
+					if len(collectorNames) == 0 {
+						collectorNames = defaultDashboardCollectors
+					}
+
+					// Database is used by the health checks, the
+					// read-only API and the database collector.
+					// Connections are established lazily.
+					db, err := newDB(conf)
+					if err != nil {
+						return err
+					}
+					defer db.Close() // nolint: errcheck
+
 					// Metrics
+					namespace := metricsConf.Namespace
+					if namespace == "" {
+						namespace = inventorymetrics.DefaultNamespace
+					}
 					promRegistry := prometheus.NewPedanticRegistry()
-					promRegistry.MustRegister(
-						// Queue metrics
-						metrics.NewQueueMetricsCollector(inspector),
-						// Standard Go metrics
-						collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
-						collectors.NewGoCollector(),
-					)
+					nsRegistry := prometheus.WrapRegistererWithPrefix(namespace+"_", promRegistry)
+					for _, name := range collectorNames {
+						switch name {
+						case dashboardCollectorQueue:
+							promRegistry.MustRegister(metrics.NewQueueMetricsCollector(inspector))
+						case dashboardCollectorRuntime:
+							promRegistry.MustRegister(
+								collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+								collectors.NewGoCollector(),
+							)
+						case dashboardCollectorDB:
+							nsRegistry.MustRegister(inventorymetrics.NewDBCollector(db, metricsConf.DBTimeout))
+						}
+					}
 
 					mux := http.NewServeMux()
 					mux.Handle("/", ui)
 					mux.Handle("/metrics", promhttp.HandlerFor(promRegistry, promhttp.HandlerOpts{}))
 
+					// Health checks
+					checks := map[string]health.CheckFunc{
+						"db": db.PingContext,
+						"redis": func(_ context.Context) error {
+							_, err := inspector.Queues()
+
+							return err
+						},
+					}
+					lastCollections := func(ctx context.Context) (map[string]time.Time, error) {
+						return auxutils.LastCollectionsByProvider(ctx, db)
+					}
+					health.NewHandler(checks, health.DefaultTimeout).
+						WithLastCollections(lastCollections).
+						Register(mux)
+
+					// Read-only API
+					if conf.Dashboard.API.IsEnabled {
+						apiServer, err := newAPIServer(conf, db)
+						if err != nil {
+							return err
+						}
+						mux.Handle("/api/", apiServer.Handler())
+					}
+
 					srv := &http.Server{
 						Addr:              conf.Dashboard.Address,
 						ReadHeaderTimeout: time.Second * 30,
 						Handler:           mux,
+						ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelError),
 					}
 
-					slog.Info("starting server", "address", conf.Dashboard.Address, "ui", "/", "metrics", "/metrics")
+					slog.Info("starting server", "address", conf.Dashboard.Address, "ui", "/", "metrics", "/metrics", "healthz", "/healthz", "readyz", "/readyz", "api", conf.Dashboard.API.IsEnabled, "collectors", collectorNames)
+
+					shutdownTimeout := conf.Dashboard.ShutdownTimeout
+					if shutdownTimeout <= 0 {
+						shutdownTimeout = config.DefaultShutdownTimeout
+					}
 
-					return srv.ListenAndServe()
+					return utils.ListenAndServe(ctx.Context, srv, nil, shutdownTimeout)
 				},
 			},
 		},
@@ -5,16 +5,23 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/migrate"
 	"github.com/urfave/cli/v2"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	"github.com/gardener/inventory/pkg/core/registry"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // NewDatabaseCommand returns a new command for interfacing with the database.
@@ -172,6 +179,10 @@ func execDatabaseMigrateCmd(ctx *cli.Context) error {
 		return err
 	}
 
+	if err := promoteTags(ctx.Context, conf, db); err != nil {
+		return err
+	}
+
 	if group.IsZero() {
 		fmt.Printf("database is up to date\n")
 
@@ -183,6 +194,39 @@ func execDatabaseMigrateCmd(ctx *cli.Context) error {
 	return nil
 }
 
+// promoteTags materializes the configured promoted tag keys into indexed
+// columns for each registered model, which provides tags. The tables are
+// changed in a single transaction, so that none of them is changed, if a
+// promoted key collides with an existing column.
+func promoteTags(ctx context.Context, conf *config.Config, db *bun.DB) error {
+	if len(conf.Database.PromotedTags) == 0 {
+		return nil
+	}
+
+	if _, err := dbutils.PromotedTagColumns(conf.Database.PromotedTags); err != nil {
+		return err
+	}
+
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		walker := func(name string, model any) error {
+			table := db.Table(reflect.TypeOf(model))
+			if !table.HasField(dbutils.TagsColumn) {
+				return nil
+			}
+
+			if err := dbutils.PromoteTags(ctx, tx, table.Name, conf.Database.PromotedTags); err != nil {
+				return err
+			}
+
+			slog.Info("promoted tags", "model", name, "keys", conf.Database.PromotedTags)
+
+			return nil
+		}
+
+		return registry.ModelRegistry.Range(walker)
+	})
+}
+
 // execDatabaseRollbackCmd executes the command for rolling back migrations.
 func execDatabaseRollbackCmd(ctx *cli.Context) error {
 	conf := getConfig(ctx)
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/utils/export"
+)
+
+const (
+	// exportFormatNDJSON specifies the NDJSON export format.
+	exportFormatNDJSON = "ndjson"
+
+	// exportFormatCSV specifies the CSV export format.
+	exportFormatCSV = "csv"
+)
+
+// NewExportCommand returns a new command for exporting models.
+func NewExportCommand() *cli.Command {
+	cmd := &cli.Command{
+		Name:  "export",
+		Usage: "export model records or relationship edges",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "model",
+				Aliases: []string{"m"},
+				Usage:   "model name to export",
+			},
+			&cli.BoolFlag{
+				Name:  "edges",
+				Usage: "export the relationship graph edges instead of model records",
+			},
+			&cli.PathFlag{
+				Name:    "dir",
+				Aliases: []string{"d"},
+				Usage:   "export the records of all models to NDJSON files in this directory",
+			},
+			&cli.StringSliceFlag{
+				Name:    "tables",
+				Aliases: []string{"t"},
+				Usage:   "export only the given tables (--dir only)",
+			},
+			&cli.TimestampFlag{
+				Name:   "since",
+				Usage:  "export only records updated at or after this RFC3339 timestamp",
+				Layout: time.RFC3339,
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "output format to use, ndjson or csv (edges only)",
+				Value:   exportFormatNDJSON,
+			},
+			&cli.PathFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "path to the output file, defaults to stdout",
+			},
+			&cli.IntFlag{
+				Name:  "flush-interval",
+				Usage: "flush the output after this number of records",
+				Value: export.DefaultFlushInterval,
+			},
+		},
+		Action: execExportCmd,
+	}
+
+	return cmd
+}
+
+// execExportCmd executes the command for exporting models.
+func execExportCmd(ctx *cli.Context) error {
+	modelName := ctx.String("model")
+	edges := ctx.Bool("edges")
+	dir := ctx.Path("dir")
+	format := ctx.String("format")
+
+	modes := 0
+	for _, isSet := range []bool{modelName != "", edges, dir != ""} {
+		if isSet {
+			modes++
+		}
+	}
+
+	switch {
+	case modes == 0:
+		return errors.New("must specify either --model, --edges or --dir")
+	case modes > 1:
+		return errors.New("--model, --edges and --dir are mutually exclusive")
+	case ctx.IsSet("tables") && dir == "":
+		return errors.New("--tables is supported only with --dir")
+	case ctx.IsSet("since") && edges:
+		return errors.New("--since is not supported with --edges")
+	}
+
+	var since time.Time
+	if ts := ctx.Timestamp("since"); ts != nil {
+		since = *ts
+	}
+
+	if dir != "" {
+		return execExportDir(ctx, dir, since)
+	}
+
+	switch format {
+	case exportFormatNDJSON:
+		// Supported for both models and edges
+	case exportFormatCSV:
+		if !edges {
+			return fmt.Errorf("format %q is supported only with --edges", format)
+		}
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+
+	var model any
+	if !edges {
+		var ok bool
+		model, ok = registry.ModelRegistry.Get(modelName)
+		if !ok {
+			return fmt.Errorf("model %q not found in registry", modelName)
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if path := ctx.Path("output"); path != "" {
+		f, err := os.Create(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint: errcheck
+		out = f
+	}
+
+	conf := getConfig(ctx)
+	db, err := newDB(conf)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint: errcheck
+
+	flushInterval := ctx.Int("flush-interval")
+	if !edges {
+		w := export.NewNDJSONWriter(out, flushInterval)
+
+		return export.Model(ctx.Context, db, model, w, export.UpdatedSince(db, model, since))
+	}
+
+	relationships, err := export.Relationships(ctx.Context, db)
+	if err != nil {
+		return err
+	}
+
+	var w export.EdgeWriter
+	switch format {
+	case exportFormatCSV:
+		w = export.NewCSVEdgeWriter(out, flushInterval)
+	default:
+		w = export.NewNDJSONWriter(out, flushInterval)
+	}
+
+	return export.Edges(ctx.Context, db, relationships, w)
+}
+
+// execExportDir exports the records of all models, or the ones specified via
+// --tables, to NDJSON files in the given directory.
+func execExportDir(ctx *cli.Context, dir string, since time.Time) error {
+	conf := getConfig(ctx)
+	db, err := newDB(conf)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint: errcheck
+
+	tables, err := export.Tables(db)
+	if err != nil {
+		return err
+	}
+
+	tables, err = export.FilterTables(tables, ctx.StringSlice("tables"))
+	if err != nil {
+		return err
+	}
+
+	opts := export.DumpOptions{
+		Since:         since,
+		FlushInterval: ctx.Int("flush-interval"),
+	}
+	manifest, err := export.Dump(ctx.Context, db, tables, filepath.Clean(dir), opts)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for _, entry := range manifest.Tables {
+		total += entry.Count
+	}
+
+	slog.Info(
+		"exported tables",
+		"dir", dir,
+		"tables", len(manifest.Tables),
+		"records", total,
+	)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/gardener/inventory/pkg/utils/export"
+)
+
+// NewImportCommand returns a new command for importing models.
+func NewImportCommand() *cli.Command {
+	cmd := &cli.Command{
+		Name:  "import",
+		Usage: "import model records exported with the export command",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:     "dir",
+				Aliases:  []string{"d"},
+				Usage:    "directory of an export created with export --dir",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "batch-size",
+				Usage: "number of records to upsert at once",
+				Value: export.DefaultImportBatchSize,
+			},
+		},
+		Action: execImportCmd,
+	}
+
+	return cmd
+}
+
+// execImportCmd executes the command for importing models.
+func execImportCmd(ctx *cli.Context) error {
+	dir := filepath.Clean(ctx.Path("dir"))
+	manifest, err := export.ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	conf := getConfig(ctx)
+	db, err := newDB(conf)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint: errcheck
+
+	tables, err := export.Tables(db)
+	if err != nil {
+		return err
+	}
+
+	// Validate the whole manifest before importing anything
+	entries, err := export.ValidateManifest(manifest, tables)
+	if err != nil {
+		return err
+	}
+
+	opts := export.ImportOptions{
+		BatchSize: ctx.Int("batch-size"),
+	}
+	if err := export.Import(ctx.Context, db, dir, entries, opts); err != nil {
+		return err
+	}
+
+	slog.Info(
+		"imported tables",
+		"dir", dir,
+		"tables", len(entries),
+		"exported_at", manifest.ExportedAt,
+	)
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 	"github.com/gardener/inventory/pkg/version"
 )
 
@@ -46,6 +47,16 @@ func main() {
 				Usage:   "database uri to connect to",
 				EnvVars: []string{"DATABASE_URI"},
 			},
+			&cli.StringFlag{
+				Name:    "log-level",
+				Usage:   "log level to use: [info|warn|error|debug]",
+				EnvVars: []string{"INVENTORY_LOG_LEVEL"},
+			},
+			&cli.StringFlag{
+				Name:    "log-format",
+				Usage:   "log format to use: [text|json]",
+				EnvVars: []string{"INVENTORY_LOG_FORMAT"},
+			},
 		},
 		Before: func(ctx *cli.Context) error {
 			configPaths := ctx.StringSlice("config")
@@ -54,17 +65,19 @@ func main() {
 				return fmt.Errorf("cannot parse config: %w", err)
 			}
 
-			logger, err := newLogger(os.Stdout, conf)
-			if err != nil {
-				return err
-			}
-			slog.SetDefault(logger)
-
 			// Overrides from flags/options
 			if ctx.IsSet("debug") {
 				conf.Debug = ctx.Bool("debug")
 			}
 
+			if ctx.IsSet("log-level") {
+				conf.Logging.Level = ctx.String("log-level")
+			}
+
+			if ctx.IsSet("log-format") {
+				conf.Logging.Format = ctx.String("log-format")
+			}
+
 			if ctx.IsSet("redis-endpoint") {
 				conf.Redis.Endpoint = ctx.String("redis-endpoint")
 			}
@@ -73,6 +86,23 @@ func main() {
 				conf.Database.DSN = ctx.String("database-uri")
 			}
 
+			// The logger is used as the default logger of all
+			// commands, and as the parent of the task-scoped
+			// loggers of the workers.
+			logger, err := newLogger(os.Stdout, conf)
+			if err != nil {
+				return err
+			}
+			slog.SetDefault(logger)
+
+			// The validate-config command reports invalid settings
+			// along with the rest of the checks.
+			if ctx.Args().First() != validateConfigCommandName {
+				if err := asynqutils.ValidateRedisConfig(conf.Redis); err != nil {
+					return err
+				}
+			}
+
 			ctx.Context = context.WithValue(ctx.Context, configKey{}, conf)
 
 			return nil
@@ -85,6 +115,12 @@ func main() {
 			NewQueueCommand(),
 			NewModelCommand(),
 			NewDashboardCommand(),
+			NewCoverageCommand(),
+			NewExportCommand(),
+			NewImportCommand(),
+			NewMaintenanceCommand(),
+			NewCredentialsCommand(),
+			NewValidateConfigCommand(),
 		},
 	}
 
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+
+	auxtasks "github.com/gardener/inventory/pkg/auxiliary/tasks"
+)
+
+// NewMaintenanceCommand returns a new command for one-shot maintenance
+// operations.
+func NewMaintenanceCommand() *cli.Command {
+	cmd := &cli.Command{
+		Name:  "maintenance",
+		Usage: "maintenance operations",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "backfill-global-id",
+				Usage: "populate the global ids of existing records",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "model",
+						Aliases: []string{"m"},
+						Usage:   "model to backfill (defaults to all models with global ids)",
+					},
+					&cli.IntFlag{
+						Name:  "batch-size",
+						Usage: "number of records to update at once",
+						Value: auxtasks.DefaultBackfillGlobalIDBatchSize,
+					},
+				},
+				Action: execBackfillGlobalIDCmd,
+			},
+		},
+	}
+
+	return cmd
+}
+
+// execBackfillGlobalIDCmd executes the command for backfilling global ids and
+// reports the number of updated records per model.
+func execBackfillGlobalIDCmd(ctx *cli.Context) error {
+	names := ctx.StringSlice("model")
+	if len(names) == 0 {
+		names = auxtasks.GlobalIDModels()
+	}
+
+	conf := getConfig(ctx)
+	db, err := newDB(conf)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint: errcheck
+
+	headers := []string{
+		"MODEL",
+		"UPDATED",
+	}
+	table := newTableWriter(os.Stdout, headers)
+
+	for _, name := range names {
+		count, err := auxtasks.BackfillGlobalID(ctx.Context, db, name, ctx.Int("batch-size"))
+		if err != nil {
+			return err
+		}
+
+		row := []string{
+			name,
+			strconv.FormatInt(count, 10),
+		}
+		if err := table.Append(row); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
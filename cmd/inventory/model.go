@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/uptrace/bun"
 	"github.com/urfave/cli/v2"
@@ -87,6 +88,10 @@ func NewModelCommand() *cli.Command {
 						Aliases: []string{"r"},
 						Usage:   "relationship to load for the model",
 					},
+					&cli.DurationFlag{
+						Name:  "stale-after",
+						Usage: "expected collection interval, after which records are considered stale (defaults to the configured staleness of the model)",
+					},
 				},
 				Action: func(ctx *cli.Context) error {
 					var templateBody string
@@ -174,6 +179,11 @@ func NewModelCommand() *cli.Command {
 						return err
 					}
 
+					staleAfter := conf.Staleness.StaleAfter(modelName)
+					if ctx.IsSet("stale-after") {
+						staleAfter = ctx.Duration("stale-after")
+					}
+
 					// Parse template
 					funcMap := template.FuncMap{
 						"HasPrefix":  strings.HasPrefix,
@@ -187,6 +197,8 @@ func NewModelCommand() *cli.Command {
 						"ToTitle":    strings.ToTitle,
 						"TrimPrefix": strings.TrimPrefix,
 						"TrimSuffix": strings.TrimSuffix,
+						"Duration":   time.ParseDuration,
+						"StaleAfter": func() time.Duration { return staleAfter },
 					}
 					tmpl, err := template.New("inventory").Funcs(funcMap).Parse(templateBody)
 					if err != nil {
@@ -12,9 +12,12 @@ import (
 	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/robfig/cron/v3"
 	"github.com/urfave/cli/v2"
 
+	"github.com/gardener/inventory/pkg/core/config"
 	"github.com/gardener/inventory/pkg/core/registry"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 )
 
 // NewSchedulerCommand returns a new command for interfacing with the scheduler.
@@ -23,6 +26,11 @@ func NewSchedulerCommand() *cli.Command {
 		Name:    "scheduler",
 		Usage:   "scheduler operations",
 		Aliases: []string{"s"},
+		Before: func(ctx *cli.Context) error {
+			conf := getConfig(ctx)
+
+			return validateSchedulerConfig(conf)
+		},
 		Subcommands: []*cli.Command{
 			{
 				Name:    "start",
@@ -31,12 +39,13 @@ func NewSchedulerCommand() *cli.Command {
 				Action: func(ctx *cli.Context) error {
 					conf := getConfig(ctx)
 					scheduler := newScheduler(conf)
+					asynqutils.ConfigureQueueRouting(conf.QueueRouting)
 
 					// Add the periodic tasks from the registry
 					walker := func(spec string, task *asynq.Task) error {
 						// TODO(dnaeon): add support for specifying queue for tasks
 						// originating from the registry.
-						queue := conf.Scheduler.DefaultQueue
+						queue := asynqutils.RouteQueue(task.Type(), conf.Scheduler.DefaultQueue)
 						id, err := scheduler.Register(
 							spec,
 							task,
@@ -62,8 +71,19 @@ func NewSchedulerCommand() *cli.Command {
 
 					// Add tasks from configuration file as well
 					for _, job := range conf.Scheduler.Jobs {
+						if job.IsDisabled {
+							slog.Info(
+								"periodic task disabled",
+								"name", job.Name,
+								"spec", job.Spec,
+								"source", "config",
+							)
+
+							continue
+						}
+
 						task := asynq.NewTask(job.Name, []byte(job.Payload))
-						queue := conf.Scheduler.DefaultQueue
+						queue := asynqutils.RouteQueue(task.Type(), conf.Scheduler.DefaultQueue)
 						if job.Queue != "" {
 							queue = job.Queue
 						}
@@ -159,3 +179,20 @@ func NewSchedulerCommand() *cli.Command {
 
 	return cmd
 }
+
+// validateSchedulerConfig validates the periodic jobs of the scheduler, so that
+// the scheduler fails to start with a clear error, instead of when registering
+// a job with an invalid cron spec.
+func validateSchedulerConfig(conf *config.Config) error {
+	for i, job := range conf.Scheduler.Jobs {
+		if job.Name == "" {
+			return fmt.Errorf("scheduler: %w: job #%d", errNoJobName, i)
+		}
+
+		if _, err := cron.ParseStandard(job.Spec); err != nil {
+			return fmt.Errorf("scheduler: invalid spec %q for job %s: %w", job.Spec, job.Name, err)
+		}
+	}
+
+	return nil
+}
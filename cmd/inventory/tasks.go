@@ -5,17 +5,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"github.com/urfave/cli/v2"
+	"go.opentelemetry.io/otel/trace"
 
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
 	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/tracing"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 )
 
 // NewTaskCommand returns a [cli.Command] for interfacing with task-related
@@ -294,16 +302,37 @@ func NewTaskCommand() *cli.Command {
 						Usage: "set timeout for task",
 						Value: 30 * time.Minute,
 					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "bypass the min collection interval",
+					},
+					&cli.BoolFlag{
+						Name:  "no-validate",
+						Usage: "skip validation of the task name, e.g. for tasks of extension workers",
+					},
 				},
 				Action: func(ctx *cli.Context) error {
+					taskName := ctx.String("task")
+					if !ctx.Bool("no-validate") {
+						if err := validateTaskName(taskName); err != nil {
+							return err
+						}
+					}
+
 					conf := getConfig(ctx)
 					client := newAsynqClient(conf)
 					defer client.Close() // nolint: errcheck
 
-					taskName := ctx.String("task")
 					timeout := ctx.Duration("timeout")
 					queue := ctx.String("queue")
 
+					// An explicitly specified queue takes
+					// precedence over the queue routes
+					if !ctx.IsSet("queue") {
+						asynqutils.ConfigureQueueRouting(conf.QueueRouting)
+						queue = asynqutils.RouteQueue(taskName, queue)
+					}
+
 					var payload []byte
 					payloadData := ctx.String("payload")
 					payloadFile := ctx.Path("payload-file")
@@ -320,12 +349,32 @@ func NewTaskCommand() *cli.Command {
 						payload = data
 					}
 
+					// Link the submitted task with the
+					// span of the submission, if tracing is
+					// enabled
+					shutdownTracing, err := tracing.Setup(ctx.Context, conf.Tracing)
+					if err != nil {
+						return fmt.Errorf("cannot configure tracing: %w", err)
+					}
+					defer shutdownTracing(context.Background()) // nolint: errcheck
+
+					spanCtx, span := tracing.Start(ctx.Context, "submit "+taskName, trace.WithSpanKind(trace.SpanKindProducer))
+					payload = asynqutils.InjectTraceContext(spanCtx, payload)
+
 					task := asynq.NewTask(taskName, payload)
 					opts := []asynq.Option{
 						asynq.Queue(queue),
 						asynq.Timeout(timeout),
 					}
-					info, err := client.EnqueueContext(ctx.Context, task, opts...)
+
+					// The prefix of forced tasks is propagated to
+					// the child tasks via their ids.
+					if ctx.Bool("force") {
+						opts = append(opts, asynq.TaskID(asynqutils.ForceTaskIDPrefix+uuid.NewString()))
+					}
+
+					info, err := client.EnqueueContext(spanCtx, task, opts...)
+					tracing.End(span, err)
 					if err != nil {
 						return fmt.Errorf("cannot enqueue %q task: %w", taskName, err)
 					}
@@ -421,12 +470,168 @@ func NewTaskCommand() *cli.Command {
 					return nil
 				},
 			},
+			{
+				Name:      "result",
+				Usage:     "show the persisted results of a task",
+				Aliases:   []string{"res"},
+				ArgsUsage: "<task-id>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "queue",
+						Aliases: []string{"q"},
+						Usage:   "name of queue to fetch the asynq task result from, if not persisted in the database",
+					},
+				},
+				Action: execTaskResultCmd,
+			},
+			{
+				Name:      "graph",
+				Usage:     "print the resolved collection ordering",
+				Aliases:   []string{"g"},
+				ArgsUsage: "[task...]",
+				Action:    execTaskGraphCmd,
+			},
 		},
 	}
 
 	return cmd
 }
 
+// execTaskGraphCmd prints the waves of the resolved collection ordering from
+// [registry.TaskGraph]. When tasks are specified, only the given tasks and
+// their prerequisites are printed.
+func execTaskGraphCmd(ctx *cli.Context) error {
+	waves, err := registry.TaskGraph.Waves(ctx.Args().Slice()...)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{
+		"WAVE",
+		"TASK",
+		"PREREQUISITES",
+	}
+	table := newTableWriter(os.Stdout, headers)
+	for i, wave := range waves {
+		for _, name := range wave {
+			prerequisites, _ := registry.TaskGraph.Prerequisites(name)
+			deps := na
+			if len(prerequisites) > 0 {
+				deps = strings.Join(prerequisites, ", ")
+			}
+
+			row := []string{
+				strconv.Itoa(i),
+				name,
+				deps,
+			}
+			if err := table.Append(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return table.Render()
+}
+
+// validateTaskName returns an error, if the given task name is not registered
+// in the [registry.TaskRegistry].
+func validateTaskName(name string) error {
+	if registry.TaskRegistry.Exists(name) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"unknown task %q, use `inventory task list' to view the registered tasks, or --no-validate to enqueue it anyway",
+		name,
+	)
+}
+
+// execTaskResultCmd prints the results of a task, which were persisted in the
+// database. When no results are found in the database and a queue is specified
+// the result stored in the asynq task is printed instead.
+func execTaskResultCmd(ctx *cli.Context) error {
+	taskID := ctx.Args().First()
+	if taskID == "" {
+		return errors.New("must specify task id")
+	}
+
+	conf := getConfig(ctx)
+	db, err := newDB(conf)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint: errcheck
+
+	runs := make([]auxmodels.CollectionRun, 0)
+	err = db.NewSelect().
+		Model(&runs).
+		Where("task_id = ?", taskID).
+		Order("started_at ASC").
+		Scan(ctx.Context)
+
+	if err != nil {
+		return err
+	}
+
+	queueName := ctx.String("queue")
+	if len(runs) == 0 && queueName != "" {
+		inspector := newInspector(conf)
+		defer inspector.Close() // nolint: errcheck
+		info, err := inspector.GetTaskInfo(queueName, taskID)
+		if err != nil {
+			return err
+		}
+
+		if info.Result == nil {
+			return fmt.Errorf("no result found for task %s", taskID)
+		}
+		fmt.Printf("%s\n", string(info.Result))
+
+		return nil
+	}
+
+	if len(runs) == 0 {
+		return fmt.Errorf("no result found for task %s", taskID)
+	}
+
+	headers := []string{
+		"TYPE",
+		"QUEUE",
+		"STARTED-AT",
+		"DURATION",
+		"ROWS",
+		"PAGES",
+		"SKIPPED",
+		"STATUS",
+		"ERROR",
+	}
+	table := newTableWriter(os.Stdout, headers)
+	for _, run := range runs {
+		runErr := run.Error
+		if runErr == "" {
+			runErr = na
+		}
+
+		row := []string{
+			run.TaskName,
+			run.Queue,
+			run.StartedAt.String(),
+			run.Duration.String(),
+			strconv.FormatInt(run.Rows, 10),
+			strconv.FormatInt(run.Pages, 10),
+			strconv.FormatInt(run.Skipped, 10),
+			run.Status,
+			runErr,
+		}
+		if err := table.Append(row); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
+
 // printTasksInState prints the tasks in the given state
 func printTasksInState(ctx *cli.Context, state asynq.TaskState) error {
 	page := ctx.Int("page")
@@ -6,6 +6,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"io"
@@ -13,6 +14,10 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/olekukonko/tablewriter"
@@ -21,12 +26,19 @@ import (
 	"github.com/uptrace/bun/extra/bundebug"
 	"github.com/uptrace/bun/migrate"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/time/rate"
 
 	"github.com/gardener/inventory/internal/pkg/migrations"
+	"github.com/gardener/inventory/pkg/api"
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
+	awsutils "github.com/gardener/inventory/pkg/aws/utils"
+	dbclient "github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/core/config"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 	workerutils "github.com/gardener/inventory/pkg/utils/asynq/worker"
 	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ratelimit"
 	slogutils "github.com/gardener/inventory/pkg/utils/slog"
 )
 
@@ -40,6 +52,18 @@ type configKey struct{}
 // service was not configured with a bind address.
 var errNoDashboardAddress = errors.New("no bind address specified")
 
+// errNoAPITokenFile is an error, which is returned when an API principal was
+// not configured with a token file.
+var errNoAPITokenFile = errors.New("no token file specified for API principal")
+
+// errUnknownDashboardCollector is an error, which is returned when the
+// Dashboard service was configured with an unknown metrics collector.
+var errUnknownDashboardCollector = errors.New("unknown dashboard metrics collector")
+
+// errNoJobName is an error, which is returned when a periodic job of the
+// scheduler was not configured with a task name.
+var errNoJobName = errors.New("no task name specified for periodic job")
+
 // errNoServiceCredentials is an error, which is returned when a cloud provider
 // API service (e.g. AWS, GCP, etc.)  does not have any named credentials
 // configured.
@@ -67,15 +91,71 @@ func getConfig(ctx *cli.Context) *config.Config {
 	return conf
 }
 
+// validateWorkerQueues validates that a worker, which handles the configured
+// providers only, does not process the tasks of the other providers.
+func validateWorkerQueues(conf *config.Config) error {
+	queues := asynqutils.WithRoutedQueues(conf.Worker.Queues, conf.QueueRouting)
+	queues = asynqutils.FilterProviderQueues(queues, conf.Worker.Providers)
+	if len(queues) == 0 {
+		queues = map[string]int{config.DefaultQueueName: 1}
+	}
+
+	return asynqutils.ValidateProviderQueues(queues, conf.Worker.Providers, conf.QueueRouting)
+}
+
 // validateDashboardConfig validates the Dashboard service configuration.
 func validateDashboardConfig(conf *config.Config) error {
 	if conf.Dashboard.Address == "" {
 		return errNoDashboardAddress
 	}
 
-	_, err := url.Parse(conf.Dashboard.PrometheusEndpoint)
+	if _, err := url.Parse(conf.Dashboard.PrometheusEndpoint); err != nil {
+		return err
+	}
+
+	for _, name := range conf.Dashboard.Metrics.Collectors {
+		if !slices.Contains(dashboardCollectors, name) {
+			return fmt.Errorf("%w: %s", errUnknownDashboardCollector, name)
+		}
+	}
 
-	return err
+	if !conf.Dashboard.API.IsEnabled {
+		return nil
+	}
+
+	for _, p := range conf.Dashboard.API.Principals {
+		if p.TokenFile == "" {
+			return fmt.Errorf("%w: %s", errNoAPITokenFile, p.Name)
+		}
+	}
+
+	return nil
+}
+
+// newAPIServer creates a new [api.Server] based on the provided
+// [config.Config] spec.
+func newAPIServer(conf *config.Config, db *bun.DB) (*api.Server, error) {
+	tokens := make(map[string]*api.Principal)
+	for _, p := range conf.Dashboard.API.Principals {
+		data, err := os.ReadFile(filepath.Clean(p.TokenFile))
+		if err != nil {
+			return nil, fmt.Errorf("api principal %s: %w", p.Name, err)
+		}
+
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return nil, fmt.Errorf("api principal %s: empty token", p.Name)
+		}
+
+		tokens[token] = &api.Principal{
+			Name:         p.Name,
+			Unrestricted: p.Unrestricted,
+			AccountIDs:   p.AccountIDs,
+			ProjectIDs:   p.ProjectIDs,
+		}
+	}
+
+	return api.NewServer(db, tokens, api.WithStaleAfter(conf.Staleness.StaleAfter)), nil
 }
 
 // newLogger creates a new [slog.Logger] based on the provided [config.Config]
@@ -84,28 +164,28 @@ func newLogger(w io.Writer, conf *config.Config) (*slog.Logger, error) {
 	return slogutils.NewFromConfig(w, conf.Logging)
 }
 
-// newRedisClientOpt returns a new [asynq.RedisClientOpt] from the given config.
-func newRedisClientOpt(conf *config.Config) asynq.RedisClientOpt {
-	return asynqutils.NewRedisClientOptFromConfig(conf.Redis)
+// newRedisConnOpt returns a new [asynq.RedisConnOpt] from the given config.
+func newRedisConnOpt(conf *config.Config) asynq.RedisConnOpt {
+	return asynqutils.NewRedisConnOptFromConfig(conf.Redis)
 }
 
 // newAsynqClient creates a new [asynq.Client] from the given config
 func newAsynqClient(conf *config.Config) *asynq.Client {
-	redisClientOpt := newRedisClientOpt(conf)
+	redisConnOpt := newRedisConnOpt(conf)
 
-	return asynq.NewClient(redisClientOpt)
+	return asynq.NewClient(redisConnOpt)
 }
 
 // newInspector returns a new [asynq.Inspector] from the given config.
 func newInspector(conf *config.Config) *asynq.Inspector {
-	redisClientOpt := newRedisClientOpt(conf)
+	redisConnOpt := newRedisConnOpt(conf)
 
-	return asynq.NewInspector(redisClientOpt)
+	return asynq.NewInspector(redisConnOpt)
 }
 
 // newWorker creates a new [workerutils.Worker] from the given config.
 func newWorker(ctx context.Context, conf *config.Config) *workerutils.Worker {
-	redisClientOpt := newRedisClientOpt(conf)
+	redisConnOpt := newRedisConnOpt(conf)
 	opts := make([]workerutils.Option, 0)
 	logLevel := asynq.InfoLevel
 	if conf.Debug {
@@ -113,20 +193,152 @@ func newWorker(ctx context.Context, conf *config.Config) *workerutils.Worker {
 	}
 
 	opts = append(opts, workerutils.WithLogLevel(logLevel))
+	opts = append(opts, workerutils.WithLogger(asynqutils.NewLogger(slog.Default())))
 	opts = append(opts, workerutils.WithErrorHandler(asynqutils.NewDefaultErrorHandler()))
-	worker := workerutils.NewFromConfig(ctx, redisClientOpt, conf.Worker, opts...)
+	worker := workerutils.NewFromConfig(ctx, redisConnOpt, conf.Worker, opts...)
 
 	// Configure middlewares
 	middlewares := []asynq.MiddlewareFunc{
+		asynqutils.NewTracingMiddleware(),
 		asynqutils.NewLoggerMiddleware(slog.Default()),
 		asynqutils.NewMeasuringMiddleware(),
 		asynqutils.NewMetricsMiddleware(),
+		dbutils.NewStatementTimeoutsMiddleware(conf.Database),
+	}
+
+	results := conf.Worker.Results
+	if results.IsEnabled || results.StoreInAsynq {
+		sinks := make([]asynqutils.ResultSink, 0)
+		if results.IsEnabled {
+			sinks = append(sinks, persistTaskResult)
+		}
+		middlewares = append(middlewares, asynqutils.NewResultMiddleware(results.StoreInAsynq, sinks...))
+	}
+
+	if conf.Worker.RawSamples.IsEnabled {
+		middlewares = append(middlewares, asynqutils.NewRawSamplesMiddleware(conf.Worker.RawSamples))
 	}
+
+	if conf.Worker.MinIntervals.IsEnabled {
+		middlewares = append(middlewares, asynqutils.NewMinIntervalMiddleware(conf.Worker.MinIntervals, dbIntervalStore{}))
+	}
+
+	// Tasks failing with permanent OpenStack authentication errors, e.g.
+	// a rejected federated token, are not retried.
+	if conf.OpenStack.IsEnabled && asynqutils.IsProviderSelected("openstack", conf.Worker.Providers) {
+		middlewares = append(middlewares, openstackutils.NewAuthErrorMiddleware())
+	}
+
+	// Tasks failing, because a hop of an AWS assume role chain could not
+	// be assumed, are not retried.
+	if conf.AWS.IsEnabled && asynqutils.IsProviderSelected("aws", conf.Worker.Providers) {
+		middlewares = append(middlewares, awsutils.NewAssumeRoleChainErrorMiddleware())
+	}
+
+	// Tasks failing with a permanent collection error are not retried.
+	middlewares = append(middlewares, asynqutils.NewCollectionErrorMiddleware())
+
 	worker.UseMiddlewares(middlewares...)
 
 	return worker
 }
 
+// persistTaskResult is an [asynqutils.ResultSink], which persists task results
+// in the database.
+func persistTaskResult(ctx context.Context, result asynqutils.TaskResult) error {
+	run := &auxmodels.CollectionRun{
+		TaskID:      result.TaskID,
+		TaskName:    result.TaskName,
+		Queue:       result.Queue,
+		Provider:    result.Provider,
+		Account:     result.Account,
+		Region:      result.Region,
+		StartedAt:   result.StartedAt,
+		CompletedAt: result.CompletedAt,
+		Duration:    result.Duration,
+		Rows:        result.Rows,
+		Pages:       result.Pages,
+		Skipped:     result.Skipped,
+		Status:      result.Status,
+		Error:       result.Error,
+	}
+
+	_, err := dbclient.DB.NewInsert().
+		Model(run).
+		Exec(ctx)
+
+	return err
+}
+
+// dbRowCountStore is an [asynqutils.RowCountStore], which looks up the number
+// of collected rows in the collection runs.
+type dbRowCountStore struct{}
+
+// LastRows implements the [asynqutils.RowCountStore] interface.
+func (dbRowCountStore) LastRows(ctx context.Context, taskType, account, region string) (int64, bool, error) {
+	var run auxmodels.CollectionRun
+	err := dbclient.DB.NewSelect().
+		Model(&run).
+		Column("rows").
+		Where("task_name = ?", taskType).
+		Where("account = ?", account).
+		Where("region = ?", region).
+		Where("status = ?", asynqutils.TaskStatusSucceeded).
+		Order("completed_at DESC").
+		Limit(1).
+		Scan(ctx)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, false, nil
+	case err != nil:
+		return 0, false, err
+	}
+
+	return run.Rows, true, nil
+}
+
+// dbIntervalStore is an [asynqutils.IntervalStore], which keeps track of the
+// collected scopes in the database.
+type dbIntervalStore struct{}
+
+// LastCollected implements the [asynqutils.IntervalStore] interface.
+func (dbIntervalStore) LastCollected(ctx context.Context, taskType, scope string) (time.Time, error) {
+	var marker auxmodels.CollectionMarker
+	err := dbclient.DB.NewSelect().
+		Model(&marker).
+		Where("task_name = ?", taskType).
+		Where("scope = ?", scope).
+		Scan(ctx)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return time.Time{}, nil
+	case err != nil:
+		return time.Time{}, err
+	}
+
+	return marker.CollectedAt, nil
+}
+
+// MarkCollected implements the [asynqutils.IntervalStore] interface.
+func (dbIntervalStore) MarkCollected(ctx context.Context, taskType, scope string, at time.Time) error {
+	marker := &auxmodels.CollectionMarker{
+		TaskName:    taskType,
+		Scope:       scope,
+		CollectedAt: at,
+	}
+
+	_, err := dbclient.DB.NewInsert().
+		Model(marker).
+		On("CONFLICT (task_name, scope) DO UPDATE").
+		Set("collected_at = EXCLUDED.collected_at").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
 // newDB returns a new [bun.DB] database from the given config.
 func newDB(conf *config.Config) (*bun.DB, error) {
 	db, err := dbutils.NewFromConfig(conf.Database)
@@ -172,9 +384,8 @@ func newMigrator(conf *config.Config, db *bun.DB) (*migrate.Migrator, error) {
 
 // newScheduler creates a new [asynq.Scheduler] from the given config.
 func newScheduler(conf *config.Config) *asynq.Scheduler {
-	redisClientOpt := newRedisClientOpt(conf)
+	redisConnOpt := newRedisConnOpt(conf)
 
-	// TODO: Logger, etc.
 	// TODO: PostEnqueue hook to emit metrics per tasks
 	preEnqueueFunc := func(t *asynq.Task, _ []asynq.Option) {
 		slog.Info("enqueueing task", "name", t.Type())
@@ -192,6 +403,7 @@ func newScheduler(conf *config.Config) *asynq.Scheduler {
 	opts := &asynq.SchedulerOpts{
 		PreEnqueueFunc:      preEnqueueFunc,
 		EnqueueErrorHandler: errEnqueueFunc,
+		Logger:              asynqutils.NewLogger(slog.Default()),
 		LogLevel:            logLevel,
 	}
 
@@ -199,7 +411,7 @@ func newScheduler(conf *config.Config) *asynq.Scheduler {
 		conf.Scheduler.DefaultQueue = config.DefaultQueueName
 	}
 
-	scheduler := asynq.NewScheduler(redisClientOpt, opts)
+	scheduler := asynq.NewScheduler(redisConnOpt, opts)
 
 	return scheduler
 }
@@ -230,3 +442,12 @@ func newTableWriter(w io.Writer, headers []string) *tablewriter.Table {
 
 	return table
 }
+
+// getRateLimiter returns the rate limiter shared by the API clients of the
+// given provider, which use the given named credentials. A nil limiter is
+// returned, if no rate limit is configured.
+func getRateLimiter(provider, namedCredentials string, conf config.RateLimitConfig) *rate.Limiter {
+	key := ratelimit.Key(provider, namedCredentials)
+
+	return ratelimit.DefaultRegistry.Get(key, conf.RequestsPerSecond, conf.Burst)
+}
@@ -12,19 +12,28 @@ import (
 	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
 	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
 	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 
+	"github.com/gardener/inventory/pkg/aws/stscreds/chain"
 	"github.com/gardener/inventory/pkg/aws/stscreds/kubesatoken"
 	"github.com/gardener/inventory/pkg/aws/stscreds/provider"
 	"github.com/gardener/inventory/pkg/aws/stscreds/tokenfile"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/core/config"
+	"github.com/gardener/inventory/pkg/utils"
 	"github.com/gardener/inventory/pkg/utils/ptr"
+	"github.com/gardener/inventory/pkg/utils/ratelimit"
 )
 
 // errNoAWSRegion is an error which is returned when there was no region or
@@ -70,6 +79,24 @@ func validateAWSConfig(conf *config.Config) error {
 		}
 	}
 
+	// AWS Config, SNS, IAM, RDS and EKS are optional, so we only validate
+	// the named credentials, if any are specified.
+	optionalServices := map[string][]string{
+		"config": conf.AWS.Services.Config.UseCredentials,
+		"sns":    conf.AWS.Services.SNS.UseCredentials,
+		"iam":    conf.AWS.Services.IAM.UseCredentials,
+		"rds":    conf.AWS.Services.RDS.UseCredentials,
+		"eks":    conf.AWS.Services.EKS.UseCredentials,
+	}
+
+	for service, namedCredentials := range optionalServices {
+		for _, nc := range namedCredentials {
+			if _, ok := conf.AWS.Credentials[nc]; !ok {
+				return fmt.Errorf("aws: %w: service %s refers %s", errUnknownNamedCredentials, service, nc)
+			}
+		}
+	}
+
 	// Each named credential must use a valid token retriever
 	supportedTokenRetrievers := []string{
 		config.DefaultAWSTokenRetriever,
@@ -84,6 +111,32 @@ func validateAWSConfig(conf *config.Config) error {
 		if !slices.Contains(supportedTokenRetrievers, creds.TokenRetriever) {
 			return fmt.Errorf("%w: %s", errUnknownAWSTokenRetriever, creds.TokenRetriever)
 		}
+
+		switch creds.TokenRetriever {
+		case kubesatoken.TokenRetrieverName:
+			if creds.KubeSATokenRetriever.ServiceAccount == "" {
+				return fmt.Errorf("aws: %w: credentials %s", kubesatoken.ErrNoServiceAccount, name)
+			}
+			if creds.KubeSATokenRetriever.Namespace == "" {
+				return fmt.Errorf("aws: %w: credentials %s", kubesatoken.ErrNoNamespace, name)
+			}
+			if creds.KubeSATokenRetriever.RoleARN == "" {
+				return fmt.Errorf("aws: %w: credentials %s", provider.ErrNoRoleARN, name)
+			}
+		case tokenfile.TokenRetrieverName:
+			if creds.TokenFileRetriever.Path == "" {
+				return fmt.Errorf("aws: %w: credentials %s", tokenfile.ErrNoTokenPath, name)
+			}
+			if creds.TokenFileRetriever.RoleARN == "" {
+				return fmt.Errorf("aws: %w: credentials %s", provider.ErrNoRoleARN, name)
+			}
+		}
+
+		for i, hop := range creds.AssumeRoleChain {
+			if hop.RoleARN == "" {
+				return fmt.Errorf("aws: %w: credentials %s, hop %d", chain.ErrNoRoleARN, name, i+1)
+			}
+		}
 	}
 
 	return nil
@@ -187,7 +240,63 @@ func loadAWSConfig(ctx context.Context, conf *config.Config, namedCredentials st
 		return aws.Config{}, errUnknownAWSTokenRetriever
 	}
 
-	return awsconfig.LoadDefaultConfig(ctx, opts...)
+	awsConf, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	if limiter := getRateLimiter("aws", namedCredentials, conf.AWS.RateLimit); limiter != nil {
+		base := awsConf.HTTPClient
+		if base == nil {
+			base = awshttp.NewBuildableClient()
+		}
+		awsConf.HTTPClient = &ratelimit.Client{Base: base, Limiter: limiter}
+	}
+
+	if len(creds.AssumeRoleChain) == 0 {
+		return awsConf, nil
+	}
+
+	hops := make([]chain.Hop, 0, len(creds.AssumeRoleChain))
+	for _, item := range creds.AssumeRoleChain {
+		hop := chain.Hop{
+			RoleARN:         item.RoleARN,
+			RoleSessionName: item.RoleSessionName,
+			ExternalID:      item.ExternalID,
+			Duration:        item.Duration,
+		}
+		hops = append(hops, hop)
+	}
+
+	credsProvider, err := chain.New(awsConf, hops)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	awsConf.Credentials = credsProvider
+
+	return awsConf, nil
+}
+
+// skipAWSCredentials returns true, if the client for the given service and
+// named credentials should be skipped, because the credentials could not be
+// obtained via the configured assume role chain. The failed hop is logged, so
+// that the remaining clients can still be configured.
+func skipAWSCredentials(service, namedCredentials string, err error) bool {
+	var hopErr *chain.HopError
+	if !errors.As(err, &hopErr) {
+		return false
+	}
+
+	slog.Error(
+		"skipping AWS client",
+		"service", service,
+		"credentials", namedCredentials,
+		"hop", hopErr.Hop,
+		"role_arn", hopErr.RoleARN,
+		"reason", hopErr.Err,
+	)
+
+	return true
 }
 
 // configureEC2Clientset configures the [awsclients.EC2Clientset] registry.
@@ -205,6 +314,10 @@ func configureEC2Clientset(ctx context.Context, conf *config.Config) error {
 		stsClient := sts.NewFromConfig(awsConf)
 		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 		if err != nil {
+			if skipAWSCredentials("ec2", namedCreds, err) {
+				continue
+			}
+
 			return err
 		}
 		client := &awsclients.Client[*ec2.Client]{
@@ -243,6 +356,10 @@ func configureELBClientset(ctx context.Context, conf *config.Config) error {
 		stsClient := sts.NewFromConfig(awsConf)
 		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 		if err != nil {
+			if skipAWSCredentials("elb", namedCreds, err) {
+				continue
+			}
+
 			return err
 		}
 		client := &awsclients.Client[*elb.Client]{
@@ -281,6 +398,10 @@ func configureELBv2Clientset(ctx context.Context, conf *config.Config) error {
 		stsClient := sts.NewFromConfig(awsConf)
 		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 		if err != nil {
+			if skipAWSCredentials("elbv2", namedCreds, err) {
+				continue
+			}
+
 			return err
 		}
 		client := &awsclients.Client[*elbv2.Client]{
@@ -319,6 +440,10 @@ func configureS3Clientset(ctx context.Context, conf *config.Config) error {
 		stsClient := sts.NewFromConfig(awsConf)
 		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 		if err != nil {
+			if skipAWSCredentials("s3", namedCreds, err) {
+				continue
+			}
+
 			return err
 		}
 		client := &awsclients.Client[*s3.Client]{
@@ -342,6 +467,217 @@ func configureS3Clientset(ctx context.Context, conf *config.Config) error {
 	return nil
 }
 
+// configureConfigServiceClientset configures the
+// [awsclients.ConfigServiceClientset] registry.
+func configureConfigServiceClientset(ctx context.Context, conf *config.Config) error {
+	for _, namedCreds := range conf.AWS.Services.Config.UseCredentials {
+		awsConf, err := loadAWSConfig(ctx, conf, namedCreds)
+		if err != nil {
+			return err
+		}
+
+		// Get the caller identity information associated with the named
+		// credentials which were used to create the client and register
+		// it.
+		awsClient := configservice.NewFromConfig(awsConf)
+		stsClient := sts.NewFromConfig(awsConf)
+		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			if skipAWSCredentials("config", namedCreds, err) {
+				continue
+			}
+
+			return err
+		}
+		client := &awsclients.Client[*configservice.Client]{
+			NamedCredentials: namedCreds,
+			AccountID:        ptr.StringFromPointer(callerIdentity.Account),
+			ARN:              ptr.StringFromPointer(callerIdentity.Arn),
+			UserID:           ptr.StringFromPointer(callerIdentity.UserId),
+			Client:           awsClient,
+		}
+		awsclients.ConfigServiceClientset.Overwrite(client.AccountID, client)
+		slog.Info(
+			"configured AWS client",
+			"service", "config",
+			"credentials", client.NamedCredentials,
+			"account_id", client.AccountID,
+			"arn", client.ARN,
+			"user_id", client.UserID,
+		)
+	}
+
+	return nil
+}
+
+// configureSNSClientset configures the [awsclients.SNSClientset] registry.
+func configureSNSClientset(ctx context.Context, conf *config.Config) error {
+	for _, namedCreds := range conf.AWS.Services.SNS.UseCredentials {
+		awsConf, err := loadAWSConfig(ctx, conf, namedCreds)
+		if err != nil {
+			return err
+		}
+
+		// Get the caller identity information associated with the named
+		// credentials which were used to create the client and register
+		// it.
+		awsClient := sns.NewFromConfig(awsConf)
+		stsClient := sts.NewFromConfig(awsConf)
+		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			if skipAWSCredentials("sns", namedCreds, err) {
+				continue
+			}
+
+			return err
+		}
+		client := &awsclients.Client[*sns.Client]{
+			NamedCredentials: namedCreds,
+			AccountID:        ptr.StringFromPointer(callerIdentity.Account),
+			ARN:              ptr.StringFromPointer(callerIdentity.Arn),
+			UserID:           ptr.StringFromPointer(callerIdentity.UserId),
+			Client:           awsClient,
+		}
+		awsclients.SNSClientset.Overwrite(client.AccountID, client)
+		slog.Info(
+			"configured AWS client",
+			"service", "sns",
+			"credentials", client.NamedCredentials,
+			"account_id", client.AccountID,
+			"arn", client.ARN,
+			"user_id", client.UserID,
+		)
+	}
+
+	return nil
+}
+
+// configureIAMClientset configures the [awsclients.IAMClientset] registry.
+func configureIAMClientset(ctx context.Context, conf *config.Config) error {
+	for _, namedCreds := range conf.AWS.Services.IAM.UseCredentials {
+		awsConf, err := loadAWSConfig(ctx, conf, namedCreds)
+		if err != nil {
+			return err
+		}
+
+		// Get the caller identity information associated with the named
+		// credentials which were used to create the client and register
+		// it.
+		awsClient := iam.NewFromConfig(awsConf)
+		stsClient := sts.NewFromConfig(awsConf)
+		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			if skipAWSCredentials("iam", namedCreds, err) {
+				continue
+			}
+
+			return err
+		}
+		client := &awsclients.Client[*iam.Client]{
+			NamedCredentials: namedCreds,
+			AccountID:        ptr.StringFromPointer(callerIdentity.Account),
+			ARN:              ptr.StringFromPointer(callerIdentity.Arn),
+			UserID:           ptr.StringFromPointer(callerIdentity.UserId),
+			Client:           awsClient,
+		}
+		awsclients.IAMClientset.Overwrite(client.AccountID, client)
+		slog.Info(
+			"configured AWS client",
+			"service", "iam",
+			"credentials", client.NamedCredentials,
+			"account_id", client.AccountID,
+			"arn", client.ARN,
+			"user_id", client.UserID,
+		)
+	}
+
+	return nil
+}
+
+// configureRDSClientset configures the [awsclients.RDSClientset] registry.
+func configureRDSClientset(ctx context.Context, conf *config.Config) error {
+	for _, namedCreds := range conf.AWS.Services.RDS.UseCredentials {
+		awsConf, err := loadAWSConfig(ctx, conf, namedCreds)
+		if err != nil {
+			return err
+		}
+
+		// Get the caller identity information associated with the named
+		// credentials which were used to create the client and register
+		// it.
+		awsClient := rds.NewFromConfig(awsConf)
+		stsClient := sts.NewFromConfig(awsConf)
+		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			if skipAWSCredentials("rds", namedCreds, err) {
+				continue
+			}
+
+			return err
+		}
+		client := &awsclients.Client[*rds.Client]{
+			NamedCredentials: namedCreds,
+			AccountID:        ptr.StringFromPointer(callerIdentity.Account),
+			ARN:              ptr.StringFromPointer(callerIdentity.Arn),
+			UserID:           ptr.StringFromPointer(callerIdentity.UserId),
+			Client:           awsClient,
+		}
+		awsclients.RDSClientset.Overwrite(client.AccountID, client)
+		slog.Info(
+			"configured AWS client",
+			"service", "rds",
+			"credentials", client.NamedCredentials,
+			"account_id", client.AccountID,
+			"arn", client.ARN,
+			"user_id", client.UserID,
+		)
+	}
+
+	return nil
+}
+
+// configureEKSClientset configures the [awsclients.EKSClientset] registry.
+func configureEKSClientset(ctx context.Context, conf *config.Config) error {
+	for _, namedCreds := range conf.AWS.Services.EKS.UseCredentials {
+		awsConf, err := loadAWSConfig(ctx, conf, namedCreds)
+		if err != nil {
+			return err
+		}
+
+		// Get the caller identity information associated with the named
+		// credentials which were used to create the client and register
+		// it.
+		awsClient := eks.NewFromConfig(awsConf)
+		stsClient := sts.NewFromConfig(awsConf)
+		callerIdentity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			if skipAWSCredentials("eks", namedCreds, err) {
+				continue
+			}
+
+			return err
+		}
+		client := &awsclients.Client[*eks.Client]{
+			NamedCredentials: namedCreds,
+			AccountID:        ptr.StringFromPointer(callerIdentity.Account),
+			ARN:              ptr.StringFromPointer(callerIdentity.Arn),
+			UserID:           ptr.StringFromPointer(callerIdentity.UserId),
+			Client:           awsClient,
+		}
+		awsclients.EKSClientset.Overwrite(client.AccountID, client)
+		slog.Info(
+			"configured AWS client",
+			"service", "eks",
+			"credentials", client.NamedCredentials,
+			"account_id", client.AccountID,
+			"arn", client.ARN,
+			"user_id", client.UserID,
+		)
+	}
+
+	return nil
+}
+
 // configureAWSClients creates the AWS clients for the supported by Inventory
 // AWS services and registers them.
 func configureAWSClients(ctx context.Context, conf *config.Config) error {
@@ -356,11 +692,21 @@ func configureAWSClients(ctx context.Context, conf *config.Config) error {
 		return err
 	}
 
+	awsclients.AccountSelection = utils.Selection{
+		Include: conf.AWS.IncludeAccounts,
+		Exclude: conf.AWS.ExcludeAccounts,
+	}
+
 	configFuncs := map[string]func(ctx context.Context, conf *config.Config) error{
-		"ec2":   configureEC2Clientset,
-		"elb":   configureELBClientset,
-		"elbv2": configureELBv2Clientset,
-		"s3":    configureS3Clientset,
+		"ec2":    configureEC2Clientset,
+		"elb":    configureELBClientset,
+		"elbv2":  configureELBv2Clientset,
+		"s3":     configureS3Clientset,
+		"config": configureConfigServiceClientset,
+		"sns":    configureSNSClientset,
+		"iam":    configureIAMClientset,
+		"rds":    configureRDSClientset,
+		"eks":    configureEKSClientset,
 	}
 
 	for svc, configFunc := range configFuncs {
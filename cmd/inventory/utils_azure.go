@@ -25,6 +25,7 @@ import (
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/core/config"
 	"github.com/gardener/inventory/pkg/utils/ptr"
+	"github.com/gardener/inventory/pkg/utils/ratelimit"
 )
 
 // errAzureNoClientID is an error, which is returned when Azure Workload
@@ -78,6 +79,18 @@ func validateAzureConfig(conf *config.Config) error {
 		if !slices.Contains(supportedAuthnMethods, creds.Authentication) {
 			return fmt.Errorf("azure: %w: %s uses %s", errUnknownAuthenticationMethod, name, creds.Authentication)
 		}
+
+		if creds.Authentication == config.AzureAuthenticationMethodWorkloadIdentity {
+			if creds.WorkloadIdentity.ClientID == "" {
+				return fmt.Errorf("azure: %w for %s", errAzureNoClientID, name)
+			}
+			if creds.WorkloadIdentity.TenantID == "" {
+				return fmt.Errorf("azure: %w for %s", errAzureNoTenantID, name)
+			}
+			if creds.WorkloadIdentity.TokenFile == "" {
+				return fmt.Errorf("azure: %w for %s", errAzureNoTokenFile, name)
+			}
+		}
 	}
 
 	return nil
@@ -154,6 +167,18 @@ func getAzureTokenProvider(conf *config.Config, namedCredentials string) (azcore
 	}
 }
 
+// getAzureClientOptions returns the [arm.ClientOptions] for the API clients,
+// which use the given named credentials. When a rate limit is configured, the
+// clients wait for the rate limiter before sending each request.
+func getAzureClientOptions(conf *config.Config, namedCredentials string) *arm.ClientOptions {
+	opts := &arm.ClientOptions{}
+	if limiter := getRateLimiter("azure", namedCredentials, conf.Azure.RateLimit); limiter != nil {
+		opts.Transport = &ratelimit.Client{Limiter: limiter}
+	}
+
+	return opts
+}
+
 // getAzureSubscriptions returns the slice of [armsubscription.Subscription] to
 // which the given [azcore.TokenCredential] has access to.
 func getAzureSubscriptions(ctx context.Context, creds azcore.TokenCredential) ([]*armsubscription.Subscription, error) {
@@ -206,7 +231,7 @@ func configureAzureComputeClientsets(ctx context.Context, conf *config.Config) e
 			factory, err := armcompute.NewClientFactory(
 				subscriptionID,
 				tokenProvider,
-				&arm.ClientOptions{},
+				getAzureClientOptions(conf, namedCreds),
 			)
 			if err != nil {
 				return err
@@ -231,6 +256,26 @@ func configureAzureComputeClientsets(ctx context.Context, conf *config.Config) e
 				"subscription_id", subscriptionID,
 				"subscription_name", subscriptionName,
 			)
+
+			// Register Managed Disks client
+			disksClient := factory.NewDisksClient()
+			azureclients.DisksClientset.Overwrite(
+				subscriptionID,
+				&azureclients.Client[*armcompute.DisksClient]{
+					NamedCredentials: namedCreds,
+					SubscriptionID:   subscriptionID,
+					SubscriptionName: subscriptionName,
+					Client:           disksClient,
+				},
+			)
+			slog.Info(
+				"configured Azure client",
+				"service", "compute",
+				"sub_service", "disks",
+				"credentials", namedCreds,
+				"subscription_id", subscriptionID,
+				"subscription_name", subscriptionName,
+			)
 		}
 	}
 
@@ -257,7 +302,7 @@ func configureAzureResourceManagerClientsets(ctx context.Context, conf *config.C
 			return err
 		}
 
-		subFactory, err := armsubscription.NewClientFactory(tokenProvider, &arm.ClientOptions{})
+		subFactory, err := armsubscription.NewClientFactory(tokenProvider, getAzureClientOptions(conf, namedCreds))
 		if err != nil {
 			return err
 		}
@@ -293,7 +338,7 @@ func configureAzureResourceManagerClientsets(ctx context.Context, conf *config.C
 			rgFactory, err := armresources.NewClientFactory(
 				subscriptionID,
 				tokenProvider,
-				&arm.ClientOptions{},
+				getAzureClientOptions(conf, namedCreds),
 			)
 			if err != nil {
 				return err
@@ -349,7 +394,7 @@ func configureAzureNetworkClientsets(ctx context.Context, conf *config.Config) e
 			factory, err := armnetwork.NewClientFactory(
 				subscriptionID,
 				tokenProvider,
-				&arm.ClientOptions{},
+				getAzureClientOptions(conf, namedCreds),
 			)
 			if err != nil {
 				return err
@@ -468,7 +513,7 @@ func configureAzureStorageClientsets(ctx context.Context, conf *config.Config) e
 			factory, err := armstorage.NewClientFactory(
 				subscriptionID,
 				tokenProvider,
-				&arm.ClientOptions{},
+				getAzureClientOptions(conf, namedCreds),
 			)
 			if err != nil {
 				return err
@@ -16,12 +16,21 @@ import (
 	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1"
+	htransport "google.golang.org/api/transport/http"
 
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/config"
+	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
+	"github.com/gardener/inventory/pkg/utils"
+	"github.com/gardener/inventory/pkg/utils/ratelimit"
 	"github.com/gardener/inventory/pkg/version"
 )
 
+// gcpCloudPlatformScope is the OAuth2 scope, which is requested by the
+// rate-limited HTTP client shared by the GCP API clients.
+const gcpCloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
 // errNoGCPKeyFile is an error, which is returned when no path to a service
 // account JSON Key File was specified for a named credential.
 var errNoGCPKeyFile = errors.New("no service account JSON key file specified")
@@ -59,6 +68,14 @@ func validateGCPConfig(conf *config.Config) error {
 		}
 	}
 
+	// Cloud SQL is optional, so we only validate the named credentials,
+	// if any are specified.
+	for _, nc := range conf.GCP.Services.CloudSQL.UseCredentials {
+		if _, ok := conf.GCP.Credentials[nc]; !ok {
+			return fmt.Errorf("gcp: %w: service cloud_sql refers to %s", errUnknownNamedCredentials, nc)
+		}
+	}
+
 	// Validate the named credentials for using valid authentication
 	// methods/strategies.
 	supportedAuthnMethods := []string{
@@ -73,9 +90,12 @@ func validateGCPConfig(conf *config.Config) error {
 		if !slices.Contains(supportedAuthnMethods, creds.Authentication) {
 			return fmt.Errorf("gcp: %w: %s uses %s", errUnknownAuthenticationMethod, name, creds.Authentication)
 		}
-		if len(creds.Projects) == 0 {
+		if len(creds.Projects) == 0 && !creds.DiscoverProjects {
 			return fmt.Errorf("gcp: %w: credentials %s", errNoGCPProjects, name)
 		}
+		if creds.Authentication == config.GCPAuthenticationMethodKeyFile && creds.KeyFile.Path == "" {
+			return fmt.Errorf("gcp: %w: credentials %s", errNoGCPKeyFile, name)
+		}
 	}
 
 	return nil
@@ -83,7 +103,11 @@ func validateGCPConfig(conf *config.Config) error {
 
 // getGCPClientOptions returns the slice of [option.ClientOption], which are
 // derived from the configured named credentials settings.
-func getGCPClientOptions(conf *config.Config, namedCredentials string) ([]option.ClientOption, error) {
+//
+// When a rate limit is configured, the options are used to create an
+// authenticated HTTP client, which waits for the rate limiter before sending
+// each request.
+func getGCPClientOptions(ctx context.Context, conf *config.Config, namedCredentials string) ([]option.ClientOption, error) {
 	creds, ok := conf.GCP.Credentials[namedCredentials]
 	if !ok {
 		return nil, fmt.Errorf("gcp: %w: %s", errUnknownNamedCredentials, namedCredentials)
@@ -109,14 +133,76 @@ func getGCPClientOptions(conf *config.Config, namedCredentials string) ([]option
 		return nil, fmt.Errorf("gcp: %w: %s uses %s", errUnknownAuthenticationMethod, namedCredentials, creds.Authentication)
 	}
 
-	return opts, nil
+	limiter := getRateLimiter("gcp", namedCredentials, conf.GCP.RateLimit)
+	if limiter == nil {
+		return opts, nil
+	}
+
+	opts = append(opts, option.WithScopes(gcpCloudPlatformScope))
+	httpClient, _, err := htransport.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("gcp: cannot create http client for %s: %w", namedCredentials, err)
+	}
+	httpClient.Transport = &ratelimit.Transport{
+		Base:    httpClient.Transport,
+		Limiter: limiter,
+	}
+
+	return []option.ClientOption{option.WithHTTPClient(httpClient)}, nil
+}
+
+// gcpService specifies a GCP API service along with the named credentials used
+// by it, and the func, which configures the clients of the service for a single
+// project.
+type gcpService struct {
+	name           string
+	useCredentials []string
+	exists         func(projectID string) bool
+	configure      func(ctx context.Context, namedCreds string, opts []option.ClientOption, project string) error
+}
+
+// getGCPServices returns the GCP API services, for which clients are
+// configured.
+func getGCPServices(conf *config.Config) []gcpService {
+	return []gcpService{
+		{
+			name:           "resource_manager",
+			useCredentials: conf.GCP.Services.ResourceManager.UseCredentials,
+			exists:         gcpclients.ProjectsClientset.Exists,
+			configure:      configureGCPResourceManagerClients,
+		},
+		{
+			name:           "compute",
+			useCredentials: conf.GCP.Services.Compute.UseCredentials,
+			exists:         gcpclients.InstancesClientset.Exists,
+			configure:      configureGCPComputeClients,
+		},
+		{
+			name:           "storage",
+			useCredentials: conf.GCP.Services.Storage.UseCredentials,
+			exists:         gcpclients.StorageClientset.Exists,
+			configure:      configureGCPStorageClients,
+		},
+		{
+			name:           "gke",
+			useCredentials: conf.GCP.Services.GKE.UseCredentials,
+			exists:         gcpclients.ClusterManagerClientset.Exists,
+			configure:      configureGKEClients,
+		},
+		{
+			name:           "cloud_sql",
+			useCredentials: conf.GCP.Services.CloudSQL.UseCredentials,
+			exists:         gcpclients.SQLAdminClientset.Exists,
+			configure:      configureCloudSQLClients,
+		},
+	}
 }
 
-// configureGCPResourceManagerClientsets configures the GCP Resource Manager API
-// clientsets.
-func configureGCPResourceManagerClientsets(ctx context.Context, conf *config.Config) error {
-	for _, namedCreds := range conf.GCP.Services.ResourceManager.UseCredentials {
-		opts, err := getGCPClientOptions(conf, namedCreds)
+// configureGCPServiceClients configures the clients of the given service for
+// the projects specified for each of the named credentials used by the service.
+func configureGCPServiceClients(ctx context.Context, conf *config.Config, svc gcpService) error {
+	for _, namedCreds := range svc.useCredentials {
+		opts, err := getGCPClientOptions(ctx, conf, namedCreds)
 		if err != nil {
 			return err
 		}
@@ -128,296 +214,410 @@ func configureGCPResourceManagerClientsets(ctx context.Context, conf *config.Con
 
 		// Register the client for each specified GCP project
 		for _, project := range nc.Projects {
-			c, err := resourcemanager.NewProjectsRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create client for %s: %w", namedCreds, err)
+			if err := svc.configure(ctx, namedCreds, opts, project); err != nil {
+				return err
 			}
-			gcpclients.ProjectsClientset.Overwrite(
-				project,
-				&gcpclients.Client[*resourcemanager.ProjectsClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           c,
-				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "resource_manager",
-				"sub_service", "projects",
-				"credentials", namedCreds,
-				"project", project,
-			)
 		}
 	}
 
 	return nil
 }
 
-// configureGCPComputeClientsets configures the GCP Compute API clientsets.
-func configureGCPComputeClientsets(ctx context.Context, conf *config.Config) error {
-	for _, namedCreds := range conf.GCP.Services.Compute.UseCredentials {
-		opts, err := getGCPClientOptions(conf, namedCreds)
-		if err != nil {
-			return err
+// configureGCPDiscoveryClients configures the project discovery clients for the
+// named credentials configured for project discovery, which are used by any of
+// the given services. See [gcputils.RefreshProjects] for more details.
+//
+// Failing to configure a discovery client or to discover the projects is not
+// fatal, since the discovery is retried when the collection tasks fan out.
+func configureGCPDiscoveryClients(ctx context.Context, conf *config.Config, services []gcpService) {
+	for name, creds := range conf.GCP.Credentials {
+		if !creds.DiscoverProjects {
+			continue
 		}
 
-		nc, ok := conf.GCP.Credentials[namedCreds]
-		if !ok {
-			return fmt.Errorf("gcp: %w: %s", errUnknownNamedCredentials, namedCreds)
-		}
+		used := slices.DeleteFunc(slices.Clone(services), func(svc gcpService) bool {
+			return !slices.Contains(svc.useCredentials, name)
+		})
 
-		// Register the client for each specified GCP project
-		for _, project := range nc.Projects {
-			// Instances
-			instanceClient, err := compute.NewInstancesRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create instance client for %s: %w", namedCreds, err)
-			}
-			gcpclients.InstancesClientset.Overwrite(
-				project,
-				&gcpclients.Client[*compute.InstancesClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           instanceClient,
-				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "compute",
-				"sub_service", "instances",
-				"credentials", namedCreds,
-				"project", project,
+		if len(used) == 0 {
+			slog.Warn(
+				"skipping GCP project discovery for unused credentials",
+				"credentials", name,
 			)
 
-			// VPCs
-			networkClient, err := compute.NewNetworksRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create network client for %s: %w", namedCreds, err)
-			}
-			gcpclients.NetworksClientset.Overwrite(
-				project,
-				&gcpclients.Client[*compute.NetworksClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           networkClient,
-				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "compute",
-				"sub_service", "networks",
-				"credentials", namedCreds,
-				"project", project,
-			)
+			continue
+		}
 
-			// Regional Addresses client
-			addrClient, err := compute.NewAddressesRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create addresses client for %s: %w", namedCreds, err)
-			}
-			gcpclients.AddressesClientset.Overwrite(
-				project,
-				&gcpclients.Client[*compute.AddressesClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           addrClient,
-				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "compute",
-				"sub_service", "addresses",
-				"credentials", namedCreds,
-				"project", project,
+		opts, err := getGCPClientOptions(ctx, conf, name)
+		if err != nil {
+			slog.Warn(
+				"skipping GCP project discovery",
+				"credentials", name,
+				"reason", err,
 			)
 
-			// Global Addresses client
-			globalAddrClient, err := compute.NewGlobalAddressesRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create global addresses client for %s: %w", namedCreds, err)
-			}
-			gcpclients.GlobalAddressesClientset.Overwrite(
-				project,
-				&gcpclients.Client[*compute.GlobalAddressesClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           globalAddrClient,
-				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "compute",
-				"sub_service", "global-addresses",
-				"credentials", namedCreds,
-				"project", project,
-			)
+			continue
+		}
 
-			// Subnet clients
-			subnetClient, err := compute.NewSubnetworksRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create subnet client for %s: %w", namedCreds, err)
-			}
-			gcpclients.SubnetworksClientset.Overwrite(
-				project,
-				&gcpclients.Client[*compute.SubnetworksClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           subnetClient,
-				},
+		client, err := resourcemanager.NewProjectsRESTClient(ctx, opts...)
+		if err != nil {
+			slog.Warn(
+				"skipping GCP project discovery",
+				"credentials", name,
+				"reason", err,
 			)
 
-			slog.Info(
-				"configured GCP client",
-				"service", "compute",
-				"sub_service", "subnetworks",
-				"credentials", namedCreds,
-				"project", project,
-			)
+			continue
+		}
 
-			// Disk clients
-			diskClient, err := compute.NewDisksRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create disk client for %s: %w", namedCreds, err)
-			}
-			gcpclients.DisksClientset.Overwrite(
-				project,
-				&gcpclients.Client[*compute.DisksClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           diskClient,
+		discoveryServices := make([]gcpclients.Service, 0, len(used))
+		for _, svc := range used {
+			discoveryServices = append(discoveryServices, gcpclients.Service{
+				Name:   svc.name,
+				Exists: svc.exists,
+				Configure: func(ctx context.Context, projectID string) error {
+					return svc.configure(ctx, name, opts, projectID)
 				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "compute",
-				"sub_service", "disks",
-				"credentials", namedCreds,
-				"project", project,
-			)
+			})
+		}
 
-			// Forwarding Rules clients
-			frClient, err := compute.NewForwardingRulesRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create forwarding rules client for %s: %w", namedCreds, err)
-			}
-			gcpclients.ForwardingRulesClientset.Overwrite(
-				project,
-				&gcpclients.Client[*compute.ForwardingRulesClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           frClient,
-				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "compute",
-				"sub_service", "forwarding-rules",
-				"credentials", namedCreds,
-				"project", project,
-			)
+		gcpclients.DiscoveryClientset.Overwrite(
+			name,
+			&gcpclients.DiscoveryClient{
+				NamedCredentials: name,
+				Client:           client,
+				Services:         discoveryServices,
+			},
+		)
+		slog.Info(
+			"configured GCP project discovery client",
+			"credentials", name,
+			"services", len(discoveryServices),
+		)
+	}
 
-			// Target Pools clients
-			tpClient, err := compute.NewTargetPoolsRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create target pools client for %s: %w", namedCreds, err)
-			}
-			gcpclients.TargetPoolsClientset.Overwrite(
-				project,
-				&gcpclients.Client[*compute.TargetPoolsClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           tpClient,
-				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "compute",
-				"sub_service", "target-pools",
-				"credentials", namedCreds,
-				"project", project,
-			)
-		}
+	if err := gcputils.RefreshProjects(ctx); err != nil {
+		slog.Warn("failed to discover GCP projects", "reason", err)
 	}
+}
+
+// configureGCPResourceManagerClients configures the GCP Resource Manager API
+// clients for the given project.
+func configureGCPResourceManagerClients(ctx context.Context, namedCreds string, opts []option.ClientOption, project string) error {
+	c, err := resourcemanager.NewProjectsRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create client for %s: %w", namedCreds, err)
+	}
+	gcpclients.ProjectsClientset.Overwrite(
+		project,
+		&gcpclients.Client[*resourcemanager.ProjectsClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           c,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "resource_manager",
+		"sub_service", "projects",
+		"credentials", namedCreds,
+		"project", project,
+	)
 
 	return nil
 }
 
-// configureGCPStorageClientsets configures the GCP storage API clientsets.
-func configureGCPStorageClientsets(ctx context.Context, conf *config.Config) error {
-	for _, namedCreds := range conf.GCP.Services.Storage.UseCredentials {
-		opts, err := getGCPClientOptions(conf, namedCreds)
-		if err != nil {
-			return err
-		}
+// configureGCPComputeClients configures the GCP Compute API
+// clients for the given project.
+func configureGCPComputeClients(ctx context.Context, namedCreds string, opts []option.ClientOption, project string) error {
+	// Instances
+	instanceClient, err := compute.NewInstancesRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create instance client for %s: %w", namedCreds, err)
+	}
+	gcpclients.InstancesClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.InstancesClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           instanceClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "instances",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// VPCs
+	networkClient, err := compute.NewNetworksRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create network client for %s: %w", namedCreds, err)
+	}
+	gcpclients.NetworksClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.NetworksClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           networkClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "networks",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// Regional Addresses client
+	addrClient, err := compute.NewAddressesRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create addresses client for %s: %w", namedCreds, err)
+	}
+	gcpclients.AddressesClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.AddressesClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           addrClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "addresses",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// Global Addresses client
+	globalAddrClient, err := compute.NewGlobalAddressesRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create global addresses client for %s: %w", namedCreds, err)
+	}
+	gcpclients.GlobalAddressesClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.GlobalAddressesClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           globalAddrClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "global-addresses",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// Subnet clients
+	subnetClient, err := compute.NewSubnetworksRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create subnet client for %s: %w", namedCreds, err)
+	}
+	gcpclients.SubnetworksClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.SubnetworksClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           subnetClient,
+		},
+	)
+
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "subnetworks",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// Disk clients
+	diskClient, err := compute.NewDisksRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create disk client for %s: %w", namedCreds, err)
+	}
+	gcpclients.DisksClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.DisksClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           diskClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "disks",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// Forwarding Rules clients
+	frClient, err := compute.NewForwardingRulesRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create forwarding rules client for %s: %w", namedCreds, err)
+	}
+	gcpclients.ForwardingRulesClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.ForwardingRulesClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           frClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "forwarding-rules",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// Target Pools clients
+	tpClient, err := compute.NewTargetPoolsRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create target pools client for %s: %w", namedCreds, err)
+	}
+	gcpclients.TargetPoolsClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.TargetPoolsClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           tpClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "target-pools",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// Firewalls clients
+	fwClient, err := compute.NewFirewallsRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create firewalls client for %s: %w", namedCreds, err)
+	}
+	gcpclients.FirewallsClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.FirewallsClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           fwClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "firewalls",
+		"credentials", namedCreds,
+		"project", project,
+	)
+
+	// Snapshots clients
+	snapshotsClient, err := compute.NewSnapshotsRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create snapshots client for %s: %w", namedCreds, err)
+	}
+	gcpclients.SnapshotsClientset.Overwrite(
+		project,
+		&gcpclients.Client[*compute.SnapshotsClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           snapshotsClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "compute",
+		"sub_service", "snapshots",
+		"credentials", namedCreds,
+		"project", project,
+	)
 
-		nc, ok := conf.GCP.Credentials[namedCreds]
-		if !ok {
-			return fmt.Errorf("gcp: %w: %s", errUnknownNamedCredentials, namedCreds)
-		}
+	return nil
+}
 
-		// Register the client for each specified GCP project
-		for _, project := range nc.Projects {
-			// Buckets
-			storageClient, err := storage.NewClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create gcp storage client for %s: %w", namedCreds, err)
-			}
-			gcpclients.StorageClientset.Overwrite(
-				project,
-				&gcpclients.Client[*storage.Client]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           storageClient,
-				},
-			)
-			slog.Info(
-				"configured GCP client",
-				"service", "storage",
-				"credentials", namedCreds,
-				"project", project,
-			)
-		}
+// configureGCPStorageClients configures the GCP storage API
+// clients for the given project.
+func configureGCPStorageClients(ctx context.Context, namedCreds string, opts []option.ClientOption, project string) error {
+	// Buckets
+	storageClient, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create gcp storage client for %s: %w", namedCreds, err)
 	}
+	gcpclients.StorageClientset.Overwrite(
+		project,
+		&gcpclients.Client[*storage.Client]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           storageClient,
+		},
+	)
+	slog.Info(
+		"configured GCP client",
+		"service", "storage",
+		"credentials", namedCreds,
+		"project", project,
+	)
 
 	return nil
 }
 
-// configureGKEClientsets configures the GKE related API clients.
-func configureGKEClientsets(ctx context.Context, conf *config.Config) error {
-	for _, namedCreds := range conf.GCP.Services.GKE.UseCredentials {
-		opts, err := getGCPClientOptions(conf, namedCreds)
-		if err != nil {
-			return err
-		}
-
-		nc, ok := conf.GCP.Credentials[namedCreds]
-		if !ok {
-			return fmt.Errorf("gcp: %w: %s", errUnknownNamedCredentials, namedCreds)
-		}
+// configureGKEClients configures the GCP GKE API
+// clients for the given project.
+func configureGKEClients(ctx context.Context, namedCreds string, opts []option.ClientOption, project string) error {
+	client, err := container.NewClusterManagerRESTClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create gcp cluster manager client for %s: %w", namedCreds, err)
+	}
+	gcpclients.ClusterManagerClientset.Overwrite(
+		project,
+		&gcpclients.Client[*container.ClusterManagerClient]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           client,
+		},
+	)
+
+	slog.Info(
+		"configured GCP client",
+		"service", "cluster_manager",
+		"credentials", namedCreds,
+		"project", project,
+	)
 
-		// Register the client for each specified GCP project
-		for _, project := range nc.Projects {
-			client, err := container.NewClusterManagerRESTClient(ctx, opts...)
-			if err != nil {
-				return fmt.Errorf("gcp: cannot create gcp cluster manager client for %s: %w", namedCreds, err)
-			}
-			gcpclients.ClusterManagerClientset.Overwrite(
-				project,
-				&gcpclients.Client[*container.ClusterManagerClient]{
-					NamedCredentials: namedCreds,
-					ProjectID:        project,
-					Client:           client,
-				},
-			)
+	return nil
+}
 
-			slog.Info(
-				"configured GCP client",
-				"service", "cluster_manager",
-				"credentials", namedCreds,
-				"project", project,
-			)
-		}
+// configureCloudSQLClients configures the GCP Cloud SQL Admin API
+// clients for the given project.
+func configureCloudSQLClients(ctx context.Context, namedCreds string, opts []option.ClientOption, project string) error {
+	client, err := sqladmin.NewService(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("gcp: cannot create gcp cloud sql admin client for %s: %w", namedCreds, err)
 	}
+	gcpclients.SQLAdminClientset.Overwrite(
+		project,
+		&gcpclients.Client[*sqladmin.Service]{
+			NamedCredentials: namedCreds,
+			ProjectID:        project,
+			Client:           client,
+		},
+	)
+
+	slog.Info(
+		"configured GCP client",
+		"service", "cloud_sql",
+		"credentials", namedCreds,
+		"project", project,
+	)
 
 	return nil
 }
@@ -436,24 +636,29 @@ func configureGCPClients(ctx context.Context, conf *config.Config) error {
 		return err
 	}
 
-	configFuncs := map[string]func(ctx context.Context, conf *config.Config) error{
-		"resource_manager": configureGCPResourceManagerClientsets,
-		"compute":          configureGCPComputeClientsets,
-		"storage":          configureGCPStorageClientsets,
-		"gke":              configureGKEClientsets,
+	gcpclients.ProjectSelection = utils.Selection{
+		Include: conf.GCP.IncludeProjects,
+		Exclude: conf.GCP.ExcludeProjects,
 	}
 
-	for svc, configFunc := range configFuncs {
-		if err := configFunc(ctx, conf); err != nil {
-			return fmt.Errorf("unable to configure GCP clients for %s: %w", svc, err)
+	services := getGCPServices(conf)
+	for _, svc := range services {
+		if err := configureGCPServiceClients(ctx, conf, svc); err != nil {
+			return fmt.Errorf("unable to configure GCP clients for %s: %w", svc.name, err)
 		}
 	}
 
+	configureGCPDiscoveryClients(ctx, conf, services)
+
 	return nil
 }
 
 // closeGCPClients closes the existing GCP client connections
 func closeGCPClients() {
+	_ = gcpclients.DiscoveryClientset.Range(func(_ string, client *gcpclients.DiscoveryClient) error {
+		return client.Client.Close()
+	})
+
 	_ = gcpclients.ProjectsClientset.Range(func(_ string, client *gcpclients.Client[*resourcemanager.ProjectsClient]) error {
 		return client.Client.Close()
 	})
@@ -497,4 +702,12 @@ func closeGCPClients() {
 	_ = gcpclients.TargetPoolsClientset.Range(func(_ string, client *gcpclients.Client[*compute.TargetPoolsClient]) error {
 		return client.Client.Close()
 	})
+
+	_ = gcpclients.FirewallsClientset.Range(func(_ string, client *gcpclients.Client[*compute.FirewallsClient]) error {
+		return client.Client.Close()
+	})
+
+	_ = gcpclients.SnapshotsClientset.Range(func(_ string, client *gcpclients.Client[*compute.SnapshotsClient]) error {
+		return client.Client.Close()
+	})
 }
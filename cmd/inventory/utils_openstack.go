@@ -12,6 +12,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -22,6 +23,9 @@ import (
 	vaultclients "github.com/gardener/inventory/pkg/clients/vault"
 	"github.com/gardener/inventory/pkg/core/config"
 	"github.com/gardener/inventory/pkg/core/registry"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
+	"github.com/gardener/inventory/pkg/utils"
+	"github.com/gardener/inventory/pkg/utils/ratelimit"
 )
 
 var errNoUsername = errors.New("no username specified")
@@ -32,6 +36,19 @@ var errNoAuthEndpoint = errors.New("no authentication endpoint specified")
 var errNoDomain = errors.New("no domain specified")
 var errNoRegion = errors.New("no region specified")
 var errNoProject = errors.New("no project specified")
+var errDomainScopedAppCredentials = errors.New("app credentials cannot be domain-scoped")
+
+// errNoIdentityProvider is an error, which is returned when federated
+// authentication is used without an identity provider.
+var errNoIdentityProvider = errors.New("no identity provider specified")
+
+// errNoFederationProtocol is an error, which is returned when federated
+// authentication is used without a federation protocol.
+var errNoFederationProtocol = errors.New("no federation protocol specified")
+
+// errNoTokenFile is an error, which is returned when federated authentication
+// is used without an OIDC token file.
+var errNoTokenFile = errors.New("no token file specified")
 
 // openstackVaultSecret provides OpenStack credentials, which were read from a
 // Vault secret.
@@ -58,12 +75,14 @@ type openstackVaultSecret struct {
 func validateOpenStackConfig(conf *config.Config) error {
 	// Make sure that the services have named credentials configured.
 	services := map[string]config.OpenStackServiceCredentials{
-		"compute":        conf.OpenStack.Services.Compute,
-		"network":        conf.OpenStack.Services.Network,
-		"object_storage": conf.OpenStack.Services.ObjectStorage,
-		"load_balancer":  conf.OpenStack.Services.LoadBalancer,
-		"identity":       conf.OpenStack.Services.Identity,
-		"block_storage":  conf.OpenStack.Services.BlockStorage,
+		"compute":            conf.OpenStack.Services.Compute,
+		"network":            conf.OpenStack.Services.Network,
+		"object_storage":     conf.OpenStack.Services.ObjectStorage,
+		"load_balancer":      conf.OpenStack.Services.LoadBalancer,
+		"identity":           conf.OpenStack.Services.Identity,
+		"block_storage":      conf.OpenStack.Services.BlockStorage,
+		"shared_file_system": conf.OpenStack.Services.SharedFileSystem,
+		"image":              conf.OpenStack.Services.Image,
 	}
 
 	for name, creds := range conf.OpenStack.Credentials {
@@ -79,10 +98,14 @@ func validateOpenStackConfig(conf *config.Config) error {
 			return fmt.Errorf("OpenStack: %w: credentials %s", errNoRegion, name)
 		}
 
-		if creds.Project == "" {
+		if creds.Project == "" && !creds.DomainScoped {
 			return fmt.Errorf("OpenStack: %w: credentials %s", errNoProject, name)
 		}
 
+		if creds.DomainScoped && creds.Authentication == config.OpenStackAuthenticationMethodAppCredentials {
+			return fmt.Errorf("OpenStack: %w: credentials %s", errDomainScopedAppCredentials, name)
+		}
+
 		if creds.Authentication == "" {
 			return fmt.Errorf("OpenStack: %w: credentials %s", errNoAuthenticationMethod, name)
 		}
@@ -117,6 +140,16 @@ func validateOpenStackConfig(conf *config.Config) error {
 			if creds.VaultSecret.SecretPath == "" {
 				return fmt.Errorf("openstack: no vault secret path specified for %s", name)
 			}
+		case config.OpenStackAuthenticationMethodFederated:
+			if creds.Federated.IdentityProvider == "" {
+				return fmt.Errorf("openstack: %w: %s", errNoIdentityProvider, name)
+			}
+			if creds.Federated.Protocol == "" {
+				return fmt.Errorf("openstack: %w: %s", errNoFederationProtocol, name)
+			}
+			if creds.Federated.TokenFile == "" {
+				return fmt.Errorf("openstack: %w: %s", errNoTokenFile, name)
+			}
 		default:
 			return fmt.Errorf("openstack: %w: %s uses %s", errUnknownAuthenticationMethod, name, creds.Authentication)
 		}
@@ -164,19 +197,142 @@ func configureOpenStackClients(ctx context.Context, conf *config.Config) error {
 		return fmt.Errorf("invalid OpenStack configuration: %w", err)
 	}
 
-	configFuncs := map[string]func(ctx context.Context, conf *config.Config) error{
-		"compute":        configureOpenStackComputeClientsets,
-		"network":        configureOpenStackNetworkClientsets,
-		"object_storage": configureOpenStackObjectStorageClientsets,
-		"load_balancer":  configureOpenStackLoadBalancerClientsets,
-		"identity":       configureOpenStackIdentityClientsets,
-		"block_storage":  configureOpenStackBlockStorageClientsets,
+	openstackclients.ProjectSelection = utils.Selection{
+		Include: conf.OpenStack.IncludeProjects,
+		Exclude: conf.OpenStack.ExcludeProjects,
+	}
+
+	services := openStackServices(conf)
+	if err := configureOpenStackDomainClients(ctx, conf, services); err != nil {
+		return fmt.Errorf("unable to configure OpenStack domain-scoped clients: %w", err)
 	}
 
-	for svc, configFunc := range configFuncs {
-		if err := configFunc(ctx, conf); err != nil {
-			return fmt.Errorf("unable to configure OpenStack clients for %s: %w", svc, err)
+	for _, svc := range services {
+		if err := configureOpenStackServiceClientset(ctx, svc.Name, svc.Clientset, *svc.Credentials, conf, svc.NewClient); err != nil {
+			return fmt.Errorf("unable to configure OpenStack clients for %s: %w", svc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// openStackService specifies an OpenStack service, along with the named
+// credentials used by the service.
+type openStackService struct {
+	openstackclients.Service
+
+	// Credentials specifies the named credentials used by the service.
+	Credentials *config.OpenStackServiceCredentials
+}
+
+// openStackServices returns the OpenStack services, for which clients are
+// configured.
+func openStackServices(conf *config.Config) []openStackService {
+	services := []openStackService{
+		{
+			Service:     openstackclients.Service{Name: "compute", Clientset: openstackclients.ComputeClientset, NewClient: openstack.NewComputeV2},
+			Credentials: &conf.OpenStack.Services.Compute,
+		},
+		{
+			Service:     openstackclients.Service{Name: "network", Clientset: openstackclients.NetworkClientset, NewClient: openstack.NewNetworkV2},
+			Credentials: &conf.OpenStack.Services.Network,
+		},
+		{
+			Service:     openstackclients.Service{Name: "object_storage", Clientset: openstackclients.ObjectStorageClientset, NewClient: openstack.NewObjectStorageV1},
+			Credentials: &conf.OpenStack.Services.ObjectStorage,
+		},
+		{
+			Service:     openstackclients.Service{Name: "load_balancer", Clientset: openstackclients.LoadBalancerClientset, NewClient: openstack.NewLoadBalancerV2},
+			Credentials: &conf.OpenStack.Services.LoadBalancer,
+		},
+		{
+			Service:     openstackclients.Service{Name: "identity", Clientset: openstackclients.IdentityClientset, NewClient: openstack.NewIdentityV3},
+			Credentials: &conf.OpenStack.Services.Identity,
+		},
+		{
+			Service:     openstackclients.Service{Name: "block_storage", Clientset: openstackclients.BlockStorageClientset, NewClient: openstack.NewBlockStorageV3},
+			Credentials: &conf.OpenStack.Services.BlockStorage,
+		},
+		{
+			Service:     openstackclients.Service{Name: "shared_file_system", Clientset: openstackclients.SharedFileSystemClientset, NewClient: newOpenStackSharedFileSystemClient},
+			Credentials: &conf.OpenStack.Services.SharedFileSystem,
+		},
+		{
+			Service:     openstackclients.Service{Name: "image", Clientset: openstackclients.ImageClientset, NewClient: openstack.NewImageV2},
+			Credentials: &conf.OpenStack.Services.Image,
+		},
+	}
+
+	return services
+}
+
+// configureOpenStackDomainClients registers a domain-scoped client for each
+// domain-scoped named credentials, and discovers the projects of the domains,
+// so that project-scoped clients are available as soon as the worker starts.
+// The projects are discovered again when enqueueing collection tasks, and when
+// a client for a discovered project is not found. See
+// [openstackutils.RefreshDomainProjects] for more details.
+//
+// The domain-scoped named credentials are removed from the named credentials
+// of the services, since they cannot be used to create project-scoped service
+// clients directly.
+func configureOpenStackDomainClients(ctx context.Context, conf *config.Config, services []openStackService) error {
+	for name, creds := range conf.OpenStack.Credentials {
+		if !creds.DomainScoped {
+			continue
+		}
+
+		domainServices := make([]openstackclients.Service, 0)
+		for _, svc := range services {
+			if !slices.Contains(svc.Credentials.UseCredentials, name) {
+				continue
+			}
+			domainServices = append(domainServices, svc.Service)
+			svc.Credentials.UseCredentials = slices.DeleteFunc(svc.Credentials.UseCredentials, func(item string) bool {
+				return item == name
+			})
+		}
+
+		if len(domainServices) == 0 {
+			continue
+		}
+
+		providerClient, err := newOpenStackProviderClient(ctx, &creds)
+		if err != nil {
+			return fmt.Errorf("unable to create domain-scoped client with credentials %s: %w", name, err)
+		}
+
+		if limiter := getRateLimiter("openstack", name, conf.OpenStack.RateLimit); limiter != nil {
+			providerClient.HTTPClient.Transport = &ratelimit.Transport{
+				Base:    providerClient.HTTPClient.Transport,
+				Limiter: limiter,
+			}
+		}
+
+		client := openstackclients.DomainClient{
+			NamedCredentials: name,
+			Domain:           creds.Domain,
+			Region:           creds.Region,
+			ProviderClient:   providerClient,
+			Services:         domainServices,
 		}
+		openstackclients.DomainClientset.Overwrite(name, client)
+
+		slog.Info(
+			"configured OpenStack domain-scoped client",
+			"credentials", name,
+			"region", creds.Region,
+			"domain", creds.Domain,
+			"services", len(domainServices),
+			"auth_endpoint", creds.AuthEndpoint,
+			"auth_method", creds.Authentication,
+		)
+	}
+
+	// Failing to discover the projects is not fatal, since the discovery
+	// is retried later on.
+	if err := openstackutils.RefreshDomainProjects(ctx); err != nil {
+		slog.Warn("failed to discover OpenStack projects", "reason", err)
 	}
 
 	return nil
@@ -189,6 +345,27 @@ func newOpenStackProviderClient(
 	var authOpts gophercloud.AuthOptions
 
 	switch creds.Authentication {
+	case config.OpenStackAuthenticationMethodFederated:
+		// Federated authentication exchanges an OIDC token for a
+		// Keystone token, and is handled separately, since it is not
+		// supported by gophercloud.
+		opts := openstackutils.FederatedAuthOptions{
+			IdentityEndpoint: creds.AuthEndpoint,
+			IdentityProvider: creds.Federated.IdentityProvider,
+			Protocol:         creds.Federated.Protocol,
+			TokenFile:        creds.Federated.TokenFile,
+			Scope: gophercloud.AuthScope{
+				ProjectName: creds.Project,
+				DomainName:  creds.Domain,
+			},
+		}
+		if creds.DomainScoped {
+			opts.Scope = gophercloud.AuthScope{
+				DomainName: creds.Domain,
+			}
+		}
+
+		return openstackutils.NewFederatedProviderClient(ctx, opts)
 	case config.OpenStackAuthenticationMethodPassword:
 		// Username/password authentication method
 		username := strings.TrimSpace(creds.Password.Username)
@@ -297,6 +474,18 @@ func newOpenStackProviderClient(
 		return nil, fmt.Errorf("unknown authentication method: %s", creds.Authentication)
 	}
 
+	// Domain-scoped credentials request a token scoped to the domain
+	// instead of a project.
+	if creds.DomainScoped {
+		if authOpts.ApplicationCredentialID != "" {
+			return nil, errDomainScopedAppCredentials
+		}
+		authOpts.TenantName = ""
+		authOpts.Scope = &gophercloud.AuthScope{
+			DomainName: creds.Domain,
+		}
+	}
+
 	return gophercloudconfig.NewProviderClient(ctx, authOpts)
 }
 
@@ -306,7 +495,7 @@ func configureOpenStackServiceClientset(
 	clientset *registry.Registry[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]],
 	serviceConfig config.OpenStackServiceCredentials,
 	conf *config.Config,
-	serviceFunc func(providerClient *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error)) error {
+	serviceFunc openstackclients.ServiceClientFunc) error {
 	for _, credentials := range serviceConfig.UseCredentials {
 		namedCreds, ok := conf.OpenStack.Credentials[credentials]
 		if !ok {
@@ -319,6 +508,13 @@ func configureOpenStackServiceClientset(
 			return fmt.Errorf("unable to create client for service with credentials %s: %w", credentials, err)
 		}
 
+		if limiter := getRateLimiter("openstack", credentials, conf.OpenStack.RateLimit); limiter != nil {
+			providerClient.HTTPClient.Transport = &ratelimit.Transport{
+				Base:    providerClient.HTTPClient.Transport,
+				Limiter: limiter,
+			}
+		}
+
 		serviceClient, err := serviceFunc(providerClient, gophercloud.EndpointOpts{
 			Region: namedCreds.Region,
 		})
@@ -327,6 +523,21 @@ func configureOpenStackServiceClientset(
 			return fmt.Errorf("unable to create client for %s service with credentials %s: %w", serviceName, credentials, err)
 		}
 
+		// Optional services, which are not available in the cloud
+		// are skipped.
+		if serviceClient == nil {
+			slog.Warn(
+				"OpenStack service not available",
+				"service", serviceName,
+				"credentials", credentials,
+				"region", namedCreds.Region,
+				"domain", namedCreds.Domain,
+				"project", namedCreds.Project,
+			)
+
+			continue
+		}
+
 		clientScope := openstackclients.ClientScope{
 			NamedCredentials: credentials,
 			Project:          namedCreds.Project,
@@ -359,35 +570,23 @@ func configureOpenStackServiceClientset(
 	return nil
 }
 
-// configureOpenStackComputeClientsets configures the OpenStack Compute API clientsets.
-func configureOpenStackComputeClientsets(ctx context.Context, conf *config.Config) error {
-	return configureOpenStackServiceClientset(ctx, "compute", openstackclients.ComputeClientset, conf.OpenStack.Services.Compute, conf, openstack.NewComputeV2)
-}
-
-// configureOpenStackNetworkClientsets configures the OpenStack Network API clientsets.
-func configureOpenStackNetworkClientsets(ctx context.Context, conf *config.Config) error {
-	return configureOpenStackServiceClientset(ctx, "network", openstackclients.NetworkClientset, conf.OpenStack.Services.Network, conf, openstack.NewNetworkV2)
-}
-
-// configureOpenStackObjectStorageClientsets configures the OpenStack Object Storage API clientsets.
-func configureOpenStackObjectStorageClientsets(ctx context.Context, conf *config.Config) error {
-	return configureOpenStackServiceClientset(ctx, "object_storage", openstackclients.ObjectStorageClientset,
-		conf.OpenStack.Services.ObjectStorage, conf, openstack.NewObjectStorageV1)
-}
+// newOpenStackSharedFileSystemClient creates a new Shared File System API
+// client. Since not all clouds provide the Shared File System service, it
+// returns a nil client, if the service is missing from the service catalog.
+func newOpenStackSharedFileSystemClient(providerClient *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error) {
+	client, err := openstack.NewSharedFileSystemV2(providerClient, eo)
+	var notFound *gophercloud.ErrEndpointNotFound
+	if errors.As(err, &notFound) {
+		return nil, nil // nolint: nilnil
+	}
 
-// configureOpenStackLoadBalancerClientsets configures the OpenStack LoadBalancer API clientsets.
-func configureOpenStackLoadBalancerClientsets(ctx context.Context, conf *config.Config) error {
-	return configureOpenStackServiceClientset(ctx, "load_balancer", openstackclients.LoadBalancerClientset,
-		conf.OpenStack.Services.LoadBalancer, conf, openstack.NewLoadBalancerV2)
-}
+	if err != nil {
+		return nil, err
+	}
 
-// configureOpenStackIdentityClientsets configures the OpenStack Identity API clientsets.
-func configureOpenStackIdentityClientsets(ctx context.Context, conf *config.Config) error {
-	return configureOpenStackServiceClientset(ctx, "identity", openstackclients.IdentityClientset, conf.OpenStack.Services.Identity, conf, openstack.NewIdentityV3)
-}
+	// Listing share export locations requires microversion 2.9 or later,
+	// and the preferred flag of export locations requires 2.14.
+	client.Microversion = "2.14"
 
-// configureOpenStackBlockStorageClientsets configures the OpenStack BlockStorage API clientsets.
-func configureOpenStackBlockStorageClientsets(ctx context.Context, conf *config.Config) error {
-	return configureOpenStackServiceClientset(ctx, "block_storage", openstackclients.BlockStorageClientset,
-		conf.OpenStack.Services.BlockStorage, conf, openstack.NewBlockStorageV3)
+	return client, nil
 }
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+// validateConfigCommandName is the name of the command, which validates the
+// configuration.
+const validateConfigCommandName = "validate-config"
+
+// Results of the configuration checks.
+const (
+	checkResultPass = "PASS"
+	checkResultFail = "FAIL"
+	checkResultSkip = "SKIP"
+)
+
+// configCheck represents a check of the configuration.
+type configCheck struct {
+	// Name is the name of the check.
+	Name string
+
+	// IsEnabled specifies whether the checked settings are in use. Checks
+	// of settings, which are not in use, are skipped.
+	IsEnabled bool
+
+	// Validate validates the configuration.
+	Validate func(conf *config.Config) error
+}
+
+// NewValidateConfigCommand returns a new command, which validates the
+// configuration without starting any of the services.
+func NewValidateConfigCommand() *cli.Command {
+	cmd := &cli.Command{
+		Name:   validateConfigCommandName,
+		Usage:  "validate the configuration",
+		Action: execValidateConfigCmd,
+	}
+
+	return cmd
+}
+
+// execValidateConfigCmd executes the command for validating the configuration.
+func execValidateConfigCmd(ctx *cli.Context) error {
+	conf := getConfig(ctx)
+	checks := []configCheck{
+		{
+			Name:      "database",
+			IsEnabled: true,
+			Validate: func(conf *config.Config) error {
+				return dbutils.ValidateDSN(conf.Database.DSN)
+			},
+		},
+		{
+			Name:      "redis",
+			IsEnabled: true,
+			Validate: func(conf *config.Config) error {
+				return asynqutils.ValidateRedisConfig(conf.Redis)
+			},
+		},
+		{
+			Name:      "scheduler",
+			IsEnabled: true,
+			Validate:  validateSchedulerConfig,
+		},
+		{
+			Name:      "worker",
+			IsEnabled: len(conf.Worker.Providers) > 0,
+			Validate: func(conf *config.Config) error {
+				if err := asynqutils.ValidateProviders(conf.Worker.Providers); err != nil {
+					return err
+				}
+
+				return validateWorkerQueues(conf)
+			},
+		},
+		{
+			Name:      "dashboard",
+			IsEnabled: true,
+			Validate:  validateDashboardConfig,
+		},
+		{
+			Name:      "aws",
+			IsEnabled: conf.AWS.IsEnabled,
+			Validate:  validateAWSConfig,
+		},
+		{
+			Name:      "gcp",
+			IsEnabled: conf.GCP.IsEnabled,
+			Validate:  validateGCPConfig,
+		},
+		{
+			Name:      "azure",
+			IsEnabled: conf.Azure.IsEnabled,
+			Validate:  validateAzureConfig,
+		},
+		{
+			Name:      "openstack",
+			IsEnabled: conf.OpenStack.IsEnabled,
+			Validate:  validateOpenStackConfig,
+		},
+		{
+			Name:      "gardener",
+			IsEnabled: conf.Gardener.IsEnabled,
+			Validate:  validateGardenerConfig,
+		},
+	}
+
+	headers := []string{
+		"CHECK",
+		"RESULT",
+		"DETAILS",
+	}
+	table := newTableWriter(os.Stdout, headers)
+
+	failed := 0
+	for _, check := range checks {
+		result := checkResultPass
+		details := ""
+		switch {
+		case !check.IsEnabled:
+			result = checkResultSkip
+			details = "not enabled"
+		default:
+			if err := check.Validate(conf); err != nil {
+				result = checkResultFail
+				details = err.Error()
+				failed++
+			}
+		}
+
+		if err := table.Append([]string{check.Name, result, details}); err != nil {
+			return err
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return cli.Exit(fmt.Sprintf("configuration is invalid: %d check(s) failed", failed), 1)
+	}
+
+	return nil
+}
@@ -23,6 +23,10 @@ import (
 	dbclient "github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/core/config"
 	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/tracing"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // NewWorkerCommand returns a new command for interfacing with the workers.
@@ -147,8 +151,28 @@ func NewWorkerCommand() *cli.Command {
 				Name:    "start",
 				Usage:   "start worker",
 				Aliases: []string{"s"},
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "providers",
+						Usage:   "register task handlers for the given providers only",
+						EnvVars: []string{"INVENTORY_WORKER_PROVIDERS"},
+					},
+				},
 				Action: func(ctx *cli.Context) error {
 					conf := getConfig(ctx)
+
+					// The flag takes precedence over the
+					// providers from the config file
+					if providers := ctx.StringSlice("providers"); len(providers) > 0 {
+						conf.Worker.Providers = providers
+					}
+					if err := asynqutils.ValidateProviders(conf.Worker.Providers); err != nil {
+						return err
+					}
+					if err := validateWorkerQueues(conf); err != nil {
+						return fmt.Errorf("invalid worker queues: %w", err)
+					}
+
 					db, err := newDB(conf)
 					if err != nil {
 						return err
@@ -158,15 +182,47 @@ func NewWorkerCommand() *cli.Command {
 					defer client.Close() // nolint: errcheck
 					inspector := newInspector(conf)
 					defer inspector.Close() // nolint: errcheck
+
+					// Fail fast on a misdeclared collection
+					// ordering
+					if err := registry.TaskGraph.Validate(); err != nil {
+						return fmt.Errorf("invalid task graph: %w", err)
+					}
+
+					// Register metrics with the configured
+					// namespace and constant labels
+					metricsConf := conf.Worker.Metrics
+					if err := metrics.Register(metricsConf.Namespace, metricsConf.Labels); err != nil {
+						return fmt.Errorf("cannot register metrics: %w", err)
+					}
+
+					// Route tasks to the configured queues,
+					// and process the routed queues as well
+					asynqutils.ConfigureQueueRouting(conf.QueueRouting)
+					conf.Worker.Queues = asynqutils.WithRoutedQueues(conf.Worker.Queues, conf.QueueRouting)
+
+					// Spans are exported only if a tracing
+					// endpoint is configured
+					shutdownTracing, err := tracing.Setup(ctx.Context, conf.Tracing)
+					if err != nil {
+						return fmt.Errorf("cannot configure tracing: %w", err)
+					}
+					defer shutdownTracing(context.Background()) // nolint: errcheck
+
 					worker := newWorker(ctx.Context, conf)
 
 					// Gardener client configs
-					if err := configureGardenerClient(ctx.Context, conf); err != nil {
-						return err
+					if asynqutils.IsProviderSelected("gardener", conf.Worker.Providers) {
+						if err := configureGardenerClient(ctx.Context, conf); err != nil {
+							return err
+						}
 					}
 
 					// Initialize DB and asynq client
 					slog.Info("configuring db client")
+					if conf.Worker.Results.IsEnabled {
+						db.AddQueryHook(asynqutils.NewResultQueryHook())
+					}
 					dbclient.SetDB(db)
 
 					slog.Info("configuring asynq client")
@@ -179,33 +235,67 @@ func NewWorkerCommand() *cli.Command {
 					// Vault clients are configured first in
 					// order to enable other datasources to
 					// be initialized from Vault secrets.
-					configureClientFuncs := []func(context.Context, *config.Config) error{
-						configureVaultClients,
-						configureAWSClients,
-						configureGCPClients,
-						configureAzureClients,
-						configureOpenStackClients,
+					if err := configureVaultClients(ctx.Context, conf); err != nil {
+						return err
+					}
+
+					// Clients are configured only for the
+					// providers handled by the worker
+					configureClientFuncs := map[string]func(context.Context, *config.Config) error{
+						"aws":       configureAWSClients,
+						"gcp":       configureGCPClients,
+						"azure":     configureAzureClients,
+						"openstack": configureOpenStackClients,
 					}
 
-					for _, configureClientsFunc := range configureClientFuncs {
-						if err := configureClientsFunc(ctx.Context, conf); err != nil {
+					for _, provider := range slices.Sorted(maps.Keys(configureClientFuncs)) {
+						if !asynqutils.IsProviderSelected(provider, conf.Worker.Providers) {
+							slog.Info("skipping clients of provider", "provider", provider)
+							continue
+						}
+						if err := configureClientFuncs[provider](ctx.Context, conf); err != nil {
 							return err
 						}
 					}
 
 					defer closeGCPClients()
 
+					// Configure alerts for unexpected zero-row
+					// collections, which rely on the persisted
+					// collection runs.
+					if len(conf.Worker.ZeroRowAlerts) > 0 && !conf.Worker.Results.IsEnabled {
+						slog.Warn("zero-row alerts require persisting of task results, which is disabled")
+					}
+					asynqutils.ConfigureZeroRowAlerts(conf.Worker.ZeroRowAlerts, dbRowCountStore{})
+
+					// Configure consistent-hashing of fan-out tasks
+					asynqutils.ConfigureSharding(conf.Worker.Sharding)
+
+					// Configure incremental link runs
+					dbutils.ConfigureIncrementalLinks(conf.Worker.IncrementalLinks)
+
+					// Configure parent collectors, which collect
+					// their children inline
+					asynqutils.ConfigureInlineChildren(conf.Worker.InlineChildren)
+
 					// Register our task handlers using the default registry
 					worker.HandlersFromRegistry(registry.TaskRegistry)
 					_ = registry.TaskRegistry.Range(func(name string, _ asynq.Handler) error {
+						if !asynqutils.IsTaskSelected(name, conf.Worker.Providers) {
+							return nil
+						}
 						slog.Info("registered task", "name", name)
 
 						return nil
 					})
 
 					slog.Info("worker concurrency", "level", conf.Worker.Concurrency)
+					if len(conf.Worker.Providers) > 0 {
+						slog.Info("worker providers", "providers", conf.Worker.Providers)
+					}
 					slog.Info("queue priority", "strict", conf.Worker.StrictPriority)
-					for queue, priority := range conf.Worker.Queues {
+					slog.Info("queue sharding", "enabled", conf.Worker.Sharding.IsEnabled, "shards", conf.Worker.Sharding.Shards, "owned", conf.Worker.Sharding.OwnedShards)
+					for queue, priority := range asynqutils.FilterProviderQueues(conf.Worker.Queues, conf.Worker.Providers) {
 						slog.Info("queue configuration", "name", queue, "priority", priority)
 					}
 
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dbtest provides helpers for tests, which run against a PostgreSQL
+// database.
+package dbtest
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/migrate"
+
+	"github.com/gardener/inventory/internal/pkg/migrations"
+)
+
+// EnvDSN is the name of the environment variable, which specifies the DSN of
+// the PostgreSQL database used by the tests.
+const EnvDSN = "INVENTORY_TEST_DSN"
+
+// New returns a [bun.DB] connected to the test database specified by the
+// [EnvDSN] environment variable. The tests are skipped, if the environment
+// variable is not set.
+//
+// Each call creates a separate schema, in which all migrations are applied,
+// so that tests are isolated from each other. The schema is dropped when the
+// test completes.
+func New(t testing.TB) *bun.DB {
+	t.Helper()
+
+	dsn := os.Getenv(EnvDSN)
+	if dsn == "" {
+		t.Skipf("%s is not set, skipping database test", EnvDSN)
+	}
+
+	ctx := context.Background()
+	admin := bun.NewDB(sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn))), pgdialect.New())
+	t.Cleanup(func() { _ = admin.Close() })
+
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatalf("failed to generate schema name: %s", err)
+	}
+	schema := "inventory_test_" + hex.EncodeToString(suffix)
+
+	if _, err := admin.ExecContext(ctx, "CREATE SCHEMA ?", bun.Ident(schema)); err != nil {
+		t.Fatalf("failed to create schema %s: %s", schema, err)
+	}
+	t.Cleanup(func() {
+		_, _ = admin.ExecContext(ctx, "DROP SCHEMA ? CASCADE", bun.Ident(schema))
+	})
+
+	connector := pgdriver.NewConnector(
+		pgdriver.WithDSN(dsn),
+		pgdriver.WithConnParams(map[string]any{
+			"search_path": fmt.Sprintf("%s,public", schema),
+		}),
+	)
+	db := bun.NewDB(sql.OpenDB(connector), pgdialect.New())
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator := migrate.NewMigrator(db, migrations.Migrations, migrate.WithMarkAppliedOnSuccess(true))
+	if err := migrator.Init(ctx); err != nil {
+		t.Fatalf("failed to initialize migrations: %s", err)
+	}
+
+	if _, err := migrator.Migrate(ctx); err != nil {
+		t.Fatalf("failed to apply migrations: %s", err)
+	}
+
+	return db
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "az_managed_disk";
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "az_managed_disk" (
+    "disk_id" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "subscription_id" varchar NOT NULL,
+    "resource_group" varchar NOT NULL,
+    "location" varchar NOT NULL,
+    "size_gb" int NOT NULL,
+    "sku" varchar NOT NULL,
+    "disk_state" varchar NOT NULL,
+    "encryption_type" varchar NOT NULL,
+    "is_encrypted" boolean NOT NULL,
+    "vm_id" varchar NOT NULL,
+    "vm_name" varchar NOT NULL,
+    "vm_resource_group" varchar NOT NULL,
+    "disk_created_at" timestamptz,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "az_managed_disk_key" UNIQUE ("disk_id")
+);
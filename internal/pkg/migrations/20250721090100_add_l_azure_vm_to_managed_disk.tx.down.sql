@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "l_az_vm_to_managed_disk";
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "l_az_vm_to_managed_disk" (
+    "vm_id" UUID NOT NULL,
+    "disk_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_az_vm_to_managed_disk_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_az_vm_to_managed_disk_vm_id_fkey" FOREIGN KEY ("vm_id") REFERENCES az_vm ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_az_vm_to_managed_disk_disk_id_fkey" FOREIGN KEY ("disk_id") REFERENCES az_managed_disk ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_az_vm_to_managed_disk_key" UNIQUE ("vm_id", "disk_id")
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "aux_tag_violation";
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "aux_tag_violation" (
+    "model_name" varchar NOT NULL,
+    "resource_id" uuid NOT NULL,
+    "scope" varchar NOT NULL,
+    "missing_keys" varchar[] NOT NULL,
+
+    "id" uuid NOT NULL DEFAULT gen_random_uuid (),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aux_tag_violation_key" UNIQUE ("model_name", "resource_id")
+);
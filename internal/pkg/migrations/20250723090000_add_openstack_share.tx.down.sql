@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "openstack_share_export_location";
+DROP TABLE IF EXISTS "openstack_share";
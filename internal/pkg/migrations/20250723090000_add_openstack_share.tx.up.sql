@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS "openstack_share" (
+    "share_id" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "domain" varchar NOT NULL,
+    "region" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "size" int NOT NULL,
+    "share_proto" varchar NOT NULL,
+    "share_type" varchar NOT NULL,
+    "share_type_name" varchar NOT NULL,
+    "share_network_id" varchar NOT NULL,
+    "availability_zone" varchar NOT NULL,
+    "is_public" boolean NOT NULL,
+    "description" varchar NOT NULL,
+    "share_created_at" timestamptz NOT NULL,
+    "share_updated_at" timestamptz,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "openstack_share_key" UNIQUE ("share_id", "project_id")
+);
+
+CREATE TABLE IF NOT EXISTS "openstack_share_export_location" (
+    "export_location_id" varchar NOT NULL,
+    "share_id" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "path" varchar NOT NULL,
+    "share_instance_id" varchar NOT NULL,
+    "is_admin_only" boolean NOT NULL,
+    "preferred" boolean NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "openstack_share_export_location_key" UNIQUE ("export_location_id", "share_id", "project_id")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_openstack_share_to_share_network";
+DROP TABLE IF EXISTS "openstack_share_network";
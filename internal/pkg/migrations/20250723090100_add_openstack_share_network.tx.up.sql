@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS "openstack_share_network" (
+    "share_network_id" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "domain" varchar NOT NULL,
+    "region" varchar NOT NULL,
+    "neutron_net_id" varchar NOT NULL,
+    "neutron_subnet_id" varchar NOT NULL,
+    "network_type" varchar NOT NULL,
+    "cidr" varchar NOT NULL,
+    "ip_version" int NOT NULL,
+    "description" varchar NOT NULL,
+    "share_network_created_at" timestamptz NOT NULL,
+    "share_network_updated_at" timestamptz,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "openstack_share_network_key" UNIQUE ("share_network_id", "project_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_openstack_share_to_share_network" (
+    "share_id" UUID NOT NULL,
+    "share_network_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_openstack_share_to_share_network_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_openstack_share_to_share_network_share_id_fkey" FOREIGN KEY ("share_id") REFERENCES openstack_share ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_share_to_share_network_share_network_id_fkey" FOREIGN KEY ("share_network_id") REFERENCES openstack_share_network ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_share_to_share_network_key" UNIQUE ("share_id", "share_network_id")
+);
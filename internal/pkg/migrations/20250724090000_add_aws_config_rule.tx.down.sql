@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "aws_compliance_result";
+DROP TABLE IF EXISTS "aws_config_rule";
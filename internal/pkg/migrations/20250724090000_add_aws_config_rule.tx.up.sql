@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS "aws_config_rule" (
+    "name" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+    "rule_id" varchar NOT NULL,
+    "arn" varchar NOT NULL,
+    "description" varchar NOT NULL,
+    "owner" varchar NOT NULL,
+    "source_identifier" varchar NOT NULL,
+    "state" varchar NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_config_rule_key" UNIQUE ("name", "account_id", "region_name")
+);
+
+CREATE TABLE IF NOT EXISTS "aws_compliance_result" (
+    "account_id" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+    "rule_name" varchar NOT NULL,
+    "resource_type" varchar NOT NULL,
+    "resource_id" varchar NOT NULL,
+    "compliance_type" varchar NOT NULL,
+    "annotation" varchar NOT NULL,
+    "recorded_at" timestamptz,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_compliance_result_key" UNIQUE ("account_id", "region_name", "rule_name", "resource_type", "resource_id")
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "l_aws_instance_to_compliance_result";
+DROP TABLE IF EXISTS "l_aws_vpc_to_compliance_result";
+DROP TABLE IF EXISTS "l_aws_subnet_to_compliance_result";
+DROP TABLE IF EXISTS "l_aws_bucket_to_compliance_result";
@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS "l_aws_instance_to_compliance_result" (
+    "instance_id" UUID NOT NULL,
+    "compliance_result_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_aws_instance_to_compliance_result_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_instance_to_compliance_result_instance_id_fkey" FOREIGN KEY ("instance_id") REFERENCES aws_instance ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_instance_to_compliance_result_compliance_result_id_fkey" FOREIGN KEY ("compliance_result_id") REFERENCES aws_compliance_result ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_instance_to_compliance_result_key" UNIQUE ("instance_id", "compliance_result_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_vpc_to_compliance_result" (
+    "vpc_id" UUID NOT NULL,
+    "compliance_result_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_aws_vpc_to_compliance_result_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_vpc_to_compliance_result_vpc_id_fkey" FOREIGN KEY ("vpc_id") REFERENCES aws_vpc ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_vpc_to_compliance_result_compliance_result_id_fkey" FOREIGN KEY ("compliance_result_id") REFERENCES aws_compliance_result ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_vpc_to_compliance_result_key" UNIQUE ("vpc_id", "compliance_result_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_subnet_to_compliance_result" (
+    "subnet_id" UUID NOT NULL,
+    "compliance_result_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_aws_subnet_to_compliance_result_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_subnet_to_compliance_result_subnet_id_fkey" FOREIGN KEY ("subnet_id") REFERENCES aws_subnet ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_subnet_to_compliance_result_compliance_result_id_fkey" FOREIGN KEY ("compliance_result_id") REFERENCES aws_compliance_result ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_subnet_to_compliance_result_key" UNIQUE ("subnet_id", "compliance_result_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_bucket_to_compliance_result" (
+    "bucket_id" UUID NOT NULL,
+    "compliance_result_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_aws_bucket_to_compliance_result_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_bucket_to_compliance_result_bucket_id_fkey" FOREIGN KEY ("bucket_id") REFERENCES aws_bucket ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_bucket_to_compliance_result_compliance_result_id_fkey" FOREIGN KEY ("compliance_result_id") REFERENCES aws_compliance_result ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_bucket_to_compliance_result_key" UNIQUE ("bucket_id", "compliance_result_id")
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "aux_duplicate_resource" (
+    "model_name" varchar NOT NULL,
+    "resource_id" varchar NOT NULL,
+    "scopes" varchar[] NOT NULL,
+    "last_seen" timestamptz NOT NULL,
+
+    "id" uuid NOT NULL DEFAULT gen_random_uuid (),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aux_duplicate_resource_key" UNIQUE ("model_name", "resource_id")
+);
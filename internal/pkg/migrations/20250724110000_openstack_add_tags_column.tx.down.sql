@@ -0,0 +1,4 @@
+ALTER TABLE openstack_floating_ip DROP COLUMN tags;
+ALTER TABLE openstack_network DROP COLUMN tags;
+ALTER TABLE openstack_subnet DROP COLUMN tags;
+ALTER TABLE openstack_port DROP COLUMN tags;
@@ -0,0 +1,4 @@
+ALTER TABLE openstack_floating_ip ADD COLUMN tags JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE openstack_network ADD COLUMN tags JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE openstack_subnet ADD COLUMN tags JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE openstack_port ADD COLUMN tags JSONB NOT NULL DEFAULT '{}';
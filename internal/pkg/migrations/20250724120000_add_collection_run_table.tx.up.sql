@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "aux_collection_run" (
+    "task_id" varchar NOT NULL,
+    "task_name" varchar NOT NULL,
+    "queue" varchar NOT NULL,
+    "started_at" timestamptz NOT NULL,
+    "completed_at" timestamptz NOT NULL,
+    "duration" bigint NOT NULL,
+    "rows" bigint NOT NULL,
+    "pages" bigint NOT NULL,
+    "skipped" bigint NOT NULL,
+    "error" varchar NOT NULL,
+
+    "id" uuid NOT NULL DEFAULT gen_random_uuid (),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "aux_collection_run_task_id_idx" ON "aux_collection_run" ("task_id");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "gcp_iam_binding";
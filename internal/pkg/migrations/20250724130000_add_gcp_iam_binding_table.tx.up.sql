@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "gcp_iam_binding" (
+    "project_id" varchar NOT NULL,
+    "role" varchar NOT NULL,
+    "member" varchar NOT NULL,
+    "is_public" boolean NOT NULL,
+
+    "id" uuid NOT NULL DEFAULT gen_random_uuid (),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "gcp_iam_binding_key" UNIQUE ("project_id", "role", "member")
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "l_aws_sns_subscription_to_topic";
+DROP TABLE IF EXISTS "aws_sns_subscription";
+DROP TABLE IF EXISTS "aws_sns_topic";
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS "aws_sns_topic" (
+    "topic_arn" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "display_name" varchar NOT NULL,
+    "owner" varchar NOT NULL,
+    "kms_key_id" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_sns_topic_topic_arn_key" UNIQUE ("topic_arn")
+);
+
+CREATE TABLE IF NOT EXISTS "aws_sns_subscription" (
+    "subscription_arn" varchar NOT NULL,
+    "topic_arn" varchar NOT NULL,
+    "protocol" varchar NOT NULL,
+    "endpoint" varchar NOT NULL,
+    "owner" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+    "is_public" boolean NOT NULL,
+    "is_cross_account" boolean NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_sns_subscription_subscription_arn_key" UNIQUE ("subscription_arn")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_sns_subscription_to_topic" (
+    "subscription_id" UUID NOT NULL,
+    "topic_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_aws_sns_subscription_to_topic_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_sns_subscription_to_topic_subscription_id_fkey" FOREIGN KEY ("subscription_id") REFERENCES aws_sns_subscription ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_sns_subscription_to_topic_topic_id_fkey" FOREIGN KEY ("topic_id") REFERENCES aws_sns_topic ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_sns_subscription_to_topic_key" UNIQUE ("subscription_id", "topic_id")
+);
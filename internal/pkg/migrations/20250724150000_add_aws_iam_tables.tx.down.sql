@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "l_aws_iam_user_to_policy";
+DROP TABLE IF EXISTS "aws_iam_policy";
+DROP TABLE IF EXISTS "aws_iam_access_key";
+DROP TABLE IF EXISTS "aws_iam_user";
@@ -0,0 +1,66 @@
+CREATE TABLE IF NOT EXISTS "aws_iam_user" (
+    "user_name" varchar NOT NULL,
+    "user_id" varchar NOT NULL,
+    "arn" varchar NOT NULL,
+    "path" varchar NOT NULL,
+    "create_date" timestamptz NOT NULL,
+    "password_last_used" timestamptz,
+    "account_id" varchar NOT NULL,
+    "has_console_access" boolean NOT NULL,
+    "has_mfa" boolean NOT NULL,
+    "console_without_mfa" boolean NOT NULL,
+    "policy_arns" varchar[] NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_iam_user_arn_key" UNIQUE ("arn")
+);
+
+CREATE TABLE IF NOT EXISTS "aws_iam_access_key" (
+    "access_key_id" varchar NOT NULL,
+    "user_arn" varchar NOT NULL,
+    "user_name" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "create_date" timestamptz NOT NULL,
+    "last_used_date" timestamptz,
+    "last_used_service" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "is_stale" boolean NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_iam_access_key_access_key_id_key" UNIQUE ("access_key_id")
+);
+
+CREATE TABLE IF NOT EXISTS "aws_iam_policy" (
+    "policy_arn" varchar NOT NULL,
+    "policy_name" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "is_aws_managed" boolean NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_iam_policy_key" UNIQUE ("policy_arn", "account_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_iam_user_to_policy" (
+    "user_id" UUID NOT NULL,
+    "policy_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_aws_iam_user_to_policy_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_iam_user_to_policy_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES aws_iam_user ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_iam_user_to_policy_policy_id_fkey" FOREIGN KEY ("policy_id") REFERENCES aws_iam_policy ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_iam_user_to_policy_key" UNIQUE ("user_id", "policy_id")
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "aux_link_run" (
+    "name" varchar NOT NULL,
+    "last_run_at" timestamptz NOT NULL,
+    "last_full_run_at" timestamptz NOT NULL,
+
+    "id" uuid NOT NULL DEFAULT gen_random_uuid (),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aux_link_run_key" UNIQUE ("name")
+);
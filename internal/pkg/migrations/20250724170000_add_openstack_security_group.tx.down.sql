@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "openstack_security_group_rule";
+DROP TABLE IF EXISTS "openstack_security_group";
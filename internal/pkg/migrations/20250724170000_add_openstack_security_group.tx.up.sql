@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS "openstack_security_group" (
+    "security_group_id" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "domain" varchar NOT NULL,
+    "region" varchar NOT NULL,
+    "description" varchar NOT NULL,
+    "stateful" boolean NOT NULL,
+    "security_group_created_at" timestamptz,
+    "security_group_updated_at" timestamptz,
+    "tags" jsonb NOT NULL DEFAULT '{}',
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "openstack_security_group_key" UNIQUE ("security_group_id", "project_id")
+);
+
+CREATE TABLE IF NOT EXISTS "openstack_security_group_rule" (
+    "rule_id" varchar NOT NULL,
+    "security_group_id" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "domain" varchar NOT NULL,
+    "region" varchar NOT NULL,
+    "direction" varchar NOT NULL,
+    "ether_type" varchar NOT NULL,
+    "protocol" varchar NOT NULL,
+    "port_range_min" int NOT NULL,
+    "port_range_max" int NOT NULL,
+    "remote_ip_prefix" varchar NOT NULL,
+    "remote_group_id" varchar NOT NULL,
+    "description" varchar NOT NULL,
+    "rule_created_at" timestamptz,
+    "rule_updated_at" timestamptz,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "openstack_security_group_rule_key" UNIQUE ("rule_id", "project_id")
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "openstack_quota_usage_sample";
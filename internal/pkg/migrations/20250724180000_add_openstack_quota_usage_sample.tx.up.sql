@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "openstack_quota_usage_sample" (
+    "project_id" varchar NOT NULL,
+    "domain" varchar NOT NULL,
+    "region" varchar NOT NULL,
+    "resource" varchar NOT NULL,
+    "in_use" int NOT NULL,
+    "reserved" int NOT NULL,
+    "quota_limit" int NOT NULL,
+    "usage_ratio" double precision NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "openstack_quota_usage_sample_project_resource_idx"
+    ON "openstack_quota_usage_sample" ("project_id", "resource", "created_at");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "l_openstack_security_group_rule_to_group";
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "l_openstack_security_group_rule_to_group" (
+    "security_group_id" UUID NOT NULL,
+    "rule_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_openstack_security_group_rule_to_group_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_openstack_security_group_rule_to_group_security_group_id_fkey" FOREIGN KEY ("security_group_id") REFERENCES openstack_security_group ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_security_group_rule_to_group_rule_id_fkey" FOREIGN KEY ("rule_id") REFERENCES openstack_security_group_rule ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_security_group_rule_to_group_key" UNIQUE ("security_group_id", "rule_id")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_openstack_port_to_security_group";
+ALTER TABLE "openstack_port" DROP COLUMN IF EXISTS "security_groups";
@@ -0,0 +1,14 @@
+ALTER TABLE "openstack_port" ADD COLUMN IF NOT EXISTS "security_groups" varchar[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS "l_openstack_port_to_security_group" (
+    "port_id" UUID NOT NULL,
+    "security_group_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_openstack_port_to_security_group_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_openstack_port_to_security_group_port_id_fkey" FOREIGN KEY ("port_id") REFERENCES openstack_port ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_port_to_security_group_security_group_id_fkey" FOREIGN KEY ("security_group_id") REFERENCES openstack_security_group ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_port_to_security_group_key" UNIQUE ("port_id", "security_group_id")
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "l_aws_rds_instance_to_subnet";
+DROP TABLE IF EXISTS "l_aws_rds_instance_to_vpc";
+DROP TABLE IF EXISTS "aws_rds_instance";
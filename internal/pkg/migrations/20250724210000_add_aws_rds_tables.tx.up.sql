@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS "aws_rds_instance" (
+    "db_instance_arn" varchar NOT NULL,
+    "db_instance_identifier" varchar NOT NULL,
+    "resource_id" varchar NOT NULL,
+    "instance_class" varchar NOT NULL,
+    "engine" varchar NOT NULL,
+    "engine_version" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "az" varchar NOT NULL,
+    "multi_az" boolean NOT NULL,
+    "publicly_accessible" boolean NOT NULL,
+    "storage_encrypted" boolean NOT NULL,
+    "allocated_storage" bigint NOT NULL,
+    "endpoint_address" varchar NOT NULL,
+    "endpoint_port" bigint NOT NULL,
+    "db_subnet_group" varchar NOT NULL,
+    "vpc_id" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+    "instance_created_at" timestamptz,
+    "subnet_ids" varchar[] NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_rds_instance_db_instance_arn_key" UNIQUE ("db_instance_arn")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_rds_instance_to_vpc" (
+    "rds_instance_id" UUID NOT NULL,
+    "vpc_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_aws_rds_instance_to_vpc_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_rds_instance_to_vpc_rds_instance_id_fkey" FOREIGN KEY ("rds_instance_id") REFERENCES aws_rds_instance ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_rds_instance_to_vpc_vpc_id_fkey" FOREIGN KEY ("vpc_id") REFERENCES aws_vpc ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_rds_instance_to_vpc_key" UNIQUE ("rds_instance_id", "vpc_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_rds_instance_to_subnet" (
+    "rds_instance_id" UUID NOT NULL,
+    "subnet_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_aws_rds_instance_to_subnet_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_rds_instance_to_subnet_rds_instance_id_fkey" FOREIGN KEY ("rds_instance_id") REFERENCES aws_rds_instance ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_rds_instance_to_subnet_subnet_id_fkey" FOREIGN KEY ("subnet_id") REFERENCES aws_subnet ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_rds_instance_to_subnet_key" UNIQUE ("rds_instance_id", "subnet_id")
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "aux_collection_marker";
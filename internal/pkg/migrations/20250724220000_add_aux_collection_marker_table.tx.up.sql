@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "aux_collection_marker" (
+    "task_name" varchar NOT NULL,
+    "scope" varchar NOT NULL,
+    "collected_at" timestamptz NOT NULL,
+
+    "id" uuid NOT NULL DEFAULT gen_random_uuid (),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aux_collection_marker_key" UNIQUE ("task_name", "scope")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_gcp_cloud_sql_instance_to_project";
+DROP TABLE IF EXISTS "gcp_cloud_sql_instance";
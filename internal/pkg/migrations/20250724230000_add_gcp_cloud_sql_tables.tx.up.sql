@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS "gcp_cloud_sql_instance" (
+    "name" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "database_version" varchar NOT NULL,
+    "tier" varchar NOT NULL,
+    "region" varchar NOT NULL,
+    "zone" varchar NOT NULL,
+    "availability_type" varchar NOT NULL,
+    "state" varchar NOT NULL,
+    "instance_type" varchar NOT NULL,
+    "connection_name" varchar NOT NULL,
+    "creation_timestamp" varchar,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "gcp_cloud_sql_instance_key" UNIQUE ("name", "project_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_gcp_cloud_sql_instance_to_project" (
+    "instance_id" UUID NOT NULL,
+    "project_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_gcp_cloud_sql_instance_to_project_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_gcp_cloud_sql_instance_to_project_instance_id_fkey" FOREIGN KEY ("instance_id") REFERENCES gcp_cloud_sql_instance ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_cloud_sql_instance_to_project_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES gcp_project ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_cloud_sql_instance_to_project_key" UNIQUE ("instance_id", "project_id")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_openstack_floating_ip_to_server";
+ALTER TABLE "openstack_server" DROP COLUMN IF EXISTS "flavor_id";
@@ -0,0 +1,14 @@
+ALTER TABLE "openstack_server" ADD COLUMN IF NOT EXISTS "flavor_id" varchar NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "l_openstack_floating_ip_to_server" (
+    "floating_ip_id" UUID NOT NULL,
+    "server_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_openstack_floating_ip_to_server_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_openstack_floating_ip_to_server_floating_ip_id_fkey" FOREIGN KEY ("floating_ip_id") REFERENCES openstack_floating_ip ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_floating_ip_to_server_server_id_fkey" FOREIGN KEY ("server_id") REFERENCES openstack_server ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_floating_ip_to_server_key" UNIQUE ("floating_ip_id", "server_id")
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "aws_tag" (
+    "account_id" varchar NOT NULL,
+    "resource_type" varchar NOT NULL,
+    "resource_id" varchar NOT NULL,
+    "key" varchar NOT NULL,
+    "value" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_tag_key" UNIQUE ("account_id", "resource_type", "resource_id", "key")
+);
+
+CREATE INDEX IF NOT EXISTS "aws_tag_key_value_idx" ON "aws_tag" ("key", "value");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_gcp_firewall_rule_to_vpc";
+DROP TABLE IF EXISTS "gcp_firewall_rule";
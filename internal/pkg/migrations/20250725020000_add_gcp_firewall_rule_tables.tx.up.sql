@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS "gcp_firewall_rule" (
+    "name" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "rule_id" bigint NOT NULL,
+    "vpc_name" varchar NOT NULL,
+    "direction" varchar NOT NULL,
+    "priority" integer NOT NULL,
+    "disabled" boolean NOT NULL,
+    "allowed" jsonb NOT NULL,
+    "denied" jsonb NOT NULL,
+    "source_ranges" varchar[] NOT NULL,
+    "destination_ranges" varchar[] NOT NULL,
+    "source_tags" varchar[] NOT NULL,
+    "target_tags" varchar[] NOT NULL,
+    "description" varchar NOT NULL,
+    "creation_timestamp" varchar,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "gcp_firewall_rule_key" UNIQUE ("name", "project_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_gcp_firewall_rule_to_vpc" (
+    "firewall_rule_id" UUID NOT NULL,
+    "vpc_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_gcp_firewall_rule_to_vpc_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_gcp_firewall_rule_to_vpc_firewall_rule_id_fkey" FOREIGN KEY ("firewall_rule_id") REFERENCES gcp_firewall_rule ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_firewall_rule_to_vpc_vpc_id_fkey" FOREIGN KEY ("vpc_id") REFERENCES gcp_vpc ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_firewall_rule_to_vpc_key" UNIQUE ("firewall_rule_id", "vpc_id")
+);
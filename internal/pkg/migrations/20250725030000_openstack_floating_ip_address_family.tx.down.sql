@@ -0,0 +1,5 @@
+ALTER TABLE "openstack_floating_ip" DROP COLUMN IF EXISTS "address_family";
+
+-- Unattached floating IPs were not collected before
+DELETE FROM "openstack_floating_ip" WHERE "fixed_ip" IS NULL;
+ALTER TABLE "openstack_floating_ip" ALTER COLUMN "fixed_ip" SET NOT NULL;
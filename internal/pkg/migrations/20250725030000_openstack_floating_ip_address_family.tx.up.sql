@@ -0,0 +1,5 @@
+ALTER TABLE "openstack_floating_ip" ALTER COLUMN "fixed_ip" DROP NOT NULL;
+ALTER TABLE "openstack_floating_ip" ADD COLUMN IF NOT EXISTS "address_family" varchar NOT NULL DEFAULT '';
+
+UPDATE "openstack_floating_ip"
+SET "address_family" = CASE family("floating_ip") WHEN 6 THEN 'v6' ELSE 'v4' END;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "az_vm_vm_id_idx";
+ALTER TABLE "az_vm" DROP COLUMN IF EXISTS "vm_id";
@@ -0,0 +1,2 @@
+ALTER TABLE "az_vm" ADD COLUMN IF NOT EXISTS "vm_id" VARCHAR;
+CREATE INDEX IF NOT EXISTS "az_vm_vm_id_idx" ON "az_vm" ("vm_id", "subscription_id");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "l_openstack_floating_ip_to_router";
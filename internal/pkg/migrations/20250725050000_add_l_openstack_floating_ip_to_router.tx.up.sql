@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "l_openstack_floating_ip_to_router" (
+    "router_id" UUID NOT NULL,
+    "floating_ip_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "l_openstack_floating_ip_to_router_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_openstack_floating_ip_to_router_router_id_fkey" FOREIGN KEY ("router_id") REFERENCES openstack_router ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_floating_ip_to_router_floating_ip_id_fkey" FOREIGN KEY ("floating_ip_id") REFERENCES openstack_floating_ip ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_floating_ip_to_router_key" UNIQUE ("router_id", "floating_ip_id")
+);
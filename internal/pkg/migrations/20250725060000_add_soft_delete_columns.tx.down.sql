@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS "aws_instance_deleted_at_idx";
+DROP INDEX IF EXISTS "gcp_instance_deleted_at_idx";
+
+DO $$
+DECLARE
+    t record;
+BEGIN
+    FOR t IN
+        SELECT DISTINCT c.table_name
+        FROM information_schema.columns AS c
+        INNER JOIN information_schema.tables AS tbl
+              ON tbl.table_schema = c.table_schema
+              AND tbl.table_name = c.table_name
+        WHERE c.table_schema = current_schema()
+              AND c.column_name IN ('last_seen_at', 'deleted_at')
+              AND tbl.table_type = 'BASE TABLE'
+    LOOP
+        EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS "last_seen_at"', t.table_name);
+        EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS "deleted_at"', t.table_name);
+    END LOOP;
+END $$;
@@ -0,0 +1,23 @@
+-- Add the soft-delete columns of the base model to all tables
+DO $$
+DECLARE
+    t record;
+BEGIN
+    FOR t IN
+        SELECT c.table_name
+        FROM information_schema.columns AS c
+        INNER JOIN information_schema.tables AS tbl
+              ON tbl.table_schema = c.table_schema
+              AND tbl.table_name = c.table_name
+        WHERE c.table_schema = current_schema()
+              AND c.column_name = 'updated_at'
+              AND tbl.table_type = 'BASE TABLE'
+              AND c.table_name <> 'bun_migrations'
+    LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS "last_seen_at" timestamptz', t.table_name);
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz', t.table_name);
+    END LOOP;
+END $$;
+
+CREATE INDEX IF NOT EXISTS "aws_instance_deleted_at_idx" ON "aws_instance" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "gcp_instance_deleted_at_idx" ON "gcp_instance" ("deleted_at");
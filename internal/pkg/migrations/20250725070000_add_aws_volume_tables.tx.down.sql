@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_aws_volume_to_instance";
+DROP TABLE IF EXISTS "aws_volume";
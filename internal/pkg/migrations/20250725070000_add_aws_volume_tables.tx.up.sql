@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS "aws_volume" (
+    "volume_id" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+    "az" varchar NOT NULL,
+    "size" bigint NOT NULL,
+    "volume_type" varchar NOT NULL,
+    "iops" bigint NOT NULL,
+    "encrypted" boolean NOT NULL,
+    "state" varchar NOT NULL,
+    "snapshot_id" varchar NOT NULL,
+    "volume_created_at" timestamptz,
+    "instance_ids" varchar[] NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_volume_key" UNIQUE ("volume_id", "account_id", "region_name")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_volume_to_instance" (
+    "volume_id" UUID NOT NULL,
+    "instance_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_aws_volume_to_instance_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_volume_to_instance_volume_id_fkey" FOREIGN KEY ("volume_id") REFERENCES aws_volume ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_volume_to_instance_instance_id_fkey" FOREIGN KEY ("instance_id") REFERENCES aws_instance ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_volume_to_instance_key" UNIQUE ("volume_id", "instance_id")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_gcp_snapshot_to_disk";
+DROP TABLE IF EXISTS "gcp_snapshot";
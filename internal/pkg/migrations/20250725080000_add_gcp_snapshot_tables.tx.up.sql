@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS "gcp_snapshot" (
+    "name" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "snapshot_id" bigint NOT NULL,
+    "source_disk" varchar NOT NULL,
+    "source_disk_project" varchar NOT NULL,
+    "source_disk_zone" varchar NOT NULL,
+    "source_disk_region" varchar NOT NULL,
+    "disk_size_gb" bigint NOT NULL,
+    "storage_bytes" bigint NOT NULL,
+    "status" varchar NOT NULL,
+    "snapshot_type" varchar NOT NULL,
+    "auto_created" boolean NOT NULL,
+    "creation_timestamp" varchar,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "gcp_snapshot_key" UNIQUE ("name", "project_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_gcp_snapshot_to_disk" (
+    "snapshot_id" UUID NOT NULL,
+    "disk_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_gcp_snapshot_to_disk_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_gcp_snapshot_to_disk_snapshot_id_fkey" FOREIGN KEY ("snapshot_id") REFERENCES gcp_snapshot ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_snapshot_to_disk_disk_id_fkey" FOREIGN KEY ("disk_id") REFERENCES gcp_disk ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_snapshot_to_disk_key" UNIQUE ("snapshot_id", "disk_id")
+);
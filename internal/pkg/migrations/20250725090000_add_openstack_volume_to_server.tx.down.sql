@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_openstack_volume_to_server";
+ALTER TABLE "openstack_volume" DROP COLUMN IF EXISTS "server_ids";
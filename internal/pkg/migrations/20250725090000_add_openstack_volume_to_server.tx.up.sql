@@ -0,0 +1,16 @@
+ALTER TABLE "openstack_volume" ADD COLUMN IF NOT EXISTS "server_ids" varchar[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS "l_openstack_volume_to_server" (
+    "volume_id" UUID NOT NULL,
+    "server_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_openstack_volume_to_server_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_openstack_volume_to_server_volume_id_fkey" FOREIGN KEY ("volume_id") REFERENCES openstack_volume ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_volume_to_server_server_id_fkey" FOREIGN KEY ("server_id") REFERENCES openstack_server ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_volume_to_server_key" UNIQUE ("volume_id", "server_id")
+);
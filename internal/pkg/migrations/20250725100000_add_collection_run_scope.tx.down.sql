@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "aux_collection_run_scope_idx";
+
+ALTER TABLE "aux_collection_run" DROP COLUMN IF EXISTS "status";
+ALTER TABLE "aux_collection_run" DROP COLUMN IF EXISTS "region";
+ALTER TABLE "aux_collection_run" DROP COLUMN IF EXISTS "account";
+ALTER TABLE "aux_collection_run" DROP COLUMN IF EXISTS "provider";
@@ -0,0 +1,8 @@
+ALTER TABLE "aux_collection_run" ADD COLUMN IF NOT EXISTS "provider" varchar NOT NULL DEFAULT '';
+ALTER TABLE "aux_collection_run" ADD COLUMN IF NOT EXISTS "account" varchar NOT NULL DEFAULT '';
+ALTER TABLE "aux_collection_run" ADD COLUMN IF NOT EXISTS "region" varchar NOT NULL DEFAULT '';
+ALTER TABLE "aux_collection_run" ADD COLUMN IF NOT EXISTS "status" varchar NOT NULL DEFAULT '';
+
+UPDATE "aux_collection_run" SET "status" = CASE WHEN "error" = '' THEN 'succeeded' ELSE 'failed' END;
+
+CREATE INDEX IF NOT EXISTS "aux_collection_run_scope_idx" ON "aux_collection_run" ("task_name", "provider", "account", "region", "completed_at");
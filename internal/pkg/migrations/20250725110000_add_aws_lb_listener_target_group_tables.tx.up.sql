@@ -0,0 +1,70 @@
+CREATE TABLE IF NOT EXISTS "aws_lb_listener" (
+    "arn" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "lb_arn" varchar NOT NULL,
+    "port" bigint NOT NULL,
+    "protocol" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+    "target_group_arns" varchar[] NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_lb_listener_key" UNIQUE ("arn", "account_id")
+);
+
+CREATE TABLE IF NOT EXISTS "aws_target_group" (
+    "arn" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "protocol" varchar NOT NULL,
+    "port" bigint NOT NULL,
+    "target_type" varchar NOT NULL,
+    "vpc_id" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+    "lb_arns" varchar[] NOT NULL,
+    "target_ids" varchar[] NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_target_group_key" UNIQUE ("arn", "account_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_lb_listener_to_lb" (
+    "listener_id" UUID NOT NULL,
+    "lb_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_aws_lb_listener_to_lb_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_lb_listener_to_lb_listener_id_fkey" FOREIGN KEY ("listener_id") REFERENCES aws_lb_listener ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_lb_listener_to_lb_lb_id_fkey" FOREIGN KEY ("lb_id") REFERENCES aws_loadbalancer ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_lb_listener_to_lb_key" UNIQUE ("listener_id", "lb_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_target_group_to_instance" (
+    "target_group_id" UUID NOT NULL,
+    "instance_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_aws_target_group_to_instance_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_target_group_to_instance_target_group_id_fkey" FOREIGN KEY ("target_group_id") REFERENCES aws_target_group ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_target_group_to_instance_instance_id_fkey" FOREIGN KEY ("instance_id") REFERENCES aws_instance ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_target_group_to_instance_key" UNIQUE ("target_group_id", "instance_id")
+);
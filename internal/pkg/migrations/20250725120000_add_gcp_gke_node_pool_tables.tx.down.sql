@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "l_gcp_gke_node_pool_to_cluster";
+DROP TABLE IF EXISTS "gcp_gke_node_pool";
+
+ALTER TABLE "gcp_gke_cluster" DROP COLUMN IF EXISTS "status";
+ALTER TABLE "gcp_gke_cluster" DROP COLUMN IF EXISTS "current_node_count";
@@ -0,0 +1,43 @@
+ALTER TABLE "gcp_gke_cluster" ADD COLUMN IF NOT EXISTS "current_node_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "gcp_gke_cluster" ADD COLUMN IF NOT EXISTS "status" varchar NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "gcp_gke_node_pool" (
+    "name" varchar NOT NULL,
+    "cluster_id" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "cluster_name" varchar NOT NULL,
+    "location" varchar NOT NULL,
+    "version" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "machine_type" varchar NOT NULL,
+    "disk_size_gb" bigint NOT NULL,
+    "initial_node_count" bigint NOT NULL,
+    "autoscaling_enabled" boolean NOT NULL,
+    "min_node_count" bigint NOT NULL,
+    "max_node_count" bigint NOT NULL,
+    "locations" varchar[] NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "gcp_gke_node_pool_key" UNIQUE ("name", "cluster_id", "project_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_gcp_gke_node_pool_to_cluster" (
+    "node_pool_id" UUID NOT NULL,
+    "cluster_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_gcp_gke_node_pool_to_cluster_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_gcp_gke_node_pool_to_cluster_node_pool_id_fkey" FOREIGN KEY ("node_pool_id") REFERENCES gcp_gke_node_pool ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_gke_node_pool_to_cluster_cluster_id_fkey" FOREIGN KEY ("cluster_id") REFERENCES gcp_gke_cluster ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_gke_node_pool_to_cluster_key" UNIQUE ("node_pool_id", "cluster_id")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_aws_eks_cluster_to_vpc";
+DROP TABLE IF EXISTS "aws_eks_cluster";
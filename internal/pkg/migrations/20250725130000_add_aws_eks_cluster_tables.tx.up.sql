@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS "aws_eks_cluster" (
+    "name" varchar NOT NULL,
+    "account_id" varchar NOT NULL,
+    "region_name" varchar NOT NULL,
+    "arn" varchar NOT NULL,
+    "version" varchar NOT NULL,
+    "platform_version" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "endpoint" varchar NOT NULL,
+    "endpoint_public_access" boolean NOT NULL,
+    "endpoint_private_access" boolean NOT NULL,
+    "vpc_id" varchar NOT NULL,
+    "cluster_created_at" timestamptz,
+    "subnet_ids" varchar[] NOT NULL,
+    "security_group_ids" varchar[] NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_eks_cluster_key" UNIQUE ("name", "account_id", "region_name")
+);
+
+CREATE TABLE IF NOT EXISTS "l_aws_eks_cluster_to_vpc" (
+    "cluster_id" UUID NOT NULL,
+    "vpc_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_aws_eks_cluster_to_vpc_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_aws_eks_cluster_to_vpc_cluster_id_fkey" FOREIGN KEY ("cluster_id") REFERENCES aws_eks_cluster ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_eks_cluster_to_vpc_vpc_id_fkey" FOREIGN KEY ("vpc_id") REFERENCES aws_vpc ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_aws_eks_cluster_to_vpc_key" UNIQUE ("cluster_id", "vpc_id")
+);
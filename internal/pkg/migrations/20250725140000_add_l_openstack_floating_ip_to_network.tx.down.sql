@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "l_openstack_floating_ip_to_network";
+
+ALTER TABLE "openstack_network" DROP COLUMN IF EXISTS "external";
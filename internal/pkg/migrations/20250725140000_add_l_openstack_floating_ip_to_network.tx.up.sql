@@ -0,0 +1,16 @@
+ALTER TABLE "openstack_network" ADD COLUMN IF NOT EXISTS "external" BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS "l_openstack_floating_ip_to_network" (
+    "network_id" UUID NOT NULL,
+    "floating_ip_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_openstack_floating_ip_to_network_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_openstack_floating_ip_to_network_network_id_fkey" FOREIGN KEY ("network_id") REFERENCES openstack_network ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_floating_ip_to_network_floating_ip_id_fkey" FOREIGN KEY ("floating_ip_id") REFERENCES openstack_floating_ip ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_floating_ip_to_network_key" UNIQUE ("network_id", "floating_ip_id")
+);
@@ -0,0 +1,2 @@
+ALTER TABLE "openstack_loadbalancer" DROP COLUMN IF EXISTS "provisioning_status";
+ALTER TABLE "openstack_loadbalancer" DROP COLUMN IF EXISTS "vip_port_id";
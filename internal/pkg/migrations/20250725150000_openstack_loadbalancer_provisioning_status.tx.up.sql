@@ -0,0 +1,2 @@
+ALTER TABLE "openstack_loadbalancer" ADD COLUMN IF NOT EXISTS "provisioning_status" VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE "openstack_loadbalancer" ADD COLUMN IF NOT EXISTS "vip_port_id" VARCHAR NOT NULL DEFAULT '';
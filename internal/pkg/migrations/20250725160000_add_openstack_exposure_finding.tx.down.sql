@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "openstack_exposure_finding";
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS "openstack_exposure_finding" (
+    "resource_id" varchar NOT NULL,
+    "resource_type" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "reason" varchar NOT NULL,
+    "public_ip" inet NOT NULL,
+    "domain" varchar NOT NULL,
+    "region" varchar NOT NULL,
+    "severity" varchar NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "openstack_exposure_finding_key" UNIQUE ("resource_id", "resource_type", "project_id", "reason", "public_ip")
+);
+
+CREATE INDEX IF NOT EXISTS "openstack_exposure_finding_deleted_at_idx" ON "openstack_exposure_finding" ("deleted_at");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "l_gcp_bucket_to_project";
+ALTER TABLE "gcp_bucket" DROP COLUMN IF EXISTS "uniform_bucket_level_access";
+ALTER TABLE "gcp_bucket" DROP COLUMN IF EXISTS "public_access_prevention";
@@ -0,0 +1,17 @@
+ALTER TABLE "gcp_bucket" ADD COLUMN IF NOT EXISTS "public_access_prevention" VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE "gcp_bucket" ADD COLUMN IF NOT EXISTS "uniform_bucket_level_access" BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS "l_gcp_bucket_to_project" (
+    "bucket_id" UUID NOT NULL,
+    "project_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_gcp_bucket_to_project_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_gcp_bucket_to_project_bucket_id_fkey" FOREIGN KEY ("bucket_id") REFERENCES gcp_bucket ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_bucket_to_project_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES gcp_project ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_gcp_bucket_to_project_key" UNIQUE ("bucket_id", "project_id")
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "aws_iam_role";
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS "aws_iam_role" (
+    "role_name" varchar NOT NULL,
+    "role_id" varchar NOT NULL,
+    "arn" varchar NOT NULL,
+    "path" varchar NOT NULL,
+    "create_date" timestamptz NOT NULL,
+    "description" varchar NOT NULL,
+    "max_session_duration" int NOT NULL,
+    "account_id" varchar NOT NULL,
+    "trust_policy" jsonb NOT NULL,
+    "trusted_accounts" varchar[] NOT NULL,
+    "trusts_external_accounts" boolean NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aws_iam_role_arn_key" UNIQUE ("arn")
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "aux_change_event" (
+    "resource_id" varchar NOT NULL,
+    "resource_type" varchar NOT NULL,
+    "field" varchar NOT NULL,
+    "old_value" varchar NOT NULL,
+    "new_value" varchar NOT NULL,
+    "changed_at" timestamptz NOT NULL,
+
+    "id" uuid NOT NULL DEFAULT gen_random_uuid (),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "aux_change_event_resource_idx" ON "aux_change_event" ("resource_type", "resource_id", "changed_at");
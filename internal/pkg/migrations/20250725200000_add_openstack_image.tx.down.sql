@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "l_openstack_server_to_image";
+DROP TABLE IF EXISTS "openstack_image";
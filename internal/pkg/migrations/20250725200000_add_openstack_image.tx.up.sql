@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS "openstack_image" (
+    "image_id" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "project_id" varchar NOT NULL,
+    "domain" varchar NOT NULL,
+    "region" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "visibility" varchar NOT NULL,
+    "size" bigint NOT NULL,
+    "min_disk" int NOT NULL,
+    "min_ram" int NOT NULL,
+    "disk_format" varchar NOT NULL,
+    "container_format" varchar NOT NULL,
+    "image_created_at" timestamptz NOT NULL,
+    "image_updated_at" timestamptz NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+
+    PRIMARY KEY ("id"),
+    CONSTRAINT "openstack_image_key" UNIQUE ("image_id", "project_id")
+);
+
+CREATE TABLE IF NOT EXISTS "l_openstack_server_to_image" (
+    "server_id" UUID NOT NULL,
+    "image_id" UUID NOT NULL,
+
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    CONSTRAINT "l_openstack_server_to_image_pkey" PRIMARY KEY ("id"),
+    CONSTRAINT "l_openstack_server_to_image_server_id_fkey" FOREIGN KEY ("server_id") REFERENCES openstack_server ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_server_to_image_image_id_fkey" FOREIGN KEY ("image_id") REFERENCES openstack_image ("id") ON DELETE CASCADE,
+    CONSTRAINT "l_openstack_server_to_image_key" UNIQUE ("server_id", "image_id")
+);
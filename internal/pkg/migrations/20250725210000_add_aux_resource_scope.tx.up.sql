@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "aux_resource_scope" (
+    "model_name" varchar NOT NULL,
+    "resource_id" varchar NOT NULL,
+    "scope" varchar NOT NULL,
+    "last_seen" timestamptz NOT NULL,
+
+    "id" uuid NOT NULL DEFAULT gen_random_uuid (),
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_seen_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "aux_resource_scope_key" UNIQUE ("model_name", "resource_id", "scope")
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "aws_instance_global_id_idx";
+ALTER TABLE "aws_instance" DROP COLUMN IF EXISTS "global_id";
+DROP INDEX IF EXISTS "gcp_instance_global_id_idx";
+ALTER TABLE "gcp_instance" DROP COLUMN IF EXISTS "global_id";
+DROP INDEX IF EXISTS "az_vm_global_id_idx";
+ALTER TABLE "az_vm" DROP COLUMN IF EXISTS "global_id";
+DROP INDEX IF EXISTS "openstack_server_global_id_idx";
+ALTER TABLE "openstack_server" DROP COLUMN IF EXISTS "global_id";
@@ -0,0 +1,8 @@
+ALTER TABLE "aws_instance" ADD COLUMN IF NOT EXISTS "global_id" VARCHAR;
+CREATE INDEX IF NOT EXISTS "aws_instance_global_id_idx" ON "aws_instance" ("global_id");
+ALTER TABLE "gcp_instance" ADD COLUMN IF NOT EXISTS "global_id" VARCHAR;
+CREATE INDEX IF NOT EXISTS "gcp_instance_global_id_idx" ON "gcp_instance" ("global_id");
+ALTER TABLE "az_vm" ADD COLUMN IF NOT EXISTS "global_id" VARCHAR;
+CREATE INDEX IF NOT EXISTS "az_vm_global_id_idx" ON "az_vm" ("global_id");
+ALTER TABLE "openstack_server" ADD COLUMN IF NOT EXISTS "global_id" VARCHAR;
+CREATE INDEX IF NOT EXISTS "openstack_server_global_id_idx" ON "openstack_server" ("global_id");
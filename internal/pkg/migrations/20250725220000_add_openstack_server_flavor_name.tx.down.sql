@@ -0,0 +1 @@
+ALTER TABLE "openstack_server" DROP COLUMN IF EXISTS "flavor_name";
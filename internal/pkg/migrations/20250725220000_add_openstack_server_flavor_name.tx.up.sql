@@ -0,0 +1 @@
+ALTER TABLE "openstack_server" ADD COLUMN IF NOT EXISTS "flavor_name" varchar NOT NULL DEFAULT '';
@@ -0,0 +1,313 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package api provides a read-only HTTP API for the inventory models, which
+// restricts the returned records to the scope of the authenticated principal.
+package api
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+const (
+	// DefaultLimit is the default number of records returned when listing
+	// a model.
+	DefaultLimit = 100
+
+	// MaxLimit is the max number of records returned when listing a model.
+	MaxLimit = 1000
+)
+
+// Server serves the read-only API.
+type Server struct {
+	db         *bun.DB
+	tokens     map[string]*Principal
+	staleAfter func(model string) time.Duration
+}
+
+// Option is a function, which configures the [Server].
+type Option func(s *Server)
+
+// NewServer creates a new [Server], which authenticates the principals by
+// the given bearer tokens.
+func NewServer(db *bun.DB, tokens map[string]*Principal, opts ...Option) *Server {
+	s := &Server{
+		db:     db,
+		tokens: tokens,
+		staleAfter: func(string) time.Duration {
+			return config.DefaultStaleAfter
+		},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithStaleAfter configures the [Server] to use the given function for looking
+// up the expected collection interval of a model, after which its records are
+// reported as stale.
+func WithStaleAfter(fn func(model string) time.Duration) Option {
+	opt := func(s *Server) {
+		s.staleAfter = fn
+	}
+
+	return opt
+}
+
+// Handler returns the [http.Handler] of the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/models/{name}", s.listRecords)
+	mux.HandleFunc("GET /api/v1/models/{name}/{id}", s.getRecord)
+
+	return s.authenticate(mux)
+}
+
+// authenticate is a middleware, which authenticates the principal by the
+// bearer token provided in the Authorization header.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized)
+
+			return
+		}
+
+		principal := s.lookupPrincipal(token)
+		if principal == nil {
+			writeError(w, http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// lookupPrincipal returns the [Principal] associated with the given token, or
+// nil if the token is unknown.
+func (s *Server) lookupPrincipal(token string) *Principal {
+	var found *Principal
+	for t, p := range s.tokens {
+		// Compare all tokens in constant time, so that we don't leak
+		// information about known tokens.
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			found = p
+		}
+	}
+
+	return found
+}
+
+// scopedQuery returns a new query for the model with the given name, which is
+// restricted to the scope of the principal. The returned bool is false, if the
+// model is unknown or not visible to the principal.
+func (s *Server) scopedQuery(r *http.Request, dest any, model any) (*bun.SelectQuery, bool) {
+	principal, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		return nil, false
+	}
+
+	table := s.db.Table(reflect.TypeOf(model))
+	query := s.db.NewSelect().Model(dest)
+
+	return principal.Scope(query, table)
+}
+
+// listRecords returns the records of a given model.
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request) {
+	model, ok := registry.ModelRegistry.Get(r.PathValue("name"))
+	if !ok {
+		writeError(w, http.StatusNotFound)
+
+		return
+	}
+
+	limit, err := parseIntParam(r, "limit", DefaultLimit)
+	if err != nil || limit < 1 {
+		writeError(w, http.StatusBadRequest)
+
+		return
+	}
+	limit = min(limit, MaxLimit)
+
+	offset, err := parseIntParam(r, "offset", 0)
+	if err != nil || offset < 0 {
+		writeError(w, http.StatusBadRequest)
+
+		return
+	}
+
+	modelType := reflect.TypeOf(model).Elem()
+	records := reflect.New(reflect.SliceOf(modelType))
+	records.Elem().Set(reflect.MakeSlice(records.Elem().Type(), 0, 0))
+
+	query, ok := s.scopedQuery(r, records.Interface(), model)
+	if !ok {
+		writeError(w, http.StatusNotFound)
+
+		return
+	}
+
+	err = query.
+		OrderExpr("?TableAlias.id").
+		Limit(limit).
+		Offset(offset).
+		Scan(r.Context())
+
+	if err != nil {
+		slog.Error("failed to list records", "model", r.PathValue("name"), "reason", err)
+		writeError(w, http.StatusInternalServerError)
+
+		return
+	}
+
+	staleAfter := s.staleAfter(r.PathValue("name"))
+	count := records.Elem().Len()
+	items := make([]any, 0, count)
+	for i := range count {
+		item, err := NewRecordView(records.Elem().Index(i).Interface(), staleAfter)
+		if err != nil {
+			slog.Error("failed to serialize record", "model", r.PathValue("name"), "reason", err)
+			writeError(w, http.StatusInternalServerError)
+
+			return
+		}
+		items = append(items, item)
+	}
+
+	writeJSON(w, items)
+}
+
+// getRecord returns a single record of a given model by its id. Records, which
+// are outside of the scope of the principal are reported as not found.
+func (s *Server) getRecord(w http.ResponseWriter, r *http.Request) {
+	model, ok := registry.ModelRegistry.Get(r.PathValue("name"))
+	if !ok {
+		writeError(w, http.StatusNotFound)
+
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound)
+
+		return
+	}
+
+	record := reflect.New(reflect.TypeOf(model).Elem()).Interface()
+	query, ok := s.scopedQuery(r, record, model)
+	if !ok {
+		writeError(w, http.StatusNotFound)
+
+		return
+	}
+
+	err = query.
+		Where("?TableAlias.id = ?", id).
+		Scan(r.Context())
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound)
+	case err != nil:
+		slog.Error("failed to get record", "model", r.PathValue("name"), "id", id, "reason", err)
+		writeError(w, http.StatusInternalServerError)
+	default:
+		item, err := NewRecordView(record, s.staleAfter(r.PathValue("name")))
+		if err != nil {
+			slog.Error("failed to serialize record", "model", r.PathValue("name"), "id", id, "reason", err)
+			writeError(w, http.StatusInternalServerError)
+
+			return
+		}
+		writeJSON(w, item)
+	}
+}
+
+// ager is implemented by models, which provide the age of their records.
+type ager interface {
+	Age() time.Duration
+}
+
+// NewRecordView returns the JSON representation of the given record. Records of
+// models, which provide the age of their records, are extended with the
+// `age_seconds' field, which specifies the number of seconds since the record
+// was last seen by a collector, and the `stale' field, which specifies whether
+// the age exceeds the given expected collection interval of the model.
+func NewRecordView(record any, staleAfter time.Duration) (any, error) {
+	item, ok := record.(ager)
+	if !ok {
+		return record, nil
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the original field values as is, so that e.g. large numbers
+	// do not lose precision.
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	age := item.Age()
+	fields["age_seconds"] = json.RawMessage(strconv.FormatInt(int64(age.Seconds()), 10))
+	fields["stale"] = json.RawMessage(strconv.FormatBool(age > staleAfter))
+
+	return fields, nil
+}
+
+// parseIntParam parses the query parameter with the given name as an int,
+// falling back to the given default value, if the parameter is not set.
+func parseIntParam(r *http.Request, name string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+// writeJSON writes the given value as a JSON response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to write response", "reason", err)
+	}
+}
+
+// writeError writes an error response with the given status code.
+func writeError(w http.ResponseWriter, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	resp := map[string]string{
+		"error": http.StatusText(code),
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,375 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	"github.com/gardener/inventory/pkg/api"
+	awsmodels "github.com/gardener/inventory/pkg/aws/models"
+	coremodels "github.com/gardener/inventory/pkg/core/models"
+)
+
+// newTestServer returns an [httptest.Server] for an [api.Server] with the
+// following principals:
+//
+//   - `admin-token' for an unrestricted principal
+//   - `team-a-token' for a principal restricted to account 111111111111
+//   - `team-b-token' for a principal restricted to project my-project
+func newTestServer(t *testing.T, db *bun.DB) *httptest.Server {
+	t.Helper()
+
+	tokens := map[string]*api.Principal{
+		"admin-token": {
+			Name:         "admin",
+			Unrestricted: true,
+		},
+		"team-a-token": {
+			Name:       "team-a",
+			AccountIDs: []string{"111111111111"},
+		},
+		"team-b-token": {
+			Name:       "team-b",
+			ProjectIDs: []string{"my-project"},
+		},
+	}
+
+	server := httptest.NewServer(api.NewServer(db, tokens).Handler())
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func doRequest(t *testing.T, server *httptest.Server, path, token string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+path, nil)
+	if err != nil {
+		t.Fatalf("unable to create request: %s", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("unable to send request: %s", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+// TestServerRequests tests the requests, which are rejected before querying
+// the database.
+func TestServerRequests(t *testing.T) {
+	server := newTestServer(t, bun.NewDB(&sql.DB{}, pgdialect.New()))
+
+	testCases := []struct {
+		desc     string
+		path     string
+		token    string
+		wantCode int
+	}{
+		{
+			desc:     "unauthenticated request",
+			path:     "/api/v1/models/" + awsmodels.InstanceModelName,
+			token:    "",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			desc:     "unknown token",
+			path:     "/api/v1/models/" + awsmodels.InstanceModelName,
+			token:    "unknown-token",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			desc:     "unauthenticated request for a single record",
+			path:     "/api/v1/models/" + awsmodels.InstanceModelName + "/5f3e7b4e-8d3c-4c1e-9a4e-6f1f7b0c2d11",
+			token:    "",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			desc:     "unknown model",
+			path:     "/api/v1/models/aws:model:unknown",
+			token:    "admin-token",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			desc:     "denied scope",
+			path:     "/api/v1/models/" + awsmodels.InstanceModelName,
+			token:    "team-b-token",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			desc:     "denied scope for a single record",
+			path:     "/api/v1/models/" + awsmodels.InstanceModelName + "/5f3e7b4e-8d3c-4c1e-9a4e-6f1f7b0c2d11",
+			token:    "team-b-token",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			desc:     "table without scope column",
+			path:     "/api/v1/models/" + awsmodels.VPCToInstanceModelName,
+			token:    "team-a-token",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			desc:     "invalid record id",
+			path:     "/api/v1/models/" + awsmodels.InstanceModelName + "/not-a-uuid",
+			token:    "team-a-token",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			desc:     "invalid limit",
+			path:     "/api/v1/models/" + awsmodels.InstanceModelName + "?limit=0",
+			token:    "team-a-token",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc:     "invalid offset",
+			path:     "/api/v1/models/" + awsmodels.InstanceModelName + "?offset=-1",
+			token:    "team-a-token",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp := doRequest(t, server, tc.path, tc.token)
+			if resp.StatusCode != tc.wantCode {
+				t.Fatalf("want status code %d, got %d", tc.wantCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// TestServerScopedRecords tests that the records returned by the API are
+// restricted to the scope of the principal. The test is skipped, unless the
+// test database is configured via [dbtest.EnvDSN].
+func TestServerScopedRecords(t *testing.T) {
+	db := dbtest.New(t)
+	server := newTestServer(t, db)
+
+	instances := []awsmodels.Instance{
+		{InstanceID: "i-team-a", AccountID: "111111111111"},
+		{InstanceID: "i-other", AccountID: "333333333333"},
+	}
+	if _, err := db.NewInsert().Model(&instances).Returning("id").Exec(t.Context()); err != nil {
+		t.Fatalf("unable to insert instances: %s", err)
+	}
+
+	listPath := "/api/v1/models/" + awsmodels.InstanceModelName
+	testCases := []struct {
+		desc     string
+		path     string
+		token    string
+		wantCode int
+		wantIDs  []string
+	}{
+		{
+			desc:     "unrestricted principal lists all records",
+			path:     listPath,
+			token:    "admin-token",
+			wantCode: http.StatusOK,
+			wantIDs:  []string{"i-team-a", "i-other"},
+		},
+		{
+			desc:     "principal lists records in its scope",
+			path:     listPath,
+			token:    "team-a-token",
+			wantCode: http.StatusOK,
+			wantIDs:  []string{"i-team-a"},
+		},
+		{
+			desc:     "principal gets a record in its scope",
+			path:     listPath + "/" + instances[0].ID.String(),
+			token:    "team-a-token",
+			wantCode: http.StatusOK,
+			wantIDs:  []string{"i-team-a"},
+		},
+		{
+			desc:     "record outside of the scope is not found",
+			path:     listPath + "/" + instances[1].ID.String(),
+			token:    "team-a-token",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp := doRequest(t, server, tc.path, tc.token)
+			if resp.StatusCode != tc.wantCode {
+				t.Fatalf("want status code %d, got %d", tc.wantCode, resp.StatusCode)
+			}
+
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+
+			var records []map[string]any
+			if tc.path == listPath {
+				if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+			} else {
+				var record map[string]any
+				if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				records = append(records, record)
+			}
+
+			got := make(map[string]bool)
+			for _, record := range records {
+				id, _ := record["InstanceID"].(string)
+				got[id] = true
+				if _, ok := record["age_seconds"]; !ok {
+					t.Fatalf("want age_seconds in record %v", record)
+				}
+			}
+			if len(got) != len(tc.wantIDs) {
+				t.Fatalf("want records %v, got %v", tc.wantIDs, got)
+			}
+			for _, id := range tc.wantIDs {
+				if !got[id] {
+					t.Fatalf("want records %v, got %v", tc.wantIDs, got)
+				}
+			}
+		})
+	}
+}
+
+type testRecord struct {
+	coremodels.Model
+
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+type plainRecord struct {
+	Name string `json:"name"`
+}
+
+func TestNewRecordView(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		desc       string
+		record     any
+		staleAfter time.Duration
+		wantAge    bool
+		wantStale  bool
+		minAge     int64
+	}{
+		{
+			desc: "fresh record",
+			record: testRecord{
+				Model: coremodels.Model{
+					UpdatedAt:  now.Add(-2 * time.Hour),
+					LastSeenAt: now.Add(-10 * time.Minute),
+				},
+				Name: "fresh",
+				Size: 1 << 62,
+			},
+			staleAfter: time.Hour,
+			wantAge:    true,
+			wantStale:  false,
+			minAge:     600,
+		},
+		{
+			desc: "stale record",
+			record: &testRecord{
+				Model: coremodels.Model{
+					UpdatedAt:  now.Add(-time.Minute),
+					LastSeenAt: now.Add(-2 * time.Hour),
+				},
+				Name: "stale",
+				Size: 1 << 62,
+			},
+			staleAfter: time.Hour,
+			wantAge:    true,
+			wantStale:  true,
+			minAge:     7200,
+		},
+		{
+			desc: "record not seen yet falls back to updated_at",
+			record: testRecord{
+				Model: coremodels.Model{
+					UpdatedAt: now.Add(-3 * time.Hour),
+				},
+				Name: "unseen",
+				Size: 1 << 62,
+			},
+			staleAfter: time.Hour,
+			wantAge:    true,
+			wantStale:  true,
+			minAge:     10800,
+		},
+		{
+			desc:       "model without age",
+			record:     plainRecord{Name: "plain"},
+			staleAfter: time.Hour,
+			wantAge:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			view, err := api.NewRecordView(tc.record, tc.staleAfter)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			data, err := json.Marshal(view)
+			if err != nil {
+				t.Fatalf("unable to marshal view: %s", err)
+			}
+
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(data, &fields); err != nil {
+				t.Fatalf("unable to unmarshal view: %s", err)
+			}
+
+			_, hasAge := fields["age_seconds"]
+			_, hasStale := fields["stale"]
+			if hasAge != tc.wantAge || hasStale != tc.wantAge {
+				t.Fatalf("want age fields %t, got age_seconds %t, stale %t", tc.wantAge, hasAge, hasStale)
+			}
+
+			if !tc.wantAge {
+				return
+			}
+
+			var age int64
+			if err := json.Unmarshal(fields["age_seconds"], &age); err != nil {
+				t.Fatalf("invalid age_seconds: %s", err)
+			}
+			if age < tc.minAge || age > tc.minAge+60 {
+				t.Fatalf("want age_seconds of about %d, got %d", tc.minAge, age)
+			}
+
+			var stale bool
+			if err := json.Unmarshal(fields["stale"], &stale); err != nil {
+				t.Fatalf("invalid stale: %s", err)
+			}
+			if stale != tc.wantStale {
+				t.Fatalf("want stale %t, got %t", tc.wantStale, stale)
+			}
+
+			// Large numbers must not lose precision
+			if string(fields["size"]) != "4611686018427387904" {
+				t.Fatalf("want size to be preserved, got %s", fields["size"])
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// Principal represents an authenticated client of the API.
+type Principal struct {
+	// Name is the name of the principal.
+	Name string
+
+	// Unrestricted specifies whether the principal has access to all
+	// records, regardless of their scope.
+	Unrestricted bool
+
+	// AccountIDs specifies the account ids, which the principal has
+	// access to.
+	AccountIDs []string
+
+	// ProjectIDs specifies the project ids, which the principal has
+	// access to.
+	ProjectIDs []string
+}
+
+// principalKey is the key used to store the [Principal] in a [context.Context].
+type principalKey struct{}
+
+// WithPrincipal returns a new [context.Context], which carries the given
+// [Principal].
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the [Principal] from the given
+// [context.Context], if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+
+	return p, ok
+}
+
+// Scope restricts the given query to the records, which are visible to the
+// principal, based on the `account_id' and `project_id' columns of the table.
+// Only columns holding the external account or project ids are considered.
+// Link tables, which refer to the internal ids of the linked records, are not
+// visible to restricted principals.
+//
+// The returned bool is false, if no record of the table can be visible to the
+// principal, e.g. the table is not scoped by an account or project, or the
+// principal does not have access to any of the ids the table is scoped by.
+func (p *Principal) Scope(q *bun.SelectQuery, table *schema.Table) (*bun.SelectQuery, bool) {
+	if p.Unrestricted {
+		return q, true
+	}
+
+	filters := make(map[string][]string)
+	if isScopeColumn(table, "account_id") && len(p.AccountIDs) > 0 {
+		filters["account_id"] = p.AccountIDs
+	}
+	if isScopeColumn(table, "project_id") && len(p.ProjectIDs) > 0 {
+		filters["project_id"] = p.ProjectIDs
+	}
+
+	if len(filters) == 0 {
+		return q, false
+	}
+
+	q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		for column, ids := range filters {
+			q = q.WhereOr("?TableAlias.? IN (?)", bun.Ident(column), bun.In(ids))
+		}
+
+		return q
+	})
+
+	return q, true
+}
+
+// isScopeColumn returns true, if the table has the given column and the column
+// holds external ids. Columns of other types, e.g. the UUIDs of linked records
+// in link tables, cannot be compared with the ids of the principal.
+func isScopeColumn(table *schema.Table, column string) bool {
+	field, ok := table.FieldMap[column]
+	if !ok {
+		return false
+	}
+
+	return field.IndirectType.Kind() == reflect.String
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	"github.com/gardener/inventory/pkg/api"
+	awsmodels "github.com/gardener/inventory/pkg/aws/models"
+	gcpmodels "github.com/gardener/inventory/pkg/gcp/models"
+	openstackmodels "github.com/gardener/inventory/pkg/openstack/models"
+)
+
+func TestPrincipalScope(t *testing.T) {
+	db := bun.NewDB(&sql.DB{}, pgdialect.New())
+
+	testCases := []struct {
+		desc      string
+		principal *api.Principal
+		model     any
+		wantOK    bool
+		wanted    string
+		unwanted  string
+	}{
+		{
+			desc:      "unrestricted principal",
+			principal: &api.Principal{Name: "admin", Unrestricted: true},
+			model:     &awsmodels.Instance{},
+			wantOK:    true,
+			unwanted:  "WHERE",
+		},
+		{
+			desc: "allowed account",
+			principal: &api.Principal{
+				Name:       "team-a",
+				AccountIDs: []string{"111111111111", "222222222222"},
+			},
+			model:    &awsmodels.Instance{},
+			wantOK:   true,
+			wanted:   `WHERE (("instance"."account_id" IN ('111111111111', '222222222222')))`,
+			unwanted: "project_id",
+		},
+		{
+			desc: "allowed project",
+			principal: &api.Principal{
+				Name:       "team-a",
+				AccountIDs: []string{"111111111111"},
+				ProjectIDs: []string{"my-project"},
+			},
+			model:    &gcpmodels.Instance{},
+			wantOK:   true,
+			wanted:   `WHERE (("instance"."project_id" IN ('my-project')))`,
+			unwanted: "account_id",
+		},
+		{
+			desc: "denied scope",
+			principal: &api.Principal{
+				Name:       "team-a",
+				ProjectIDs: []string{"my-project"},
+			},
+			model:  &awsmodels.Instance{},
+			wantOK: false,
+		},
+		{
+			desc: "principal without any scope",
+			principal: &api.Principal{
+				Name: "nobody",
+			},
+			model:  &awsmodels.Instance{},
+			wantOK: false,
+		},
+		{
+			desc: "table without scope column",
+			principal: &api.Principal{
+				Name:       "team-a",
+				AccountIDs: []string{"111111111111"},
+				ProjectIDs: []string{"my-project"},
+			},
+			model:  &awsmodels.VPCToInstance{},
+			wantOK: false,
+		},
+		{
+			desc: "link table referring to projects",
+			principal: &api.Principal{
+				Name:       "team-a",
+				ProjectIDs: []string{"my-project"},
+			},
+			model:  &gcpmodels.InstanceToProject{},
+			wantOK: false,
+		},
+		{
+			desc: "openstack link table referring to projects",
+			principal: &api.Principal{
+				Name:       "team-a",
+				ProjectIDs: []string{"my-project"},
+			},
+			model:  &openstackmodels.ServerToProject{},
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			table := db.Table(reflect.TypeOf(tc.model))
+			query, ok := tc.principal.Scope(db.NewSelect().Model(tc.model), table)
+			if ok != tc.wantOK {
+				t.Fatalf("want ok %t, got %t", tc.wantOK, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			sql := query.String()
+			if tc.wanted != "" && !strings.Contains(sql, tc.wanted) {
+				t.Fatalf("want query containing %q, got %q", tc.wanted, sql)
+			}
+			if tc.unwanted != "" && strings.Contains(sql, tc.unwanted) {
+				t.Fatalf("want query without %q, got %q", tc.unwanted, sql)
+			}
+		})
+	}
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	if _, ok := api.PrincipalFromContext(context.Background()); ok {
+		t.Fatal("want no principal in empty context")
+	}
+
+	principal := &api.Principal{Name: "team-a"}
+	got, ok := api.PrincipalFromContext(api.WithPrincipal(context.Background(), principal))
+	if !ok || got != principal {
+		t.Fatalf("want principal %v, got %v", principal, got)
+	}
+}
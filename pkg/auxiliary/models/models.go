@@ -7,6 +7,7 @@ package models
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
 	coremodels "github.com/gardener/inventory/pkg/core/models"
@@ -35,7 +36,192 @@ type HousekeeperRun struct {
 	Count int64 `bun:"count,notnull"`
 }
 
+// TagViolation represents a resource, which does not satisfy the required
+// tag rules.
+type TagViolation struct {
+	bun.BaseModel `bun:"table:aux_tag_violation"`
+	coremodels.Model
+
+	// ModelName specifies the name of the model of the resource.
+	ModelName string `bun:"model_name,notnull,unique:aux_tag_violation_key"`
+
+	// ResourceID specifies the id of the resource in the table of the
+	// model.
+	ResourceID uuid.UUID `bun:"resource_id,notnull,type:uuid,unique:aux_tag_violation_key"`
+
+	// Scope specifies the account, project or subscription of the
+	// resource, if known.
+	Scope string `bun:"scope,notnull"`
+
+	// MissingKeys specifies the required tag keys, which are missing from
+	// the resource.
+	MissingKeys []string `bun:"missing_keys,array,notnull"`
+}
+
+// DuplicateResource represents a resource id, which was collected under more
+// than one account, project or subscription.
+type DuplicateResource struct {
+	bun.BaseModel `bun:"table:aux_duplicate_resource"`
+	coremodels.Model
+
+	// ModelName specifies the name of the model of the resource.
+	ModelName string `bun:"model_name,notnull,unique:aux_duplicate_resource_key"`
+
+	// ResourceID specifies the provider id of the resource.
+	ResourceID string `bun:"resource_id,notnull,unique:aux_duplicate_resource_key"`
+
+	// Scopes specifies the accounts, projects or subscriptions under
+	// which the resource was collected.
+	Scopes []string `bun:"scopes,array,notnull"`
+
+	// LastSeen specifies when the resource was last collected.
+	LastSeen time.Time `bun:"last_seen,notnull"`
+}
+
+// ResourceScope represents an account, project or subscription, under which a
+// resource id was collected. The scopes are recorded on each duplicate
+// detection, so that resources, whose scope was overwritten by a later
+// collection, are still detected as duplicates.
+type ResourceScope struct {
+	bun.BaseModel `bun:"table:aux_resource_scope"`
+	coremodels.Model
+
+	// ModelName specifies the name of the model of the resource.
+	ModelName string `bun:"model_name,notnull,unique:aux_resource_scope_key"`
+
+	// ResourceID specifies the provider id of the resource.
+	ResourceID string `bun:"resource_id,notnull,unique:aux_resource_scope_key"`
+
+	// Scope specifies the account, project or subscription, under which
+	// the resource was collected.
+	Scope string `bun:"scope,notnull,unique:aux_resource_scope_key"`
+
+	// LastSeen specifies when the resource was last collected under the
+	// scope.
+	LastSeen time.Time `bun:"last_seen,notnull"`
+}
+
+// CollectionRun represents the structured result of a single task run.
+type CollectionRun struct {
+	bun.BaseModel `bun:"table:aux_collection_run"`
+	coremodels.Model
+
+	// TaskID specifies the id of the task.
+	TaskID string `bun:"task_id,notnull"`
+
+	// TaskName specifies the name of the task.
+	TaskName string `bun:"task_name,notnull"`
+
+	// Queue specifies the queue from which the task was processed.
+	Queue string `bun:"queue,notnull"`
+
+	// Provider specifies the provider handled by the task, if any.
+	Provider string `bun:"provider,notnull"`
+
+	// Account specifies the account, project, subscription or seed the
+	// task is scoped to, if any.
+	Account string `bun:"account,notnull"`
+
+	// Region specifies the region the task is scoped to, if any.
+	Region string `bun:"region,notnull"`
+
+	// StartedAt specifies when the task handler was started.
+	StartedAt time.Time `bun:"started_at,notnull"`
+
+	// CompletedAt specifies when the task handler completed.
+	CompletedAt time.Time `bun:"completed_at,notnull"`
+
+	// Duration specifies the duration of the task handler.
+	Duration time.Duration `bun:"duration,notnull"`
+
+	// Rows specifies the number of rows inserted or updated by the task.
+	Rows int64 `bun:"rows,notnull"`
+
+	// Pages specifies the number of pages fetched by the task.
+	Pages int64 `bun:"pages,notnull"`
+
+	// Skipped specifies the number of items skipped by the task.
+	Skipped int64 `bun:"skipped,notnull"`
+
+	// Status specifies the status of the task, e.g. succeeded or failed.
+	Status string `bun:"status,notnull"`
+
+	// Error specifies the error returned by the task, if any.
+	Error string `bun:"error,notnull"`
+}
+
+// LinkRun tracks the runs of an incremental link function for a given
+// relationship.
+type LinkRun struct {
+	bun.BaseModel `bun:"table:aux_link_run"`
+	coremodels.Model
+
+	// Name specifies the name of the relationship, e.g. the name of the
+	// link model.
+	Name string `bun:"name,notnull,unique:aux_link_run_key"`
+
+	// LastRunAt specifies when the link function last completed
+	// successfully.
+	LastRunAt time.Time `bun:"last_run_at,notnull"`
+
+	// LastFullRunAt specifies when the link function last processed all
+	// source rows.
+	LastFullRunAt time.Time `bun:"last_full_run_at,notnull"`
+}
+
+// CollectionMarker tracks the last successful collection of a scope by a given
+// task type, which is used for enforcing a minimum interval between
+// collections.
+type CollectionMarker struct {
+	bun.BaseModel `bun:"table:aux_collection_marker"`
+	coremodels.Model
+
+	// TaskName specifies the name of the task.
+	TaskName string `bun:"task_name,notnull,unique:aux_collection_marker_key"`
+
+	// Scope specifies the digest of the task payload, which identifies
+	// the collected scope.
+	Scope string `bun:"scope,notnull,unique:aux_collection_marker_key"`
+
+	// CollectedAt specifies when the last successful collection of the
+	// scope started.
+	CollectedAt time.Time `bun:"collected_at,notnull"`
+}
+
+// ChangeEvent represents a change of a field of a collected resource, which
+// was detected when upserting the resource.
+type ChangeEvent struct {
+	bun.BaseModel `bun:"table:aux_change_event"`
+	coremodels.Model
+
+	// ResourceID specifies the provider-specific id of the resource.
+	ResourceID string `bun:"resource_id,notnull"`
+
+	// ResourceType specifies the name of the model of the resource, e.g.
+	// openstack:model:floating_ip.
+	ResourceType string `bun:"resource_type,notnull"`
+
+	// Field specifies the name of the column, which has changed.
+	Field string `bun:"field,notnull"`
+
+	// OldValue specifies the value of the field before the change.
+	OldValue string `bun:"old_value,notnull"`
+
+	// NewValue specifies the value of the field after the change.
+	NewValue string `bun:"new_value,notnull"`
+
+	// ChangedAt specifies when the change was detected.
+	ChangedAt time.Time `bun:"changed_at,notnull"`
+}
+
 func init() {
 	// Register the models with the default registry
 	registry.ModelRegistry.MustRegister("aux:model:housekeeper_run", &HousekeeperRun{})
+	registry.ModelRegistry.MustRegister("aux:model:tag_violation", &TagViolation{})
+	registry.ModelRegistry.MustRegister("aux:model:duplicate_resource", &DuplicateResource{})
+	registry.ModelRegistry.MustRegister("aux:model:resource_scope", &ResourceScope{})
+	registry.ModelRegistry.MustRegister("aux:model:collection_run", &CollectionRun{})
+	registry.ModelRegistry.MustRegister("aux:model:link_run", &LinkRun{})
+	registry.ModelRegistry.MustRegister("aux:model:collection_marker", &CollectionMarker{})
+	registry.ModelRegistry.MustRegister("aux:model:change_event", &ChangeEvent{})
 }
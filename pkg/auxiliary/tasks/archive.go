@@ -0,0 +1,347 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// ArchiveHistoryTaskType is the name of the task responsible for
+	// archiving old history records to object storage.
+	ArchiveHistoryTaskType = "aux:task:archive-history"
+
+	// ArchiveTargetAWS is the name of the archive target, which uploads
+	// archives to an AWS S3 bucket.
+	ArchiveTargetAWS = "aws"
+
+	// ArchiveTargetGCP is the name of the archive target, which uploads
+	// archives to a GCS bucket.
+	ArchiveTargetGCP = "gcp"
+
+	// DefaultArchiveBatchSize specifies the default number of records,
+	// which are archived in a single batch.
+	DefaultArchiveBatchSize = 10000
+)
+
+// ErrNoArchiveBucket is an error, which is returned when the archive target
+// does not specify a bucket.
+var ErrNoArchiveBucket = errors.New("no archive bucket specified")
+
+// ErrUnknownArchiveTarget is an error, which is returned when the archive
+// target refers to an unknown object storage provider.
+var ErrUnknownArchiveTarget = errors.New("unknown archive target")
+
+// ErrArchiveVerificationFailed is an error, which is returned when an uploaded
+// archive does not match the archived data.
+var ErrArchiveVerificationFailed = errors.New("archive verification failed")
+
+// ArchiveHistoryPayload represents the payload of the task for archiving
+// history records.
+type ArchiveHistoryPayload struct {
+	// Target specifies the object storage to which archives are uploaded.
+	Target ArchiveTarget `yaml:"target" json:"target"`
+
+	// Retention provides the archival configuration of models.
+	Retention []ArchiveRetentionConfig `yaml:"retention" json:"retention"`
+}
+
+// ArchiveTarget specifies the object storage to which archives are uploaded.
+type ArchiveTarget struct {
+	// Provider specifies the object storage provider. The currently
+	// supported providers are `aws' and `gcp'.
+	Provider string `yaml:"provider" json:"provider"`
+
+	// AccountID specifies the AWS Account ID of the S3 client to use, when
+	// the provider is `aws'.
+	AccountID string `yaml:"account_id" json:"account_id"`
+
+	// ProjectID specifies the GCP Project ID of the storage client to use,
+	// when the provider is `gcp'.
+	ProjectID string `yaml:"project_id" json:"project_id"`
+
+	// Bucket specifies the name of the bucket.
+	Bucket string `yaml:"bucket" json:"bucket"`
+
+	// Prefix specifies an optional prefix for the archive object keys.
+	Prefix string `yaml:"prefix" json:"prefix"`
+}
+
+// ArchiveRetentionConfig represents the archival configuration for a given
+// model.
+type ArchiveRetentionConfig struct {
+	// Name specifies the model name.
+	Name string `yaml:"name" json:"name"`
+
+	// Duration specifies the max duration for which records are kept in
+	// the database. Records created before that are archived and deleted.
+	Duration time.Duration `yaml:"duration" json:"duration"`
+
+	// BatchSize specifies the max number of records, which are archived
+	// in a single batch. Each batch is uploaded as a separate archive.
+	// If not specified, [DefaultArchiveBatchSize] is used.
+	BatchSize int `yaml:"batch_size" json:"batch_size"`
+}
+
+// ArchiveUploader uploads the archive data to the given key, and verifies that
+// the archive has been stored successfully.
+type ArchiveUploader func(ctx context.Context, key string, data []byte) error
+
+// ArchiveBatch represents a batch of records to be archived.
+type ArchiveBatch struct {
+	// IDs specifies the ids of the records in the batch.
+	IDs []uuid.UUID
+
+	// Records specifies the records in the batch.
+	Records []any
+}
+
+// ArchiveSource provides the records to be archived.
+type ArchiveSource interface {
+	// Next returns the next batch of up to limit records, ordered by id,
+	// whose ids are greater than the given id.
+	Next(ctx context.Context, after uuid.UUID, limit int) (ArchiveBatch, error)
+
+	// Delete deletes the records with the given ids.
+	Delete(ctx context.Context, ids []uuid.UUID) error
+}
+
+// HandleArchiveHistoryTask archives history records, which are older than the
+// configured retention, to object storage as gzip-compressed NDJSON, and
+// deletes them from the database afterwards.
+//
+// A failure to archive the records of a model does not prevent archiving the
+// remaining models. The errors of all failed models are joined and returned,
+// so that the task is retried.
+func HandleArchiveHistoryTask(ctx context.Context, task *asynq.Task) error {
+	var payload ArchiveHistoryPayload
+	if err := asynqutils.Unmarshal(task.Payload(), &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	upload, err := newArchiveUploader(payload.Target)
+	if err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	errs := make([]error, 0)
+	for _, item := range payload.Retention {
+		// Look up the registry for the actual model type
+		model, ok := registry.ModelRegistry.Get(item.Name)
+		if !ok {
+			logger.Warn("model not found in registry", "name", item.Name)
+			asynqutils.AddSkipped(ctx, 1)
+
+			continue
+		}
+
+		count, err := archiveModel(ctx, upload, payload.Target.Prefix, item, model)
+		if err != nil {
+			// Keep going with the rest of the models to archive.
+			// Batches archived before the failure have already
+			// been deleted.
+			logger.Error("failed to archive records", "name", item.Name, "archived", count, "reason", err)
+			errs = append(errs, fmt.Errorf("%s: %w", item.Name, err))
+
+			continue
+		}
+
+		logger.Info("archived records", "name", item.Name, "count", count)
+		metric := prometheus.MustNewConstMetric(
+			archivedRecordsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			item.Name,
+		)
+		key := metrics.Key(ArchiveHistoryTaskType, item.Name)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}
+
+	return errors.Join(errs...)
+}
+
+// archiveModel archives the records of the given model, which were created
+// before the configured retention.
+func archiveModel(ctx context.Context, upload ArchiveUploader, prefix string, item ArchiveRetentionConfig, model any) (int, error) {
+	now := time.Now()
+	src := &modelArchiveSource{
+		model: model,
+		past:  now.Add(-item.Duration),
+	}
+
+	batchSize := item.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultArchiveBatchSize
+	}
+
+	key := func(batch int) string {
+		name := fmt.Sprintf("%s-%05d.ndjson.gz", now.UTC().Format("20060102T150405Z"), batch)
+
+		return path.Join(prefix, item.Name, name)
+	}
+
+	return ArchiveRecords(ctx, src, upload, key, batchSize)
+}
+
+// ArchiveRecords archives the records provided by the given [ArchiveSource] in
+// batches of up to batchSize records. Each batch is uploaded as a separate
+// gzip-compressed NDJSON archive, whose key is returned by the key function for
+// the zero-based index of the batch. The records of a batch are deleted only
+// after the batch has been uploaded and verified, so that a failed upload
+// leaves the records of the batch and of all remaining batches in place.
+//
+// ArchiveRecords returns the number of records, which have been archived and
+// deleted, even if a later batch fails.
+func ArchiveRecords(ctx context.Context, src ArchiveSource, upload ArchiveUploader, key func(batch int) string, batchSize int) (int, error) {
+	total := 0
+	after := uuid.Nil
+	for batch := 0; ; batch++ {
+		items, err := src.Next(ctx, after, batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		if len(items.IDs) == 0 {
+			return total, nil
+		}
+
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		encoder := json.NewEncoder(gw)
+		for _, record := range items.Records {
+			if err := encoder.Encode(record); err != nil {
+				return total, err
+			}
+		}
+
+		if err := gw.Close(); err != nil {
+			return total, err
+		}
+
+		if err := upload(ctx, key(batch), buf.Bytes()); err != nil {
+			return total, err
+		}
+
+		if err := src.Delete(ctx, items.IDs); err != nil {
+			return total, err
+		}
+
+		total += len(items.IDs)
+		if len(items.IDs) < batchSize {
+			return total, nil
+		}
+		after = items.IDs[len(items.IDs)-1]
+	}
+}
+
+// modelArchiveSource is an [ArchiveSource], which provides the records of a
+// model, which were created before a given time.
+type modelArchiveSource struct {
+	model any
+	past  time.Time
+}
+
+// Next implements the [ArchiveSource] interface.
+func (s *modelArchiveSource) Next(ctx context.Context, after uuid.UUID, limit int) (ArchiveBatch, error) {
+	var batch ArchiveBatch
+
+	// Create a new slice of the model type, which will hold the records
+	// to be archived.
+	modelType := reflect.TypeOf(s.model).Elem()
+	slice := reflect.MakeSlice(reflect.SliceOf(modelType), 0, limit)
+	records := reflect.New(slice.Type())
+	records.Elem().Set(slice)
+
+	err := db.DB.NewSelect().
+		Model(records.Interface()).
+		Where("created_at < ?", s.past).
+		Where("id > ?", after).
+		Order("id").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return batch, err
+	}
+
+	count := records.Elem().Len()
+	batch.IDs = make([]uuid.UUID, 0, count)
+	batch.Records = make([]any, 0, count)
+	for i := range count {
+		record := records.Elem().Index(i)
+		id, ok := record.FieldByName("ID").Interface().(uuid.UUID)
+		if !ok {
+			return batch, fmt.Errorf("model %s has no uuid id", modelType.Name())
+		}
+		batch.IDs = append(batch.IDs, id)
+		batch.Records = append(batch.Records, record.Interface())
+	}
+
+	return batch, nil
+}
+
+// Delete implements the [ArchiveSource] interface.
+func (s *modelArchiveSource) Delete(ctx context.Context, ids []uuid.UUID) error {
+	return dbutils.RunWithWriteTimeout(ctx, db.DB, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model(s.model).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+
+		return err
+	})
+}
+
+// newArchiveUploader returns an [ArchiveUploader] for the given target.
+func newArchiveUploader(target ArchiveTarget) (ArchiveUploader, error) {
+	if target.Bucket == "" {
+		return nil, ErrNoArchiveBucket
+	}
+
+	switch target.Provider {
+	case ArchiveTargetAWS:
+		client, ok := awsclients.S3Clientset.Get(target.AccountID)
+		if !ok {
+			return nil, fmt.Errorf("S3 client not found for account %q", target.AccountID)
+		}
+
+		return newS3ArchiveUploader(client.Client, target.Bucket), nil
+	case ArchiveTargetGCP:
+		client, ok := gcpclients.StorageClientset.Get(target.ProjectID)
+		if !ok {
+			return nil, fmt.Errorf("storage client not found for project %q", target.ProjectID)
+		}
+
+		return newGCSArchiveUploader(client.Client.Bucket(target.Bucket)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownArchiveTarget, target.Provider)
+	}
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(ArchiveHistoryTaskType, asynq.HandlerFunc(HandleArchiveHistoryTask))
+}
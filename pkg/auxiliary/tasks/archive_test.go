@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/gardener/inventory/pkg/auxiliary/tasks"
+)
+
+type archiveRecord struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// fakeArchiveSource is an in-memory [tasks.ArchiveSource].
+type fakeArchiveSource struct {
+	records   []archiveRecord
+	deleteErr error
+	deletes   int
+}
+
+func newFakeArchiveSource(count int) *fakeArchiveSource {
+	records := make([]archiveRecord, 0, count)
+	for i := range count {
+		records = append(records, archiveRecord{
+			ID:   uuid.New(),
+			Name: fmt.Sprintf("record-%d", i),
+		})
+	}
+	slices.SortFunc(records, func(a, b archiveRecord) int {
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return &fakeArchiveSource{records: records}
+}
+
+func (s *fakeArchiveSource) Next(_ context.Context, after uuid.UUID, limit int) (tasks.ArchiveBatch, error) {
+	var batch tasks.ArchiveBatch
+	for _, record := range s.records {
+		if strings.Compare(record.ID.String(), after.String()) <= 0 {
+			continue
+		}
+		if len(batch.IDs) == limit {
+			break
+		}
+		batch.IDs = append(batch.IDs, record.ID)
+		batch.Records = append(batch.Records, record)
+	}
+
+	return batch, nil
+}
+
+func (s *fakeArchiveSource) Delete(_ context.Context, ids []uuid.UUID) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	s.deletes++
+	s.records = slices.DeleteFunc(s.records, func(record archiveRecord) bool {
+		return slices.Contains(ids, record.ID)
+	})
+
+	return nil
+}
+
+// fakeArchiveUploader records the uploaded archives, and fails the upload of
+// the batch with the given index.
+type fakeArchiveUploader struct {
+	failAt   int
+	archives map[string][]archiveRecord
+	keys     []string
+}
+
+func (u *fakeArchiveUploader) upload(_ context.Context, key string, data []byte) error {
+	if len(u.keys) == u.failAt {
+		return errors.New("upload failed")
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(gr)
+	if err != nil {
+		return err
+	}
+
+	records := make([]archiveRecord, 0)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	for decoder.More() {
+		var record archiveRecord
+		if err := decoder.Decode(&record); err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	u.keys = append(u.keys, key)
+	u.archives[key] = records
+
+	return nil
+}
+
+func TestArchiveRecords(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		records       int
+		batchSize     int
+		failUploadAt  int
+		failDelete    bool
+		wantArchived  int
+		wantBatches   int
+		wantRemaining int
+		wantErr       bool
+	}{
+		{
+			desc:         "no records",
+			records:      0,
+			batchSize:    10,
+			failUploadAt: -1,
+		},
+		{
+			desc:         "single partial batch",
+			records:      7,
+			batchSize:    10,
+			failUploadAt: -1,
+			wantArchived: 7,
+			wantBatches:  1,
+		},
+		{
+			desc:         "exact multiple of batch size",
+			records:      20,
+			batchSize:    10,
+			failUploadAt: -1,
+			wantArchived: 20,
+			wantBatches:  2,
+		},
+		{
+			desc:         "multiple batches",
+			records:      25,
+			batchSize:    10,
+			failUploadAt: -1,
+			wantArchived: 25,
+			wantBatches:  3,
+		},
+		{
+			desc:          "upload of second batch fails",
+			records:       25,
+			batchSize:     10,
+			failUploadAt:  1,
+			wantArchived:  10,
+			wantBatches:   1,
+			wantRemaining: 15,
+			wantErr:       true,
+		},
+		{
+			desc:          "delete fails",
+			records:       25,
+			batchSize:     10,
+			failUploadAt:  -1,
+			failDelete:    true,
+			wantBatches:   1,
+			wantRemaining: 25,
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := newFakeArchiveSource(tc.records)
+			if tc.failDelete {
+				src.deleteErr = errors.New("delete failed")
+			}
+			original := slices.Clone(src.records)
+			uploader := &fakeArchiveUploader{
+				failAt:   tc.failUploadAt,
+				archives: make(map[string][]archiveRecord),
+			}
+			key := func(batch int) string {
+				return fmt.Sprintf("archive-%d", batch)
+			}
+
+			archived, err := tasks.ArchiveRecords(t.Context(), src, uploader.upload, key, tc.batchSize)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("want error %t, got %v", tc.wantErr, err)
+			}
+
+			if archived != tc.wantArchived {
+				t.Fatalf("want %d archived records, got %d", tc.wantArchived, archived)
+			}
+
+			if len(uploader.keys) != tc.wantBatches {
+				t.Fatalf("want %d uploaded batches, got %d", tc.wantBatches, len(uploader.keys))
+			}
+
+			if len(src.records) != tc.wantRemaining {
+				t.Fatalf("want %d remaining records, got %d", tc.wantRemaining, len(src.records))
+			}
+
+			// Each batch is archived in order, and records are
+			// archived exactly once.
+			uploaded := make([]archiveRecord, 0)
+			for i, key := range uploader.keys {
+				if key != fmt.Sprintf("archive-%d", i) {
+					t.Fatalf("want key archive-%d, got %s", i, key)
+				}
+				batch := uploader.archives[key]
+				if len(batch) > tc.batchSize {
+					t.Fatalf("batch %s exceeds batch size: %d", key, len(batch))
+				}
+				uploaded = append(uploaded, batch...)
+			}
+			if !slices.Equal(uploaded, original[:len(uploaded)]) {
+				t.Fatal("uploaded records do not match the source records")
+			}
+
+			// Only the records of uploaded batches are deleted.
+			if !slices.Equal(src.records, original[len(original)-tc.wantRemaining:]) {
+				t.Fatal("remaining records do not match the records, which were not archived")
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// newS3ArchiveUploader returns an [ArchiveUploader], which uploads archives to
+// the given AWS S3 bucket.
+func newS3ArchiveUploader(client *s3.Client, bucket string) ArchiveUploader {
+	upload := func(ctx context.Context, key string, data []byte) error {
+		_, err := client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(bucket),
+			Key:         aws.String(key),
+			Body:        bytes.NewReader(data),
+			ContentType: aws.String("application/gzip"),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		out, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		size := aws.ToInt64(out.ContentLength)
+		if size != int64(len(data)) {
+			return fmt.Errorf("%w: s3://%s/%s has size %d, expected %d", ErrArchiveVerificationFailed, bucket, key, size, len(data))
+		}
+
+		return nil
+	}
+
+	return upload
+}
+
+// newGCSArchiveUploader returns an [ArchiveUploader], which uploads archives to
+// the given GCS bucket.
+func newGCSArchiveUploader(bucket *storage.BucketHandle) ArchiveUploader {
+	upload := func(ctx context.Context, key string, data []byte) error {
+		obj := bucket.Object(key)
+		w := obj.NewWriter(ctx)
+		w.ContentType = "application/gzip"
+		if _, err := w.Write(data); err != nil {
+			_ = w.Close()
+
+			return err
+		}
+
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		attrs, err := obj.Attrs(ctx)
+		if err != nil {
+			return err
+		}
+
+		if attrs.Size != int64(len(data)) {
+			return fmt.Errorf("%w: gs://%s/%s has size %d, expected %d", ErrArchiveVerificationFailed, attrs.Bucket, key, attrs.Size, len(data))
+		}
+
+		return nil
+	}
+
+	return upload
+}
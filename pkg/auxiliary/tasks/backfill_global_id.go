@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hibiken/asynq"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/globalid"
+	"github.com/gardener/inventory/pkg/core/registry"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// TaskBackfillGlobalID is the name of the task, which populates the
+	// global ids of the existing rows of the models.
+	TaskBackfillGlobalID = "aux:task:backfill-global-id"
+
+	// DefaultBackfillGlobalIDBatchSize is the default number of rows, which
+	// are updated at once when backfilling global ids.
+	DefaultBackfillGlobalIDBatchSize = 1000
+)
+
+// ErrNoGlobalID is returned when a model does not provide global ids.
+var ErrNoGlobalID = errors.New("model does not provide global ids")
+
+// BackfillGlobalIDPayload represents the payload of the task for backfilling
+// global ids.
+type BackfillGlobalIDPayload struct {
+	// Models specifies the names of the models to backfill. If not
+	// specified, all models registered in [globalid.Registry] are
+	// backfilled.
+	Models []string `yaml:"models" json:"models"`
+
+	// BatchSize specifies the number of rows, which are updated at once.
+	// If not specified, [DefaultBackfillGlobalIDBatchSize] is used.
+	BatchSize int `yaml:"batch_size" json:"batch_size"`
+}
+
+// HandleBackfillGlobalIDTask populates the global ids of the existing rows of
+// the models from their current columns, so that they are usable without
+// waiting for the next collection.
+func HandleBackfillGlobalIDTask(ctx context.Context, task *asynq.Task) error {
+	var payload BackfillGlobalIDPayload
+	if data := task.Payload(); data != nil {
+		if err := asynqutils.Unmarshal(data, &payload); err != nil {
+			return asynqutils.SkipRetry(err)
+		}
+	}
+
+	names := payload.Models
+	if len(names) == 0 {
+		names = GlobalIDModels()
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	for _, name := range names {
+		count, err := BackfillGlobalID(ctx, db.DB, name, payload.BatchSize)
+		if err != nil {
+			// Simply log the error here and keep going with the
+			// rest of the models
+			logger.Error("failed to backfill global ids", "name", name, "updated", count, "reason", err)
+			asynqutils.AddSkipped(ctx, 1)
+
+			continue
+		}
+
+		logger.Info("backfilled global ids", "name", name, "updated", count)
+		asynqutils.AddRows(ctx, count)
+	}
+
+	return nil
+}
+
+// GlobalIDModels returns the sorted names of the models, which provide global
+// ids.
+func GlobalIDModels() []string {
+	names := make([]string, 0, globalid.Registry.Length())
+	_ = globalid.Registry.Range(func(name string, _ globalid.Spec) error {
+		names = append(names, name)
+
+		return nil
+	})
+	sort.Strings(names)
+
+	return names
+}
+
+// BackfillGlobalID populates the global ids of the rows of the given model,
+// which are missing or outdated, and returns the number of updated rows.
+//
+// The rows are updated in batches of the given size, each one in a separate
+// transaction, so that large tables are not locked at once. Rows, which
+// already have the expected global id, are not updated, which makes the
+// backfill safe to run repeatedly.
+func BackfillGlobalID(ctx context.Context, idb bun.IDB, name string, batchSize int) (int64, error) {
+	spec, ok := globalid.Registry.Get(name)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrNoGlobalID, name)
+	}
+
+	model, ok := registry.ModelRegistry.Get(name)
+	if !ok {
+		return 0, fmt.Errorf("model %q not found in registry", name)
+	}
+
+	if batchSize <= 0 {
+		batchSize = DefaultBackfillGlobalIDBatchSize
+	}
+
+	expr := spec.Expr()
+	var total int64
+	for {
+		var count int64
+		err := dbutils.RunWithWriteTimeout(ctx, idb, func(ctx context.Context, tx bun.Tx) error {
+			batch := tx.NewSelect().
+				Model(model).
+				Column("id").
+				Where("? IS DISTINCT FROM ?", bun.Ident(globalid.Column), expr).
+				Limit(batchSize)
+
+			out, err := tx.NewUpdate().
+				Model(model).
+				Set("? = ?", bun.Ident(globalid.Column), expr).
+				Where("id IN (?)", batch).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			count, err = out.RowsAffected()
+
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+
+		total += count
+		if count < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(TaskBackfillGlobalID, asynq.HandlerFunc(HandleBackfillGlobalIDTask))
+}
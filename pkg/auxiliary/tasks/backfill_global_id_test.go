@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	"github.com/gardener/inventory/pkg/auxiliary/tasks"
+	awsmodels "github.com/gardener/inventory/pkg/aws/models"
+	azuremodels "github.com/gardener/inventory/pkg/azure/models"
+	gcpmodels "github.com/gardener/inventory/pkg/gcp/models"
+	openstackmodels "github.com/gardener/inventory/pkg/openstack/models"
+)
+
+func TestGlobalIDModels(t *testing.T) {
+	wanted := []string{
+		awsmodels.InstanceModelName,
+		azuremodels.VirtualMachineModelName,
+		gcpmodels.InstanceModelName,
+		openstackmodels.ServerModelName,
+	}
+
+	got := tasks.GlobalIDModels()
+	if !slices.IsSorted(got) {
+		t.Fatalf("want sorted models, got %v", got)
+	}
+
+	for _, name := range wanted {
+		if !slices.Contains(got, name) {
+			t.Fatalf("want model %s in %v", name, got)
+		}
+	}
+}
+
+func TestBackfillGlobalIDUnknownModel(t *testing.T) {
+	// The model is registered, but does not provide global ids, so the
+	// database is never used.
+	_, err := tasks.BackfillGlobalID(t.Context(), nil, awsmodels.VPCModelName, 0)
+	if !errors.Is(err, tasks.ErrNoGlobalID) {
+		t.Fatalf("want error %v, got %v", tasks.ErrNoGlobalID, err)
+	}
+}
+
+// TestBackfillGlobalID verifies the backfill of global ids against a real
+// database. The test is skipped, unless the test database is configured via
+// [dbtest.EnvDSN].
+func TestBackfillGlobalID(t *testing.T) {
+	testDB := dbtest.New(t)
+
+	ctx := t.Context()
+	instances := []awsmodels.Instance{
+		{InstanceID: "i-missing-1", AccountID: "111111111111"},
+		{InstanceID: "i-missing-2", AccountID: "111111111111"},
+		{InstanceID: "i-missing-3", AccountID: "222222222222"},
+		{InstanceID: "i-outdated", AccountID: "111111111111", GlobalID: "aws:stale"},
+		{InstanceID: "i-current", AccountID: "111111111111", GlobalID: "aws:111111111111:i-current"},
+	}
+	if _, err := testDB.NewInsert().Model(&instances).Returning("id").Exec(ctx); err != nil {
+		t.Fatalf("unable to insert instances: %s", err)
+	}
+
+	// A batch size smaller than the number of rows exercises the batching
+	count, err := tasks.BackfillGlobalID(ctx, testDB, awsmodels.InstanceModelName, 2)
+	if err != nil {
+		t.Fatalf("unable to backfill global ids: %s", err)
+	}
+	if count != 4 {
+		t.Fatalf("want 4 updated rows, got %d", count)
+	}
+
+	var got []awsmodels.Instance
+	if err := testDB.NewSelect().Model(&got).Scan(ctx); err != nil {
+		t.Fatalf("unable to select instances: %s", err)
+	}
+	for _, item := range got {
+		want := "aws:" + item.AccountID + ":" + item.InstanceID
+		if item.GlobalID != want {
+			t.Fatalf("want global id %s, got %s", want, item.GlobalID)
+		}
+	}
+
+	// The backfill is idempotent
+	count, err = tasks.BackfillGlobalID(ctx, testDB, awsmodels.InstanceModelName, 2)
+	if err != nil {
+		t.Fatalf("unable to backfill global ids: %s", err)
+	}
+	if count != 0 {
+		t.Fatalf("want 0 updated rows on second run, got %d", count)
+	}
+}
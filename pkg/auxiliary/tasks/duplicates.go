@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/auxiliary/models"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// DetectDuplicatesTaskType is the name of the task responsible for
+	// detecting resource ids, which are reported under more than one
+	// account, project or subscription.
+	DetectDuplicatesTaskType = "aux:task:detect-duplicates"
+
+	// DefaultDuplicatesWindow is the default window within which resources
+	// are considered when detecting duplicates.
+	DefaultDuplicatesWindow = 24 * time.Hour
+)
+
+// ErrNoIDColumn is an error, which is returned when a duplicate check does
+// not specify the column holding the resource id.
+var ErrNoIDColumn = errors.New("no id column specified")
+
+// DetectDuplicatesPayload represents the payload of the task for detecting
+// duplicate resource ids across scopes.
+type DetectDuplicatesPayload struct {
+	// Window specifies the duration within which resources must have been
+	// collected in order to be considered.
+	Window time.Duration `yaml:"window" json:"window"`
+
+	// Models specifies the models to check.
+	Models []DuplicateCheck `yaml:"models" json:"models"`
+}
+
+// DuplicateCheck specifies a model to check for duplicate resource ids.
+type DuplicateCheck struct {
+	// Name specifies the model name.
+	Name string `yaml:"name" json:"name"`
+
+	// IDColumn specifies the column, which holds the provider id of the
+	// resource, e.g. `instance_id'.
+	IDColumn string `yaml:"id_column" json:"id_column"`
+}
+
+// duplicateRow represents a resource id, which was seen under multiple
+// scopes.
+type duplicateRow struct {
+	ResourceID string    `bun:"resource_id"`
+	Scopes     []string  `bun:"scopes,array"`
+	LastSeen   time.Time `bun:"last_seen"`
+}
+
+// HandleDetectDuplicatesTask detects resource ids, which were collected under
+// more than one account, project or subscription within the configured window,
+// and records them along with the conflicting scopes.
+func HandleDetectDuplicatesTask(ctx context.Context, task *asynq.Task) error {
+	var payload DetectDuplicatesPayload
+	if err := asynqutils.Unmarshal(task.Payload(), &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	window := payload.Window
+	if window <= 0 {
+		window = DefaultDuplicatesWindow
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	for _, item := range payload.Models {
+		count, err := DetectDuplicates(ctx, item, window)
+		if err != nil {
+			// Simply log the error here and keep going with the
+			// rest of the models to check
+			logger.Error("failed to detect duplicates", "name", item.Name, "reason", err)
+
+			continue
+		}
+
+		if count > 0 {
+			logger.Warn("detected duplicate resource ids", "name", item.Name, "count", count)
+		}
+
+		metric := prometheus.MustNewConstMetric(
+			duplicateResourcesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			item.Name,
+		)
+		key := metrics.Key(DetectDuplicatesTaskType, item.Name)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}
+
+	return nil
+}
+
+// DetectDuplicates detects the duplicate resource ids for the given model and
+// replaces the previously recorded duplicates for it. It returns the number of
+// duplicate resource ids found.
+//
+// The scopes, under which the resources are currently stored, are recorded in
+// the [models.ResourceScope] history on each run. A resource id is a duplicate,
+// if it was recorded under more than one scope within the window. This detects
+// both resources stored once per scope, and resources, whose scope is
+// overwritten by the upsert of a collection using other credentials.
+func DetectDuplicates(ctx context.Context, item DuplicateCheck, window time.Duration) (int, error) {
+	model, ok := registry.ModelRegistry.Get(item.Name)
+	if !ok {
+		return 0, fmt.Errorf("model %q not found in registry", item.Name)
+	}
+
+	if item.IDColumn == "" {
+		return 0, ErrNoIDColumn
+	}
+
+	table := db.DB.Table(reflect.TypeOf(model))
+	if !table.HasField(item.IDColumn) {
+		return 0, fmt.Errorf("model %q has no column %q", item.Name, item.IDColumn)
+	}
+
+	scopeColumn := ""
+	for _, col := range scopeColumns {
+		if table.HasField(col) {
+			scopeColumn = col
+			break
+		}
+	}
+
+	if scopeColumn == "" {
+		return 0, fmt.Errorf("model %q is not scoped by an account, project or subscription", item.Name)
+	}
+
+	since := time.Now().Add(-window)
+	count := 0
+	err := dbutils.RunWithWriteTimeout(ctx, db.DB, func(ctx context.Context, tx bun.Tx) error {
+		// Record the current scopes of the resources
+		observed := tx.NewSelect().
+			Model(model).
+			ColumnExpr("? AS model_name", item.Name).
+			ColumnExpr("?TableAlias.? AS resource_id", bun.Ident(item.IDColumn)).
+			ColumnExpr("?TableAlias.? AS scope", bun.Ident(scopeColumn)).
+			ColumnExpr("max(?TableAlias.updated_at) AS last_seen").
+			Where("?TableAlias.updated_at >= ?", since).
+			Where("?TableAlias.? <> ''", bun.Ident(item.IDColumn)).
+			Where("?TableAlias.? <> ''", bun.Ident(scopeColumn)).
+			GroupExpr("?TableAlias.?", bun.Ident(item.IDColumn)).
+			GroupExpr("?TableAlias.?", bun.Ident(scopeColumn))
+
+		_, err := tx.NewRaw(
+			`INSERT INTO "aux_resource_scope" ("model_name", "resource_id", "scope", "last_seen") ? `+
+				`ON CONFLICT ("model_name", "resource_id", "scope") DO UPDATE `+
+				`SET "last_seen" = greatest("aux_resource_scope"."last_seen", EXCLUDED."last_seen"), "updated_at" = now()`,
+			observed,
+		).Exec(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		// Scopes, which were not seen within the window, are no
+		// longer considered.
+		_, err = tx.NewDelete().
+			Model((*models.ResourceScope)(nil)).
+			Where("model_name = ?", item.Name).
+			Where("last_seen < ?", since).
+			Exec(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		rows := make([]duplicateRow, 0)
+		err = tx.NewSelect().
+			Model((*models.ResourceScope)(nil)).
+			Column("resource_id").
+			ColumnExpr("array_agg(DISTINCT scope ORDER BY scope) AS scopes").
+			ColumnExpr("max(last_seen) AS last_seen").
+			Where("model_name = ?", item.Name).
+			Group("resource_id").
+			Having("count(DISTINCT scope) > 1").
+			Scan(ctx, &rows)
+
+		if err != nil {
+			return err
+		}
+
+		duplicates := make([]models.DuplicateResource, 0, len(rows))
+		for _, r := range rows {
+			duplicate := models.DuplicateResource{
+				ModelName:  item.Name,
+				ResourceID: r.ResourceID,
+				Scopes:     r.Scopes,
+				LastSeen:   r.LastSeen,
+			}
+			duplicates = append(duplicates, duplicate)
+		}
+
+		_, err = tx.NewDelete().
+			Model((*models.DuplicateResource)(nil)).
+			Where("model_name = ?", item.Name).
+			Exec(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		count = len(duplicates)
+		if count == 0 {
+			return nil
+		}
+
+		_, err = tx.NewInsert().
+			Model(&duplicates).
+			Returning("id").
+			Exec(ctx)
+
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(DetectDuplicatesTaskType, asynq.HandlerFunc(HandleDetectDuplicatesTask))
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks_test
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
+	"github.com/gardener/inventory/pkg/auxiliary/tasks"
+	awsmodels "github.com/gardener/inventory/pkg/aws/models"
+	"github.com/gardener/inventory/pkg/clients/db"
+)
+
+func TestDetectDuplicatesInvalidCheck(t *testing.T) {
+	oldDB := db.DB
+	db.DB = bun.NewDB(&sql.DB{}, pgdialect.New())
+	t.Cleanup(func() { db.DB = oldDB })
+
+	testCases := []struct {
+		desc    string
+		check   tasks.DuplicateCheck
+		wantErr error
+	}{
+		{
+			desc:  "unknown model",
+			check: tasks.DuplicateCheck{Name: "aws:model:unknown", IDColumn: "instance_id"},
+		},
+		{
+			desc:    "no id column",
+			check:   tasks.DuplicateCheck{Name: awsmodels.InstanceModelName},
+			wantErr: tasks.ErrNoIDColumn,
+		},
+		{
+			desc:  "unknown id column",
+			check: tasks.DuplicateCheck{Name: awsmodels.InstanceModelName, IDColumn: "server_id"},
+		},
+		{
+			desc:  "model without scope column",
+			check: tasks.DuplicateCheck{Name: "aux:model:collection_run", IDColumn: "task_id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tasks.DetectDuplicates(t.Context(), tc.check, time.Hour)
+			if err == nil {
+				t.Fatal("want error, got nil")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestDetectDuplicates detects duplicate AWS instances against a real
+// database. The test is skipped, unless the test database is configured via
+// [dbtest.EnvDSN].
+func TestDetectDuplicates(t *testing.T) {
+	testDB := dbtest.New(t)
+	oldDB := db.DB
+	db.DB = testDB
+	t.Cleanup(func() { db.DB = oldDB })
+
+	ctx := t.Context()
+	check := tasks.DuplicateCheck{
+		Name:     awsmodels.InstanceModelName,
+		IDColumn: "instance_id",
+	}
+
+	instances := []awsmodels.Instance{
+		{InstanceID: "i-shared", AccountID: "111111111111"},
+		{InstanceID: "i-shared", AccountID: "222222222222"},
+		{InstanceID: "i-moved", AccountID: "111111111111"},
+		{InstanceID: "i-single", AccountID: "111111111111"},
+	}
+	if _, err := testDB.NewInsert().Model(&instances).Returning("id").Exec(ctx); err != nil {
+		t.Fatalf("unable to insert instances: %s", err)
+	}
+
+	// wantDuplicates detects the duplicates and checks the recorded
+	// duplicates against the wanted scopes per resource id.
+	wantDuplicates := func(t *testing.T, window time.Duration, wanted map[string][]string) {
+		t.Helper()
+
+		count, err := tasks.DetectDuplicates(ctx, check, window)
+		if err != nil {
+			t.Fatalf("unable to detect duplicates: %s", err)
+		}
+		if count != len(wanted) {
+			t.Fatalf("want %d duplicates, got %d", len(wanted), count)
+		}
+
+		var duplicates []auxmodels.DuplicateResource
+		err = testDB.NewSelect().
+			Model(&duplicates).
+			Where("model_name = ?", check.Name).
+			Scan(ctx)
+		if err != nil {
+			t.Fatalf("unable to select duplicates: %s", err)
+		}
+		if len(duplicates) != len(wanted) {
+			t.Fatalf("want %d recorded duplicates, got %d", len(wanted), len(duplicates))
+		}
+		for _, d := range duplicates {
+			if !slices.Equal(d.Scopes, wanted[d.ResourceID]) {
+				t.Fatalf("want scopes %v for %s, got %v", wanted[d.ResourceID], d.ResourceID, d.Scopes)
+			}
+		}
+	}
+
+	// Resources stored once per scope
+	wantDuplicates(t, time.Hour, map[string][]string{
+		"i-shared": {"111111111111", "222222222222"},
+	})
+
+	// The scope of a resource is overwritten by a later collection,
+	// which leaves a single row for the resource.
+	_, err := testDB.NewUpdate().
+		Model((*awsmodels.Instance)(nil)).
+		Set("account_id = ?", "333333333333").
+		Set("updated_at = ?", time.Now()).
+		Where("instance_id = ?", "i-moved").
+		Exec(ctx)
+	if err != nil {
+		t.Fatalf("unable to update instance: %s", err)
+	}
+
+	wantDuplicates(t, time.Hour, map[string][]string{
+		"i-shared": {"111111111111", "222222222222"},
+		"i-moved":  {"111111111111", "333333333333"},
+	})
+
+	// Scopes, which were not seen within the window, are no longer
+	// considered.
+	_, err = testDB.NewUpdate().
+		Model((*auxmodels.ResourceScope)(nil)).
+		Set("last_seen = ?", time.Now().Add(-2*time.Hour)).
+		Where("resource_id = ?", "i-moved").
+		Where("scope = ?", "111111111111").
+		Exec(ctx)
+	if err != nil {
+		t.Fatalf("unable to update resource scope: %s", err)
+	}
+
+	wantDuplicates(t, time.Hour, map[string][]string{
+		"i-shared": {"111111111111", "222222222222"},
+	})
+}
@@ -6,16 +6,20 @@ package tasks
 
 import (
 	"context"
+	"database/sql"
+	"reflect"
 	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/auxiliary/models"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -57,25 +61,85 @@ func HandleHousekeeperTask(ctx context.Context, task *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
-	// Record each model processed by the housekeeper
-	hkRuns := make([]models.HousekeeperRun, 0)
+	return deleteStaleRecords(ctx, HousekeeperTaskType, payload.Retention)
+}
+
+// retentionItem represents a retention config along with the resolved model
+// and table.
+type retentionItem struct {
+	HousekeeperRetentionConfig
+
+	model any
+	table string
+}
 
+// orderRetentionItems resolves the models of the given retention configs and
+// orders them, so that tables referencing other tables, e.g. link tables, are
+// cleaned up before the tables they reference. Configs for unknown models are
+// skipped.
+func orderRetentionItems(ctx context.Context, items []HousekeeperRetentionConfig) []retentionItem {
 	logger := asynqutils.GetLogger(ctx)
-	for _, item := range payload.Retention {
+	resolved := make([]retentionItem, 0, len(items))
+	tables := make([]string, 0, len(items))
+	for _, item := range items {
 		// Look up the registry for the actual model type
 		model, ok := registry.ModelRegistry.Get(item.Name)
 		if !ok {
 			logger.Warn("model not found in registry", "name", item.Name)
+			asynqutils.AddSkipped(ctx, 1)
 
 			continue
 		}
 
+		table := db.DB.Table(reflect.TypeOf(model)).Name
+		resolved = append(resolved, retentionItem{HousekeeperRetentionConfig: item, model: model, table: table})
+		tables = append(tables, table)
+	}
+
+	foreignKeys, err := dbutils.GetForeignKeys(ctx, db.DB)
+	if err != nil {
+		// Link rows are removed by the cascading foreign keys anyway,
+		// so we can still proceed in the configured order.
+		logger.Warn("failed to get foreign keys, using configured order", "reason", err)
+
+		return resolved
+	}
+
+	byTable := make(map[string][]retentionItem, len(resolved))
+	for _, item := range resolved {
+		byTable[item.table] = append(byTable[item.table], item)
+	}
+
+	result := make([]retentionItem, 0, len(resolved))
+	for _, table := range dbutils.DeletionOrder(tables, foreignKeys) {
+		result = append(result, byTable[table]...)
+		delete(byTable, table)
+	}
+
+	return result
+}
+
+// deleteStaleRecords deletes the records of the given models, which have not
+// been updated within their retention duration, and records the housekeeper
+// runs. Link tables are cleaned up before the tables they reference.
+func deleteStaleRecords(ctx context.Context, taskType string, items []HousekeeperRetentionConfig) error {
+	// Record each model processed by the housekeeper
+	hkRuns := make([]models.HousekeeperRun, 0)
+
+	logger := asynqutils.GetLogger(ctx)
+	for _, item := range orderRetentionItems(ctx, items) {
 		now := time.Now()
 		past := now.Add(-item.Duration)
-		out, err := db.DB.NewDelete().
-			Model(model).
-			Where("date_part('epoch', updated_at) < ?", past.Unix()).
-			Exec(ctx)
+		var out sql.Result
+		err := dbutils.RunWithWriteTimeout(ctx, db.DB, func(ctx context.Context, tx bun.Tx) error {
+			var err error
+			out, err = tx.NewDelete().
+				Model(item.model).
+				Where("date_part('epoch', updated_at) < ?", past.Unix()).
+				Exec(ctx)
+
+			return err
+		})
 
 		completedAt := time.Now()
 		switch err {
@@ -86,7 +150,7 @@ func HandleHousekeeperTask(ctx context.Context, task *asynq.Task) error {
 
 				continue
 			}
-			logger.Info("deleted stale records", "name", item.Name, "count", count)
+			logger.Info("deleted stale records", "name", item.Name, "table", item.table, "count", count)
 			hkRun := models.HousekeeperRun{
 				ModelName:   item.Name,
 				StartedAt:   now,
@@ -101,7 +165,7 @@ func HandleHousekeeperTask(ctx context.Context, task *asynq.Task) error {
 				float64(count),
 				item.Name,
 			)
-			key := metrics.Key(HousekeeperTaskType, item.Name)
+			key := metrics.Key(taskType, item.Name)
 			metrics.DefaultCollector.AddMetric(key, metric)
 		default:
 			// Simply log the error here and keep going with the
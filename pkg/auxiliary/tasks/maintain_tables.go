@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/registry"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+const (
+	// MaintainTablesTaskType is the name of the task responsible for
+	// vacuuming and analyzing high-churn tables.
+	MaintainTablesTaskType = "aux:task:maintain-tables"
+
+	// DefaultMinDeadTuples is the default min number of dead tuples, which
+	// a table must have in order to be vacuumed.
+	DefaultMinDeadTuples = 10000
+
+	// DefaultAutovacuumGracePeriod is the default period after a vacuum of
+	// a table, e.g. by the autovacuum daemon, during which the table is
+	// not vacuumed again.
+	DefaultAutovacuumGracePeriod = time.Hour
+)
+
+// MaintainTablesPayload represents the payload of the task for maintaining
+// tables.
+type MaintainTablesPayload struct {
+	// Tables specifies the models, whose tables are maintained.
+	Tables []MaintainTableConfig `yaml:"tables" json:"tables"`
+
+	// AutovacuumGracePeriod specifies the period after the last vacuum of a
+	// table, during which the table is skipped. If not specified,
+	// [DefaultAutovacuumGracePeriod] is used.
+	AutovacuumGracePeriod time.Duration `yaml:"autovacuum_grace_period" json:"autovacuum_grace_period"`
+}
+
+// MaintainTableConfig represents the maintenance configuration for a given
+// model.
+type MaintainTableConfig struct {
+	// Name specifies the model name.
+	Name string `yaml:"name" json:"name"`
+
+	// MinDeadTuples specifies the min number of dead tuples, which the
+	// table must have in order to be vacuumed. If not specified,
+	// [DefaultMinDeadTuples] is used.
+	MinDeadTuples int64 `yaml:"min_dead_tuples" json:"min_dead_tuples"`
+}
+
+// tableStats represents the statistics of a table, which are used to decide
+// whether the table needs to be vacuumed.
+type tableStats struct {
+	DeadTuples     int64        `bun:"n_dead_tup"`
+	LastVacuum     sql.NullTime `bun:"last_vacuum"`
+	LastAutovacuum sql.NullTime `bun:"last_autovacuum"`
+}
+
+// lastVacuum returns the time of the most recent vacuum of the table, either
+// manual or by the autovacuum daemon.
+func (s tableStats) lastVacuum() time.Time {
+	var last time.Time
+	if s.LastVacuum.Valid {
+		last = s.LastVacuum.Time
+	}
+	if s.LastAutovacuum.Valid && s.LastAutovacuum.Time.After(last) {
+		last = s.LastAutovacuum.Time
+	}
+
+	return last
+}
+
+// HandleMaintainTablesTask vacuums and analyzes the tables of the models
+// specified in the payload.
+//
+// The task is opt-in, and does nothing unless tables are specified in the
+// payload. In order not to fight with the autovacuum daemon, tables which have
+// been vacuumed recently, or which have only a few dead tuples are skipped.
+func HandleMaintainTablesTask(ctx context.Context, task *asynq.Task) error {
+	var payload MaintainTablesPayload
+	if data := task.Payload(); data != nil {
+		if err := asynqutils.Unmarshal(data, &payload); err != nil {
+			return asynqutils.SkipRetry(err)
+		}
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	if len(payload.Tables) == 0 {
+		logger.Info("no tables configured for maintenance")
+
+		return nil
+	}
+
+	gracePeriod := payload.AutovacuumGracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultAutovacuumGracePeriod
+	}
+
+	for _, item := range payload.Tables {
+		// Look up the registry for the actual model type
+		model, ok := registry.ModelRegistry.Get(item.Name)
+		if !ok {
+			logger.Warn("model not found in registry", "name", item.Name)
+			asynqutils.AddSkipped(ctx, 1)
+
+			continue
+		}
+
+		minDeadTuples := item.MinDeadTuples
+		if minDeadTuples <= 0 {
+			minDeadTuples = DefaultMinDeadTuples
+		}
+
+		table := db.DB.Table(reflect.TypeOf(model)).Name
+		var stats tableStats
+		err := db.DB.NewSelect().
+			TableExpr("pg_stat_user_tables").
+			Column("n_dead_tup", "last_vacuum", "last_autovacuum").
+			Where("relname = ?", table).
+			Where("schemaname = current_schema()").
+			Scan(ctx, &stats)
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			logger.Warn("no statistics found for table", "name", item.Name, "table", table)
+			asynqutils.AddSkipped(ctx, 1)
+
+			continue
+		case err != nil:
+			// Simply log the error here and keep going with the
+			// rest of the tables
+			logger.Error("failed to get table statistics", "name", item.Name, "table", table, "reason", err)
+
+			continue
+		}
+
+		if stats.DeadTuples < minDeadTuples {
+			logger.Info("skipping table with few dead tuples", "name", item.Name, "table", table, "dead_tuples", stats.DeadTuples)
+			asynqutils.AddSkipped(ctx, 1)
+
+			continue
+		}
+
+		if lastVacuum := stats.lastVacuum(); time.Since(lastVacuum) < gracePeriod {
+			logger.Info("skipping recently vacuumed table", "name", item.Name, "table", table, "last_vacuum", lastVacuum)
+			asynqutils.AddSkipped(ctx, 1)
+
+			continue
+		}
+
+		// VACUUM cannot be executed within a transaction block
+		start := time.Now()
+		if _, err := db.DB.ExecContext(ctx, "VACUUM (ANALYZE) ?", bun.Ident(table)); err != nil {
+			logger.Error("failed to vacuum table", "name", item.Name, "table", table, "reason", err)
+
+			continue
+		}
+
+		logger.Info(
+			"vacuumed table",
+			"name", item.Name,
+			"table", table,
+			"dead_tuples", stats.DeadTuples,
+			"duration", time.Since(start),
+		)
+	}
+
+	return nil
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(MaintainTablesTaskType, asynq.HandlerFunc(HandleMaintainTablesTask))
+}
@@ -14,16 +14,66 @@ var (
 	// hkDeletedRecordsDesc is the descriptor for a metric, which tracks the
 	// number of deleted resources for models by the housekeeper.
 	hkDeletedRecordsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "housekeeper_deleted_records"),
+		"housekeeper_deleted_records",
 		"Gauge which tracks the number of deleted records by the housekeeper",
 		[]string{"model_name"},
 		nil,
 	)
+
+	// archivedRecordsDesc is the descriptor for a metric, which tracks the
+	// number of archived records for models.
+	archivedRecordsDesc = prometheus.NewDesc(
+		"archived_records",
+		"Gauge which tracks the number of archived records",
+		[]string{"model_name"},
+		nil,
+	)
+
+	// tagViolationsDesc is the descriptor for a metric, which tracks the
+	// number of resources violating the required tag rules.
+	tagViolationsDesc = prometheus.NewDesc(
+		"tag_violations",
+		"Gauge which tracks the number of resources violating the tag rules",
+		[]string{"model_name", "scope"},
+		nil,
+	)
+
+	// duplicateResourcesDesc is the descriptor for a metric, which tracks
+	// the number of resource ids collected under more than one scope.
+	duplicateResourcesDesc = prometheus.NewDesc(
+		"duplicate_resources",
+		"Gauge which tracks the number of resource ids collected under more than one scope",
+		[]string{"model_name"},
+		nil,
+	)
+
+	// tableRowsDesc is the descriptor for a metric, which tracks the exact
+	// number of rows of the tables of the registered models.
+	tableRowsDesc = prometheus.NewDesc(
+		"table_rows",
+		"Gauge which tracks the number of rows of the table of a registered model",
+		[]string{"model", "table"},
+		nil,
+	)
+
+	// orphanedLinksDesc is the descriptor for a metric, which tracks the
+	// number of orphaned link table rows deleted per link model.
+	orphanedLinksDesc = prometheus.NewDesc(
+		"orphaned_links_deleted",
+		"Gauge which tracks the number of deleted orphaned rows of link tables",
+		[]string{"model_name"},
+		nil,
+	)
 )
 
 // init registers the metric descriptors with the [metrics.DefaultCollector]
 func init() {
 	metrics.DefaultCollector.AddDesc(
 		hkDeletedRecordsDesc,
+		archivedRecordsDesc,
+		tagViolationsDesc,
+		duplicateResourcesDesc,
+		tableRowsDesc,
+		orphanedLinksDesc,
 	)
 }
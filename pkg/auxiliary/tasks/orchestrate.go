@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
+	"github.com/gardener/inventory/pkg/core/registry"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+const (
+	// OrchestrateTaskType is the name of the task, which enqueues the tasks
+	// from [registry.TaskGraph] in waves, according to their declared
+	// prerequisites.
+	OrchestrateTaskType = "aux:task:orchestrate"
+
+	// DefaultOrchestratePollInterval is the default interval at which the
+	// orchestration task checks whether the current wave has completed.
+	DefaultOrchestratePollInterval = 30 * time.Second
+)
+
+// OrchestratePayload represents the payload of the orchestration task.
+type OrchestratePayload struct {
+	// Tasks specifies the tasks to enqueue. The prerequisites of the
+	// given tasks are enqueued as well. If empty, all tasks from the graph
+	// are enqueued.
+	Tasks []string `yaml:"tasks" json:"tasks"`
+
+	// PollInterval specifies the interval at which to check whether the
+	// current wave has completed.
+	PollInterval time.Duration `yaml:"poll_interval" json:"poll_interval"`
+
+	// Wave specifies the wave to be enqueued next. It is set by the
+	// orchestration task itself and should not be specified by users.
+	Wave int `yaml:"wave" json:"wave"`
+
+	// Pending specifies the ids of the tasks from the previous wave. It is
+	// set by the orchestration task itself and should not be specified by
+	// users.
+	Pending []string `yaml:"pending" json:"pending"`
+}
+
+// HandleOrchestrateTask enqueues the tasks from [registry.TaskGraph] in waves.
+//
+// The tasks of a wave are enqueued only after the tasks of the previous wave,
+// including the child tasks they have enqueued, are no longer pending, active,
+// scheduled or waiting to be retried. Tasks which have failed permanently do
+// not block the next wave.
+//
+// Instead of blocking a worker while waiting for a wave to complete, the task
+// enqueues itself again to be processed after the configured poll interval.
+func HandleOrchestrateTask(ctx context.Context, task *asynq.Task) error {
+	var payload OrchestratePayload
+	if data := task.Payload(); len(data) > 0 {
+		if err := asynqutils.Unmarshal(data, &payload); err != nil {
+			return asynqutils.SkipRetry(err)
+		}
+	}
+
+	if payload.PollInterval <= 0 {
+		payload.PollInterval = DefaultOrchestratePollInterval
+	}
+
+	waves, err := registry.TaskGraph.Waves(payload.Tasks...)
+	if err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	queue := asynqutils.GetQueueName(ctx)
+
+	unfinished, err := asynqutils.HasUnfinishedTasks(payload.Pending...)
+	if err != nil {
+		return err
+	}
+
+	if unfinished {
+		logger.Info("waiting for previous wave to complete", "wave", payload.Wave-1)
+
+		return enqueueOrchestrateTask(payload, queue)
+	}
+
+	if payload.Wave >= len(waves) {
+		logger.Info("no more waves to enqueue", "waves", len(waves))
+
+		return nil
+	}
+
+	pending := make([]string, 0, len(waves[payload.Wave]))
+	for _, name := range waves[payload.Wave] {
+		info, err := asynqutils.EnqueueChild(ctx, asynq.NewTask(name, nil), asynq.Queue(queue))
+		if err != nil {
+			logger.Error(
+				"failed to enqueue task",
+				"type", name,
+				"wave", payload.Wave,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		logger.Info(
+			"enqueued task",
+			"type", name,
+			"id", info.ID,
+			"queue", info.Queue,
+			"wave", payload.Wave,
+		)
+		pending = append(pending, info.ID)
+	}
+
+	payload.Wave++
+	payload.Pending = pending
+	if payload.Wave >= len(waves) {
+		logger.Info("enqueued all waves", "waves", len(waves))
+
+		return nil
+	}
+
+	return enqueueOrchestrateTask(payload, queue)
+}
+
+// enqueueOrchestrateTask enqueues the orchestration task with the given
+// payload, to be processed after the poll interval.
+func enqueueOrchestrateTask(payload OrchestratePayload, queue string) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	task := asynq.NewTask(OrchestrateTaskType, data)
+	_, err = asynqclient.Client.Enqueue(task, asynq.Queue(queue), asynq.ProcessIn(payload.PollInterval))
+
+	return err
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(OrchestrateTaskType, asynq.HandlerFunc(HandleOrchestrateTask))
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	"github.com/gardener/inventory/pkg/utils/export"
+)
+
+const (
+	// CleanupOrphanedLinksTaskType is the name of the task responsible for
+	// removing orphaned rows from the link tables.
+	CleanupOrphanedLinksTaskType = "aux:task:cleanup-orphaned-links"
+)
+
+// softDeleteColumn is the column, which marks soft-deleted records.
+const softDeleteColumn = "deleted_at"
+
+// NewCleanupOrphanedLinksTask creates a new [asynq.Task] for removing orphaned
+// rows from the link tables.
+func NewCleanupOrphanedLinksTask() *asynq.Task {
+	return asynq.NewTask(CleanupOrphanedLinksTaskType, nil)
+}
+
+// HandleCleanupOrphanedLinksTask removes the rows of the link tables, which
+// refer to records that no longer exist, or which have been soft-deleted.
+//
+// The link tables are discovered from the link models in the
+// [registry.ModelRegistry], so that new link tables are handled automatically.
+func HandleCleanupOrphanedLinksTask(ctx context.Context, _ *asynq.Task) error {
+	logger := asynqutils.GetLogger(ctx)
+	relationships, err := export.Relationships(ctx, db.DB)
+	if err != nil {
+		return err
+	}
+
+	for _, rel := range relationships {
+		count, err := deleteOrphanedLinks(ctx, db.DB, rel)
+		if err != nil {
+			// Simply log the error here and keep going with the
+			// rest of the link tables
+			logger.Error(
+				"failed to delete orphaned links",
+				"name", rel.Name,
+				"table", rel.Table,
+				"reason", err,
+			)
+
+			continue
+		}
+
+		metric := prometheus.MustNewConstMetric(
+			orphanedLinksDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			rel.Name,
+		)
+		key := metrics.Key(CleanupOrphanedLinksTaskType, rel.Name)
+		metrics.DefaultCollector.AddMetric(key, metric)
+
+		logger.Info(
+			"deleted orphaned links",
+			"name", rel.Name,
+			"table", rel.Table,
+			"count", count,
+		)
+	}
+
+	return nil
+}
+
+// deleteOrphanedLinks deletes the rows of the link table of the given
+// relationship, which refer to missing or soft-deleted records, and returns
+// the number of deleted rows.
+func deleteOrphanedLinks(ctx context.Context, db *bun.DB, rel export.Relationship) (int64, error) {
+	source := parentExistsQuery(db, rel.SourceModel, rel.SourceTable, rel.SourceColumn)
+	target := parentExistsQuery(db, rel.TargetModel, rel.TargetTable, rel.TargetColumn)
+	out, err := db.NewDelete().
+		TableExpr("? AS l", bun.Ident(rel.Table)).
+		WhereOr("NOT EXISTS (?)", source).
+		WhereOr("NOT EXISTS (?)", target).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return out.RowsAffected()
+}
+
+// parentExistsQuery returns a query, which selects the record of the given
+// model, which is referred to by the given column of a link table row.
+// Soft-deleted records are not selected, if the model supports soft deletion.
+func parentExistsQuery(db *bun.DB, name, table, column string) *bun.SelectQuery {
+	query := db.NewSelect().
+		TableExpr("? AS p", bun.Ident(table)).
+		ColumnExpr("1").
+		Where("p.id = l.?", bun.Ident(column))
+
+	model, ok := registry.ModelRegistry.Get(name)
+	if ok && db.Table(reflect.TypeOf(model)).HasField(softDeleteColumn) {
+		query = query.Where("p.? IS NULL", bun.Ident(softDeleteColumn))
+	}
+
+	return query
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(CleanupOrphanedLinksTaskType, asynq.HandlerFunc(HandleCleanupOrphanedLinksTask))
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+const (
+	// DeleteStaleObjectsTaskType is the name of the task responsible for
+	// deleting objects, which have not been updated by collectors within a
+	// given TTL.
+	DeleteStaleObjectsTaskType = "aux:task:delete-stale-objects"
+
+	// DefaultStaleObjectsTTL is the default TTL of objects, after which they
+	// are considered stale.
+	DefaultStaleObjectsTTL = 24 * time.Hour
+)
+
+// DeleteStaleObjectsPayload represents the payload of the task for deleting
+// stale objects.
+type DeleteStaleObjectsPayload struct {
+	// Models specifies the names of the models, whose stale objects are
+	// deleted.
+	Models []string `yaml:"models" json:"models"`
+
+	// TTL specifies the duration after the last update of an object, after
+	// which the object is considered stale. If not specified,
+	// [DefaultStaleObjectsTTL] is used.
+	TTL time.Duration `yaml:"ttl" json:"ttl"`
+}
+
+// HandleDeleteStaleObjectsTask deletes the objects of the models specified in
+// the payload, which have not been updated within the TTL.
+//
+// Unlike [HandleHousekeeperTask], which supports a retention duration per
+// model, this task applies the same TTL to all models. In both cases link
+// tables are cleaned up before the tables they reference.
+func HandleDeleteStaleObjectsTask(ctx context.Context, task *asynq.Task) error {
+	var payload DeleteStaleObjectsPayload
+	if err := asynqutils.Unmarshal(task.Payload(), &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	ttl := payload.TTL
+	if ttl <= 0 {
+		ttl = DefaultStaleObjectsTTL
+	}
+
+	items := make([]HousekeeperRetentionConfig, 0, len(payload.Models))
+	for _, name := range payload.Models {
+		item := HousekeeperRetentionConfig{
+			Name:     name,
+			Duration: ttl,
+		}
+		items = append(items, item)
+	}
+
+	return deleteStaleRecords(ctx, DeleteStaleObjectsTaskType, items)
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(DeleteStaleObjectsTaskType, asynq.HandlerFunc(HandleDeleteStaleObjectsTask))
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// CollectTableMetricsTaskType is the name of the task responsible for
+	// reporting the number of rows of the tables of the registered models.
+	CollectTableMetricsTaskType = "aux:task:collect-table-metrics"
+
+	// DefaultTableCountTimeout is the default timeout for counting the rows
+	// of a single table.
+	DefaultTableCountTimeout = 30 * time.Second
+)
+
+// CollectTableMetricsPayload represents the payload of the task for reporting
+// the number of rows of the tables of the registered models.
+type CollectTableMetricsPayload struct {
+	// Timeout specifies the timeout for counting the rows of a single
+	// table. If not specified, [DefaultTableCountTimeout] is used.
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+// HandleCollectTableMetricsTask counts the rows of the tables of all models
+// registered in [registry.ModelRegistry] and reports them as metrics.
+//
+// Each count is executed in a separate transaction with a statement timeout,
+// so that a large table does not hold up the worker. Tables, which cannot be
+// counted within the timeout, are skipped.
+func HandleCollectTableMetricsTask(ctx context.Context, task *asynq.Task) error {
+	var payload CollectTableMetricsPayload
+	if data := task.Payload(); data != nil {
+		if err := asynqutils.Unmarshal(data, &payload); err != nil {
+			return asynqutils.SkipRetry(err)
+		}
+	}
+
+	timeout := payload.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTableCountTimeout
+	}
+
+	// Multiple models may share the same table
+	tableModels := make(map[string]string)
+	walker := func(name string, model any) error {
+		table := db.DB.Table(reflect.TypeOf(model)).Name
+		if _, ok := tableModels[table]; !ok {
+			tableModels[table] = name
+		}
+
+		return nil
+	}
+
+	if err := registry.ModelRegistry.Range(walker); err != nil {
+		return err
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	for table, name := range tableModels {
+		count, err := countTableRows(ctx, table, timeout)
+		if err != nil {
+			// Simply log the error here and keep going with the
+			// rest of the tables
+			logger.Error("failed to count table rows", "name", name, "table", table, "reason", err)
+			asynqutils.AddSkipped(ctx, 1)
+
+			continue
+		}
+
+		metric := prometheus.MustNewConstMetric(
+			tableRowsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			name,
+			table,
+		)
+		key := metrics.Key(CollectTableMetricsTaskType, table)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}
+
+	logger.Info("reported table rows", "tables", len(tableModels))
+
+	return nil
+}
+
+// countTableRows returns the exact number of rows of the given table. The count
+// is aborted, if it does not complete within the given timeout.
+func countTableRows(ctx context.Context, table string, timeout time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The given timeout is used as the read statement timeout, so that it
+	// is enforced on the server side as well, in case the cancellation of
+	// the query does not reach the database.
+	timeouts := dbutils.GetStatementTimeouts(ctx)
+	timeouts.Read = timeout
+	ctx = dbutils.WithStatementTimeouts(ctx, timeouts)
+
+	var count int64
+	err := dbutils.RunWithReadTimeout(ctx, db.DB, func(ctx context.Context, tx bun.Tx) error {
+		return tx.NewSelect().
+			TableExpr("?", bun.Ident(table)).
+			ColumnExpr("count(*)").
+			Scan(ctx, &count)
+	})
+
+	return count, err
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(CollectTableMetricsTaskType, asynq.HandlerFunc(HandleCollectTableMetricsTask))
+}
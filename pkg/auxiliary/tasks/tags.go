@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+
+	"github.com/gardener/inventory/pkg/auxiliary/models"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// ValidateTagsTaskType is the name of the task responsible for
+	// validating the tags of collected resources.
+	ValidateTagsTaskType = "aux:task:validate-tags"
+
+	// tagsColumn is the name of the column, which holds the tags of a
+	// resource.
+	tagsColumn = "tags"
+
+	// tagsRelation is the name of the relation of models, which store
+	// their tags as key/value pairs in a separate table, e.g. the AWS
+	// tags. Models without a tags column or relation are not validated.
+	tagsRelation = "Tags"
+
+	// tagKeyColumn and tagValueColumn are the names of the key and value
+	// columns of the tags relation.
+	tagKeyColumn   = "key"
+	tagValueColumn = "value"
+)
+
+// scopeColumns specifies the columns, which are used to group tag violations
+// by account, project or subscription, in order of preference.
+var scopeColumns = []string{"account_id", "project_id", "subscription_id"}
+
+// ValidateTagsPayload represents the payload of the task for validating tags.
+type ValidateTagsPayload struct {
+	// Rules specifies the required tag rules.
+	Rules []TagRule `yaml:"rules" json:"rules"`
+}
+
+// TagRule represents a rule, which specifies the tag keys required for
+// resources of a provider or model.
+type TagRule struct {
+	// Provider specifies the provider prefix of models to which the rule
+	// applies, e.g. `aws', `gcp', `az' or `openstack'.
+	Provider string `yaml:"provider" json:"provider"`
+
+	// Model specifies the name of the model to which the rule applies. If
+	// set, the rule applies only to the given model, otherwise it applies
+	// to all models of the provider.
+	Model string `yaml:"model" json:"model"`
+
+	// RequiredKeys specifies the tag keys, which must be present.
+	RequiredKeys []string `yaml:"required_keys" json:"required_keys"`
+}
+
+// matches returns true if the rule applies to the model with the given name.
+func (r TagRule) matches(name string) bool {
+	if r.Model != "" {
+		return r.Model == name
+	}
+
+	provider, _, _ := strings.Cut(name, ":")
+
+	return r.Provider != "" && r.Provider == provider
+}
+
+// TaggedResource represents the tags of a single resource.
+type TaggedResource struct {
+	ID    uuid.UUID      `bun:"id"`
+	Scope string         `bun:"scope"`
+	Tags  map[string]any `bun:"tags,type:jsonb"`
+}
+
+// HandleValidateTagsTask validates the tags of collected resources against the
+// configured rules and records the violations.
+func HandleValidateTagsTask(ctx context.Context, task *asynq.Task) error {
+	var payload ValidateTagsPayload
+	if err := asynqutils.Unmarshal(task.Payload(), &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	walker := func(name string, model any) error {
+		required := make([]string, 0)
+		explicit := false
+		for _, rule := range payload.Rules {
+			if !rule.matches(name) {
+				continue
+			}
+			explicit = explicit || rule.Model != ""
+			required = append(required, rule.RequiredKeys...)
+		}
+
+		if len(required) == 0 {
+			return nil
+		}
+
+		query, ok := TagsQuery(db.DB, model)
+		if !ok {
+			if explicit {
+				logger.Warn("model does not support tags", "name", name)
+			}
+
+			return nil
+		}
+
+		slices.Sort(required)
+		required = slices.Compact(required)
+		count, err := validateModelTags(ctx, name, query, required)
+		if err != nil {
+			// Simply log the error here and keep going with the
+			// rest of the models to validate
+			logger.Error("failed to validate tags", "name", name, "reason", err)
+
+			return nil
+		}
+
+		logger.Info("validated tags", "name", name, "violations", count)
+
+		return nil
+	}
+
+	return registry.ModelRegistry.Range(walker)
+}
+
+// TagsQuery returns a query, which selects the id, scope and tags of the
+// resources of the given model into a [TaggedResource]. The tags are either
+// read from the `tags' column of the model, or aggregated from the key/value
+// pairs of the polymorphic `Tags' relation of the model, e.g. the AWS tags.
+//
+// The returned bool is false, if the model does not support tags.
+func TagsQuery(db *bun.DB, model any) (*bun.SelectQuery, bool) {
+	table := db.Table(reflect.TypeOf(model))
+	scopeExpr := "''"
+	for _, col := range scopeColumns {
+		if table.HasField(col) {
+			scopeExpr = "?TableAlias." + col
+
+			break
+		}
+	}
+
+	query := db.NewSelect().
+		Model(model).
+		ColumnExpr("?TableAlias.id").
+		ColumnExpr(scopeExpr + " AS scope")
+
+	if table.HasField(tagsColumn) {
+		query = query.ColumnExpr("?TableAlias.?", bun.Ident(tagsColumn))
+
+		return query, true
+	}
+
+	rel, ok := table.Relations[tagsRelation]
+	if !ok || rel.Type != schema.HasManyRelation {
+		return nil, false
+	}
+
+	joinTable := rel.JoinTable
+	if !joinTable.HasField(tagKeyColumn) || !joinTable.HasField(tagValueColumn) {
+		return nil, false
+	}
+
+	// Join the tags of each resource and aggregate them into a single
+	// JSON object, so that resources without any tags are validated too.
+	conds := make([]string, 0, len(rel.BasePKs)+1)
+	args := make([]any, 0)
+	for i, basePK := range rel.BasePKs {
+		conds = append(conds, "tag.? = ?TableAlias.?")
+		args = append(args, bun.Ident(rel.JoinPKs[i].Name), bun.Ident(basePK.Name))
+	}
+	if rel.PolymorphicField != nil {
+		conds = append(conds, "tag.? = ?")
+		args = append(args, bun.Ident(rel.PolymorphicField.Name), rel.PolymorphicValue)
+	}
+
+	join := "LEFT JOIN ? AS tag ON " + strings.Join(conds, " AND ")
+	query = query.
+		Join(join, append([]any{bun.Ident(joinTable.Name)}, args...)...).
+		ColumnExpr(
+			"coalesce(jsonb_object_agg(tag.?, tag.?) FILTER (WHERE tag.? IS NOT NULL), '{}'::jsonb) AS ?",
+			bun.Ident(tagKeyColumn),
+			bun.Ident(tagValueColumn),
+			bun.Ident(tagKeyColumn),
+			bun.Ident(tagsColumn),
+		).
+		GroupExpr("?TableAlias.id")
+
+	return query, true
+}
+
+// MissingTagKeys returns the required tag keys, which are missing from the
+// given tags.
+func MissingTagKeys(tags map[string]any, required []string) []string {
+	missing := make([]string, 0)
+	for _, key := range required {
+		if _, ok := tags[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing
+}
+
+// validateModelTags validates the tags of the resources selected by the given
+// query, and replaces the previously recorded violations for the model. It
+// returns the number of violations found.
+func validateModelTags(ctx context.Context, name string, query *bun.SelectQuery, required []string) (int, error) {
+	resources := make([]TaggedResource, 0)
+	if err := query.Scan(ctx, &resources); err != nil {
+		return 0, err
+	}
+
+	violations := make([]models.TagViolation, 0)
+	byScope := make(map[string]int)
+	for _, r := range resources {
+		missing := MissingTagKeys(r.Tags, required)
+		if len(missing) == 0 {
+			continue
+		}
+
+		violation := models.TagViolation{
+			ModelName:   name,
+			ResourceID:  r.ID,
+			Scope:       r.Scope,
+			MissingKeys: missing,
+		}
+		violations = append(violations, violation)
+		byScope[r.Scope]++
+	}
+
+	err := dbutils.RunWithWriteTimeout(ctx, db.DB, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model((*models.TagViolation)(nil)).
+			Where("model_name = ?", name).
+			Exec(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if len(violations) == 0 {
+			return nil
+		}
+
+		_, err = tx.NewInsert().
+			Model(&violations).
+			Returning("id").
+			Exec(ctx)
+
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Reset the metrics of the model, so that scopes without violations
+	// are no longer reported.
+	metrics.DefaultCollector.DeleteMetrics(metrics.Key(ValidateTagsTaskType, name) + "/")
+	for scope, count := range byScope {
+		metric := prometheus.MustNewConstMetric(
+			tagViolationsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			name,
+			scope,
+		)
+		key := metrics.Key(ValidateTagsTaskType, name, scope)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}
+
+	return len(violations), nil
+}
+
+func init() {
+	registry.TaskRegistry.MustRegister(ValidateTagsTaskType, asynq.HandlerFunc(HandleValidateTagsTask))
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
+	"github.com/gardener/inventory/pkg/auxiliary/tasks"
+	awsmodels "github.com/gardener/inventory/pkg/aws/models"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	openstackmodels "github.com/gardener/inventory/pkg/openstack/models"
+)
+
+func TestTagsQuery(t *testing.T) {
+	testDB := bun.NewDB(&sql.DB{}, pgdialect.New())
+
+	testCases := []struct {
+		desc     string
+		model    any
+		wantOK   bool
+		wanted   []string
+		unwanted []string
+	}{
+		{
+			desc:   "model with tags column",
+			model:  &openstackmodels.Network{},
+			wantOK: true,
+			wanted: []string{
+				`"network".project_id AS scope`,
+				`"network"."tags"`,
+			},
+			unwanted: []string{"JOIN", "GROUP BY"},
+		},
+		{
+			desc:   "model with polymorphic tags relation",
+			model:  &awsmodels.Instance{},
+			wantOK: true,
+			wanted: []string{
+				`"instance".account_id AS scope`,
+				`LEFT JOIN "aws_tag" AS tag ON tag."resource_id" = "instance"."instance_id" AND tag."account_id" = "instance"."account_id" AND tag."resource_type" = 'instance'`,
+				`coalesce(jsonb_object_agg(tag."key", tag."value") FILTER (WHERE tag."key" IS NOT NULL), '{}'::jsonb) AS "tags"`,
+				`GROUP BY "instance".id`,
+			},
+		},
+		{
+			desc:   "tags relation joined on a non-id column",
+			model:  &awsmodels.Bucket{},
+			wantOK: true,
+			wanted: []string{
+				`tag."resource_id" = "bucket"."name"`,
+				`tag."resource_type" = 'bucket'`,
+			},
+		},
+		{
+			desc:   "model without tags",
+			model:  &awsmodels.Region{},
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			query, ok := tasks.TagsQuery(testDB, tc.model)
+			if ok != tc.wantOK {
+				t.Fatalf("want ok %t, got %t", tc.wantOK, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			sql := query.String()
+			for _, want := range tc.wanted {
+				if !strings.Contains(sql, want) {
+					t.Fatalf("want query containing %q, got %q", want, sql)
+				}
+			}
+			for _, unwanted := range tc.unwanted {
+				if strings.Contains(sql, unwanted) {
+					t.Fatalf("want query without %q, got %q", unwanted, sql)
+				}
+			}
+		})
+	}
+}
+
+func TestMissingTagKeys(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tags     map[string]any
+		required []string
+		wanted   []string
+	}{
+		{
+			desc:     "all keys present",
+			tags:     map[string]any{"owner": "team", "cost-center": "42"},
+			required: []string{"cost-center", "owner"},
+			wanted:   []string{},
+		},
+		{
+			desc:     "some keys missing",
+			tags:     map[string]any{"owner": "team"},
+			required: []string{"cost-center", "owner"},
+			wanted:   []string{"cost-center"},
+		},
+		{
+			desc:     "no tags",
+			tags:     nil,
+			required: []string{"cost-center", "owner"},
+			wanted:   []string{"cost-center", "owner"},
+		},
+		{
+			desc:     "empty tag value counts as present",
+			tags:     map[string]any{"owner": ""},
+			required: []string{"owner"},
+			wanted:   []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tasks.MissingTagKeys(tc.tags, tc.required)
+			if !slices.Equal(got, tc.wanted) {
+				t.Fatalf("want %v, got %v", tc.wanted, got)
+			}
+		})
+	}
+}
+
+// TestValidateTagsAWS validates the tags of AWS instances, which are stored in
+// the AWS tag table, against a real database. The test is skipped, unless the
+// test database is configured via [dbtest.EnvDSN].
+func TestValidateTagsAWS(t *testing.T) {
+	testDB := dbtest.New(t)
+	oldDB := db.DB
+	db.DB = testDB
+	t.Cleanup(func() { db.DB = oldDB })
+
+	ctx := t.Context()
+	instances := []awsmodels.Instance{
+		{InstanceID: "i-tagged", AccountID: "111111111111"},
+		{InstanceID: "i-partial", AccountID: "111111111111"},
+		{InstanceID: "i-untagged", AccountID: "222222222222"},
+	}
+	if _, err := testDB.NewInsert().Model(&instances).Returning("id").Exec(ctx); err != nil {
+		t.Fatalf("unable to insert instances: %s", err)
+	}
+
+	tags := []awsmodels.Tag{
+		{AccountID: "111111111111", ResourceType: awsmodels.TagResourceTypeInstance, ResourceID: "i-tagged", Key: "owner", Value: "team"},
+		{AccountID: "111111111111", ResourceType: awsmodels.TagResourceTypeInstance, ResourceID: "i-tagged", Key: "cost-center", Value: "42"},
+		{AccountID: "111111111111", ResourceType: awsmodels.TagResourceTypeInstance, ResourceID: "i-partial", Key: "owner", Value: "team"},
+		// Same resource id in another account must not be used
+		{AccountID: "222222222222", ResourceType: awsmodels.TagResourceTypeInstance, ResourceID: "i-partial", Key: "cost-center", Value: "42"},
+		// Same resource id of another resource type must not be used
+		{AccountID: "111111111111", ResourceType: awsmodels.TagResourceTypeVPC, ResourceID: "i-partial", Key: "cost-center", Value: "42"},
+	}
+	if _, err := testDB.NewInsert().Model(&tags).Exec(ctx); err != nil {
+		t.Fatalf("unable to insert tags: %s", err)
+	}
+
+	payload := tasks.ValidateTagsPayload{
+		Rules: []tasks.TagRule{
+			{
+				Model:        awsmodels.InstanceModelName,
+				RequiredKeys: []string{"owner", "cost-center"},
+			},
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("unable to marshal payload: %s", err)
+	}
+	task := asynq.NewTask(tasks.ValidateTagsTaskType, data)
+
+	if err := tasks.HandleValidateTagsTask(ctx, task); err != nil {
+		t.Fatalf("unable to validate tags: %s", err)
+	}
+
+	var violations []auxmodels.TagViolation
+	err = testDB.NewSelect().
+		Model(&violations).
+		Where("model_name = ?", awsmodels.InstanceModelName).
+		Order("scope").
+		Scan(ctx)
+	if err != nil {
+		t.Fatalf("unable to select violations: %s", err)
+	}
+
+	wanted := map[string][]string{
+		"111111111111": {"cost-center"},
+		"222222222222": {"cost-center", "owner"},
+	}
+	if len(violations) != len(wanted) {
+		t.Fatalf("want %d violations, got %d", len(wanted), len(violations))
+	}
+	for _, v := range violations {
+		if !slices.Equal(v.MissingKeys, wanted[v.Scope]) {
+			t.Fatalf("want missing keys %v for scope %s, got %v", wanted[v.Scope], v.Scope, v.MissingKeys)
+		}
+	}
+
+	// Fix the violations and validate again. The violations and the
+	// metrics reported by the previous run must be removed.
+	fixed := []awsmodels.Tag{
+		{AccountID: "111111111111", ResourceType: awsmodels.TagResourceTypeInstance, ResourceID: "i-partial", Key: "cost-center", Value: "42"},
+		{AccountID: "222222222222", ResourceType: awsmodels.TagResourceTypeInstance, ResourceID: "i-untagged", Key: "owner", Value: "team"},
+		{AccountID: "222222222222", ResourceType: awsmodels.TagResourceTypeInstance, ResourceID: "i-untagged", Key: "cost-center", Value: "42"},
+	}
+	if _, err := testDB.NewInsert().Model(&fixed).Exec(ctx); err != nil {
+		t.Fatalf("unable to insert tags: %s", err)
+	}
+
+	if err := tasks.HandleValidateTagsTask(ctx, task); err != nil {
+		t.Fatalf("unable to validate tags: %s", err)
+	}
+
+	count, err := testDB.NewSelect().
+		Model((*auxmodels.TagViolation)(nil)).
+		Where("model_name = ?", awsmodels.InstanceModelName).
+		Count(ctx)
+	if err != nil {
+		t.Fatalf("unable to count violations: %s", err)
+	}
+	if count != 0 {
+		t.Fatalf("want no violations, got %d", count)
+	}
+
+	if n := testutil.CollectAndCount(metrics.DefaultCollector, "tag_violations"); n != 0 {
+		t.Fatalf("want no tag violation metrics, got %d", n)
+	}
+}
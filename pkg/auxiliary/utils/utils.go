@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/auxiliary/models"
+)
+
+// LastCollection represents the last successful collection of a given task
+// type for a provider, account and region.
+type LastCollection struct {
+	// TaskName specifies the name of the task.
+	TaskName string `bun:"task_name"`
+
+	// Provider specifies the provider handled by the task.
+	Provider string `bun:"provider"`
+
+	// Account specifies the account, project, subscription or seed the
+	// task is scoped to, if any.
+	Account string `bun:"account"`
+
+	// Region specifies the region the task is scoped to, if any.
+	Region string `bun:"region"`
+
+	// CompletedAt specifies when the last successful collection completed.
+	CompletedAt time.Time `bun:"completed_at"`
+}
+
+// LastCollections returns the last successful collection for each task type,
+// provider, account and region from the persisted collection runs. Only
+// collection tasks of known providers are considered.
+func LastCollections(ctx context.Context, db bun.IDB) ([]LastCollection, error) {
+	items := make([]LastCollection, 0)
+	err := db.NewSelect().
+		Model((*models.CollectionRun)(nil)).
+		Column("task_name", "provider", "account", "region").
+		ColumnExpr("MAX(completed_at) AS completed_at").
+		Where("error = ''").
+		Where("provider != ''").
+		Where("task_name LIKE ?", "%:task:collect-%").
+		Group("task_name", "provider", "account", "region").
+		Order("task_name", "provider", "account", "region").
+		Scan(ctx, &items)
+
+	return items, err
+}
+
+// LastCollectionsByProvider returns the time of the last successful
+// collection per provider.
+func LastCollectionsByProvider(ctx context.Context, db bun.IDB) (map[string]time.Time, error) {
+	items, err := LastCollections(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]time.Time)
+	for _, item := range items {
+		if item.CompletedAt.After(result[item.Provider]) {
+			result[item.Provider] = item.CompletedAt
+		}
+	}
+
+	return result, nil
+}
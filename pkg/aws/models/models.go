@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
+	"github.com/gardener/inventory/pkg/core/globalid"
 	coremodels "github.com/gardener/inventory/pkg/core/models"
 	"github.com/gardener/inventory/pkg/core/registry"
 )
@@ -39,20 +40,69 @@ const (
 	LoadBalancerToRegionModelName           = "aws:model:link_lb_to_region"
 	LoadBalancerToNetworkInterfaceModelName = "aws:model:link_lb_to_net_interface"
 	InstanceToNetworkInterfaceModelName     = "aws:model:link_instance_to_net_interface"
+	ConfigRuleModelName                     = "aws:model:config_rule"
+	ComplianceResultModelName               = "aws:model:compliance_result"
+	InstanceToComplianceResultModelName     = "aws:model:link_instance_to_compliance_result"
+	VPCToComplianceResultModelName          = "aws:model:link_vpc_to_compliance_result"
+	SubnetToComplianceResultModelName       = "aws:model:link_subnet_to_compliance_result"
+	BucketToComplianceResultModelName       = "aws:model:link_bucket_to_compliance_result"
+	SNSTopicModelName                       = "aws:model:sns_topic"
+	SNSSubscriptionModelName                = "aws:model:sns_subscription"
+	SNSSubscriptionToTopicModelName         = "aws:model:link_sns_subscription_to_topic"
+	IAMUserModelName                        = "aws:model:iam_user"
+	IAMAccessKeyModelName                   = "aws:model:iam_access_key"
+	IAMPolicyModelName                      = "aws:model:iam_policy"
+	IAMRoleModelName                        = "aws:model:iam_role"
+	IAMUserToPolicyModelName                = "aws:model:link_iam_user_to_policy"
+	RDSInstanceModelName                    = "aws:model:rds_instance"
+	RDSInstanceToVPCModelName               = "aws:model:link_rds_instance_to_vpc"
+	RDSInstanceToSubnetModelName            = "aws:model:link_rds_instance_to_subnet"
+	TagModelName                            = "aws:model:tag"
+	VolumeModelName                         = "aws:model:volume"
+	VolumeToInstanceModelName               = "aws:model:link_volume_to_instance"
+	LoadBalancerListenerModelName           = "aws:model:lb_listener"
+	TargetGroupModelName                    = "aws:model:target_group"
+	ListenerToLoadBalancerModelName         = "aws:model:link_lb_listener_to_lb"
+	TargetGroupToInstanceModelName          = "aws:model:link_target_group_to_instance"
+	EKSClusterModelName                     = "aws:model:eks_cluster"
+	EKSClusterToVPCModelName                = "aws:model:link_eks_cluster_to_vpc"
+)
+
+// Resource types of the tagged resources, which are stored in the
+// [Tag.ResourceType] field.
+const (
+	TagResourceTypeInstance = "instance"
+	TagResourceTypeVPC      = "vpc"
+	TagResourceTypeSubnet   = "subnet"
+	TagResourceTypeBucket   = "bucket"
 )
 
 // models specifies the mapping between name and model type, which will be
 // registered with [registry.ModelRegistry].
 var models = map[string]any{
-	RegionModelName:           &Region{},
-	AvailabilityZoneModelName: &AvailabilityZone{},
-	VPCModelName:              &VPC{},
-	SubnetModelName:           &Subnet{},
-	InstanceModelName:         &Instance{},
-	ImageModelName:            &Image{},
-	LoadBalancerModelName:     &LoadBalancer{},
-	BucketModelName:           &Bucket{},
-	NetworkInterfaceModelName: &NetworkInterface{},
+	RegionModelName:               &Region{},
+	AvailabilityZoneModelName:     &AvailabilityZone{},
+	VPCModelName:                  &VPC{},
+	SubnetModelName:               &Subnet{},
+	InstanceModelName:             &Instance{},
+	ImageModelName:                &Image{},
+	LoadBalancerModelName:         &LoadBalancer{},
+	BucketModelName:               &Bucket{},
+	NetworkInterfaceModelName:     &NetworkInterface{},
+	ConfigRuleModelName:           &ConfigRule{},
+	ComplianceResultModelName:     &ComplianceResult{},
+	SNSTopicModelName:             &SNSTopic{},
+	SNSSubscriptionModelName:      &SNSSubscription{},
+	IAMUserModelName:              &IAMUser{},
+	IAMAccessKeyModelName:         &IAMAccessKey{},
+	IAMPolicyModelName:            &IAMPolicy{},
+	IAMRoleModelName:              &IAMRole{},
+	RDSInstanceModelName:          &RDSInstance{},
+	TagModelName:                  &Tag{},
+	VolumeModelName:               &Volume{},
+	LoadBalancerListenerModelName: &LoadBalancerListener{},
+	TargetGroupModelName:          &TargetGroup{},
+	EKSClusterModelName:           &EKSCluster{},
 
 	// Link models
 	RegionToAZModelName:                     &RegionToAZ{},
@@ -68,6 +118,18 @@ var models = map[string]any{
 	LoadBalancerToRegionModelName:           &LoadBalancerToRegion{},
 	LoadBalancerToNetworkInterfaceModelName: &LoadBalancerToNetworkInterface{},
 	InstanceToNetworkInterfaceModelName:     &InstanceToNetworkInterface{},
+	InstanceToComplianceResultModelName:     &InstanceToComplianceResult{},
+	VPCToComplianceResultModelName:          &VPCToComplianceResult{},
+	SubnetToComplianceResultModelName:       &SubnetToComplianceResult{},
+	BucketToComplianceResultModelName:       &BucketToComplianceResult{},
+	SNSSubscriptionToTopicModelName:         &SNSSubscriptionToTopic{},
+	IAMUserToPolicyModelName:                &IAMUserToPolicy{},
+	RDSInstanceToVPCModelName:               &RDSInstanceToVPC{},
+	RDSInstanceToSubnetModelName:            &RDSInstanceToSubnet{},
+	VolumeToInstanceModelName:               &VolumeToInstance{},
+	ListenerToLoadBalancerModelName:         &ListenerToLoadBalancer{},
+	TargetGroupToInstanceModelName:          &TargetGroupToInstance{},
+	EKSClusterToVPCModelName:                &EKSClusterToVPC{},
 }
 
 // RegionToAZ represents a link table connecting the Region with AZ.
@@ -194,6 +256,7 @@ type VPC struct {
 	OwnerID    string  `bun:"owner_id,notnull"`
 	RegionName string  `bun:"region_name,notnull"`
 	Region     *Region `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
+	Tags       []*Tag  `bun:"rel:has-many,join:vpc_id=resource_id,join:account_id=account_id,join:type=resource_type,polymorphic:vpc"`
 }
 
 // Subnet represents an AWS Subnet
@@ -214,6 +277,7 @@ type Subnet struct {
 	IPv6CIDR               string            `bun:"ipv6_cidr,nullzero"`
 	VPC                    *VPC              `bun:"rel:has-one,join:vpc_id=vpc_id,join:account_id=account_id"`
 	AvailabilityZone       *AvailabilityZone `bun:"rel:has-one,join:az_id=zone_id,join:account_id=account_id"`
+	Tags                   []*Tag            `bun:"rel:has-many,join:subnet_id=resource_id,join:account_id=account_id,join:type=resource_type,polymorphic:subnet"`
 }
 
 // Instance represents an AWS EC2 instance
@@ -233,10 +297,12 @@ type Instance struct {
 	RegionName   string    `bun:"region_name,notnull"`
 	ImageID      string    `bun:"image_id,notnull"`
 	LaunchTime   time.Time `bun:"launch_time,nullzero"`
+	GlobalID     string    `bun:"global_id,nullzero"`
 	Region       *Region   `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
 	VPC          *VPC      `bun:"rel:has-one,join:vpc_id=vpc_id,join:account_id=account_id"`
 	Subnet       *Subnet   `bun:"rel:has-one,join:subnet_id=subnet_id,join:account_id=account_id"`
 	Image        *Image    `bun:"rel:has-one,join:image_id=image_id,join:account_id=account_id"`
+	Tags         []*Tag    `bun:"rel:has-many,join:instance_id=resource_id,join:account_id=account_id,join:type=resource_type,polymorphic:instance"`
 }
 
 // InstanceToNetworkInterface represents a link table connecting the [Instance]
@@ -323,6 +389,7 @@ type Bucket struct {
 	CreationDate time.Time `bun:"creation_date,notnull"`
 	RegionName   string    `bun:"region_name,notnull"`
 	Region       *Region   `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
+	Tags         []*Tag    `bun:"rel:has-many,join:name=resource_id,join:account_id=account_id,join:type=resource_type,polymorphic:bucket"`
 }
 
 // NetworkInterface represents an AWS Elastic Network Interface (ENI)
@@ -378,9 +445,437 @@ type LoadBalancerToNetworkInterface struct {
 	NetworkInterfaceID uuid.UUID `bun:"ni_id,notnull,type:uuid,unique:l_aws_lb_to_net_interface_key"`
 }
 
+// ConfigRule represents an AWS Config Rule.
+type ConfigRule struct {
+	bun.BaseModel `bun:"table:aws_config_rule"`
+	coremodels.Model
+
+	Name             string  `bun:"name,notnull,unique:aws_config_rule_key"`
+	AccountID        string  `bun:"account_id,notnull,unique:aws_config_rule_key"`
+	RegionName       string  `bun:"region_name,notnull,unique:aws_config_rule_key"`
+	RuleID           string  `bun:"rule_id,notnull"`
+	ARN              string  `bun:"arn,notnull"`
+	Description      string  `bun:"description,notnull"`
+	Owner            string  `bun:"owner,notnull"`
+	SourceIdentifier string  `bun:"source_identifier,notnull"`
+	State            string  `bun:"state,notnull"`
+	Region           *Region `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
+}
+
+// ComplianceResult represents the compliance status of a resource against an
+// AWS Config Rule.
+type ComplianceResult struct {
+	bun.BaseModel `bun:"table:aws_compliance_result"`
+	coremodels.Model
+
+	AccountID      string      `bun:"account_id,notnull,unique:aws_compliance_result_key"`
+	RegionName     string      `bun:"region_name,notnull,unique:aws_compliance_result_key"`
+	RuleName       string      `bun:"rule_name,notnull,unique:aws_compliance_result_key"`
+	ResourceType   string      `bun:"resource_type,notnull,unique:aws_compliance_result_key"`
+	ResourceID     string      `bun:"resource_id,notnull,unique:aws_compliance_result_key"`
+	ComplianceType string      `bun:"compliance_type,notnull"`
+	Annotation     string      `bun:"annotation,notnull"`
+	RecordedAt     time.Time   `bun:"recorded_at,nullzero"`
+	ConfigRule     *ConfigRule `bun:"rel:has-one,join:rule_name=name,join:account_id=account_id,join:region_name=region_name"`
+}
+
+// InstanceToComplianceResult represents a link table connecting the
+// [Instance] with [ComplianceResult].
+type InstanceToComplianceResult struct {
+	bun.BaseModel `bun:"table:l_aws_instance_to_compliance_result"`
+	coremodels.Model
+
+	InstanceID         uuid.UUID `bun:"instance_id,notnull,type:uuid,unique:l_aws_instance_to_compliance_result_key"`
+	ComplianceResultID uuid.UUID `bun:"compliance_result_id,notnull,type:uuid,unique:l_aws_instance_to_compliance_result_key"`
+}
+
+// VPCToComplianceResult represents a link table connecting the [VPC] with
+// [ComplianceResult].
+type VPCToComplianceResult struct {
+	bun.BaseModel `bun:"table:l_aws_vpc_to_compliance_result"`
+	coremodels.Model
+
+	VpcID              uuid.UUID `bun:"vpc_id,notnull,type:uuid,unique:l_aws_vpc_to_compliance_result_key"`
+	ComplianceResultID uuid.UUID `bun:"compliance_result_id,notnull,type:uuid,unique:l_aws_vpc_to_compliance_result_key"`
+}
+
+// SubnetToComplianceResult represents a link table connecting the [Subnet]
+// with [ComplianceResult].
+type SubnetToComplianceResult struct {
+	bun.BaseModel `bun:"table:l_aws_subnet_to_compliance_result"`
+	coremodels.Model
+
+	SubnetID           uuid.UUID `bun:"subnet_id,notnull,type:uuid,unique:l_aws_subnet_to_compliance_result_key"`
+	ComplianceResultID uuid.UUID `bun:"compliance_result_id,notnull,type:uuid,unique:l_aws_subnet_to_compliance_result_key"`
+}
+
+// BucketToComplianceResult represents a link table connecting the [Bucket]
+// with [ComplianceResult].
+type BucketToComplianceResult struct {
+	bun.BaseModel `bun:"table:l_aws_bucket_to_compliance_result"`
+	coremodels.Model
+
+	BucketID           uuid.UUID `bun:"bucket_id,notnull,type:uuid,unique:l_aws_bucket_to_compliance_result_key"`
+	ComplianceResultID uuid.UUID `bun:"compliance_result_id,notnull,type:uuid,unique:l_aws_bucket_to_compliance_result_key"`
+}
+
+// SNSTopic represents an AWS SNS topic.
+type SNSTopic struct {
+	bun.BaseModel `bun:"table:aws_sns_topic"`
+	coremodels.Model
+
+	TopicARN    string  `bun:"topic_arn,notnull,unique"`
+	Name        string  `bun:"name,notnull"`
+	DisplayName string  `bun:"display_name,notnull"`
+	Owner       string  `bun:"owner,notnull"`
+	KMSKeyID    string  `bun:"kms_key_id,notnull"`
+	AccountID   string  `bun:"account_id,notnull"`
+	RegionName  string  `bun:"region_name,notnull"`
+	Region      *Region `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
+}
+
+// SNSSubscription represents an AWS SNS subscription.
+type SNSSubscription struct {
+	bun.BaseModel `bun:"table:aws_sns_subscription"`
+	coremodels.Model
+
+	SubscriptionARN string `bun:"subscription_arn,notnull,unique"`
+	TopicARN        string `bun:"topic_arn,notnull"`
+	Protocol        string `bun:"protocol,notnull"`
+	Endpoint        string `bun:"endpoint,notnull"`
+	Owner           string `bun:"owner,notnull"`
+	AccountID       string `bun:"account_id,notnull"`
+	RegionName      string `bun:"region_name,notnull"`
+
+	// IsPublic specifies whether the subscription delivers messages to
+	// an endpoint outside of AWS, e.g. an HTTP(S) endpoint or an e-mail
+	// address.
+	IsPublic bool `bun:"is_public,notnull"`
+
+	// IsCrossAccount specifies whether the subscription delivers messages
+	// to an AWS resource, which belongs to a different account.
+	IsCrossAccount bool      `bun:"is_cross_account,notnull"`
+	Topic          *SNSTopic `bun:"rel:has-one,join:topic_arn=topic_arn"`
+}
+
+// SNSSubscriptionToTopic represents a link table connecting the
+// [SNSSubscription] with [SNSTopic] models.
+type SNSSubscriptionToTopic struct {
+	bun.BaseModel `bun:"table:l_aws_sns_subscription_to_topic"`
+	coremodels.Model
+
+	SubscriptionID uuid.UUID `bun:"subscription_id,notnull,type:uuid,unique:l_aws_sns_subscription_to_topic_key"`
+	TopicID        uuid.UUID `bun:"topic_id,notnull,type:uuid,unique:l_aws_sns_subscription_to_topic_key"`
+}
+
 // init registers the models with the [registry.ModelRegistry]
 func init() {
 	for k, v := range models {
 		registry.ModelRegistry.MustRegister(k, v)
 	}
+
+	// Register the global ids of the models
+	globalid.Registry.MustRegister(InstanceModelName, globalid.Spec{
+		Provider: "aws",
+		Columns:  []string{"account_id", "instance_id"},
+	})
+}
+
+// IAMUser represents an AWS IAM user.
+type IAMUser struct {
+	bun.BaseModel `bun:"table:aws_iam_user"`
+	coremodels.Model
+
+	UserName         string    `bun:"user_name,notnull"`
+	UserID           string    `bun:"user_id,notnull"`
+	ARN              string    `bun:"arn,notnull,unique"`
+	Path             string    `bun:"path,notnull"`
+	CreateDate       time.Time `bun:"create_date,notnull"`
+	PasswordLastUsed time.Time `bun:"password_last_used,nullzero"`
+	AccountID        string    `bun:"account_id,notnull"`
+	HasConsoleAccess bool      `bun:"has_console_access,notnull"`
+	HasMFA           bool      `bun:"has_mfa,notnull"`
+
+	// ConsoleWithoutMFA specifies whether the user has console access,
+	// but no MFA device.
+	ConsoleWithoutMFA bool `bun:"console_without_mfa,notnull"`
+
+	// PolicyARNs specifies the ARNs of the managed policies attached to
+	// the user.
+	PolicyARNs []string        `bun:"policy_arns,array,notnull"`
+	AccessKeys []*IAMAccessKey `bun:"rel:has-many,join:arn=user_arn"`
+}
+
+// IAMAccessKey represents an access key of an AWS IAM user.
+type IAMAccessKey struct {
+	bun.BaseModel `bun:"table:aws_iam_access_key"`
+	coremodels.Model
+
+	AccessKeyID     string    `bun:"access_key_id,notnull,unique"`
+	UserARN         string    `bun:"user_arn,notnull"`
+	UserName        string    `bun:"user_name,notnull"`
+	Status          string    `bun:"status,notnull"`
+	CreateDate      time.Time `bun:"create_date,notnull"`
+	LastUsedDate    time.Time `bun:"last_used_date,nullzero"`
+	LastUsedService string    `bun:"last_used_service,notnull"`
+	AccountID       string    `bun:"account_id,notnull"`
+
+	// IsStale specifies whether the access key is older than the
+	// configured max age.
+	IsStale bool     `bun:"is_stale,notnull"`
+	User    *IAMUser `bun:"rel:has-one,join:user_arn=arn"`
+}
+
+// IAMPolicy represents an AWS IAM managed policy, which is attached to an IAM
+// user.
+type IAMPolicy struct {
+	bun.BaseModel `bun:"table:aws_iam_policy"`
+	coremodels.Model
+
+	PolicyARN    string `bun:"policy_arn,notnull,unique:aws_iam_policy_key"`
+	PolicyName   string `bun:"policy_name,notnull"`
+	AccountID    string `bun:"account_id,notnull,unique:aws_iam_policy_key"`
+	IsAWSManaged bool   `bun:"is_aws_managed,notnull"`
+}
+
+// IAMRole represents an AWS IAM role.
+type IAMRole struct {
+	bun.BaseModel `bun:"table:aws_iam_role"`
+	coremodels.Model
+
+	RoleName           string    `bun:"role_name,notnull"`
+	RoleID             string    `bun:"role_id,notnull"`
+	ARN                string    `bun:"arn,notnull,unique"`
+	Path               string    `bun:"path,notnull"`
+	CreateDate         time.Time `bun:"create_date,notnull"`
+	Description        string    `bun:"description,notnull"`
+	MaxSessionDuration int       `bun:"max_session_duration,notnull"`
+	AccountID          string    `bun:"account_id,notnull"`
+
+	// TrustPolicy is the JSON trust policy document of the role.
+	TrustPolicy string `bun:"trust_policy,type:jsonb,notnull"`
+
+	// TrustedAccounts specifies the AWS account ids, whose principals are
+	// allowed to assume the role. Any AWS principal is represented as
+	// "*".
+	TrustedAccounts []string `bun:"trusted_accounts,array,notnull"`
+
+	// TrustsExternalAccounts specifies whether principals of accounts
+	// other than the account of the role are allowed to assume the role.
+	TrustsExternalAccounts bool `bun:"trusts_external_accounts,notnull"`
+}
+
+// IAMUserToPolicy represents a link table connecting the [IAMUser] with
+// [IAMPolicy] models.
+type IAMUserToPolicy struct {
+	bun.BaseModel `bun:"table:l_aws_iam_user_to_policy"`
+	coremodels.Model
+
+	UserID   uuid.UUID `bun:"user_id,notnull,type:uuid,unique:l_aws_iam_user_to_policy_key"`
+	PolicyID uuid.UUID `bun:"policy_id,notnull,type:uuid,unique:l_aws_iam_user_to_policy_key"`
+}
+
+// RDSInstance represents an AWS RDS DB instance.
+type RDSInstance struct {
+	bun.BaseModel `bun:"table:aws_rds_instance"`
+	coremodels.Model
+
+	DBInstanceARN        string    `bun:"db_instance_arn,notnull,unique"`
+	DBInstanceIdentifier string    `bun:"db_instance_identifier,notnull"`
+	ResourceID           string    `bun:"resource_id,notnull"`
+	InstanceClass        string    `bun:"instance_class,notnull"`
+	Engine               string    `bun:"engine,notnull"`
+	EngineVersion        string    `bun:"engine_version,notnull"`
+	Status               string    `bun:"status,notnull"`
+	AZ                   string    `bun:"az,notnull"`
+	MultiAZ              bool      `bun:"multi_az,notnull"`
+	PubliclyAccessible   bool      `bun:"publicly_accessible,notnull"`
+	StorageEncrypted     bool      `bun:"storage_encrypted,notnull"`
+	AllocatedStorage     int       `bun:"allocated_storage,notnull"`
+	EndpointAddress      string    `bun:"endpoint_address,notnull"`
+	EndpointPort         int       `bun:"endpoint_port,notnull"`
+	DBSubnetGroup        string    `bun:"db_subnet_group,notnull"`
+	VpcID                string    `bun:"vpc_id,notnull"`
+	AccountID            string    `bun:"account_id,notnull"`
+	RegionName           string    `bun:"region_name,notnull"`
+	InstanceCreatedAt    time.Time `bun:"instance_created_at,nullzero"`
+
+	// SubnetIDs specifies the IDs of the subnets, which are members of
+	// the DB subnet group of the instance.
+	SubnetIDs []string `bun:"subnet_ids,array,notnull"`
+	VPC       *VPC     `bun:"rel:has-one,join:vpc_id=vpc_id,join:account_id=account_id"`
+	Region    *Region  `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
+}
+
+// RDSInstanceToVPC represents a link table connecting the [RDSInstance] with
+// [VPC] models.
+type RDSInstanceToVPC struct {
+	bun.BaseModel `bun:"table:l_aws_rds_instance_to_vpc"`
+	coremodels.Model
+
+	RDSInstanceID uuid.UUID `bun:"rds_instance_id,notnull,type:uuid,unique:l_aws_rds_instance_to_vpc_key"`
+	VPCID         uuid.UUID `bun:"vpc_id,notnull,type:uuid,unique:l_aws_rds_instance_to_vpc_key"`
+}
+
+// RDSInstanceToSubnet represents a link table connecting the [RDSInstance]
+// with [Subnet] models.
+type RDSInstanceToSubnet struct {
+	bun.BaseModel `bun:"table:l_aws_rds_instance_to_subnet"`
+	coremodels.Model
+
+	RDSInstanceID uuid.UUID `bun:"rds_instance_id,notnull,type:uuid,unique:l_aws_rds_instance_to_subnet_key"`
+	SubnetID      uuid.UUID `bun:"subnet_id,notnull,type:uuid,unique:l_aws_rds_instance_to_subnet_key"`
+}
+
+// Tag represents a tag of an AWS resource. The tagged resource is identified by
+// its type, e.g. [TagResourceTypeInstance], and its id within the account.
+//
+// Tags are loaded along with the resources via the polymorphic Tags relation
+// of the tagged models.
+type Tag struct {
+	bun.BaseModel `bun:"table:aws_tag"`
+	coremodels.Model
+
+	AccountID    string `bun:"account_id,notnull,unique:aws_tag_key"`
+	ResourceType string `bun:"resource_type,notnull,unique:aws_tag_key"`
+	ResourceID   string `bun:"resource_id,notnull,unique:aws_tag_key"`
+	Key          string `bun:"key,notnull,unique:aws_tag_key"`
+	Value        string `bun:"value,notnull"`
+	RegionName   string `bun:"region_name,notnull"`
+}
+
+// Volume represents an AWS EBS volume.
+type Volume struct {
+	bun.BaseModel `bun:"table:aws_volume"`
+	coremodels.Model
+
+	VolumeID        string    `bun:"volume_id,notnull,unique:aws_volume_key"`
+	AccountID       string    `bun:"account_id,notnull,unique:aws_volume_key"`
+	RegionName      string    `bun:"region_name,notnull,unique:aws_volume_key"`
+	AZ              string    `bun:"az,notnull"`
+	Size            int       `bun:"size,notnull"`
+	VolumeType      string    `bun:"volume_type,notnull"`
+	IOPS            int       `bun:"iops,notnull"`
+	Encrypted       bool      `bun:"encrypted,notnull"`
+	State           string    `bun:"state,notnull"`
+	SnapshotID      string    `bun:"snapshot_id,notnull"`
+	VolumeCreatedAt time.Time `bun:"volume_created_at,nullzero"`
+
+	// InstanceIDs specifies the IDs of the instances, to which the volume
+	// is attached. Multi-Attach enabled volumes may be attached to more
+	// than one instance.
+	InstanceIDs []string `bun:"instance_ids,array,notnull"`
+	Region      *Region  `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
+}
+
+// VolumeToInstance represents a link table connecting the [Volume] with the
+// [Instance] models, to which the volume is attached.
+type VolumeToInstance struct {
+	bun.BaseModel `bun:"table:l_aws_volume_to_instance"`
+	coremodels.Model
+
+	VolumeID   uuid.UUID `bun:"volume_id,notnull,type:uuid,unique:l_aws_volume_to_instance_key"`
+	InstanceID uuid.UUID `bun:"instance_id,notnull,type:uuid,unique:l_aws_volume_to_instance_key"`
+}
+
+// LoadBalancerListener represents a listener of an AWS ELB v2 load balancer.
+type LoadBalancerListener struct {
+	bun.BaseModel `bun:"table:aws_lb_listener"`
+	coremodels.Model
+
+	ARN             string `bun:"arn,notnull,unique:aws_lb_listener_key"`
+	AccountID       string `bun:"account_id,notnull,unique:aws_lb_listener_key"`
+	LoadBalancerARN string `bun:"lb_arn,notnull"`
+	Port            int    `bun:"port,notnull"`
+	Protocol        string `bun:"protocol,notnull"`
+	RegionName      string `bun:"region_name,notnull"`
+
+	// TargetGroupARNs specifies the ARNs of the target groups, to which
+	// the default actions of the listener forward requests.
+	TargetGroupARNs []string      `bun:"target_group_arns,array,notnull"`
+	LoadBalancer    *LoadBalancer `bun:"rel:has-one,join:lb_arn=arn,join:account_id=account_id"`
+}
+
+// TargetGroup represents a target group of AWS ELB v2 load balancers.
+type TargetGroup struct {
+	bun.BaseModel `bun:"table:aws_target_group"`
+	coremodels.Model
+
+	ARN        string `bun:"arn,notnull,unique:aws_target_group_key"`
+	AccountID  string `bun:"account_id,notnull,unique:aws_target_group_key"`
+	Name       string `bun:"name,notnull"`
+	Protocol   string `bun:"protocol,notnull"`
+	Port       int    `bun:"port,notnull"`
+	TargetType string `bun:"target_type,notnull"`
+	VpcID      string `bun:"vpc_id,notnull"`
+	RegionName string `bun:"region_name,notnull"`
+
+	// LoadBalancerARNs specifies the ARNs of the load balancers, which
+	// route traffic to the target group.
+	LoadBalancerARNs []string `bun:"lb_arns,array,notnull"`
+
+	// TargetIDs specifies the IDs of the registered targets, e.g. the
+	// instance IDs, IP addresses or Lambda ARNs depending on the target
+	// type.
+	TargetIDs []string `bun:"target_ids,array,notnull"`
+	Region    *Region  `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
+}
+
+// ListenerToLoadBalancer represents a link table connecting the
+// [LoadBalancerListener] with [LoadBalancer] models.
+type ListenerToLoadBalancer struct {
+	bun.BaseModel `bun:"table:l_aws_lb_listener_to_lb"`
+	coremodels.Model
+
+	ListenerID     uuid.UUID `bun:"listener_id,notnull,type:uuid,unique:l_aws_lb_listener_to_lb_key"`
+	LoadBalancerID uuid.UUID `bun:"lb_id,notnull,type:uuid,unique:l_aws_lb_listener_to_lb_key"`
+}
+
+// TargetGroupToInstance represents a link table connecting the [TargetGroup]
+// with the [Instance] models, which are registered as targets.
+type TargetGroupToInstance struct {
+	bun.BaseModel `bun:"table:l_aws_target_group_to_instance"`
+	coremodels.Model
+
+	TargetGroupID uuid.UUID `bun:"target_group_id,notnull,type:uuid,unique:l_aws_target_group_to_instance_key"`
+	InstanceID    uuid.UUID `bun:"instance_id,notnull,type:uuid,unique:l_aws_target_group_to_instance_key"`
+}
+
+// EKSCluster represents an AWS EKS cluster.
+type EKSCluster struct {
+	bun.BaseModel `bun:"table:aws_eks_cluster"`
+	coremodels.Model
+
+	Name                  string    `bun:"name,notnull,unique:aws_eks_cluster_key"`
+	AccountID             string    `bun:"account_id,notnull,unique:aws_eks_cluster_key"`
+	RegionName            string    `bun:"region_name,notnull,unique:aws_eks_cluster_key"`
+	ARN                   string    `bun:"arn,notnull"`
+	Version               string    `bun:"version,notnull"`
+	PlatformVersion       string    `bun:"platform_version,notnull"`
+	Status                string    `bun:"status,notnull"`
+	Endpoint              string    `bun:"endpoint,notnull"`
+	EndpointPublicAccess  bool      `bun:"endpoint_public_access,notnull"`
+	EndpointPrivateAccess bool      `bun:"endpoint_private_access,notnull"`
+	VpcID                 string    `bun:"vpc_id,notnull"`
+	ClusterCreatedAt      time.Time `bun:"cluster_created_at,nullzero"`
+
+	// SubnetIDs specifies the IDs of the subnets, in which the control
+	// plane network interfaces of the cluster are placed.
+	SubnetIDs []string `bun:"subnet_ids,array,notnull"`
+
+	// SecurityGroupIDs specifies the IDs of the security groups, which
+	// are associated with the control plane network interfaces.
+	SecurityGroupIDs []string `bun:"security_group_ids,array,notnull"`
+	VPC              *VPC     `bun:"rel:has-one,join:vpc_id=vpc_id,join:account_id=account_id"`
+	Region           *Region  `bun:"rel:has-one,join:region_name=name,join:account_id=account_id"`
+}
+
+// EKSClusterToVPC represents a link table connecting the [EKSCluster] with
+// [VPC] models.
+type EKSClusterToVPC struct {
+	bun.BaseModel `bun:"table:l_aws_eks_cluster_to_vpc"`
+	coremodels.Model
+
+	ClusterID uuid.UUID `bun:"cluster_id,notnull,type:uuid,unique:l_aws_eks_cluster_to_vpc_key"`
+	VPCID     uuid.UUID `bun:"vpc_id,notnull,type:uuid,unique:l_aws_eks_cluster_to_vpc_key"`
 }
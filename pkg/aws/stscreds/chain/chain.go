@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Package chain implements an [aws.CredentialsProvider], which assumes an
+// ordered chain of IAM roles, e.g. hub -> intermediate -> target.
+//
+// The credentials of each hop are used for calling AssumeRole for the next hop,
+// and the credentials of the last hop are the ones used by the API clients.
+//
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/id_roles_terms-and-concepts.html#iam-term-role-chaining
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// ExpiryWindow specifies how long before their expiry the credentials of each
+// hop are refreshed, so that requests are not signed with credentials, which
+// expire while the request is in flight.
+const ExpiryWindow = 5 * time.Minute
+
+// ErrEmptyChain is an error, which is returned when creating a new credentials
+// provider without any hops.
+var ErrEmptyChain = errors.New("empty assume role chain")
+
+// ErrNoRoleARN is an error, which is returned when a hop of the chain does not
+// specify an IAM Role ARN to be assumed.
+var ErrNoRoleARN = errors.New("no IAM Role ARN specified")
+
+// Hop specifies an IAM Role to be assumed as part of the chain.
+type Hop struct {
+	// RoleARN is the IAM Role ARN to assume.
+	RoleARN string
+
+	// RoleSessionName is the name of the session, which uniquely
+	// identifies it.
+	RoleSessionName string
+
+	// ExternalID is the optional external id required by the trust
+	// policy of the role.
+	ExternalID string
+
+	// Duration specifies the expiry duration of the STS credentials.
+	Duration time.Duration
+}
+
+// HopError is an error, which is returned when assuming the role of a given
+// hop of the chain has failed.
+type HopError struct {
+	// Hop is the 1-based index of the hop, which failed.
+	Hop int
+
+	// RoleARN is the IAM Role ARN of the hop, which failed.
+	RoleARN string
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the [error] interface.
+func (e *HopError) Error() string {
+	return fmt.Sprintf("assume role chain hop %d (%s) failed: %s", e.Hop, e.RoleARN, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *HopError) Unwrap() error {
+	return e.Err
+}
+
+// hopProvider wraps the [aws.CredentialsProvider] of a hop, so that failures are
+// attributed to the hop.
+type hopProvider struct {
+	hop      int
+	roleARN  string
+	provider aws.CredentialsProvider
+}
+
+// Retrieve implements the [aws.CredentialsProvider] interface.
+func (p *hopProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	creds, err := p.provider.Retrieve(ctx)
+	if err == nil {
+		return creds, nil
+	}
+
+	// Failures of previous hops are already attributed
+	var hopErr *HopError
+	if errors.As(err, &hopErr) {
+		return aws.Credentials{}, err
+	}
+
+	return aws.Credentials{}, &HopError{Hop: p.hop, RoleARN: p.roleARN, Err: err}
+}
+
+// New creates a new [aws.CredentialsProvider], which assumes the roles of the
+// given hops in order, starting with the credentials of the given base config.
+func New(base aws.Config, hops []Hop) (aws.CredentialsProvider, error) {
+	if len(hops) == 0 {
+		return nil, ErrEmptyChain
+	}
+
+	conf := base.Copy()
+	for i, hop := range hops {
+		if hop.RoleARN == "" {
+			return nil, fmt.Errorf("%w: hop %d", ErrNoRoleARN, i+1)
+		}
+
+		opts := func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = hop.RoleSessionName
+			if hop.Duration > 0 {
+				o.Duration = hop.Duration
+			}
+			if hop.ExternalID != "" {
+				o.ExternalID = aws.String(hop.ExternalID)
+			}
+		}
+
+		provider := &hopProvider{
+			hop:      i + 1,
+			roleARN:  hop.RoleARN,
+			provider: stscreds.NewAssumeRoleProvider(sts.NewFromConfig(conf), hop.RoleARN, opts),
+		}
+		conf = conf.Copy()
+		conf.Credentials = aws.NewCredentialsCache(provider, func(o *aws.CredentialsCacheOptions) {
+			o.ExpiryWindow = ExpiryWindow
+		})
+	}
+
+	return conf.Credentials, nil
+}
@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/gardener/inventory/pkg/aws/stscreds/chain"
+)
+
+// assumeRoleResponse is the response of a successful AssumeRole call, which
+// returns the access key id given as argument.
+const assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>%s</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>2099-01-01T00:00:00Z</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::111111111111:assumed-role/role/session</Arn>
+      <AssumedRoleId>id:session</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`
+
+// accessDeniedResponse is the response of an AssumeRole call, which was
+// denied.
+const accessDeniedResponse = `<ErrorResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <Error>
+    <Type>Sender</Type>
+    <Code>AccessDenied</Code>
+    <Message>not authorized to perform sts:AssumeRole</Message>
+  </Error>
+  <RequestId>request-id</RequestId>
+</ErrorResponse>`
+
+// assumeRoleCall records an AssumeRole call made by the chain.
+type assumeRoleCall struct {
+	// roleARN is the ARN of the role to be assumed.
+	roleARN string
+
+	// accessKeyID is the access key id of the credentials, which were
+	// used for signing the call.
+	accessKeyID string
+}
+
+// fakeSTS is an [aws.HTTPClient], which serves AssumeRole calls. Assuming a
+// role returns credentials with the name of the role as access key id, unless
+// the role is denied.
+type fakeSTS struct {
+	mu     sync.Mutex
+	calls  []assumeRoleCall
+	denied map[string]bool
+}
+
+// Do implements the [aws.HTTPClient] interface.
+func (f *fakeSTS) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	// The Authorization header looks like
+	// AWS4-HMAC-SHA256 Credential=<access-key-id>/<scope>, ...
+	_, credential, _ := strings.Cut(req.Header.Get("Authorization"), "Credential=")
+	accessKeyID, _, _ := strings.Cut(credential, "/")
+
+	roleARN := params.Get("RoleArn")
+	f.mu.Lock()
+	f.calls = append(f.calls, assumeRoleCall{roleARN: roleARN, accessKeyID: accessKeyID})
+	f.mu.Unlock()
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(assumeRoleResponse, roleName(roleARN)))),
+		Request:    req,
+	}
+	if f.denied[roleARN] {
+		resp.StatusCode = http.StatusForbidden
+		resp.Body = io.NopCloser(strings.NewReader(accessDeniedResponse))
+	}
+
+	return resp, nil
+}
+
+// roleName returns the name of the role from the given role ARN.
+func roleName(roleARN string) string {
+	_, name, _ := strings.Cut(roleARN, ":role/")
+
+	return name
+}
+
+// newBaseConfig returns the base config used by the chain, which uses the
+// given fake STS service.
+func newBaseConfig(sts *fakeSTS) aws.Config {
+	return aws.Config{
+		Region:     "eu-west-1",
+		HTTPClient: sts,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			creds := aws.Credentials{
+				AccessKeyID:     "base",
+				SecretAccessKey: "secret",
+			}
+
+			return creds, nil
+		}),
+	}
+}
+
+func TestNewInvalidChain(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		hops    []chain.Hop
+		wantErr error
+	}{
+		{
+			desc:    "no hops",
+			hops:    nil,
+			wantErr: chain.ErrEmptyChain,
+		},
+		{
+			desc: "hop without role arn",
+			hops: []chain.Hop{
+				{RoleARN: "arn:aws:iam::111111111111:role/hub", RoleSessionName: "hub"},
+				{RoleSessionName: "target"},
+			},
+			wantErr: chain.ErrNoRoleARN,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := chain.New(newBaseConfig(&fakeSTS{}), tc.hops)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	hops := []chain.Hop{
+		{RoleARN: "arn:aws:iam::111111111111:role/hub", RoleSessionName: "hub"},
+		{RoleARN: "arn:aws:iam::222222222222:role/intermediate", RoleSessionName: "intermediate"},
+		{RoleARN: "arn:aws:iam::333333333333:role/target", RoleSessionName: "target"},
+	}
+
+	testCases := []struct {
+		desc            string
+		denied          []string
+		wantAccessKeyID string
+		wantCalls       []assumeRoleCall
+		wantHop         int
+	}{
+		{
+			desc:            "all hops assumed in order",
+			wantAccessKeyID: "target",
+			wantCalls: []assumeRoleCall{
+				{roleARN: hops[0].RoleARN, accessKeyID: "base"},
+				{roleARN: hops[1].RoleARN, accessKeyID: "hub"},
+				{roleARN: hops[2].RoleARN, accessKeyID: "intermediate"},
+			},
+		},
+		{
+			desc:   "first hop fails",
+			denied: []string{hops[0].RoleARN},
+			wantCalls: []assumeRoleCall{
+				{roleARN: hops[0].RoleARN, accessKeyID: "base"},
+			},
+			wantHop: 1,
+		},
+		{
+			desc:   "intermediate hop fails",
+			denied: []string{hops[1].RoleARN},
+			wantCalls: []assumeRoleCall{
+				{roleARN: hops[0].RoleARN, accessKeyID: "base"},
+				{roleARN: hops[1].RoleARN, accessKeyID: "hub"},
+			},
+			wantHop: 2,
+		},
+		{
+			desc:   "last hop fails",
+			denied: []string{hops[2].RoleARN},
+			wantCalls: []assumeRoleCall{
+				{roleARN: hops[0].RoleARN, accessKeyID: "base"},
+				{roleARN: hops[1].RoleARN, accessKeyID: "hub"},
+				{roleARN: hops[2].RoleARN, accessKeyID: "intermediate"},
+			},
+			wantHop: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sts := &fakeSTS{denied: make(map[string]bool)}
+			for _, roleARN := range tc.denied {
+				sts.denied[roleARN] = true
+			}
+
+			provider, err := chain.New(newBaseConfig(sts), hops)
+			if err != nil {
+				t.Fatalf("unable to create chain: %s", err)
+			}
+
+			creds, err := provider.Retrieve(t.Context())
+			if !slices.Equal(sts.calls, tc.wantCalls) {
+				t.Fatalf("want calls %v, got %v", tc.wantCalls, sts.calls)
+			}
+
+			if tc.wantHop == 0 {
+				if err != nil {
+					t.Fatalf("unable to retrieve credentials: %s", err)
+				}
+				if creds.AccessKeyID != tc.wantAccessKeyID {
+					t.Fatalf("want access key id %s, got %s", tc.wantAccessKeyID, creds.AccessKeyID)
+				}
+
+				return
+			}
+
+			// The failure is attributed to the failed hop only,
+			// and not to the hops, which depend on it.
+			var hopErr *chain.HopError
+			if !errors.As(err, &hopErr) {
+				t.Fatalf("want hop error, got %v", err)
+			}
+			if hopErr.Hop != tc.wantHop {
+				t.Fatalf("want failed hop %d, got %d", tc.wantHop, hopErr.Hop)
+			}
+			if hopErr.RoleARN != hops[tc.wantHop-1].RoleARN {
+				t.Fatalf("want failed role %s, got %s", hops[tc.wantHop-1].RoleARN, hopErr.RoleARN)
+			}
+			if strings.Count(err.Error(), "assume role chain hop") != 1 {
+				t.Fatalf("want error attributed to a single hop, got %s", err)
+			}
+		})
+	}
+}
@@ -7,19 +7,18 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/models"
-	awsutils "github.com/gardener/inventory/pkg/aws/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -38,6 +37,11 @@ type CollectAvailabilityZonesPayload struct {
 	// AccountID specifies the AWS Account ID, which is associated with a
 	// registered client.
 	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
 }
 
 // NewCollectAvailabilityZonesTask creates a new [asynq.Task] for collecting AWS
@@ -52,7 +56,7 @@ func HandleCollectAvailabilityZonesTask(ctx context.Context, t *asynq.Task) erro
 	// collecting the Availability Zones for all known regions.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectAvailabilityZones(ctx)
+		return enqueueCollectAvailabilityZones(ctx, nil)
 	}
 
 	// Collect the AZs from the specified region using the specified account
@@ -61,6 +65,12 @@ func HandleCollectAvailabilityZonesTask(ctx context.Context, t *asynq.Task) erro
 		return asynqutils.SkipRetry(err)
 	}
 
+	// Enqueue tasks only for the given regions, if no specific region
+	// has been requested.
+	if payload.Region == "" && len(payload.Regions) > 0 {
+		return enqueueCollectAvailabilityZones(ctx, payload.Regions)
+	}
+
 	if payload.Region == "" {
 		return asynqutils.SkipRetry(ErrNoRegion)
 	}
@@ -121,6 +131,7 @@ func collectAvailabilityZones(ctx context.Context, payload CollectAvailabilityZo
 	}
 
 	items := make([]models.AvailabilityZone, 0, len(result.AvailabilityZones))
+	ids := make([]string, 0, len(result.AvailabilityZones))
 	for _, item := range result.AvailabilityZones {
 		item := models.AvailabilityZone{
 			ZoneID:             ptr.StringFromPointer(item.ZoneId),
@@ -134,25 +145,25 @@ func collectAvailabilityZones(ctx context.Context, payload CollectAvailabilityZo
 			NetworkBorderGroup: ptr.StringFromPointer(item.NetworkBorderGroup),
 		}
 		items = append(items, item)
+		ids = append(ids, item.ZoneID)
 	}
 
 	if len(items) == 0 {
-		return nil
+		return reconcileRegion[models.AvailabilityZone](ctx, "availability zones", payload.AccountID, payload.Region, "zone_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (zone_id, account_id) DO UPDATE").
-		Set("zone_type = EXCLUDED.zone_type").
-		Set("name = EXCLUDED.name").
-		Set("opt_in_status = EXCLUDED.opt_in_status").
-		Set("state = EXCLUDED.state").
-		Set("region_name = EXCLUDED.region_name").
-		Set("group_name = EXCLUDED.group_name").
-		Set("network_border_group = EXCLUDED.network_border_group").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (zone_id, account_id) DO UPDATE").
+			Set("zone_type = EXCLUDED.zone_type").
+			Set("name = EXCLUDED.name").
+			Set("opt_in_status = EXCLUDED.opt_in_status").
+			Set("state = EXCLUDED.state").
+			Set("region_name = EXCLUDED.region_name").
+			Set("group_name = EXCLUDED.group_name").
+			Set("network_border_group = EXCLUDED.network_border_group").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -165,11 +176,6 @@ func collectAvailabilityZones(ctx context.Context, payload CollectAvailabilityZo
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated aws availability zones",
 		"region", payload.Region,
@@ -177,21 +183,24 @@ func collectAvailabilityZones(ctx context.Context, payload CollectAvailabilityZo
 		"count", count,
 	)
 
-	return nil
+	return reconcileRegion[models.AvailabilityZone](ctx, "availability zones", payload.AccountID, payload.Region, "zone_id", ids)
 }
 
 // enqueueCollectAvailabilityZones enqueues tasks for collecting AWS AZs for all
 // known regions by specifying the respective payload for each region and
 // account.
-func enqueueCollectAvailabilityZones(ctx context.Context) error {
+//
+// If region names are specified, tasks are enqueued only for these regions.
+func enqueueCollectAvailabilityZones(ctx context.Context, regionNames []string) error {
 	// Get the known regions
-	regions, err := awsutils.GetRegionsFromDB(ctx)
+	regions, err := getRegions(ctx, regionNames)
 	if err != nil {
-		return fmt.Errorf("failed to get regions: %w", err)
+		return err
 	}
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
 
 	// Enqueue a task for each region
 	for _, r := range regions {
@@ -221,29 +230,10 @@ func enqueueCollectAvailabilityZones(ctx context.Context) error {
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectAvailabilityZones, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"region", r.Name,
-				"account_id", r.AccountID,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"region", r.Name,
-			"account_id", r.AccountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectAvailabilityZones, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
@@ -7,20 +7,23 @@ package tasks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/models"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -44,6 +47,9 @@ type CollectBucketsPayload struct {
 }
 
 // HandleCollectBucketsTask handles the collection of AWS S3 Buckets.
+//
+// Only bucket-level metadata is collected. The objects of the buckets are
+// never listed, since listing all objects of large buckets is infeasible.
 func HandleCollectBucketsTask(ctx context.Context, t *asynq.Task) error {
 	// If we were called without a payload, then we will enqueue tasks for
 	// collecting S3 buckets for all configured AWS S3 clients.
@@ -75,7 +81,12 @@ func enqueueCollectBuckets(ctx context.Context) error {
 	}
 
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, awsclients.S3Clientset.Length())
 	err := awsclients.S3Clientset.Range(func(accountID string, _ *awsclients.Client[*s3.Client]) error {
+		if !isAccountSelected(ctx, accountID) {
+			return nil
+		}
+
 		p := CollectBucketsPayload{AccountID: accountID}
 		data, err := json.Marshal(p)
 		if err != nil {
@@ -88,31 +99,18 @@ func enqueueCollectBuckets(ctx context.Context) error {
 			return registry.ErrContinue
 		}
 
-		task := asynq.NewTask(TaskCollectBuckets, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"account_id", accountID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"account_id", accountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectBuckets, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectBuckets collects the S3 buckets for the specified account in the
@@ -137,6 +135,8 @@ func collectBuckets(ctx context.Context, payload CollectBucketsPayload) error {
 	}
 
 	buckets := make([]models.Bucket, 0, len(result.Buckets))
+	tags := make([]models.Tag, 0)
+	taggedIDs := make([]string, 0)
 	for _, bucket := range result.Buckets {
 		location, err := client.Client.GetBucketLocation(
 			ctx,
@@ -172,20 +172,41 @@ func collectBuckets(ctx context.Context, payload CollectBucketsPayload) error {
 			RegionName:   region,
 		}
 		buckets = append(buckets, item)
+
+		bucketTags, err := getBucketTags(ctx, client.Client, item.Name, region)
+		if err != nil {
+			// Tags are not essential, so we simply log the
+			// error here and keep going with the bucket.
+			logger.Error(
+				"could not get bucket tags",
+				"account_id", payload.AccountID,
+				"bucket", item.Name,
+				"reason", err,
+			)
+
+			continue
+		}
+		taggedIDs = append(taggedIDs, item.Name)
+		tags = append(tags, newS3Tags(
+			payload.AccountID,
+			region,
+			models.TagResourceTypeBucket,
+			item.Name,
+			bucketTags,
+		)...)
 	}
 
 	if len(buckets) == 0 {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&buckets).
-		On("CONFLICT (name, account_id) DO UPDATE").
-		Set("creation_date = EXCLUDED.creation_date").
-		Set("region_name = EXCLUDED.region_name").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, buckets, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, account_id) DO UPDATE").
+			Set("creation_date = EXCLUDED.creation_date").
+			Set("region_name = EXCLUDED.region_name").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -197,17 +218,22 @@ func collectBuckets(ctx context.Context, payload CollectBucketsPayload) error {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated aws s3 buckets",
 		"account_id", payload.AccountID,
 		"count", count,
 	)
 
+	if err := UpsertTags(ctx, db.DB, payload.AccountID, models.TagResourceTypeBucket, taggedIDs, tags); err != nil {
+		logger.Error(
+			"could not insert bucket tags into db",
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
 	// Emit metrics by first grouping the items by region
 	groups := utils.GroupBy(buckets, func(item models.Bucket) string {
 		return item.RegionName
@@ -226,3 +252,28 @@ func collectBuckets(ctx context.Context, payload CollectBucketsPayload) error {
 
 	return nil
 }
+
+// getBucketTags returns the tags of the given S3 bucket. Buckets without tags
+// yield no tags, instead of an error.
+func getBucketTags(ctx context.Context, client *s3.Client, bucket, region string) ([]s3types.Tag, error) {
+	out, err := client.GetBucketTagging(
+		ctx,
+		&s3.GetBucketTaggingInput{
+			Bucket: &bucket,
+		},
+		func(o *s3.Options) {
+			o.Region = region
+		},
+	)
+
+	if err != nil {
+		var apiErr interface{ ErrorCode() string }
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchTagSet" {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return out.TagSet, nil
+}
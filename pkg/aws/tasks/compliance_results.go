@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
+	"github.com/aws/aws-sdk-go-v2/service/configservice/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectComplianceResults is the name of the task for collecting
+	// the AWS Config compliance results of resources.
+	TaskCollectComplianceResults = "aws:task:collect-compliance-results"
+
+	// complianceResultsPageSize is the max number of evaluation results
+	// returned in a single page.
+	complianceResultsPageSize = 100
+)
+
+// CollectComplianceResultsPayload is the payload, which is used for
+// collecting AWS Config compliance results.
+type CollectComplianceResultsPayload struct {
+	// Region is the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+}
+
+// NewCollectComplianceResultsTask creates a new [asynq.Task] for collecting
+// AWS Config compliance results, without specifying a payload.
+func NewCollectComplianceResultsTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectComplianceResults, nil)
+}
+
+// HandleCollectComplianceResultsTask handles the task for collecting AWS
+// Config compliance results.
+func HandleCollectComplianceResultsTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting the compliance results for all known regions.
+	data := t.Payload()
+	if data == nil {
+		newPayload := func(region, accountID string) any {
+			return CollectComplianceResultsPayload{Region: region, AccountID: accountID}
+		}
+
+		return enqueueOptionalServiceTasks(ctx, TaskCollectComplianceResults, awsclients.ConfigServiceClientset.Exists, newPayload)
+	}
+
+	var payload CollectComplianceResultsPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	return collectComplianceResults(ctx, payload)
+}
+
+// listEvaluationResults returns the evaluation results of the given AWS
+// Config Rule.
+func listEvaluationResults(ctx context.Context, client *configservice.Client, region string, ruleName string) ([]types.EvaluationResult, error) {
+	paginator := configservice.NewGetComplianceDetailsByConfigRulePaginator(
+		client,
+		&configservice.GetComplianceDetailsByConfigRuleInput{
+			ConfigRuleName: &ruleName,
+		},
+		func(params *configservice.GetComplianceDetailsByConfigRulePaginatorOptions) {
+			params.Limit = complianceResultsPageSize
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]types.EvaluationResult, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(
+			ctx,
+			func(o *configservice.Options) {
+				o.Region = region
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		items = append(items, page.EvaluationResults...)
+	}
+
+	return items, nil
+}
+
+// collectComplianceResults collects the AWS Config compliance results for
+// the specified region and using the client associated with the given account
+// id from the payload.
+func collectComplianceResults(ctx context.Context, payload CollectComplianceResultsPayload) error {
+	client, ok := awsclients.ConfigServiceClientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	enabled, err := isConfigServiceEnabled(ctx, client.Client, payload.Region)
+	if err != nil {
+		logger.Error(
+			"could not describe configuration recorders",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	if !enabled {
+		logger.Info(
+			"AWS Config is not enabled, skipping",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+		)
+		asynqutils.AddSkipped(ctx, 1)
+
+		return nil
+	}
+
+	logger.Info(
+		"collecting AWS Config compliance results",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+	)
+
+	rules, err := listConfigRules(ctx, client.Client, payload.Region)
+	if err != nil {
+		logger.Error(
+			"could not describe config rules",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	results := make([]models.ComplianceResult, 0)
+	for _, rule := range rules {
+		ruleName := ptr.StringFromPointer(rule.ConfigRuleName)
+		items, err := listEvaluationResults(ctx, client.Client, payload.Region, ruleName)
+		if err != nil {
+			logger.Error(
+				"could not get compliance details",
+				"region", payload.Region,
+				"account_id", payload.AccountID,
+				"rule_name", ruleName,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		nonCompliant := 0
+		for _, r := range items {
+			item := models.ComplianceResult{
+				AccountID:      payload.AccountID,
+				RegionName:     payload.Region,
+				RuleName:       ruleName,
+				ComplianceType: string(r.ComplianceType),
+				Annotation:     ptr.StringFromPointer(r.Annotation),
+				RecordedAt:     ptr.Value(r.ResultRecordedTime, time.Time{}),
+			}
+			if r.EvaluationResultIdentifier != nil && r.EvaluationResultIdentifier.EvaluationResultQualifier != nil {
+				qualifier := r.EvaluationResultIdentifier.EvaluationResultQualifier
+				item.ResourceType = ptr.StringFromPointer(qualifier.ResourceType)
+				item.ResourceID = ptr.StringFromPointer(qualifier.ResourceId)
+			}
+			results = append(results, item)
+
+			if r.ComplianceType == types.ComplianceTypeNonCompliant {
+				nonCompliant++
+			}
+		}
+
+		metric := prometheus.MustNewConstMetric(
+			nonCompliantResourcesDesc,
+			prometheus.GaugeValue,
+			float64(nonCompliant),
+			payload.AccountID,
+			payload.Region,
+			ruleName,
+		)
+		key := metrics.Key(TaskCollectComplianceResults, payload.AccountID, payload.Region, ruleName)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db.DB, results, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (account_id, region_name, rule_name, resource_type, resource_id) DO UPDATE").
+			Set("compliance_type = EXCLUDED.compliance_type").
+			Set("annotation = EXCLUDED.annotation").
+			Set("recorded_at = EXCLUDED.recorded_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert aws compliance results into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws compliance results",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+	)
+
+	return nil
+}
@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
+	"github.com/aws/aws-sdk-go-v2/service/configservice/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsutils "github.com/gardener/inventory/pkg/aws/utils"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectConfigRules is the name of the task for collecting AWS
+	// Config Rules.
+	TaskCollectConfigRules = "aws:task:collect-config-rules"
+)
+
+// CollectConfigRulesPayload is the payload, which is used for collecting AWS
+// Config Rules.
+type CollectConfigRulesPayload struct {
+	// Region is the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+}
+
+// NewCollectConfigRulesTask creates a new [asynq.Task] for collecting AWS
+// Config Rules, without specifying a payload.
+func NewCollectConfigRulesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectConfigRules, nil)
+}
+
+// HandleCollectConfigRulesTask handles the task for collecting AWS Config
+// Rules.
+func HandleCollectConfigRulesTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting the Config Rules for all known regions.
+	data := t.Payload()
+	if data == nil {
+		newPayload := func(region, accountID string) any {
+			return CollectConfigRulesPayload{Region: region, AccountID: accountID}
+		}
+
+		return enqueueOptionalServiceTasks(ctx, TaskCollectConfigRules, awsclients.ConfigServiceClientset.Exists, newPayload)
+	}
+
+	var payload CollectConfigRulesPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	return collectConfigRules(ctx, payload)
+}
+
+// enqueueOptionalServiceTasks enqueues tasks of the given type for each known
+// region of the accounts, for which clientExists reports a configured client of
+// an optional service, e.g. AWS Config. The payload of each task is created by
+// the given newPayload function.
+func enqueueOptionalServiceTasks(ctx context.Context, taskType string, clientExists func(accountID string) bool, newPayload func(region, accountID string) any) error {
+	regions, err := awsutils.GetRegionsFromDB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get regions: %w", err)
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	queue := asynqutils.GetQueueName(ctx)
+	selected := selectRegions(ctx, regions)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(selected))
+	for _, r := range selected {
+		// The service is optional, so we simply skip accounts, which
+		// don't have a client configured.
+		if !clientExists(r.AccountID) {
+			continue
+		}
+
+		data, err := json.Marshal(newPayload(r.Name, r.AccountID))
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload",
+				"type", taskType,
+				"region", r.Name,
+				"account_id", r.AccountID,
+				"reason", err,
+			)
+
+			continue
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(taskType, data)
+		})
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// isConfigServiceEnabled returns true, if AWS Config has at least one
+// configuration recorder in the given region.
+func isConfigServiceEnabled(ctx context.Context, client *configservice.Client, region string) (bool, error) {
+	out, err := client.DescribeConfigurationRecorderStatus(
+		ctx,
+		&configservice.DescribeConfigurationRecorderStatusInput{},
+		func(o *configservice.Options) {
+			o.Region = region
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return len(out.ConfigurationRecordersStatus) > 0, nil
+}
+
+// listConfigRules returns the AWS Config Rules from the given region.
+func listConfigRules(ctx context.Context, client *configservice.Client, region string) ([]types.ConfigRule, error) {
+	paginator := configservice.NewDescribeConfigRulesPaginator(
+		client,
+		&configservice.DescribeConfigRulesInput{},
+		func(params *configservice.DescribeConfigRulesPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]types.ConfigRule, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(
+			ctx,
+			func(o *configservice.Options) {
+				o.Region = region
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		items = append(items, page.ConfigRules...)
+	}
+
+	return items, nil
+}
+
+// collectConfigRules collects the AWS Config Rules for the specified region
+// and using the client associated with the given account id from the payload.
+func collectConfigRules(ctx context.Context, payload CollectConfigRulesPayload) error {
+	client, ok := awsclients.ConfigServiceClientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	enabled, err := isConfigServiceEnabled(ctx, client.Client, payload.Region)
+	if err != nil {
+		logger.Error(
+			"could not describe configuration recorders",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	if !enabled {
+		logger.Info(
+			"AWS Config is not enabled, skipping",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+		)
+		asynqutils.AddSkipped(ctx, 1)
+
+		return nil
+	}
+
+	logger.Info(
+		"collecting AWS Config Rules",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+	)
+
+	items, err := listConfigRules(ctx, client.Client, payload.Region)
+	if err != nil {
+		logger.Error(
+			"could not describe config rules",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	// Emit metrics
+	metric := prometheus.MustNewConstMetric(
+		configRulesDesc,
+		prometheus.GaugeValue,
+		float64(len(items)),
+		payload.AccountID,
+		payload.Region,
+	)
+	key := metrics.Key(TaskCollectConfigRules, payload.AccountID, payload.Region)
+	metrics.DefaultCollector.AddMetric(key, metric)
+
+	if len(items) == 0 {
+		return reconcileRegion[models.ConfigRule](ctx, "config rules", payload.AccountID, payload.Region, "name", nil)
+	}
+
+	rules := make([]models.ConfigRule, 0, len(items))
+	ids := make([]string, 0, len(items))
+	for _, r := range items {
+		item := models.ConfigRule{
+			Name:        ptr.StringFromPointer(r.ConfigRuleName),
+			AccountID:   payload.AccountID,
+			RegionName:  payload.Region,
+			RuleID:      ptr.StringFromPointer(r.ConfigRuleId),
+			ARN:         ptr.StringFromPointer(r.ConfigRuleArn),
+			Description: ptr.StringFromPointer(r.Description),
+			State:       string(r.ConfigRuleState),
+		}
+		if r.Source != nil {
+			item.Owner = string(r.Source.Owner)
+			item.SourceIdentifier = ptr.StringFromPointer(r.Source.SourceIdentifier)
+		}
+		rules = append(rules, item)
+		ids = append(ids, item.Name)
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db.DB, rules, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, account_id, region_name) DO UPDATE").
+			Set("rule_id = EXCLUDED.rule_id").
+			Set("arn = EXCLUDED.arn").
+			Set("description = EXCLUDED.description").
+			Set("owner = EXCLUDED.owner").
+			Set("source_identifier = EXCLUDED.source_identifier").
+			Set("state = EXCLUDED.state").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert aws config rules into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws config rules",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+	)
+
+	return reconcileRegion[models.ConfigRule](ctx, "config rules", payload.AccountID, payload.Region, "name", ids)
+}
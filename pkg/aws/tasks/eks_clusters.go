@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/constants"
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectEKSClusters is the name of the task for collecting AWS
+	// EKS clusters.
+	TaskCollectEKSClusters = "aws:task:collect-eks-clusters"
+)
+
+// CollectEKSClustersPayload is the payload, which is used for collecting AWS
+// EKS clusters.
+type CollectEKSClustersPayload struct {
+	// Region is the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+}
+
+// NewCollectEKSClustersTask creates a new [asynq.Task] for collecting AWS EKS
+// clusters, without specifying a payload.
+func NewCollectEKSClustersTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectEKSClusters, nil)
+}
+
+// HandleCollectEKSClustersTask handles the task for collecting AWS EKS
+// clusters.
+func HandleCollectEKSClustersTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting the EKS clusters for all known regions.
+	data := t.Payload()
+	if data == nil {
+		newPayload := func(region, accountID string) any {
+			return CollectEKSClustersPayload{Region: region, AccountID: accountID}
+		}
+
+		return enqueueOptionalServiceTasks(ctx, TaskCollectEKSClusters, awsclients.EKSClientset.Exists, newPayload)
+	}
+
+	var payload CollectEKSClustersPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	return collectEKSClusters(ctx, payload)
+}
+
+// listEKSClusters returns the EKS clusters from the given region.
+func listEKSClusters(ctx context.Context, client *eks.Client, region string) ([]types.Cluster, error) {
+	setRegion := func(o *eks.Options) {
+		o.Region = region
+	}
+
+	paginator := eks.NewListClustersPaginator(
+		client,
+		&eks.ListClustersInput{},
+		func(params *eks.ListClustersPaginatorOptions) {
+			params.Limit = int32(constants.PageSize)
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	names := make([]string, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, setRegion)
+		if err != nil {
+			return nil, err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		names = append(names, page.Clusters...)
+	}
+
+	// The list API returns only the cluster names, so we need to describe
+	// each cluster separately in order to get its details.
+	items := make([]types.Cluster, 0, len(names))
+	for _, name := range names {
+		out, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: &name}, setRegion)
+		if err != nil {
+			return nil, err
+		}
+
+		if out.Cluster != nil {
+			items = append(items, *out.Cluster)
+		}
+	}
+
+	return items, nil
+}
+
+// collectEKSClusters collects the AWS EKS clusters for the specified region
+// and using the client associated with the given account id from the payload.
+func collectEKSClusters(ctx context.Context, payload CollectEKSClustersPayload) error {
+	client, ok := awsclients.EKSClientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			eksClustersDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+			payload.Region,
+		)
+		key := metrics.Key(TaskCollectEKSClusters, payload.AccountID, payload.Region)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info(
+		"collecting AWS EKS clusters",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+	)
+
+	clusters, err := listEKSClusters(ctx, client.Client, payload.Region)
+	if err != nil {
+		logger.Error(
+			"could not list eks clusters",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	if len(clusters) == 0 {
+		return reconcileRegion[models.EKSCluster](ctx, "eks clusters", payload.AccountID, payload.Region, "name", nil)
+	}
+
+	items := make([]models.EKSCluster, 0, len(clusters))
+	ids := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		vpcConfig := ptr.Value(cluster.ResourcesVpcConfig, types.VpcConfigResponse{})
+		subnetIDs := vpcConfig.SubnetIds
+		if subnetIDs == nil {
+			subnetIDs = []string{}
+		}
+
+		// The cluster security group is created by EKS and is not part
+		// of the security groups specified by the user.
+		securityGroupIDs := make([]string, 0, len(vpcConfig.SecurityGroupIds)+1)
+		securityGroupIDs = append(securityGroupIDs, vpcConfig.SecurityGroupIds...)
+		if clusterSecurityGroupID := ptr.StringFromPointer(vpcConfig.ClusterSecurityGroupId); clusterSecurityGroupID != "" {
+			securityGroupIDs = append(securityGroupIDs, clusterSecurityGroupID)
+		}
+
+		item := models.EKSCluster{
+			Name:                  ptr.StringFromPointer(cluster.Name),
+			AccountID:             payload.AccountID,
+			RegionName:            payload.Region,
+			ARN:                   ptr.StringFromPointer(cluster.Arn),
+			Version:               ptr.StringFromPointer(cluster.Version),
+			PlatformVersion:       ptr.StringFromPointer(cluster.PlatformVersion),
+			Status:                string(cluster.Status),
+			Endpoint:              ptr.StringFromPointer(cluster.Endpoint),
+			EndpointPublicAccess:  vpcConfig.EndpointPublicAccess,
+			EndpointPrivateAccess: vpcConfig.EndpointPrivateAccess,
+			VpcID:                 ptr.StringFromPointer(vpcConfig.VpcId),
+			ClusterCreatedAt:      ptr.Value(cluster.CreatedAt, time.Time{}),
+			SubnetIDs:             subnetIDs,
+			SecurityGroupIDs:      securityGroupIDs,
+		}
+		items = append(items, item)
+		ids = append(ids, item.Name)
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, account_id, region_name) DO UPDATE").
+			Set("arn = EXCLUDED.arn").
+			Set("version = EXCLUDED.version").
+			Set("platform_version = EXCLUDED.platform_version").
+			Set("status = EXCLUDED.status").
+			Set("endpoint = EXCLUDED.endpoint").
+			Set("endpoint_public_access = EXCLUDED.endpoint_public_access").
+			Set("endpoint_private_access = EXCLUDED.endpoint_private_access").
+			Set("vpc_id = EXCLUDED.vpc_id").
+			Set("cluster_created_at = EXCLUDED.cluster_created_at").
+			Set("subnet_ids = EXCLUDED.subnet_ids").
+			Set("security_group_ids = EXCLUDED.security_group_ids").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert aws eks clusters into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws eks clusters",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+	)
+
+	return reconcileRegion[models.EKSCluster](ctx, "eks clusters", payload.AccountID, payload.Region, "name", ids)
+}
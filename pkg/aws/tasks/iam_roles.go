@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsutils "github.com/gardener/inventory/pkg/aws/utils"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectIAMRoles is the name of the task for collecting AWS IAM
+	// roles.
+	TaskCollectIAMRoles = "aws:task:collect-iam-roles"
+)
+
+// CollectIAMRolesPayload is the payload, which is used for collecting AWS IAM
+// roles.
+type CollectIAMRolesPayload struct {
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client. If not specified, tasks are enqueued for all
+	// accounts with a configured IAM client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+}
+
+// NewCollectIAMRolesTask creates a new [asynq.Task] for collecting AWS IAM
+// roles, without specifying a payload.
+func NewCollectIAMRolesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectIAMRoles, nil)
+}
+
+// HandleCollectIAMRolesTask handles the task for collecting AWS IAM roles.
+func HandleCollectIAMRolesTask(ctx context.Context, t *asynq.Task) error {
+	var payload CollectIAMRolesPayload
+	if data := t.Payload(); data != nil {
+		if err := asynqutils.Unmarshal(data, &payload); err != nil {
+			return asynqutils.SkipRetry(err)
+		}
+	}
+
+	// IAM is a global service, so we enqueue tasks for each account
+	// only, and not for each region.
+	if payload.AccountID == "" {
+		return enqueueCollectIAMRoles(ctx)
+	}
+
+	return collectIAMRoles(ctx, payload)
+}
+
+// enqueueCollectIAMRoles enqueues tasks for collecting AWS IAM roles for all
+// accounts with a configured IAM client.
+func enqueueCollectIAMRoles(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+	queue := asynqutils.GetQueueName(ctx)
+
+	if awsclients.IAMClientset.Length() == 0 {
+		logger.Warn("no AWS IAM clients found")
+
+		return nil
+	}
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, awsclients.IAMClientset.Length())
+	err := awsclients.IAMClientset.Range(func(accountID string, _ *awsclients.Client[*iam.Client]) error {
+		if !isAccountSelected(ctx, accountID) {
+			return nil
+		}
+
+		payload := CollectIAMRolesPayload{
+			AccountID: accountID,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for AWS IAM roles",
+				"account_id", accountID,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectIAMRoles, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectIAMRoles collects the AWS IAM roles using the client associated with
+// the given account id from the payload.
+func collectIAMRoles(ctx context.Context, payload CollectIAMRolesPayload) error {
+	client, ok := awsclients.IAMClientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count, trustingExternal int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			iamRolesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+		)
+		key := metrics.Key(TaskCollectIAMRoles, payload.AccountID)
+		metrics.DefaultCollector.AddMetric(key, metric)
+
+		externalMetric := prometheus.MustNewConstMetric(
+			iamRolesTrustingExternalAccountsDesc,
+			prometheus.GaugeValue,
+			float64(trustingExternal),
+			payload.AccountID,
+		)
+		externalKey := metrics.Key(TaskCollectIAMRoles, "trusting_external_accounts", payload.AccountID)
+		metrics.DefaultCollector.AddMetric(externalKey, externalMetric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("collecting AWS IAM roles", "account_id", payload.AccountID)
+
+	items := make([]models.IAMRole, 0)
+	paginator := iam.NewListRolesPaginator(
+		client.Client,
+		&iam.ListRolesInput{},
+		func(params *iam.ListRolesPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	roles := make([]types.Role, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.Error(
+				"could not list iam roles",
+				"account_id", payload.AccountID,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		roles = append(roles, page.Roles...)
+	}
+
+	for _, role := range roles {
+		roleName := ptr.StringFromPointer(role.RoleName)
+		item := models.IAMRole{
+			RoleName:           roleName,
+			RoleID:             ptr.StringFromPointer(role.RoleId),
+			ARN:                ptr.StringFromPointer(role.Arn),
+			Path:               ptr.StringFromPointer(role.Path),
+			CreateDate:         ptr.Value(role.CreateDate, time.Time{}),
+			Description:        ptr.StringFromPointer(role.Description),
+			MaxSessionDuration: int(ptr.Value(role.MaxSessionDuration, 0)),
+			AccountID:          payload.AccountID,
+			TrustPolicy:        "{}",
+			TrustedAccounts:    make([]string, 0),
+		}
+
+		// The trust policy of a role is URL-encoded. Failing to parse
+		// the trust policy of a role should not prevent us from
+		// collecting the role itself.
+		document, err := url.QueryUnescape(ptr.StringFromPointer(role.AssumeRolePolicyDocument))
+		if err == nil {
+			item.TrustedAccounts, err = awsutils.TrustedAccounts(document)
+		}
+
+		if err != nil {
+			logger.Warn(
+				"could not parse trust policy of iam role",
+				"account_id", payload.AccountID,
+				"role_name", roleName,
+				"reason", err,
+			)
+			item.TrustedAccounts = make([]string, 0)
+		} else {
+			item.TrustPolicy = document
+		}
+
+		item.TrustsExternalAccounts = len(awsutils.ExternalAccounts(item.TrustedAccounts, payload.AccountID)) > 0
+		if item.TrustsExternalAccounts {
+			trustingExternal++
+		}
+		items = append(items, item)
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		q = q.On("CONFLICT (arn) DO UPDATE")
+
+		return dbutils.UpsertAllColumns(q, items).
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert aws iam roles into db",
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws iam roles",
+		"account_id", payload.AccountID,
+		"count", count,
+		"trusting_external_accounts", trustingExternal,
+	)
+
+	return nil
+}
@@ -0,0 +1,461 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectIAMUsers is the name of the task for collecting AWS IAM
+	// users and their access keys.
+	TaskCollectIAMUsers = "aws:task:collect-iam-users"
+
+	// DefaultMaxAccessKeyAge is the default age after which IAM access
+	// keys are flagged as stale.
+	DefaultMaxAccessKeyAge = 90 * 24 * time.Hour
+)
+
+// CollectIAMUsersPayload is the payload, which is used for collecting AWS IAM
+// users.
+type CollectIAMUsersPayload struct {
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client. If not specified, tasks are enqueued for all
+	// accounts with a configured IAM client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// MaxAccessKeyAge specifies the age after which access keys are
+	// flagged as stale.
+	MaxAccessKeyAge time.Duration `json:"max_access_key_age" yaml:"max_access_key_age"`
+}
+
+// NewCollectIAMUsersTask creates a new [asynq.Task] for collecting AWS IAM
+// users, without specifying a payload.
+func NewCollectIAMUsersTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectIAMUsers, nil)
+}
+
+// HandleCollectIAMUsersTask handles the task for collecting AWS IAM users.
+func HandleCollectIAMUsersTask(ctx context.Context, t *asynq.Task) error {
+	var payload CollectIAMUsersPayload
+	if data := t.Payload(); data != nil {
+		if err := asynqutils.Unmarshal(data, &payload); err != nil {
+			return asynqutils.SkipRetry(err)
+		}
+	}
+
+	if payload.MaxAccessKeyAge <= 0 {
+		payload.MaxAccessKeyAge = DefaultMaxAccessKeyAge
+	}
+
+	// IAM is a global service, so we enqueue tasks for each account
+	// only, and not for each region.
+	if payload.AccountID == "" {
+		return enqueueCollectIAMUsers(ctx, payload.MaxAccessKeyAge)
+	}
+
+	return collectIAMUsers(ctx, payload)
+}
+
+// enqueueCollectIAMUsers enqueues tasks for collecting AWS IAM users for all
+// accounts with a configured IAM client.
+func enqueueCollectIAMUsers(ctx context.Context, maxAccessKeyAge time.Duration) error {
+	logger := asynqutils.GetLogger(ctx)
+	queue := asynqutils.GetQueueName(ctx)
+
+	if awsclients.IAMClientset.Length() == 0 {
+		logger.Warn("no AWS IAM clients found")
+
+		return nil
+	}
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, awsclients.IAMClientset.Length())
+	err := awsclients.IAMClientset.Range(func(accountID string, _ *awsclients.Client[*iam.Client]) error {
+		if !isAccountSelected(ctx, accountID) {
+			return nil
+		}
+
+		payload := CollectIAMUsersPayload{
+			AccountID:       accountID,
+			MaxAccessKeyAge: maxAccessKeyAge,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for AWS IAM users",
+				"account_id", accountID,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectIAMUsers, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// iamUserDetails provides the details of an IAM user, which are fetched
+// using separate API calls.
+type iamUserDetails struct {
+	accessKeys       []types.AccessKeyMetadata
+	policies         []types.AttachedPolicy
+	hasConsoleAccess bool
+	hasMFA           bool
+}
+
+// listIAMUsers returns the IAM users of the account.
+func listIAMUsers(ctx context.Context, client *iam.Client) ([]types.User, error) {
+	paginator := iam.NewListUsersPaginator(
+		client,
+		&iam.ListUsersInput{},
+		func(params *iam.ListUsersPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]types.User, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		items = append(items, page.Users...)
+	}
+
+	return items, nil
+}
+
+// getIAMUserDetails fetches the access keys, attached policies, login profile
+// and MFA devices of the given IAM user.
+func getIAMUserDetails(ctx context.Context, client *iam.Client, userName string) (*iamUserDetails, error) {
+	details := &iamUserDetails{
+		accessKeys: make([]types.AccessKeyMetadata, 0),
+		policies:   make([]types.AttachedPolicy, 0),
+	}
+
+	keysPaginator := iam.NewListAccessKeysPaginator(
+		client,
+		&iam.ListAccessKeysInput{UserName: &userName},
+		func(params *iam.ListAccessKeysPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+	for keysPaginator.HasMorePages() {
+		page, err := keysPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		details.accessKeys = append(details.accessKeys, page.AccessKeyMetadata...)
+	}
+
+	policiesPaginator := iam.NewListAttachedUserPoliciesPaginator(
+		client,
+		&iam.ListAttachedUserPoliciesInput{UserName: &userName},
+		func(params *iam.ListAttachedUserPoliciesPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+	for policiesPaginator.HasMorePages() {
+		page, err := policiesPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		details.policies = append(details.policies, page.AttachedPolicies...)
+	}
+
+	mfaDevices, err := client.ListMFADevices(ctx, &iam.ListMFADevicesInput{UserName: &userName})
+	if err != nil {
+		return nil, err
+	}
+	details.hasMFA = len(mfaDevices.MFADevices) > 0
+
+	// Users without console access don't have a login profile.
+	var noSuchEntity *types.NoSuchEntityException
+	_, err = client.GetLoginProfile(ctx, &iam.GetLoginProfileInput{UserName: &userName})
+	switch {
+	case err == nil:
+		details.hasConsoleAccess = true
+	case errors.As(err, &noSuchEntity):
+		details.hasConsoleAccess = false
+	default:
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// collectIAMUsers collects the AWS IAM users and their access keys using the
+// client associated with the given account id from the payload.
+func collectIAMUsers(ctx context.Context, payload CollectIAMUsersPayload) error {
+	client, ok := awsclients.IAMClientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count, staleKeys, withoutMFA int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			iamUsersDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+		)
+		key := metrics.Key(TaskCollectIAMUsers, payload.AccountID)
+		metrics.DefaultCollector.AddMetric(key, metric)
+
+		staleMetric := prometheus.MustNewConstMetric(
+			iamStaleAccessKeysDesc,
+			prometheus.GaugeValue,
+			float64(staleKeys),
+			payload.AccountID,
+		)
+		staleKey := metrics.Key(TaskCollectIAMUsers, "stale_access_keys", payload.AccountID)
+		metrics.DefaultCollector.AddMetric(staleKey, staleMetric)
+
+		mfaMetric := prometheus.MustNewConstMetric(
+			iamConsoleUsersWithoutMFADesc,
+			prometheus.GaugeValue,
+			float64(withoutMFA),
+			payload.AccountID,
+		)
+		mfaKey := metrics.Key(TaskCollectIAMUsers, "console_without_mfa", payload.AccountID)
+		metrics.DefaultCollector.AddMetric(mfaKey, mfaMetric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("collecting AWS IAM users", "account_id", payload.AccountID)
+
+	users, err := listIAMUsers(ctx, client.Client)
+	if err != nil {
+		logger.Error(
+			"could not list iam users",
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	if len(users) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	userItems := make([]models.IAMUser, 0, len(users))
+	keyItems := make([]models.IAMAccessKey, 0)
+	policyItems := make([]models.IAMPolicy, 0)
+	seenPolicies := make(map[string]struct{})
+	for _, user := range users {
+		userName := ptr.StringFromPointer(user.UserName)
+		userARN := ptr.StringFromPointer(user.Arn)
+		details, err := getIAMUserDetails(ctx, client.Client, userName)
+		if err != nil {
+			logger.Error(
+				"could not get iam user details",
+				"account_id", payload.AccountID,
+				"user_name", userName,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		policyARNs := make([]string, 0, len(details.policies))
+		for _, policy := range details.policies {
+			policyARN := ptr.StringFromPointer(policy.PolicyArn)
+			policyARNs = append(policyARNs, policyARN)
+			if _, ok := seenPolicies[policyARN]; ok {
+				continue
+			}
+			seenPolicies[policyARN] = struct{}{}
+
+			var isAWSManaged bool
+			if parsed, err := arn.Parse(policyARN); err == nil {
+				isAWSManaged = parsed.AccountID == "aws"
+			}
+
+			policyItem := models.IAMPolicy{
+				PolicyARN:    policyARN,
+				PolicyName:   ptr.StringFromPointer(policy.PolicyName),
+				AccountID:    payload.AccountID,
+				IsAWSManaged: isAWSManaged,
+			}
+			policyItems = append(policyItems, policyItem)
+		}
+
+		userItem := models.IAMUser{
+			UserName:          userName,
+			UserID:            ptr.StringFromPointer(user.UserId),
+			ARN:               userARN,
+			Path:              ptr.StringFromPointer(user.Path),
+			CreateDate:        ptr.Value(user.CreateDate, time.Time{}),
+			PasswordLastUsed:  ptr.Value(user.PasswordLastUsed, time.Time{}),
+			AccountID:         payload.AccountID,
+			HasConsoleAccess:  details.hasConsoleAccess,
+			HasMFA:            details.hasMFA,
+			ConsoleWithoutMFA: details.hasConsoleAccess && !details.hasMFA,
+			PolicyARNs:        policyARNs,
+		}
+		if userItem.ConsoleWithoutMFA {
+			withoutMFA++
+		}
+		userItems = append(userItems, userItem)
+
+		for _, accessKey := range details.accessKeys {
+			accessKeyID := ptr.StringFromPointer(accessKey.AccessKeyId)
+			createDate := ptr.Value(accessKey.CreateDate, time.Time{})
+			keyItem := models.IAMAccessKey{
+				AccessKeyID: accessKeyID,
+				UserARN:     userARN,
+				UserName:    userName,
+				Status:      string(accessKey.Status),
+				CreateDate:  createDate,
+				AccountID:   payload.AccountID,
+				IsStale:     now.Sub(createDate) > payload.MaxAccessKeyAge,
+			}
+
+			// Failing to get the last usage of an access key
+			// should not prevent us from collecting the key
+			// itself.
+			lastUsed, err := client.Client.GetAccessKeyLastUsed(
+				ctx,
+				&iam.GetAccessKeyLastUsedInput{AccessKeyId: accessKey.AccessKeyId},
+			)
+			if err != nil {
+				logger.Warn(
+					"could not get last usage of iam access key",
+					"account_id", payload.AccountID,
+					"user_name", userName,
+					"access_key_id", accessKeyID,
+					"reason", err,
+				)
+			} else if lastUsed.AccessKeyLastUsed != nil {
+				keyItem.LastUsedDate = ptr.Value(lastUsed.AccessKeyLastUsed.LastUsedDate, time.Time{})
+				keyItem.LastUsedService = ptr.StringFromPointer(lastUsed.AccessKeyLastUsed.ServiceName)
+			}
+
+			if keyItem.IsStale {
+				staleKeys++
+			}
+			keyItems = append(keyItems, keyItem)
+		}
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, userItems, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (arn) DO UPDATE").
+			Set("user_name = EXCLUDED.user_name").
+			Set("user_id = EXCLUDED.user_id").
+			Set("path = EXCLUDED.path").
+			Set("create_date = EXCLUDED.create_date").
+			Set("password_last_used = EXCLUDED.password_last_used").
+			Set("account_id = EXCLUDED.account_id").
+			Set("has_console_access = EXCLUDED.has_console_access").
+			Set("has_mfa = EXCLUDED.has_mfa").
+			Set("console_without_mfa = EXCLUDED.console_without_mfa").
+			Set("policy_arns = EXCLUDED.policy_arns").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert aws iam users into db",
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	if len(keyItems) > 0 {
+		_, err = dbutils.BulkInsert(ctx, db.DB, keyItems, func(q *bun.InsertQuery) *bun.InsertQuery {
+			return q.On("CONFLICT (access_key_id) DO UPDATE").
+				Set("user_arn = EXCLUDED.user_arn").
+				Set("user_name = EXCLUDED.user_name").
+				Set("status = EXCLUDED.status").
+				Set("create_date = EXCLUDED.create_date").
+				Set("last_used_date = EXCLUDED.last_used_date").
+				Set("last_used_service = EXCLUDED.last_used_service").
+				Set("account_id = EXCLUDED.account_id").
+				Set("is_stale = EXCLUDED.is_stale").
+				Set("updated_at = EXCLUDED.updated_at").
+				Returning("id")
+		}, 0)
+
+		if err != nil {
+			logger.Error(
+				"could not insert aws iam access keys into db",
+				"account_id", payload.AccountID,
+				"reason", err,
+			)
+
+			return err
+		}
+	}
+
+	if len(policyItems) > 0 {
+		_, err = dbutils.BulkInsert(ctx, db.DB, policyItems, func(q *bun.InsertQuery) *bun.InsertQuery {
+			return q.On("CONFLICT (policy_arn, account_id) DO UPDATE").
+				Set("policy_name = EXCLUDED.policy_name").
+				Set("is_aws_managed = EXCLUDED.is_aws_managed").
+				Set("updated_at = EXCLUDED.updated_at").
+				Returning("id")
+		}, 0)
+
+		if err != nil {
+			logger.Error(
+				"could not insert aws iam policies into db",
+				"account_id", payload.AccountID,
+				"reason", err,
+			)
+
+			return err
+		}
+	}
+
+	logger.Info(
+		"populated aws iam users",
+		"account_id", payload.AccountID,
+		"count", count,
+		"access_keys", len(keyItems),
+		"stale_access_keys", staleKeys,
+		"console_without_mfa", withoutMFA,
+	)
+
+	return nil
+}
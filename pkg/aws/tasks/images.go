@@ -7,21 +7,20 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/constants"
 	"github.com/gardener/inventory/pkg/aws/models"
-	awsutils "github.com/gardener/inventory/pkg/aws/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -42,6 +41,11 @@ type CollectImagesPayload struct {
 	// Owners specifies owners of AMI images. Only images with the specified
 	// owners will be collected.
 	Owners []string `json:"owners" yaml:"owners"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
 }
 
 // NewCollectImagesTask creates a new [asynq.Task] for collecting AWS AMIs
@@ -63,7 +67,7 @@ func HandleCollectImagesTask(ctx context.Context, t *asynq.Task) error {
 	switch {
 	case payload.Region == "":
 		// We don't have a specified region, enqueue collection for all
-		// known regions.
+		// known or the requested regions.
 		return enqueueCollectImages(ctx, payload)
 	case payload.AccountID == "":
 		// Required AccountID is missing
@@ -77,13 +81,14 @@ func HandleCollectImagesTask(ctx context.Context, t *asynq.Task) error {
 // enqueueCollectImages enqueues tasks for collecting AWS AMIs from all known
 // regions and accounts.
 func enqueueCollectImages(ctx context.Context, payload CollectImagesPayload) error {
-	regions, err := awsutils.GetRegionsFromDB(ctx)
+	regions, err := getRegions(ctx, payload.Regions)
 	if err != nil {
-		return fmt.Errorf("failed to get regions: %w", err)
+		return err
 	}
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
 	// Enqueue task for each known region
 	for _, r := range regions {
 		if !awsclients.EC2Clientset.Exists(r.AccountID) {
@@ -119,31 +124,12 @@ func enqueueCollectImages(ctx context.Context, payload CollectImagesPayload) err
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectImages, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"region", r.Name,
-				"account_id", r.AccountID,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"region", r.Name,
-			"account_id", r.AccountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectImages, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectImages collects the AWS AMIs based on the specified payload.
@@ -207,10 +193,13 @@ func collectImages(ctx context.Context, payload CollectImagesPayload) error {
 			return err
 		}
 
+		asynqutils.AddPages(ctx, 1)
+
 		items = append(items, page.Images...)
 	}
 
 	images := make([]models.Image, 0, len(items))
+	ids := make([]string, 0, len(items))
 	for _, image := range items {
 		item := models.Image{
 			ImageID:        ptr.StringFromPointer(image.ImageId),
@@ -223,24 +212,24 @@ func collectImages(ctx context.Context, payload CollectImagesPayload) error {
 			RegionName:     payload.Region,
 		}
 		images = append(images, item)
+		ids = append(ids, item.ImageID)
 	}
 
 	if len(images) == 0 {
-		return nil
+		return reconcileRegion[models.Image](ctx, "images", payload.AccountID, payload.Region, "image_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&images).
-		On("CONFLICT (image_id, account_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("owner_id = EXCLUDED.owner_id").
-		Set("image_type = EXCLUDED.image_type").
-		Set("root_device_type = EXCLUDED.root_device_type").
-		Set("description = EXCLUDED.description").
-		Set("region_name = EXCLUDED.region_name").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, images, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (image_id, account_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("owner_id = EXCLUDED.owner_id").
+			Set("image_type = EXCLUDED.image_type").
+			Set("root_device_type = EXCLUDED.root_device_type").
+			Set("description = EXCLUDED.description").
+			Set("region_name = EXCLUDED.region_name").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -253,11 +242,6 @@ func collectImages(ctx context.Context, payload CollectImagesPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated aws amis",
 		"region", payload.Region,
@@ -265,5 +249,5 @@ func collectImages(ctx context.Context, payload CollectImagesPayload) error {
 		"count", count,
 	)
 
-	return nil
+	return reconcileRegion[models.Image](ctx, "images", payload.AccountID, payload.Region, "image_id", ids)
 }
@@ -7,23 +7,25 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"slices"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/constants"
 	"github.com/gardener/inventory/pkg/aws/models"
 	awsutils "github.com/gardener/inventory/pkg/aws/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/globalid"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -31,6 +33,11 @@ const (
 	// TaskCollectInstances is the name of the task for collecting AWS EC2
 	// Instances.
 	TaskCollectInstances = "aws:task:collect-instances"
+
+	// incrementalInstancesOverlap specifies how far back before the start
+	// of the last successful run instances are collected again in
+	// incremental mode.
+	incrementalInstancesOverlap = 15 * time.Minute
 )
 
 // CollectInstancesPayload represents the payload for collecting EC2 Instances.
@@ -41,6 +48,22 @@ type CollectInstancesPayload struct {
 	// AccountID specifies the AWS Account ID, which is associated with a
 	// registered client.
 	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
+
+	// Filters optionally specifies the tags, which the collected instances
+	// must have. A tag with an empty value matches instances by tag key
+	// only. If empty, all instances are collected.
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters"`
+
+	// Incremental specifies whether to collect only the instances, which
+	// have been launched since the last successful run for the account and
+	// region, along with the instances, which are not running. A full scan
+	// is performed, if no successful run has been recorded.
+	Incremental bool `json:"incremental,omitempty" yaml:"incremental"`
 }
 
 // NewCollectInstancesTask creates a new [asynq.Task] for collecting EC2
@@ -55,7 +78,7 @@ func HandleCollectInstancesTask(ctx context.Context, t *asynq.Task) error {
 	// collecting EC2 Instances from all known regions and accounts.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectInstances(ctx)
+		return enqueueCollectInstances(ctx, nil, nil, false)
 	}
 
 	var payload CollectInstancesPayload
@@ -63,6 +86,12 @@ func HandleCollectInstancesTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// Enqueue tasks only for the given regions, with the given filters
+	// and collection mode, if no specific region has been requested.
+	if payload.Region == "" && (len(payload.Regions) > 0 || len(payload.Filters) > 0 || payload.Incremental) {
+		return enqueueCollectInstances(ctx, payload.Regions, payload.Filters, payload.Incremental)
+	}
+
 	if payload.AccountID == "" {
 		return asynqutils.SkipRetry(ErrNoAccountID)
 	}
@@ -77,14 +106,18 @@ func HandleCollectInstancesTask(ctx context.Context, t *asynq.Task) error {
 // enqueueCollectInstances enqueues tasks for collecting AWS EC2 Instances from
 // all known AWS Regions by creating a payload with the respective region and
 // Account ID.
-func enqueueCollectInstances(ctx context.Context) error {
-	regions, err := awsutils.GetRegionsFromDB(ctx)
+//
+// If region names are specified, tasks are enqueued only for these regions.
+// The given filters and collection mode are propagated to each enqueued task.
+func enqueueCollectInstances(ctx context.Context, regionNames []string, filters map[string]string, incremental bool) error {
+	regions, err := getRegions(ctx, regionNames)
 	if err != nil {
-		return fmt.Errorf("failed to get regions: %w", err)
+		return err
 	}
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
 
 	// Enqueue task for each known region and account id
 	for _, r := range regions {
@@ -99,8 +132,10 @@ func enqueueCollectInstances(ctx context.Context) error {
 		}
 
 		payload := CollectInstancesPayload{
-			Region:    r.Name,
-			AccountID: r.AccountID,
+			Region:      r.Name,
+			AccountID:   r.AccountID,
+			Filters:     filters,
+			Incremental: incremental,
 		}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -114,31 +149,12 @@ func enqueueCollectInstances(ctx context.Context) error {
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectInstances, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"region", r.Name,
-				"account_id", r.AccountID,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"region", r.Name,
-			"account_id", r.AccountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectInstances, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectInstances collects the AWS EC2 instances from the specified region,
@@ -151,47 +167,54 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 
 	logger := asynqutils.GetLogger(ctx)
 
+	since, err := incrementalInstancesSince(ctx, payload)
+	if err != nil {
+		logger.Error(
+			"could not get last successful run",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	isIncremental := !since.IsZero()
+	mode := "full"
+	if isIncremental {
+		mode = "incremental"
+	}
+
 	logger.Info(
 		"collecting AWS instances ",
 		"region", payload.Region,
 		"account_id", payload.AccountID,
+		"mode", mode,
+		"since", since,
 	)
 
-	paginator := ec2.NewDescribeInstancesPaginator(
-		client.Client,
-		&ec2.DescribeInstancesInput{},
-		func(params *ec2.DescribeInstancesPaginatorOptions) {
-			params.Limit = int32(constants.PageSize)
-			params.StopOnDuplicateToken = true
-		},
-	)
+	var items []types.Instance
+	tagFilters := awsutils.TagFilters(payload.Filters)
+	if isIncremental {
+		items, err = describeChangedInstances(ctx, client.Client, payload.Region, tagFilters, since)
+	} else {
+		items, err = describeInstances(ctx, client.Client, payload.Region, tagFilters)
+	}
 
-	// Fetch items from all pages
-	items := make([]types.Instance, 0)
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(
-			ctx,
-			func(o *ec2.Options) {
-				o.Region = payload.Region
-			},
+	if err != nil {
+		logger.Error(
+			"could not describe instances",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
 		)
-		if err != nil {
-			logger.Error(
-				"could not describe instances",
-				"region", payload.Region,
-				"account_id", payload.AccountID,
-				"reason", err,
-			)
-
-			return err
-		}
 
-		for _, reservation := range page.Reservations {
-			items = append(items, reservation.Instances...)
-		}
+		return err
 	}
 
 	instances := make([]models.Instance, 0, len(items))
+	tags := make([]models.Tag, 0)
+	taggedIDs := make([]string, 0)
 	for _, instance := range items {
 		name := awsutils.FetchTag(instance.Tags, "Name")
 		item := models.Instance{
@@ -207,30 +230,49 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 			RegionName:   payload.Region,
 			ImageID:      ptr.StringFromPointer(instance.ImageId),
 			LaunchTime:   ptr.Value(instance.LaunchTime, time.Time{}),
+			GlobalID: globalid.New(
+				"aws",
+				payload.AccountID,
+				ptr.StringFromPointer(instance.InstanceId),
+			),
 		}
 		instances = append(instances, item)
+		taggedIDs = append(taggedIDs, item.InstanceID)
+		tags = append(tags, newEC2Tags(
+			payload.AccountID,
+			payload.Region,
+			models.TagResourceTypeInstance,
+			item.InstanceID,
+			instance.Tags,
+		)...)
 	}
 
+	// In incremental mode the instances, which have not been collected,
+	// are not known to have disappeared, so they are not reconciled.
 	if len(instances) == 0 {
-		return nil
+		if isIncremental {
+			return nil
+		}
+
+		return reconcileInstances(ctx, payload, instances)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&instances).
-		On("CONFLICT (instance_id, account_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("arch = EXCLUDED.arch").
-		Set("instance_type = EXCLUDED.instance_type").
-		Set("state = EXCLUDED.state").
-		Set("subnet_id = EXCLUDED.subnet_id").
-		Set("vpc_id = EXCLUDED.vpc_id").
-		Set("platform = EXCLUDED.platform").
-		Set("region_name = EXCLUDED.region_name").
-		Set("image_id = EXCLUDED.image_id").
-		Set("launch_time = EXCLUDED.launch_time").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, instances, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (instance_id, account_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("arch = EXCLUDED.arch").
+			Set("instance_type = EXCLUDED.instance_type").
+			Set("state = EXCLUDED.state").
+			Set("subnet_id = EXCLUDED.subnet_id").
+			Set("vpc_id = EXCLUDED.vpc_id").
+			Set("platform = EXCLUDED.platform").
+			Set("region_name = EXCLUDED.region_name").
+			Set("image_id = EXCLUDED.image_id").
+			Set("launch_time = EXCLUDED.launch_time").
+			Set("global_id = EXCLUDED.global_id").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -243,18 +285,39 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated aws instances",
 		"region", payload.Region,
 		"account_id", payload.AccountID,
+		"mode", mode,
 		"count", count,
 	)
 
+	if err := UpsertTags(ctx, db.DB, payload.AccountID, models.TagResourceTypeInstance, taggedIDs, tags); err != nil {
+		logger.Error(
+			"could not insert instance tags into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	// The metrics reflect all instances of the region, so they are emitted
+	// only after a full scan, which is not filtered by tags.
+	if isIncremental {
+		return nil
+	}
+
+	if err := reconcileInstances(ctx, payload, instances); err != nil {
+		return err
+	}
+
+	if len(payload.Filters) > 0 {
+		return nil
+	}
+
 	// Emit metrics by grouping the instances by VPC
 	groups := utils.GroupBy(instances, func(item models.Instance) string {
 		return item.VpcID
@@ -280,3 +343,142 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 
 	return nil
 }
+
+// incrementalInstancesSince returns the time since which instances are
+// collected, when incremental mode is requested in the given payload. A zero
+// time is returned, if a full scan should be performed, e.g. incremental mode
+// has not been requested, no successful run has been recorded, or the last
+// successful run is too old.
+func incrementalInstancesSince(ctx context.Context, payload CollectInstancesPayload) (time.Time, error) {
+	if !payload.Incremental {
+		return time.Time{}, nil
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	lastRun, err := dbutils.LastSuccessfulRun(ctx, db.DB, TaskCollectInstances, payload.AccountID, payload.Region)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if lastRun.IsZero() {
+		logger.Info(
+			"no successful run found, falling back to full scan",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+		)
+
+		return time.Time{}, nil
+	}
+
+	since := lastRun.Add(-incrementalInstancesOverlap)
+	if _, ok := awsutils.LaunchTimeFilter(since, time.Now()); !ok {
+		logger.Info(
+			"last successful run is too old, falling back to full scan",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"last_run", lastRun,
+		)
+
+		return time.Time{}, nil
+	}
+
+	return since, nil
+}
+
+// describeInstances returns the EC2 instances from the given region, which
+// match the given filters.
+func describeInstances(ctx context.Context, client *ec2.Client, region string, filters []types.Filter) ([]types.Instance, error) {
+	paginator := ec2.NewDescribeInstancesPaginator(
+		client,
+		&ec2.DescribeInstancesInput{
+			Filters: filters,
+		},
+		func(params *ec2.DescribeInstancesPaginatorOptions) {
+			params.Limit = int32(constants.PageSize)
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]types.Instance, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(
+			ctx,
+			func(o *ec2.Options) {
+				o.Region = region
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+
+		for _, reservation := range page.Reservations {
+			items = append(items, reservation.Instances...)
+		}
+	}
+
+	return items, nil
+}
+
+// describeChangedInstances returns the EC2 instances from the given region,
+// which match the given filters, and have changed since the given time.
+//
+// The EC2 API does not support filtering instances by modification time, so
+// the instances, which have been launched or started since the given time, are
+// returned along with all instances, which are not running. Changes to running
+// instances, e.g. tag updates, are picked up by the next full scan.
+func describeChangedInstances(ctx context.Context, client *ec2.Client, region string, filters []types.Filter, since time.Time) ([]types.Instance, error) {
+	launchTime, ok := awsutils.LaunchTimeFilter(since, time.Now())
+	if !ok {
+		return describeInstances(ctx, client, region, filters)
+	}
+
+	states := types.Filter{
+		Name: ptr.To("instance-state-name"),
+		Values: []string{
+			string(types.InstanceStateNamePending),
+			string(types.InstanceStateNameStopping),
+			string(types.InstanceStateNameStopped),
+			string(types.InstanceStateNameShuttingDown),
+			string(types.InstanceStateNameTerminated),
+		},
+	}
+
+	items := make([]types.Instance, 0)
+	seen := make(map[string]struct{})
+	for _, filter := range []types.Filter{launchTime, states} {
+		result, err := describeInstances(ctx, client, region, append(slices.Clone(filters), filter))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range result {
+			id := ptr.StringFromPointer(item.InstanceId)
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// reconcileInstances marks the collected instances of the account and region as
+// seen, and the ones, which have disappeared, as deleted. Collections filtered
+// by tags do not cover the whole region, and are not reconciled.
+func reconcileInstances(ctx context.Context, payload CollectInstancesPayload, instances []models.Instance) error {
+	if len(payload.Filters) > 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(instances))
+	for _, item := range instances {
+		ids = append(ids, item.InstanceID)
+	}
+
+	return reconcileRegion[models.Instance](ctx, "instances", payload.AccountID, payload.Region, "instance_id", ids)
+}
@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	v2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/constants"
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectLoadBalancerListeners is the name of the task for
+	// collecting AWS ELB v2 listeners.
+	TaskCollectLoadBalancerListeners = "aws:task:collect-lb-listeners"
+)
+
+// CollectLoadBalancerListenersPayload represents the payload for collecting
+// AWS ELB v2 listeners.
+type CollectLoadBalancerListenersPayload struct {
+	// Region specifies the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
+}
+
+// NewCollectLoadBalancerListenersTask creates a new [asynq.Task] for
+// collecting AWS ELB v2 listeners, without specifying a payload.
+func NewCollectLoadBalancerListenersTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectLoadBalancerListeners, nil)
+}
+
+// HandleCollectLoadBalancerListenersTask handles the task for collecting AWS
+// ELB v2 listeners.
+func HandleCollectLoadBalancerListenersTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting listeners from all known regions and their
+	// respective accounts.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectLoadBalancerListeners(ctx, nil)
+	}
+
+	var payload CollectLoadBalancerListenersPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	// Enqueue tasks only for the given regions, if no specific region
+	// has been requested.
+	if payload.Region == "" && len(payload.Regions) > 0 {
+		return enqueueCollectLoadBalancerListeners(ctx, payload.Regions)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	return collectLoadBalancerListeners(ctx, payload)
+}
+
+// enqueueCollectLoadBalancerListeners enqueues tasks for collecting AWS ELB v2
+// listeners for the known regions and accounts.
+//
+// If region names are specified, tasks are enqueued only for these regions.
+func enqueueCollectLoadBalancerListeners(ctx context.Context, regionNames []string) error {
+	regions, err := getRegions(ctx, regionNames)
+	if err != nil {
+		return err
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
+
+	// Enqueue listener collection for each region
+	for _, r := range regions {
+		if !awsclients.ELBv2Clientset.Exists(r.AccountID) {
+			logger.Warn(
+				"AWS client not found",
+				"region", r.Name,
+				"account_id", r.AccountID,
+			)
+
+			continue
+		}
+
+		payload := CollectLoadBalancerListenersPayload{
+			Region:    r.Name,
+			AccountID: r.AccountID,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for AWS ELB v2 listeners",
+				"region", r.Name,
+				"account_id", r.AccountID,
+				"reason", err,
+			)
+
+			continue
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectLoadBalancerListeners, data)
+		})
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectLoadBalancerListeners collects the listeners of the known AWS ELB v2
+// load balancers from the specified region using the client associated with
+// the given AccountID from the payload.
+func collectLoadBalancerListeners(ctx context.Context, payload CollectLoadBalancerListenersPayload) error {
+	client, ok := awsclients.ELBv2Clientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			lbListenersDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+			payload.Region,
+		)
+		key := metrics.Key(TaskCollectLoadBalancerListeners, payload.AccountID, payload.Region)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	// Listeners are described per load balancer, so we use the v2 load
+	// balancers, which were collected for the account and region.
+	lbARNs := make([]string, 0)
+	err := db.DB.NewSelect().
+		Model((*models.LoadBalancer)(nil)).
+		Column("arn").
+		Where("account_id = ?", payload.AccountID).
+		Where("region_name = ?", payload.Region).
+		Where("arn != ''").
+		Where("deleted_at IS NULL").
+		Scan(ctx, &lbARNs)
+
+	if err != nil {
+		return err
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info(
+		"collecting AWS ELB v2 listeners",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"load_balancers", len(lbARNs),
+	)
+
+	// Fetch items from all pages of each load balancer
+	items := make([]v2types.Listener, 0)
+	pageSize := int32(constants.PageSize)
+	for _, lbARN := range lbARNs {
+		paginator := elbv2.NewDescribeListenersPaginator(
+			client.Client,
+			&elbv2.DescribeListenersInput{
+				LoadBalancerArn: &lbARN,
+				PageSize:        &pageSize,
+			},
+			func(params *elbv2.DescribeListenersPaginatorOptions) {
+				params.StopOnDuplicateToken = true
+			},
+		)
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(
+				ctx,
+				func(o *elbv2.Options) {
+					o.Region = payload.Region
+				},
+			)
+
+			if err != nil {
+				logger.Error(
+					"could not describe listeners",
+					"region", payload.Region,
+					"account_id", payload.AccountID,
+					"load_balancer", lbARN,
+					"reason", err,
+				)
+
+				return err
+			}
+
+			asynqutils.AddPages(ctx, 1)
+
+			items = append(items, page.Listeners...)
+		}
+	}
+
+	// Create model instances from the collected data
+	listeners := make([]models.LoadBalancerListener, 0, len(items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		listener := models.LoadBalancerListener{
+			ARN:             ptr.StringFromPointer(item.ListenerArn),
+			AccountID:       payload.AccountID,
+			LoadBalancerARN: ptr.StringFromPointer(item.LoadBalancerArn),
+			Port:            int(ptr.Value(item.Port, 0)),
+			Protocol:        string(item.Protocol),
+			RegionName:      payload.Region,
+			TargetGroupARNs: getListenerTargetGroups(item.DefaultActions),
+		}
+		listeners = append(listeners, listener)
+		ids = append(ids, listener.ARN)
+	}
+
+	if len(listeners) == 0 {
+		return reconcileRegion[models.LoadBalancerListener](ctx, "listeners", payload.AccountID, payload.Region, "arn", ids)
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, listeners, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (arn, account_id) DO UPDATE").
+			Set("lb_arn = EXCLUDED.lb_arn").
+			Set("port = EXCLUDED.port").
+			Set("protocol = EXCLUDED.protocol").
+			Set("region_name = EXCLUDED.region_name").
+			Set("target_group_arns = EXCLUDED.target_group_arns").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert listeners into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws listeners",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+	)
+
+	return reconcileRegion[models.LoadBalancerListener](ctx, "listeners", payload.AccountID, payload.Region, "arn", ids)
+}
+
+// getListenerTargetGroups returns the ARNs of the target groups, to which the
+// given listener actions forward requests.
+func getListenerTargetGroups(actions []v2types.Action) []string {
+	arns := make([]string, 0)
+	add := func(arn *string) {
+		value := ptr.StringFromPointer(arn)
+		if value != "" && !slices.Contains(arns, value) {
+			arns = append(arns, value)
+		}
+	}
+
+	for _, action := range actions {
+		if action.Type != v2types.ActionTypeEnumForward {
+			continue
+		}
+		add(action.TargetGroupArn)
+		if action.ForwardConfig != nil {
+			for _, tg := range action.ForwardConfig.TargetGroups {
+				add(tg.TargetGroupArn)
+			}
+		}
+	}
+
+	return arns
+}
@@ -9,15 +9,17 @@ import (
 	"fmt"
 
 	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/constants"
 	"github.com/gardener/inventory/pkg/aws/models"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // LinkAvailabilityZoneWithRegion creates links between the AWS AZs and Regions
-func LinkAvailabilityZoneWithRegion(ctx context.Context, db *bun.DB) error {
+func LinkAvailabilityZoneWithRegion(ctx context.Context, db bun.IDB) error {
 	var zones []models.AvailabilityZone
 	err := db.NewSelect().
 		Model(&zones).
@@ -42,6 +44,10 @@ func LinkAvailabilityZoneWithRegion(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.RegionToAZ) []uuid.UUID {
+		return []uuid.UUID{l.RegionID, l.AvailabilityZoneID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (region_id, az_id) DO UPDATE").
@@ -58,6 +64,8 @@ func LinkAvailabilityZoneWithRegion(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws region with az", "count", count)
 
@@ -65,7 +73,7 @@ func LinkAvailabilityZoneWithRegion(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkRegionWithVPC creates links between the AWS Region and VPC
-func LinkRegionWithVPC(ctx context.Context, db *bun.DB) error {
+func LinkRegionWithVPC(ctx context.Context, db bun.IDB) error {
 	var vpcs []models.VPC
 	err := db.NewSelect().
 		Model(&vpcs).
@@ -90,6 +98,10 @@ func LinkRegionWithVPC(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.RegionToVPC) []uuid.UUID {
+		return []uuid.UUID{l.RegionID, l.VpcID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (region_id, vpc_id) DO UPDATE").
@@ -106,6 +118,8 @@ func LinkRegionWithVPC(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws region with vpc", "count", count)
 
@@ -113,7 +127,7 @@ func LinkRegionWithVPC(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkSubnetWithVPC creates links between the AWS Subnet and VPC
-func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
+func LinkSubnetWithVPC(ctx context.Context, db bun.IDB) error {
 	var subnets []models.Subnet
 	err := db.NewSelect().
 		Model(&subnets).
@@ -138,6 +152,10 @@ func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.VPCToSubnet) []uuid.UUID {
+		return []uuid.UUID{l.SubnetID, l.VpcID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (subnet_id, vpc_id) DO UPDATE").
@@ -154,6 +172,8 @@ func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws subnet with vpc", "count", count)
 
@@ -161,7 +181,7 @@ func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkInstanceWithVPC creates links between the AWS VPC and Instance.
-func LinkInstanceWithVPC(ctx context.Context, db *bun.DB) error {
+func LinkInstanceWithVPC(ctx context.Context, db bun.IDB) error {
 	var instances []models.Instance
 	err := db.NewSelect().
 		Model(&instances).
@@ -186,6 +206,10 @@ func LinkInstanceWithVPC(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.VPCToInstance) []uuid.UUID {
+		return []uuid.UUID{l.InstanceID, l.VpcID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (instance_id, vpc_id) DO UPDATE").
@@ -202,6 +226,8 @@ func LinkInstanceWithVPC(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws instance with vpc", "count", count)
 
@@ -209,7 +235,7 @@ func LinkInstanceWithVPC(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkSubnetWithAZ creates links between the AZ and Subnets.
-func LinkSubnetWithAZ(ctx context.Context, db *bun.DB) error {
+func LinkSubnetWithAZ(ctx context.Context, db bun.IDB) error {
 	var subnets []models.Subnet
 	err := db.NewSelect().
 		Model(&subnets).
@@ -234,6 +260,10 @@ func LinkSubnetWithAZ(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.SubnetToAZ) []uuid.UUID {
+		return []uuid.UUID{l.SubnetID, l.AvailabilityZoneID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (subnet_id, az_id) DO UPDATE").
@@ -250,6 +280,8 @@ func LinkSubnetWithAZ(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws subnet with az", "count", count)
 
@@ -257,7 +289,7 @@ func LinkSubnetWithAZ(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkInstanceWithSubnet creates links between the Instance and Subnet.
-func LinkInstanceWithSubnet(ctx context.Context, db *bun.DB) error {
+func LinkInstanceWithSubnet(ctx context.Context, db bun.IDB) error {
 	var instances []models.Instance
 	err := db.NewSelect().
 		Model(&instances).
@@ -282,6 +314,10 @@ func LinkInstanceWithSubnet(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.InstanceToSubnet) []uuid.UUID {
+		return []uuid.UUID{l.InstanceID, l.SubnetID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (instance_id, subnet_id) DO UPDATE").
@@ -298,6 +334,8 @@ func LinkInstanceWithSubnet(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws instance with subnet", "count", count)
 
@@ -305,7 +343,7 @@ func LinkInstanceWithSubnet(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkInstanceWithRegion creates links between the Instance and Region.
-func LinkInstanceWithRegion(ctx context.Context, db *bun.DB) error {
+func LinkInstanceWithRegion(ctx context.Context, db bun.IDB) error {
 	var instances []models.Instance
 	err := db.NewSelect().
 		Model(&instances).
@@ -330,6 +368,10 @@ func LinkInstanceWithRegion(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.InstanceToRegion) []uuid.UUID {
+		return []uuid.UUID{l.InstanceID, l.RegionID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (instance_id, region_id) DO UPDATE").
@@ -346,6 +388,8 @@ func LinkInstanceWithRegion(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws instance with region", "count", count)
 
@@ -353,7 +397,7 @@ func LinkInstanceWithRegion(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkImageWithRegion creates links between the Image and Region.
-func LinkImageWithRegion(ctx context.Context, db *bun.DB) error {
+func LinkImageWithRegion(ctx context.Context, db bun.IDB) error {
 	var images []models.Image
 	err := db.NewSelect().
 		Model(&images).
@@ -378,6 +422,10 @@ func LinkImageWithRegion(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.ImageToRegion) []uuid.UUID {
+		return []uuid.UUID{l.ImageID, l.RegionID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (image_id, region_id) DO UPDATE").
@@ -394,6 +442,8 @@ func LinkImageWithRegion(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("Linked AWS images (AMIs) with region", "count", count)
 
@@ -401,7 +451,7 @@ func LinkImageWithRegion(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkLoadBalancerWithVpc creates links between the LoadBalancer and VPC.
-func LinkLoadBalancerWithVpc(ctx context.Context, db *bun.DB) error {
+func LinkLoadBalancerWithVpc(ctx context.Context, db bun.IDB) error {
 	var lbs []models.LoadBalancer
 	err := db.NewSelect().
 		Model(&lbs).
@@ -426,6 +476,10 @@ func LinkLoadBalancerWithVpc(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.LoadBalancerToVPC) []uuid.UUID {
+		return []uuid.UUID{l.LoadBalancerID, l.VpcID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (lb_id, vpc_id) DO UPDATE").
@@ -442,6 +496,8 @@ func LinkLoadBalancerWithVpc(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws load balancers with VPC", "count", count)
 
@@ -449,7 +505,7 @@ func LinkLoadBalancerWithVpc(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkLoadBalancerWithRegion creates links between the LoadBalancer and Region.
-func LinkLoadBalancerWithRegion(ctx context.Context, db *bun.DB) error {
+func LinkLoadBalancerWithRegion(ctx context.Context, db bun.IDB) error {
 	var lbs []models.LoadBalancer
 	err := db.NewSelect().
 		Model(&lbs).
@@ -474,6 +530,10 @@ func LinkLoadBalancerWithRegion(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.LoadBalancerToRegion) []uuid.UUID {
+		return []uuid.UUID{l.LoadBalancerID, l.RegionID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (lb_id, region_id) DO UPDATE").
@@ -490,6 +550,8 @@ func LinkLoadBalancerWithRegion(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws load balancer with region", "count", count)
 
@@ -497,7 +559,7 @@ func LinkLoadBalancerWithRegion(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkInstanceWithImage creates links between the Instance and Image.
-func LinkInstanceWithImage(ctx context.Context, db *bun.DB) error {
+func LinkInstanceWithImage(ctx context.Context, db bun.IDB) error {
 	var instances []models.Instance
 	err := db.NewSelect().
 		Model(&instances).
@@ -522,6 +584,10 @@ func LinkInstanceWithImage(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.InstanceToImage) []uuid.UUID {
+		return []uuid.UUID{l.InstanceID, l.ImageID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (instance_id, image_id) DO UPDATE").
@@ -538,6 +604,8 @@ func LinkInstanceWithImage(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws instance with image", "count", count)
 
@@ -546,7 +614,7 @@ func LinkInstanceWithImage(ctx context.Context, db *bun.DB) error {
 
 // LinkNetworkInterfaceWithInstance creates links between [models.Instance] and
 // [models.NetworkInterface].
-func LinkNetworkInterfaceWithInstance(ctx context.Context, db *bun.DB) error {
+func LinkNetworkInterfaceWithInstance(ctx context.Context, db bun.IDB) error {
 	var items []models.NetworkInterface
 	err := db.NewSelect().
 		Model(&items).
@@ -571,6 +639,10 @@ func LinkNetworkInterfaceWithInstance(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.InstanceToNetworkInterface) []uuid.UUID {
+		return []uuid.UUID{l.InstanceID, l.NetworkInterfaceID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (instance_id, ni_id) DO UPDATE").
@@ -587,6 +659,8 @@ func LinkNetworkInterfaceWithInstance(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws instance with network interface", "count", count)
 
@@ -595,7 +669,7 @@ func LinkNetworkInterfaceWithInstance(ctx context.Context, db *bun.DB) error {
 
 // getInterfacesForLoadBalancer retrieves the [models.NetworkInterface]s
 // associated with the given [models.LoadBalancer].
-func getInterfacesForLoadBalancer(ctx context.Context, db *bun.DB, lb models.LoadBalancer) ([]models.NetworkInterface, error) {
+func getInterfacesForLoadBalancer(ctx context.Context, db bun.IDB, lb models.LoadBalancer) ([]models.NetworkInterface, error) {
 	// In AWS we have a mixture of Load Balancers such as `classic',
 	// `network', `application' and `gateway'.
 	//
@@ -645,7 +719,7 @@ func getInterfacesForLoadBalancer(ctx context.Context, db *bun.DB, lb models.Loa
 
 // LinkNetworkInterfaceWithLoadBalancer creates links between
 // [models.NetworkInterface] and [models.LoadBalancer].
-func LinkNetworkInterfaceWithLoadBalancer(ctx context.Context, db *bun.DB) error {
+func LinkNetworkInterfaceWithLoadBalancer(ctx context.Context, db bun.IDB) error {
 	loadBalancers := make([]models.LoadBalancer, 0)
 	err := db.NewSelect().
 		Model(&loadBalancers).
@@ -675,6 +749,10 @@ func LinkNetworkInterfaceWithLoadBalancer(ctx context.Context, db *bun.DB) error
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.LoadBalancerToNetworkInterface) []uuid.UUID {
+		return []uuid.UUID{l.LoadBalancerID, l.NetworkInterfaceID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (lb_id, ni_id) DO UPDATE").
@@ -691,8 +769,625 @@ func LinkNetworkInterfaceWithLoadBalancer(ctx context.Context, db *bun.DB) error
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked aws load balancer with network interface", "count", count)
 
 	return nil
 }
+
+// LinkInstanceWithComplianceResult creates links between the AWS EC2 Instances and
+// the AWS Config compliance results.
+func LinkInstanceWithComplianceResult(ctx context.Context, db bun.IDB) error {
+	links := make([]models.InstanceToComplianceResult, 0)
+	err := db.NewSelect().
+		TableExpr("aws_compliance_result AS cr").
+		Join("INNER JOIN aws_instance AS r").
+		JoinOn("r.account_id = cr.account_id").
+		JoinOn("r.instance_id = cr.resource_id").
+		ColumnExpr("r.id AS instance_id").
+		ColumnExpr("cr.id AS compliance_result_id").
+		Where("cr.resource_type = ?", "AWS::EC2::Instance").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.InstanceToComplianceResult) []uuid.UUID {
+		return []uuid.UUID{l.InstanceID, l.ComplianceResultID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (instance_id, compliance_result_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws instance with compliance result", "count", count)
+
+	return nil
+}
+
+// LinkVPCWithComplianceResult creates links between the AWS VPCs and
+// the AWS Config compliance results.
+func LinkVPCWithComplianceResult(ctx context.Context, db bun.IDB) error {
+	links := make([]models.VPCToComplianceResult, 0)
+	err := db.NewSelect().
+		TableExpr("aws_compliance_result AS cr").
+		Join("INNER JOIN aws_vpc AS r").
+		JoinOn("r.account_id = cr.account_id").
+		JoinOn("r.vpc_id = cr.resource_id").
+		ColumnExpr("r.id AS vpc_id").
+		ColumnExpr("cr.id AS compliance_result_id").
+		Where("cr.resource_type = ?", "AWS::EC2::VPC").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.VPCToComplianceResult) []uuid.UUID {
+		return []uuid.UUID{l.VpcID, l.ComplianceResultID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (vpc_id, compliance_result_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws vpc with compliance result", "count", count)
+
+	return nil
+}
+
+// LinkSubnetWithComplianceResult creates links between the AWS Subnets and
+// the AWS Config compliance results.
+func LinkSubnetWithComplianceResult(ctx context.Context, db bun.IDB) error {
+	links := make([]models.SubnetToComplianceResult, 0)
+	err := db.NewSelect().
+		TableExpr("aws_compliance_result AS cr").
+		Join("INNER JOIN aws_subnet AS r").
+		JoinOn("r.account_id = cr.account_id").
+		JoinOn("r.subnet_id = cr.resource_id").
+		ColumnExpr("r.id AS subnet_id").
+		ColumnExpr("cr.id AS compliance_result_id").
+		Where("cr.resource_type = ?", "AWS::EC2::Subnet").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.SubnetToComplianceResult) []uuid.UUID {
+		return []uuid.UUID{l.SubnetID, l.ComplianceResultID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (subnet_id, compliance_result_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws subnet with compliance result", "count", count)
+
+	return nil
+}
+
+// LinkBucketWithComplianceResult creates links between the AWS S3 Buckets and
+// the AWS Config compliance results.
+func LinkBucketWithComplianceResult(ctx context.Context, db bun.IDB) error {
+	links := make([]models.BucketToComplianceResult, 0)
+	err := db.NewSelect().
+		TableExpr("aws_compliance_result AS cr").
+		Join("INNER JOIN aws_bucket AS r").
+		JoinOn("r.account_id = cr.account_id").
+		JoinOn("r.name = cr.resource_id").
+		ColumnExpr("r.id AS bucket_id").
+		ColumnExpr("cr.id AS compliance_result_id").
+		Where("cr.resource_type = ?", "AWS::S3::Bucket").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.BucketToComplianceResult) []uuid.UUID {
+		return []uuid.UUID{l.BucketID, l.ComplianceResultID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (bucket_id, compliance_result_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws bucket with compliance result", "count", count)
+
+	return nil
+}
+
+// LinkSNSSubscriptionWithTopic creates links between the AWS SNS subscriptions
+// and their topics.
+func LinkSNSSubscriptionWithTopic(ctx context.Context, db bun.IDB) error {
+	var subscriptions []models.SNSSubscription
+	err := db.NewSelect().
+		Model(&subscriptions).
+		Relation("Topic").
+		Where("topic.id IS NOT NULL").
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.SNSSubscriptionToTopic, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		link := models.SNSSubscriptionToTopic{
+			SubscriptionID: sub.ID,
+			TopicID:        sub.Topic.ID,
+		}
+		links = append(links, link)
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.SNSSubscriptionToTopic) []uuid.UUID {
+		return []uuid.UUID{l.SubscriptionID, l.TopicID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (subscription_id, topic_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws sns subscription with topic", "count", count)
+
+	return nil
+}
+
+// LinkIAMUserWithPolicy creates links between the AWS IAM users and the managed
+// policies attached to them.
+func LinkIAMUserWithPolicy(ctx context.Context, db bun.IDB) error {
+	links := make([]models.IAMUserToPolicy, 0)
+	err := db.NewSelect().
+		TableExpr("aws_iam_user AS u").
+		Join("CROSS JOIN LATERAL unnest(u.policy_arns) AS pa(policy_arn)").
+		Join("INNER JOIN aws_iam_policy AS p").
+		JoinOn("p.policy_arn = pa.policy_arn").
+		JoinOn("p.account_id = u.account_id").
+		ColumnExpr("u.id AS user_id").
+		ColumnExpr("p.id AS policy_id").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.IAMUserToPolicy) []uuid.UUID {
+		return []uuid.UUID{l.UserID, l.PolicyID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (user_id, policy_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws iam user with policy", "count", count)
+
+	return nil
+}
+
+// LinkRDSInstanceWithVPC creates links between the AWS RDS instances and the
+// VPCs of their DB subnet groups.
+func LinkRDSInstanceWithVPC(ctx context.Context, db bun.IDB) error {
+	var instances []models.RDSInstance
+	err := db.NewSelect().
+		Model(&instances).
+		Relation("VPC").
+		Where("vpc.id IS NOT NULL").
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.RDSInstanceToVPC, 0, len(instances))
+	for _, instance := range instances {
+		link := models.RDSInstanceToVPC{
+			RDSInstanceID: instance.ID,
+			VPCID:         instance.VPC.ID,
+		}
+		links = append(links, link)
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.RDSInstanceToVPC) []uuid.UUID {
+		return []uuid.UUID{l.RDSInstanceID, l.VPCID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (rds_instance_id, vpc_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws rds instance with vpc", "count", count)
+
+	return nil
+}
+
+// LinkRDSInstanceWithSubnet creates links between the AWS RDS instances and the
+// subnets, which are members of their DB subnet groups.
+func LinkRDSInstanceWithSubnet(ctx context.Context, db bun.IDB) error {
+	links := make([]models.RDSInstanceToSubnet, 0)
+	err := db.NewSelect().
+		TableExpr("aws_rds_instance AS ri").
+		Join("CROSS JOIN LATERAL unnest(ri.subnet_ids) AS si(subnet_id)").
+		Join("INNER JOIN aws_subnet AS s").
+		JoinOn("s.subnet_id = si.subnet_id").
+		JoinOn("s.account_id = ri.account_id").
+		ColumnExpr("ri.id AS rds_instance_id").
+		ColumnExpr("s.id AS subnet_id").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.RDSInstanceToSubnet) []uuid.UUID {
+		return []uuid.UUID{l.RDSInstanceID, l.SubnetID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (rds_instance_id, subnet_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws rds instance with subnet", "count", count)
+
+	return nil
+}
+
+// LinkVolumeWithInstance creates links between the AWS EBS volumes and the
+// instances, to which they are attached.
+func LinkVolumeWithInstance(ctx context.Context, db bun.IDB) error {
+	links := make([]models.VolumeToInstance, 0)
+	err := db.NewSelect().
+		TableExpr("aws_volume AS v").
+		Join("CROSS JOIN LATERAL unnest(v.instance_ids) AS vi(instance_id)").
+		Join("INNER JOIN aws_instance AS i").
+		JoinOn("i.instance_id = vi.instance_id").
+		JoinOn("i.account_id = v.account_id").
+		ColumnExpr("v.id AS volume_id").
+		ColumnExpr("i.id AS instance_id").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.VolumeToInstance) []uuid.UUID {
+		return []uuid.UUID{l.VolumeID, l.InstanceID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (volume_id, instance_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws volume with instance", "count", count)
+
+	return nil
+}
+
+// LinkListenerWithLoadBalancer creates links between the AWS ELB v2 listeners
+// and their load balancers.
+func LinkListenerWithLoadBalancer(ctx context.Context, db bun.IDB) error {
+	links := make([]models.ListenerToLoadBalancer, 0)
+	err := db.NewSelect().
+		TableExpr("aws_lb_listener AS l").
+		Join("INNER JOIN aws_loadbalancer AS lb").
+		JoinOn("l.lb_arn = lb.arn").
+		JoinOn("l.account_id = lb.account_id").
+		ColumnExpr("l.id AS listener_id").
+		ColumnExpr("lb.id AS lb_id").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.ListenerToLoadBalancer) []uuid.UUID {
+		return []uuid.UUID{l.ListenerID, l.LoadBalancerID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (listener_id, lb_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws lb listener with lb", "count", count)
+
+	return nil
+}
+
+// LinkTargetGroupWithInstance creates links between the AWS ELB v2 target
+// groups and the instances, which are registered as targets.
+func LinkTargetGroupWithInstance(ctx context.Context, db bun.IDB) error {
+	links := make([]models.TargetGroupToInstance, 0)
+	err := db.NewSelect().
+		TableExpr("aws_target_group AS tg").
+		Join("CROSS JOIN LATERAL unnest(tg.target_ids) AS tt(target_id)").
+		Join("INNER JOIN aws_instance AS i").
+		JoinOn("i.instance_id = tt.target_id").
+		JoinOn("i.account_id = tg.account_id").
+		ColumnExpr("tg.id AS target_group_id").
+		ColumnExpr("i.id AS instance_id").
+		Where("tg.target_type = ?", string(elbv2types.TargetTypeEnumInstance)).
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.TargetGroupToInstance) []uuid.UUID {
+		return []uuid.UUID{l.TargetGroupID, l.InstanceID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (target_group_id, instance_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws target group with instance", "count", count)
+
+	return nil
+}
+
+// LinkEKSClusterWithVPC creates links between the AWS EKS clusters and the
+// VPCs from their VPC configuration.
+func LinkEKSClusterWithVPC(ctx context.Context, db bun.IDB) error {
+	var clusters []models.EKSCluster
+	err := db.NewSelect().
+		Model(&clusters).
+		Relation("VPC").
+		Where("vpc.id IS NOT NULL").
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.EKSClusterToVPC, 0, len(clusters))
+	for _, cluster := range clusters {
+		link := models.EKSClusterToVPC{
+			ClusterID: cluster.ID,
+			VPCID:     cluster.VPC.ID,
+		}
+		links = append(links, link)
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.EKSClusterToVPC) []uuid.UUID {
+		return []uuid.UUID{l.ClusterID, l.VPCID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (cluster_id, vpc_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked aws eks cluster with vpc", "count", count)
+
+	return nil
+}
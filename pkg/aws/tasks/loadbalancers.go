@@ -7,7 +7,6 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"strings"
 
 	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
@@ -16,16 +15,16 @@ import (
 	v2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/constants"
 	"github.com/gardener/inventory/pkg/aws/models"
-	awsutils "github.com/gardener/inventory/pkg/aws/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -44,6 +43,11 @@ type CollectLoadBalancersPayload struct {
 	// AccountID specifies the AWS Account ID, which is associated with a
 	// registered client.
 	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
 }
 
 // NewCollectLoadBalancersTask creates a new [asynq.Task] for collecting AWS
@@ -58,7 +62,7 @@ func HandleCollectLoadBalancersTask(ctx context.Context, t *asynq.Task) error {
 	// collecting ELBs from all known regions and their respective accounts.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectLoadBalancers(ctx)
+		return enqueueCollectLoadBalancers(ctx, nil)
 	}
 
 	var payload CollectLoadBalancersPayload
@@ -66,6 +70,12 @@ func HandleCollectLoadBalancersTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// Enqueue tasks only for the given regions, if no specific region
+	// has been requested.
+	if payload.Region == "" && len(payload.Regions) > 0 {
+		return enqueueCollectLoadBalancers(ctx, payload.Regions)
+	}
+
 	if payload.AccountID == "" {
 		return asynqutils.SkipRetry(ErrNoAccountID)
 	}
@@ -79,14 +89,17 @@ func HandleCollectLoadBalancersTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectLoadBalancers enqueues tasks for collecting the ELBs from all
 // known AWS Regions.
-func enqueueCollectLoadBalancers(ctx context.Context) error {
-	regions, err := awsutils.GetRegionsFromDB(ctx)
+//
+// If region names are specified, tasks are enqueued only for these regions.
+func enqueueCollectLoadBalancers(ctx context.Context, regionNames []string) error {
+	regions, err := getRegions(ctx, regionNames)
 	if err != nil {
-		return fmt.Errorf("failed to get regions: %w", err)
+		return err
 	}
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
 
 	// Enqueue ELB collection tasks for each region
 	for _, r := range regions {
@@ -106,39 +119,28 @@ func enqueueCollectLoadBalancers(ctx context.Context) error {
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectLoadBalancers, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"region", r.Name,
-				"account_id", r.AccountID,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"region", r.Name,
-			"account_id", r.AccountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectLoadBalancers, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectLoadBalancers collects the AWS ELBs from the specified region in the
 // payload.
+//
+// The ELB v1 and v2 load balancers are stored in the same table, so they are
+// reconciled only after both of them were collected successfully.
 func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPayload) error {
 	logger := asynqutils.GetLogger(ctx)
+	complete := true
+	ids := make([]string, 0)
 	if awsclients.ELBClientset.Exists(payload.AccountID) {
-		if err := collectELBv1(ctx, payload); err != nil {
+		names, err := collectELBv1(ctx, payload)
+		ids = append(ids, names...)
+		if err != nil {
+			complete = false
 			logger.Error(
 				"failed to collect ELB v1",
 				"region", payload.Region,
@@ -147,6 +149,7 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 			)
 		}
 	} else {
+		complete = false
 		logger.Warn(
 			"AWS client not found",
 			"region", payload.Region,
@@ -155,7 +158,10 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 	}
 
 	if awsclients.ELBv2Clientset.Exists(payload.AccountID) {
-		if err := collectELBv2(ctx, payload); err != nil {
+		names, err := collectELBv2(ctx, payload)
+		ids = append(ids, names...)
+		if err != nil {
+			complete = false
 			logger.Error(
 				"failed to collect ELB v2",
 				"region", payload.Region,
@@ -164,6 +170,7 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 			)
 		}
 	} else {
+		complete = false
 		logger.Warn(
 			"AWS client not found",
 			"region", payload.Region,
@@ -171,14 +178,19 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 		)
 	}
 
-	return nil
+	if !complete {
+		return nil
+	}
+
+	return reconcileRegion[models.LoadBalancer](ctx, "load balancers", payload.AccountID, payload.Region, "dns_name", ids)
 }
 
-// collectELBv2 collects ELB v2 load balancers.
-func collectELBv2(ctx context.Context, payload CollectLoadBalancersPayload) error {
+// collectELBv2 collects ELB v2 load balancers, and returns the DNS names of
+// the collected load balancers.
+func collectELBv2(ctx context.Context, payload CollectLoadBalancersPayload) ([]string, error) {
 	client, ok := awsclients.ELBv2Clientset.Get(payload.AccountID)
 	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+		return nil, asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
 	}
 
 	logger := asynqutils.GetLogger(ctx)
@@ -215,12 +227,16 @@ func collectELBv2(ctx context.Context, payload CollectLoadBalancersPayload) erro
 				"reason", err,
 			)
 
-			return err
+			return nil, err
 		}
+
+		asynqutils.AddPages(ctx, 1)
+
 		items = append(items, page.LoadBalancers...)
 	}
 
 	lbs := make([]models.LoadBalancer, 0, len(items))
+	names := make([]string, 0, len(items))
 	for _, lb := range items {
 		// Get the LoadBalancerID from the last component of the ARN
 		arn := ptr.StringFromPointer(lb.LoadBalancerArn)
@@ -241,27 +257,27 @@ func collectELBv2(ctx context.Context, payload CollectLoadBalancersPayload) erro
 			RegionName:            payload.Region,
 		}
 		lbs = append(lbs, item)
+		names = append(names, item.DNSName)
 	}
 
 	if len(lbs) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&lbs).
-		On("CONFLICT (dns_name, account_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("arn = EXCLUDED.arn").
-		Set("load_balancer_id = EXCLUDED.load_balancer_id").
-		Set("canonical_hosted_zone_id = EXCLUDED.canonical_hosted_zone_id").
-		Set("state = EXCLUDED.state").
-		Set("scheme = EXCLUDED.scheme").
-		Set("type = EXCLUDED.type").
-		Set("vpc_id = EXCLUDED.vpc_id").
-		Set("region_name = EXCLUDED.region_name").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, lbs, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (dns_name, account_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("arn = EXCLUDED.arn").
+			Set("load_balancer_id = EXCLUDED.load_balancer_id").
+			Set("canonical_hosted_zone_id = EXCLUDED.canonical_hosted_zone_id").
+			Set("state = EXCLUDED.state").
+			Set("scheme = EXCLUDED.scheme").
+			Set("type = EXCLUDED.type").
+			Set("vpc_id = EXCLUDED.vpc_id").
+			Set("region_name = EXCLUDED.region_name").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -271,12 +287,7 @@ func collectELBv2(ctx context.Context, payload CollectLoadBalancersPayload) erro
 			"reason", err,
 		)
 
-		return err
-	}
-
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Info(
@@ -303,14 +314,15 @@ func collectELBv2(ctx context.Context, payload CollectLoadBalancersPayload) erro
 		metrics.DefaultCollector.AddMetric(key, metric)
 	}
 
-	return nil
+	return names, nil
 }
 
-// collectELBv1 collects ELB v1 (classic) load balancers.
-func collectELBv1(ctx context.Context, payload CollectLoadBalancersPayload) error {
+// collectELBv1 collects ELB v1 (classic) load balancers, and returns the DNS
+// names of the collected load balancers.
+func collectELBv1(ctx context.Context, payload CollectLoadBalancersPayload) ([]string, error) {
 	client, ok := awsclients.ELBClientset.Get(payload.AccountID)
 	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+		return nil, asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
 	}
 
 	logger := asynqutils.GetLogger(ctx)
@@ -347,12 +359,16 @@ func collectELBv1(ctx context.Context, payload CollectLoadBalancersPayload) erro
 				"reason", err,
 			)
 
-			return err
+			return nil, err
 		}
+
+		asynqutils.AddPages(ctx, 1)
+
 		items = append(items, page.LoadBalancerDescriptions...)
 	}
 
 	lbs := make([]models.LoadBalancer, 0, len(items))
+	names := make([]string, 0, len(items))
 	for _, lb := range items {
 		item := models.LoadBalancer{
 			Name:                  ptr.StringFromPointer(lb.LoadBalancerName),
@@ -365,25 +381,25 @@ func collectELBv1(ctx context.Context, payload CollectLoadBalancersPayload) erro
 			RegionName:            payload.Region,
 		}
 		lbs = append(lbs, item)
+		names = append(names, item.DNSName)
 	}
 
 	if len(lbs) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&lbs).
-		On("CONFLICT (dns_name, account_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("canonical_hosted_zone_id = EXCLUDED.canonical_hosted_zone_id").
-		Set("state = EXCLUDED.state").
-		Set("scheme = EXCLUDED.scheme").
-		Set("type = EXCLUDED.type").
-		Set("vpc_id = EXCLUDED.vpc_id").
-		Set("region_name = EXCLUDED.region_name").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, lbs, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (dns_name, account_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("canonical_hosted_zone_id = EXCLUDED.canonical_hosted_zone_id").
+			Set("state = EXCLUDED.state").
+			Set("scheme = EXCLUDED.scheme").
+			Set("type = EXCLUDED.type").
+			Set("vpc_id = EXCLUDED.vpc_id").
+			Set("region_name = EXCLUDED.region_name").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -393,12 +409,7 @@ func collectELBv1(ctx context.Context, payload CollectLoadBalancersPayload) erro
 			"reason", err,
 		)
 
-		return err
-	}
-
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Info(
@@ -408,5 +419,5 @@ func collectELBv1(ctx context.Context, payload CollectLoadBalancersPayload) erro
 		"count", count,
 	)
 
-	return nil
+	return names, nil
 }
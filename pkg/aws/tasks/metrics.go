@@ -14,7 +14,7 @@ var (
 	// regionsDesc is the descriptor for a metric, which tracks the number
 	// of collected AWS regions.
 	regionsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_regions"),
+		"aws_regions",
 		"A gauge which tracks the number of collected AWS Regions",
 		[]string{"account_id"},
 		nil,
@@ -23,7 +23,7 @@ var (
 	// bucketsDesc is the descriptor for a metric, which tracks the number
 	// of collected AWS S3 buckets.
 	bucketsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_buckets"),
+		"aws_buckets",
 		"A gauge which tracks the number of collected AWS S3 buckets",
 		[]string{"account_id", "region"},
 		nil,
@@ -32,7 +32,7 @@ var (
 	// imagesDesc is the descriptor for a metric, which tracks the number
 	// of collected AWS AMI images.
 	imagesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_images"),
+		"aws_images",
 		"A gauge which tracks the number of collected AWS AMI images",
 		[]string{"account_id", "region"},
 		nil,
@@ -41,7 +41,7 @@ var (
 	// zonesDesc is the descriptor for a metric, which tracks the number
 	// of collected AWS Availability Zones.
 	zonesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_zones"),
+		"aws_zones",
 		"A gauge which tracks the number of collected AWS AZs",
 		[]string{"account_id", "region"},
 		nil,
@@ -50,7 +50,7 @@ var (
 	// vpcsDesc is the descriptor for a metric, which tracks the number
 	// of collected AWS VPCs.
 	vpcsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_vpcs"),
+		"aws_vpcs",
 		"A gauge which tracks the number of collected AWS VPCs",
 		[]string{"account_id", "region"},
 		nil,
@@ -59,7 +59,7 @@ var (
 	// subnetsDesc is the descriptor for a metric, which tracks the number
 	// of collected AWS Subnets.
 	subnetsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_subnets"),
+		"aws_subnets",
 		"A gauge which tracks the number of collected AWS Subnets",
 		[]string{"account_id", "region", "vpc_id"},
 		nil,
@@ -68,7 +68,7 @@ var (
 	// instancesDesc is the descriptor for a metric, which tracks the number
 	// of collected AWS EC2 instances.
 	instancesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_instances"),
+		"aws_instances",
 		"A gauge which tracks the number of collected AWS EC2 Instances",
 		[]string{"account_id", "region", "vpc_id"},
 		nil,
@@ -77,7 +77,7 @@ var (
 	// loadBalancersDesc is the descriptor for a metric, which tracks the number
 	// of collected AWS Elastic Load Balancers (ELBs).
 	loadBalancersDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_load_balancers"),
+		"aws_load_balancers",
 		"A gauge which tracks the number of collected AWS ELBs",
 		[]string{"account_id", "region", "vpc_id"},
 		nil,
@@ -86,11 +86,148 @@ var (
 	// netInterfacesDesc is the descriptor for a metric, which tracks the
 	// number of collected AWS Elastic Network Interfaces (ENIs).
 	netInterfacesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "aws_net_interfaces"),
+		"aws_net_interfaces",
 		"A gauge which tracks the number of collected AWS ENIs",
 		[]string{"account_id", "region", "vpc_id"},
 		nil,
 	)
+
+	// configRulesDesc is the descriptor for a metric, which tracks the
+	// number of collected AWS Config Rules.
+	configRulesDesc = prometheus.NewDesc(
+		"aws_config_rules",
+		"A gauge which tracks the number of collected AWS Config Rules",
+		[]string{"account_id", "region"},
+		nil,
+	)
+
+	// nonCompliantResourcesDesc is the descriptor for a metric, which
+	// tracks the number of resources, which are not compliant with an AWS
+	// Config Rule.
+	nonCompliantResourcesDesc = prometheus.NewDesc(
+		"aws_non_compliant_resources",
+		"A gauge which tracks the number of non-compliant resources per AWS Config Rule",
+		[]string{"account_id", "region", "rule_name"},
+		nil,
+	)
+
+	// snsTopicsDesc is the descriptor for a metric, which tracks the
+	// number of collected AWS SNS topics.
+	snsTopicsDesc = prometheus.NewDesc(
+		"aws_sns_topics",
+		"A gauge which tracks the number of collected AWS SNS topics",
+		[]string{"account_id", "region"},
+		nil,
+	)
+
+	// snsSubscriptionsDesc is the descriptor for a metric, which tracks
+	// the number of collected AWS SNS subscriptions.
+	snsSubscriptionsDesc = prometheus.NewDesc(
+		"aws_sns_subscriptions",
+		"A gauge which tracks the number of collected AWS SNS subscriptions",
+		[]string{"account_id", "region"},
+		nil,
+	)
+
+	// snsExposedSubscriptionsDesc is the descriptor for a metric, which
+	// tracks the number of AWS SNS subscriptions with public or
+	// cross-account endpoints.
+	snsExposedSubscriptionsDesc = prometheus.NewDesc(
+		"aws_sns_exposed_subscriptions",
+		"A gauge which tracks the number of AWS SNS subscriptions with public or cross-account endpoints",
+		[]string{"account_id", "region"},
+		nil,
+	)
+
+	// iamUsersDesc is the descriptor for a metric, which tracks the number
+	// of collected AWS IAM users.
+	iamUsersDesc = prometheus.NewDesc(
+		"aws_iam_users",
+		"A gauge which tracks the number of collected AWS IAM users",
+		[]string{"account_id"},
+		nil,
+	)
+
+	// iamStaleAccessKeysDesc is the descriptor for a metric, which tracks
+	// the number of AWS IAM access keys older than the configured max age.
+	iamStaleAccessKeysDesc = prometheus.NewDesc(
+		"aws_iam_stale_access_keys",
+		"A gauge which tracks the number of AWS IAM access keys older than the configured max age",
+		[]string{"account_id"},
+		nil,
+	)
+
+	// iamConsoleUsersWithoutMFADesc is the descriptor for a metric, which
+	// tracks the number of AWS IAM users with console access, but no MFA.
+	iamConsoleUsersWithoutMFADesc = prometheus.NewDesc(
+		"aws_iam_console_users_without_mfa",
+		"A gauge which tracks the number of AWS IAM users with console access, but no MFA",
+		[]string{"account_id"},
+		nil,
+	)
+
+	// iamRolesDesc is the descriptor for a metric, which tracks the number
+	// of collected AWS IAM roles.
+	iamRolesDesc = prometheus.NewDesc(
+		"aws_iam_roles",
+		"A gauge which tracks the number of collected AWS IAM roles",
+		[]string{"account_id"},
+		nil,
+	)
+
+	// iamRolesTrustingExternalAccountsDesc is the descriptor for a metric,
+	// which tracks the number of AWS IAM roles trusting external accounts.
+	iamRolesTrustingExternalAccountsDesc = prometheus.NewDesc(
+		"aws_iam_roles_trusting_external_accounts",
+		"A gauge which tracks the number of AWS IAM roles trusting external accounts",
+		[]string{"account_id"},
+		nil,
+	)
+
+	// rdsInstancesDesc is the descriptor for a metric, which tracks the
+	// number of collected AWS RDS instances.
+	rdsInstancesDesc = prometheus.NewDesc(
+		"aws_rds_instances",
+		"A gauge which tracks the number of collected AWS RDS instances",
+		[]string{"account_id", "region"},
+		nil,
+	)
+
+	// volumesDesc is the descriptor for a metric, which tracks the number
+	// of collected AWS EBS volumes.
+	volumesDesc = prometheus.NewDesc(
+		"aws_volumes",
+		"A gauge which tracks the number of collected AWS EBS volumes",
+		[]string{"account_id", "region"},
+		nil,
+	)
+
+	// lbListenersDesc is the descriptor for a metric, which tracks the
+	// number of collected AWS ELB v2 listeners.
+	lbListenersDesc = prometheus.NewDesc(
+		"aws_lb_listeners",
+		"A gauge which tracks the number of collected AWS ELB v2 listeners",
+		[]string{"account_id", "region"},
+		nil,
+	)
+
+	// targetGroupsDesc is the descriptor for a metric, which tracks the
+	// number of collected AWS ELB v2 target groups.
+	targetGroupsDesc = prometheus.NewDesc(
+		"aws_target_groups",
+		"A gauge which tracks the number of collected AWS ELB v2 target groups",
+		[]string{"account_id", "region"},
+		nil,
+	)
+
+	// eksClustersDesc is the descriptor for a metric, which tracks the
+	// number of collected AWS EKS clusters.
+	eksClustersDesc = prometheus.NewDesc(
+		"aws_eks_clusters",
+		"A gauge which tracks the number of collected AWS EKS clusters",
+		[]string{"account_id", "region"},
+		nil,
+	)
 )
 
 // init registers the metrics with the [metrics.DefaultCollector]
@@ -105,5 +242,20 @@ func init() {
 		instancesDesc,
 		loadBalancersDesc,
 		netInterfacesDesc,
+		configRulesDesc,
+		nonCompliantResourcesDesc,
+		snsTopicsDesc,
+		snsSubscriptionsDesc,
+		snsExposedSubscriptionsDesc,
+		iamUsersDesc,
+		iamStaleAccessKeysDesc,
+		iamConsoleUsersWithoutMFADesc,
+		iamRolesDesc,
+		iamRolesTrustingExternalAccountsDesc,
+		rdsInstancesDesc,
+		volumesDesc,
+		lbListenersDesc,
+		targetGroupsDesc,
+		eksClustersDesc,
 	)
 }
@@ -7,22 +7,21 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/constants"
 	"github.com/gardener/inventory/pkg/aws/models"
-	awsutils "github.com/gardener/inventory/pkg/aws/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -41,6 +40,11 @@ type CollectNetworkInterfacesPayload struct {
 	// AccountID specifies the AWS Account ID, which is associated with a
 	// registered client.
 	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
 }
 
 // NewCollectNetworkInterfacesTask creates a new [asynq.Task] for collecting AWS
@@ -56,7 +60,7 @@ func HandleCollectNetworkInterfacesTask(ctx context.Context, t *asynq.Task) erro
 	// collecting ENIs from all known regions and their respective accounts.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectENIs(ctx)
+		return enqueueCollectENIs(ctx, nil)
 	}
 
 	var payload CollectNetworkInterfacesPayload
@@ -64,6 +68,12 @@ func HandleCollectNetworkInterfacesTask(ctx context.Context, t *asynq.Task) erro
 		return asynqutils.SkipRetry(err)
 	}
 
+	// Enqueue tasks only for the given regions, if no specific region
+	// has been requested.
+	if payload.Region == "" && len(payload.Regions) > 0 {
+		return enqueueCollectENIs(ctx, payload.Regions)
+	}
+
 	if payload.AccountID == "" {
 		return asynqutils.SkipRetry(ErrNoAccountID)
 	}
@@ -77,14 +87,17 @@ func HandleCollectNetworkInterfacesTask(ctx context.Context, t *asynq.Task) erro
 
 // enqueueCollectENIs enqueues tasks for collecting AWS ENIs for the known
 // regions and accounts.
-func enqueueCollectENIs(ctx context.Context) error {
-	regions, err := awsutils.GetRegionsFromDB(ctx)
+//
+// If region names are specified, tasks are enqueued only for these regions.
+func enqueueCollectENIs(ctx context.Context, regionNames []string) error {
+	regions, err := getRegions(ctx, regionNames)
 	if err != nil {
-		return fmt.Errorf("failed to get regions: %w", err)
+		return err
 	}
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
 
 	// Enqueue ENI collection for each region
 	for _, r := range regions {
@@ -114,31 +127,12 @@ func enqueueCollectENIs(ctx context.Context) error {
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectNetworkInterfaces, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"region", r.Name,
-				"account_id", r.AccountID,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"region", r.Name,
-			"account_id", r.AccountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectNetworkInterfaces, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectENIs collects the AWS ENIs from the specified region using the client
@@ -185,11 +179,15 @@ func collectENIs(ctx context.Context, payload CollectNetworkInterfacesPayload) e
 
 			return err
 		}
+
+		asynqutils.AddPages(ctx, 1)
+
 		items = append(items, page.NetworkInterfaces...)
 	}
 
 	// Create model instances from the collected data
 	networkInterfaces := make([]models.NetworkInterface, 0, len(items))
+	ids := make([]string, 0, len(items))
 	for _, item := range items {
 		netInterface := models.NetworkInterface{
 			RegionName:       payload.Region,
@@ -230,42 +228,42 @@ func collectENIs(ctx context.Context, payload CollectNetworkInterfacesPayload) e
 		}
 
 		networkInterfaces = append(networkInterfaces, netInterface)
+		ids = append(ids, netInterface.InterfaceID)
 	}
 
 	if len(networkInterfaces) == 0 {
-		return nil
+		return reconcileRegion[models.NetworkInterface](ctx, "network interfaces", payload.AccountID, payload.Region, "interface_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&networkInterfaces).
-		On("CONFLICT (interface_id, account_id) DO UPDATE").
-		Set("az = EXCLUDED.az").
-		Set("description = EXCLUDED.description").
-		Set("interface_type = EXCLUDED.interface_type").
-		Set("mac_address = EXCLUDED.mac_address").
-		Set("owner_id = EXCLUDED.owner_id").
-		Set("private_dns_name = EXCLUDED.private_dns_name").
-		Set("private_ip_address = EXCLUDED.private_ip_address").
-		Set("requester_id = EXCLUDED.requester_id").
-		Set("requester_managed = EXCLUDED.requester_managed").
-		Set("src_dst_check = EXCLUDED.src_dst_check").
-		Set("status = EXCLUDED.status").
-		Set("subnet_id = EXCLUDED.subnet_id").
-		Set("vpc_id = EXCLUDED.vpc_id").
-		Set("allocation_id = EXCLUDED.allocation_id").
-		Set("association_id = EXCLUDED.association_id").
-		Set("ip_owner_id = EXCLUDED.ip_owner_id").
-		Set("public_dns_name = EXCLUDED.public_dns_name").
-		Set("public_ip_address = EXCLUDED.public_ip_address").
-		Set("attachment_id = EXCLUDED.attachment_id").
-		Set("delete_on_termination = EXCLUDED.delete_on_termination").
-		Set("device_index = EXCLUDED.device_index").
-		Set("instance_id = EXCLUDED.instance_id").
-		Set("instance_owner_id = EXCLUDED.instance_owner_id").
-		Set("attachment_status = EXCLUDED.attachment_status").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, networkInterfaces, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (interface_id, account_id) DO UPDATE").
+			Set("az = EXCLUDED.az").
+			Set("description = EXCLUDED.description").
+			Set("interface_type = EXCLUDED.interface_type").
+			Set("mac_address = EXCLUDED.mac_address").
+			Set("owner_id = EXCLUDED.owner_id").
+			Set("private_dns_name = EXCLUDED.private_dns_name").
+			Set("private_ip_address = EXCLUDED.private_ip_address").
+			Set("requester_id = EXCLUDED.requester_id").
+			Set("requester_managed = EXCLUDED.requester_managed").
+			Set("src_dst_check = EXCLUDED.src_dst_check").
+			Set("status = EXCLUDED.status").
+			Set("subnet_id = EXCLUDED.subnet_id").
+			Set("vpc_id = EXCLUDED.vpc_id").
+			Set("allocation_id = EXCLUDED.allocation_id").
+			Set("association_id = EXCLUDED.association_id").
+			Set("ip_owner_id = EXCLUDED.ip_owner_id").
+			Set("public_dns_name = EXCLUDED.public_dns_name").
+			Set("public_ip_address = EXCLUDED.public_ip_address").
+			Set("attachment_id = EXCLUDED.attachment_id").
+			Set("delete_on_termination = EXCLUDED.delete_on_termination").
+			Set("device_index = EXCLUDED.device_index").
+			Set("instance_id = EXCLUDED.instance_id").
+			Set("instance_owner_id = EXCLUDED.instance_owner_id").
+			Set("attachment_status = EXCLUDED.attachment_status").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -278,11 +276,6 @@ func collectENIs(ctx context.Context, payload CollectNetworkInterfacesPayload) e
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated aws network interfaces",
 		"region", payload.Region,
@@ -307,5 +300,5 @@ func collectENIs(ctx context.Context, payload CollectNetworkInterfacesPayload) e
 		metrics.DefaultCollector.AddMetric(key, metric)
 	}
 
-	return nil
+	return reconcileRegion[models.NetworkInterface](ctx, "network interfaces", payload.AccountID, payload.Region, "interface_id", ids)
 }
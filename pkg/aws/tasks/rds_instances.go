@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectRDSInstances is the name of the task for collecting AWS
+	// RDS instances.
+	TaskCollectRDSInstances = "aws:task:collect-rds-instances"
+)
+
+// CollectRDSInstancesPayload is the payload, which is used for collecting AWS
+// RDS instances.
+type CollectRDSInstancesPayload struct {
+	// Region is the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+}
+
+// NewCollectRDSInstancesTask creates a new [asynq.Task] for collecting AWS RDS
+// instances, without specifying a payload.
+func NewCollectRDSInstancesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectRDSInstances, nil)
+}
+
+// HandleCollectRDSInstancesTask handles the task for collecting AWS RDS
+// instances.
+func HandleCollectRDSInstancesTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting the RDS instances for all known regions.
+	data := t.Payload()
+	if data == nil {
+		newPayload := func(region, accountID string) any {
+			return CollectRDSInstancesPayload{Region: region, AccountID: accountID}
+		}
+
+		return enqueueOptionalServiceTasks(ctx, TaskCollectRDSInstances, awsclients.RDSClientset.Exists, newPayload)
+	}
+
+	var payload CollectRDSInstancesPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	return collectRDSInstances(ctx, payload)
+}
+
+// listRDSInstances returns the RDS instances from the given region.
+func listRDSInstances(ctx context.Context, client *rds.Client, region string) ([]types.DBInstance, error) {
+	paginator := rds.NewDescribeDBInstancesPaginator(
+		client,
+		&rds.DescribeDBInstancesInput{},
+		func(params *rds.DescribeDBInstancesPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]types.DBInstance, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(
+			ctx,
+			func(o *rds.Options) {
+				o.Region = region
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		items = append(items, page.DBInstances...)
+	}
+
+	return items, nil
+}
+
+// collectRDSInstances collects the AWS RDS instances for the specified region
+// and using the client associated with the given account id from the payload.
+func collectRDSInstances(ctx context.Context, payload CollectRDSInstancesPayload) error {
+	client, ok := awsclients.RDSClientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			rdsInstancesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+			payload.Region,
+		)
+		key := metrics.Key(TaskCollectRDSInstances, payload.AccountID, payload.Region)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info(
+		"collecting AWS RDS instances",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+	)
+
+	instances, err := listRDSInstances(ctx, client.Client, payload.Region)
+	if err != nil {
+		logger.Error(
+			"could not list rds instances",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	if len(instances) == 0 {
+		return nil
+	}
+
+	items := make([]models.RDSInstance, 0, len(instances))
+	for _, instance := range instances {
+		var endpoint types.Endpoint
+		if instance.Endpoint != nil {
+			endpoint = *instance.Endpoint
+		}
+
+		var subnetGroup types.DBSubnetGroup
+		if instance.DBSubnetGroup != nil {
+			subnetGroup = *instance.DBSubnetGroup
+		}
+
+		subnetIDs := make([]string, 0, len(subnetGroup.Subnets))
+		for _, subnet := range subnetGroup.Subnets {
+			subnetIDs = append(subnetIDs, ptr.StringFromPointer(subnet.SubnetIdentifier))
+		}
+
+		item := models.RDSInstance{
+			DBInstanceARN:        ptr.StringFromPointer(instance.DBInstanceArn),
+			DBInstanceIdentifier: ptr.StringFromPointer(instance.DBInstanceIdentifier),
+			ResourceID:           ptr.StringFromPointer(instance.DbiResourceId),
+			InstanceClass:        ptr.StringFromPointer(instance.DBInstanceClass),
+			Engine:               ptr.StringFromPointer(instance.Engine),
+			EngineVersion:        ptr.StringFromPointer(instance.EngineVersion),
+			Status:               ptr.StringFromPointer(instance.DBInstanceStatus),
+			AZ:                   ptr.StringFromPointer(instance.AvailabilityZone),
+			MultiAZ:              ptr.Value(instance.MultiAZ, false),
+			PubliclyAccessible:   ptr.Value(instance.PubliclyAccessible, false),
+			StorageEncrypted:     ptr.Value(instance.StorageEncrypted, false),
+			AllocatedStorage:     int(ptr.Value(instance.AllocatedStorage, 0)),
+			EndpointAddress:      ptr.StringFromPointer(endpoint.Address),
+			EndpointPort:         int(ptr.Value(endpoint.Port, 0)),
+			DBSubnetGroup:        ptr.StringFromPointer(subnetGroup.DBSubnetGroupName),
+			VpcID:                ptr.StringFromPointer(subnetGroup.VpcId),
+			AccountID:            payload.AccountID,
+			RegionName:           payload.Region,
+			InstanceCreatedAt:    ptr.Value(instance.InstanceCreateTime, time.Time{}),
+			SubnetIDs:            subnetIDs,
+		}
+		items = append(items, item)
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (db_instance_arn) DO UPDATE").
+			Set("db_instance_identifier = EXCLUDED.db_instance_identifier").
+			Set("resource_id = EXCLUDED.resource_id").
+			Set("instance_class = EXCLUDED.instance_class").
+			Set("engine = EXCLUDED.engine").
+			Set("engine_version = EXCLUDED.engine_version").
+			Set("status = EXCLUDED.status").
+			Set("az = EXCLUDED.az").
+			Set("multi_az = EXCLUDED.multi_az").
+			Set("publicly_accessible = EXCLUDED.publicly_accessible").
+			Set("storage_encrypted = EXCLUDED.storage_encrypted").
+			Set("allocated_storage = EXCLUDED.allocated_storage").
+			Set("endpoint_address = EXCLUDED.endpoint_address").
+			Set("endpoint_port = EXCLUDED.endpoint_port").
+			Set("db_subnet_group = EXCLUDED.db_subnet_group").
+			Set("vpc_id = EXCLUDED.vpc_id").
+			Set("subnet_ids = EXCLUDED.subnet_ids").
+			Set("account_id = EXCLUDED.account_id").
+			Set("region_name = EXCLUDED.region_name").
+			Set("instance_created_at = EXCLUDED.instance_created_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert aws rds instances into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws rds instances",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+	)
+
+	return nil
+}
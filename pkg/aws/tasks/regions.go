@@ -7,18 +7,21 @@ package tasks
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/models"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
+	awsutils "github.com/gardener/inventory/pkg/aws/utils"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -75,7 +78,12 @@ func enqueueCollectRegions(ctx context.Context) error {
 	}
 
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, awsclients.EC2Clientset.Length())
 	err := awsclients.EC2Clientset.Range(func(accountID string, _ *awsclients.Client[*ec2.Client]) error {
+		if !isAccountSelected(ctx, accountID) {
+			return nil
+		}
+
 		p := &CollectRegionsPayload{AccountID: accountID}
 		data, err := json.Marshal(p)
 		if err != nil {
@@ -88,31 +96,18 @@ func enqueueCollectRegions(ctx context.Context) error {
 			return registry.ErrContinue
 		}
 
-		task := asynq.NewTask(TaskCollectRegions, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"account_id", accountID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"account_id", accountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectRegions, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectRegions collects the AWS regions using the client configuration
@@ -165,14 +160,13 @@ func collectRegions(ctx context.Context, payload CollectRegionsPayload) error {
 	}
 
 	// Bulk insert regions into db
-	out, err := db.DB.NewInsert().
-		Model(&regions).
-		On("CONFLICT (name, account_id) DO UPDATE").
-		Set("endpoint = EXCLUDED.endpoint").
-		Set("opt_in_status = EXCLUDED.opt_in_status").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, regions, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, account_id) DO UPDATE").
+			Set("endpoint = EXCLUDED.endpoint").
+			Set("opt_in_status = EXCLUDED.opt_in_status").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -184,11 +178,6 @@ func collectRegions(ctx context.Context, payload CollectRegionsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated aws regions",
 		"account_id", payload.AccountID,
@@ -197,3 +186,20 @@ func collectRegions(ctx context.Context, payload CollectRegionsPayload) error {
 
 	return nil
 }
+
+// getRegions returns the known regions from the database, which match the
+// given region names. If no names are specified, all known regions are
+// returned. Unknown region names result in an error, which is not retried.
+func getRegions(ctx context.Context, names []string) ([]models.Region, error) {
+	regions, err := awsutils.GetRegionsFromDB(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regions: %w", err)
+	}
+
+	items, err := awsutils.FilterRegions(regions, names)
+	if err != nil {
+		return nil, asynqutils.SkipRetry(err)
+	}
+
+	return selectRegions(ctx, items), nil
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// isAccountSelected returns true, if the given AWS account is selected for
+// collection via the configured include and exclude lists. Accounts, which are
+// not selected are logged along with the reason.
+func isAccountSelected(ctx context.Context, accountID string) bool {
+	ok, reason := awsclients.AccountSelection.Check(accountID)
+	if !ok {
+		logger := asynqutils.GetLogger(ctx)
+		logger.Info(
+			"skipping aws account",
+			"account_id", accountID,
+			"reason", reason,
+		)
+	}
+
+	return ok
+}
+
+// selectRegions returns the regions, which belong to accounts selected for
+// collection. Each skipped account is logged only once.
+func selectRegions(ctx context.Context, regions []models.Region) []models.Region {
+	selected := make(map[string]bool)
+	items := make([]models.Region, 0, len(regions))
+	for _, r := range regions {
+		ok, seen := selected[r.AccountID]
+		if !seen {
+			ok = isAccountSelected(ctx, r.AccountID)
+			selected[r.AccountID] = ok
+		}
+
+		if ok {
+			items = append(items, r)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectSNSSubscriptions is the name of the task for collecting
+	// AWS SNS subscriptions.
+	TaskCollectSNSSubscriptions = "aws:task:collect-sns-subscriptions"
+)
+
+// publicSNSProtocols specifies the SNS subscription protocols, which deliver
+// messages to endpoints outside of AWS.
+var publicSNSProtocols = []string{
+	"http",
+	"https",
+	"email",
+	"email-json",
+	"sms",
+}
+
+// CollectSNSSubscriptionsPayload is the payload, which is used for collecting
+// AWS SNS subscriptions.
+type CollectSNSSubscriptionsPayload struct {
+	// Region is the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+}
+
+// NewCollectSNSSubscriptionsTask creates a new [asynq.Task] for collecting AWS
+// SNS subscriptions, without specifying a payload.
+func NewCollectSNSSubscriptionsTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectSNSSubscriptions, nil)
+}
+
+// HandleCollectSNSSubscriptionsTask handles the task for collecting AWS SNS
+// subscriptions.
+func HandleCollectSNSSubscriptionsTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting the SNS subscriptions for all known regions.
+	data := t.Payload()
+	if data == nil {
+		newPayload := func(region, accountID string) any {
+			return CollectSNSSubscriptionsPayload{Region: region, AccountID: accountID}
+		}
+
+		return enqueueOptionalServiceTasks(ctx, TaskCollectSNSSubscriptions, awsclients.SNSClientset.Exists, newPayload)
+	}
+
+	var payload CollectSNSSubscriptionsPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	return collectSNSSubscriptions(ctx, payload)
+}
+
+// listSNSSubscriptions returns the SNS subscriptions from the given region.
+func listSNSSubscriptions(ctx context.Context, client *sns.Client, region string) ([]types.Subscription, error) {
+	paginator := sns.NewListSubscriptionsPaginator(
+		client,
+		&sns.ListSubscriptionsInput{},
+		func(params *sns.ListSubscriptionsPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]types.Subscription, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(
+			ctx,
+			func(o *sns.Options) {
+				o.Region = region
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		items = append(items, page.Subscriptions...)
+	}
+
+	return items, nil
+}
+
+// isCrossAccountSNSEndpoint returns true, if the given subscription endpoint
+// is an AWS resource, which belongs to an account other than the given one.
+func isCrossAccountSNSEndpoint(endpoint, accountID string) bool {
+	parsed, err := arn.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+
+	return parsed.AccountID != "" && parsed.AccountID != accountID
+}
+
+// collectSNSSubscriptions collects the AWS SNS subscriptions for the specified
+// region and using the client associated with the given account id from the
+// payload.
+func collectSNSSubscriptions(ctx context.Context, payload CollectSNSSubscriptionsPayload) error {
+	client, ok := awsclients.SNSClientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count, exposedCount int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			snsSubscriptionsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+			payload.Region,
+		)
+		key := metrics.Key(TaskCollectSNSSubscriptions, payload.AccountID, payload.Region)
+		metrics.DefaultCollector.AddMetric(key, metric)
+
+		exposedMetric := prometheus.MustNewConstMetric(
+			snsExposedSubscriptionsDesc,
+			prometheus.GaugeValue,
+			float64(exposedCount),
+			payload.AccountID,
+			payload.Region,
+		)
+		exposedKey := metrics.Key(TaskCollectSNSSubscriptions, "exposed", payload.AccountID, payload.Region)
+		metrics.DefaultCollector.AddMetric(exposedKey, exposedMetric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info(
+		"collecting AWS SNS subscriptions",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+	)
+
+	subscriptions, err := listSNSSubscriptions(ctx, client.Client, payload.Region)
+	if err != nil {
+		logger.Error(
+			"could not list sns subscriptions",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	items := make([]models.SNSSubscription, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		// Subscriptions, which are pending confirmation or are being
+		// deleted don't have an ARN yet, so we skip them.
+		subscriptionARN := ptr.StringFromPointer(sub.SubscriptionArn)
+		if !arn.IsARN(subscriptionARN) {
+			asynqutils.AddSkipped(ctx, 1)
+
+			continue
+		}
+
+		protocol := ptr.StringFromPointer(sub.Protocol)
+		endpoint := ptr.StringFromPointer(sub.Endpoint)
+		item := models.SNSSubscription{
+			SubscriptionARN: subscriptionARN,
+			TopicARN:        ptr.StringFromPointer(sub.TopicArn),
+			Protocol:        protocol,
+			Endpoint:        endpoint,
+			Owner:           ptr.StringFromPointer(sub.Owner),
+			AccountID:       payload.AccountID,
+			RegionName:      payload.Region,
+			IsPublic:        slices.Contains(publicSNSProtocols, protocol),
+			IsCrossAccount:  isCrossAccountSNSEndpoint(endpoint, payload.AccountID),
+		}
+		if item.IsPublic || item.IsCrossAccount {
+			exposedCount++
+		}
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subscription_arn) DO UPDATE").
+			Set("topic_arn = EXCLUDED.topic_arn").
+			Set("protocol = EXCLUDED.protocol").
+			Set("endpoint = EXCLUDED.endpoint").
+			Set("owner = EXCLUDED.owner").
+			Set("account_id = EXCLUDED.account_id").
+			Set("region_name = EXCLUDED.region_name").
+			Set("is_public = EXCLUDED.is_public").
+			Set("is_cross_account = EXCLUDED.is_cross_account").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert aws sns subscriptions into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws sns subscriptions",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+		"exposed", exposedCount,
+	)
+
+	return nil
+}
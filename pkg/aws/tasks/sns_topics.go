@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectSNSTopics is the name of the task for collecting AWS SNS
+	// topics.
+	TaskCollectSNSTopics = "aws:task:collect-sns-topics"
+)
+
+// CollectSNSTopicsPayload is the payload, which is used for collecting AWS SNS
+// topics.
+type CollectSNSTopicsPayload struct {
+	// Region is the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+}
+
+// NewCollectSNSTopicsTask creates a new [asynq.Task] for collecting AWS SNS
+// topics, without specifying a payload.
+func NewCollectSNSTopicsTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectSNSTopics, nil)
+}
+
+// HandleCollectSNSTopicsTask handles the task for collecting AWS SNS topics.
+func HandleCollectSNSTopicsTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting the SNS topics for all known regions.
+	data := t.Payload()
+	if data == nil {
+		newPayload := func(region, accountID string) any {
+			return CollectSNSTopicsPayload{Region: region, AccountID: accountID}
+		}
+
+		return enqueueOptionalServiceTasks(ctx, TaskCollectSNSTopics, awsclients.SNSClientset.Exists, newPayload)
+	}
+
+	var payload CollectSNSTopicsPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	return collectSNSTopics(ctx, payload)
+}
+
+// listSNSTopics returns the SNS topics from the given region.
+func listSNSTopics(ctx context.Context, client *sns.Client, region string) ([]types.Topic, error) {
+	paginator := sns.NewListTopicsPaginator(
+		client,
+		&sns.ListTopicsInput{},
+		func(params *sns.ListTopicsPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]types.Topic, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(
+			ctx,
+			func(o *sns.Options) {
+				o.Region = region
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		items = append(items, page.Topics...)
+	}
+
+	return items, nil
+}
+
+// collectSNSTopics collects the AWS SNS topics for the specified region and
+// using the client associated with the given account id from the payload.
+func collectSNSTopics(ctx context.Context, payload CollectSNSTopicsPayload) error {
+	client, ok := awsclients.SNSClientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			snsTopicsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+			payload.Region,
+		)
+		key := metrics.Key(TaskCollectSNSTopics, payload.AccountID, payload.Region)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info(
+		"collecting AWS SNS topics",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+	)
+
+	topics, err := listSNSTopics(ctx, client.Client, payload.Region)
+	if err != nil {
+		logger.Error(
+			"could not list sns topics",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	if len(topics) == 0 {
+		return nil
+	}
+
+	items := make([]models.SNSTopic, 0, len(topics))
+	for _, topic := range topics {
+		topicARN := ptr.StringFromPointer(topic.TopicArn)
+		var name string
+		if parsed, err := arn.Parse(topicARN); err == nil {
+			name = parsed.Resource
+		}
+
+		item := models.SNSTopic{
+			TopicARN:   topicARN,
+			Name:       name,
+			AccountID:  payload.AccountID,
+			RegionName: payload.Region,
+		}
+
+		// Failing to get the attributes of a topic, e.g. because of
+		// a restrictive topic policy, should not prevent us from
+		// collecting the topic itself.
+		attrs, err := client.Client.GetTopicAttributes(
+			ctx,
+			&sns.GetTopicAttributesInput{TopicArn: topic.TopicArn},
+			func(o *sns.Options) {
+				o.Region = payload.Region
+			},
+		)
+		if err != nil {
+			logger.Warn(
+				"could not get sns topic attributes",
+				"region", payload.Region,
+				"account_id", payload.AccountID,
+				"topic_arn", topicARN,
+				"reason", err,
+			)
+		} else {
+			item.DisplayName = attrs.Attributes["DisplayName"]
+			item.Owner = attrs.Attributes["Owner"]
+			item.KMSKeyID = attrs.Attributes["KmsMasterKeyId"]
+		}
+
+		items = append(items, item)
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (topic_arn) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("display_name = EXCLUDED.display_name").
+			Set("owner = EXCLUDED.owner").
+			Set("kms_key_id = EXCLUDED.kms_key_id").
+			Set("account_id = EXCLUDED.account_id").
+			Set("region_name = EXCLUDED.region_name").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert aws sns topics into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws sns topics",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+	)
+
+	return nil
+}
@@ -7,22 +7,22 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/constants"
 	"github.com/gardener/inventory/pkg/aws/models"
 	awsutils "github.com/gardener/inventory/pkg/aws/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -40,6 +40,11 @@ type CollectSubnetsPayload struct {
 	// AccountID specifies the AWS Account ID, which is associated with a
 	// registered client.
 	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
 }
 
 // NewCollectSubnetsTask creates a new [asynq.Task] for collecting AWS Subnets,
@@ -55,7 +60,7 @@ func HandleCollectSubnetsTask(ctx context.Context, t *asynq.Task) error {
 	// collecting the subnets for all known regions.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectSubnets(ctx)
+		return enqueueCollectSubnets(ctx, nil)
 	}
 
 	var payload CollectSubnetsPayload
@@ -63,6 +68,12 @@ func HandleCollectSubnetsTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// Enqueue tasks only for the given regions, if no specific region
+	// has been requested.
+	if payload.Region == "" && len(payload.Regions) > 0 {
+		return enqueueCollectSubnets(ctx, payload.Regions)
+	}
+
 	if payload.Region == "" {
 		return asynqutils.SkipRetry(ErrNoRegion)
 	}
@@ -74,15 +85,20 @@ func HandleCollectSubnetsTask(ctx context.Context, t *asynq.Task) error {
 	return collectSubnets(ctx, payload)
 }
 
-func enqueueCollectSubnets(ctx context.Context) error {
+// enqueueCollectSubnets enqueues tasks for collecting AWS Subnets for the known
+// regions and accounts.
+//
+// If region names are specified, tasks are enqueued only for these regions.
+func enqueueCollectSubnets(ctx context.Context, regionNames []string) error {
 	// Get the known regions and enqueue a task for each
-	regions, err := awsutils.GetRegionsFromDB(ctx)
+	regions, err := getRegions(ctx, regionNames)
 	if err != nil {
-		return fmt.Errorf("failed to get regions: %w", err)
+		return err
 	}
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
 	for _, r := range regions {
 		if !awsclients.EC2Clientset.Exists(r.AccountID) {
 			logger.Warn(
@@ -109,31 +125,13 @@ func enqueueCollectSubnets(ctx context.Context) error {
 
 			continue
 		}
-		task := asynq.NewTask(TaskCollectSubnets, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"region", r.Name,
-				"account_id", r.AccountID,
-				"reason", err,
-			)
-
-			continue
-		}
 
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"region", r.Name,
-			"account_id", r.AccountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectSubnets, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectSubnets collects the AWS Subnets for the specified region and using
@@ -179,10 +177,15 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 
 			return err
 		}
+
+		asynqutils.AddPages(ctx, 1)
+
 		items = append(items, page.Subnets...)
 	}
 
 	subnets := make([]models.Subnet, 0, len(items))
+	tags := make([]models.Tag, 0)
+	taggedIDs := make([]string, 0)
 	for _, s := range items {
 		name := awsutils.FetchTag(s.Tags, "Name")
 		item := models.Subnet{
@@ -199,27 +202,34 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 			IPv6CIDR:               "", // TODO: fetch IPv6 CIDR
 		}
 		subnets = append(subnets, item)
+		taggedIDs = append(taggedIDs, item.SubnetID)
+		tags = append(tags, newEC2Tags(
+			payload.AccountID,
+			payload.Region,
+			models.TagResourceTypeSubnet,
+			item.SubnetID,
+			s.Tags,
+		)...)
 	}
 
 	if len(subnets) == 0 {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&subnets).
-		On("CONFLICT (subnet_id, account_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("subnet_arn = EXCLUDED.subnet_arn").
-		Set("vpc_id = EXCLUDED.vpc_id").
-		Set("state = EXCLUDED.state").
-		Set("az = EXCLUDED.az").
-		Set("az_id = EXCLUDED.az_id").
-		Set("available_ipv4_addresses = EXCLUDED.available_ipv4_addresses").
-		Set("ipv4_cidr = EXCLUDED.ipv4_cidr").
-		Set("ipv6_cidr = EXCLUDED.ipv6_cidr").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, subnets, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subnet_id, account_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("subnet_arn = EXCLUDED.subnet_arn").
+			Set("vpc_id = EXCLUDED.vpc_id").
+			Set("state = EXCLUDED.state").
+			Set("az = EXCLUDED.az").
+			Set("az_id = EXCLUDED.az_id").
+			Set("available_ipv4_addresses = EXCLUDED.available_ipv4_addresses").
+			Set("ipv4_cidr = EXCLUDED.ipv4_cidr").
+			Set("ipv6_cidr = EXCLUDED.ipv6_cidr").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -232,11 +242,6 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated aws subnets",
 		"region", payload.Region,
@@ -244,6 +249,17 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		"count", count,
 	)
 
+	if err := UpsertTags(ctx, db.DB, payload.AccountID, models.TagResourceTypeSubnet, taggedIDs, tags); err != nil {
+		logger.Error(
+			"could not insert subnet tags into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
 	// Emit metrics by grouping the subnets by VPC
 	groups := utils.GroupBy(subnets, func(item models.Subnet) string {
 		return item.VpcID
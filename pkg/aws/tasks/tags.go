@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	"github.com/gardener/inventory/pkg/aws/models"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+// newEC2Tags returns the [models.Tag] items for the given EC2 resource tags.
+func newEC2Tags(accountID, region, resourceType, resourceID string, tags []ec2types.Tag) []models.Tag {
+	kv := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		kv[ptr.StringFromPointer(tag.Key)] = ptr.StringFromPointer(tag.Value)
+	}
+
+	return newTags(accountID, region, resourceType, resourceID, kv)
+}
+
+// newS3Tags returns the [models.Tag] items for the given S3 resource tags.
+func newS3Tags(accountID, region, resourceType, resourceID string, tags []s3types.Tag) []models.Tag {
+	kv := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		kv[ptr.StringFromPointer(tag.Key)] = ptr.StringFromPointer(tag.Value)
+	}
+
+	return newTags(accountID, region, resourceType, resourceID, kv)
+}
+
+// newTags returns the [models.Tag] items for the given key/value pairs.
+func newTags(accountID, region, resourceType, resourceID string, kv map[string]string) []models.Tag {
+	items := make([]models.Tag, 0, len(kv))
+	for key, value := range kv {
+		if key == "" {
+			continue
+		}
+
+		item := models.Tag{
+			AccountID:    accountID,
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			Key:          key,
+			Value:        value,
+			RegionName:   region,
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// UpsertTags persists the given tags of the AWS resources of the given type and
+// account, and deletes the tags, which have been removed from the resources
+// with the given ids, within the same transaction. The resource ids specify the
+// resources, whose tags have been collected, including the ones without any
+// tags.
+//
+// The tags are sorted before being inserted, so that concurrent upserts lock
+// the rows in the same order.
+func UpsertTags(ctx context.Context, db bun.IDB, accountID, resourceType string, resourceIDs []string, tags []models.Tag) error {
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(tags, func(a, b models.Tag) int {
+		return cmp.Or(
+			cmp.Compare(a.ResourceType, b.ResourceType),
+			cmp.Compare(a.ResourceID, b.ResourceID),
+			cmp.Compare(a.Key, b.Key),
+		)
+	})
+
+	// The collected tags are passed as parallel arrays of resource ids
+	// and keys, so that any tag of the resources, which is not among
+	// them, is deleted.
+	tagResourceIDs := make([]string, 0, len(tags))
+	tagKeys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagResourceIDs = append(tagResourceIDs, tag.ResourceID)
+		tagKeys = append(tagKeys, tag.Key)
+	}
+
+	var count, deleted int64
+	err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		count, err = dbutils.BulkInsert(ctx, tx, tags, func(q *bun.InsertQuery) *bun.InsertQuery {
+			return q.On("CONFLICT (account_id, resource_type, resource_id, key) DO UPDATE").
+				Set("value = EXCLUDED.value").
+				Set("region_name = EXCLUDED.region_name").
+				Set("updated_at = EXCLUDED.updated_at").
+				Returning("id")
+		}, 0)
+
+		if err != nil {
+			return err
+		}
+
+		out, err := tx.NewDelete().
+			Model((*models.Tag)(nil)).
+			Where("account_id = ?", accountID).
+			Where("resource_type = ?", resourceType).
+			Where("resource_id IN (?)", bun.In(resourceIDs)).
+			Where(
+				"(resource_id, key) NOT IN (SELECT * FROM unnest(?::varchar[], ?::varchar[]))",
+				pgdialect.Array(tagResourceIDs),
+				pgdialect.Array(tagKeys),
+			).
+			Exec(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		deleted, err = out.RowsAffected()
+
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info(
+		"populated aws tags",
+		"account_id", accountID,
+		"resource_type", resourceType,
+		"count", count,
+		"deleted", deleted,
+	)
+
+	return nil
+}
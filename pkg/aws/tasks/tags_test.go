@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	"github.com/gardener/inventory/pkg/aws/models"
+	"github.com/gardener/inventory/pkg/aws/tasks"
+)
+
+// TestUpsertTags verifies the upsert of AWS tags against a real database. The
+// test is skipped, unless the test database is configured via
+// [dbtest.EnvDSN].
+func TestUpsertTags(t *testing.T) {
+	testDB := dbtest.New(t)
+
+	ctx := t.Context()
+	vpcs := []models.VPC{
+		{VpcID: "vpc-changed", AccountID: "111111111111", RegionName: "eu-west-1"},
+		{VpcID: "vpc-untagged", AccountID: "111111111111", RegionName: "eu-west-1"},
+		{VpcID: "vpc-not-collected", AccountID: "111111111111", RegionName: "eu-west-1"},
+		{VpcID: "vpc-changed", AccountID: "222222222222", RegionName: "eu-west-1"},
+	}
+	if _, err := testDB.NewInsert().Model(&vpcs).Returning("id").Exec(ctx); err != nil {
+		t.Fatalf("unable to insert vpcs: %s", err)
+	}
+
+	newTag := func(accountID, resourceType, resourceID, key, value string) models.Tag {
+		return models.Tag{
+			AccountID:    accountID,
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			Key:          key,
+			Value:        value,
+			RegionName:   "eu-west-1",
+		}
+	}
+
+	existing := []models.Tag{
+		newTag("111111111111", models.TagResourceTypeVPC, "vpc-changed", "owner", "team-a"),
+		newTag("111111111111", models.TagResourceTypeVPC, "vpc-changed", "removed", "yes"),
+		newTag("111111111111", models.TagResourceTypeVPC, "vpc-untagged", "removed", "yes"),
+		// Tags of resources, which have not been collected, are kept
+		newTag("111111111111", models.TagResourceTypeVPC, "vpc-not-collected", "owner", "team-a"),
+		// Tags of the same resource id in another account are kept
+		newTag("222222222222", models.TagResourceTypeVPC, "vpc-changed", "removed", "yes"),
+		// Tags of the same resource id of another type are kept
+		newTag("111111111111", models.TagResourceTypeSubnet, "vpc-changed", "removed", "yes"),
+	}
+	if _, err := testDB.NewInsert().Model(&existing).Returning("id").Exec(ctx); err != nil {
+		t.Fatalf("unable to insert tags: %s", err)
+	}
+
+	collected := []models.Tag{
+		newTag("111111111111", models.TagResourceTypeVPC, "vpc-changed", "owner", "team-b"),
+		newTag("111111111111", models.TagResourceTypeVPC, "vpc-changed", "added", "yes"),
+	}
+	resourceIDs := []string{"vpc-changed", "vpc-untagged"}
+	if err := tasks.UpsertTags(ctx, testDB, "111111111111", models.TagResourceTypeVPC, resourceIDs, collected); err != nil {
+		t.Fatalf("unable to upsert tags: %s", err)
+	}
+
+	// The tags are loaded via the polymorphic Tags relation of the VPCs
+	var got []models.VPC
+	err := testDB.NewSelect().
+		Model(&got).
+		Relation("Tags").
+		Order("account_id", "vpc_id").
+		Scan(ctx)
+	if err != nil {
+		t.Fatalf("unable to select vpcs: %s", err)
+	}
+
+	wanted := map[string]map[string]string{
+		"111111111111/vpc-changed":       {"owner": "team-b", "added": "yes"},
+		"111111111111/vpc-not-collected": {"owner": "team-a"},
+		"111111111111/vpc-untagged":      {},
+		"222222222222/vpc-changed":       {"removed": "yes"},
+	}
+	for _, vpc := range got {
+		name := vpc.AccountID + "/" + vpc.VpcID
+		tags := make(map[string]string, len(vpc.Tags))
+		for _, tag := range vpc.Tags {
+			tags[tag.Key] = tag.Value
+		}
+
+		if !maps.Equal(tags, wanted[name]) {
+			t.Fatalf("want tags %v for %s, got %v", wanted[name], name, tags)
+		}
+	}
+
+	count, err := testDB.NewSelect().
+		Model((*models.Tag)(nil)).
+		Where("resource_type = ?", models.TagResourceTypeSubnet).
+		Count(ctx)
+	if err != nil {
+		t.Fatalf("unable to count subnet tags: %s", err)
+	}
+	if count != 1 {
+		t.Fatalf("want 1 subnet tag, got %d", count)
+	}
+}
@@ -0,0 +1,307 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	v2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/constants"
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectTargetGroups is the name of the task for collecting AWS
+	// ELB v2 target groups.
+	TaskCollectTargetGroups = "aws:task:collect-target-groups"
+)
+
+// CollectTargetGroupsPayload represents the payload for collecting AWS ELB v2
+// target groups.
+type CollectTargetGroupsPayload struct {
+	// Region specifies the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
+}
+
+// NewCollectTargetGroupsTask creates a new [asynq.Task] for collecting AWS ELB
+// v2 target groups, without specifying a payload.
+func NewCollectTargetGroupsTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectTargetGroups, nil)
+}
+
+// HandleCollectTargetGroupsTask handles the task for collecting AWS ELB v2
+// target groups.
+func HandleCollectTargetGroupsTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting target groups from all known regions and their
+	// respective accounts.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectTargetGroups(ctx, nil)
+	}
+
+	var payload CollectTargetGroupsPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	// Enqueue tasks only for the given regions, if no specific region
+	// has been requested.
+	if payload.Region == "" && len(payload.Regions) > 0 {
+		return enqueueCollectTargetGroups(ctx, payload.Regions)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	return collectTargetGroups(ctx, payload)
+}
+
+// enqueueCollectTargetGroups enqueues tasks for collecting AWS ELB v2 target
+// groups for the known regions and accounts.
+//
+// If region names are specified, tasks are enqueued only for these regions.
+func enqueueCollectTargetGroups(ctx context.Context, regionNames []string) error {
+	regions, err := getRegions(ctx, regionNames)
+	if err != nil {
+		return err
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
+
+	// Enqueue target group collection for each region
+	for _, r := range regions {
+		if !awsclients.ELBv2Clientset.Exists(r.AccountID) {
+			logger.Warn(
+				"AWS client not found",
+				"region", r.Name,
+				"account_id", r.AccountID,
+			)
+
+			continue
+		}
+
+		payload := CollectTargetGroupsPayload{
+			Region:    r.Name,
+			AccountID: r.AccountID,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for AWS target groups",
+				"region", r.Name,
+				"account_id", r.AccountID,
+				"reason", err,
+			)
+
+			continue
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectTargetGroups, data)
+		})
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectTargetGroups collects the AWS ELB v2 target groups along with their
+// registered targets from the specified region using the client associated
+// with the given AccountID from the payload.
+func collectTargetGroups(ctx context.Context, payload CollectTargetGroupsPayload) error {
+	client, ok := awsclients.ELBv2Clientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			targetGroupsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+			payload.Region,
+		)
+		key := metrics.Key(TaskCollectTargetGroups, payload.AccountID, payload.Region)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info(
+		"collecting AWS target groups",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+	)
+
+	pageSize := int32(constants.PageSize)
+	paginator := elbv2.NewDescribeTargetGroupsPaginator(
+		client.Client,
+		&elbv2.DescribeTargetGroupsInput{PageSize: &pageSize},
+		func(params *elbv2.DescribeTargetGroupsPaginatorOptions) {
+			params.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]v2types.TargetGroup, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(
+			ctx,
+			func(o *elbv2.Options) {
+				o.Region = payload.Region
+			},
+		)
+
+		if err != nil {
+			logger.Error(
+				"could not describe target groups",
+				"region", payload.Region,
+				"account_id", payload.AccountID,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+
+		items = append(items, page.TargetGroups...)
+	}
+
+	// Create model instances from the collected data
+	groups := make([]models.TargetGroup, 0, len(items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		arn := ptr.StringFromPointer(item.TargetGroupArn)
+		targetIDs, err := getTargetGroupTargets(ctx, client.Client, payload.Region, arn)
+		if err != nil {
+			logger.Error(
+				"could not describe target health",
+				"region", payload.Region,
+				"account_id", payload.AccountID,
+				"target_group", arn,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		group := models.TargetGroup{
+			ARN:              arn,
+			AccountID:        payload.AccountID,
+			Name:             ptr.StringFromPointer(item.TargetGroupName),
+			Protocol:         string(item.Protocol),
+			Port:             int(ptr.Value(item.Port, 0)),
+			TargetType:       string(item.TargetType),
+			VpcID:            ptr.StringFromPointer(item.VpcId),
+			RegionName:       payload.Region,
+			LoadBalancerARNs: item.LoadBalancerArns,
+			TargetIDs:        targetIDs,
+		}
+		if group.LoadBalancerARNs == nil {
+			group.LoadBalancerARNs = make([]string, 0)
+		}
+		groups = append(groups, group)
+		ids = append(ids, group.ARN)
+	}
+
+	if len(groups) == 0 {
+		return reconcileRegion[models.TargetGroup](ctx, "target groups", payload.AccountID, payload.Region, "arn", ids)
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db.DB, groups, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (arn, account_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("protocol = EXCLUDED.protocol").
+			Set("port = EXCLUDED.port").
+			Set("target_type = EXCLUDED.target_type").
+			Set("vpc_id = EXCLUDED.vpc_id").
+			Set("region_name = EXCLUDED.region_name").
+			Set("lb_arns = EXCLUDED.lb_arns").
+			Set("target_ids = EXCLUDED.target_ids").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert target groups into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws target groups",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+	)
+
+	return reconcileRegion[models.TargetGroup](ctx, "target groups", payload.AccountID, payload.Region, "arn", ids)
+}
+
+// getTargetGroupTargets returns the IDs of the targets, which are registered
+// with the given target group. Targets registered on multiple ports are
+// reported once.
+func getTargetGroupTargets(ctx context.Context, client *elbv2.Client, region, arn string) ([]string, error) {
+	out, err := client.DescribeTargetHealth(
+		ctx,
+		&elbv2.DescribeTargetHealthInput{TargetGroupArn: &arn},
+		func(o *elbv2.Options) {
+			o.Region = region
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	targetIDs := make([]string, 0, len(out.TargetHealthDescriptions))
+	for _, desc := range out.TargetHealthDescriptions {
+		if desc.Target == nil {
+			continue
+		}
+		targetID := ptr.StringFromPointer(desc.Target.Id)
+		if !slices.Contains(targetIDs, targetID) {
+			targetIDs = append(targetIDs, targetID)
+		}
+	}
+
+	return targetIDs, nil
+}
@@ -41,9 +41,20 @@ func HandleCollectAllTask(ctx context.Context, _ *asynq.Task) error {
 		NewCollectLoadBalancersTask,
 		NewCollectBucketsTask,
 		NewCollectNetworkInterfacesTask,
+		NewCollectConfigRulesTask,
+		NewCollectComplianceResultsTask,
+		NewCollectSNSTopicsTask,
+		NewCollectSNSSubscriptionsTask,
+		NewCollectIAMUsersTask,
+		NewCollectIAMRolesTask,
+		NewCollectRDSInstancesTask,
+		NewCollectVolumesTask,
+		NewCollectTargetGroupsTask,
+		NewCollectLoadBalancerListenersTask,
+		NewCollectEKSClustersTask,
 	}
 
-	return asynqutils.Enqueue(ctx, taskFns, asynq.Queue(queue))
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // HandleLinkAllTask is a handler, which establishes links between the various
@@ -63,6 +74,18 @@ func HandleLinkAllTask(ctx context.Context, _ *asynq.Task) error {
 		LinkLoadBalancerWithRegion,
 		LinkNetworkInterfaceWithInstance,
 		LinkNetworkInterfaceWithLoadBalancer,
+		LinkInstanceWithComplianceResult,
+		LinkVPCWithComplianceResult,
+		LinkSubnetWithComplianceResult,
+		LinkBucketWithComplianceResult,
+		LinkSNSSubscriptionWithTopic,
+		LinkIAMUserWithPolicy,
+		LinkRDSInstanceWithVPC,
+		LinkRDSInstanceWithSubnet,
+		LinkVolumeWithInstance,
+		LinkListenerWithLoadBalancer,
+		LinkTargetGroupWithInstance,
+		LinkEKSClusterWithVPC,
 	}
 
 	return dbutils.LinkObjects(ctx, db.DB, linkFns)
@@ -80,6 +103,61 @@ func init() {
 	registry.TaskRegistry.MustRegister(TaskCollectLoadBalancers, asynq.HandlerFunc(HandleCollectLoadBalancersTask))
 	registry.TaskRegistry.MustRegister(TaskCollectBuckets, asynq.HandlerFunc(HandleCollectBucketsTask))
 	registry.TaskRegistry.MustRegister(TaskCollectNetworkInterfaces, asynq.HandlerFunc(HandleCollectNetworkInterfacesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectConfigRules, asynq.HandlerFunc(HandleCollectConfigRulesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectComplianceResults, asynq.HandlerFunc(HandleCollectComplianceResultsTask))
+	registry.TaskRegistry.MustRegister(TaskCollectSNSTopics, asynq.HandlerFunc(HandleCollectSNSTopicsTask))
+	registry.TaskRegistry.MustRegister(TaskCollectSNSSubscriptions, asynq.HandlerFunc(HandleCollectSNSSubscriptionsTask))
+	registry.TaskRegistry.MustRegister(TaskCollectIAMUsers, asynq.HandlerFunc(HandleCollectIAMUsersTask))
+	registry.TaskRegistry.MustRegister(TaskCollectIAMRoles, asynq.HandlerFunc(HandleCollectIAMRolesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectRDSInstances, asynq.HandlerFunc(HandleCollectRDSInstancesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectVolumes, asynq.HandlerFunc(HandleCollectVolumesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectTargetGroups, asynq.HandlerFunc(HandleCollectTargetGroupsTask))
+	registry.TaskRegistry.MustRegister(TaskCollectLoadBalancerListeners, asynq.HandlerFunc(HandleCollectLoadBalancerListenersTask))
+	registry.TaskRegistry.MustRegister(TaskCollectEKSClusters, asynq.HandlerFunc(HandleCollectEKSClustersTask))
 	registry.TaskRegistry.MustRegister(TaskCollectAll, asynq.HandlerFunc(HandleCollectAllTask))
 	registry.TaskRegistry.MustRegister(TaskLinkAll, asynq.HandlerFunc(HandleLinkAllTask))
+
+	// Collection ordering
+	registry.TaskGraph.MustAdd(TaskCollectAll)
+	registry.TaskGraph.MustAdd(TaskLinkAll, TaskCollectAll)
+}
+
+// reconcileRegion marks the resources of model T, which were collected from
+// the given account and region, as seen, and the ones, which have
+// disappeared, as deleted. The idColumn specifies the column, which contains
+// the collected ids, and the kind is used for logging.
+//
+// It must be called only after the collection of the whole region
+// succeeded, since an empty list of ids marks all resources of the region as
+// deleted.
+func reconcileRegion[T any](ctx context.Context, kind, accountID, region, idColumn string, ids []string) error {
+	scope := map[string]any{
+		"account_id":  accountID,
+		"region_name": region,
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	result, err := dbutils.Reconcile[T](ctx, db.DB, scope, idColumn, ids)
+	if err != nil {
+		logger.Error(
+			"could not reconcile aws resources",
+			"kind", kind,
+			"region", region,
+			"account_id", accountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"reconciled aws resources",
+		"kind", kind,
+		"region", region,
+		"account_id", accountID,
+		"seen", result.Seen,
+		"deleted", result.Deleted,
+	)
+
+	return nil
 }
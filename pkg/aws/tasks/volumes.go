@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/aws/constants"
+	"github.com/gardener/inventory/pkg/aws/models"
+	awsclients "github.com/gardener/inventory/pkg/clients/aws"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+const (
+	// TaskCollectVolumes is the name of the task for collecting AWS EBS
+	// volumes.
+	TaskCollectVolumes = "aws:task:collect-volumes"
+)
+
+// CollectVolumesPayload represents the payload for collecting AWS EBS volumes.
+type CollectVolumesPayload struct {
+	// Region specifies the region from which to collect.
+	Region string `json:"region" yaml:"region"`
+
+	// AccountID specifies the AWS Account ID, which is associated with a
+	// registered client.
+	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
+}
+
+// NewCollectVolumesTask creates a new [asynq.Task] for collecting AWS EBS
+// volumes, without specifying a payload.
+func NewCollectVolumesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectVolumes, nil)
+}
+
+// HandleCollectVolumesTask handles the task for collecting AWS EBS volumes.
+func HandleCollectVolumesTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting volumes from all known regions and their respective accounts.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectVolumes(ctx, nil)
+	}
+
+	var payload CollectVolumesPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	// Enqueue tasks only for the given regions, if no specific region
+	// has been requested.
+	if payload.Region == "" && len(payload.Regions) > 0 {
+		return enqueueCollectVolumes(ctx, payload.Regions)
+	}
+
+	if payload.AccountID == "" {
+		return asynqutils.SkipRetry(ErrNoAccountID)
+	}
+
+	if payload.Region == "" {
+		return asynqutils.SkipRetry(ErrNoRegion)
+	}
+
+	return collectVolumes(ctx, payload)
+}
+
+// enqueueCollectVolumes enqueues tasks for collecting AWS EBS volumes for the
+// known regions and accounts.
+//
+// If region names are specified, tasks are enqueued only for these regions.
+func enqueueCollectVolumes(ctx context.Context, regionNames []string) error {
+	regions, err := getRegions(ctx, regionNames)
+	if err != nil {
+		return err
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
+
+	// Enqueue volume collection for each region
+	for _, r := range regions {
+		if !awsclients.EC2Clientset.Exists(r.AccountID) {
+			logger.Warn(
+				"AWS client not found",
+				"region", r.Name,
+				"account_id", r.AccountID,
+			)
+
+			continue
+		}
+
+		payload := CollectVolumesPayload{
+			Region:    r.Name,
+			AccountID: r.AccountID,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for AWS volumes",
+				"region", r.Name,
+				"account_id", r.AccountID,
+				"reason", err,
+			)
+
+			continue
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectVolumes, data)
+		})
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectVolumes collects the AWS EBS volumes from the specified region using
+// the client associated with the given AccountID from the payload.
+func collectVolumes(ctx context.Context, payload CollectVolumesPayload) error {
+	client, ok := awsclients.EC2Clientset.Get(payload.AccountID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.AccountID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			volumesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.AccountID,
+			payload.Region,
+		)
+		key := metrics.Key(TaskCollectVolumes, payload.AccountID, payload.Region)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info(
+		"collecting AWS volumes",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+	)
+
+	paginator := ec2.NewDescribeVolumesPaginator(
+		client.Client,
+		&ec2.DescribeVolumesInput{},
+		func(opts *ec2.DescribeVolumesPaginatorOptions) {
+			opts.Limit = int32(constants.PageSize)
+			opts.StopOnDuplicateToken = true
+		},
+	)
+
+	// Fetch items from all pages
+	items := make([]types.Volume, 0)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(
+			ctx,
+			func(o *ec2.Options) {
+				o.Region = payload.Region
+			},
+		)
+
+		if err != nil {
+			logger.Error(
+				"could not describe volumes",
+				"region", payload.Region,
+				"account_id", payload.AccountID,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+
+		items = append(items, page.Volumes...)
+	}
+
+	// Create model instances from the collected data
+	volumes := make([]models.Volume, 0, len(items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		// Volumes, which are being detached, are still reported
+		// as attachments, so only the attached ones are considered.
+		instanceIDs := make([]string, 0, len(item.Attachments))
+		for _, attachment := range item.Attachments {
+			if attachment.State != types.VolumeAttachmentStateAttached {
+				continue
+			}
+			instanceIDs = append(instanceIDs, ptr.StringFromPointer(attachment.InstanceId))
+		}
+
+		volume := models.Volume{
+			VolumeID:        ptr.StringFromPointer(item.VolumeId),
+			AccountID:       payload.AccountID,
+			RegionName:      payload.Region,
+			AZ:              ptr.StringFromPointer(item.AvailabilityZone),
+			Size:            int(ptr.Value(item.Size, 0)),
+			VolumeType:      string(item.VolumeType),
+			IOPS:            int(ptr.Value(item.Iops, 0)),
+			Encrypted:       ptr.Value(item.Encrypted, false),
+			State:           string(item.State),
+			SnapshotID:      ptr.StringFromPointer(item.SnapshotId),
+			VolumeCreatedAt: ptr.Value(item.CreateTime, time.Time{}),
+			InstanceIDs:     instanceIDs,
+		}
+		volumes = append(volumes, volume)
+		ids = append(ids, volume.VolumeID)
+	}
+
+	if len(volumes) == 0 {
+		return reconcileRegion[models.Volume](ctx, "volumes", payload.AccountID, payload.Region, "volume_id", ids)
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db.DB, volumes, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (volume_id, account_id, region_name) DO UPDATE").
+			Set("az = EXCLUDED.az").
+			Set("size = EXCLUDED.size").
+			Set("volume_type = EXCLUDED.volume_type").
+			Set("iops = EXCLUDED.iops").
+			Set("encrypted = EXCLUDED.encrypted").
+			Set("state = EXCLUDED.state").
+			Set("snapshot_id = EXCLUDED.snapshot_id").
+			Set("volume_created_at = EXCLUDED.volume_created_at").
+			Set("instance_ids = EXCLUDED.instance_ids").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert volumes into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated aws volumes",
+		"region", payload.Region,
+		"account_id", payload.AccountID,
+		"count", count,
+	)
+
+	return reconcileRegion[models.Volume](ctx, "volumes", payload.AccountID, payload.Region, "volume_id", ids)
+}
@@ -7,21 +7,21 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/aws/constants"
 	"github.com/gardener/inventory/pkg/aws/models"
 	awsutils "github.com/gardener/inventory/pkg/aws/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	awsclients "github.com/gardener/inventory/pkg/clients/aws"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -38,6 +38,11 @@ type CollectVPCsPayload struct {
 	// AccountID specifies the AWS Account ID, which is associated with a
 	// registered client.
 	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// Regions specifies the regions, for which collection tasks are
+	// enqueued, when no region is specified. If empty, tasks are enqueued
+	// for all known regions.
+	Regions []string `json:"regions" yaml:"regions"`
 }
 
 // NewCollectVPCsTask creates a new [asynq.Task] for collecting AWS VPCs without
@@ -52,7 +57,7 @@ func HandleCollectVPCsTask(ctx context.Context, t *asynq.Task) error {
 	// collecting VPCs for all known regions.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectVPCs(ctx)
+		return enqueueCollectVPCs(ctx, nil)
 	}
 
 	var payload CollectVPCsPayload
@@ -60,6 +65,12 @@ func HandleCollectVPCsTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// Enqueue tasks only for the given regions, if no specific region
+	// has been requested.
+	if payload.Region == "" && len(payload.Regions) > 0 {
+		return enqueueCollectVPCs(ctx, payload.Regions)
+	}
+
 	if payload.AccountID == "" {
 		return asynqutils.SkipRetry(ErrNoAccountID)
 	}
@@ -73,14 +84,17 @@ func HandleCollectVPCsTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectVPCs enqueues tasks for collecting AWS VPCs from all known
 // regions by creating payload with the respective region and account id.
-func enqueueCollectVPCs(ctx context.Context) error {
-	regions, err := awsutils.GetRegionsFromDB(ctx)
+//
+// If region names are specified, tasks are enqueued only for these regions.
+func enqueueCollectVPCs(ctx context.Context, regionNames []string) error {
+	regions, err := getRegions(ctx, regionNames)
 	if err != nil {
-		return fmt.Errorf("failed to get regions: %w", err)
+		return err
 	}
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(regions))
 
 	// Enqueue task for each region
 	for _, r := range regions {
@@ -110,31 +124,12 @@ func enqueueCollectVPCs(ctx context.Context) error {
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectVPCs, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"region", r.Name,
-				"account_id", r.AccountID,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"region", r.Name,
-			"account_id", r.AccountID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectVPCs, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectVPCs collects the AWS VPCs from the specified payload region using the
@@ -194,10 +189,15 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 
 			return err
 		}
+
+		asynqutils.AddPages(ctx, 1)
+
 		items = append(items, page.Vpcs...)
 	}
 
 	vpcs := make([]models.VPC, 0, len(items))
+	tags := make([]models.Tag, 0)
+	taggedIDs := make([]string, 0)
 	for _, vpc := range items {
 		name := awsutils.FetchTag(vpc.Tags, "Name")
 		item := models.VPC{
@@ -212,25 +212,32 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 			RegionName: payload.Region,
 		}
 		vpcs = append(vpcs, item)
+		taggedIDs = append(taggedIDs, item.VpcID)
+		tags = append(tags, newEC2Tags(
+			payload.AccountID,
+			payload.Region,
+			models.TagResourceTypeVPC,
+			item.VpcID,
+			vpc.Tags,
+		)...)
 	}
 
 	if len(vpcs) == 0 {
-		return nil
+		return reconcileRegion[models.VPC](ctx, "vpcs", payload.AccountID, payload.Region, "vpc_id", taggedIDs)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&vpcs).
-		On("CONFLICT (vpc_id, account_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("state = EXCLUDED.state").
-		Set("ipv4_cidr = EXCLUDED.ipv4_cidr").
-		Set("ipv6_cidr = EXCLUDED.ipv6_cidr").
-		Set("is_default = EXCLUDED.is_default").
-		Set("owner_id = EXCLUDED.owner_id").
-		Set("region_name = EXCLUDED.region_name").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, vpcs, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (vpc_id, account_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("state = EXCLUDED.state").
+			Set("ipv4_cidr = EXCLUDED.ipv4_cidr").
+			Set("ipv6_cidr = EXCLUDED.ipv6_cidr").
+			Set("is_default = EXCLUDED.is_default").
+			Set("owner_id = EXCLUDED.owner_id").
+			Set("region_name = EXCLUDED.region_name").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -243,11 +250,6 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated aws vpcs",
 		"region", payload.Region,
@@ -255,5 +257,16 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 		"count", count,
 	)
 
-	return nil
+	if err := UpsertTags(ctx, db.DB, payload.AccountID, models.TagResourceTypeVPC, taggedIDs, tags); err != nil {
+		logger.Error(
+			"could not insert vpc tags into db",
+			"region", payload.Region,
+			"account_id", payload.AccountID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	return reconcileRegion[models.VPC](ctx, "vpcs", payload.AccountID, payload.Region, "vpc_id", taggedIDs)
 }
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/gardener/inventory/pkg/aws/stscreds/chain"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// NewAssumeRoleChainErrorMiddleware returns a new [asynq.MiddlewareFunc],
+// which prevents tasks failing because a hop of an assume role chain could not
+// be assumed from being retried. Such failures, e.g. a missing trust
+// relationship, are not resolved by retrying the task.
+func NewAssumeRoleChainErrorMiddleware() asynq.MiddlewareFunc {
+	middleware := func(handler asynq.Handler) asynq.Handler {
+		mw := func(ctx context.Context, task *asynq.Task) error {
+			err := handler.ProcessTask(ctx, task)
+			if err == nil || errors.Is(err, asynq.SkipRetry) {
+				return err
+			}
+
+			var hopErr *chain.HopError
+			if !errors.As(err, &hopErr) {
+				return err
+			}
+
+			logger := asynqutils.GetLogger(ctx)
+			logger.Error(
+				"aws assume role chain failed, will not retry",
+				"hop", hopErr.Hop,
+				"role_arn", hopErr.RoleARN,
+				"reason", hopErr.Err,
+			)
+
+			return asynqutils.SkipRetry(err)
+		}
+
+		return asynq.HandlerFunc(mw)
+	}
+
+	return asynq.MiddlewareFunc(middleware)
+}
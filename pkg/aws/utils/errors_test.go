@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/gardener/inventory/pkg/aws/stscreds/chain"
+	"github.com/gardener/inventory/pkg/aws/utils"
+)
+
+func TestAssumeRoleChainErrorMiddleware(t *testing.T) {
+	hopErr := &chain.HopError{
+		Hop:     2,
+		RoleARN: "arn:aws:iam::222222222222:role/intermediate",
+		Err:     errors.New("access denied"),
+	}
+
+	testCases := []struct {
+		desc          string
+		err           error
+		wantSkipRetry bool
+	}{
+		{
+			desc: "no error",
+		},
+		{
+			desc: "transient error",
+			err:  errors.New("connection reset"),
+		},
+		{
+			desc:          "hop error",
+			err:           fmt.Errorf("failed to retrieve credentials: %w", hopErr),
+			wantSkipRetry: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			handler := asynq.HandlerFunc(func(context.Context, *asynq.Task) error {
+				return tc.err
+			})
+
+			mw := utils.NewAssumeRoleChainErrorMiddleware()
+			err := mw(handler).ProcessTask(t.Context(), asynq.NewTask("test", nil))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("want error %v, got %v", tc.err, err)
+			}
+			if errors.Is(err, asynq.SkipRetry) != tc.wantSkipRetry {
+				t.Fatalf("want skip retry %t, got %v", tc.wantSkipRetry, err)
+			}
+		})
+	}
+}
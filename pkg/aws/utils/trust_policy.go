@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// AnyAccount is returned by [TrustedAccounts], when a trust policy allows any
+// AWS principal to assume the role.
+const AnyAccount = "*"
+
+// accountIDPattern matches a plain AWS account id.
+var accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// stringOrSlice is a JSON value, which is either a single string, or a list of
+// strings, as used by the elements of IAM policies.
+type stringOrSlice []string
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = []string{single}
+
+		return nil
+	}
+
+	var items []string
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*s = items
+
+	return nil
+}
+
+// trustPolicyPrincipal is the principal of a trust policy statement, which is
+// either "*", or a map of principal types, e.g. AWS, Service, Federated.
+type trustPolicyPrincipal struct {
+	Any bool
+	AWS stringOrSlice
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (p *trustPolicyPrincipal) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		p.Any = single == AnyAccount
+
+		return nil
+	}
+
+	var principals struct {
+		AWS stringOrSlice `json:"AWS"`
+	}
+	if err := json.Unmarshal(data, &principals); err != nil {
+		return err
+	}
+	p.AWS = principals.AWS
+
+	return nil
+}
+
+// trustPolicy represents an IAM trust policy document.
+type trustPolicy struct {
+	Statement []struct {
+		Effect    string               `json:"Effect"`
+		Principal trustPolicyPrincipal `json:"Principal"`
+	} `json:"Statement"`
+}
+
+// TrustedAccounts returns the sorted AWS account ids, whose principals are
+// allowed to assume a role by the given trust policy document. Service and
+// federated principals are not reported. A policy allowing any AWS principal
+// results in [AnyAccount].
+func TrustedAccounts(document string) ([]string, error) {
+	var policy trustPolicy
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		return nil, err
+	}
+
+	accounts := make([]string, 0)
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+
+		if statement.Principal.Any {
+			accounts = append(accounts, AnyAccount)
+		}
+
+		for _, principal := range statement.Principal.AWS {
+			switch {
+			case principal == AnyAccount || accountIDPattern.MatchString(principal):
+				accounts = append(accounts, principal)
+			case arn.IsARN(principal):
+				parsed, err := arn.Parse(principal)
+				if err != nil {
+					return nil, err
+				}
+				accounts = append(accounts, parsed.AccountID)
+			}
+		}
+	}
+
+	slices.Sort(accounts)
+
+	return slices.Compact(accounts), nil
+}
+
+// ExternalAccounts returns the given trusted accounts, which are different
+// from the given account id.
+func ExternalAccounts(trusted []string, accountID string) []string {
+	external := make([]string, 0, len(trusted))
+	for _, account := range trusted {
+		if account != accountID {
+			external = append(external, account)
+		}
+	}
+
+	return external
+}
@@ -6,14 +6,24 @@ package utils
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 
 	"github.com/gardener/inventory/pkg/aws/models"
 	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
+// ErrUnknownRegion is an error, which is returned when a region was requested,
+// which is not known in the database.
+var ErrUnknownRegion = errors.New("unknown region")
+
 // FetchTag returns the value of the AWS tag with the key s or an empty string if the tag is not found.
 func FetchTag(tags []types.Tag, key string) string {
 	for _, t := range tags {
@@ -35,3 +45,93 @@ func GetRegionsFromDB(ctx context.Context) ([]models.Region, error) {
 
 	return items, err
 }
+
+// FilterRegions returns the regions with the given names. If no names are
+// specified, all regions are returned. An error wrapping [ErrUnknownRegion] is
+// returned, if any of the names does not match a known region.
+func FilterRegions(regions []models.Region, names []string) ([]models.Region, error) {
+	if len(names) == 0 {
+		return regions, nil
+	}
+
+	known := make(map[string]struct{}, len(regions))
+	for _, r := range regions {
+		known[r.Name] = struct{}{}
+	}
+
+	unknown := make([]string, 0)
+	for _, name := range names {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRegion, strings.Join(unknown, ", "))
+	}
+
+	items := make([]models.Region, 0, len(regions))
+	for _, r := range regions {
+		if slices.Contains(names, r.Name) {
+			items = append(items, r)
+		}
+	}
+
+	return items, nil
+}
+
+// MaxLaunchTimeFilterDays specifies the max number of days, which are matched
+// by the filter returned by [LaunchTimeFilter].
+const MaxLaunchTimeFilterDays = 31
+
+// LaunchTimeFilter returns the EC2 API filter, which matches instances
+// launched between since and until. The launch-time filter supports wildcards
+// only, so the filter matches whole days in UTC. The returned bool is false,
+// if the interval spans more than [MaxLaunchTimeFilterDays] days.
+func LaunchTimeFilter(since, until time.Time) (types.Filter, bool) {
+	since = since.UTC()
+	until = until.UTC()
+	day := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+
+	values := make([]string, 0)
+	for !day.After(until) || len(values) == 0 {
+		if len(values) == MaxLaunchTimeFilterDays {
+			return types.Filter{}, false
+		}
+		values = append(values, day.Format("2006-01-02")+"T*")
+		day = day.AddDate(0, 0, 1)
+	}
+
+	filter := types.Filter{
+		Name:   ptr.To("launch-time"),
+		Values: values,
+	}
+
+	return filter, true
+}
+
+// TagFilters returns the EC2 API filters, which match resources having all of
+// the given tags. A tag with an empty value matches resources by tag key only.
+// The filters are sorted by tag key, so that the result is deterministic.
+func TagFilters(tags map[string]string) []types.Filter {
+	keys := slices.Sorted(maps.Keys(tags))
+	filters := make([]types.Filter, 0, len(keys))
+	for _, key := range keys {
+		value := tags[key]
+		if value == "" {
+			filters = append(filters, types.Filter{
+				Name:   ptr.To("tag-key"),
+				Values: []string{key},
+			})
+
+			continue
+		}
+
+		filters = append(filters, types.Filter{
+			Name:   ptr.To("tag:" + key),
+			Values: []string{value},
+		})
+	}
+
+	return filters
+}
@@ -5,11 +5,15 @@
 package utils_test
 
 import (
+	"errors"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 
+	"github.com/gardener/inventory/pkg/aws/models"
 	"github.com/gardener/inventory/pkg/aws/utils"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
@@ -60,3 +64,235 @@ func TestFetchTag(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterRegions(t *testing.T) {
+	regions := []models.Region{
+		{Name: "eu-central-1", AccountID: "1"},
+		{Name: "us-east-1", AccountID: "1"},
+		{Name: "eu-central-1", AccountID: "2"},
+		{Name: "ap-south-1", AccountID: "2"},
+	}
+
+	testCases := []struct {
+		desc    string
+		names   []string
+		wantLen int
+		wantErr error
+	}{
+		{
+			desc:    "no names returns all regions",
+			names:   nil,
+			wantLen: 4,
+			wantErr: nil,
+		},
+		{
+			desc:    "known names across accounts",
+			names:   []string{"eu-central-1", "us-east-1"},
+			wantLen: 3,
+			wantErr: nil,
+		},
+		{
+			desc:    "unknown name",
+			names:   []string{"eu-central-1", "mars-north-1"},
+			wantLen: 0,
+			wantErr: utils.ErrUnknownRegion,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			items, err := utils.FilterRegions(regions, tc.names)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+
+			if len(items) != tc.wantLen {
+				t.Fatalf("want %d regions, got %d", tc.wantLen, len(items))
+			}
+		})
+	}
+}
+
+func TestTagFilters(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		tags   map[string]string
+		wanted []string
+	}{
+		{
+			desc:   "no tags",
+			tags:   nil,
+			wanted: []string{},
+		},
+		{
+			desc: "tags with values are sorted by key",
+			tags: map[string]string{
+				"owner":                  "team-a",
+				"gardener.cloud/managed": "true",
+			},
+			wanted: []string{
+				"tag:gardener.cloud/managed=true",
+				"tag:owner=team-a",
+			},
+		},
+		{
+			desc: "tag with empty value matches by key",
+			tags: map[string]string{
+				"kubernetes.io/cluster/foo": "",
+			},
+			wanted: []string{
+				"tag-key=kubernetes.io/cluster/foo",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			filters := utils.TagFilters(tc.tags)
+			got := make([]string, 0, len(filters))
+			for _, f := range filters {
+				got = append(got, ptr.StringFromPointer(f.Name)+"="+strings.Join(f.Values, ","))
+			}
+
+			if !slices.Equal(got, tc.wanted) {
+				t.Fatalf("want %v got %v", tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestTrustedAccounts(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		document string
+		wanted   []string
+		wantErr  bool
+	}{
+		{
+			desc:     "service principal",
+			document: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			wanted:   []string{},
+		},
+		{
+			desc:     "single account arn",
+			document: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"sts:AssumeRole"}]}`,
+			wanted:   []string{"123456789012"},
+		},
+		{
+			desc:     "multiple accounts with duplicates",
+			document: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::210987654321:role/foo","123456789012","arn:aws:iam::123456789012:user/bar"]}}]}`,
+			wanted:   []string{"123456789012", "210987654321"},
+		},
+		{
+			desc:     "any principal",
+			document: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"sts:AssumeRole"}]}`,
+			wanted:   []string{utils.AnyAccount},
+		},
+		{
+			desc:     "deny statements are ignored",
+			document: `{"Statement":[{"Effect":"Deny","Principal":{"AWS":"210987654321"}}]}`,
+			wanted:   []string{},
+		},
+		{
+			desc:     "invalid document",
+			document: `not-json`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := utils.TrustedAccounts(tc.document)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("want error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+
+			if !slices.Equal(got, tc.wanted) {
+				t.Fatalf("want %v got %v", tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestExternalAccounts(t *testing.T) {
+	trusted := []string{utils.AnyAccount, "123456789012", "210987654321"}
+	wanted := []string{utils.AnyAccount, "210987654321"}
+	got := utils.ExternalAccounts(trusted, "123456789012")
+	if !slices.Equal(got, wanted) {
+		t.Fatalf("want %v got %v", wanted, got)
+	}
+}
+
+func TestLaunchTimeFilter(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		since  time.Time
+		until  time.Time
+		wanted []string
+		ok     bool
+	}{
+		{
+			desc:   "same day",
+			since:  time.Date(2025, 7, 25, 10, 0, 0, 0, time.UTC),
+			until:  time.Date(2025, 7, 25, 11, 0, 0, 0, time.UTC),
+			wanted: []string{"2025-07-25T*"},
+			ok:     true,
+		},
+		{
+			desc:   "multiple days",
+			since:  time.Date(2025, 7, 30, 23, 0, 0, 0, time.UTC),
+			until:  time.Date(2025, 8, 1, 1, 0, 0, 0, time.UTC),
+			wanted: []string{"2025-07-30T*", "2025-07-31T*", "2025-08-01T*"},
+			ok:     true,
+		},
+		{
+			desc:   "non-UTC times",
+			since:  time.Date(2025, 7, 26, 1, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+			until:  time.Date(2025, 7, 26, 3, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+			wanted: []string{"2025-07-25T*", "2025-07-26T*"},
+			ok:     true,
+		},
+		{
+			desc:   "until before since",
+			since:  time.Date(2025, 7, 25, 10, 0, 0, 0, time.UTC),
+			until:  time.Date(2025, 7, 24, 10, 0, 0, 0, time.UTC),
+			wanted: []string{"2025-07-25T*"},
+			ok:     true,
+		},
+		{
+			desc:  "too many days",
+			since: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC),
+			ok:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			filter, ok := utils.LaunchTimeFilter(tc.since, tc.until)
+			if ok != tc.ok {
+				t.Fatalf("want ok %t got %t", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if name := ptr.StringFromPointer(filter.Name); name != "launch-time" {
+				t.Fatalf("want filter name launch-time got %s", name)
+			}
+
+			if !slices.Equal(filter.Values, tc.wanted) {
+				t.Fatalf("want %v got %v", tc.wanted, filter.Values)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
+	"github.com/gardener/inventory/pkg/core/globalid"
 	coremodels "github.com/gardener/inventory/pkg/core/models"
 	"github.com/gardener/inventory/pkg/core/registry"
 )
@@ -28,6 +29,7 @@ const (
 	StorageAccountModelName                = "az:model:storage_account"
 	BlobContainerModelName                 = "az:model:blob_container"
 	UserModelName                          = "az:model:user"
+	ManagedDiskModelName                   = "az:model:managed_disk"
 	ResourceGroupToSubscriptionModelName   = "az:model:link_rg_to_subscription"
 	VirtualMachineToResourceGroupModelName = "az:model:link_vm_to_rg"
 	PublicAddressToResourceGroupModelName  = "az:model:link_public_address_to_rg"
@@ -35,6 +37,7 @@ const (
 	VPCToResourceGroupModelName            = "az:model:link_vpc_to_rg"
 	SubnetToVPCModelName                   = "az:model:link_subnet_to_vpc"
 	BlobContainerToResourceGroupModelName  = "az:model:link_blob_container_to_rg"
+	VirtualMachineToManagedDiskModelName   = "az:model:link_vm_to_managed_disk"
 )
 
 // models specifies the mapping between name and model type, which will be
@@ -50,6 +53,7 @@ var models = map[string]any{
 	StorageAccountModelName: &StorageAccount{},
 	BlobContainerModelName:  &BlobContainer{},
 	UserModelName:           &User{},
+	ManagedDiskModelName:    &ManagedDisk{},
 
 	// Link models
 	ResourceGroupToSubscriptionModelName:   &ResourceGroupToSubscription{},
@@ -59,6 +63,7 @@ var models = map[string]any{
 	VPCToResourceGroupModelName:            &VPCToResourceGroup{},
 	SubnetToVPCModelName:                   &SubnetToVPC{},
 	BlobContainerToResourceGroupModelName:  &BlobContainerToResourceGroup{},
+	VirtualMachineToManagedDiskModelName:   &VirtualMachineToManagedDisk{},
 }
 
 // Subscription represents an Azure Subscription
@@ -102,6 +107,7 @@ type VirtualMachine struct {
 	Name              string         `bun:"name,notnull,unique:az_vm_key"`
 	SubscriptionID    string         `bun:"subscription_id,notnull,unique:az_vm_key"`
 	ResourceGroupName string         `bun:"resource_group,notnull,unique:az_vm_key"`
+	VMID              string         `bun:"vm_id,nullzero"`
 	Location          string         `bun:"location,notnull"`
 	ProvisioningState string         `bun:"provisioning_state,notnull"`
 	TimeCreated       time.Time      `bun:"vm_created_at,nullzero"`
@@ -110,6 +116,7 @@ type VirtualMachine struct {
 	HyperVGeneration  string         `bun:"hyper_v_gen,nullzero"`
 	VMAgentVersion    string         `bun:"vm_agent_version,nullzero"`
 	GalleryImageID    string         `bun:"gallery_image_id,nullzero"`
+	GlobalID          string         `bun:"global_id,nullzero"`
 	Subscription      *Subscription  `bun:"rel:has-one,join:subscription_id=subscription_id"`
 	ResourceGroup     *ResourceGroup `bun:"rel:has-one,join:resource_group=name,join:subscription_id=subscription_id"`
 }
@@ -292,9 +299,48 @@ type User struct {
 	Mail     string `bun:"mail,notnull"`
 }
 
+// ManagedDisk represents an Azure Managed Disk.
+type ManagedDisk struct {
+	bun.BaseModel `bun:"table:az_managed_disk"`
+	coremodels.Model
+
+	DiskID            string         `bun:"disk_id,notnull,unique:az_managed_disk_key"`
+	Name              string         `bun:"name,notnull"`
+	SubscriptionID    string         `bun:"subscription_id,notnull"`
+	ResourceGroupName string         `bun:"resource_group,notnull"`
+	Location          string         `bun:"location,notnull"`
+	SizeGB            int32          `bun:"size_gb,notnull"`
+	SKU               string         `bun:"sku,notnull"`
+	DiskState         string         `bun:"disk_state,notnull"`
+	EncryptionType    string         `bun:"encryption_type,notnull"`
+	IsEncrypted       bool           `bun:"is_encrypted,notnull"`
+	VMID              string         `bun:"vm_id,notnull"`
+	VMName            string         `bun:"vm_name,notnull"`
+	VMResourceGroup   string         `bun:"vm_resource_group,notnull"`
+	TimeCreated       time.Time      `bun:"disk_created_at,nullzero"`
+	Subscription      *Subscription  `bun:"rel:has-one,join:subscription_id=subscription_id"`
+	ResourceGroup     *ResourceGroup `bun:"rel:has-one,join:resource_group=name,join:subscription_id=subscription_id"`
+}
+
+// VirtualMachineToManagedDisk represents a link table connecting the
+// [VirtualMachine] with [ManagedDisk] models.
+type VirtualMachineToManagedDisk struct {
+	bun.BaseModel `bun:"table:l_az_vm_to_managed_disk"`
+	coremodels.Model
+
+	VMID   uuid.UUID `bun:"vm_id,notnull,type:uuid,unique:l_az_vm_to_managed_disk_key"`
+	DiskID uuid.UUID `bun:"disk_id,notnull,type:uuid,unique:l_az_vm_to_managed_disk_key"`
+}
+
 // init registers the models with the [registry.ModelRegistry].
 func init() {
 	for k, v := range models {
 		registry.ModelRegistry.MustRegister(k, v)
 	}
+
+	// Register the global ids of the models
+	globalid.Registry.MustRegister(VirtualMachineModelName, globalid.Spec{
+		Provider: "az",
+		Columns:  []string{"subscription_id", "resource_group", "name"},
+	})
 }
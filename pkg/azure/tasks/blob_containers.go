@@ -12,14 +12,15 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -85,6 +86,7 @@ func enqueueCollectBlobContainers(ctx context.Context) error {
 	// Enqueue task for each resource group
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(storageAccounts))
 	for _, acc := range storageAccounts {
 		if !azureclients.BlobContainersClientset.Exists(acc.SubscriptionID) {
 			logger.Warn(
@@ -115,33 +117,13 @@ func enqueueCollectBlobContainers(ctx context.Context) error {
 
 			continue
 		}
-		task := asynq.NewTask(TaskCollectBlobContainers, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"subscription_id", acc.SubscriptionID,
-				"resource_group", acc.ResourceGroupName,
-				"storage_account", acc.Name,
-				"reason", err,
-			)
 
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"subscription_id", acc.SubscriptionID,
-			"resource_group", acc.ResourceGroupName,
-			"storage_account", acc.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectBlobContainers, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectBlobContainers collects the Azure Blob containers from the
@@ -200,6 +182,8 @@ func collectBlobContainers(ctx context.Context, payload CollectBlobContainersPay
 			return azureutils.MaybeSkipRetry(err)
 		}
 
+		asynqutils.AddPages(ctx, 1)
+
 		for _, container := range page.Value {
 			var publicAccess armstorage.PublicAccess
 			var deleted bool
@@ -228,21 +212,15 @@ func collectBlobContainers(ctx context.Context, payload CollectBlobContainersPay
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, storage_account, resource_group, subscription_id) DO UPDATE").
-		Set("public_access = EXCLUDED.public_access").
-		Set("deleted = EXCLUDED.deleted").
-		Set("last_modified_time = EXCLUDED.last_modified_time").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, storage_account, resource_group, subscription_id) DO UPDATE").
+			Set("public_access = EXCLUDED.public_access").
+			Set("deleted = EXCLUDED.deleted").
+			Set("last_modified_time = EXCLUDED.last_modified_time").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err = out.RowsAffected()
 	if err != nil {
 		return err
 	}
@@ -7,15 +7,17 @@ package tasks
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // LinkResourceGroupWithSubscription creates links between the
 // [models.ResourceGroup] and [models.Subscription] models.
-func LinkResourceGroupWithSubscription(ctx context.Context, db *bun.DB) error {
+func LinkResourceGroupWithSubscription(ctx context.Context, db bun.IDB) error {
 	var items []models.ResourceGroup
 	err := db.NewSelect().
 		Model(&items).
@@ -40,6 +42,10 @@ func LinkResourceGroupWithSubscription(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.ResourceGroupToSubscription) []uuid.UUID {
+		return []uuid.UUID{l.ResourceGroupID, l.SubscriptionID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (rg_id, sub_id) DO UPDATE").
@@ -56,6 +62,8 @@ func LinkResourceGroupWithSubscription(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked azure resource group with subscription", "count", count)
 
@@ -64,7 +72,7 @@ func LinkResourceGroupWithSubscription(ctx context.Context, db *bun.DB) error {
 
 // LinkVirtualMachineWithResourceGroup creates links between the
 // [models.VirtualMachine] and [models.ResourceGroup] models.
-func LinkVirtualMachineWithResourceGroup(ctx context.Context, db *bun.DB) error {
+func LinkVirtualMachineWithResourceGroup(ctx context.Context, db bun.IDB) error {
 	var items []models.VirtualMachine
 	err := db.NewSelect().
 		Model(&items).
@@ -89,6 +97,10 @@ func LinkVirtualMachineWithResourceGroup(ctx context.Context, db *bun.DB) error
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.VirtualMachineToResourceGroup) []uuid.UUID {
+		return []uuid.UUID{l.ResourceGroupID, l.VMID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (rg_id, vm_id) DO UPDATE").
@@ -105,6 +117,8 @@ func LinkVirtualMachineWithResourceGroup(ctx context.Context, db *bun.DB) error
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked azure vm with resource group", "count", count)
 
@@ -113,7 +127,7 @@ func LinkVirtualMachineWithResourceGroup(ctx context.Context, db *bun.DB) error
 
 // LinkPublicAddressWithResourceGroup establishes relationships between the
 // [models.PublicAddress] and [models.ResourceGroup] models.
-func LinkPublicAddressWithResourceGroup(ctx context.Context, db *bun.DB) error {
+func LinkPublicAddressWithResourceGroup(ctx context.Context, db bun.IDB) error {
 	var items []models.PublicAddress
 	err := db.NewSelect().
 		Model(&items).
@@ -138,6 +152,10 @@ func LinkPublicAddressWithResourceGroup(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.PublicAddressToResourceGroup) []uuid.UUID {
+		return []uuid.UUID{l.ResourceGroupID, l.PublicAddressID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (rg_id, pa_id) DO UPDATE").
@@ -154,6 +172,8 @@ func LinkPublicAddressWithResourceGroup(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked azure public address with resource group", "count", count)
 
@@ -162,7 +182,7 @@ func LinkPublicAddressWithResourceGroup(ctx context.Context, db *bun.DB) error {
 
 // LinkLoadBalancerWithResourceGroup establishes relationships between the
 // [models.LoadBalancer] and [models.ResourceGroup] models.
-func LinkLoadBalancerWithResourceGroup(ctx context.Context, db *bun.DB) error {
+func LinkLoadBalancerWithResourceGroup(ctx context.Context, db bun.IDB) error {
 	var items []models.LoadBalancer
 	err := db.NewSelect().
 		Model(&items).
@@ -187,6 +207,10 @@ func LinkLoadBalancerWithResourceGroup(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.LoadBalancerToResourceGroup) []uuid.UUID {
+		return []uuid.UUID{l.LoadBalancerID, l.ResourceGroupID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (lb_id, rg_id) DO UPDATE").
@@ -203,6 +227,8 @@ func LinkLoadBalancerWithResourceGroup(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked load balancer with resource group", "count", count)
 
@@ -211,7 +237,7 @@ func LinkLoadBalancerWithResourceGroup(ctx context.Context, db *bun.DB) error {
 
 // LinkVPCWithResourceGroup establishes relationships between the
 // [models.VPC] and [models.ResourceGroup] models.
-func LinkVPCWithResourceGroup(ctx context.Context, db *bun.DB) error {
+func LinkVPCWithResourceGroup(ctx context.Context, db bun.IDB) error {
 	var items []models.VPC
 	err := db.NewSelect().
 		Model(&items).
@@ -236,6 +262,10 @@ func LinkVPCWithResourceGroup(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.VPCToResourceGroup) []uuid.UUID {
+		return []uuid.UUID{l.VPCID, l.ResourceGroupID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (vpc_id, rg_id) DO UPDATE").
@@ -252,6 +282,8 @@ func LinkVPCWithResourceGroup(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked azure vpc with resource group", "count", count)
 
@@ -260,7 +292,7 @@ func LinkVPCWithResourceGroup(ctx context.Context, db *bun.DB) error {
 
 // LinkSubnetWithVPC establishes relationships between the
 // [models.Subnet] and [models.VPC] models.
-func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
+func LinkSubnetWithVPC(ctx context.Context, db bun.IDB) error {
 	var items []models.Subnet
 	err := db.NewSelect().
 		Model(&items).
@@ -285,6 +317,10 @@ func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.SubnetToVPC) []uuid.UUID {
+		return []uuid.UUID{l.SubnetID, l.VPCID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (subnet_id, vpc_id) DO UPDATE").
@@ -301,6 +337,8 @@ func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked azure subnet with vpc", "count", count)
 
@@ -309,7 +347,7 @@ func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
 
 // LinkBlobContainerWithResourceGroup establishes relationships between the
 // [models.BlobContainer] and [models.ResourceGroup] models.
-func LinkBlobContainerWithResourceGroup(ctx context.Context, db *bun.DB) error {
+func LinkBlobContainerWithResourceGroup(ctx context.Context, db bun.IDB) error {
 	var items []models.BlobContainer
 	err := db.NewSelect().
 		Model(&items).
@@ -334,6 +372,10 @@ func LinkBlobContainerWithResourceGroup(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.BlobContainerToResourceGroup) []uuid.UUID {
+		return []uuid.UUID{l.BlobContainerID, l.ResourceGroupID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (blob_container_id, rg_id) DO UPDATE").
@@ -350,8 +392,65 @@ func LinkBlobContainerWithResourceGroup(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked azure blob container with resource group", "count", count)
 
 	return nil
 }
+
+// LinkVirtualMachineWithDisk creates links between the
+// [models.VirtualMachine] and [models.ManagedDisk] models. Disks, which are
+// not attached to a Virtual Machine are skipped.
+func LinkVirtualMachineWithDisk(ctx context.Context, db bun.IDB) error {
+	// The managedBy reference of a disk may differ in case from the
+	// resource group and name of the Virtual Machine, so we match them
+	// case-insensitively.
+	links := make([]models.VirtualMachineToManagedDisk, 0)
+	err := db.NewSelect().
+		TableExpr("az_managed_disk AS disk").
+		Join("INNER JOIN az_vm AS vm").
+		JoinOn("vm.subscription_id = disk.subscription_id").
+		JoinOn("lower(vm.resource_group) = lower(disk.vm_resource_group)").
+		JoinOn("lower(vm.name) = lower(disk.vm_name)").
+		ColumnExpr("vm.id AS vm_id").
+		ColumnExpr("disk.id AS disk_id").
+		Where("disk.vm_id <> ''").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.VirtualMachineToManagedDisk) []uuid.UUID {
+		return []uuid.UUID{l.VMID, l.DiskID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (vm_id, disk_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked azure virtual machine with managed disk", "count", count)
+
+	return nil
+}
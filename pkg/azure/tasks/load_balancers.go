@@ -11,14 +11,15 @@ import (
 	armnetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -79,6 +80,7 @@ func enqueueCollectLoadBalancers(ctx context.Context) error {
 	// Enqueue task for each resource group
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(resourceGroups))
 	for _, rg := range resourceGroups {
 		if !azureclients.LoadBalancersClientset.Exists(rg.SubscriptionID) {
 			logger.Warn(
@@ -106,31 +108,13 @@ func enqueueCollectLoadBalancers(ctx context.Context) error {
 
 			continue
 		}
-		task := asynq.NewTask(TaskCollectLoadBalancers, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"subscription_id", rg.SubscriptionID,
-				"resource_group", rg.Name,
-				"reason", err,
-			)
-
-			continue
-		}
 
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"subscription_id", rg.SubscriptionID,
-			"resource_group", rg.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectLoadBalancers, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectLoadBalancers collects the Azure Load Balancers from the subscription
@@ -180,6 +164,8 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 			return azureutils.MaybeSkipRetry(err)
 		}
 
+		asynqutils.AddPages(ctx, 1)
+
 		// NOTE: Frontend and Backend configuration for Load Balancers is not
 		// collected at the moment, because the Go SDK for Azure does not return
 		// results for them. See [1] for more details.
@@ -214,22 +200,16 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (subscription_id, resource_group, name) DO UPDATE").
-		Set("location = EXCLUDED.location").
-		Set("provisioning_state = EXCLUDED.provisioning_state").
-		Set("sku_name = EXCLUDED.sku_name").
-		Set("sku_tier = EXCLUDED.sku_tier").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subscription_id, resource_group, name) DO UPDATE").
+			Set("location = EXCLUDED.location").
+			Set("provisioning_state = EXCLUDED.provisioning_state").
+			Set("sku_name = EXCLUDED.sku_name").
+			Set("sku_tier = EXCLUDED.sku_tier").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err = out.RowsAffected()
 	if err != nil {
 		return err
 	}
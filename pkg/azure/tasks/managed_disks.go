@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/azure/models"
+	azureutils "github.com/gardener/inventory/pkg/azure/utils"
+	azureclients "github.com/gardener/inventory/pkg/clients/azure"
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
+)
+
+// TaskCollectManagedDisks is the name of the task for collecting Azure
+// Managed Disks.
+const TaskCollectManagedDisks = "az:task:collect-managed-disks"
+
+// CollectManagedDisksPayload is the payload used for collecting Azure Managed
+// Disks.
+type CollectManagedDisksPayload struct {
+	// SubscriptionID specifies the Azure Subscription ID from which to
+	// collect.
+	SubscriptionID string `json:"subscription_id" yaml:"subscription_id"`
+}
+
+// NewCollectManagedDisksTask creates a new [asynq.Task] for collecting Azure
+// Managed Disks, without specifying a payload.
+func NewCollectManagedDisksTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectManagedDisks, nil)
+}
+
+// HandleCollectManagedDisksTask is the handler, which collects Azure Managed
+// Disks.
+func HandleCollectManagedDisksTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue collection from
+	// all known subscriptions.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectManagedDisks(ctx)
+	}
+
+	var payload CollectManagedDisksPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.SubscriptionID == "" {
+		return asynqutils.SkipRetry(ErrNoSubscriptionID)
+	}
+
+	return collectManagedDisks(ctx, payload)
+}
+
+// enqueueCollectManagedDisks enqueues tasks for collecting Azure Managed Disks
+// for all known Subscriptions.
+func enqueueCollectManagedDisks(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+	if azureclients.DisksClientset.Length() == 0 {
+		logger.Warn("no Azure Managed Disks clients found")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, azureclients.DisksClientset.Length())
+	err := azureclients.DisksClientset.Range(func(subscriptionID string, _ *azureclients.Client[*armcompute.DisksClient]) error {
+		payload := CollectManagedDisksPayload{
+			SubscriptionID: subscriptionID,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for Azure Managed Disks",
+				"subscription_id", subscriptionID,
+				"reason", err,
+			)
+
+			return registry.ErrContinue
+		}
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectManagedDisks, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectManagedDisks collects the Azure Managed Disks from the subscription
+// specified in the payload.
+func collectManagedDisks(ctx context.Context, payload CollectManagedDisksPayload) error {
+	client, ok := azureclients.DisksClientset.Get(payload.SubscriptionID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.SubscriptionID))
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("collecting Azure Managed Disks", "subscription_id", payload.SubscriptionID)
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			managedDisksDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.SubscriptionID,
+		)
+		key := metrics.Key(TaskCollectManagedDisks, payload.SubscriptionID)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	items := make([]models.ManagedDisk, 0)
+	pager := client.Client.NewListPager(&armcompute.DisksClientListOptions{})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			logger.Error(
+				"failed to get Azure Managed Disks",
+				"subscription_id", payload.SubscriptionID,
+				"reason", err,
+			)
+
+			return azureutils.MaybeSkipRetry(err)
+		}
+
+		asynqutils.AddPages(ctx, 1)
+
+		for _, disk := range page.Value {
+			diskID := ptr.Value(disk.ID, "")
+			resourceID, err := arm.ParseResourceID(diskID)
+			if err != nil {
+				logger.Warn(
+					"failed to parse Azure Managed Disk id",
+					"subscription_id", payload.SubscriptionID,
+					"disk_id", diskID,
+					"reason", err,
+				)
+
+				continue
+			}
+
+			item := models.ManagedDisk{
+				DiskID:            diskID,
+				Name:              ptr.Value(disk.Name, ""),
+				SubscriptionID:    payload.SubscriptionID,
+				ResourceGroupName: resourceID.ResourceGroupName,
+				Location:          ptr.Value(disk.Location, ""),
+			}
+
+			if disk.SKU != nil {
+				item.SKU = string(ptr.Value(disk.SKU.Name, ""))
+			}
+
+			if disk.Properties != nil {
+				item.SizeGB = ptr.Value(disk.Properties.DiskSizeGB, 0)
+				item.DiskState = string(ptr.Value(disk.Properties.DiskState, ""))
+				item.TimeCreated = ptr.Value(disk.Properties.TimeCreated, time.Time{})
+				if disk.Properties.Encryption != nil {
+					item.EncryptionType = string(ptr.Value(disk.Properties.Encryption.Type, ""))
+				}
+				settings := disk.Properties.EncryptionSettingsCollection
+				item.IsEncrypted = item.EncryptionType != "" || (settings != nil && ptr.Value(settings.Enabled, false))
+			}
+
+			// Unattached disks do not have a managedBy reference
+			if disk.ManagedBy != nil {
+				item.VMID = ptr.Value(disk.ManagedBy, "")
+				vmResourceID, err := arm.ParseResourceID(item.VMID)
+				if err == nil {
+					item.VMName = vmResourceID.Name
+					item.VMResourceGroup = vmResourceID.ResourceGroupName
+				}
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (disk_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("subscription_id = EXCLUDED.subscription_id").
+			Set("resource_group = EXCLUDED.resource_group").
+			Set("location = EXCLUDED.location").
+			Set("size_gb = EXCLUDED.size_gb").
+			Set("sku = EXCLUDED.sku").
+			Set("disk_state = EXCLUDED.disk_state").
+			Set("encryption_type = EXCLUDED.encryption_type").
+			Set("is_encrypted = EXCLUDED.is_encrypted").
+			Set("vm_id = EXCLUDED.vm_id").
+			Set("vm_name = EXCLUDED.vm_name").
+			Set("vm_resource_group = EXCLUDED.vm_resource_group").
+			Set("disk_created_at = EXCLUDED.disk_created_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	logger.Info("populated azure managed disks", "count", count)
+
+	return nil
+}
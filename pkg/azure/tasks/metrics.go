@@ -14,7 +14,7 @@ var (
 	// subscriptionsDesc is the descriptor for a metric, which tracks the number
 	// of collected Azure Subscriptions.
 	subscriptionsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_subscriptions"),
+		"az_subscriptions",
 		"A gauge which tracks the number of collected Azure Subscriptions",
 		nil,
 		nil,
@@ -23,7 +23,7 @@ var (
 	// vpcsDesc is the descriptor for a metric, which tracks the number
 	// of collected Azure VPCs.
 	vpcsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_vpcs"),
+		"az_vpcs",
 		"A gauge which tracks the number of collected Azure VPCs",
 		[]string{"subscription_id", "resource_group"},
 		nil,
@@ -32,7 +32,7 @@ var (
 	// subnetsDesc is the descriptor for a metric, which tracks the number
 	// of collected Azure Subnets.
 	subnetsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_subnets"),
+		"az_subnets",
 		"A gauge which tracks the number of collected Azure Subnets",
 		[]string{"subscription_id", "resource_group", "vpc_name"},
 		nil,
@@ -41,7 +41,7 @@ var (
 	// loadBalancersDesc is the descriptor for a metric, which tracks the number
 	// of collected Azure Load Balancers.
 	loadBalancersDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_load_balancers"),
+		"az_load_balancers",
 		"A gauge which tracks the number of collected Azure Load Balancers",
 		[]string{"subscription_id", "resource_group"},
 		nil,
@@ -50,7 +50,7 @@ var (
 	// blobContainersDesc is the descriptor for a metric, which tracks the
 	// number of collected Azure Blob Containers.
 	blobContainersDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_blob_containers"),
+		"az_blob_containers",
 		"A gauge which tracks the number of collected Azure Blob Containers",
 		[]string{"subscription_id", "resource_group", "storage_account"},
 		nil,
@@ -59,7 +59,7 @@ var (
 	// resourceGroupsDesc is the descriptor for a metric, which tracks the
 	// number of collected Azure Resource Groups.
 	resourceGroupsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_resource_groups"),
+		"az_resource_groups",
 		"A gauge which tracks the number of collected Azure Resource Groups",
 		[]string{"subscription_id"},
 		nil,
@@ -68,7 +68,7 @@ var (
 	// publicAddressesDesc is the descriptor for a metric, which tracks the
 	// number of collected Azure Public Addresses.
 	publicAddressesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_public_addresses"),
+		"az_public_addresses",
 		"A gauge which tracks the number of collected Azure Public Addresses",
 		[]string{"subscription_id", "resource_group"},
 		nil,
@@ -77,7 +77,7 @@ var (
 	// storageAccountsDesc is the descriptor for a metric, which tracks the
 	// number of collected Azure Storage Accounts.
 	storageAccountsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_storage_accounts"),
+		"az_storage_accounts",
 		"A gauge which tracks the number of collected Azure Storage Accounts",
 		[]string{"subscription_id", "resource_group"},
 		nil,
@@ -86,11 +86,20 @@ var (
 	// virtualMachinesDesc is the descriptor for a metric, which tracks the
 	// number of collected Azure Virtual Machines.
 	virtualMachinesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "az_vms"),
+		"az_vms",
 		"A gauge which tracks the number of collected Azure Virtual Machines",
 		[]string{"subscription_id", "resource_group"},
 		nil,
 	)
+
+	// managedDisksDesc is the descriptor for a metric, which tracks the
+	// number of collected Azure Managed Disks.
+	managedDisksDesc = prometheus.NewDesc(
+		"az_managed_disks",
+		"A gauge which tracks the number of collected Azure Managed Disks",
+		[]string{"subscription_id"},
+		nil,
+	)
 )
 
 // init registers the metric descriptors with the [metrics.DefaultCollector].
@@ -105,5 +114,6 @@ func init() {
 		publicAddressesDesc,
 		storageAccountsDesc,
 		virtualMachinesDesc,
+		managedDisksDesc,
 	)
 }
@@ -12,14 +12,15 @@ import (
 	armnetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -80,6 +81,7 @@ func enqueueCollectPublicAddresses(ctx context.Context) error {
 	// Enqueue task for each resource group
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(resourceGroups))
 	for _, rg := range resourceGroups {
 		if !azureclients.PublicIPAddressesClientset.Exists(rg.SubscriptionID) {
 			logger.Warn(
@@ -107,31 +109,13 @@ func enqueueCollectPublicAddresses(ctx context.Context) error {
 
 			continue
 		}
-		task := asynq.NewTask(TaskCollectPublicAddresses, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"subscription_id", rg.SubscriptionID,
-				"resource_group", rg.Name,
-				"reason", err,
-			)
-
-			continue
-		}
 
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"subscription_id", rg.SubscriptionID,
-			"resource_group", rg.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectPublicAddresses, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectPublicAddresses collects the Azure Public IP Addresses from the
@@ -181,6 +165,8 @@ func collectPublicAddresses(ctx context.Context, payload CollectPublicAddressesP
 			return azureutils.MaybeSkipRetry(err)
 		}
 
+		asynqutils.AddPages(ctx, 1)
+
 		for _, addr := range page.Value {
 			var provisioningState armnetwork.ProvisioningState
 			var ddosProtection armnetwork.DdosSettingsProtectionMode
@@ -232,28 +218,22 @@ func collectPublicAddresses(ctx context.Context, payload CollectPublicAddressesP
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (subscription_id, resource_group, name) DO UPDATE").
-		Set("location = EXCLUDED.location").
-		Set("provisioning_state = EXCLUDED.provisioning_state").
-		Set("sku_name = EXCLUDED.sku_name").
-		Set("sku_tier = EXCLUDED.sku_tier").
-		Set("ddos_protection = EXCLUDED.ddos_protection").
-		Set("fqdn = EXCLUDED.fqdn").
-		Set("reverse_fqdn = EXCLUDED.reverse_fqdn").
-		Set("nat_gateway = EXCLUDED.nat_gateway").
-		Set("ip_address = EXCLUDED.ip_address").
-		Set("ip_version = EXCLUDED.ip_version").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subscription_id, resource_group, name) DO UPDATE").
+			Set("location = EXCLUDED.location").
+			Set("provisioning_state = EXCLUDED.provisioning_state").
+			Set("sku_name = EXCLUDED.sku_name").
+			Set("sku_tier = EXCLUDED.sku_tier").
+			Set("ddos_protection = EXCLUDED.ddos_protection").
+			Set("fqdn = EXCLUDED.fqdn").
+			Set("reverse_fqdn = EXCLUDED.reverse_fqdn").
+			Set("nat_gateway = EXCLUDED.nat_gateway").
+			Set("ip_address = EXCLUDED.ip_address").
+			Set("ip_version = EXCLUDED.ip_version").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err = out.RowsAffected()
 	if err != nil {
 		return err
 	}
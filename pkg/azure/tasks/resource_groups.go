@@ -11,15 +11,16 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -74,6 +75,7 @@ func enqueueCollectResourceGroups(ctx context.Context) error {
 	}
 
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, azureclients.ResourceGroupsClientset.Length())
 	err := azureclients.ResourceGroupsClientset.Range(func(subscriptionID string, _ *azureclients.Client[*armresources.ResourceGroupsClient]) error {
 		payload := CollectResourceGroupsPayload{
 			SubscriptionID: subscriptionID,
@@ -88,31 +90,18 @@ func enqueueCollectResourceGroups(ctx context.Context) error {
 
 			return registry.ErrContinue
 		}
-		task := asynq.NewTask(TaskCollectResourceGroups, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"subscription_id", subscriptionID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"subscription_id", subscriptionID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectResourceGroups, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectResourceGroups collects the Azure Resource Groups from the
@@ -151,6 +140,9 @@ func collectResourceGroups(ctx context.Context, payload CollectResourceGroupsPay
 
 			return azureutils.MaybeSkipRetry(err)
 		}
+
+		asynqutils.AddPages(ctx, 1)
+
 		for _, rg := range page.Value {
 			item := models.ResourceGroup{
 				Name:           ptr.Value(rg.Name, ""),
@@ -165,19 +157,13 @@ func collectResourceGroups(ctx context.Context, payload CollectResourceGroupsPay
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (subscription_id, name) DO UPDATE").
-		Set("location = EXCLUDED.location").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subscription_id, name) DO UPDATE").
+			Set("location = EXCLUDED.location").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err = out.RowsAffected()
 	if err != nil {
 		return err
 	}
@@ -12,14 +12,15 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -78,6 +79,7 @@ func enqueueCollectStorageAccounts(ctx context.Context) error {
 	// Enqueue task for each resource group
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(resourceGroups))
 	for _, rg := range resourceGroups {
 		if !azureclients.StorageAccountsClientset.Exists(rg.SubscriptionID) {
 			logger.Warn(
@@ -105,31 +107,13 @@ func enqueueCollectStorageAccounts(ctx context.Context) error {
 
 			continue
 		}
-		task := asynq.NewTask(TaskCollectStorageAccounts, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"subscription_id", rg.SubscriptionID,
-				"resource_group", rg.Name,
-				"reason", err,
-			)
-
-			continue
-		}
 
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"subscription_id", rg.SubscriptionID,
-			"resource_group", rg.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectStorageAccounts, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectStorageAccounts collects the Azure Storage Accounts from the
@@ -179,6 +163,8 @@ func collectStorageAccounts(ctx context.Context, payload CollectStorageAccountsP
 			return azureutils.MaybeSkipRetry(err)
 		}
 
+		asynqutils.AddPages(ctx, 1)
+
 		for _, account := range page.Value {
 			var provisioningState armstorage.ProvisioningState
 			var skuName armstorage.SKUName
@@ -216,24 +202,18 @@ func collectStorageAccounts(ctx context.Context, payload CollectStorageAccountsP
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, resource_group, subscription_id) DO UPDATE").
-		Set("location = EXCLUDED.location").
-		Set("provisioning_state = EXCLUDED.provisioning_state").
-		Set("kind = EXCLUDED.kind").
-		Set("sku_name = EXCLUDED.sku_name").
-		Set("sku_tier = EXCLUDED.sku_tier").
-		Set("creation_time = EXCLUDED.creation_time").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, resource_group, subscription_id) DO UPDATE").
+			Set("location = EXCLUDED.location").
+			Set("provisioning_state = EXCLUDED.provisioning_state").
+			Set("kind = EXCLUDED.kind").
+			Set("sku_name = EXCLUDED.sku_name").
+			Set("sku_tier = EXCLUDED.sku_tier").
+			Set("creation_time = EXCLUDED.creation_time").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err = out.RowsAffected()
 	if err != nil {
 		return err
 	}
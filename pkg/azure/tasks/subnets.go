@@ -11,14 +11,15 @@ import (
 	armnetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -84,6 +85,7 @@ func enqueueCollectSubnets(ctx context.Context) error {
 	// Enqueue task for each resource group
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(vpcs))
 	for _, vpc := range vpcs {
 		if !azureclients.SubnetsClientset.Exists(vpc.SubscriptionID) {
 			logger.Warn(
@@ -113,33 +115,13 @@ func enqueueCollectSubnets(ctx context.Context) error {
 
 			continue
 		}
-		task := asynq.NewTask(TaskCollectSubnets, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"subscription_id", vpc.SubscriptionID,
-				"resource_group", vpc.ResourceGroupName,
-				"vpc", vpc.Name,
-				"reason", err,
-			)
 
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"subscription_id", vpc.SubscriptionID,
-			"resource_group", vpc.ResourceGroupName,
-			"vpc", vpc.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectSubnets, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectSubnets collects the Azure Subnets from the
@@ -198,6 +180,8 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 			return azureutils.MaybeSkipRetry(err)
 		}
 
+		asynqutils.AddPages(ctx, 1)
+
 		for _, subnet := range page.Value {
 			var provisioningState armnetwork.ProvisioningState
 			var addressPrefix string
@@ -232,23 +216,17 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&subnets).
-		On("CONFLICT (subscription_id, resource_group, vpc_name, name) DO UPDATE").
-		Set("type = EXCLUDED.type").
-		Set("provisioning_state = EXCLUDED.provisioning_state").
-		Set("address_prefix = EXCLUDED.address_prefix").
-		Set("security_group = EXCLUDED.security_group").
-		Set("purpose = EXCLUDED.purpose").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, subnets, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subscription_id, resource_group, vpc_name, name) DO UPDATE").
+			Set("type = EXCLUDED.type").
+			Set("provisioning_state = EXCLUDED.provisioning_state").
+			Set("address_prefix = EXCLUDED.address_prefix").
+			Set("security_group = EXCLUDED.security_group").
+			Set("purpose = EXCLUDED.purpose").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err = out.RowsAffected()
 	if err != nil {
 		return err
 	}
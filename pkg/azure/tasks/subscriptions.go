@@ -10,6 +10,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/subscription/armsubscription"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
@@ -17,6 +18,7 @@ import (
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -81,24 +83,18 @@ func HandleCollectSubscriptionsTask(ctx context.Context, _ *asynq.Task) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (subscription_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("state = EXCLUDED.state").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subscription_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("state = EXCLUDED.state").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info("populated azure subscriptions", "count", count)
 
 	return nil
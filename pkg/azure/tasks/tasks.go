@@ -39,9 +39,10 @@ func HandleCollectAllTask(ctx context.Context, _ *asynq.Task) error {
 		NewCollectSubnetsTask,
 		NewCollectStorageAccountsTask,
 		NewCollectBlobContainersTask,
+		NewCollectManagedDisksTask,
 	}
 
-	return asynqutils.Enqueue(ctx, taskFns, asynq.Queue(queue))
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // HandleLinkAllTask is a handler, which establishes links between the various
@@ -55,6 +56,7 @@ func HandleLinkAllTask(ctx context.Context, _ *asynq.Task) error {
 		LinkVPCWithResourceGroup,
 		LinkSubnetWithVPC,
 		LinkBlobContainerWithResourceGroup,
+		LinkVirtualMachineWithDisk,
 	}
 
 	return dbutils.LinkObjects(ctx, db.DB, linkFns)
@@ -75,4 +77,9 @@ func init() {
 	registry.TaskRegistry.MustRegister(TaskCollectStorageAccounts, asynq.HandlerFunc(HandleCollectStorageAccountsTask))
 	registry.TaskRegistry.MustRegister(TaskCollectBlobContainers, asynq.HandlerFunc(HandleCollectBlobContainersTask))
 	registry.TaskRegistry.MustRegister(TaskCollectUsers, asynq.HandlerFunc(HandleCollectUsersTask))
+	registry.TaskRegistry.MustRegister(TaskCollectManagedDisks, asynq.HandlerFunc(HandleCollectManagedDisksTask))
+
+	// Collection ordering
+	registry.TaskGraph.MustAdd(TaskCollectAll)
+	registry.TaskGraph.MustAdd(TaskLinkAll, TaskCollectAll)
 }
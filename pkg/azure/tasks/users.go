@@ -9,12 +9,14 @@ import (
 	"fmt"
 
 	"github.com/hibiken/asynq"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -112,23 +114,17 @@ func collectUsers(ctx context.Context, payload CollectUsersPayload) error {
 		Mail:     mail,
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&user).
-		On("CONFLICT (tenant_id, user_id) DO UPDATE").
-		Set("mail = EXCLUDED.mail").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, []models.User{user}, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (tenant_id, user_id) DO UPDATE").
+			Set("mail = EXCLUDED.mail").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated azure user",
 		"count", count,
@@ -12,14 +12,16 @@ import (
 	armcompute "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/core/globalid"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -80,6 +82,7 @@ func enqueueCollectVirtualMachines(ctx context.Context) error {
 	// Enqueue task for each resource group
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(resourceGroups))
 	for _, rg := range resourceGroups {
 		if !azureclients.VirtualMachinesClientset.Exists(rg.SubscriptionID) {
 			logger.Warn(
@@ -107,31 +110,13 @@ func enqueueCollectVirtualMachines(ctx context.Context) error {
 
 			continue
 		}
-		task := asynq.NewTask(TaskCollectVirtualMachines, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"subscription_id", rg.SubscriptionID,
-				"resource_group", rg.Name,
-				"reason", err,
-			)
-
-			continue
-		}
 
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"subscription_id", rg.SubscriptionID,
-			"resource_group", rg.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectVirtualMachines, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectVirtualMachines collects the Azure Virtual Machines from the
@@ -181,12 +166,16 @@ func collectVirtualMachines(ctx context.Context, payload CollectVirtualMachinesP
 			return azureutils.MaybeSkipRetry(err)
 		}
 
+		asynqutils.AddPages(ctx, 1)
+
 		for _, vm := range page.Value {
 			vmName := ptr.Value(vm.Name, "")
+			var vmID string
 			var provisioningState string
 			var vmSize armcompute.VirtualMachineSizeTypes
 			var timeCreated time.Time
 			if vm.Properties != nil {
+				vmID = ptr.Value(vm.Properties.VMID, "")
 				provisioningState = ptr.Value(vm.Properties.ProvisioningState, "")
 				vmSize = ptr.Value(vm.Properties.HardwareProfile.VMSize, armcompute.VirtualMachineSizeTypes(""))
 				timeCreated = ptr.Value(vm.Properties.TimeCreated, time.Time{})
@@ -236,6 +225,7 @@ func collectVirtualMachines(ctx context.Context, payload CollectVirtualMachinesP
 				Name:              vmName,
 				SubscriptionID:    payload.SubscriptionID,
 				ResourceGroupName: payload.ResourceGroup,
+				VMID:              vmID,
 				Location:          ptr.Value(vm.Location, ""),
 				ProvisioningState: provisioningState,
 				TimeCreated:       timeCreated,
@@ -244,6 +234,12 @@ func collectVirtualMachines(ctx context.Context, payload CollectVirtualMachinesP
 				PowerState:        azureutils.GetPowerState(instanceView.Statuses),
 				VMAgentVersion:    vmAgentVersion,
 				GalleryImageID:    galleryImageID,
+				GlobalID: globalid.New(
+					"az",
+					payload.SubscriptionID,
+					payload.ResourceGroup,
+					vmName,
+				),
 			}
 			items = append(items, item)
 		}
@@ -253,26 +249,22 @@ func collectVirtualMachines(ctx context.Context, payload CollectVirtualMachinesP
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (subscription_id, resource_group, name) DO UPDATE").
-		Set("location = EXCLUDED.location").
-		Set("provisioning_state = EXCLUDED.provisioning_state").
-		Set("vm_created_at = EXCLUDED.vm_created_at").
-		Set("hyper_v_gen = EXCLUDED.hyper_v_gen").
-		Set("vm_size = EXCLUDED.vm_size").
-		Set("power_state = EXCLUDED.power_state").
-		Set("vm_agent_version = EXCLUDED.vm_agent_version").
-		Set("gallery_image_id = EXCLUDED.gallery_image_id").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subscription_id, resource_group, name) DO UPDATE").
+			Set("vm_id = EXCLUDED.vm_id").
+			Set("location = EXCLUDED.location").
+			Set("provisioning_state = EXCLUDED.provisioning_state").
+			Set("vm_created_at = EXCLUDED.vm_created_at").
+			Set("hyper_v_gen = EXCLUDED.hyper_v_gen").
+			Set("vm_size = EXCLUDED.vm_size").
+			Set("power_state = EXCLUDED.power_state").
+			Set("vm_agent_version = EXCLUDED.vm_agent_version").
+			Set("gallery_image_id = EXCLUDED.gallery_image_id").
+			Set("global_id = EXCLUDED.global_id").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err = out.RowsAffected()
 	if err != nil {
 		return err
 	}
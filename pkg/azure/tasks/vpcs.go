@@ -11,14 +11,15 @@ import (
 	armnetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/azure/models"
 	azureutils "github.com/gardener/inventory/pkg/azure/utils"
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	azureclients "github.com/gardener/inventory/pkg/clients/azure"
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -78,6 +79,7 @@ func enqueueCollectVPCs(ctx context.Context) error {
 	// Enqueue task for each resource group
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(resourceGroups))
 	for _, rg := range resourceGroups {
 		if !azureclients.VirtualNetworksClientset.Exists(rg.SubscriptionID) {
 			logger.Warn(
@@ -105,31 +107,13 @@ func enqueueCollectVPCs(ctx context.Context) error {
 
 			continue
 		}
-		task := asynq.NewTask(TaskCollectVPCs, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"subscription_id", rg.SubscriptionID,
-				"resource_group", rg.Name,
-				"reason", err,
-			)
-
-			continue
-		}
 
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"subscription_id", rg.SubscriptionID,
-			"resource_group", rg.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectVPCs, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectVPCs collects the Azure VPCs from the
@@ -179,6 +163,8 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 			return azureutils.MaybeSkipRetry(err)
 		}
 
+		asynqutils.AddPages(ctx, 1)
+
 		for _, vpc := range page.Value {
 			var provisioningState armnetwork.ProvisioningState
 			var encryptionEnabled *bool
@@ -209,22 +195,16 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (subscription_id, resource_group, name) DO UPDATE").
-		Set("location = EXCLUDED.location").
-		Set("provisioning_state = EXCLUDED.provisioning_state").
-		Set("encryption_enabled = EXCLUDED.encryption_enabled").
-		Set("vm_protection_enabled = EXCLUDED.vm_protection_enabled").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subscription_id, resource_group, name) DO UPDATE").
+			Set("location = EXCLUDED.location").
+			Set("provisioning_state = EXCLUDED.provisioning_state").
+			Set("encryption_enabled = EXCLUDED.encryption_enabled").
+			Set("vm_protection_enabled = EXCLUDED.vm_protection_enabled").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err = out.RowsAffected()
 	if err != nil {
 		return err
 	}
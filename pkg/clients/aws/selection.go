@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aws
+
+import "github.com/gardener/inventory/pkg/utils"
+
+// AccountSelection specifies the AWS accounts, which are selected for
+// collection.
+var AccountSelection utils.Selection
@@ -13,3 +13,7 @@ import (
 // VirtualMachinesClientset provides the registry of Azure Compute API clients
 // for interfacing with Virtual Machines.
 var VirtualMachinesClientset = registry.New[string, *Client[*armcompute.VirtualMachinesClient]]()
+
+// DisksClientset provides the registry of Azure Compute API clients for
+// interfacing with Managed Disks.
+var DisksClientset = registry.New[string, *Client[*armcompute.DisksClient]]()
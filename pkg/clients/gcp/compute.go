@@ -41,3 +41,11 @@ var ForwardingRulesClientset = registry.New[string, *Client[*compute.ForwardingR
 // TargetPoolsClientset provides the registry of GCP API clients for interfacing
 // with the Target Pools service.
 var TargetPoolsClientset = registry.New[string, *Client[*compute.TargetPoolsClient]]()
+
+// FirewallsClientset provides the registry of GCP API clients for interfacing
+// with the Firewalls service.
+var FirewallsClientset = registry.New[string, *Client[*compute.FirewallsClient]]()
+
+// SnapshotsClientset provides the registry of GCP API clients for interfacing
+// with the Snapshots service.
+var SnapshotsClientset = registry.New[string, *Client[*compute.SnapshotsClient]]()
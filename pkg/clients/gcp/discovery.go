@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"context"
+
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+// Service specifies a GCP API service, for which clients are configured for
+// the projects discovered via a [DiscoveryClient].
+type Service struct {
+	// Name is the name of the service, e.g. compute.
+	Name string
+
+	// Exists returns true, if the clients of the service are configured
+	// for the given project.
+	Exists func(projectID string) bool
+
+	// Configure creates and registers the clients of the service for the
+	// given project.
+	Configure func(ctx context.Context, projectID string) error
+}
+
+// DiscoveryClient is a client, which discovers the projects, which are
+// accessible with named credentials configured for project discovery.
+type DiscoveryClient struct {
+	// NamedCredentials is the name of the credentials, which were used to
+	// create the API client.
+	NamedCredentials string
+
+	// Client is the Resource Manager client used to search for projects.
+	Client *resourcemanager.ProjectsClient
+
+	// Services specifies the services, which use the named credentials.
+	Services []Service
+}
+
+// DiscoveryClientset provides the registry of project discovery clients,
+// keyed by the name of the credentials.
+var DiscoveryClientset = registry.New[string, *DiscoveryClient]()
@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import "github.com/gardener/inventory/pkg/utils"
+
+// ProjectSelection specifies the GCP projects, which are selected for
+// collection.
+var ProjectSelection utils.Selection
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+// ServiceClientFunc creates a new service client from the given provider
+// client, e.g. [github.com/gophercloud/gophercloud/v2/openstack.NewComputeV2].
+// Optional services, which are not available in the cloud, may return a nil
+// service client without an error.
+type ServiceClientFunc func(providerClient *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error)
+
+// Service specifies an OpenStack service, for which project-scoped clients
+// are created from the projects discovered via a [DomainClient].
+type Service struct {
+	// Name is the name of the service, e.g. compute.
+	Name string
+
+	// Clientset is the registry of the service clients.
+	Clientset *registry.Registry[ClientScope, Client[*gophercloud.ServiceClient]]
+
+	// NewClient creates a new service client.
+	NewClient ServiceClientFunc
+}
+
+// DomainClient is a client, which has been authenticated with domain-scoped
+// credentials. It is used to discover the projects of the domain, and to
+// create project-scoped service clients for them.
+type DomainClient struct {
+	// NamedCredentials is the name of the domain-scoped credentials.
+	NamedCredentials string
+
+	// Domain is the domain associated with the client.
+	Domain string
+
+	// Region is the region associated with the client.
+	Region string
+
+	// ProviderClient is authenticated with a domain-scoped token.
+	ProviderClient *gophercloud.ProviderClient
+
+	// Services specifies the services, for which the domain-scoped
+	// credentials are used.
+	Services []Service
+}
+
+// DomainClientset provides the registry of domain-scoped clients, keyed by
+// the name of the domain-scoped credentials.
+var DomainClientset = registry.New[string, DomainClient]()
@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import "github.com/gardener/inventory/pkg/utils"
+
+// ProjectSelection specifies the OpenStack projects, which are selected for
+// collection.
+var ProjectSelection utils.Selection
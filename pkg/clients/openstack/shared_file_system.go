@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"github.com/gophercloud/gophercloud/v2"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+// SharedFileSystemClientset provides the registry of OpenStack Shared File
+// System (Manila) API clients for interfacing with shares.
+var SharedFileSystemClientset = registry.New[ClientScope, Client[*gophercloud.ServiceClient]]()
@@ -76,6 +76,11 @@ const (
 	// a Vault secret.
 	OpenStackAuthenticationMethodVaultSecret = "vault_secret"
 
+	// OpenStackAuthenticationMethodFederated is the name of the
+	// authentication mechanism for OpenStack, which exchanges an OIDC
+	// token for a Keystone token via federated authentication.
+	OpenStackAuthenticationMethodFederated = "federated"
+
 	// OpenStackVaultSecretKindV3Password is a Vault secret kind for
 	// OpenStack credentials using username/password.
 	OpenStackVaultSecretKindV3Password = "v3password"
@@ -91,6 +96,27 @@ const (
 	// DefaultWorkerMetricsPath is the default HTTP path at which the worker
 	// is exposing metrics.
 	DefaultWorkerMetricsPath = "/metrics"
+
+	// DefaultShutdownTimeout is the default grace period for draining
+	// in-flight tasks and requests, when shutting down the worker and
+	// dashboard services.
+	DefaultShutdownTimeout = 30 * time.Second
+
+	// DefaultStaleAfter is the default expected collection interval of
+	// models, after which their records are considered stale.
+	DefaultStaleAfter = 24 * time.Hour
+
+	// RedisModeStandalone is the name of the Redis mode, which connects to
+	// a single Redis endpoint.
+	RedisModeStandalone = "standalone"
+
+	// RedisModeFailover is the name of the Redis mode, which discovers the
+	// Redis master via Redis Sentinel.
+	RedisModeFailover = "failover"
+
+	// RedisModeCluster is the name of the Redis mode, which connects to a
+	// Redis Cluster.
+	RedisModeCluster = "cluster"
 )
 
 // ErrNoConfigVersion error is returned when the configuration does not specify
@@ -115,6 +141,9 @@ type Config struct {
 	// Logging provides the logging config settings
 	Logging LoggingConfig `yaml:"logging"`
 
+	// Tracing provides the OpenTelemetry tracing config settings.
+	Tracing TracingConfig `yaml:"tracing"`
+
 	// Redis represents the Redis configuration
 	Redis RedisConfig `yaml:"redis"`
 
@@ -127,6 +156,10 @@ type Config struct {
 	// Scheduler represents the scheduler configuration.
 	Scheduler SchedulerConfig `yaml:"scheduler"`
 
+	// QueueRouting represents the configuration for routing tasks to
+	// queues based on their task type or provider.
+	QueueRouting QueueRoutingConfig `yaml:"queue_routing"`
+
 	// Gardener represents the Gardener specific configuration.
 	Gardener GardenerConfig `yaml:"gardener"`
 
@@ -134,6 +167,10 @@ type Config struct {
 	// service.
 	Dashboard DashboardConfig `yaml:"dashboard"`
 
+	// Staleness provides the expected collection intervals of the models,
+	// after which their records are considered stale.
+	Staleness StalenessConfig `yaml:"staleness"`
+
 	// AWS represents the AWS specific configuration settings.
 	AWS AWSConfig `yaml:"aws"`
 
@@ -252,6 +289,18 @@ type OpenStackConfig struct {
 	// Credentials specifies the OpenStack named credentials configuration,
 	// which is used by the various OpenStack services.
 	Credentials map[string]OpenStackCredentialsConfig `yaml:"credentials"`
+
+	// RateLimit specifies the rate limit for the API requests sent with
+	// each named credentials.
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+
+	// IncludeProjects specifies the OpenStack projects to collect from. If
+	// empty, all projects with configured clients are collected.
+	IncludeProjects []string `yaml:"include_projects"`
+
+	// ExcludeProjects specifies the OpenStack projects, which are never
+	// collected. ExcludeProjects takes precedence over IncludeProjects.
+	ExcludeProjects []string `yaml:"exclude_projects"`
 }
 
 // OpenStackServices repsesents the known OpenStack services and their config.
@@ -273,6 +322,13 @@ type OpenStackServices struct {
 
 	// BlockStorage provides the BlockStorage service configuration.
 	BlockStorage OpenStackServiceCredentials `yaml:"block_storage"`
+
+	// SharedFileSystem provides the Shared File System (Manila) service
+	// configuration.
+	SharedFileSystem OpenStackServiceCredentials `yaml:"shared_file_system"`
+
+	// Image provides the Image (Glance) service configuration.
+	Image OpenStackServiceCredentials `yaml:"image"`
 }
 
 // OpenStackServiceCredentials specifies which credentials a service can use.
@@ -287,8 +343,9 @@ type OpenStackCredentialsConfig struct {
 	// Authentication specifies the authentication method/strategy to use
 	// when creating OpenStack API clients. The currently supported
 	// authentication mechanisms are `password' for username/password,
-	// `app_credentials' for Application Credentials and `vault_secret' for
-	// reading credentials from a Vault secret.
+	// `app_credentials' for Application Credentials, `vault_secret' for
+	// reading credentials from a Vault secret and `federated' for
+	// exchanging an OIDC token for a Keystone token.
 	Authentication string `yaml:"authentication"`
 
 	// Password provides the settings to use for authentication when using username/password.
@@ -301,12 +358,24 @@ type OpenStackCredentialsConfig struct {
 	// credentials from a Vault secret.
 	VaultSecret OpenStackVaultSecretConfig `yaml:"vault_secret"`
 
+	// Federated provides the settings to use for authentication when
+	// using federated authentication.
+	Federated OpenStackFederatedConfig `yaml:"federated"`
+
 	// Domain specifies the domain to use when initializing an OpenStack client.
 	Domain string `yaml:"domain"`
 
 	// Project specifies the project to use when initializing an OpenStack client.
 	Project string `yaml:"project"`
 
+	// DomainScoped specifies whether the credentials are scoped to the
+	// domain instead of a single project. When set to true the projects
+	// of the domain are discovered via the Identity service, and a
+	// project-scoped client is created for each project, which the
+	// credentials can access. Domain-scoped credentials are supported
+	// only with username/password and federated authentication.
+	DomainScoped bool `yaml:"domain_scoped"`
+
 	// Region specifies the region to use when initializing an OpenStack client.
 	Region string `yaml:"region"`
 
@@ -347,6 +416,24 @@ type OpenStackAppCredentialsConfig struct {
 	AppCredentialsSecretFile string `yaml:"app_credentials_secret_file"`
 }
 
+// OpenStackFederatedConfig provides the settings to use for authentication when
+// using federated authentication.
+type OpenStackFederatedConfig struct {
+	// IdentityProvider specifies the name of the identity provider as
+	// registered in Keystone.
+	IdentityProvider string `yaml:"identity_provider"`
+
+	// Protocol specifies the name of the federation protocol as
+	// registered in Keystone for the identity provider, e.g. `openid'.
+	Protocol string `yaml:"protocol"`
+
+	// TokenFile specifies the file path containing the OIDC token issued
+	// by the identity provider. The file is read each time a Keystone
+	// token is requested, so that rotated tokens are picked up when
+	// re-authenticating.
+	TokenFile string `yaml:"token_file"`
+}
+
 // AzureConfig provides Azure specific configuration settings.
 type AzureConfig struct {
 	// IsEnabled specifies whether the Azure collection is enabled or not.
@@ -359,6 +446,10 @@ type AzureConfig struct {
 	// Credentials specifies the Azure named credentials configuration,
 	// which is used by the various Azure services.
 	Credentials map[string]AzureCredentialsConfig `yaml:"credentials"`
+
+	// RateLimit specifies the rate limit for the API requests sent with
+	// each named credentials.
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
 }
 
 // AzureServices repsesents the known Azure services and their config.
@@ -441,9 +532,21 @@ type GCPConfig struct {
 	// is used by the various GCP services.
 	Credentials map[string]GCPCredentialsConfig `yaml:"credentials"`
 
+	// RateLimit specifies the rate limit for the API requests sent with
+	// each named credentials.
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+
 	// SoilCluster specifies the configuration settings for the GKE Regional
 	// Soil cluster.
 	SoilCluster GCPSoilClusterConfig `yaml:"soil_cluster"`
+
+	// IncludeProjects specifies the GCP projects to collect from. If
+	// empty, all projects with configured clients are collected.
+	IncludeProjects []string `yaml:"include_projects"`
+
+	// ExcludeProjects specifies the GCP projects, which are never
+	// collected. ExcludeProjects takes precedence over IncludeProjects.
+	ExcludeProjects []string `yaml:"exclude_projects"`
 }
 
 // GCPSoilClusterConfig provides config settings specific to the GKE Regional
@@ -470,6 +573,11 @@ type GCPServices struct {
 
 	// GKE contains the GKE service configuration.
 	GKE GCPServiceConfig `yaml:"gke"`
+
+	// CloudSQL contains the Cloud SQL service configuration. Collection
+	// of Cloud SQL instances is optional, and is enabled only when named
+	// credentials are specified.
+	CloudSQL GCPServiceConfig `yaml:"cloud_sql"`
 }
 
 // GCPServiceConfig provides service-specific configuration for a GCP service.
@@ -503,6 +611,13 @@ type GCPCredentialsConfig struct {
 	// happen only against the specified projects.
 	Projects []string `yaml:"projects"`
 
+	// DiscoverProjects specifies whether to discover the active projects,
+	// which the credentials can access, via the Resource Manager API. The
+	// projects are discovered when the GCP API clients are created, and
+	// again when the collection tasks fan out, for the services, which use
+	// the credentials.
+	DiscoverProjects bool `yaml:"discover_projects"`
+
 	// KeyFile provides the settings to use for authentication when using
 	// service account JSON Key File [1].
 	//
@@ -539,6 +654,18 @@ type AWSConfig struct {
 	// Credentials specifies the AWS credentials configuration, which is
 	// used by the various AWS services.
 	Credentials map[string]AWSCredentialsConfig `yaml:"credentials"`
+
+	// RateLimit specifies the rate limit for the API requests sent with
+	// each named credentials.
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+
+	// IncludeAccounts specifies the AWS accounts to collect from. If
+	// empty, all accounts with configured clients are collected.
+	IncludeAccounts []string `yaml:"include_accounts"`
+
+	// ExcludeAccounts specifies the AWS accounts, which are never
+	// collected. ExcludeAccounts takes precedence over IncludeAccounts.
+	ExcludeAccounts []string `yaml:"exclude_accounts"`
 }
 
 // AWSServices provides service-specific configuration for the AWS services.
@@ -554,6 +681,31 @@ type AWSServices struct {
 
 	// S3 provides S3-specific service configuration
 	S3 AWSServiceConfig `yaml:"s3"`
+
+	// Config provides AWS Config-specific service configuration. The
+	// service is optional, and no clients are created, if no credentials
+	// are specified.
+	Config AWSServiceConfig `yaml:"config"`
+
+	// SNS provides SNS-specific service configuration. The service is
+	// optional, and no clients are created, if no credentials are
+	// specified.
+	SNS AWSServiceConfig `yaml:"sns"`
+
+	// IAM provides IAM-specific service configuration. The service is
+	// optional, and no clients are created, if no credentials are
+	// specified.
+	IAM AWSServiceConfig `yaml:"iam"`
+
+	// RDS provides RDS-specific service configuration. The service is
+	// optional, and no clients are created, if no credentials are
+	// specified.
+	RDS AWSServiceConfig `yaml:"rds"`
+
+	// EKS provides EKS-specific service configuration. The service is
+	// optional, and no clients are created, if no credentials are
+	// specified.
+	EKS AWSServiceConfig `yaml:"eks"`
 }
 
 // AWSServiceConfig prvides service-specific configuration for an AWS service.
@@ -603,6 +755,29 @@ type AWSCredentialsConfig struct {
 	// TokenFileRetriever provides the configuration settings for the Token
 	// File retriever.
 	TokenFileRetriever AWSTokenFileRetrieverConfig `yaml:"token_file"`
+
+	// AssumeRoleChain specifies an optional ordered chain of IAM Roles,
+	// which are assumed successively, starting with the credentials
+	// provided by the token retriever. The credentials of the last role
+	// in the chain are used for accessing the AWS services.
+	AssumeRoleChain []AWSAssumeRoleConfig `yaml:"assume_role_chain"`
+}
+
+// AWSAssumeRoleConfig represents the configuration settings for an IAM Role,
+// which is assumed as part of a role chain.
+type AWSAssumeRoleConfig struct {
+	// RoleARN specifies the IAM Role ARN to be assumed.
+	RoleARN string `yaml:"role_arn"`
+
+	// RoleSessionName is a unique name for the session.
+	RoleSessionName string `yaml:"role_session_name"`
+
+	// ExternalID specifies the optional external id required by the trust
+	// policy of the role.
+	ExternalID string `yaml:"external_id"`
+
+	// Duration specifies the expiry duration for the STS credentials.
+	Duration time.Duration `yaml:"duration"`
 }
 
 // AWSKubeSATokenRetrieverConfig represents the configuration settings for the
@@ -652,8 +827,39 @@ type AWSTokenFileRetrieverConfig struct {
 
 // RedisConfig provides Redis specific configuration settings.
 type RedisConfig struct {
-	// Endpoint is the endpoint of the Redis service.
+	// Mode specifies how to connect to Redis. Supported modes are
+	// [RedisModeStandalone], [RedisModeFailover] and [RedisModeCluster].
+	// If not specified, [RedisModeStandalone] is used.
+	Mode string `yaml:"mode"`
+
+	// Endpoint is the endpoint of the Redis service, which is used in
+	// [RedisModeStandalone] mode.
 	Endpoint string `yaml:"endpoint"`
+
+	// Failover provides the settings, which are used in
+	// [RedisModeFailover] mode.
+	Failover RedisFailoverConfig `yaml:"failover"`
+
+	// Cluster provides the settings, which are used in [RedisModeCluster]
+	// mode.
+	Cluster RedisClusterConfig `yaml:"cluster"`
+}
+
+// RedisFailoverConfig provides the settings for discovering the Redis master
+// via Redis Sentinel.
+type RedisFailoverConfig struct {
+	// MasterName specifies the name of the master as configured in Redis
+	// Sentinel.
+	MasterName string `yaml:"master_name"`
+
+	// SentinelAddrs specifies the addresses of the Sentinel instances.
+	SentinelAddrs []string `yaml:"sentinel_addrs"`
+}
+
+// RedisClusterConfig provides the settings for connecting to a Redis Cluster.
+type RedisClusterConfig struct {
+	// Addrs specifies the seed addresses of the cluster nodes.
+	Addrs []string `yaml:"addrs"`
 }
 
 // DatabaseConfig provides database specific configuration settings.
@@ -664,6 +870,19 @@ type DatabaseConfig struct {
 	// MigrationDirectory specifies an alternate location with migration
 	// files.
 	MigrationDirectory string `yaml:"migration_dir"`
+
+	// ReadStatementTimeout specifies the statement timeout for read
+	// operations, e.g. the selects performed by link functions.
+	ReadStatementTimeout time.Duration `yaml:"read_statement_timeout"`
+
+	// WriteStatementTimeout specifies the statement timeout for write
+	// operations, e.g. upserts and deletes.
+	WriteStatementTimeout time.Duration `yaml:"write_statement_timeout"`
+
+	// PromotedTags specifies the tag keys, which are materialized from the
+	// generic tag set into indexed columns of the models, which provide
+	// tags.
+	PromotedTags []string `yaml:"promoted_tags"`
 }
 
 // WorkerConfig provides worker specific configuration settings.
@@ -674,6 +893,12 @@ type WorkerConfig struct {
 	// Concurrency specifies the concurrency level for workers.
 	Concurrency int `yaml:"concurrency"`
 
+	// ShutdownTimeout specifies the grace period for in-flight tasks to
+	// complete, when the worker is shutting down. Tasks, which do not
+	// complete within the grace period, are re-enqueued. If not specified,
+	// [DefaultShutdownTimeout] is used.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
 	// Queues specifies the priority queue configuration for the worker.
 	//
 	// See [1] for more details about how priority queues work.
@@ -681,12 +906,137 @@ type WorkerConfig struct {
 	// [1]: https://github.com/hibiken/asynq/wiki/Queue-Priority
 	Queues map[string]int `yaml:"queues"`
 
+	// Providers specifies the providers, for which the worker registers
+	// task handlers, e.g. `aws' and `gcp'. Queues dedicated to other
+	// providers are ignored. If empty, the handlers of all providers are
+	// registered.
+	Providers []string `yaml:"providers"`
+
 	// StrictPriority specifies whether queue priority is treated strictly.
 	//
 	// When it is set to true tasks from queues with higher priority are
 	// always processed first, and tasks from queues with lower priority are
 	// processed only after higher priority queues are empty.
 	StrictPriority bool `yaml:"strict_priority"`
+
+	// ZeroRowAlerts specifies the task types, for which collections
+	// returning zero rows for a previously non-empty scope are reported.
+	ZeroRowAlerts []ZeroRowAlertConfig `yaml:"zero_row_alerts"`
+
+	// Sharding specifies the settings for distributing the fan-out tasks
+	// to shard queues using consistent hashing.
+	Sharding ShardingConfig `yaml:"sharding"`
+
+	// InlineChildren specifies the task types of parent collectors, which
+	// collect and link their children within the same task, instead of
+	// relying on separate collection and link tasks. This reduces latency
+	// for small child sets, while separate tasks are better suited for
+	// large child sets.
+	InlineChildren []string `yaml:"inline_children"`
+
+	// Results specifies the settings for persisting the structured
+	// results of tasks.
+	Results TaskResultsConfig `yaml:"results"`
+
+	// IncrementalLinks specifies the settings for establishing links
+	// only for the rows, which have changed since the last link run.
+	IncrementalLinks IncrementalLinksConfig `yaml:"incremental_links"`
+
+	// RawSamples specifies the settings for sampling the raw provider
+	// responses of tasks for debugging purposes.
+	RawSamples RawSamplesConfig `yaml:"raw_samples"`
+
+	// MinIntervals specifies the settings for enforcing a minimum interval
+	// between collections of the same scope.
+	MinIntervals MinIntervalsConfig `yaml:"min_intervals"`
+}
+
+// MinIntervalsConfig provides the settings for enforcing a minimum interval
+// between collections of the same scope, which prevents manual enqueues and
+// scheduled runs from collecting a scope more often than needed.
+type MinIntervalsConfig struct {
+	// IsEnabled specifies whether minimum intervals are enforced.
+	IsEnabled bool `yaml:"is_enabled"`
+
+	// Tasks maps task types to their minimum collection interval. Task
+	// types, which are not specified here, are not guarded.
+	Tasks map[string]time.Duration `yaml:"tasks"`
+}
+
+// RawSamplesConfig provides the settings for capturing the raw provider
+// responses for the first items collected by a task, which is useful when
+// investigating unexpected data returned by a provider.
+type RawSamplesConfig struct {
+	// IsEnabled specifies whether raw responses are sampled.
+	IsEnabled bool `yaml:"is_enabled"`
+
+	// Tasks specifies the task types, for which raw responses are
+	// sampled.
+	Tasks []string `yaml:"tasks"`
+
+	// Size specifies the max number of items, which are sampled per task
+	// run. If not specified, the default sample size is used.
+	Size int `yaml:"size"`
+
+	// OutputDir specifies a directory, in which the samples are written
+	// as JSON files. If not specified, the samples are logged instead.
+	OutputDir string `yaml:"output_dir"`
+}
+
+// IncrementalLinksConfig provides the settings for incremental link runs, which
+// process only the source rows updated since the last run of a relationship.
+type IncrementalLinksConfig struct {
+	// IsEnabled specifies whether incremental link runs are enabled.
+	IsEnabled bool `yaml:"is_enabled"`
+
+	// FullRebuildInterval specifies the interval after which all links of
+	// a relationship are rebuilt, regardless of whether the rows have
+	// changed.
+	FullRebuildInterval time.Duration `yaml:"full_rebuild_interval"`
+
+	// ForceFullRebuild specifies whether to always rebuild all links.
+	ForceFullRebuild bool `yaml:"force_full_rebuild"`
+}
+
+// TaskResultsConfig provides the settings for persisting the structured
+// results of tasks, e.g. rows inserted or updated, pages fetched, etc.
+type TaskResultsConfig struct {
+	// IsEnabled specifies whether task results are persisted in the
+	// database.
+	IsEnabled bool `yaml:"is_enabled"`
+
+	// StoreInAsynq specifies whether task results are also written to the
+	// result field of asynq tasks. Note, that asynq keeps the results of
+	// completed tasks only for tasks enqueued with a retention period.
+	StoreInAsynq bool `yaml:"store_in_asynq"`
+}
+
+// ShardingConfig provides the settings for distributing tasks for a given
+// account or project to a deterministic shard queue.
+type ShardingConfig struct {
+	// IsEnabled specifies whether sharding is enabled or not.
+	IsEnabled bool `yaml:"is_enabled"`
+
+	// Shards specifies the number of shard queues per queue.
+	Shards int `yaml:"shards"`
+
+	// OwnedShards specifies the shards, which are processed by the worker.
+	// If empty, the worker processes all shards.
+	OwnedShards []int `yaml:"owned_shards"`
+}
+
+// ZeroRowAlertConfig provides the settings for reporting unexpected zero-row
+// collections for a given task type.
+type ZeroRowAlertConfig struct {
+	// Task specifies the task type name.
+	Task string `yaml:"task"`
+
+	// AllowedScopes specifies the scopes, which may legitimately return
+	// zero rows, and for which no alert is reported. A scope is the
+	// account and region of a task joined by a slash, e.g.
+	// `project/region', or the account only for tasks, which are not
+	// regional.
+	AllowedScopes []string `yaml:"allowed_scopes"`
 }
 
 // WorkerMetricsConfig provides settings for exposing worker-related metrics
@@ -697,6 +1047,42 @@ type WorkerMetricsConfig struct {
 	// Address specifies the TCP network address for the HTTP server, which
 	// serves the metrics.
 	Address string `yaml:"address"`
+
+	// Namespace specifies the namespace component of the fully qualified
+	// metric names. If not specified, the default `inventory' namespace
+	// is used.
+	Namespace string `yaml:"namespace"`
+
+	// Labels specifies constant labels, which are added to all metrics,
+	// e.g. in order to distinguish deployments in a shared Prometheus.
+	Labels map[string]string `yaml:"labels"`
+}
+
+// QueueRoutingConfig provides the settings for routing tasks to queues based on
+// their task type or provider. When both a task type and a provider route match
+// a task, the task type route takes precedence.
+type QueueRoutingConfig struct {
+	// IsEnabled specifies whether tasks are routed to the configured
+	// queues.
+	IsEnabled bool `yaml:"is_enabled"`
+
+	// Tasks maps task types to queue routes.
+	Tasks map[string]QueueRouteConfig `yaml:"tasks"`
+
+	// Providers maps provider names, e.g. `aws' or `openstack', to queue
+	// routes.
+	Providers map[string]QueueRouteConfig `yaml:"providers"`
+}
+
+// QueueRouteConfig specifies the queue, to which matching tasks are routed.
+type QueueRouteConfig struct {
+	// Queue specifies the name of the queue.
+	Queue string `yaml:"queue"`
+
+	// Priority specifies the priority of the queue, which is used by the
+	// workers, unless the queue is already configured in the worker queues.
+	// Defaults to 1.
+	Priority int `yaml:"priority"`
 }
 
 // SchedulerConfig provides scheduler specific configuration settings.
@@ -728,6 +1114,10 @@ type PeriodicJob struct {
 	// submitted. If it is not specified, then the task will be submitted to
 	// the [DefaultQueueName] queue.
 	Queue string `yaml:"queue"`
+
+	// IsDisabled specifies whether the job is disabled. Disabled jobs are
+	// validated, but not registered with the scheduler.
+	IsDisabled bool `yaml:"is_disabled"`
 }
 
 // GardenerConfig represents the Gardener specific configuration.
@@ -796,6 +1186,98 @@ type DashboardConfig struct {
 	// PrometheusEndpoint specifies the Prometheus endpoint from which the
 	// Dashboard UI will read metrics.
 	PrometheusEndpoint string `yaml:"prometheus_endpoint"`
+
+	// API provides the settings for the read-only API.
+	API APIConfig `yaml:"api"`
+
+	// Metrics specifies the settings for the metrics exposed by the
+	// Dashboard service.
+	Metrics DashboardMetricsConfig `yaml:"metrics"`
+
+	// ShutdownTimeout specifies the grace period for in-flight requests to
+	// complete, when the Dashboard service is shutting down. If not
+	// specified, [DefaultShutdownTimeout] is used.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// DashboardMetricsConfig provides the settings for the metrics exposed by the
+// Dashboard service.
+type DashboardMetricsConfig struct {
+	// Collectors specifies the names of the collectors, which are
+	// registered with the metrics endpoint of the Dashboard service. If
+	// not specified, the queue and runtime collectors are registered.
+	Collectors []string `yaml:"collectors"`
+
+	// Namespace specifies the namespace component of the fully qualified
+	// names of the database metrics. If not specified, the default
+	// `inventory' namespace is used.
+	Namespace string `yaml:"namespace"`
+
+	// DBTimeout specifies the timeout for the queries executed by the
+	// database collector.
+	DBTimeout time.Duration `yaml:"db_timeout"`
+}
+
+// APIConfig provides the settings for the read-only API, which is served by
+// the Dashboard service.
+type APIConfig struct {
+	// IsEnabled specifies whether the API is enabled or not.
+	IsEnabled bool `yaml:"is_enabled"`
+
+	// Principals specifies the principals, which are allowed to access the
+	// API.
+	Principals []APIPrincipalConfig `yaml:"principals"`
+}
+
+// StalenessConfig provides the expected collection intervals of the models,
+// after which their records are considered stale.
+type StalenessConfig struct {
+	// Default specifies the expected collection interval of the models,
+	// which are not configured in Models. If not specified,
+	// [DefaultStaleAfter] is used.
+	Default time.Duration `yaml:"default"`
+
+	// Models specifies the expected collection interval of models, keyed
+	// by model name, e.g. `aws:model:instance'.
+	Models map[string]time.Duration `yaml:"models"`
+}
+
+// StaleAfter returns the expected collection interval of the model with the
+// given name.
+func (c StalenessConfig) StaleAfter(model string) time.Duration {
+	if interval, ok := c.Models[model]; ok && interval > 0 {
+		return interval
+	}
+
+	if c.Default > 0 {
+		return c.Default
+	}
+
+	return DefaultStaleAfter
+}
+
+// APIPrincipalConfig represents a principal, which authenticates against the
+// API using a bearer token, and is restricted to a set of accounts and
+// projects.
+type APIPrincipalConfig struct {
+	// Name specifies the name of the principal.
+	Name string `yaml:"name"`
+
+	// TokenFile specifies the path to a file containing the bearer token
+	// of the principal.
+	TokenFile string `yaml:"token_file"`
+
+	// Unrestricted specifies whether the principal has access to all
+	// records, regardless of their scope.
+	Unrestricted bool `yaml:"unrestricted"`
+
+	// AccountIDs specifies the account ids, which the principal has access
+	// to.
+	AccountIDs []string `yaml:"account_ids"`
+
+	// ProjectIDs specifies the project ids, which the principal has access
+	// to.
+	ProjectIDs []string `yaml:"project_ids"`
 }
 
 // LoggingConfig provides the logging-specific settings.
@@ -815,6 +1297,22 @@ type LoggingConfig struct {
 	Attributes map[string]string `yaml:"attributes"`
 }
 
+// TracingConfig provides the OpenTelemetry tracing config settings.
+type TracingConfig struct {
+	// Endpoint specifies the host and port of the OTLP/HTTP endpoint, to
+	// which spans are exported, e.g. localhost:4318. If empty, tracing is
+	// disabled.
+	Endpoint string `yaml:"endpoint"`
+
+	// Insecure specifies whether to export spans via plain HTTP instead of
+	// HTTPS.
+	Insecure bool `yaml:"insecure"`
+
+	// ServiceName specifies the name of the service, which is reported
+	// with the spans.
+	ServiceName string `yaml:"service_name"`
+}
+
 // ParseFileInto parses the configuration from the given path and unmarshals it
 // into the specified out value.
 func ParseFileInto(path string, out any) error {
@@ -873,3 +1371,17 @@ func MustParse(paths ...string) *Config {
 
 	return config
 }
+
+// RateLimitConfig provides the settings of the token-bucket rate limiter for
+// the requests sent by the API clients of a provider. A limiter is shared by
+// the clients of all services, which use the same named credentials.
+type RateLimitConfig struct {
+	// RequestsPerSecond specifies the max number of requests per second.
+	// Zero means no limit.
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+
+	// Burst specifies the max number of requests, which may be sent at
+	// once. If not a positive number, it defaults to the ceiling of
+	// RequestsPerSecond.
+	Burst int `yaml:"burst"`
+}
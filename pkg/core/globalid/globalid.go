@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package globalid provides global ids, which identify resources across
+// providers, e.g. aws:<account-id>:<instance-id>.
+//
+// The global id of a resource is derived from the columns, which identify the
+// resource within its provider, so that it can be computed both by the
+// collectors and by the database for existing rows.
+package globalid
+
+import (
+	"strings"
+
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+// Column is the name of the column, which stores the global id of a resource.
+const Column = "global_id"
+
+// Separator separates the parts of a global id.
+const Separator = ":"
+
+// Spec specifies how the global id of a model is derived from its columns.
+type Spec struct {
+	// Provider specifies the provider prefix of the global id, e.g. aws.
+	Provider string
+
+	// Columns specifies the columns, which identify the resource within
+	// its provider, in the order in which they are part of the global id.
+	Columns []string
+}
+
+// New returns the global id of the resource with the given provider and parts.
+// The parts are expected in the same order as the columns of the [Spec] of the
+// respective model.
+func New(provider string, parts ...string) string {
+	return strings.Join(append([]string{provider}, parts...), Separator)
+}
+
+// Expr returns the SQL expression, which computes the global id of a row from
+// its columns. The expression yields the same global id as [New].
+func (s Spec) Expr() bun.Safe {
+	parts := make([]string, 0, len(s.Columns)+2)
+	parts = append(parts, quoteLiteral(Separator), quoteLiteral(s.Provider))
+	for _, column := range s.Columns {
+		parts = append(parts, `"`+column+`"::text`)
+	}
+
+	return bun.Safe("concat_ws(" + strings.Join(parts, ", ") + ")")
+}
+
+// quoteLiteral returns the given value as a SQL string literal.
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// Registry provides the [Spec] of the models, which provide global ids, keyed
+// by the model name.
+var Registry = registry.New[string, Spec]()
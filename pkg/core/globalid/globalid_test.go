@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package globalid_test
+
+import (
+	"testing"
+
+	"github.com/gardener/inventory/pkg/core/globalid"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		provider string
+		parts    []string
+		want     string
+	}{
+		{
+			desc:     "without parts",
+			provider: "aws",
+			want:     "aws",
+		},
+		{
+			desc:     "single part",
+			provider: "gcp",
+			parts:    []string{"project"},
+			want:     "gcp:project",
+		},
+		{
+			desc:     "multiple parts",
+			provider: "az",
+			parts:    []string{"subscription", "group", "vm"},
+			want:     "az:subscription:group:vm",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := globalid.New(tc.provider, tc.parts...)
+			if got != tc.want {
+				t.Fatalf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSpecExpr(t *testing.T) {
+	testCases := []struct {
+		desc string
+		spec globalid.Spec
+		want string
+	}{
+		{
+			desc: "single column",
+			spec: globalid.Spec{Provider: "aws", Columns: []string{"instance_id"}},
+			want: `concat_ws(':', 'aws', "instance_id"::text)`,
+		},
+		{
+			desc: "multiple columns",
+			spec: globalid.Spec{Provider: "openstack", Columns: []string{"project_id", "server_id"}},
+			want: `concat_ws(':', 'openstack', "project_id"::text, "server_id"::text)`,
+		},
+		{
+			desc: "provider with quote",
+			spec: globalid.Spec{Provider: "o'brien", Columns: []string{"id"}},
+			want: `concat_ws(':', 'o''brien', "id"::text)`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := string(tc.spec.Expr())
+			if got != tc.want {
+				t.Fatalf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -15,4 +15,41 @@ type Model struct {
 	ID        uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()"`
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// LastSeenAt specifies when the record was last seen by a collector,
+	// which reconciles the records of its scope.
+	LastSeenAt time.Time `bun:"last_seen_at,nullzero"`
+
+	// DeletedAt specifies when the record disappeared from the provider,
+	// i.e. it was not seen by the latest reconciled collection of its
+	// scope.
+	DeletedAt time.Time `bun:"deleted_at,nullzero"`
+}
+
+// Age returns the duration since the record was last seen by a collector. For
+// records, which have not been seen by a reconciling collector yet, the age is
+// based on the time the record was last updated.
+func (m Model) Age() time.Duration {
+	if !m.LastSeenAt.IsZero() {
+		return time.Since(m.LastSeenAt)
+	}
+
+	return time.Since(m.UpdatedAt)
+}
+
+// AgeSeconds returns the number of seconds since the record was last seen by a
+// collector.
+func (m Model) AgeSeconds() int64 {
+	return int64(m.Age().Seconds())
+}
+
+// IsDeleted returns true, if the record has disappeared from the provider.
+func (m Model) IsDeleted() bool {
+	return !m.DeletedAt.IsZero()
+}
+
+// IsStale returns true, if the record has not been seen within the given
+// expected collection interval.
+func (m Model) IsStale(interval time.Duration) bool {
+	return m.Age() > interval
 }
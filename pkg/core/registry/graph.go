@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrCycleDetected is returned when the task graph contains a cycle.
+var ErrCycleDetected = errors.New("cycle detected")
+
+// ErrUnknownPrerequisite is returned when a task in the graph declares a
+// prerequisite, which is not part of the graph.
+var ErrUnknownPrerequisite = errors.New("unknown prerequisite")
+
+// ErrUnknownTask is returned when requesting a task, which is not part of the
+// graph.
+var ErrUnknownTask = errors.New("unknown task")
+
+// TaskGraph is the default graph, which declares the ordering of collection
+// tasks.
+var TaskGraph = NewGraph()
+
+// Graph is a concurrent-safe directed acyclic graph of tasks, where each task
+// declares the tasks, which must complete before it can be processed.
+type Graph struct {
+	mu    sync.Mutex
+	nodes map[string][]string
+}
+
+// NewGraph creates a new empty graph.
+func NewGraph() *Graph {
+	g := &Graph{
+		nodes: make(map[string][]string),
+	}
+
+	return g
+}
+
+// Add adds the given task with its prerequisites to the graph.
+func (g *Graph) Add(task string, prerequisites ...string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.nodes[task]; exists {
+		return fmt.Errorf("%w: %s", ErrKeyAlreadyRegistered, task)
+	}
+
+	g.nodes[task] = slices.Clone(prerequisites)
+
+	return nil
+}
+
+// MustAdd adds the given task with its prerequisites to the graph, or panics
+// in case of errors.
+func (g *Graph) MustAdd(task string, prerequisites ...string) {
+	if err := g.Add(task, prerequisites...); err != nil {
+		panic(err)
+	}
+}
+
+// Prerequisites returns the prerequisites of the given task, and a boolean
+// indicating whether the task is present in the graph.
+func (g *Graph) Prerequisites(task string) ([]string, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	prerequisites, ok := g.nodes[task]
+
+	return slices.Clone(prerequisites), ok
+}
+
+// Validate verifies that all prerequisites are part of the graph, and that the
+// graph does not contain any cycles.
+func (g *Graph) Validate() error {
+	_, err := g.Waves()
+
+	return err
+}
+
+// Waves sorts the graph topologically and returns the tasks grouped in waves.
+// The tasks of a wave depend only on tasks from the previous waves, and can be
+// processed concurrently once the previous waves have completed.
+//
+// If tasks are specified, then only the given tasks and their transitive
+// prerequisites are considered.
+func (g *Graph) Waves(tasks ...string) ([][]string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Validate the prerequisites in sorted order, so that errors are
+	// reported consistently.
+	names := make([]string, 0, len(g.nodes))
+	for name := range g.nodes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		for _, prerequisite := range g.nodes[name] {
+			if _, ok := g.nodes[prerequisite]; !ok {
+				return nil, fmt.Errorf("%w: %s requires %s", ErrUnknownPrerequisite, name, prerequisite)
+			}
+		}
+	}
+
+	if err := g.detectCycle(names); err != nil {
+		return nil, err
+	}
+
+	selected, err := g.closure(names, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the tasks by the length of the longest path to a task
+	// without prerequisites.
+	depth := make(map[string]int, len(selected))
+	var visit func(name string) int
+	visit = func(name string) int {
+		if d, ok := depth[name]; ok {
+			return d
+		}
+		d := 0
+		for _, prerequisite := range g.nodes[name] {
+			d = max(d, visit(prerequisite)+1)
+		}
+		depth[name] = d
+
+		return d
+	}
+
+	waves := make([][]string, 0)
+	for _, name := range selected {
+		d := visit(name)
+		for len(waves) <= d {
+			waves = append(waves, make([]string, 0))
+		}
+		waves[d] = append(waves[d], name)
+	}
+
+	return waves, nil
+}
+
+// closure returns the given tasks and their transitive prerequisites in
+// sorted order. If no tasks are given, all tasks from the graph are returned.
+func (g *Graph) closure(names []string, tasks []string) ([]string, error) {
+	if len(tasks) == 0 {
+		return names, nil
+	}
+
+	seen := make(map[string]bool)
+	var walk func(name string)
+	walk = func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		for _, prerequisite := range g.nodes[name] {
+			walk(prerequisite)
+		}
+	}
+
+	for _, task := range tasks {
+		if _, ok := g.nodes[task]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTask, task)
+		}
+		walk(task)
+	}
+
+	result := make([]string, 0, len(seen))
+	for _, name := range names {
+		if seen[name] {
+			result = append(result, name)
+		}
+	}
+
+	return result, nil
+}
+
+// detectCycle returns an error, which describes the first cycle found in the
+// graph, if any.
+func (g *Graph) detectCycle(names []string) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(names))
+	path := make([]string, 0)
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, name)
+			cycle := append(slices.Clone(path[start:]), name)
+
+			return fmt.Errorf("%w: %s", ErrCycleDetected, strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, prerequisite := range g.nodes[name] {
+			if err := visit(prerequisite); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
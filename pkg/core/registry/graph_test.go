@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+func TestGraphWaves(t *testing.T) {
+	g := registry.NewGraph()
+	g.MustAdd("projects")
+	g.MustAdd("networks", "projects")
+	g.MustAdd("servers", "projects")
+	g.MustAdd("ports", "servers", "networks")
+	g.MustAdd("floating-ips", "ports")
+	g.MustAdd("images")
+
+	testCases := []struct {
+		desc   string
+		tasks  []string
+		wanted [][]string
+	}{
+		{
+			desc:  "all tasks",
+			tasks: nil,
+			wanted: [][]string{
+				{"images", "projects"},
+				{"networks", "servers"},
+				{"ports"},
+				{"floating-ips"},
+			},
+		},
+		{
+			desc:  "task with prerequisites",
+			tasks: []string{"ports"},
+			wanted: [][]string{
+				{"projects"},
+				{"networks", "servers"},
+				{"ports"},
+			},
+		},
+		{
+			desc:  "task without prerequisites",
+			tasks: []string{"images"},
+			wanted: [][]string{
+				{"images"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := g.Waves(tc.tasks...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.wanted) {
+				t.Fatalf("want %v, got %v", tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestGraphValidate(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		nodes  map[string][]string
+		wanted error
+	}{
+		{
+			desc: "valid graph",
+			nodes: map[string][]string{
+				"a": nil,
+				"b": {"a"},
+			},
+			wanted: nil,
+		},
+		{
+			desc: "self-referencing task",
+			nodes: map[string][]string{
+				"a": {"a"},
+			},
+			wanted: registry.ErrCycleDetected,
+		},
+		{
+			desc: "cycle between tasks",
+			nodes: map[string][]string{
+				"a": {"c"},
+				"b": {"a"},
+				"c": {"b"},
+			},
+			wanted: registry.ErrCycleDetected,
+		},
+		{
+			desc: "unknown prerequisite",
+			nodes: map[string][]string{
+				"a": {"b"},
+			},
+			wanted: registry.ErrUnknownPrerequisite,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := registry.NewGraph()
+			for name, prerequisites := range tc.nodes {
+				g.MustAdd(name, prerequisites...)
+			}
+
+			err := g.Validate()
+			if !errors.Is(err, tc.wanted) {
+				t.Fatalf("want error %v, got %v", tc.wanted, err)
+			}
+		})
+	}
+}
+
+func TestGraphUnknownTask(t *testing.T) {
+	g := registry.NewGraph()
+	g.MustAdd("a")
+
+	if _, err := g.Waves("b"); !errors.Is(err, registry.ErrUnknownTask) {
+		t.Fatalf("want error %v, got %v", registry.ErrUnknownTask, err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws"
 	awsinstall "github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/install"
 	"github.com/hibiken/asynq"
+	"github.com/uptrace/bun"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/gardener/inventory/pkg/gardener/models"
 	gutils "github.com/gardener/inventory/pkg/gardener/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -77,13 +79,12 @@ func collectAWSMachineImages(ctx context.Context, payload CollectCPMachineImages
 		}
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, ami, version, region_name, cloud_profile_name) DO UPDATE").
-		Set("architecture = EXCLUDED.architecture").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, ami, version, region_name, cloud_profile_name) DO UPDATE").
+			Set("architecture = EXCLUDED.architecture").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -95,11 +96,6 @@ func collectAWSMachineImages(ctx context.Context, payload CollectCPMachineImages
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated gardener aws cloud profile images",
 		"cloud_profile", payload.CloudProfileName,
@@ -11,6 +11,7 @@ import (
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/pager"
@@ -21,6 +22,7 @@ import (
 	"github.com/gardener/inventory/pkg/gardener/models"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -99,18 +101,17 @@ func HandleCollectBackupBucketsTask(ctx context.Context, _ *asynq.Task) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&buckets).
-		On("CONFLICT (name) DO UPDATE").
-		Set("provider_type = EXCLUDED.provider_type").
-		Set("region_name = EXCLUDED.region_name").
-		Set("seed_name = EXCLUDED.seed_name").
-		Set("state = EXCLUDED.state").
-		Set("state_progress = EXCLUDED.state_progress").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, buckets, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name) DO UPDATE").
+			Set("provider_type = EXCLUDED.provider_type").
+			Set("region_name = EXCLUDED.region_name").
+			Set("seed_name = EXCLUDED.seed_name").
+			Set("state = EXCLUDED.state").
+			Set("state_progress = EXCLUDED.state_progress").
+			Set("creation_timestamp = EXCLUDED.creation_timestamp").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -121,11 +122,6 @@ func HandleCollectBackupBucketsTask(ctx context.Context, _ *asynq.Task) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info("populated gardener backup buckets", "count", count)
 
 	return nil
@@ -12,17 +12,18 @@ import (
 	gardenerv1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/pager"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gardenerclient "github.com/gardener/inventory/pkg/clients/gardener"
 	"github.com/gardener/inventory/pkg/gardener/constants"
 	"github.com/gardener/inventory/pkg/gardener/models"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -100,6 +101,7 @@ func HandleCollectCloudProfilesTask(ctx context.Context, _ *asynq.Task) error {
 	)
 	opts := metav1.ListOptions{Limit: constants.PageSize}
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0)
 	err := p.EachListItem(ctx, opts, func(obj runtime.Object) error {
 		cp, ok := obj.(*gardenerv1beta1.CloudProfile)
 		if !ok {
@@ -154,28 +156,9 @@ func HandleCollectCloudProfilesTask(ctx context.Context, _ *asynq.Task) error {
 			return nil
 		}
 
-		task := asynq.NewTask(miTaskName, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"cloud_profile", cp.Name,
-				"provider_type", providerType,
-				"reason", err,
-			)
-
-			return nil
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"cloud_profile", cp.Name,
-			"provider_type", providerType,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(miTaskName, data)
+		})
 
 		return nil
 	})
@@ -188,14 +171,13 @@ func HandleCollectCloudProfilesTask(ctx context.Context, _ *asynq.Task) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&cloudProfiles).
-		On("CONFLICT (name) DO UPDATE").
-		Set("type = EXCLUDED.type").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, cloudProfiles, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name) DO UPDATE").
+			Set("type = EXCLUDED.type").
+			Set("creation_timestamp = EXCLUDED.creation_timestamp").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -206,12 +188,9 @@ func HandleCollectCloudProfilesTask(ctx context.Context, _ *asynq.Task) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info("populated gardener cloud profiles", "count", count)
 
-	return nil
+	// The machine images tasks are enqueued once the Cloud Profiles have
+	// been persisted.
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
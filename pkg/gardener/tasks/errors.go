@@ -4,28 +4,50 @@
 
 package tasks
 
-import "errors"
+import (
+	"errors"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
 
 // ErrNoProjectName is an error, which is returned when an expected Project Name
 // was not specified as part of the task payload.
-var ErrNoProjectName = errors.New("no project name specified")
+var ErrNoProjectName error = &asynqutils.CollectionError{
+	Provider: "gardener",
+	Err:      errors.New("no project name specified"),
+}
 
 // ErrNoProjectNamespace is an error, which is returned when an expected Project
 // namespace was not specified as part of the task payload.
-var ErrNoProjectNamespace = errors.New("no project namespace specified")
+var ErrNoProjectNamespace error = &asynqutils.CollectionError{
+	Provider: "gardener",
+	Err:      errors.New("no project namespace specified"),
+}
 
 // ErrNoSeedCluster is an error, which is returned when an expected Seed Cluster
 // was not specified.
-var ErrNoSeedCluster = errors.New("no seed cluster specified")
+var ErrNoSeedCluster error = &asynqutils.CollectionError{
+	Provider: "gardener",
+	Err:      errors.New("no seed cluster specified"),
+}
 
 // ErrNoProviderConfig is returned when an expected provider config is
 // missing from the payload.
-var ErrNoProviderConfig = errors.New("no provider config specified")
+var ErrNoProviderConfig error = &asynqutils.CollectionError{
+	Provider: "gardener",
+	Err:      errors.New("no provider config specified"),
+}
 
 // ErrNoCloudProfileName is returned when an expected cloud profile name is
 // missing from the payload.
-var ErrNoCloudProfileName = errors.New("no cloud profile name specified")
+var ErrNoCloudProfileName error = &asynqutils.CollectionError{
+	Provider: "gardener",
+	Err:      errors.New("no cloud profile name specified"),
+}
 
 // ErrNoPayload is an error, which is returned by task handlers, which expect
 // payload, but none was provided.
-var ErrNoPayload = errors.New("no payload specified")
+var ErrNoPayload error = &asynqutils.CollectionError{
+	Provider: "gardener",
+	Err:      errors.New("no payload specified"),
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp"
 	gcpinstall "github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/install"
 	"github.com/hibiken/asynq"
+	"github.com/uptrace/bun"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 
@@ -19,6 +20,7 @@ import (
 	gutils "github.com/gardener/inventory/pkg/gardener/utils"
 	"github.com/gardener/inventory/pkg/gcp/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -75,13 +77,12 @@ func collectGCPMachineImages(ctx context.Context, payload CollectCPMachineImages
 		}
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, image, version, cloud_profile_name) DO UPDATE").
-		Set("architecture = EXCLUDED.architecture").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, image, version, cloud_profile_name) DO UPDATE").
+			Set("architecture = EXCLUDED.architecture").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -93,11 +94,6 @@ func collectGCPMachineImages(ctx context.Context, payload CollectCPMachineImages
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated gardener gcp cloud profile images",
 		"cloud_profile", payload.CloudProfileName,
@@ -7,15 +7,17 @@ package tasks
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/gardener/models"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // LinkShootWithProject creates the relationship between the Gardener Shoot and
 // Project.
-func LinkShootWithProject(ctx context.Context, db *bun.DB) error {
+func LinkShootWithProject(ctx context.Context, db bun.IDB) error {
 	var shoots []models.Shoot
 	err := db.NewSelect().
 		Model(&shoots).
@@ -40,6 +42,10 @@ func LinkShootWithProject(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.ShootToProject) []uuid.UUID {
+		return []uuid.UUID{l.ShootID, l.ProjectID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (shoot_id, project_id) DO UPDATE").
@@ -56,6 +62,8 @@ func LinkShootWithProject(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gardener shoot with project", "count", count)
 
@@ -63,7 +71,7 @@ func LinkShootWithProject(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkShootWithSeed creates the relationship between the Shoot and Seed
-func LinkShootWithSeed(ctx context.Context, db *bun.DB) error {
+func LinkShootWithSeed(ctx context.Context, db bun.IDB) error {
 	var shoots []models.Shoot
 	err := db.NewSelect().
 		Model(&shoots).
@@ -88,6 +96,10 @@ func LinkShootWithSeed(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.ShootToSeed) []uuid.UUID {
+		return []uuid.UUID{l.ShootID, l.SeedID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (shoot_id, seed_id) DO UPDATE").
@@ -104,6 +116,8 @@ func LinkShootWithSeed(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gardener shoot with seed", "count", count)
 
@@ -111,7 +125,7 @@ func LinkShootWithSeed(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkMachineWithShoot creates the relationship between the Machine and Shoot
-func LinkMachineWithShoot(ctx context.Context, db *bun.DB) error {
+func LinkMachineWithShoot(ctx context.Context, db bun.IDB) error {
 	var machines []models.Machine
 	err := db.NewSelect().
 		Model(&machines).
@@ -136,6 +150,10 @@ func LinkMachineWithShoot(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.MachineToShoot) []uuid.UUID {
+		return []uuid.UUID{l.MachineID, l.ShootID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (machine_id, shoot_id) DO UPDATE").
@@ -152,6 +170,8 @@ func LinkMachineWithShoot(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gardener machine with shoot", "count", count)
 
@@ -159,7 +179,7 @@ func LinkMachineWithShoot(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkAWSImageWithCloudProfile creates the relationship between the CloudProfileAWSImage and CloudProfile
-func LinkAWSImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
+func LinkAWSImageWithCloudProfile(ctx context.Context, db bun.IDB) error {
 	var awsImages []models.CloudProfileAWSImage
 	err := db.NewSelect().
 		Model(&awsImages).
@@ -184,6 +204,10 @@ func LinkAWSImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.AWSImageToCloudProfile) []uuid.UUID {
+		return []uuid.UUID{l.AWSImageID, l.CloudProfileID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (aws_image_id, cloud_profile_id) DO UPDATE").
@@ -200,6 +224,8 @@ func LinkAWSImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gardener cloud profile aws image with cloud profile", "count", count)
 
@@ -207,7 +233,7 @@ func LinkAWSImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkGCPImageWithCloudProfile creates the relationship between the CloudProfileGCPImage and CloudProfile
-func LinkGCPImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
+func LinkGCPImageWithCloudProfile(ctx context.Context, db bun.IDB) error {
 	var gcpImages []models.CloudProfileGCPImage
 	err := db.NewSelect().
 		Model(&gcpImages).
@@ -232,6 +258,10 @@ func LinkGCPImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.GCPImageToCloudProfile) []uuid.UUID {
+		return []uuid.UUID{l.GCPImageID, l.CloudProfileID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (gcp_image_id, cloud_profile_id) DO UPDATE").
@@ -248,6 +278,8 @@ func LinkGCPImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gardener cloud profile gcp image with cloud profile", "count", count)
 
@@ -255,7 +287,7 @@ func LinkGCPImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkAzureImageWithCloudProfile creates the relationship between the CloudProfileAzureImage and CloudProfile
-func LinkAzureImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
+func LinkAzureImageWithCloudProfile(ctx context.Context, db bun.IDB) error {
 	var azureImages []models.CloudProfileAzureImage
 	err := db.NewSelect().
 		Model(&azureImages).
@@ -280,6 +312,10 @@ func LinkAzureImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.AzureImageToCloudProfile) []uuid.UUID {
+		return []uuid.UUID{l.AzureImageID, l.CloudProfileID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (azure_image_id, cloud_profile_id) DO UPDATE").
@@ -296,6 +332,8 @@ func LinkAzureImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gardener cloud profile azure image with cloud profile", "count", count)
 
@@ -303,7 +341,7 @@ func LinkAzureImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkOpenStackImageWithCloudProfile creates the relationship between the CloudProfileOpenStackImage and CloudProfile
-func LinkOpenStackImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
+func LinkOpenStackImageWithCloudProfile(ctx context.Context, db bun.IDB) error {
 	var openstackImages []models.CloudProfileOpenStackImage
 	err := db.NewSelect().
 		Model(&openstackImages).
@@ -328,6 +366,10 @@ func LinkOpenStackImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.OpenStackImageToCloudProfile) []uuid.UUID {
+		return []uuid.UUID{l.OpenStackImageID, l.CloudProfileID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (openstack_image_id, cloud_profile_id) DO UPDATE").
@@ -344,6 +386,8 @@ func LinkOpenStackImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gardener cloud profile openstack image with cloud profile", "count", count)
 
@@ -352,7 +396,7 @@ func LinkOpenStackImageWithCloudProfile(ctx context.Context, db *bun.DB) error {
 
 // LinkProjectWithMember creates the relationship between the [models.Project]
 // and [models.ProjectMember] models.
-func LinkProjectWithMember(ctx context.Context, db *bun.DB) error {
+func LinkProjectWithMember(ctx context.Context, db bun.IDB) error {
 	var items []models.ProjectMember
 	err := db.NewSelect().
 		Model(&items).
@@ -377,6 +421,10 @@ func LinkProjectWithMember(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.ProjectToMember) []uuid.UUID {
+		return []uuid.UUID{l.ProjectID, l.MemberID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (project_id, member_id) DO UPDATE").
@@ -393,6 +441,8 @@ func LinkProjectWithMember(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gardener project with member", "count", count)
 
@@ -13,11 +13,11 @@ import (
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/pager"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gardenerclient "github.com/gardener/inventory/pkg/clients/gardener"
 	"github.com/gardener/inventory/pkg/gardener/constants"
@@ -25,6 +25,7 @@ import (
 	gutils "github.com/gardener/inventory/pkg/gardener/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -78,6 +79,7 @@ func enqueueCollectMachines(ctx context.Context) error {
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(seeds))
 
 	// Create a task for each known seed cluster
 	for _, s := range seeds {
@@ -95,29 +97,12 @@ func enqueueCollectMachines(ctx context.Context) error {
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectMachines, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"seed", s.Name,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"seed", s.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectMachines, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectMachines collects the Gardener Machines from the Seed Cluster
@@ -190,16 +175,15 @@ func collectMachines(ctx context.Context, payload CollectMachinesPayload) error
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&machines).
-		On("CONFLICT (name, namespace) DO UPDATE").
-		Set("status = EXCLUDED.status").
-		Set("node = EXCLUDED.node").
-		Set("seed_name = EXCLUDED.seed_name").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, machines, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, namespace) DO UPDATE").
+			Set("status = EXCLUDED.status").
+			Set("node = EXCLUDED.node").
+			Set("seed_name = EXCLUDED.seed_name").
+			Set("creation_timestamp = EXCLUDED.creation_timestamp").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -211,11 +195,6 @@ func collectMachines(ctx context.Context, payload CollectMachinesPayload) error
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated gardener machines",
 		"seed", payload.Seed,
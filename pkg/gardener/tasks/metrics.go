@@ -14,7 +14,7 @@ var (
 	// projectsDesc is the descriptor for a metric, which tracks the number
 	// of collected Gardener Projects.
 	projectsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "g_projects"),
+		"g_projects",
 		"A gauge which tracks the number of collected Gardener projects",
 		nil,
 		nil,
@@ -23,7 +23,7 @@ var (
 	// projectMembersDesc is the descriptor for a metric, which tracks the
 	// number of collected Gardener Project members.
 	projectMembersDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "g_project_members"),
+		"g_project_members",
 		"A gauge which tracks the number of collected Gardener project members",
 		[]string{"project_name"},
 		nil,
@@ -32,7 +32,7 @@ var (
 	// shootsDesc is the descriptor for a metric, which tracks the number of
 	// collected Gardener Shoots.
 	shootsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "g_shoots"),
+		"g_shoots",
 		"A gauge which tracks the number of collected Gardener shoots",
 		[]string{"project_name"},
 		nil,
@@ -41,7 +41,7 @@ var (
 	// seedsDesc is the descriptor for a metric, which tracks the number
 	// of collected Gardener Seeds.
 	seedsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "g_seeds"),
+		"g_seeds",
 		"A gauge which tracks the number of collected Gardener seeds",
 		nil,
 		nil,
@@ -50,7 +50,7 @@ var (
 	// machinesDesc is the descriptor for a metric, which tracks the number
 	// of collected Gardener Machines from seeds.
 	machinesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "g_machines"),
+		"g_machines",
 		"A gauge which tracks the number of collected Gardener machines",
 		[]string{"seed"},
 		nil,
@@ -59,7 +59,7 @@ var (
 	// backupBucketsDesc is the descriptor for a metric, which tracks the
 	// number of collected Gardener Backup Buckets.
 	backupBucketsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "g_backup_buckets"),
+		"g_backup_buckets",
 		"A gauge which tracks the number of collected Gardener backup buckets",
 		nil,
 		nil,
@@ -68,7 +68,7 @@ var (
 	// cloudProfilesDesc is the descriptor for a metric, which tracks the
 	// number of collected Gardener Cloud Profiles.
 	cloudProfilesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "g_cloud_profiles"),
+		"g_cloud_profiles",
 		"A gauge which tracks the number of collected Gardener Cloud Profiles",
 		nil,
 		nil,
@@ -77,7 +77,7 @@ var (
 	// seedVolumesDesc is the descriptor for a metric, which tracks the
 	// number of collected Persitent Volumes from seed clusters.
 	seedVolumesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "g_seed_volumes"),
+		"g_seed_volumes",
 		"A gauge which tracks the number of collected persistent volumes from seeds",
 		[]string{"seed"},
 		nil,
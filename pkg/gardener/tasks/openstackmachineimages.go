@@ -11,6 +11,7 @@ import (
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
 	openstackinstall "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/install"
 	"github.com/hibiken/asynq"
+	"github.com/uptrace/bun"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/gardener/inventory/pkg/gardener/models"
 	gutils "github.com/gardener/inventory/pkg/gardener/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -81,13 +83,12 @@ func collectOpenStackMachineImages(ctx context.Context, payload CollectCPMachine
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, version, region_name, image_id, cloud_profile_name) DO UPDATE").
-		Set("architecture = EXCLUDED.architecture").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, version, region_name, image_id, cloud_profile_name) DO UPDATE").
+			Set("architecture = EXCLUDED.architecture").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -99,11 +100,6 @@ func collectOpenStackMachineImages(ctx context.Context, payload CollectCPMachine
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated gardener openstack cloud profile images",
 		"cloud_profile", payload.CloudProfileName,
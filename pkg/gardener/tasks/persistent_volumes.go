@@ -12,12 +12,12 @@ import (
 
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/pager"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gardenerclient "github.com/gardener/inventory/pkg/clients/gardener"
 	"github.com/gardener/inventory/pkg/gardener/constants"
@@ -25,6 +25,7 @@ import (
 	gutils "github.com/gardener/inventory/pkg/gardener/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -87,6 +88,7 @@ func enqueueCollectPersistentVolumes(ctx context.Context) error {
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(seeds))
 
 	// Create a task for each known seed cluster
 	for _, s := range seeds {
@@ -104,29 +106,12 @@ func enqueueCollectPersistentVolumes(ctx context.Context) error {
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectPersistentVolumes, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"seed", s.Name,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"seed", s.Name,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectPersistentVolumes, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectPersistentVolumes collects the Gardener Volumes from the Seed Cluster
@@ -255,19 +240,18 @@ func collectPersistentVolumes(ctx context.Context, payload CollectPersistentVolu
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&pvs).
-		On("CONFLICT (name, seed_name) DO UPDATE").
-		Set("provider = EXCLUDED.provider").
-		Set("disk_ref = EXCLUDED.disk_ref").
-		Set("status = EXCLUDED.status").
-		Set("capacity = EXCLUDED.capacity").
-		Set("storage_class = EXCLUDED.storage_class").
-		Set("volume_mode = EXCLUDED.volume_mode").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, pvs, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, seed_name) DO UPDATE").
+			Set("provider = EXCLUDED.provider").
+			Set("disk_ref = EXCLUDED.disk_ref").
+			Set("status = EXCLUDED.status").
+			Set("capacity = EXCLUDED.capacity").
+			Set("storage_class = EXCLUDED.storage_class").
+			Set("volume_mode = EXCLUDED.volume_mode").
+			Set("creation_timestamp = EXCLUDED.creation_timestamp").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -279,11 +263,6 @@ func collectPersistentVolumes(ctx context.Context, payload CollectPersistentVolu
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated gardener persistent volumes",
 		"seed", payload.Seed,
@@ -11,6 +11,7 @@ import (
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -23,6 +24,7 @@ import (
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -185,27 +187,21 @@ func persistProjects(ctx context.Context, items []models.Project) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name) DO UPDATE").
-		Set("namespace = EXCLUDED.namespace").
-		Set("status = EXCLUDED.status").
-		Set("purpose = EXCLUDED.purpose").
-		Set("owner = EXCLUDED.owner").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name) DO UPDATE").
+			Set("namespace = EXCLUDED.namespace").
+			Set("status = EXCLUDED.status").
+			Set("purpose = EXCLUDED.purpose").
+			Set("owner = EXCLUDED.owner").
+			Set("creation_timestamp = EXCLUDED.creation_timestamp").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("populated gardener projects", "count", count)
 
@@ -237,20 +233,14 @@ func persistProjectMembers(ctx context.Context, items []models.ProjectMember) er
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, project_name) DO UPDATE").
-		Set("kind = EXCLUDED.kind").
-		Set("role = EXCLUDED.role").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
-		return err
-	}
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, project_name) DO UPDATE").
+			Set("kind = EXCLUDED.kind").
+			Set("role = EXCLUDED.role").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
-	count, err := out.RowsAffected()
 	if err != nil {
 		return err
 	}
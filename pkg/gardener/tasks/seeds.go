@@ -11,6 +11,7 @@ import (
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/pager"
@@ -21,6 +22,7 @@ import (
 	"github.com/gardener/inventory/pkg/gardener/models"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -87,14 +89,13 @@ func HandleCollectSeedsTask(ctx context.Context, _ *asynq.Task) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&seeds).
-		On("CONFLICT (name) DO UPDATE").
-		Set("kubernetes_version = EXCLUDED.kubernetes_version").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, seeds, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name) DO UPDATE").
+			Set("kubernetes_version = EXCLUDED.kubernetes_version").
+			Set("creation_timestamp = EXCLUDED.creation_timestamp").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -105,11 +106,6 @@ func HandleCollectSeedsTask(ctx context.Context, _ *asynq.Task) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info("populated gardener seeds", "count", count)
 
 	return nil
@@ -13,11 +13,11 @@ import (
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/pager"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gardenerclient "github.com/gardener/inventory/pkg/clients/gardener"
 	"github.com/gardener/inventory/pkg/gardener/constants"
@@ -25,6 +25,7 @@ import (
 	gutils "github.com/gardener/inventory/pkg/gardener/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
@@ -110,6 +111,7 @@ func enqueueCollectShoots(ctx context.Context) error {
 
 	logger := asynqutils.GetLogger(ctx)
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, len(projects))
 
 	// Create a task for each known project
 	for _, p := range projects {
@@ -129,31 +131,12 @@ func enqueueCollectShoots(ctx context.Context) error {
 			continue
 		}
 
-		task := asynq.NewTask(TaskCollectShoots, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", p.Name,
-				"namespace", p.Namespace,
-				"reason", err,
-			)
-
-			continue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", p.Name,
-			"namespace", p.Namespace,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectShoots, data)
+		})
 	}
 
-	return nil
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectShoots collects Gardener shoot clusters from the project specified in
@@ -257,26 +240,25 @@ func collectShoots(ctx context.Context, payload CollectShootsPayload) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&shoots).
-		On("CONFLICT (technical_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("namespace = EXCLUDED.namespace").
-		Set("project_name = EXCLUDED.project_name").
-		Set("cloud_profile = EXCLUDED.cloud_profile").
-		Set("purpose = EXCLUDED.purpose").
-		Set("seed_name = EXCLUDED.seed_name").
-		Set("status = EXCLUDED.status").
-		Set("is_hibernated = EXCLUDED.is_hibernated").
-		Set("created_by = EXCLUDED.created_by").
-		Set("region = EXCLUDED.region").
-		Set("k8s_version = EXCLUDED.k8s_version").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("worker_groups = EXCLUDED.worker_groups").
-		Set("worker_prefixes = EXCLUDED.worker_prefixes").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, shoots, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (technical_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("namespace = EXCLUDED.namespace").
+			Set("project_name = EXCLUDED.project_name").
+			Set("cloud_profile = EXCLUDED.cloud_profile").
+			Set("purpose = EXCLUDED.purpose").
+			Set("seed_name = EXCLUDED.seed_name").
+			Set("status = EXCLUDED.status").
+			Set("is_hibernated = EXCLUDED.is_hibernated").
+			Set("created_by = EXCLUDED.created_by").
+			Set("region = EXCLUDED.region").
+			Set("k8s_version = EXCLUDED.k8s_version").
+			Set("creation_timestamp = EXCLUDED.creation_timestamp").
+			Set("worker_groups = EXCLUDED.worker_groups").
+			Set("worker_prefixes = EXCLUDED.worker_prefixes").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -287,11 +269,6 @@ func collectShoots(ctx context.Context, payload CollectShootsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated gardener shoots",
 		"count", count,
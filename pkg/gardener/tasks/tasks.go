@@ -41,7 +41,7 @@ func HandleCollectAllTask(ctx context.Context, _ *asynq.Task) error {
 		NewCollectPersistentVolumesTask,
 	}
 
-	return asynqutils.Enqueue(ctx, taskFns, asynq.Queue(queue))
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // HandleLinkAllTask is the handler, which establishes relationships between the
@@ -76,4 +76,8 @@ func init() {
 	registry.TaskRegistry.MustRegister(TaskCollectPersistentVolumes, asynq.HandlerFunc(HandleCollectPersistentVolumesTask))
 	registry.TaskRegistry.MustRegister(TaskCollectAll, asynq.HandlerFunc(HandleCollectAllTask))
 	registry.TaskRegistry.MustRegister(TaskLinkAll, asynq.HandlerFunc(HandleLinkAllTask))
+
+	// Collection ordering
+	registry.TaskGraph.MustAdd(TaskCollectAll)
+	registry.TaskGraph.MustAdd(TaskLinkAll, TaskCollectAll)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
+	"github.com/gardener/inventory/pkg/core/globalid"
 	coremodels "github.com/gardener/inventory/pkg/core/models"
 	"github.com/gardener/inventory/pkg/core/registry"
 )
@@ -31,6 +32,11 @@ const (
 	GKEClusterModelName                 = "gcp:model:gke_cluster"
 	TargetPoolModelName                 = "gcp:model:target_pool"
 	TargetPoolInstanceModelName         = "gcp:model:target_pool_instance"
+	IAMBindingModelName                 = "gcp:model:iam_binding"
+	CloudSQLInstanceModelName           = "gcp:model:cloud_sql_instance"
+	FirewallRuleModelName               = "gcp:model:firewall_rule"
+	SnapshotModelName                   = "gcp:model:snapshot"
+	NodePoolModelName                   = "gcp:model:gke_node_pool"
 	InstanceToProjectModelName          = "gcp:model:link_instance_to_project"
 	VPCToProjectModelName               = "gcp:model:link_vpc_to_project"
 	AddressToProjectModelName           = "gcp:model:link_addr_to_project"
@@ -42,6 +48,11 @@ const (
 	GKEClusterToProjectModelName        = "gcp:model:link_gke_cluster_to_project"
 	TargetPoolToInstanceModelName       = "gcp:model:link_target_pool_to_instance"
 	TargetPoolToProjectModelName        = "gcp:model:link_target_pool_to_project"
+	CloudSQLInstanceToProjectModelName  = "gcp:model:link_cloud_sql_instance_to_project"
+	FirewallRuleToVPCModelName          = "gcp:model:link_firewall_rule_to_vpc"
+	SnapshotToDiskModelName             = "gcp:model:link_snapshot_to_disk"
+	NodePoolToClusterModelName          = "gcp:model:link_gke_node_pool_to_cluster"
+	BucketToProjectModelName            = "gcp:model:link_bucket_to_project"
 )
 
 // models specifies the mapping between name and model type, which will be
@@ -60,6 +71,11 @@ var models = map[string]any{
 	GKEClusterModelName:         &GKECluster{},
 	TargetPoolModelName:         &TargetPool{},
 	TargetPoolInstanceModelName: &TargetPoolInstance{},
+	IAMBindingModelName:         &IAMBinding{},
+	CloudSQLInstanceModelName:   &CloudSQLInstance{},
+	FirewallRuleModelName:       &FirewallRule{},
+	SnapshotModelName:           &Snapshot{},
+	NodePoolModelName:           &NodePool{},
 
 	// Link models
 	InstanceToProjectModelName:          &InstanceToProject{},
@@ -73,6 +89,11 @@ var models = map[string]any{
 	GKEClusterToProjectModelName:        &GKEClusterToProject{},
 	TargetPoolToInstanceModelName:       &TargetPoolToInstance{},
 	TargetPoolToProjectModelName:        &TargetPoolToProject{},
+	CloudSQLInstanceToProjectModelName:  &CloudSQLInstanceToProject{},
+	FirewallRuleToVPCModelName:          &FirewallRuleToVPC{},
+	SnapshotToDiskModelName:             &SnapshotToDisk{},
+	NodePoolToClusterModelName:          &NodePoolToCluster{},
+	BucketToProjectModelName:            &BucketToProject{},
 }
 
 // Project represents a GCP Project.
@@ -81,8 +102,10 @@ type Project struct {
 	coremodels.Model
 
 	// Name is the globally unique id of the project represented as
-	// "projects/<uint64>" value
-	Name string `bun:"name,notnull,unique"`
+	// "projects/<uint64>" value. The name is unique as well, but the
+	// project id is the conflict key of the table, so the name is not
+	// tagged as unique.
+	Name string `bun:"name,notnull"`
 	// ProjectID is the user-defined globally unique project id.
 	ProjectID string `bun:"project_id,notnull,unique"`
 
@@ -123,6 +146,7 @@ type Instance struct {
 	StatusMessage        string   `bun:"status_message,notnull"`
 	GKEClusterName       string   `bun:"gke_cluster_name,nullzero"`
 	GKEPoolName          string   `bun:"gke_pool_name,nullzero"`
+	GlobalID             string   `bun:"global_id,nullzero"`
 	Project              *Project `bun:"rel:has-one,join:project_id=project_id"`
 }
 
@@ -272,13 +296,25 @@ type Bucket struct {
 	bun.BaseModel `bun:"table:gcp_bucket"`
 	coremodels.Model
 
-	Name                string   `bun:"name,notnull,unique:gcp_bucket_key"`
-	ProjectID           string   `bun:"project_id,notnull,unique:gcp_bucket_key"`
-	LocationType        string   `bun:"location_type,notnull"`
-	Location            string   `bun:"location,notnull"`
-	DefaultStorageClass string   `bun:"default_storage_class,notnull"`
-	CreationTimestamp   string   `bun:"creation_timestamp,nullzero"`
-	Project             *Project `bun:"rel:has-one,join:project_id=project_id"`
+	Name                     string   `bun:"name,notnull,unique:gcp_bucket_key"`
+	ProjectID                string   `bun:"project_id,notnull,unique:gcp_bucket_key"`
+	LocationType             string   `bun:"location_type,notnull"`
+	Location                 string   `bun:"location,notnull"`
+	DefaultStorageClass      string   `bun:"default_storage_class,notnull"`
+	CreationTimestamp        string   `bun:"creation_timestamp,nullzero"`
+	PublicAccessPrevention   string   `bun:"public_access_prevention,notnull"`
+	UniformBucketLevelAccess bool     `bun:"uniform_bucket_level_access,notnull"`
+	Project                  *Project `bun:"rel:has-one,join:project_id=project_id"`
+}
+
+// BucketToProject represents a link table connecting the [Bucket] with
+// [Project] models.
+type BucketToProject struct {
+	bun.BaseModel `bun:"table:l_gcp_bucket_to_project"`
+	coremodels.Model
+
+	BucketID  uuid.UUID `bun:"bucket_id,notnull,type:uuid,unique:l_gcp_bucket_to_project_key"`
+	ProjectID uuid.UUID `bun:"project_id,notnull,type:uuid,unique:l_gcp_bucket_to_project_key"`
 }
 
 // ForwardingRule represents a GCP Forwarding Rule resource. The Forwarding
@@ -373,6 +409,38 @@ type InstanceToDisk struct {
 	DiskID     uuid.UUID `bun:"disk_id,notnull,type:uuid,unique:l_gcp_instance_to_disk_key"`
 }
 
+// Snapshot represents a GCP Disk Snapshot.
+type Snapshot struct {
+	bun.BaseModel `bun:"table:gcp_snapshot"`
+	coremodels.Model
+
+	Name              string   `bun:"name,notnull,unique:gcp_snapshot_key"`
+	ProjectID         string   `bun:"project_id,notnull,unique:gcp_snapshot_key"`
+	SnapshotID        uint64   `bun:"snapshot_id,notnull"`
+	SourceDisk        string   `bun:"source_disk,notnull"`
+	SourceDiskProject string   `bun:"source_disk_project,notnull"`
+	SourceDiskZone    string   `bun:"source_disk_zone,notnull"`
+	SourceDiskRegion  string   `bun:"source_disk_region,notnull"`
+	DiskSizeGB        int64    `bun:"disk_size_gb,notnull"`
+	StorageBytes      int64    `bun:"storage_bytes,notnull"`
+	Status            string   `bun:"status,notnull"`
+	SnapshotType      string   `bun:"snapshot_type,notnull"`
+	AutoCreated       bool     `bun:"auto_created,notnull"`
+	CreationTimestamp string   `bun:"creation_timestamp,nullzero"`
+	Project           *Project `bun:"rel:has-one,join:project_id=project_id"`
+	Disk              *Disk    `bun:"rel:has-one,join:source_disk_project=project_id,join:source_disk=name,join:source_disk_zone=zone"`
+}
+
+// SnapshotToDisk represents a link table connecting the [Snapshot] with the
+// [Disk] it was created from.
+type SnapshotToDisk struct {
+	bun.BaseModel `bun:"table:l_gcp_snapshot_to_disk"`
+	coremodels.Model
+
+	SnapshotID uuid.UUID `bun:"snapshot_id,notnull,type:uuid,unique:l_gcp_snapshot_to_disk_key"`
+	DiskID     uuid.UUID `bun:"disk_id,notnull,type:uuid,unique:l_gcp_snapshot_to_disk_key"`
+}
+
 // GKECluster represents a GKE Cluster.
 type GKECluster struct {
 	bun.BaseModel `bun:"table:gcp_gke_cluster"`
@@ -390,6 +458,8 @@ type GKECluster struct {
 	Endpoint              string   `bun:"endpoint,notnull"`
 	InitialVersion        string   `bun:"initial_version,notnull"`
 	CurrentMasterVersion  string   `bun:"current_master_version,notnull"`
+	CurrentNodeCount      int      `bun:"current_node_count,notnull"`
+	Status                string   `bun:"status,notnull"`
 	CAData                string   `bun:"ca_data,notnull"`
 	Project               *Project `bun:"rel:has-one,join:project_id=project_id"`
 	VPC                   *VPC     `bun:"rel:has-one,join:project_id=project_id,join:network=name"`
@@ -406,6 +476,113 @@ type GKEClusterToProject struct {
 	ProjectID uuid.UUID `bun:"project_id,notnull,type:uuid,unique:l_gcp_gke_cluster_to_project_key"`
 }
 
+// NodePool represents a node pool of a [GKECluster].
+type NodePool struct {
+	bun.BaseModel `bun:"table:gcp_gke_node_pool"`
+	coremodels.Model
+
+	Name               string      `bun:"name,notnull,unique:gcp_gke_node_pool_key"`
+	ClusterID          string      `bun:"cluster_id,notnull,unique:gcp_gke_node_pool_key"`
+	ProjectID          string      `bun:"project_id,notnull,unique:gcp_gke_node_pool_key"`
+	ClusterName        string      `bun:"cluster_name,notnull"`
+	Location           string      `bun:"location,notnull"`
+	Version            string      `bun:"version,notnull"`
+	Status             string      `bun:"status,notnull"`
+	MachineType        string      `bun:"machine_type,notnull"`
+	DiskSizeGB         int         `bun:"disk_size_gb,notnull"`
+	InitialNodeCount   int         `bun:"initial_node_count,notnull"`
+	AutoscalingEnabled bool        `bun:"autoscaling_enabled,notnull"`
+	MinNodeCount       int         `bun:"min_node_count,notnull"`
+	MaxNodeCount       int         `bun:"max_node_count,notnull"`
+	Locations          []string    `bun:"locations,array,notnull"`
+	Cluster            *GKECluster `bun:"rel:has-one,join:project_id=project_id,join:cluster_id=cluster_id"`
+}
+
+// NodePoolToCluster represents a link table connecting the [NodePool] with
+// [GKECluster] models.
+type NodePoolToCluster struct {
+	bun.BaseModel `bun:"table:l_gcp_gke_node_pool_to_cluster"`
+	coremodels.Model
+
+	NodePoolID uuid.UUID `bun:"node_pool_id,notnull,type:uuid,unique:l_gcp_gke_node_pool_to_cluster_key"`
+	ClusterID  uuid.UUID `bun:"cluster_id,notnull,type:uuid,unique:l_gcp_gke_node_pool_to_cluster_key"`
+}
+
+// CloudSQLInstance represents a Cloud SQL instance.
+type CloudSQLInstance struct {
+	bun.BaseModel `bun:"table:gcp_cloud_sql_instance"`
+	coremodels.Model
+
+	Name              string   `bun:"name,notnull,unique:gcp_cloud_sql_instance_key"`
+	ProjectID         string   `bun:"project_id,notnull,unique:gcp_cloud_sql_instance_key"`
+	DatabaseVersion   string   `bun:"database_version,notnull"`
+	Tier              string   `bun:"tier,notnull"`
+	Region            string   `bun:"region,notnull"`
+	Zone              string   `bun:"zone,notnull"`
+	AvailabilityType  string   `bun:"availability_type,notnull"`
+	State             string   `bun:"state,notnull"`
+	InstanceType      string   `bun:"instance_type,notnull"`
+	ConnectionName    string   `bun:"connection_name,notnull"`
+	CreationTimestamp string   `bun:"creation_timestamp,nullzero"`
+	Project           *Project `bun:"rel:has-one,join:project_id=project_id"`
+}
+
+// CloudSQLInstanceToProject represents a link table connecting the
+// [CloudSQLInstance] with [Project] models.
+type CloudSQLInstanceToProject struct {
+	bun.BaseModel `bun:"table:l_gcp_cloud_sql_instance_to_project"`
+	coremodels.Model
+
+	InstanceID uuid.UUID `bun:"instance_id,notnull,type:uuid,unique:l_gcp_cloud_sql_instance_to_project_key"`
+	ProjectID  uuid.UUID `bun:"project_id,notnull,type:uuid,unique:l_gcp_cloud_sql_instance_to_project_key"`
+}
+
+// FirewallRuleSpec represents a protocol and the ports, which are allowed or
+// denied by a [FirewallRule].
+type FirewallRuleSpec struct {
+	// IPProtocol specifies the name or number of the IP protocol, e.g.
+	// `tcp', `udp' or `all'.
+	IPProtocol string `json:"ip_protocol"`
+
+	// Ports specifies the ports or port ranges. No ports means all ports
+	// of the protocol.
+	Ports []string `json:"ports,omitempty"`
+}
+
+// FirewallRule represents a GCP VPC firewall rule.
+type FirewallRule struct {
+	bun.BaseModel `bun:"table:gcp_firewall_rule"`
+	coremodels.Model
+
+	Name              string             `bun:"name,notnull,unique:gcp_firewall_rule_key"`
+	ProjectID         string             `bun:"project_id,notnull,unique:gcp_firewall_rule_key"`
+	RuleID            uint64             `bun:"rule_id,notnull"`
+	VPCName           string             `bun:"vpc_name,notnull"`
+	Direction         string             `bun:"direction,notnull"`
+	Priority          int32              `bun:"priority,notnull"`
+	Disabled          bool               `bun:"disabled,notnull"`
+	Allowed           []FirewallRuleSpec `bun:"allowed,type:jsonb,notnull"`
+	Denied            []FirewallRuleSpec `bun:"denied,type:jsonb,notnull"`
+	SourceRanges      []string           `bun:"source_ranges,array,notnull"`
+	DestinationRanges []string           `bun:"destination_ranges,array,notnull"`
+	SourceTags        []string           `bun:"source_tags,array,notnull"`
+	TargetTags        []string           `bun:"target_tags,array,notnull"`
+	Description       string             `bun:"description,notnull"`
+	CreationTimestamp string             `bun:"creation_timestamp,nullzero"`
+	Project           *Project           `bun:"rel:has-one,join:project_id=project_id"`
+	VPC               *VPC               `bun:"rel:has-one,join:vpc_name=name,join:project_id=project_id"`
+}
+
+// FirewallRuleToVPC represents a link table connecting the [FirewallRule]
+// with [VPC] models.
+type FirewallRuleToVPC struct {
+	bun.BaseModel `bun:"table:l_gcp_firewall_rule_to_vpc"`
+	coremodels.Model
+
+	FirewallRuleID uuid.UUID `bun:"firewall_rule_id,notnull,type:uuid,unique:l_gcp_firewall_rule_to_vpc_key"`
+	VPCID          uuid.UUID `bun:"vpc_id,notnull,type:uuid,unique:l_gcp_firewall_rule_to_vpc_key"`
+}
+
 // TargetPool represents a group of backend instances which receive incoming
 // traffic from GCP load balancers.
 type TargetPool struct {
@@ -458,8 +635,30 @@ type TargetPoolToProject struct {
 }
 
 // init registers the models with the [registry.ModelRegistry]
+// IAMBinding represents a binding of a member to a role in the IAM policy of a
+// GCP Project.
+type IAMBinding struct {
+	bun.BaseModel `bun:"table:gcp_iam_binding"`
+	coremodels.Model
+
+	ProjectID string `bun:"project_id,notnull,unique:gcp_iam_binding_key"`
+	Role      string `bun:"role,notnull,unique:gcp_iam_binding_key"`
+	Member    string `bun:"member,notnull,unique:gcp_iam_binding_key"`
+
+	// IsPublic specifies whether the member grants access to everyone,
+	// i.e. `allUsers' or `allAuthenticatedUsers'.
+	IsPublic bool     `bun:"is_public,notnull"`
+	Project  *Project `bun:"rel:has-one,join:project_id=project_id"`
+}
+
 func init() {
 	for k, v := range models {
 		registry.ModelRegistry.MustRegister(k, v)
 	}
+
+	// Register the global ids of the models
+	globalid.Registry.MustRegister(InstanceModelName, globalid.Spec{
+		Provider: "gcp",
+		Columns:  []string{"project_id", "instance_id"},
+	})
 }
@@ -14,9 +14,9 @@ import (
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	"google.golang.org/api/iterator"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/registry"
@@ -25,6 +25,7 @@ import (
 	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // TaskCollectAddresses is the name of the task for collecting global and
@@ -37,6 +38,14 @@ type CollectAddressesPayload struct {
 	// ProjectID specifies the globally unique project id from which to
 	// collect.
 	ProjectID string `json:"project_id" yaml:"project_id"`
+
+	// Regions specifies the regions from which to collect addresses. Global
+	// addresses are collected only when no regions are specified.
+	Regions []string `json:"regions" yaml:"regions"`
+
+	// Labels specifies the labels, which addresses must have in order to be
+	// collected.
+	Labels map[string]string `json:"labels" yaml:"labels"`
 }
 
 // NewCollectAddressesTask creates a new [asynq.Task] for collecting global and
@@ -64,13 +73,25 @@ func HandleCollectAddressesTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(ErrNoProjectID)
 	}
 
+	if err := payload.filter().Validate(); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
 	return collectAddresses(ctx, payload)
 }
 
+// filter returns the [gcputils.Filter] for the constraints from the payload.
+func (p CollectAddressesPayload) filter() gcputils.Filter {
+	return gcputils.Filter{Regions: p.Regions, Labels: p.Labels}
+}
+
 // enqueueCollectAddresses enqueues tasks for collecting global and regional static IP
 // addresses for all known projects.
 func enqueueCollectAddresses(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
 	if gcpclients.AddressesClientset.Length() == 0 && gcpclients.GlobalAddressesClientset.Length() == 0 {
 		logger.Warn("no GCP addresses clients found")
 
@@ -81,7 +102,12 @@ func enqueueCollectAddresses(ctx context.Context) error {
 	// registered for the regional and global addresses clients, so here we
 	// can iterate through just one of the registries.
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.AddressesClientset.Length())
 	err := gcpclients.AddressesClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.AddressesClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		payload := CollectAddressesPayload{ProjectID: projectID}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -93,31 +119,18 @@ func enqueueCollectAddresses(ctx context.Context) error {
 
 			return registry.ErrContinue
 		}
-		task := asynq.NewTask(TaskCollectAddresses, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectAddresses, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // getRegionalAddresses fetches the regional static IP addresses for the
@@ -136,6 +149,11 @@ func getRegionalAddresses(ctx context.Context, payload CollectAddressesPayload)
 		MaxResults:           &pageSize,
 	}
 
+	filter := payload.filter()
+	if expr := filter.Expression("region"); expr != "" {
+		req.Filter = &expr
+	}
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("collecting gcp regional addresses", "project_id", payload.ProjectID)
 
@@ -149,7 +167,12 @@ func getRegionalAddresses(ctx context.Context, payload CollectAddressesPayload)
 		if err != nil {
 			return nil, err
 		}
-		items = append(items, pair.Value.Addresses...)
+		for _, item := range pair.Value.Addresses {
+			region := gcputils.ResourceNameFromURL(item.GetRegion())
+			if filter.Matches(region, item.GetLabels()) {
+				items = append(items, item)
+			}
+		}
 	}
 
 	return items, nil
@@ -163,6 +186,11 @@ func getGlobalAddresses(ctx context.Context, payload CollectAddressesPayload) ([
 		return nil, asynqutils.SkipRetry(ClientNotFound(payload.ProjectID))
 	}
 
+	// Global addresses do not belong to any region
+	if len(payload.Regions) > 0 {
+		return nil, nil
+	}
+
 	partialSuccess := true
 	pageSize := uint32(constants.PageSize)
 	req := &computepb.ListGlobalAddressesRequest{
@@ -171,6 +199,11 @@ func getGlobalAddresses(ctx context.Context, payload CollectAddressesPayload) ([
 		MaxResults:           &pageSize,
 	}
 
+	filter := payload.filter()
+	if expr := filter.Expression(""); expr != "" {
+		req.Filter = &expr
+	}
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("collecting gcp global addresses", "project_id", payload.ProjectID)
 
@@ -185,7 +218,9 @@ func getGlobalAddresses(ctx context.Context, payload CollectAddressesPayload) ([
 			return nil, err
 		}
 
-		items = append(items, item)
+		if filter.Matches("", item.GetLabels()) {
+			items = append(items, item)
+		}
 	}
 
 	return items, nil
@@ -259,40 +294,39 @@ func collectAddresses(ctx context.Context, payload CollectAddressesPayload) erro
 	}
 
 	if len(items) == 0 {
-		return nil
+		return reconcileAddresses(ctx, payload, items)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (project_id, address_id) DO UPDATE").
-		Set("address = EXCLUDED.address").
-		Set("address_type = EXCLUDED.address_type").
-		Set("is_global = EXCLUDED.is_global").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("description = EXCLUDED.description").
-		Set("region = EXCLUDED.region").
-		Set("ip_version = EXCLUDED.ip_version").
-		Set("ipv6_endpoint_type = EXCLUDED.ipv6_endpoint_type").
-		Set("name = EXCLUDED.name").
-		Set("network = EXCLUDED.network").
-		Set("network_tier = EXCLUDED.network_tier").
-		Set("subnetwork = EXCLUDED.subnetwork").
-		Set("prefix_length = EXCLUDED.prefix_length").
-		Set("purpose = EXCLUDED.purpose").
-		Set("status = EXCLUDED.status").
-		Set("self_link = EXCLUDED.self_link").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, address_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"address",
+				"address_type",
+				"is_global",
+				"creation_timestamp",
+				"description",
+				"region",
+				"ip_version",
+				"ipv6_endpoint_type",
+				"name",
+				"network",
+				"network_tier",
+				"subnetwork",
+				"prefix_length",
+				"purpose",
+				"status",
+				"self_link",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info(
@@ -301,5 +335,21 @@ func collectAddresses(ctx context.Context, payload CollectAddressesPayload) erro
 		"count", count,
 	)
 
-	return nil
+	return reconcileAddresses(ctx, payload, items)
+}
+
+// reconcileAddresses marks the collected addresses of the project as seen, and
+// the ones, which have disappeared, as deleted. Filtered collections do not
+// cover the whole project, and are not reconciled.
+func reconcileAddresses(ctx context.Context, payload CollectAddressesPayload, items []models.Address) error {
+	if !payload.filter().IsEmpty() {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.AddressID)
+	}
+
+	return reconcileProject[models.Address](ctx, "addresses", payload.ProjectID, "address_id", ids)
 }
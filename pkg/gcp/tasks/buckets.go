@@ -12,15 +12,16 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	"google.golang.org/api/iterator"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/gcp/models"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -43,6 +44,9 @@ type CollectBucketsPayload struct {
 }
 
 // HandleCollectBucketsTask is the handler, which collects GCP Buckets.
+//
+// Only bucket-level metadata is collected. The objects of the buckets are
+// never listed, since listing all objects of large buckets is infeasible.
 func HandleCollectBucketsTask(ctx context.Context, t *asynq.Task) error {
 	// If we were called without a payload, then we will enqueue tasks for
 	// collecting Buckets for all configured clients.
@@ -70,8 +74,15 @@ func HandleCollectBucketsTask(ctx context.Context, t *asynq.Task) error {
 func enqueueCollectBuckets(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshProjects(ctx)
+
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.StorageClientset.Length())
 	err := gcpclients.StorageClientset.Range(func(projectID string, _ *gcpclients.Client[*storage.Client]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		p := &CollectBucketsPayload{ProjectID: projectID}
 		data, err := json.Marshal(p)
 		if err != nil {
@@ -84,31 +95,18 @@ func enqueueCollectBuckets(ctx context.Context) error {
 			return registry.ErrContinue
 		}
 
-		task := asynq.NewTask(TaskCollectBuckets, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectBuckets, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectBuckets collects the GCP Buckets using the client configuration
@@ -154,31 +152,40 @@ func collectBuckets(ctx context.Context, payload CollectBucketsPayload) error {
 		}
 
 		item := models.Bucket{
-			Name:                b.Name,
-			ProjectID:           payload.ProjectID,
-			LocationType:        b.LocationType,
-			Location:            b.Location,
-			DefaultStorageClass: b.StorageClass,
-			CreationTimestamp:   b.Created.String(),
+			Name:                     b.Name,
+			ProjectID:                payload.ProjectID,
+			LocationType:             b.LocationType,
+			Location:                 b.Location,
+			DefaultStorageClass:      b.StorageClass,
+			CreationTimestamp:        b.Created.String(),
+			PublicAccessPrevention:   b.PublicAccessPrevention.String(),
+			UniformBucketLevelAccess: b.UniformBucketLevelAccess.Enabled,
 		}
 
 		items = append(items, item)
 	}
 
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Name)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.Bucket](ctx, "buckets", payload.ProjectID, "name", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, project_id) DO UPDATE").
-		Set("location_type = EXCLUDED.location_type").
-		Set("location = EXCLUDED.location").
-		Set("default_storage_class = EXCLUDED.default_storage_class").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"location_type",
+				"location",
+				"default_storage_class",
+				"creation_timestamp",
+				"public_access_prevention",
+				"uniform_bucket_level_access",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -190,10 +197,9 @@ func collectBuckets(ctx context.Context, payload CollectBucketsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
 
 	logger.Info(
 		"populated gcp buckets",
@@ -201,5 +207,5 @@ func collectBuckets(ctx context.Context, payload CollectBucketsPayload) error {
 		"count", count,
 	)
 
-	return nil
+	return reconcileProject[models.Bucket](ctx, "buckets", payload.ProjectID, "name", ids)
 }
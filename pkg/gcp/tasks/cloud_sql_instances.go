@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+	sqladmin "google.golang.org/api/sqladmin/v1"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/gcp/models"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+// TaskCollectCloudSQLInstances is the name of the task for collecting Cloud SQL
+// instances.
+const TaskCollectCloudSQLInstances = "gcp:task:collect-cloud-sql-instances"
+
+// CollectCloudSQLInstancesPayload is the payload used for collecting Cloud SQL
+// instances.
+type CollectCloudSQLInstancesPayload struct {
+	// ProjectID specifies the globally unique project id from which to
+	// collect.
+	ProjectID string `json:"project_id" yaml:"project_id"`
+}
+
+// NewCollectCloudSQLInstancesTask creates a new [asynq.Task] for collecting
+// Cloud SQL instances, without specifying a payload.
+func NewCollectCloudSQLInstancesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectCloudSQLInstances, nil)
+}
+
+// HandleCollectCloudSQLInstancesTask is the handler, which collects Cloud SQL
+// instances.
+func HandleCollectCloudSQLInstancesTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting Cloud SQL instances from all registered projects.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectCloudSQLInstances(ctx)
+	}
+
+	var payload CollectCloudSQLInstancesPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.ProjectID == "" {
+		return asynqutils.SkipRetry(ErrNoProjectID)
+	}
+
+	return collectCloudSQLInstances(ctx, payload)
+}
+
+// enqueueCollectCloudSQLInstances enqueues tasks for collecting Cloud SQL
+// instances.
+func enqueueCollectCloudSQLInstances(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
+	if gcpclients.SQLAdminClientset.Length() == 0 {
+		logger.Warn("no GCP Cloud SQL Admin clients found")
+
+		return nil
+	}
+
+	// Enqueue tasks for all registered GCP Projects
+	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.SQLAdminClientset.Length())
+	err := gcpclients.SQLAdminClientset.Range(func(projectID string, _ *gcpclients.Client[*sqladmin.Service]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
+		payload := CollectCloudSQLInstancesPayload{
+			ProjectID: projectID,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for Cloud SQL instances",
+				"project", projectID,
+				"reason", err,
+			)
+
+			return registry.ErrContinue
+		}
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectCloudSQLInstances, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectCloudSQLInstances collects the Cloud SQL instances from the project
+// specified in the payload.
+func collectCloudSQLInstances(ctx context.Context, payload CollectCloudSQLInstancesPayload) error {
+	client, ok := gcpclients.SQLAdminClientset.Get(payload.ProjectID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.ProjectID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			cloudSQLInstancesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.ProjectID,
+		)
+		key := metrics.Key(TaskCollectCloudSQLInstances, payload.ProjectID)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("collecting Cloud SQL instances", "project", payload.ProjectID)
+
+	items := make([]models.CloudSQLInstance, 0)
+	pager := func(resp *sqladmin.InstancesListResponse) error {
+		for _, instance := range resp.Items {
+			var tier, availabilityType string
+			if instance.Settings != nil {
+				tier = instance.Settings.Tier
+				availabilityType = instance.Settings.AvailabilityType
+			}
+
+			item := models.CloudSQLInstance{
+				Name:              instance.Name,
+				ProjectID:         payload.ProjectID,
+				DatabaseVersion:   instance.DatabaseVersion,
+				Tier:              tier,
+				Region:            instance.Region,
+				Zone:              instance.GceZone,
+				AvailabilityType:  availabilityType,
+				State:             instance.State,
+				InstanceType:      instance.InstanceType,
+				ConnectionName:    instance.ConnectionName,
+				CreationTimestamp: instance.CreateTime,
+			}
+			items = append(items, item)
+		}
+
+		return nil
+	}
+
+	if err := client.Client.Instances.List(payload.ProjectID).Pages(ctx, pager); err != nil {
+		logger.Error(
+			"failed to list Cloud SQL instances",
+			"project", payload.ProjectID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Name)
+	}
+
+	if len(items) == 0 {
+		return reconcileProject[models.CloudSQLInstance](ctx, "cloud sql instances", payload.ProjectID, "name", ids)
+	}
+
+	_, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"database_version",
+				"tier",
+				"region",
+				"zone",
+				"availability_type",
+				"state",
+				"instance_type",
+				"connection_name",
+				"creation_timestamp",
+			)).
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
+
+	logger.Info(
+		"populated cloud sql instances",
+		"project", payload.ProjectID,
+		"count", count,
+	)
+
+	return reconcileProject[models.CloudSQLInstance](ctx, "cloud sql instances", payload.ProjectID, "name", ids)
+}
@@ -13,9 +13,9 @@ import (
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	"google.golang.org/api/iterator"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/registry"
@@ -24,6 +24,7 @@ import (
 	"github.com/gardener/inventory/pkg/gcp/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -43,6 +44,14 @@ type CollectDisksPayload struct {
 	// ProjectID specifies the GCP project ID, which is associated with a
 	// registered client.
 	ProjectID string `json:"project_id" yaml:"project_id"`
+
+	// Regions specifies the regions from which to collect disks. If
+	// empty, disks from all regions are collected.
+	Regions []string `json:"regions" yaml:"regions"`
+
+	// Labels specifies the labels, which disks must have in order to be
+	// collected.
+	Labels map[string]string `json:"labels" yaml:"labels"`
 }
 
 // HandleCollectDisksTask is the handler, which collects GCP disks.
@@ -65,16 +74,32 @@ func HandleCollectDisksTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(ErrNoProjectID)
 	}
 
+	if err := payload.filter().Validate(); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
 	return collectDisks(ctx, payload)
 }
 
+// filter returns the [utils.Filter] for the constraints from the payload.
+func (p CollectDisksPayload) filter() utils.Filter {
+	return utils.Filter{Regions: p.Regions, Labels: p.Labels}
+}
+
 // enqueueCollectDisks enqueues tasks for collecting GCP disks
 // for all collected GCP projects.
 func enqueueCollectDisks(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshProjects(ctx)
+
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.DisksClientset.Length())
 	err := gcpclients.DisksClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.DisksClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		p := &CollectDisksPayload{ProjectID: projectID}
 		data, err := json.Marshal(p)
 		if err != nil {
@@ -87,31 +112,18 @@ func enqueueCollectDisks(ctx context.Context) error {
 			return registry.ErrContinue
 		}
 
-		task := asynq.NewTask(TaskCollectDisks, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectDisks, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectDisks collects the GCP disks using the client configuration
@@ -144,6 +156,16 @@ func collectDisks(ctx context.Context, payload CollectDisksPayload) error {
 		MaxResults:           &pageSize,
 		ReturnPartialSuccess: &partialSuccess,
 	}
+
+	filter := payload.filter()
+
+	// Zonal and regional disks refer to their location via different
+	// fields, and the filter syntax does not support combining constraints
+	// on different fields with OR, so regions are filtered client-side.
+	if expr := filter.Expression(""); expr != "" {
+		disksRequest.Filter = &expr
+	}
+
 	iter := client.Client.AggregatedList(ctx, &disksRequest)
 
 	disks := make([]models.Disk, 0)
@@ -169,9 +191,22 @@ func collectDisks(ctx context.Context, payload CollectDisksPayload) error {
 			if i == nil {
 				continue
 			}
-			currentDiskAttachedInstances := i.GetUsers()
-
 			zone := utils.ResourceNameFromURL(i.GetZone())
+			isRegional := (zone == "")
+
+			var region string
+			if isRegional {
+				region = utils.ResourceNameFromURL(i.GetRegion())
+			} else {
+				region = utils.RegionFromZone(zone)
+			}
+
+			labels := i.GetLabels()
+			if !filter.Matches(region, labels) {
+				continue
+			}
+
+			currentDiskAttachedInstances := i.GetUsers()
 			for _, instanceURL := range currentDiskAttachedInstances {
 				attachedDisk := models.AttachedDisk{
 					InstanceName: utils.ResourceNameFromURL(instanceURL),
@@ -183,20 +218,10 @@ func collectDisks(ctx context.Context, payload CollectDisksPayload) error {
 				attachedDisks = append(attachedDisks, attachedDisk)
 			}
 
-			isRegional := (zone == "")
-
-			var region string
-			if isRegional {
-				region = utils.ResourceNameFromURL(i.GetRegion())
-			} else {
-				region = utils.RegionFromZone(zone)
-			}
-
 			// Infer the cluster name by inspecting the labels added
 			// by the GCP provider extension.
 			//
 			// https://github.com/gardener/gardener-extension-provider-gcp/pull/660
-			labels := i.GetLabels()
 			kubeClusterName := labels["k8s-cluster-name"]
 			disk := models.Disk{
 				Name:                i.GetName(),
@@ -219,25 +244,25 @@ func collectDisks(ctx context.Context, payload CollectDisksPayload) error {
 	}
 
 	if len(disks) == 0 {
-		return nil
+		return reconcileDisks(ctx, payload, disks, attachedDisks)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&disks).
-		On("CONFLICT (name, project_id, zone) DO UPDATE").
-		Set("region = EXCLUDED.region").
-		Set("type = EXCLUDED.type").
-		Set("description = EXCLUDED.description").
-		Set("is_regional = EXCLUDED.is_regional").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("last_attach_timestamp = EXCLUDED.last_attach_timestamp").
-		Set("last_detach_timestamp = EXCLUDED.last_detach_timestamp").
-		Set("status = EXCLUDED.status").
-		Set("size_gb = EXCLUDED.size_gb").
-		Set("k8s_cluster_name = EXCLUDED.k8s_cluster_name").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err := dbutils.BulkInsert(ctx, db.DB, disks, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, project_id, zone) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"region",
+				"type",
+				"description",
+				"is_regional",
+				"creation_timestamp",
+				"last_attach_timestamp",
+				"last_detach_timestamp",
+				"status",
+				"size_gb",
+				"k8s_cluster_name",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -249,10 +274,9 @@ func collectDisks(ctx context.Context, payload CollectDisksPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(disks))
 
 	logger.Info(
 		"populated gcp disks",
@@ -260,14 +284,14 @@ func collectDisks(ctx context.Context, payload CollectDisksPayload) error {
 		"count", count,
 	)
 
-	out, err = db.DB.NewInsert().
-		Model(&attachedDisks).
-		On("CONFLICT (instance_name, disk_name, project_id) DO UPDATE").
-		Set("zone = EXCLUDED.zone").
-		Set("region = EXCLUDED.region").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err = dbutils.BulkInsert(ctx, db.DB, attachedDisks, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (instance_name, disk_name, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"zone",
+				"region",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -279,10 +303,9 @@ func collectDisks(ctx context.Context, payload CollectDisksPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(attachedDisks))
 
 	logger.Info(
 		"populated gcp attached disks",
@@ -290,5 +313,31 @@ func collectDisks(ctx context.Context, payload CollectDisksPayload) error {
 		"count", count,
 	)
 
-	return nil
+	return reconcileDisks(ctx, payload, disks, attachedDisks)
+}
+
+// reconcileDisks marks the collected disks and attached disks of the project
+// as seen, and the ones, which have disappeared, as deleted. Filtered
+// collections do not cover the whole project, and are not reconciled.
+func reconcileDisks(ctx context.Context, payload CollectDisksPayload, disks []models.Disk, attachedDisks []models.AttachedDisk) error {
+	if !payload.filter().IsEmpty() {
+		return nil
+	}
+
+	// Disk names are unique within their zone only
+	diskKeys := make([][]any, 0, len(disks))
+	for _, item := range disks {
+		diskKeys = append(diskKeys, []any{item.Name, item.Zone})
+	}
+
+	if err := reconcileProjectKeys[models.Disk](ctx, "disks", payload.ProjectID, []string{"name", "zone"}, diskKeys); err != nil {
+		return err
+	}
+
+	attachedKeys := make([][]any, 0, len(attachedDisks))
+	for _, item := range attachedDisks {
+		attachedKeys = append(attachedKeys, []any{item.InstanceName, item.DiskName})
+	}
+
+	return reconcileProjectKeys[models.AttachedDisk](ctx, "attached disks", payload.ProjectID, []string{"instance_name", "disk_name"}, attachedKeys)
 }
@@ -6,18 +6,29 @@ package tasks
 
 import (
 	"errors"
-	"fmt"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 )
 
 // ErrNoProjectID is an error, which is returned when a task expects a project
 // id to be sent as part of the payload, but none was provided.
-var ErrNoProjectID = errors.New("no project id specified")
+var ErrNoProjectID error = &asynqutils.CollectionError{
+	Provider: "gcp",
+	Err:      errors.New("no project id specified"),
+}
 
 // ErrClientNotFound is an error, which is returned when an API client was not
 // found in the clientset registries.
-var ErrClientNotFound = errors.New("client not found")
+var ErrClientNotFound error = &asynqutils.CollectionError{
+	Provider: "gcp",
+	Err:      errors.New("client not found"),
+}
 
 // ClientNotFound wraps [ErrClientNotFound] with the given project id.
 func ClientNotFound(projectID string) error {
-	return fmt.Errorf("%w: project %s", ErrClientNotFound, projectID)
+	return &asynqutils.CollectionError{
+		Provider: "gcp",
+		Account:  projectID,
+		Err:      ErrClientNotFound,
+	}
 }
@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+	"google.golang.org/api/iterator"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/gcp/constants"
+	"github.com/gardener/inventory/pkg/gcp/models"
+	"github.com/gardener/inventory/pkg/gcp/utils"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// TaskCollectFirewallRules is the name of the task for collecting GCP
+	// firewall rules.
+	TaskCollectFirewallRules = "gcp:task:collect-firewall-rules"
+)
+
+// NewCollectFirewallRulesTask creates a new [asynq.Task] task for collecting
+// GCP firewall rules without specifying a payload.
+func NewCollectFirewallRulesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectFirewallRules, nil)
+}
+
+// CollectFirewallRulesPayload is the payload, which is used to collect GCP
+// firewall rules.
+type CollectFirewallRulesPayload struct {
+	// ProjectID specifies the GCP project ID, which is associated with a
+	// registered client.
+	ProjectID string `json:"project_id" yaml:"project_id"`
+}
+
+// HandleCollectFirewallRulesTask is the handler, which collects GCP firewall
+// rules.
+func HandleCollectFirewallRulesTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we will enqueue tasks for
+	// collecting firewall rules for all configured clients.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectFirewallRules(ctx)
+	}
+
+	// Collect firewall rules using the client associated with the project
+	// ID from the payload.
+	var payload CollectFirewallRulesPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.ProjectID == "" {
+		return asynqutils.SkipRetry(ErrNoProjectID)
+	}
+
+	return collectFirewallRules(ctx, payload)
+}
+
+// enqueueCollectFirewallRules enqueues tasks for collecting GCP firewall rules
+// for all collected GCP projects.
+func enqueueCollectFirewallRules(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
+	if gcpclients.FirewallsClientset.Length() == 0 {
+		logger.Warn(
+			"no gcp firewall clients configured. skipping task.",
+		)
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.FirewallsClientset.Length())
+	err := gcpclients.FirewallsClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.FirewallsClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
+		p := &CollectFirewallRulesPayload{ProjectID: projectID}
+		data, err := json.Marshal(p)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for GCP firewall rules",
+				"project", projectID,
+				"reason", err,
+			)
+
+			return registry.ErrContinue
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectFirewallRules, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectFirewallRules collects the GCP firewall rules using the client
+// configuration specified in the payload.
+func collectFirewallRules(ctx context.Context, payload CollectFirewallRulesPayload) error {
+	client, ok := gcpclients.FirewallsClientset.Get(payload.ProjectID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.ProjectID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			firewallRulesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.ProjectID,
+		)
+		key := metrics.Key(TaskCollectFirewallRules, payload.ProjectID)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("collecting GCP firewall rules", "project", payload.ProjectID)
+
+	pageSize := uint32(constants.PageSize)
+	partialSuccess := true
+	req := computepb.ListFirewallsRequest{
+		Project:              utils.ProjectFQN(payload.ProjectID),
+		MaxResults:           &pageSize,
+		ReturnPartialSuccess: &partialSuccess,
+	}
+
+	it := client.Client.List(ctx, &req)
+	items := make([]models.FirewallRule, 0)
+	for {
+		rule, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			logger.Error(
+				"failed to get GCP firewall rules",
+				"project", payload.ProjectID,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		allowed := make([]models.FirewallRuleSpec, 0, len(rule.GetAllowed()))
+		for _, spec := range rule.GetAllowed() {
+			allowed = append(allowed, models.FirewallRuleSpec{
+				IPProtocol: spec.GetIPProtocol(),
+				Ports:      spec.GetPorts(),
+			})
+		}
+
+		denied := make([]models.FirewallRuleSpec, 0, len(rule.GetDenied()))
+		for _, spec := range rule.GetDenied() {
+			denied = append(denied, models.FirewallRuleSpec{
+				IPProtocol: spec.GetIPProtocol(),
+				Ports:      spec.GetPorts(),
+			})
+		}
+
+		item := models.FirewallRule{
+			Name:              rule.GetName(),
+			ProjectID:         payload.ProjectID,
+			RuleID:            rule.GetId(),
+			VPCName:           utils.ResourceNameFromURL(rule.GetNetwork()),
+			Direction:         rule.GetDirection(),
+			Priority:          rule.GetPriority(),
+			Disabled:          rule.GetDisabled(),
+			Allowed:           allowed,
+			Denied:            denied,
+			SourceRanges:      nonNilStrings(rule.GetSourceRanges()),
+			DestinationRanges: nonNilStrings(rule.GetDestinationRanges()),
+			SourceTags:        nonNilStrings(rule.GetSourceTags()),
+			TargetTags:        nonNilStrings(rule.GetTargetTags()),
+			Description:       rule.GetDescription(),
+			CreationTimestamp: rule.GetCreationTimestamp(),
+		}
+		items = append(items, item)
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Name)
+	}
+
+	if len(items) == 0 {
+		return reconcileProject[models.FirewallRule](ctx, "firewall rules", payload.ProjectID, "name", ids)
+	}
+
+	_, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"rule_id",
+				"vpc_name",
+				"direction",
+				"priority",
+				"disabled",
+				"allowed",
+				"denied",
+				"source_ranges",
+				"destination_ranges",
+				"source_tags",
+				"target_tags",
+				"description",
+				"creation_timestamp",
+			)).
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert firewall rules into db",
+			"project", payload.ProjectID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
+
+	logger.Info(
+		"populated gcp firewall rules",
+		"project", payload.ProjectID,
+		"count", count,
+	)
+
+	return reconcileProject[models.FirewallRule](ctx, "firewall rules", payload.ProjectID, "name", ids)
+}
+
+// nonNilStrings returns the given slice, or an empty slice if it is nil, so
+// that it satisfies the NOT NULL constraint of array columns.
+func nonNilStrings(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+
+	return items
+}
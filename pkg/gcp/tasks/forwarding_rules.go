@@ -14,9 +14,9 @@ import (
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	"google.golang.org/api/iterator"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/registry"
@@ -25,6 +25,7 @@ import (
 	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // TaskCollectForwardingRules is the name of the task for collecting GCP
@@ -76,6 +77,9 @@ func HandleCollectForwardingRules(ctx context.Context, t *asynq.Task) error {
 // Rules for all known projects.
 func enqueueCollectForwardingRules(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
 	if gcpclients.ForwardingRulesClientset.Length() == 0 {
 		logger.Warn("no GCP forwarding rules clients found")
 
@@ -84,7 +88,12 @@ func enqueueCollectForwardingRules(ctx context.Context) error {
 
 	// Enqueue tasks for all registered GCP Projects
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.ForwardingRulesClientset.Length())
 	err := gcpclients.ForwardingRulesClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.ForwardingRulesClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		payload := CollectForwardingRulesPayload{
 			ProjectID: projectID,
 		}
@@ -98,31 +107,18 @@ func enqueueCollectForwardingRules(ctx context.Context) error {
 
 			return registry.ErrContinue
 		}
-		task := asynq.NewTask(TaskCollectForwardingRules, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectForwardingRules, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectForwardingRules collects the GCP Forwarding Rules from the project
@@ -209,46 +205,50 @@ func collectForwardingRules(ctx context.Context, payload CollectForwardingRulesP
 		}
 	}
 
+	ids := make([]uint64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.RuleID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.ForwardingRule](ctx, "forwarding rules", payload.ProjectID, "rule_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (project_id, rule_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("ip_address = EXCLUDED.ip_address").
-		Set("ip_protocol = EXCLUDED.ip_protocol").
-		Set("ip_version = EXCLUDED.ip_version").
-		Set("all_ports = EXCLUDED.all_ports").
-		Set("allow_global_access = EXCLUDED.allow_global_access").
-		Set("backend_service = EXCLUDED.backend_service").
-		Set("base_forwarding_rule = EXCLUDED.base_forwarding_rule").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("description = EXCLUDED.description").
-		Set("load_balancing_scheme = EXCLUDED.load_balancing_scheme").
-		Set("network = EXCLUDED.network").
-		Set("network_tier = EXCLUDED.network_tier").
-		Set("port_range = EXCLUDED.port_range").
-		Set("ports = EXCLUDED.ports").
-		Set("region = EXCLUDED.region").
-		Set("service_label = EXCLUDED.service_label").
-		Set("service_name = EXCLUDED.service_name").
-		Set("source_ip_ranges = EXCLUDED.source_ip_ranges").
-		Set("subnetwork = EXCLUDED.subnetwork").
-		Set("target = EXCLUDED.target").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, rule_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"name",
+				"ip_address",
+				"ip_protocol",
+				"ip_version",
+				"all_ports",
+				"allow_global_access",
+				"backend_service",
+				"base_forwarding_rule",
+				"creation_timestamp",
+				"description",
+				"load_balancing_scheme",
+				"network",
+				"network_tier",
+				"port_range",
+				"ports",
+				"region",
+				"service_label",
+				"service_name",
+				"source_ip_ranges",
+				"subnetwork",
+				"target",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
 
 	logger.Info(
 		"populated gcp forwarding rules",
@@ -256,5 +256,5 @@ func collectForwardingRules(ctx context.Context, payload CollectForwardingRulesP
 		"count", count,
 	)
 
-	return nil
+	return reconcileProject[models.ForwardingRule](ctx, "forwarding rules", payload.ProjectID, "rule_id", ids)
 }
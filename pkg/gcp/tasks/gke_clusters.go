@@ -13,14 +13,15 @@ import (
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/gcp/models"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // TaskCollectGKEClusters is the name of the task for collecting GKE clusters.
@@ -63,6 +64,9 @@ func HandleCollectGKEClusters(ctx context.Context, t *asynq.Task) error {
 // enqueueCollectGKEClusters enqueues tasks for collecting GKE Clusters.
 func enqueueCollectGKEClusters(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
 	if gcpclients.ClusterManagerClientset.Length() == 0 {
 		logger.Warn("no GCP Cluster Manager clients found")
 
@@ -71,7 +75,12 @@ func enqueueCollectGKEClusters(ctx context.Context) error {
 
 	// Enqueue tasks for all registered GCP Projects
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.ClusterManagerClientset.Length())
 	err := gcpclients.ClusterManagerClientset.Range(func(projectID string, _ *gcpclients.Client[*container.ClusterManagerClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		payload := CollectGKEClustersPayload{
 			ProjectID: projectID,
 		}
@@ -85,31 +94,18 @@ func enqueueCollectGKEClusters(ctx context.Context) error {
 
 			return registry.ErrContinue
 		}
-		task := asynq.NewTask(TaskCollectGKEClusters, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectGKEClusters, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectGKEClusters collects the GKE Clusters from the project specified in
@@ -120,7 +116,7 @@ func collectGKEClusters(ctx context.Context, payload CollectGKEClustersPayload)
 		return asynqutils.SkipRetry(ClientNotFound(payload.ProjectID))
 	}
 
-	var count int64
+	var count, poolsCount int64
 	defer func() {
 		metric := prometheus.MustNewConstMetric(
 			gkeClustersDesc,
@@ -130,6 +126,15 @@ func collectGKEClusters(ctx context.Context, payload CollectGKEClustersPayload)
 		)
 		key := metrics.Key(TaskCollectGKEClusters, payload.ProjectID)
 		metrics.DefaultCollector.AddMetric(key, metric)
+
+		poolsMetric := prometheus.MustNewConstMetric(
+			gkeNodePoolsDesc,
+			prometheus.GaugeValue,
+			float64(poolsCount),
+			payload.ProjectID,
+		)
+		poolsKey := metrics.Key(TaskCollectGKEClusters, payload.ProjectID, "node_pools")
+		metrics.DefaultCollector.AddMetric(poolsKey, poolsMetric)
 	}()
 
 	logger := asynqutils.GetLogger(ctx)
@@ -145,6 +150,7 @@ func collectGKEClusters(ctx context.Context, payload CollectGKEClustersPayload)
 	}
 
 	items := make([]models.GKECluster, 0)
+	pools := make([]models.NodePool, 0)
 	for _, cluster := range resp.Clusters {
 		var caData string
 		if cluster.MasterAuth != nil {
@@ -163,47 +169,129 @@ func collectGKEClusters(ctx context.Context, payload CollectGKEClustersPayload)
 			Endpoint:              cluster.GetEndpoint(),
 			InitialVersion:        cluster.GetInitialClusterVersion(),
 			CurrentMasterVersion:  cluster.GetCurrentMasterVersion(),
+			CurrentNodeCount:      int(cluster.GetCurrentNodeCount()), // nolint: staticcheck
+			Status:                cluster.GetStatus().String(),
 			CAData:                caData,
 		}
 		items = append(items, item)
+
+		for _, pool := range cluster.GetNodePools() {
+			nodePool := models.NodePool{
+				Name:               pool.GetName(),
+				ClusterID:          cluster.GetId(),
+				ProjectID:          payload.ProjectID,
+				ClusterName:        cluster.GetName(),
+				Location:           cluster.GetLocation(),
+				Version:            pool.GetVersion(),
+				Status:             pool.GetStatus().String(),
+				MachineType:        pool.GetConfig().GetMachineType(),
+				DiskSizeGB:         int(pool.GetConfig().GetDiskSizeGb()),
+				InitialNodeCount:   int(pool.GetInitialNodeCount()),
+				AutoscalingEnabled: pool.GetAutoscaling().GetEnabled(),
+				MinNodeCount:       int(pool.GetAutoscaling().GetMinNodeCount()),
+				MaxNodeCount:       int(pool.GetAutoscaling().GetMaxNodeCount()),
+				Locations:          pool.GetLocations(),
+			}
+			if nodePool.Locations == nil {
+				nodePool.Locations = make([]string, 0)
+			}
+			pools = append(pools, nodePool)
+		}
 	}
 
 	if len(items) == 0 {
-		return nil
+		return reconcileGKEClusters(ctx, payload, items, pools)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (project_id, cluster_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("location = EXCLUDED.location").
-		Set("network = EXCLUDED.network").
-		Set("subnetwork = EXCLUDED.subnetwork").
-		Set("cluster_ipv4_cidr = EXCLUDED.cluster_ipv4_cidr").
-		Set("services_ipv4_cidr = EXCLUDED.services_ipv4_cidr").
-		Set("enable_k8s_alpha = EXCLUDED.enable_k8s_alpha").
-		Set("endpoint = EXCLUDED.endpoint").
-		Set("initial_version = EXCLUDED.initial_version").
-		Set("current_master_version = EXCLUDED.current_master_version").
-		Set("ca_data = EXCLUDED.ca_data").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, cluster_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"name",
+				"location",
+				"network",
+				"subnetwork",
+				"cluster_ipv4_cidr",
+				"services_ipv4_cidr",
+				"enable_k8s_alpha",
+				"endpoint",
+				"initial_version",
+				"current_master_version",
+				"current_node_count",
+				"status",
+				"ca_data",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err = out.RowsAffected()
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
+
+	logger.Info(
+		"populated gke clusters",
+		"project", payload.ProjectID,
+		"count", count,
+	)
+
+	if len(pools) == 0 {
+		return reconcileGKEClusters(ctx, payload, items, pools)
+	}
+
+	_, err = dbutils.BulkInsert(ctx, db.DB, pools, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, cluster_id, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"cluster_name",
+				"location",
+				"version",
+				"status",
+				"machine_type",
+				"disk_size_gb",
+				"initial_node_count",
+				"autoscaling_enabled",
+				"min_node_count",
+				"max_node_count",
+				"locations",
+			)).
+			Returning("id")
+	}, 0)
+
 	if err != nil {
 		return err
 	}
 
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	poolsCount = int64(len(pools))
+
 	logger.Info(
-		"populated gke clusters",
+		"populated gke node pools",
 		"project", payload.ProjectID,
-		"count", count,
+		"count", poolsCount,
 	)
 
-	return nil
+	return reconcileGKEClusters(ctx, payload, items, pools)
+}
+
+// reconcileGKEClusters marks the collected GKE clusters and node pools of the
+// project as seen, and the ones, which have disappeared, as deleted.
+func reconcileGKEClusters(ctx context.Context, payload CollectGKEClustersPayload, clusters []models.GKECluster, pools []models.NodePool) error {
+	ids := make([]string, 0, len(clusters))
+	for _, item := range clusters {
+		ids = append(ids, item.ClusterID)
+	}
+
+	if err := reconcileProject[models.GKECluster](ctx, "gke clusters", payload.ProjectID, "cluster_id", ids); err != nil {
+		return err
+	}
+
+	keys := make([][]any, 0, len(pools))
+	for _, item := range pools {
+		keys = append(keys, []any{item.ClusterID, item.Name})
+	}
+
+	return reconcileProjectKeys[models.NodePool](ctx, "gke node pools", payload.ProjectID, []string{"cluster_id", "name"}, keys)
 }
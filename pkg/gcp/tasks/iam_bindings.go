@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/gcp/models"
+	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+// TaskCollectIAMBindings is the name of the task for collecting the IAM policy
+// bindings of GCP Projects.
+const TaskCollectIAMBindings = "gcp:task:collect-iam-bindings"
+
+// publicMembers specifies the IAM members, which grant access to everyone.
+var publicMembers = []string{
+	"allUsers",
+	"allAuthenticatedUsers",
+}
+
+// CollectIAMBindingsPayload is the payload used for collecting the IAM policy
+// bindings of a GCP Project.
+type CollectIAMBindingsPayload struct {
+	// ProjectID specifies the globally unique project id from which to
+	// collect.
+	ProjectID string `json:"project_id" yaml:"project_id"`
+}
+
+// NewCollectIAMBindingsTask creates a new [asynq.Task] for collecting IAM
+// policy bindings, without specifying a payload.
+func NewCollectIAMBindingsTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectIAMBindings, nil)
+}
+
+// HandleCollectIAMBindingsTask is the handler, which collects the IAM policy
+// bindings of GCP Projects.
+func HandleCollectIAMBindingsTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting IAM bindings from all registered projects.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectIAMBindings(ctx)
+	}
+
+	var payload CollectIAMBindingsPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.ProjectID == "" {
+		return asynqutils.SkipRetry(ErrNoProjectID)
+	}
+
+	return collectIAMBindings(ctx, payload)
+}
+
+// enqueueCollectIAMBindings enqueues tasks for collecting the IAM policy
+// bindings of all registered GCP Projects.
+func enqueueCollectIAMBindings(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
+	if gcpclients.ProjectsClientset.Length() == 0 {
+		logger.Warn("no GCP project clients found")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.ProjectsClientset.Length())
+	err := gcpclients.ProjectsClientset.Range(func(projectID string, _ *gcpclients.Client[*resourcemanager.ProjectsClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
+		payload := CollectIAMBindingsPayload{
+			ProjectID: projectID,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for GCP IAM bindings",
+				"project", projectID,
+				"reason", err,
+			)
+
+			return registry.ErrContinue
+		}
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectIAMBindings, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectIAMBindings collects the IAM policy bindings of the project specified
+// in the payload.
+func collectIAMBindings(ctx context.Context, payload CollectIAMBindingsPayload) error {
+	client, ok := gcpclients.ProjectsClientset.Get(payload.ProjectID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.ProjectID))
+	}
+
+	var count, publicCount int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			iamBindingsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.ProjectID,
+		)
+		key := metrics.Key(TaskCollectIAMBindings, payload.ProjectID)
+		metrics.DefaultCollector.AddMetric(key, metric)
+
+		publicMetric := prometheus.MustNewConstMetric(
+			publicIAMBindingsDesc,
+			prometheus.GaugeValue,
+			float64(publicCount),
+			payload.ProjectID,
+		)
+		publicKey := metrics.Key(TaskCollectIAMBindings, "public", payload.ProjectID)
+		metrics.DefaultCollector.AddMetric(publicKey, publicMetric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("collecting GCP IAM bindings", "project", payload.ProjectID)
+
+	req := &iampb.GetIamPolicyRequest{
+		Resource: gcputils.ProjectFQN(payload.ProjectID),
+	}
+	policy, err := client.Client.GetIamPolicy(ctx, req)
+	if err != nil {
+		// Credentials for some projects may lack the permission
+		// for getting the IAM policy, so we skip those.
+		if status.Code(err) == codes.PermissionDenied {
+			logger.Warn(
+				"no permission to get GCP IAM policy, skipping",
+				"project", payload.ProjectID,
+				"reason", err,
+			)
+			asynqutils.AddSkipped(ctx, 1)
+
+			return nil
+		}
+
+		logger.Error(
+			"failed to get GCP IAM policy",
+			"project", payload.ProjectID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	// The same member may be bound to a role multiple times with different
+	// conditions, so we de-duplicate them here.
+	items := make([]models.IAMBinding, 0)
+	seen := make(map[string]bool)
+	for _, binding := range policy.GetBindings() {
+		for _, member := range binding.GetMembers() {
+			key := binding.GetRole() + "/" + member
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			item := models.IAMBinding{
+				ProjectID: payload.ProjectID,
+				Role:      binding.GetRole(),
+				Member:    member,
+				IsPublic:  isPublicMember(member),
+			}
+			if item.IsPublic {
+				publicCount++
+			}
+			items = append(items, item)
+		}
+	}
+
+	keys := make([][]any, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, []any{item.Role, item.Member})
+	}
+
+	if len(items) == 0 {
+		return reconcileProjectKeys[models.IAMBinding](ctx, "iam bindings", payload.ProjectID, []string{"role", "member"}, keys)
+	}
+
+	_, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, role, member) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"is_public",
+			)).
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
+
+	logger.Info(
+		"populated gcp iam bindings",
+		"project", payload.ProjectID,
+		"count", count,
+		"public", publicCount,
+	)
+
+	return reconcileProjectKeys[models.IAMBinding](ctx, "iam bindings", payload.ProjectID, []string{"role", "member"}, keys)
+}
+
+// isPublicMember returns true, if the given IAM member grants access to
+// everyone.
+func isPublicMember(member string) bool {
+	return slices.Contains(publicMembers, member)
+}
@@ -9,22 +9,25 @@ import (
 	"encoding/json"
 	"errors"
 	"net"
+	"strconv"
 
 	compute "cloud.google.com/go/compute/apiv1"
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	"google.golang.org/api/iterator"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
+	"github.com/gardener/inventory/pkg/core/globalid"
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/gcp/constants"
 	"github.com/gardener/inventory/pkg/gcp/models"
 	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -42,6 +45,14 @@ type CollectInstancesPayload struct {
 	// ProjectID specifies the globally unique project id from which to
 	// collect GCP Compute Engine Instances.
 	ProjectID string `json:"project_id" yaml:"project_id"`
+
+	// Regions specifies the regions from which to collect instances. If
+	// empty, instances from all regions are collected.
+	Regions []string `json:"regions" yaml:"regions"`
+
+	// Labels specifies the labels, which instances must have in order to be
+	// collected.
+	Labels map[string]string `json:"labels" yaml:"labels"`
 }
 
 // ErrNoSourceImage is an error returned when a
@@ -72,13 +83,25 @@ func HandleCollectInstancesTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(ErrNoProjectID)
 	}
 
+	if err := payload.filter().Validate(); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
 	return collectInstances(ctx, payload)
 }
 
+// filter returns the [gcputils.Filter] for the constraints from the payload.
+func (p CollectInstancesPayload) filter() gcputils.Filter {
+	return gcputils.Filter{Regions: p.Regions, Labels: p.Labels}
+}
+
 // enqueueCollectInstances enqueues tasks for collecting GCP Compute Engine
 // Instances for all known projects.
 func enqueueCollectInstances(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
 	if gcpclients.InstancesClientset.Length() == 0 {
 		logger.Warn("no GCP instance clients found")
 
@@ -87,7 +110,12 @@ func enqueueCollectInstances(ctx context.Context) error {
 
 	// Enqueue tasks for all registered GCP Projects
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.InstancesClientset.Length())
 	err := gcpclients.InstancesClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.InstancesClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		payload := CollectInstancesPayload{
 			ProjectID: projectID,
 		}
@@ -101,31 +129,18 @@ func enqueueCollectInstances(ctx context.Context) error {
 
 			return registry.ErrContinue
 		}
-		task := asynq.NewTask(TaskCollectInstances, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectInstances, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectInstances collects the GCP Compute Engine instances from the project
@@ -159,6 +174,14 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 		ReturnPartialSuccess: &partialSuccess,
 	}
 
+	filter := payload.filter()
+
+	// Filter instances server-side, so that only the matching
+	// instances are transferred.
+	if expr := filter.Expression("zone"); expr != "" {
+		req.Filter = &expr
+	}
+
 	instances := make([]models.Instance, 0)
 	nics := make([]models.NetworkInterface, 0)
 	it := client.Client.AggregatedList(ctx, req)
@@ -183,6 +206,10 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 		zone := gcputils.UnqualifyZone(pair.Key)
 		region := gcputils.RegionFromZone(zone)
 		for _, inst := range pair.Value.Instances {
+			if !filter.Matches(region, inst.GetLabels()) {
+				continue
+			}
+
 			sourceMachineImage, err := getSourceMachineImageFromDisks(ctx, payload.ProjectID, zone, inst.GetDisks())
 			if err != nil {
 				logger.Error(
@@ -218,6 +245,11 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 				StatusMessage:        inst.GetStatusMessage(),
 				GKEClusterName:       gkeClusterName,
 				GKEPoolName:          gkeClusterPoolName,
+				GlobalID: globalid.New(
+					"gcp",
+					payload.ProjectID,
+					strconv.FormatUint(inst.GetId(), 10),
+				),
 			}
 			instances = append(instances, instance)
 
@@ -242,43 +274,43 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 
 	// Upsert instances
 	if len(instances) == 0 {
-		return nil
+		return reconcileInstances(ctx, payload, instances, nics)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&instances).
-		On("CONFLICT (project_id, instance_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("hostname = EXCLUDED.hostname").
-		Set("zone = EXCLUDED.zone").
-		Set("region = EXCLUDED.region").
-		Set("can_ip_forward = EXCLUDED.can_ip_forward").
-		Set("cpu_platform = EXCLUDED.cpu_platform").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("description = EXCLUDED.description").
-		Set("last_start_timestamp = EXCLUDED.last_start_timestamp").
-		Set("last_stop_timestamp = EXCLUDED.last_stop_timestamp").
-		Set("last_suspend_timestamp = EXCLUDED.last_suspend_timestamp").
-		Set("machine_type = EXCLUDED.machine_type").
-		Set("min_cpu_platform = EXCLUDED.min_cpu_platform").
-		Set("self_link = EXCLUDED.self_link").
-		Set("source_machine_image = EXCLUDED.source_machine_image").
-		Set("status = EXCLUDED.status").
-		Set("status_message = EXCLUDED.status_message").
-		Set("gke_cluster_name = EXCLUDED.gke_cluster_name").
-		Set("gke_pool_name = EXCLUDED.gke_pool_name").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err := dbutils.BulkInsert(ctx, db.DB, instances, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, instance_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"name",
+				"hostname",
+				"zone",
+				"region",
+				"can_ip_forward",
+				"cpu_platform",
+				"creation_timestamp",
+				"description",
+				"last_start_timestamp",
+				"last_stop_timestamp",
+				"last_suspend_timestamp",
+				"machine_type",
+				"min_cpu_platform",
+				"self_link",
+				"source_machine_image",
+				"status",
+				"status_message",
+				"gke_cluster_name",
+				"gke_pool_name",
+				"global_id",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(instances))
 
 	logger.Info(
 		"populated gcp instances",
@@ -288,31 +320,30 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 
 	// Upsert NICs
 	if len(nics) == 0 {
-		return nil
+		return reconcileInstances(ctx, payload, instances, nics)
 	}
 
-	out, err = db.DB.NewInsert().
-		Model(&nics).
-		On("CONFLICT (project_id, instance_id, name) DO UPDATE").
-		Set("network = EXCLUDED.network").
-		Set("subnetwork = EXCLUDED.subnetwork").
-		Set("ipv4 = EXCLUDED.ipv4").
-		Set("ipv6 = EXCLUDED.ipv6").
-		Set("ipv6_access_type = EXCLUDED.ipv6_access_type").
-		Set("nic_type = EXCLUDED.nic_type").
-		Set("stack_type = EXCLUDED.stack_type").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err = dbutils.BulkInsert(ctx, db.DB, nics, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, instance_id, name) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"network",
+				"subnetwork",
+				"ipv4",
+				"ipv6",
+				"ipv6_access_type",
+				"nic_type",
+				"stack_type",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(nics))
 
 	logger.Info(
 		"populated gcp network interfaces",
@@ -320,7 +351,32 @@ func collectInstances(ctx context.Context, payload CollectInstancesPayload) erro
 		"count", count,
 	)
 
-	return nil
+	return reconcileInstances(ctx, payload, instances, nics)
+}
+
+// reconcileInstances marks the collected instances and network interfaces of
+// the project as seen, and the ones, which have disappeared, as deleted.
+// Filtered collections do not cover the whole project, and are not reconciled.
+func reconcileInstances(ctx context.Context, payload CollectInstancesPayload, instances []models.Instance, nics []models.NetworkInterface) error {
+	if !payload.filter().IsEmpty() {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(instances))
+	for _, item := range instances {
+		ids = append(ids, item.InstanceID)
+	}
+
+	if err := reconcileProject[models.Instance](ctx, "instances", payload.ProjectID, "instance_id", ids); err != nil {
+		return err
+	}
+
+	keys := make([][]any, 0, len(nics))
+	for _, item := range nics {
+		keys = append(keys, []any{item.InstanceID, item.Name})
+	}
+
+	return reconcileProjectKeys[models.NetworkInterface](ctx, "network interfaces", payload.ProjectID, []string{"instance_id", "name"}, keys)
 }
 
 func getSourceMachineImageFromDisks(
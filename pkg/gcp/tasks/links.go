@@ -11,16 +11,18 @@ import (
 
 	"github.com/gardener/inventory/pkg/gcp/models"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // LinkInstanceWithProject creates links between the [models.Instance] and
 // [models.Project] models.
-func LinkInstanceWithProject(ctx context.Context, db *bun.DB) error {
+func LinkInstanceWithProject(ctx context.Context, db bun.IDB) error {
 	var items []models.Instance
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Project").
 		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
 		Scan(ctx)
 
 	if err != nil {
@@ -40,21 +42,17 @@ func LinkInstanceWithProject(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (project_id, instance_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, instance_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp instance with project", "count", count)
@@ -64,12 +62,13 @@ func LinkInstanceWithProject(ctx context.Context, db *bun.DB) error {
 
 // LinkVPCWithProject creates links between the [models.VPC] and
 // [models.Project] models.
-func LinkVPCWithProject(ctx context.Context, db *bun.DB) error {
+func LinkVPCWithProject(ctx context.Context, db bun.IDB) error {
 	var items []models.VPC
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Project").
 		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
 		Scan(ctx)
 
 	if err != nil {
@@ -89,21 +88,17 @@ func LinkVPCWithProject(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (project_id, vpc_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, vpc_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp vpc with project", "count", count)
@@ -113,12 +108,13 @@ func LinkVPCWithProject(ctx context.Context, db *bun.DB) error {
 
 // LinkAddressWithProject creates links between the [models.Address] and
 // [models.Project] models.
-func LinkAddressWithProject(ctx context.Context, db *bun.DB) error {
+func LinkAddressWithProject(ctx context.Context, db bun.IDB) error {
 	var items []models.Address
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Project").
 		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
 		Scan(ctx)
 
 	if err != nil {
@@ -138,21 +134,17 @@ func LinkAddressWithProject(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (project_id, address_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, address_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp address with project", "count", count)
@@ -162,12 +154,13 @@ func LinkAddressWithProject(ctx context.Context, db *bun.DB) error {
 
 // LinkInstanceWithNetworkInterface creates links between the
 // [models.NetworkInterface] and [models.Instance] models.
-func LinkInstanceWithNetworkInterface(ctx context.Context, db *bun.DB) error {
+func LinkInstanceWithNetworkInterface(ctx context.Context, db bun.IDB) error {
 	var items []models.NetworkInterface
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Instance").
 		Where("instance.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "instance")).
 		Scan(ctx)
 
 	if err != nil {
@@ -187,21 +180,17 @@ func LinkInstanceWithNetworkInterface(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (instance_id, nic_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (instance_id, nic_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp instance with network interface", "count", count)
@@ -211,12 +200,13 @@ func LinkInstanceWithNetworkInterface(ctx context.Context, db *bun.DB) error {
 
 // LinkSubnetWithVPC creates links between the [models.Subnet] and
 // [models.VPC] models.
-func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
+func LinkSubnetWithVPC(ctx context.Context, db bun.IDB) error {
 	var items []models.Subnet
 	err := db.NewSelect().
 		Model(&items).
 		Relation("VPC").
 		Where("vpc.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "vpc")).
 		Scan(ctx)
 
 	if err != nil {
@@ -236,21 +226,17 @@ func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (vpc_id, subnet_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (vpc_id, subnet_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp subnet with vpc", "count", count)
@@ -260,12 +246,13 @@ func LinkSubnetWithVPC(ctx context.Context, db *bun.DB) error {
 
 // LinkSubnetWithProject creates links between the [models.Subnet] and
 // [models.Project] models.
-func LinkSubnetWithProject(ctx context.Context, db *bun.DB) error {
+func LinkSubnetWithProject(ctx context.Context, db bun.IDB) error {
 	var items []models.Subnet
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Project").
 		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
 		Scan(ctx)
 
 	if err != nil {
@@ -285,21 +272,17 @@ func LinkSubnetWithProject(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (project_id, subnet_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, subnet_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp subnet with project", "count", count)
@@ -309,12 +292,13 @@ func LinkSubnetWithProject(ctx context.Context, db *bun.DB) error {
 
 // LinkForwardingRuleWithProject creates links between the
 // [models.ForwardingRule] and [models.Project] models.
-func LinkForwardingRuleWithProject(ctx context.Context, db *bun.DB) error {
+func LinkForwardingRuleWithProject(ctx context.Context, db bun.IDB) error {
 	var items []models.ForwardingRule
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Project").
 		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
 		Scan(ctx)
 
 	if err != nil {
@@ -334,21 +318,17 @@ func LinkForwardingRuleWithProject(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (project_id, rule_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, rule_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp forwarding rule with project", "count", count)
@@ -358,13 +338,14 @@ func LinkForwardingRuleWithProject(ctx context.Context, db *bun.DB) error {
 
 // LinkInstanceWithDisk creates links between the
 // [models.Instance] and [models.Disk] models.
-func LinkInstanceWithDisk(ctx context.Context, db *bun.DB) error {
+func LinkInstanceWithDisk(ctx context.Context, db bun.IDB) error {
 	var items []models.AttachedDisk
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Instance").
 		Relation("Disk").
 		Where("instance.id IS NOT NULL AND disk.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "instance", "disk")).
 		Scan(ctx)
 
 	if err != nil {
@@ -384,21 +365,17 @@ func LinkInstanceWithDisk(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (instance_id, disk_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (instance_id, disk_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp instance with disk", "count", count)
@@ -408,12 +385,13 @@ func LinkInstanceWithDisk(ctx context.Context, db *bun.DB) error {
 
 // LinkGKEClusterWithProject creates links between the [models.GKECluster] and
 // [models.Project] models.
-func LinkGKEClusterWithProject(ctx context.Context, db *bun.DB) error {
+func LinkGKEClusterWithProject(ctx context.Context, db bun.IDB) error {
 	var items []models.GKECluster
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Project").
 		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
 		Scan(ctx)
 
 	if err != nil {
@@ -433,21 +411,17 @@ func LinkGKEClusterWithProject(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (project_id, cluster_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, cluster_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gke cluster with project", "count", count)
@@ -457,12 +431,13 @@ func LinkGKEClusterWithProject(ctx context.Context, db *bun.DB) error {
 
 // LinkTargetPoolWithInstance creates links between the [models.TargetPool] and
 // [models.TargetPoolInstance] models.
-func LinkTargetPoolWithInstance(ctx context.Context, db *bun.DB) error {
+func LinkTargetPoolWithInstance(ctx context.Context, db bun.IDB) error {
 	var items []models.TargetPoolInstance
 	err := db.NewSelect().
 		Model(&items).
 		Relation("TargetPool").
 		Where("target_pool.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "target_pool")).
 		Scan(ctx)
 
 	if err != nil {
@@ -482,21 +457,17 @@ func LinkTargetPoolWithInstance(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (target_pool_id, instance_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (target_pool_id, instance_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	dbutils.AddLinks(ctx, count)
 
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked gcp target pool with instance", "count", count)
@@ -506,12 +477,13 @@ func LinkTargetPoolWithInstance(ctx context.Context, db *bun.DB) error {
 
 // LinkTargetPoolWithProject creates links between the [models.TargetPool] and
 // [models.Project] models.
-func LinkTargetPoolWithProject(ctx context.Context, db *bun.DB) error {
+func LinkTargetPoolWithProject(ctx context.Context, db bun.IDB) error {
 	var items []models.TargetPool
 	err := db.NewSelect().
 		Model(&items).
 		Relation("Project").
 		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
 		Scan(ctx)
 
 	if err != nil {
@@ -531,24 +503,250 @@ func LinkTargetPoolWithProject(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
-	out, err := db.NewInsert().
-		Model(&links).
-		On("CONFLICT (target_pool_id, project_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (target_pool_id, project_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err := out.RowsAffected()
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked gcp target pool with project", "count", count)
+
+	return nil
+}
+
+// LinkCloudSQLInstanceWithProject creates links between the
+// [models.CloudSQLInstance] and [models.Project] models.
+func LinkCloudSQLInstanceWithProject(ctx context.Context, db bun.IDB) error {
+	var items []models.CloudSQLInstance
+	err := db.NewSelect().
+		Model(&items).
+		Relation("Project").
+		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
+		Scan(ctx)
+
 	if err != nil {
 		return err
 	}
 
+	links := make([]models.CloudSQLInstanceToProject, 0)
+	for _, item := range items {
+		link := models.CloudSQLInstanceToProject{
+			InstanceID: item.ID,
+			ProjectID:  item.Project.ID,
+		}
+		links = append(links, link)
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id, instance_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
-	logger.Info("linked gcp target pool with project", "count", count)
+	logger.Info("linked cloud sql instance with project", "count", count)
+
+	return nil
+}
+
+// LinkFirewallRuleWithVPC creates links between the [models.FirewallRule] and
+// [models.VPC] models.
+func LinkFirewallRuleWithVPC(ctx context.Context, db bun.IDB) error {
+	var items []models.FirewallRule
+	err := db.NewSelect().
+		Model(&items).
+		Relation("VPC").
+		Where("vpc.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "vpc")).
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.FirewallRuleToVPC, 0, len(items))
+	for _, item := range items {
+		link := models.FirewallRuleToVPC{
+			FirewallRuleID: item.ID,
+			VPCID:          item.VPC.ID,
+		}
+		links = append(links, link)
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (firewall_rule_id, vpc_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked gcp firewall rule with vpc", "count", count)
+
+	return nil
+}
+
+// LinkSnapshotWithDisk creates links between the [models.Snapshot] and the
+// [models.Disk] it was created from.
+func LinkSnapshotWithDisk(ctx context.Context, db bun.IDB) error {
+	var items []models.Snapshot
+	err := db.NewSelect().
+		Model(&items).
+		Relation("Disk").
+		Where("disk.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "disk")).
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.SnapshotToDisk, 0, len(items))
+	for _, item := range items {
+		link := models.SnapshotToDisk{
+			SnapshotID: item.ID,
+			DiskID:     item.Disk.ID,
+		}
+		links = append(links, link)
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (snapshot_id, disk_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked gcp snapshot with disk", "count", count)
+
+	return nil
+}
+
+// LinkNodePoolWithCluster creates links between the [models.NodePool] and
+// [models.GKECluster] models.
+func LinkNodePoolWithCluster(ctx context.Context, db bun.IDB) error {
+	var items []models.NodePool
+	err := db.NewSelect().
+		Model(&items).
+		Relation("Cluster").
+		Where("cluster.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "cluster")).
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.NodePoolToCluster, 0, len(items))
+	for _, item := range items {
+		link := models.NodePoolToCluster{
+			NodePoolID: item.ID,
+			ClusterID:  item.Cluster.ID,
+		}
+		links = append(links, link)
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (node_pool_id, cluster_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked gke node pool with cluster", "count", count)
+
+	return nil
+}
+
+// LinkBucketWithProject creates links between the [models.Bucket] and
+// [models.Project] models.
+func LinkBucketWithProject(ctx context.Context, db bun.IDB) error {
+	var items []models.Bucket
+	err := db.NewSelect().
+		Model(&items).
+		Relation("Project").
+		Where("project.id IS NOT NULL").
+		Apply(dbutils.UpdatedSince(ctx, "project")).
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.BucketToProject, 0)
+	for _, item := range items {
+		link := models.BucketToProject{
+			BucketID:  item.ID,
+			ProjectID: item.Project.ID,
+		}
+		links = append(links, link)
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	count, err := dbutils.BulkInsert(ctx, db, links, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (bucket_id, project_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked gcp bucket with project", "count", count)
 
 	return nil
 }
@@ -14,7 +14,7 @@ var (
 	// projectsDesc is the descriptor for a metric, which tracks the number
 	// of collected GCP projects.
 	projectsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_projects"),
+		"gcp_projects",
 		"A gauge which tracks the number of collected GCP projects",
 		nil,
 		nil,
@@ -23,7 +23,7 @@ var (
 	// vpcsDesc is the descriptor for a metric, which tracks the number
 	// of collected GCP VPC networks.
 	vpcsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_vpcs"),
+		"gcp_vpcs",
 		"A gauge which tracks the number of collected GCP VPCs",
 		[]string{"project_id"},
 		nil,
@@ -32,7 +32,7 @@ var (
 	// disksDesc is the descriptor for a metric, which tracks the number
 	// of collected GCP disks.
 	disksDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_disks"),
+		"gcp_disks",
 		"A gauge which tracks the number of collected GCP disks",
 		[]string{"project_id"},
 		nil,
@@ -41,7 +41,7 @@ var (
 	// bucketsDesc is the descriptor for a metric, which tracks the number
 	// of collected GCP buckets.
 	bucketsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_buckets"),
+		"gcp_buckets",
 		"A gauge which tracks the number of collected GCP buckets",
 		[]string{"project_id"},
 		nil,
@@ -50,7 +50,7 @@ var (
 	// subnetsDesc is the descriptor for a metric, which tracks the number
 	// of collected GCP subnets.
 	subnetsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_subnets"),
+		"gcp_subnets",
 		"A gauge which tracks the number of collected GCP subnets",
 		[]string{"project_id"},
 		nil,
@@ -59,7 +59,7 @@ var (
 	// addressesDesc is the descriptor for a metric, which tracks the number
 	// of collected GCP regional and global addresses.
 	addressesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_addresses"),
+		"gcp_addresses",
 		"A gauge which tracks the number of collected GCP addresses",
 		[]string{"project_id"},
 		nil,
@@ -68,7 +68,7 @@ var (
 	// instancesDesc is the descriptor for a metric, which tracks the number
 	// of collected GCP instances.
 	instancesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_instances"),
+		"gcp_instances",
 		"A gauge which tracks the number of collected GCP instances",
 		[]string{"project_id"},
 		nil,
@@ -77,16 +77,52 @@ var (
 	// gkeClustersDesc is the descriptor for a metric, which tracks the number
 	// of collected GKE clusters.
 	gkeClustersDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_gke_clusters"),
+		"gcp_gke_clusters",
 		"A gauge which tracks the number of collected GKE clusters",
 		[]string{"project_id"},
 		nil,
 	)
 
+	// gkeNodePoolsDesc is the descriptor for a metric, which tracks the
+	// number of collected GKE node pools.
+	gkeNodePoolsDesc = prometheus.NewDesc(
+		"gcp_gke_node_pools",
+		"A gauge which tracks the number of collected GKE node pools",
+		[]string{"project_id"},
+		nil,
+	)
+
+	// cloudSQLInstancesDesc is the descriptor for a metric, which tracks the
+	// number of collected Cloud SQL instances.
+	cloudSQLInstancesDesc = prometheus.NewDesc(
+		"gcp_cloud_sql_instances",
+		"A gauge which tracks the number of collected Cloud SQL instances",
+		[]string{"project_id"},
+		nil,
+	)
+
+	// firewallRulesDesc is the descriptor for a metric, which tracks the
+	// number of collected GCP firewall rules.
+	firewallRulesDesc = prometheus.NewDesc(
+		"gcp_firewall_rules",
+		"A gauge which tracks the number of collected GCP firewall rules",
+		[]string{"project_id"},
+		nil,
+	)
+
+	// snapshotsDesc is the descriptor for a metric, which tracks the number
+	// of collected GCP disk snapshots.
+	snapshotsDesc = prometheus.NewDesc(
+		"gcp_snapshots",
+		"A gauge which tracks the number of collected GCP disk snapshots",
+		[]string{"project_id"},
+		nil,
+	)
+
 	// targetPoolsDesc is the descriptor for a metric, which tracks the number
 	// of collected GCP target pools.
 	targetPoolsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_target_pools"),
+		"gcp_target_pools",
 		"A gauge which tracks the number of collected GCP target pools",
 		[]string{"project_id"},
 		nil,
@@ -95,11 +131,29 @@ var (
 	// forwardingRulesDesc is the descriptor for a metric, which tracks
 	// the number of collected GCP Forwarding Rules.
 	forwardingRulesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "gcp_forwarding_rules"),
+		"gcp_forwarding_rules",
 		"A gauge which tracks the number of collected GCP forwarding rules",
 		[]string{"project_id"},
 		nil,
 	)
+
+	// iamBindingsDesc is the descriptor for a metric, which tracks the
+	// number of collected GCP IAM policy bindings.
+	iamBindingsDesc = prometheus.NewDesc(
+		"gcp_iam_bindings",
+		"A gauge which tracks the number of collected GCP IAM policy bindings",
+		[]string{"project_id"},
+		nil,
+	)
+
+	// publicIAMBindingsDesc is the descriptor for a metric, which tracks
+	// the number of GCP IAM policy bindings granting access to everyone.
+	publicIAMBindingsDesc = prometheus.NewDesc(
+		"gcp_public_iam_bindings",
+		"A gauge which tracks the number of GCP IAM policy bindings granting access to allUsers or allAuthenticatedUsers",
+		[]string{"project_id"},
+		nil,
+	)
 )
 
 // init registers the metrics with the [metrics.DefaultCollector].
@@ -113,7 +167,13 @@ func init() {
 		addressesDesc,
 		instancesDesc,
 		gkeClustersDesc,
+		gkeNodePoolsDesc,
+		cloudSQLInstancesDesc,
+		firewallRulesDesc,
+		snapshotsDesc,
 		targetPoolsDesc,
 		forwardingRulesDesc,
+		iamBindingsDesc,
+		publicIAMBindingsDesc,
 	)
 }
@@ -11,6 +11,7 @@ import (
 	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
@@ -19,6 +20,7 @@ import (
 	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // TaskCollectProjects is the name of the task for collecting GCP Projects
@@ -33,6 +35,9 @@ func NewCollectProjectsTask() *asynq.Task {
 // HandleCollectProjectsTask is the handler, which collects GCP projects
 func HandleCollectProjectsTask(ctx context.Context, _ *asynq.Task) error {
 	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
 	if gcpclients.ProjectsClientset.Length() == 0 {
 		logger.Warn("no GCP project clients found")
 
@@ -51,6 +56,10 @@ func HandleCollectProjectsTask(ctx context.Context, _ *asynq.Task) error {
 
 	items := make([]models.Project, 0, gcpclients.ProjectsClientset.Length())
 	err := gcpclients.ProjectsClientset.Range(func(projectID string, client *gcpclients.Client[*resourcemanager.ProjectsClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		logger.Info("collecting GCP project", "project", projectID)
 		req := &resourcemanagerpb.GetProjectRequest{
 			Name: gcputils.ProjectFQN(projectID),
@@ -89,30 +98,39 @@ func HandleCollectProjectsTask(ctx context.Context, _ *asynq.Task) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (project_id) DO UPDATE").
-		Set("parent = EXCLUDED.parent").
-		Set("state = EXCLUDED.state").
-		Set("display_name = EXCLUDED.display_name").
-		Set("etag = EXCLUDED.etag").
-		Set("project_create_time = EXCLUDED.project_create_time").
-		Set("project_update_time = EXCLUDED.project_update_time").
-		Set("project_delete_time = EXCLUDED.project_delete_time").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"parent",
+				"state",
+				"display_name",
+				"etag",
+				"project_create_time",
+				"project_update_time",
+				"project_delete_time",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
 
 	logger.Info("populated gcp projects", "count", count)
 
+	// Each project is collected separately, so the collected projects are
+	// marked as seen within their own scope. Projects, which could not be
+	// collected are not marked as deleted, since the failure may be
+	// transient.
+	for _, item := range items {
+		if err := reconcileProject[models.Project](ctx, "projects", item.ProjectID, "project_id", []string{item.ProjectID}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
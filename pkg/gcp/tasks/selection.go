@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+
+	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// isProjectSelected returns true, if the given GCP project is selected for
+// collection via the configured include and exclude lists. Projects, which are
+// not selected are logged along with the reason.
+func isProjectSelected(ctx context.Context, projectID string) bool {
+	ok, reason := gcpclients.ProjectSelection.Check(projectID)
+	if !ok {
+		logger := asynqutils.GetLogger(ctx)
+		logger.Info(
+			"skipping gcp project",
+			"project", projectID,
+			"reason", reason,
+		)
+	}
+
+	return ok
+}
@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+	"google.golang.org/api/iterator"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/gcp/constants"
+	"github.com/gardener/inventory/pkg/gcp/models"
+	"github.com/gardener/inventory/pkg/gcp/utils"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// TaskCollectSnapshots is the name of the task for collecting GCP
+	// snapshots.
+	TaskCollectSnapshots = "gcp:task:collect-snapshots"
+)
+
+// NewCollectSnapshotsTask creates a new [asynq.Task] task for collecting
+// GCP snapshots without specifying a payload.
+func NewCollectSnapshotsTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectSnapshots, nil)
+}
+
+// CollectSnapshotsPayload is the payload, which is used to collect GCP
+// snapshots.
+type CollectSnapshotsPayload struct {
+	// ProjectID specifies the GCP project ID, which is associated with a
+	// registered client.
+	ProjectID string `json:"project_id" yaml:"project_id"`
+}
+
+// HandleCollectSnapshotsTask is the handler, which collects GCP disk
+// snapshots.
+func HandleCollectSnapshotsTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we will enqueue tasks for
+	// collecting snapshots for all configured clients.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectSnapshots(ctx)
+	}
+
+	// Collect snapshots using the client associated with the project
+	// ID from the payload.
+	var payload CollectSnapshotsPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if payload.ProjectID == "" {
+		return asynqutils.SkipRetry(ErrNoProjectID)
+	}
+
+	return collectSnapshots(ctx, payload)
+}
+
+// enqueueCollectSnapshots enqueues tasks for collecting GCP snapshots
+// for all collected GCP projects.
+func enqueueCollectSnapshots(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
+	if gcpclients.SnapshotsClientset.Length() == 0 {
+		logger.Warn(
+			"no gcp snapshots clients configured. skipping task.",
+		)
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.SnapshotsClientset.Length())
+	err := gcpclients.SnapshotsClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.SnapshotsClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
+		p := &CollectSnapshotsPayload{ProjectID: projectID}
+		data, err := json.Marshal(p)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for GCP snapshots",
+				"project", projectID,
+				"reason", err,
+			)
+
+			return registry.ErrContinue
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectSnapshots, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectSnapshots collects the GCP disk snapshots using the client
+// configuration specified in the payload.
+func collectSnapshots(ctx context.Context, payload CollectSnapshotsPayload) error {
+	client, ok := gcpclients.SnapshotsClientset.Get(payload.ProjectID)
+	if !ok {
+		return asynqutils.SkipRetry(ClientNotFound(payload.ProjectID))
+	}
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			snapshotsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.ProjectID,
+		)
+		key := metrics.Key(TaskCollectSnapshots, payload.ProjectID)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("collecting GCP snapshots", "project", payload.ProjectID)
+
+	pageSize := uint32(constants.PageSize)
+	partialSuccess := true
+	req := computepb.ListSnapshotsRequest{
+		Project:              payload.ProjectID,
+		MaxResults:           &pageSize,
+		ReturnPartialSuccess: &partialSuccess,
+	}
+
+	it := client.Client.List(ctx, &req)
+	items := make([]models.Snapshot, 0)
+	for {
+		snapshot, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			logger.Error(
+				"failed to get GCP snapshots",
+				"project", payload.ProjectID,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		// The source disk is referred to by its URL, which contains
+		// either the zone of a zonal disk, or the region of a regional
+		// disk.
+		sourceDisk := snapshot.GetSourceDisk()
+		sourceDiskZone := utils.SegmentFromURL(sourceDisk, "zones")
+		sourceDiskRegion := utils.SegmentFromURL(sourceDisk, "regions")
+		if sourceDiskZone != "" {
+			sourceDiskRegion = utils.RegionFromZone(sourceDiskZone)
+		}
+
+		item := models.Snapshot{
+			Name:              snapshot.GetName(),
+			ProjectID:         payload.ProjectID,
+			SnapshotID:        snapshot.GetId(),
+			SourceDisk:        utils.ResourceNameFromURL(sourceDisk),
+			SourceDiskProject: utils.SegmentFromURL(sourceDisk, "projects"),
+			SourceDiskZone:    sourceDiskZone,
+			SourceDiskRegion:  sourceDiskRegion,
+			DiskSizeGB:        snapshot.GetDiskSizeGb(),
+			StorageBytes:      snapshot.GetStorageBytes(),
+			Status:            snapshot.GetStatus(),
+			SnapshotType:      snapshot.GetSnapshotType(),
+			AutoCreated:       snapshot.GetAutoCreated(),
+			CreationTimestamp: snapshot.GetCreationTimestamp(),
+		}
+		items = append(items, item)
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Name)
+	}
+
+	if len(items) == 0 {
+		return reconcileProject[models.Snapshot](ctx, "snapshots", payload.ProjectID, "name", ids)
+	}
+
+	_, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"snapshot_id",
+				"source_disk",
+				"source_disk_project",
+				"source_disk_zone",
+				"source_disk_region",
+				"disk_size_gb",
+				"storage_bytes",
+				"status",
+				"snapshot_type",
+				"auto_created",
+				"creation_timestamp",
+			)).
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert snapshots into db",
+			"project", payload.ProjectID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
+
+	logger.Info(
+		"populated gcp snapshots",
+		"project", payload.ProjectID,
+		"count", count,
+	)
+
+	return reconcileProject[models.Snapshot](ctx, "snapshots", payload.ProjectID, "name", ids)
+}
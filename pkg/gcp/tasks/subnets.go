@@ -14,9 +14,9 @@ import (
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	"google.golang.org/api/iterator"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/registry"
@@ -25,6 +25,7 @@ import (
 	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -73,6 +74,9 @@ func HandleCollectSubnetsTask(ctx context.Context, t *asynq.Task) error {
 // for all collected GCP projects.
 func enqueueCollectSubnets(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
 	if gcpclients.SubnetworksClientset.Length() == 0 {
 		logger.Warn("no GCP subnet clients found")
 
@@ -80,7 +84,12 @@ func enqueueCollectSubnets(ctx context.Context) error {
 	}
 
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.SubnetworksClientset.Length())
 	err := gcpclients.SubnetworksClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.SubnetworksClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		p := &CollectSubnetsPayload{ProjectID: projectID}
 		data, err := json.Marshal(p)
 		if err != nil {
@@ -93,31 +102,18 @@ func enqueueCollectSubnets(ctx context.Context) error {
 			return registry.ErrContinue
 		}
 
-		task := asynq.NewTask(TaskCollectSubnets, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectSubnets, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectSubnets collects the GCP subnets using the client configuration
@@ -203,23 +199,28 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		}
 	}
 
+	ids := make([]uint64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.SubnetID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.Subnet](ctx, "subnets", payload.ProjectID, "subnet_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (subnet_id, vpc_name, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("region = EXCLUDED.region").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("description = EXCLUDED.description").
-		Set("ipv4_cidr_range = EXCLUDED.ipv4_cidr_range").
-		Set("gateway = EXCLUDED.gateway").
-		Set("purpose = EXCLUDED.purpose").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subnet_id, vpc_name, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"name",
+				"region",
+				"creation_timestamp",
+				"description",
+				"ipv4_cidr_range",
+				"gateway",
+				"purpose",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -231,10 +232,9 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
 
 	logger.Info(
 		"populated gcp subnets",
@@ -242,5 +242,5 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		"count", count,
 	)
 
-	return nil
+	return reconcileProject[models.Subnet](ctx, "subnets", payload.ProjectID, "subnet_id", ids)
 }
@@ -13,9 +13,9 @@ import (
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	"google.golang.org/api/iterator"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/registry"
@@ -25,6 +25,7 @@ import (
 	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // TaskCollectTargetPools is the name of the task for collecting GCP
@@ -75,6 +76,9 @@ func HandleCollectTargetPools(ctx context.Context, t *asynq.Task) error {
 // all known projects.
 func enqueueCollectTargetPools(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
+
+	refreshProjects(ctx)
+
 	if gcpclients.TargetPoolsClientset.Length() == 0 {
 		logger.Warn("no GCP target pools clients found")
 
@@ -83,7 +87,12 @@ func enqueueCollectTargetPools(ctx context.Context) error {
 
 	// Enqueue tasks for all registered GCP Projects
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.TargetPoolsClientset.Length())
 	err := gcpclients.TargetPoolsClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.TargetPoolsClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		payload := CollectTargetPoolsPayload{
 			ProjectID: projectID,
 		}
@@ -97,31 +106,18 @@ func enqueueCollectTargetPools(ctx context.Context) error {
 
 			return registry.ErrContinue
 		}
-		task := asynq.NewTask(TaskCollectTargetPools, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectTargetPools, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectTargetPools collects the GCP Target Pools from the project
@@ -215,31 +211,30 @@ func collectTargetPools(ctx context.Context, payload CollectTargetPoolsPayload)
 
 	// UPSERT Target Pools
 	if len(targetPools) == 0 {
-		return nil
+		return reconcileTargetPools(ctx, payload, targetPools, targetPoolInstances)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&targetPools).
-		On("CONFLICT (target_pool_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("description = EXCLUDED.description").
-		Set("backup_pool = EXCLUDED.backup_pool").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("region = EXCLUDED.region").
-		Set("security_policy = EXCLUDED.security_policy").
-		Set("session_affinity = EXCLUDED.session_affinity").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err := dbutils.BulkInsert(ctx, db.DB, targetPools, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (target_pool_id, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"name",
+				"description",
+				"backup_pool",
+				"creation_timestamp",
+				"region",
+				"security_policy",
+				"session_affinity",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	tpCount, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	tpCount = int64(len(targetPools))
 
 	logger.Info(
 		"populated gcp target pools",
@@ -249,25 +244,24 @@ func collectTargetPools(ctx context.Context, payload CollectTargetPoolsPayload)
 
 	// UPSERT Target Pool Instances
 	if len(targetPoolInstances) == 0 {
-		return nil
+		return reconcileTargetPools(ctx, payload, targetPools, targetPoolInstances)
 	}
 
-	out, err = db.DB.NewInsert().
-		Model(&targetPoolInstances).
-		On("CONFLICT (target_pool_id, project_id, instance_name) DO UPDATE").
-		Set("inferred_g_shoot = EXCLUDED.inferred_g_shoot").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err = dbutils.BulkInsert(ctx, db.DB, targetPoolInstances, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (target_pool_id, project_id, instance_name) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"inferred_g_shoot",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		return err
 	}
 
-	tpiCount, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	tpiCount := int64(len(targetPoolInstances))
 
 	logger.Info(
 		"populated gcp target pool instances",
@@ -275,5 +269,26 @@ func collectTargetPools(ctx context.Context, payload CollectTargetPoolsPayload)
 		"count", tpiCount,
 	)
 
-	return nil
+	return reconcileTargetPools(ctx, payload, targetPools, targetPoolInstances)
+}
+
+// reconcileTargetPools marks the collected target pools and target pool
+// instances of the project as seen, and the ones, which have disappeared, as
+// deleted.
+func reconcileTargetPools(ctx context.Context, payload CollectTargetPoolsPayload, targetPools []models.TargetPool, targetPoolInstances []models.TargetPoolInstance) error {
+	ids := make([]uint64, 0, len(targetPools))
+	for _, item := range targetPools {
+		ids = append(ids, item.TargetPoolID)
+	}
+
+	if err := reconcileProject[models.TargetPool](ctx, "target pools", payload.ProjectID, "target_pool_id", ids); err != nil {
+		return err
+	}
+
+	keys := make([][]any, 0, len(targetPoolInstances))
+	for _, item := range targetPoolInstances {
+		keys = append(keys, []any{item.TargetPoolID, item.InstanceName})
+	}
+
+	return reconcileProjectKeys[models.TargetPoolInstance](ctx, "target pool instances", payload.ProjectID, []string{"target_pool_id", "instance_name"}, keys)
 }
@@ -11,6 +11,8 @@ import (
 
 	"github.com/gardener/inventory/pkg/clients/db"
 	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/gcp/models"
+	gcputils "github.com/gardener/inventory/pkg/gcp/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
@@ -23,6 +25,17 @@ const (
 	TaskLinkAll = "gcp:task:link-all"
 )
 
+// refreshProjects discovers the projects of the named credentials configured
+// for project discovery, so that tasks are enqueued for projects, which have
+// been created since the last discovery. Failures to discover the projects are
+// not fatal, since tasks are still enqueued for the already known projects.
+func refreshProjects(ctx context.Context) {
+	if err := gcputils.RefreshProjects(ctx); err != nil {
+		logger := asynqutils.GetLogger(ctx)
+		logger.Warn("failed to discover GCP projects", "reason", err)
+	}
+}
+
 // HandleCollectAllTask is a handler, which enqueues tasks for collecting all
 // GCP objects.
 func HandleCollectAllTask(ctx context.Context, _ *asynq.Task) error {
@@ -40,31 +53,93 @@ func HandleCollectAllTask(ctx context.Context, _ *asynq.Task) error {
 		NewCollectDisksTask,
 		NewCollectGKEClustersTask,
 		NewCollectTargetPoolsTask,
+		NewCollectIAMBindingsTask,
+		NewCollectCloudSQLInstancesTask,
+		NewCollectFirewallRulesTask,
+		NewCollectSnapshotsTask,
 	}
 
-	return asynqutils.Enqueue(ctx, taskFns, asynq.Queue(queue))
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // HandleLinkAllTask is a handler, which establishes links between the various
 // GCP models.
 func HandleLinkAllTask(ctx context.Context, _ *asynq.Task) error {
 	linkFns := []dbutils.LinkFunction{
-		LinkInstanceWithProject,
-		LinkVPCWithProject,
-		LinkAddressWithProject,
-		LinkInstanceWithNetworkInterface,
-		LinkSubnetWithVPC,
-		LinkSubnetWithProject,
-		LinkForwardingRuleWithProject,
-		LinkInstanceWithDisk,
-		LinkGKEClusterWithProject,
-		LinkTargetPoolWithInstance,
-		LinkTargetPoolWithProject,
+		dbutils.Incremental(models.InstanceToProjectModelName, LinkInstanceWithProject),
+		dbutils.Incremental(models.VPCToProjectModelName, LinkVPCWithProject),
+		dbutils.Incremental(models.AddressToProjectModelName, LinkAddressWithProject),
+		dbutils.Incremental(models.InstanceToNetworkInterfaceModelName, LinkInstanceWithNetworkInterface),
+		dbutils.Incremental(models.SubnetToVPCModelName, LinkSubnetWithVPC),
+		dbutils.Incremental(models.SubnetToProjectModelName, LinkSubnetWithProject),
+		dbutils.Incremental(models.ForwardingRuleToProjectModelName, LinkForwardingRuleWithProject),
+		dbutils.Incremental(models.InstanceToDiskModelName, LinkInstanceWithDisk),
+		dbutils.Incremental(models.GKEClusterToProjectModelName, LinkGKEClusterWithProject),
+		dbutils.Incremental(models.TargetPoolToInstanceModelName, LinkTargetPoolWithInstance),
+		dbutils.Incremental(models.TargetPoolToProjectModelName, LinkTargetPoolWithProject),
+		dbutils.Incremental(models.CloudSQLInstanceToProjectModelName, LinkCloudSQLInstanceWithProject),
+		dbutils.Incremental(models.FirewallRuleToVPCModelName, LinkFirewallRuleWithVPC),
+		dbutils.Incremental(models.SnapshotToDiskModelName, LinkSnapshotWithDisk),
+		dbutils.Incremental(models.NodePoolToClusterModelName, LinkNodePoolWithCluster),
+		dbutils.Incremental(models.BucketToProjectModelName, LinkBucketWithProject),
 	}
 
 	return dbutils.LinkObjects(ctx, db.DB, linkFns)
 }
 
+// reconcileProject marks the records of model T in the given project, whose
+// idColumn is one of the given ids, as seen, and the remaining ones as deleted.
+// It must be called only after the whole project has been collected
+// successfully.
+func reconcileProject[T any, K any](ctx context.Context, kind, projectID, idColumn string, ids []K) error {
+	scope := map[string]any{
+		"project_id": projectID,
+	}
+
+	result, err := dbutils.Reconcile[T](ctx, db.DB, scope, idColumn, ids)
+
+	return logReconcile(ctx, kind, projectID, result, err)
+}
+
+// reconcileProjectKeys is like [reconcileProject], but identifies the records
+// of the project by the values of multiple columns, see
+// [dbutils.ReconcileKeys].
+func reconcileProjectKeys[T any](ctx context.Context, kind, projectID string, idColumns []string, keys [][]any) error {
+	scope := map[string]any{
+		"project_id": projectID,
+	}
+
+	result, err := dbutils.ReconcileKeys[T](ctx, db.DB, scope, idColumns, keys)
+
+	return logReconcile(ctx, kind, projectID, result, err)
+}
+
+// logReconcile logs the result of reconciling the records of the given kind
+// in the given project, and returns the error of the reconciliation, if any.
+func logReconcile(ctx context.Context, kind, projectID string, result dbutils.ReconcileResult, err error) error {
+	logger := asynqutils.GetLogger(ctx)
+	if err != nil {
+		logger.Error(
+			"could not reconcile gcp resources",
+			"kind", kind,
+			"project", projectID,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"reconciled gcp resources",
+		"kind", kind,
+		"project", projectID,
+		"seen", result.Seen,
+		"deleted", result.Deleted,
+	)
+
+	return nil
+}
+
 // init registers our task handlers and periodic tasks with the registries.
 func init() {
 	// Task handlers
@@ -80,4 +155,12 @@ func init() {
 	registry.TaskRegistry.MustRegister(TaskCollectDisks, asynq.HandlerFunc(HandleCollectDisksTask))
 	registry.TaskRegistry.MustRegister(TaskCollectGKEClusters, asynq.HandlerFunc(HandleCollectGKEClusters))
 	registry.TaskRegistry.MustRegister(TaskCollectTargetPools, asynq.HandlerFunc(HandleCollectTargetPools))
+	registry.TaskRegistry.MustRegister(TaskCollectIAMBindings, asynq.HandlerFunc(HandleCollectIAMBindingsTask))
+	registry.TaskRegistry.MustRegister(TaskCollectCloudSQLInstances, asynq.HandlerFunc(HandleCollectCloudSQLInstancesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectFirewallRules, asynq.HandlerFunc(HandleCollectFirewallRulesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectSnapshots, asynq.HandlerFunc(HandleCollectSnapshotsTask))
+
+	// Collection ordering
+	registry.TaskGraph.MustAdd(TaskCollectAll)
+	registry.TaskGraph.MustAdd(TaskLinkAll, TaskCollectAll)
 }
@@ -13,9 +13,9 @@ import (
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 	"google.golang.org/api/iterator"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
 	"github.com/gardener/inventory/pkg/core/registry"
@@ -24,6 +24,7 @@ import (
 	"github.com/gardener/inventory/pkg/gcp/utils"
 	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -73,6 +74,8 @@ func HandleCollectVPCsTask(ctx context.Context, t *asynq.Task) error {
 func enqueueCollectVPCs(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshProjects(ctx)
+
 	if gcpclients.NetworksClientset.Length() == 0 {
 		logger.Warn(
 			"no gcp network clients configured. skipping task.",
@@ -82,7 +85,12 @@ func enqueueCollectVPCs(ctx context.Context) error {
 	}
 
 	queue := asynqutils.GetQueueName(ctx)
+	taskFns := make([]asynqutils.TaskConstructor, 0, gcpclients.NetworksClientset.Length())
 	err := gcpclients.NetworksClientset.Range(func(projectID string, _ *gcpclients.Client[*compute.NetworksClient]) error {
+		if !isProjectSelected(ctx, projectID) {
+			return nil
+		}
+
 		p := &CollectVPCsPayload{ProjectID: projectID}
 		data, err := json.Marshal(p)
 		if err != nil {
@@ -95,31 +103,18 @@ func enqueueCollectVPCs(ctx context.Context) error {
 			return registry.ErrContinue
 		}
 
-		task := asynq.NewTask(TaskCollectVPCs, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", projectID,
-				"reason", err,
-			)
-
-			return registry.ErrContinue
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", projectID,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectVPCs, data)
+		})
 
 		return nil
 	})
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectVPCs collects the GCP VPCs using the client configuration
@@ -186,22 +181,27 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 		items = append(items, item)
 	}
 
+	ids := make([]uint64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.VPCID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.VPC](ctx, "vpcs", payload.ProjectID, "vpc_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (vpc_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("creation_timestamp = EXCLUDED.creation_timestamp").
-		Set("description = EXCLUDED.description").
-		Set("gateway_ipv4 = EXCLUDED.gateway_ipv4").
-		Set("firewall_policy = EXCLUDED.firewall_policy").
-		Set("mtu = EXCLUDED.mtu").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	_, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (vpc_id, project_id) DO UPDATE").
+			Apply(dbutils.UpdateChanged(
+				"name",
+				"creation_timestamp",
+				"description",
+				"gateway_ipv4",
+				"firewall_policy",
+				"mtu",
+			)).
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -213,10 +213,9 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
+	// Unchanged rows are not updated, so the number of affected
+	// rows is not the number of collected rows.
+	count = int64(len(items))
 
 	logger.Info(
 		"populated gcp vpcs",
@@ -224,5 +223,5 @@ func collectVPCs(ctx context.Context, payload CollectVPCsPayload) error {
 		"count", count,
 	)
 
-	return nil
+	return reconcileProject[models.VPC](ctx, "vpcs", payload.ProjectID, "vpc_id", ids)
 }
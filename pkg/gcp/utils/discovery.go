@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+	"google.golang.org/api/iterator"
+
+	gcpclients "github.com/gardener/inventory/pkg/clients/gcp"
+)
+
+// DefaultProjectDiscoveryInterval specifies the default min interval between
+// discovering the projects of named credentials via [RefreshProjects].
+const DefaultProjectDiscoveryInterval = 5 * time.Minute
+
+// projectDiscovery tracks when the projects of each named credentials have
+// last been discovered by [RefreshProjects].
+var projectDiscovery = struct {
+	sync.Mutex
+	lastRun map[string]time.Time
+}{
+	lastRun: make(map[string]time.Time),
+}
+
+// ProjectDiscoveryResult provides the result of discovering the projects of
+// named credentials.
+type ProjectDiscoveryResult struct {
+	// Projects is the number of active projects, which are accessible
+	// with the named credentials.
+	Projects int
+
+	// Added is the number of projects, for which clients have been added.
+	Added int
+}
+
+// ListProjects returns the IDs of the active projects, which are accessible
+// with the given Resource Manager client.
+func ListProjects(ctx context.Context, client *resourcemanager.ProjectsClient) ([]string, error) {
+	req := &resourcemanagerpb.SearchProjectsRequest{
+		Query: "state:ACTIVE",
+	}
+
+	result := make([]string, 0)
+	it := client.SearchProjects(ctx, req)
+	for {
+		p, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p.ProjectId)
+	}
+
+	return result, nil
+}
+
+// DiscoverProjects discovers the active projects, which are accessible with
+// the named credentials of the given client, and configures the clients of
+// each of the client services for them.
+//
+// Clients are configured only for projects, which are not known yet to the
+// respective service.
+func DiscoverProjects(ctx context.Context, dc *gcpclients.DiscoveryClient) (ProjectDiscoveryResult, error) {
+	var result ProjectDiscoveryResult
+
+	projectIDs, err := ListProjects(ctx, dc.Client)
+	if err != nil {
+		return result, fmt.Errorf("cannot list projects for credentials %s: %w", dc.NamedCredentials, err)
+	}
+	result.Projects = len(projectIDs)
+
+	for _, projectID := range projectIDs {
+		added := false
+		for _, svc := range dc.Services {
+			if svc.Exists(projectID) {
+				continue
+			}
+			if err := svc.Configure(ctx, projectID); err != nil {
+				return result, fmt.Errorf("unable to configure %s clients for project %s with credentials %s: %w", svc.Name, projectID, dc.NamedCredentials, err)
+			}
+			added = true
+		}
+
+		if added {
+			result.Added++
+		}
+	}
+
+	return result, nil
+}
+
+// RefreshProjects discovers the projects of all registered discovery clients
+// via [DiscoverProjects], so that newly created projects are picked up without
+// restarting the worker. The projects of each named credentials are discovered
+// at most once per [DefaultProjectDiscoveryInterval].
+func RefreshProjects(ctx context.Context) error {
+	errs := make([]error, 0)
+	_ = gcpclients.DiscoveryClientset.Range(func(_ string, dc *gcpclients.DiscoveryClient) error {
+		if err := refreshProjects(ctx, dc); err != nil {
+			errs = append(errs, err)
+		}
+
+		return nil
+	})
+
+	return errors.Join(errs...)
+}
+
+// refreshProjects discovers the projects of the given discovery client, unless
+// they have been discovered within the last [DefaultProjectDiscoveryInterval].
+func refreshProjects(ctx context.Context, dc *gcpclients.DiscoveryClient) error {
+	name := dc.NamedCredentials
+	projectDiscovery.Lock()
+	lastRun := projectDiscovery.lastRun[name]
+	if time.Since(lastRun) < DefaultProjectDiscoveryInterval {
+		projectDiscovery.Unlock()
+
+		return nil
+	}
+	projectDiscovery.lastRun[name] = time.Now()
+	projectDiscovery.Unlock()
+
+	result, err := DiscoverProjects(ctx, dc)
+	if err != nil {
+		// Retry on the next refresh
+		projectDiscovery.Lock()
+		projectDiscovery.lastRun[name] = lastRun
+		projectDiscovery.Unlock()
+
+		return err
+	}
+
+	slog.Info(
+		"discovered GCP projects",
+		"credentials", name,
+		"projects", result.Projects,
+		"added", result.Added,
+	)
+
+	return nil
+}
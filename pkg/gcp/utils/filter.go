@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gardener/inventory/pkg/gcp/constants"
+)
+
+// ErrInvalidFilter is an error, which is returned when a [Filter] contains
+// constraints, which cannot be used for filtering resources.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+var (
+	// regionPattern matches GCP region names, e.g. europe-west1.
+	regionPattern = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+$`)
+
+	// labelKeyPattern matches GCP label keys.
+	labelKeyPattern = regexp.MustCompile(`^[\p{Ll}\p{Lo}][\p{Ll}\p{Lo}\p{N}_-]{0,62}$`)
+
+	// labelValuePattern matches GCP label values.
+	labelValuePattern = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]{0,63}$`)
+)
+
+// Filter represents the region and label constraints, which are used for
+// filtering resources when listing them via the GCP Compute Engine API.
+type Filter struct {
+	// Regions specifies the regions from which to list resources. If
+	// empty, resources from all regions are listed.
+	Regions []string
+
+	// Labels specifies the labels, which resources must have.
+	Labels map[string]string
+}
+
+// IsEmpty returns true, if the filter has no constraints.
+func (f Filter) IsEmpty() bool {
+	return len(f.Regions) == 0 && len(f.Labels) == 0
+}
+
+// Validate verifies that the filter constraints are valid region names and
+// label keys and values, so that the resulting filter expression is accepted
+// by the API.
+func (f Filter) Validate() error {
+	for _, region := range f.Regions {
+		if !regionPattern.MatchString(region) {
+			return fmt.Errorf("%w: invalid region %q", ErrInvalidFilter, region)
+		}
+	}
+
+	for k, v := range f.Labels {
+		if !labelKeyPattern.MatchString(k) {
+			return fmt.Errorf("%w: invalid label key %q", ErrInvalidFilter, k)
+		}
+		if !labelValuePattern.MatchString(v) {
+			return fmt.Errorf("%w: invalid label value %q for key %q", ErrInvalidFilter, v, k)
+		}
+	}
+
+	return nil
+}
+
+// Expression returns the filter expression, which can be used as the `filter'
+// parameter of the API list calls. The location field specifies the field of
+// the resource, which is matched against the regions, e.g. `zone' for zonal
+// resources, or `region' for regional resources. If the location field is
+// empty, the region constraints are not part of the expression, and should be
+// applied client-side via [Filter.Matches] instead.
+//
+// The expression uses the regular expression syntax, which allows for matching
+// multiple regions with a single expression. The filter is expected to be
+// validated via [Filter.Validate] beforehand.
+func (f Filter) Expression(locationField string) string {
+	exprs := make([]string, 0)
+	if len(f.Regions) > 0 && locationField != "" {
+		regions := strings.Join(f.sortedRegions(), "|")
+		var pattern string
+		switch locationField {
+		case "zone":
+			pattern = fmt.Sprintf(".*/%s(%s)-[a-z]+", constants.ZonesPrefix, regions)
+		default:
+			pattern = fmt.Sprintf(".*/%s(%s)", constants.RegionsPrefix, regions)
+		}
+		exprs = append(exprs, fmt.Sprintf("(%s eq %q)", locationField, pattern))
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(f.Labels)) {
+		exprs = append(exprs, fmt.Sprintf("(labels.%s eq %q)", k, regexp.QuoteMeta(f.Labels[k])))
+	}
+
+	return strings.Join(exprs, " ")
+}
+
+// Matches returns true, if a resource from the given region and with the given
+// labels satisfies the filter constraints.
+func (f Filter) Matches(region string, labels map[string]string) bool {
+	if len(f.Regions) > 0 && !slices.Contains(f.Regions, region) {
+		return false
+	}
+
+	for k, v := range f.Labels {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortedRegions returns the regions of the filter in sorted order.
+func (f Filter) sortedRegions() []string {
+	regions := slices.Clone(f.Regions)
+	slices.Sort(regions)
+
+	return slices.Compact(regions)
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gardener/inventory/pkg/gcp/utils"
+)
+
+func TestFilterValidate(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		filter  utils.Filter
+		wantErr bool
+	}{
+		{
+			desc:    "empty filter",
+			filter:  utils.Filter{},
+			wantErr: false,
+		},
+		{
+			desc: "valid regions and labels",
+			filter: utils.Filter{
+				Regions: []string{"europe-west1", "northamerica-northeast1"},
+				Labels:  map[string]string{"env": "prod", "k8s-cluster-name": ""},
+			},
+			wantErr: false,
+		},
+		{
+			desc:    "invalid region",
+			filter:  utils.Filter{Regions: []string{"europe-west1-b"}},
+			wantErr: true,
+		},
+		{
+			desc:    "label key with uppercase letters",
+			filter:  utils.Filter{Labels: map[string]string{"Env": "prod"}},
+			wantErr: true,
+		},
+		{
+			desc:    "label value with quotes",
+			filter:  utils.Filter{Labels: map[string]string{"env": `prod") OR ("`}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.filter.Validate()
+			if tc.wantErr && !errors.Is(err, utils.ErrInvalidFilter) {
+				t.Fatalf("want ErrInvalidFilter, got %v", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFilterExpression(t *testing.T) {
+	filter := utils.Filter{
+		Regions: []string{"us-central1", "europe-west1", "us-central1"},
+		Labels:  map[string]string{"team": "a-b", "env": "prod"},
+	}
+
+	testCases := []struct {
+		desc          string
+		filter        utils.Filter
+		locationField string
+		wanted        string
+	}{
+		{
+			desc:          "empty filter",
+			filter:        utils.Filter{},
+			locationField: "zone",
+			wanted:        "",
+		},
+		{
+			desc:          "zonal resources",
+			filter:        filter,
+			locationField: "zone",
+			wanted:        `(zone eq ".*/zones/(europe-west1|us-central1)-[a-z]+") (labels.env eq "prod") (labels.team eq "a-b")`,
+		},
+		{
+			desc:          "regional resources",
+			filter:        filter,
+			locationField: "region",
+			wanted:        `(region eq ".*/regions/(europe-west1|us-central1)") (labels.env eq "prod") (labels.team eq "a-b")`,
+		},
+		{
+			desc:          "regions filtered client-side",
+			filter:        filter,
+			locationField: "",
+			wanted:        `(labels.env eq "prod") (labels.team eq "a-b")`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			output := tc.filter.Expression(tc.locationField)
+			if output != tc.wanted {
+				t.Fatalf("wanted %s got %s", tc.wanted, output)
+			}
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	filter := utils.Filter{
+		Regions: []string{"europe-west1"},
+		Labels:  map[string]string{"env": "prod"},
+	}
+
+	testCases := []struct {
+		desc   string
+		region string
+		labels map[string]string
+		wanted bool
+	}{
+		{
+			desc:   "matching region and labels",
+			region: "europe-west1",
+			labels: map[string]string{"env": "prod", "team": "a"},
+			wanted: true,
+		},
+		{
+			desc:   "different region",
+			region: "us-central1",
+			labels: map[string]string{"env": "prod"},
+			wanted: false,
+		},
+		{
+			desc:   "missing label",
+			region: "europe-west1",
+			labels: nil,
+			wanted: false,
+		},
+		{
+			desc:   "different label value",
+			region: "europe-west1",
+			labels: map[string]string{"env": "dev"},
+			wanted: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := filter.Matches(tc.region, tc.labels); got != tc.wanted {
+				t.Fatalf("wanted %t got %t", tc.wanted, got)
+			}
+		})
+	}
+}
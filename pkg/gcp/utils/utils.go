@@ -77,6 +77,30 @@ func ResourceNameFromURL(s string) string {
 	return parts[len(parts)-1]
 }
 
+// SegmentFromURL returns the path segment of the specified URL, which follows
+// the given collection, e.g. the zone name of a disk URL for the `zones'
+// collection. If the URL does not contain the collection an empty string is
+// returned.
+//
+// See [Resource Names] for more details.
+//
+// [Resource Names]: https://cloud.google.com/apis/design/resource_names
+func SegmentFromURL(s string, collection string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+
+	parts := strings.Split(u.Path, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == collection {
+			return parts[i+1]
+		}
+	}
+
+	return ""
+}
+
 // GetGKEClusterFromDB returns the [models.GKECluster] with the given name by
 // looking up the database.
 func GetGKEClusterFromDB(ctx context.Context, name string) (models.GKECluster, error) {
@@ -194,3 +194,52 @@ func TestResourceNameFromURL(t *testing.T) {
 		})
 	}
 }
+
+func TestSegmentFromURL(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		input      string
+		collection string
+		wanted     string
+	}{
+		{
+			desc:       "zone of zonal disk",
+			input:      "https://www.googleapis.com/compute/v1/projects/p1/zones/europe-west1-b/disks/d1",
+			collection: "zones",
+			wanted:     "europe-west1-b",
+		},
+		{
+			desc:       "project of zonal disk",
+			input:      "https://www.googleapis.com/compute/v1/projects/p1/zones/europe-west1-b/disks/d1",
+			collection: "projects",
+			wanted:     "p1",
+		},
+		{
+			desc:       "zone of regional disk",
+			input:      "https://www.googleapis.com/compute/v1/projects/p1/regions/europe-west1/disks/d1",
+			collection: "zones",
+			wanted:     "",
+		},
+		{
+			desc:       "collection as last segment",
+			input:      "projects/p1/zones",
+			collection: "zones",
+			wanted:     "",
+		},
+		{
+			desc:       "empty input",
+			input:      "",
+			collection: "zones",
+			wanted:     "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			output := utils.SegmentFromURL(tc.input, tc.collection)
+			if output != tc.wanted {
+				t.Fatalf("wanted %q got %q", tc.wanted, output)
+			}
+		})
+	}
+}
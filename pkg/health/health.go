@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package health provides the HTTP handlers for the liveness and readiness
+// probes of the Inventory services.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	// StatusOK is the status of a healthy check.
+	StatusOK = "ok"
+
+	// StatusFailed is the status of a failed check.
+	StatusFailed = "failed"
+
+	// DefaultTimeout is the default timeout for running the checks.
+	DefaultTimeout = 5 * time.Second
+)
+
+// CheckFunc checks the health of a dependency, e.g. the database.
+type CheckFunc func(ctx context.Context) error
+
+// LastCollectionsFunc returns the time of the last successful collection per
+// provider.
+type LastCollectionsFunc func(ctx context.Context) (map[string]time.Time, error)
+
+// Response is the response of the health handlers.
+type Response struct {
+	// Status is the overall status, which is [StatusOK] only if all checks
+	// are healthy.
+	Status string `json:"status"`
+
+	// Checks provides the status of each check.
+	Checks map[string]string `json:"checks"`
+
+	// LastCollections provides the time of the last successful collection
+	// per provider, if available.
+	LastCollections map[string]time.Time `json:"last_collections,omitempty"`
+}
+
+// Handler serves the liveness and readiness probes.
+type Handler struct {
+	checks          map[string]CheckFunc
+	lastCollections LastCollectionsFunc
+	timeout         time.Duration
+}
+
+// NewHandler creates a new [Handler], which runs the given named checks with
+// the given timeout. If timeout is not positive, [DefaultTimeout] is used.
+func NewHandler(checks map[string]CheckFunc, timeout time.Duration) *Handler {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	h := &Handler{
+		checks:  checks,
+		timeout: timeout,
+	}
+
+	return h
+}
+
+// WithLastCollections configures the function, which reports the last
+// successful collection time per provider in the readiness response.
+func (h *Handler) WithLastCollections(fn LastCollectionsFunc) *Handler {
+	h.lastCollections = fn
+
+	return h
+}
+
+// Register registers the `GET /healthz' and `GET /readyz' endpoints with the
+// given mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.Healthz)
+	mux.HandleFunc("GET /readyz", h.Readyz)
+}
+
+// Healthz responds with 200, if all checks are healthy, and 503 otherwise.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	writeResponse(w, h.check(ctx))
+}
+
+// Readyz responds like [Handler.Healthz], and additionally reports the time
+// of the last successful collection per provider, if configured. Failing to
+// get the last collections does not affect the readiness.
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	resp := h.check(ctx)
+	if h.lastCollections != nil && resp.Status == StatusOK {
+		items, err := h.lastCollections(ctx)
+		if err != nil {
+			slog.Warn("failed to get last collections", "reason", err)
+		}
+		resp.LastCollections = items
+	}
+
+	writeResponse(w, resp)
+}
+
+// check runs the checks in the order of their names.
+func (h *Handler) check(ctx context.Context) Response {
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp := Response{
+		Status: StatusOK,
+		Checks: make(map[string]string, len(names)),
+	}
+	for _, name := range names {
+		if err := h.checks[name](ctx); err != nil {
+			slog.Warn("health check failed", "check", name, "reason", err)
+			resp.Status = StatusFailed
+			resp.Checks[name] = StatusFailed
+
+			continue
+		}
+		resp.Checks[name] = StatusOK
+	}
+
+	return resp
+}
+
+// writeResponse writes the given response as JSON.
+func writeResponse(w http.ResponseWriter, resp Response) {
+	code := http.StatusOK
+	if resp.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -80,6 +80,28 @@ func (c *Collector) AddMetric(key string, metric prometheus.Metric) {
 	c.reg.Overwrite(key, metric)
 }
 
+// DeleteMetrics removes the metrics, whose `idempotency key' starts with the
+// given prefix, from the [Collector].
+//
+// Tasks, which report metrics for a varying set of label values, should delete
+// their previously added metrics before adding the new ones, so that label
+// values, which are no longer reported, do not keep their last value until the
+// next scrape.
+func (c *Collector) DeleteMetrics(prefix string) {
+	keys := make([]string, 0)
+	_ = c.reg.Range(func(k string, _ prometheus.Metric) error {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+
+		return nil
+	})
+
+	for _, k := range keys {
+		c.reg.Unregister(k)
+	}
+}
+
 // Describe implements the [prometheus.Collector] interface.
 func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.mu.Lock()
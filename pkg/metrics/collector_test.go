@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/inventory/pkg/metrics"
+)
+
+func TestCollectorDeleteMetrics(t *testing.T) {
+	desc := prometheus.NewDesc("test_metric", "Test metric", []string{"model", "scope"}, nil)
+	keys := [][]string{
+		{"model-a", "scope-1"},
+		{"model-a", "scope-2"},
+		{"model-ab", "scope-1"},
+		{"model-b", "scope-1"},
+	}
+
+	testCases := []struct {
+		desc   string
+		prefix string
+		wanted int
+	}{
+		{
+			desc:   "delete metrics of a model",
+			prefix: metrics.Key("task", "model-a") + "/",
+			wanted: 2,
+		},
+		{
+			desc:   "delete all metrics of a task",
+			prefix: metrics.Key("task") + "/",
+			wanted: 0,
+		},
+		{
+			desc:   "unknown prefix",
+			prefix: metrics.Key("other") + "/",
+			wanted: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			collector := metrics.NewCollector()
+			collector.AddDesc(desc)
+			for _, key := range keys {
+				metric := prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, key...)
+				collector.AddMetric(metrics.Key("task", key...), metric)
+			}
+
+			collector.DeleteMetrics(tc.prefix)
+			if got := testutil.CollectAndCount(collector, "test_metric"); got != tc.wanted {
+				t.Fatalf("want %d metrics, got %d", tc.wanted, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	auxutils "github.com/gardener/inventory/pkg/auxiliary/utils"
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+// DefaultDBCollectorTimeout is the default timeout for the queries executed by
+// the [DBCollector] during scraping.
+const DefaultDBCollectorTimeout = 10 * time.Second
+
+// tableEstimatedRowsDesc is the descriptor for the metric, which reports the
+// estimated number of rows of the tables of the registered models.
+var tableEstimatedRowsDesc = prometheus.NewDesc(
+	"table_estimated_rows",
+	"Estimated number of rows of the table of a registered model",
+	[]string{"model", "table"},
+	nil,
+)
+
+// lastCollectionTimestampDesc is the descriptor for the metric, which reports
+// the time of the last successful collection of a task type for a provider,
+// account and region.
+var lastCollectionTimestampDesc = prometheus.NewDesc(
+	"last_collection_timestamp_seconds",
+	"Unix time of the last successful collection per provider, account, region and task",
+	[]string{"provider", "account", "region", "task_name"},
+	nil,
+)
+
+// DBCollector is an implementation of the [prometheus.Collector] interface,
+// which reports the estimated number of rows for the tables of the models
+// registered in [registry.ModelRegistry], and the time of the last successful
+// collections from the persisted task results.
+//
+// The estimates are read from the statistics of the database, and don't
+// require scanning the tables, so that scraping the collector is cheap.
+type DBCollector struct {
+	db      *bun.DB
+	timeout time.Duration
+}
+
+var _ prometheus.Collector = &DBCollector{}
+
+// NewDBCollector creates a new [DBCollector], which uses the given database and
+// query timeout. If the timeout is not positive, [DefaultDBCollectorTimeout] is
+// used.
+func NewDBCollector(db *bun.DB, timeout time.Duration) *DBCollector {
+	if timeout <= 0 {
+		timeout = DefaultDBCollectorTimeout
+	}
+
+	c := &DBCollector{
+		db:      db,
+		timeout: timeout,
+	}
+
+	return c
+}
+
+// Describe implements the [prometheus.Collector] interface.
+func (c *DBCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tableEstimatedRowsDesc
+	ch <- lastCollectionTimestampDesc
+}
+
+// Collect implements the [prometheus.Collector] interface.
+func (c *DBCollector) Collect(ch chan<- prometheus.Metric) {
+	tableModels := make(map[string]string)
+	walker := func(name string, model any) error {
+		table := c.db.Table(reflect.TypeOf(model))
+		tableModels[table.Name] = name
+
+		return nil
+	}
+
+	_ = registry.ModelRegistry.Range(walker)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	c.collectLastCollections(ctx, ch)
+
+	var items []struct {
+		Table string `bun:"relname"`
+		Rows  int64  `bun:"n_live_tup"`
+	}
+
+	err := c.db.NewSelect().
+		TableExpr("pg_stat_user_tables").
+		Column("relname", "n_live_tup").
+		Where("schemaname = current_schema()").
+		Scan(ctx, &items)
+
+	if err != nil {
+		slog.Error("cannot collect table statistics", "reason", err)
+		return
+	}
+
+	for _, item := range items {
+		model, ok := tableModels[item.Table]
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			tableEstimatedRowsDesc,
+			prometheus.GaugeValue,
+			float64(item.Rows),
+			model,
+			item.Table,
+		)
+	}
+}
+
+// collectLastCollections reports the time of the last successful collections.
+func (c *DBCollector) collectLastCollections(ctx context.Context, ch chan<- prometheus.Metric) {
+	items, err := auxutils.LastCollections(ctx, c.db)
+	if err != nil {
+		slog.Error("cannot collect last collections", "reason", err)
+		return
+	}
+
+	for _, item := range items {
+		ch <- prometheus.MustNewConstMetric(
+			lastCollectionTimestampDesc,
+			prometheus.GaugeValue,
+			float64(item.CompletedAt.Unix()),
+			item.Provider,
+			item.Account,
+			item.Region,
+			item.TaskName,
+		)
+	}
+}
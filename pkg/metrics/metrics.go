@@ -15,8 +15,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// Namespace is the namespace component of the fully qualified metric name
-const Namespace = "inventory"
+// DefaultNamespace is the default namespace component of the fully qualified
+// metric names.
+//
+// Metrics are defined without a namespace, which is applied along with any
+// constant labels, when the metrics are registered via [Register].
+const DefaultNamespace = "inventory"
 
 // DefaultRegistry is the default [prometheus.Registry] for metrics.
 var DefaultRegistry = prometheus.NewPedanticRegistry()
@@ -26,9 +30,8 @@ var (
 	// task has been successfully executed.
 	TaskSuccessfulTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
-			Namespace: Namespace,
-			Name:      "task_successful_total",
-			Help:      "Total number of times a task has been successfully executed",
+			Name: "task_successful_total",
+			Help: "Total number of times a task has been successfully executed",
 		},
 		[]string{"task_name", "task_queue"},
 	)
@@ -37,9 +40,8 @@ var (
 	// has failed.
 	TaskFailedTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
-			Namespace: Namespace,
-			Name:      "task_failed_total",
-			Help:      "Total number of times a task has failed",
+			Name: "task_failed_total",
+			Help: "Total number of times a task has failed",
 		},
 		[]string{"task_name", "task_queue"},
 	)
@@ -48,24 +50,86 @@ var (
 	// has failed and will be skipped from being retried.
 	TaskSkippedTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
-			Namespace: Namespace,
-			Name:      "task_skipped_total",
-			Help:      "Total number of times a task has been skipped from being retried",
+			Name: "task_skipped_total",
+			Help: "Total number of times a task has been skipped from being retried",
 		},
 		[]string{"task_name", "task_queue"},
 	)
 
+	// TaskErrorsTotal is a metric, which gets incremented each time a task
+	// has failed, labelled by the reason of the failure.
+	TaskErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "task_errors_total",
+			Help: "Total number of task errors by reason",
+		},
+		[]string{"task_type", "reason"},
+	)
+
 	// TaskDurationSeconds is a metric, which tracks the duration of task
 	// execution in seconds.
 	TaskDurationSeconds = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
-			Namespace: Namespace,
-			Name:      "task_duration_seconds",
-			Help:      "Duration of task execution in seconds",
-			Buckets:   []float64{1.0, 10.0, 30.0, 60.0, 120.0},
+			Name:    "task_duration_seconds",
+			Help:    "Duration of task execution in seconds",
+			Buckets: []float64{1.0, 10.0, 30.0, 60.0, 120.0},
 		},
 		[]string{"task_name", "task_queue"},
 	)
+
+	// CollectionDurationSeconds is a metric, which tracks the duration of
+	// successful collection tasks in seconds. The buckets span from 100ms
+	// up to 10m, since collecting some regions takes a while.
+	CollectionDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "collection_duration_seconds",
+			Help:    "Duration of collection tasks in seconds",
+			Buckets: prometheus.ExponentialBucketsRange(0.1, 600.0, 12),
+		},
+		[]string{"task_type", "provider"},
+	)
+
+	// UnexpectedZeroRowsTotal is a metric, which gets incremented each
+	// time a collection returns zero rows for a scope, which previously
+	// returned a non-zero number of rows.
+	UnexpectedZeroRowsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "unexpected_zero_rows_total",
+			Help: "Total number of times a collection unexpectedly returned zero rows",
+		},
+		[]string{"task_name", "scope"},
+	)
+
+	// EnqueueRetriesTotal is a metric, which gets incremented each time
+	// enqueueing a task is retried after a transient Redis connection
+	// error.
+	EnqueueRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "enqueue_retries_total",
+			Help: "Total number of times enqueueing a task has been retried",
+		},
+		[]string{"task_name"},
+	)
+
+	// LinkDeadlocksTotal is a metric, which gets incremented each time a
+	// link function is aborted by the database because of a deadlock.
+	LinkDeadlocksTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "link_deadlocks_total",
+			Help: "Total number of times a link function has been aborted because of a deadlock",
+		},
+		[]string{"link_type"},
+	)
+
+	// LinksTotal is a metric, which reports the number of links
+	// established by the last successful run of a link function.
+	LinksTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "links_total",
+			Help: "Number of links established by the last run of a link function",
+		},
+		[]string{"link_type"},
+	)
 )
 
 // NewServer returns a new [http.Server] which can serve the metrics from
@@ -88,18 +152,51 @@ func NewServer(ctx context.Context, addr, path string) *http.Server {
 	return server
 }
 
-// init registers collectors with the [DefaultRegistry].
-func init() {
-	DefaultRegistry.MustRegister(
-		// Inventory metrics
+// Register registers the collectors with the [DefaultRegistry].
+//
+// The given namespace is used as the namespace component of the fully qualified
+// names of the Inventory metrics. If the namespace is empty, [DefaultNamespace]
+// is used. The given labels are added as constant labels to all metrics,
+// including the standard Go and process metrics.
+func Register(namespace string, labels map[string]string) error {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	reg := prometheus.WrapRegistererWith(prometheus.Labels(labels), DefaultRegistry)
+	nsReg := prometheus.WrapRegistererWithPrefix(namespace+"_", reg)
+
+	inventoryCollectors := []prometheus.Collector{
 		TaskSuccessfulTotal,
 		TaskFailedTotal,
 		TaskSkippedTotal,
+		TaskErrorsTotal,
 		TaskDurationSeconds,
+		CollectionDurationSeconds,
+		UnexpectedZeroRowsTotal,
+		EnqueueRetriesTotal,
+		LinkDeadlocksTotal,
+		LinksTotal,
 		DefaultCollector,
+	}
 
-		// Standard Go metrics
+	for _, c := range inventoryCollectors {
+		if err := nsReg.Register(c); err != nil {
+			return err
+		}
+	}
+
+	// Standard Go metrics
+	standardCollectors := []prometheus.Collector{
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 		collectors.NewGoCollector(),
-	)
+	}
+
+	for _, c := range standardCollectors {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
+	"github.com/gardener/inventory/pkg/core/globalid"
 	coremodels "github.com/gardener/inventory/pkg/core/models"
 	"github.com/gardener/inventory/pkg/core/registry"
 )
@@ -34,16 +35,32 @@ const (
 	ContainerModelName            = "openstack:model:container"
 	ObjectModelName               = "openstack:model:object"
 	VolumeModelName               = "openstack:model:volume"
-
-	SubnetToNetworkModelName       = "openstack:model:link_subnet_to_network"
-	SubnetToProjectModelName       = "openstack:model:link_subnet_to_project"
-	ServerToProjectModelName       = "openstack:model:link_server_to_project"
-	ServerToNetworkModelName       = "openstack:model:link_server_to_network"
-	LoadBalancerToSubnetModelName  = "openstack:model:link_loadbalancer_to_subnet"
-	LoadBalancerToNetworkModelName = "openstack:model:link_loadbalancer_to_network"
-	LoadBalancerToProjectModelName = "openstack:model:link_loadbalancer_to_project"
-	NetworkToProjectModelName      = "openstack:model:link_network_to_project"
-	PortToServerModelName          = "openstack:model:link_server_to_port"
+	ShareModelName                = "openstack:model:share"
+	ShareExportLocationModelName  = "openstack:model:share_export_location"
+	ShareNetworkModelName         = "openstack:model:share_network"
+	SecurityGroupModelName        = "openstack:model:security_group"
+	SecurityGroupRuleModelName    = "openstack:model:security_group_rule"
+	QuotaUsageSampleModelName     = "openstack:model:quota_usage_sample"
+	ExposureFindingModelName      = "openstack:model:exposure_finding"
+	ImageModelName                = "openstack:model:image"
+
+	SubnetToNetworkModelName          = "openstack:model:link_subnet_to_network"
+	SubnetToProjectModelName          = "openstack:model:link_subnet_to_project"
+	ServerToProjectModelName          = "openstack:model:link_server_to_project"
+	ServerToNetworkModelName          = "openstack:model:link_server_to_network"
+	LoadBalancerToSubnetModelName     = "openstack:model:link_loadbalancer_to_subnet"
+	LoadBalancerToNetworkModelName    = "openstack:model:link_loadbalancer_to_network"
+	LoadBalancerToProjectModelName    = "openstack:model:link_loadbalancer_to_project"
+	NetworkToProjectModelName         = "openstack:model:link_network_to_project"
+	PortToServerModelName             = "openstack:model:link_server_to_port"
+	ShareToShareNetworkModelName      = "openstack:model:link_share_to_share_network"
+	SecurityGroupRuleToGroupModelName = "openstack:model:link_security_group_rule_to_group"
+	PortToSecurityGroupModelName      = "openstack:model:link_port_to_security_group"
+	FloatingIPToServerModelName       = "openstack:model:link_floating_ip_to_server"
+	FloatingIPToRouterModelName       = "openstack:model:link_floating_ip_to_router"
+	FloatingIPToNetworkModelName      = "openstack:model:link_floating_ip_to_network"
+	VolumeToServerModelName           = "openstack:model:link_volume_to_server"
+	ServerToImageModelName            = "openstack:model:link_server_to_image"
 )
 
 // models specifies the mapping between name and model type, which will be
@@ -65,17 +82,33 @@ var models = map[string]any{
 	ContainerModelName:            &Container{},
 	ObjectModelName:               &Object{},
 	VolumeModelName:               &Volume{},
+	ShareModelName:                &Share{},
+	ShareExportLocationModelName:  &ShareExportLocation{},
+	ShareNetworkModelName:         &ShareNetwork{},
+	SecurityGroupModelName:        &SecurityGroup{},
+	SecurityGroupRuleModelName:    &SecurityGroupRule{},
+	QuotaUsageSampleModelName:     &QuotaUsageSample{},
+	ExposureFindingModelName:      &ExposureFinding{},
+	ImageModelName:                &Image{},
 
 	// Link models
-	SubnetToNetworkModelName:       &SubnetToNetwork{},
-	SubnetToProjectModelName:       &SubnetToProject{},
-	ServerToProjectModelName:       &ServerToProject{},
-	ServerToNetworkModelName:       &ServerToNetwork{},
-	LoadBalancerToSubnetModelName:  &LoadBalancerToSubnet{},
-	LoadBalancerToNetworkModelName: &LoadBalancerToNetwork{},
-	LoadBalancerToProjectModelName: &LoadBalancerToProject{},
-	NetworkToProjectModelName:      &NetworkToProject{},
-	PortToServerModelName:          &PortToServer{},
+	SubnetToNetworkModelName:          &SubnetToNetwork{},
+	SubnetToProjectModelName:          &SubnetToProject{},
+	ServerToProjectModelName:          &ServerToProject{},
+	ServerToNetworkModelName:          &ServerToNetwork{},
+	LoadBalancerToSubnetModelName:     &LoadBalancerToSubnet{},
+	LoadBalancerToNetworkModelName:    &LoadBalancerToNetwork{},
+	LoadBalancerToProjectModelName:    &LoadBalancerToProject{},
+	NetworkToProjectModelName:         &NetworkToProject{},
+	PortToServerModelName:             &PortToServer{},
+	ShareToShareNetworkModelName:      &ShareToShareNetwork{},
+	SecurityGroupRuleToGroupModelName: &SecurityGroupRuleToGroup{},
+	PortToSecurityGroupModelName:      &PortToSecurityGroup{},
+	FloatingIPToServerModelName:       &FloatingIPToServer{},
+	FloatingIPToRouterModelName:       &FloatingIPToRouter{},
+	FloatingIPToNetworkModelName:      &FloatingIPToNetwork{},
+	VolumeToServerModelName:           &VolumeToServer{},
+	ServerToImageModelName:            &ServerToImage{},
 }
 
 // Server represents an OpenStack Server.
@@ -92,8 +125,11 @@ type Server struct {
 	AvailabilityZone string    `bun:"availability_zone,notnull"`
 	Status           string    `bun:"status,notnull"`
 	ImageID          string    `bun:"image_id,notnull"`
+	FlavorID         string    `bun:"flavor_id,notnull"`
+	FlavorName       string    `bun:"flavor_name,notnull"`
 	TimeCreated      time.Time `bun:"server_created_at,notnull"`
 	TimeUpdated      time.Time `bun:"server_updated_at,notnull"`
+	GlobalID         string    `bun:"global_id,nullzero"`
 	Project          *Project  `bun:"rel:has-one,join:project_id=project_id"`
 }
 
@@ -102,18 +138,20 @@ type Network struct {
 	bun.BaseModel `bun:"table:openstack_network"`
 	coremodels.Model
 
-	NetworkID   string    `bun:"network_id,notnull,unique:openstack_network_key"`
-	Name        string    `bun:"name,notnull"`
-	ProjectID   string    `bun:"project_id,notnull,unique:openstack_network_key"`
-	Domain      string    `bun:"domain,notnull"`
-	Region      string    `bun:"region,notnull"`
-	Status      string    `bun:"status,notnull"`
-	Shared      bool      `bun:"shared,notnull"`
-	Description string    `bun:"description,notnull"`
-	TimeCreated time.Time `bun:"network_created_at,notnull"`
-	TimeUpdated time.Time `bun:"network_updated_at,notnull"`
-	Subnets     []*Subnet `bun:"rel:has-many,join:network_id=network_id,join:project_id=project_id"`
-	Project     *Project  `bun:"rel:has-one,join:project_id=project_id"`
+	NetworkID   string            `bun:"network_id,notnull,unique:openstack_network_key"`
+	Name        string            `bun:"name,notnull"`
+	ProjectID   string            `bun:"project_id,notnull,unique:openstack_network_key"`
+	Domain      string            `bun:"domain,notnull"`
+	Region      string            `bun:"region,notnull"`
+	Status      string            `bun:"status,notnull"`
+	Shared      bool              `bun:"shared,notnull"`
+	External    bool              `bun:"external,notnull"`
+	Description string            `bun:"description,notnull"`
+	TimeCreated time.Time         `bun:"network_created_at,notnull"`
+	TimeUpdated time.Time         `bun:"network_updated_at,notnull"`
+	Tags        map[string]string `bun:"tags,type:jsonb,notnull"`
+	Subnets     []*Subnet         `bun:"rel:has-many,join:network_id=network_id,join:project_id=project_id"`
+	Project     *Project          `bun:"rel:has-one,join:project_id=project_id"`
 }
 
 // LoadBalancer represents an OpenStack LoadBalancer.
@@ -121,22 +159,24 @@ type LoadBalancer struct {
 	bun.BaseModel `bun:"table:openstack_loadbalancer"`
 	coremodels.Model
 
-	LoadBalancerID string    `bun:"loadbalancer_id,notnull,unique:openstack_loadbalancer_key"`
-	Name           string    `bun:"name,notnull"`
-	ProjectID      string    `bun:"project_id,notnull,unique:openstack_loadbalancer_key"`
-	Domain         string    `bun:"domain,notnull"`
-	Region         string    `bun:"region,notnull"`
-	Status         string    `bun:"status,notnull"`
-	Provider       string    `bun:"provider,notnull"`
-	VipAddress     string    `bun:"vip_address,notnull"`
-	VipNetworkID   string    `bun:"vip_network_id,notnull"`
-	VipSubnetID    string    `bun:"vip_subnet_id,notnull"`
-	Description    string    `bun:"description,notnull"`
-	TimeCreated    time.Time `bun:"loadbalancer_created_at,notnull"`
-	TimeUpdated    time.Time `bun:"loadbalancer_updated_at,notnull"`
-	Subnet         *Subnet   `bun:"rel:has-one,join:vip_subnet_id=subnet_id,join:project_id=project_id"`
-	Project        *Project  `bun:"rel:has-one,join:project_id=project_id"`
-	Network        *Network  `bun:"rel:has-one,join:vip_network_id=network_id,join:project_id=project_id"`
+	LoadBalancerID     string    `bun:"loadbalancer_id,notnull,unique:openstack_loadbalancer_key"`
+	Name               string    `bun:"name,notnull"`
+	ProjectID          string    `bun:"project_id,notnull,unique:openstack_loadbalancer_key"`
+	Domain             string    `bun:"domain,notnull"`
+	Region             string    `bun:"region,notnull"`
+	Status             string    `bun:"status,notnull"`
+	ProvisioningStatus string    `bun:"provisioning_status,notnull"`
+	Provider           string    `bun:"provider,notnull"`
+	VipAddress         string    `bun:"vip_address,notnull"`
+	VipNetworkID       string    `bun:"vip_network_id,notnull"`
+	VipSubnetID        string    `bun:"vip_subnet_id,notnull"`
+	VipPortID          string    `bun:"vip_port_id,notnull"`
+	Description        string    `bun:"description,notnull"`
+	TimeCreated        time.Time `bun:"loadbalancer_created_at,notnull"`
+	TimeUpdated        time.Time `bun:"loadbalancer_updated_at,notnull"`
+	Subnet             *Subnet   `bun:"rel:has-one,join:vip_subnet_id=subnet_id,join:project_id=project_id"`
+	Project            *Project  `bun:"rel:has-one,join:project_id=project_id"`
+	Network            *Network  `bun:"rel:has-one,join:vip_network_id=network_id,join:project_id=project_id"`
 }
 
 // Subnet represents an OpenStack Subnet.
@@ -144,40 +184,50 @@ type Subnet struct {
 	bun.BaseModel `bun:"table:openstack_subnet"`
 	coremodels.Model
 
-	SubnetID     string   `bun:"subnet_id,notnull,unique:openstack_subnet_key"`
-	Name         string   `bun:"name,notnull"`
-	ProjectID    string   `bun:"project_id,notnull,unique:openstack_subnet_key"`
-	Domain       string   `bun:"domain,notnull"`
-	Region       string   `bun:"region,notnull"`
-	NetworkID    string   `bun:"network_id,notnull"`
-	GatewayIP    string   `bun:"gateway_ip,notnull"`
-	CIDR         string   `bun:"cidr,notnull"`
-	SubnetPoolID string   `bun:"subnet_pool_id,notnull"`
-	EnableDHCP   bool     `bun:"enable_dhcp,notnull"`
-	IPVersion    int      `bun:"ip_version,notnull"`
-	Description  string   `bun:"description,notnull"`
-	Network      *Network `bun:"rel:has-one,join:network_id=network_id,join:project_id=project_id"`
-	Project      *Project `bun:"rel:has-one,join:project_id=project_id"`
+	SubnetID     string            `bun:"subnet_id,notnull,unique:openstack_subnet_key"`
+	Name         string            `bun:"name,notnull"`
+	ProjectID    string            `bun:"project_id,notnull,unique:openstack_subnet_key"`
+	Domain       string            `bun:"domain,notnull"`
+	Region       string            `bun:"region,notnull"`
+	NetworkID    string            `bun:"network_id,notnull"`
+	GatewayIP    string            `bun:"gateway_ip,notnull"`
+	CIDR         string            `bun:"cidr,notnull"`
+	SubnetPoolID string            `bun:"subnet_pool_id,notnull"`
+	EnableDHCP   bool              `bun:"enable_dhcp,notnull"`
+	IPVersion    int               `bun:"ip_version,notnull"`
+	Description  string            `bun:"description,notnull"`
+	Tags         map[string]string `bun:"tags,type:jsonb,notnull"`
+	Network      *Network          `bun:"rel:has-one,join:network_id=network_id,join:project_id=project_id"`
+	Project      *Project          `bun:"rel:has-one,join:project_id=project_id"`
 }
 
-// FloatingIP represents an OpenStack Floating IP.
+// Address families of the [FloatingIP] addresses.
+const (
+	AddressFamilyIPv4 = "v4"
+	AddressFamilyIPv6 = "v6"
+)
+
+// FloatingIP represents an OpenStack Floating IP. The fixed IP of floating IPs,
+// which are not associated with a port, is NULL.
 type FloatingIP struct {
 	bun.BaseModel `bun:"table:openstack_floating_ip"`
 	coremodels.Model
 
-	FloatingIPID      string    `bun:"floating_ip_id,notnull,unique:openstack_floating_ip_key"`
-	ProjectID         string    `bun:"project_id,notnull,unique:openstack_floating_ip_key"`
-	Domain            string    `bun:"domain,notnull"`
-	Region            string    `bun:"region,notnull"`
-	FloatingIP        net.IP    `bun:"floating_ip,notnull"`
-	FloatingNetworkID string    `bun:"floating_network_id,notnull"`
-	PortID            string    `bun:"port_id,notnull"`
-	RouterID          string    `bun:"router_id,notnull"`
-	FixedIP           net.IP    `bun:"fixed_ip,notnull"`
-	Description       string    `bun:"description,notnull"`
-	TimeCreated       time.Time `bun:"ip_created_at,notnull"`
-	TimeUpdated       time.Time `bun:"ip_updated_at,notnull"`
-	Project           *Project  `bun:"rel:has-one,join:project_id=project_id"`
+	FloatingIPID      string            `bun:"floating_ip_id,notnull,unique:openstack_floating_ip_key"`
+	ProjectID         string            `bun:"project_id,notnull,unique:openstack_floating_ip_key"`
+	Domain            string            `bun:"domain,notnull"`
+	Region            string            `bun:"region,notnull"`
+	FloatingIP        net.IP            `bun:"floating_ip,notnull"`
+	AddressFamily     string            `bun:"address_family,notnull"`
+	FloatingNetworkID string            `bun:"floating_network_id,notnull"`
+	PortID            string            `bun:"port_id,notnull"`
+	RouterID          string            `bun:"router_id,notnull"`
+	FixedIP           net.IP            `bun:"fixed_ip,nullzero"`
+	Description       string            `bun:"description,notnull"`
+	TimeCreated       time.Time         `bun:"ip_created_at,notnull"`
+	TimeUpdated       time.Time         `bun:"ip_updated_at,notnull"`
+	Tags              map[string]string `bun:"tags,type:jsonb,notnull"`
+	Project           *Project          `bun:"rel:has-one,join:project_id=project_id"`
 }
 
 // SubnetToNetwork represents a link table connecting Subnets with Networks.
@@ -185,8 +235,8 @@ type SubnetToNetwork struct {
 	bun.BaseModel `bun:"table:l_openstack_subnet_to_network"`
 	coremodels.Model
 
-	SubnetID  uuid.UUID `bun:"subnet_id,notnull"`
-	NetworkID uuid.UUID `bun:"network_id,notnull"`
+	SubnetID  uuid.UUID `bun:"subnet_id,notnull,unique:l_openstack_subnet_to_network_key"`
+	NetworkID uuid.UUID `bun:"network_id,notnull,unique:l_openstack_subnet_to_network_key"`
 }
 
 // SubnetToProject represents a link table connecting Subnets with Projects.
@@ -194,8 +244,8 @@ type SubnetToProject struct {
 	bun.BaseModel `bun:"table:l_openstack_subnet_to_project"`
 	coremodels.Model
 
-	SubnetID  uuid.UUID `bun:"subnet_id,notnull"`
-	ProjectID uuid.UUID `bun:"project_id,notnull"`
+	SubnetID  uuid.UUID `bun:"subnet_id,notnull,unique:l_openstack_subnet_to_project_key"`
+	ProjectID uuid.UUID `bun:"project_id,notnull,unique:l_openstack_subnet_to_project_key"`
 }
 
 // LoadBalancerToSubnet represents a link table connecting LoadBalancers with Subnets.
@@ -203,8 +253,8 @@ type LoadBalancerToSubnet struct {
 	bun.BaseModel `bun:"table:l_openstack_loadbalancer_to_subnet"`
 	coremodels.Model
 
-	LoadBalancerID uuid.UUID `bun:"lb_id,notnull"`
-	SubnetID       uuid.UUID `bun:"subnet_id,notnull"`
+	LoadBalancerID uuid.UUID `bun:"lb_id,notnull,unique:l_openstack_loadbalancer_to_subnet_key"`
+	SubnetID       uuid.UUID `bun:"subnet_id,notnull,unique:l_openstack_loadbalancer_to_subnet_key"`
 }
 
 // LoadBalancerToProject represents a link table connecting LoadBalancers with Projects.
@@ -212,8 +262,8 @@ type LoadBalancerToProject struct {
 	bun.BaseModel `bun:"table:l_openstack_loadbalancer_to_project"`
 	coremodels.Model
 
-	LoadBalancerID uuid.UUID `bun:"lb_id,notnull"`
-	ProjectID      uuid.UUID `bun:"project_id,notnull"`
+	LoadBalancerID uuid.UUID `bun:"lb_id,notnull,unique:l_openstack_loadbalancer_to_project_key"`
+	ProjectID      uuid.UUID `bun:"project_id,notnull,unique:l_openstack_loadbalancer_to_project_key"`
 }
 
 // LoadBalancerToNetwork represents a link table connecting LoadBalancers with Networks.
@@ -221,8 +271,8 @@ type LoadBalancerToNetwork struct {
 	bun.BaseModel `bun:"table:l_openstack_loadbalancer_to_network"`
 	coremodels.Model
 
-	LoadBalancerID uuid.UUID `bun:"lb_id,notnull"`
-	NetworkID      uuid.UUID `bun:"network_id,notnull"`
+	LoadBalancerID uuid.UUID `bun:"lb_id,notnull,unique:l_openstack_loadbalancer_to_network_key"`
+	NetworkID      uuid.UUID `bun:"network_id,notnull,unique:l_openstack_loadbalancer_to_network_key"`
 }
 
 // ServerToProject represents a link table connecting Servers with Projects.
@@ -230,8 +280,8 @@ type ServerToProject struct {
 	bun.BaseModel `bun:"table:l_openstack_server_to_project"`
 	coremodels.Model
 
-	ServerID  uuid.UUID `bun:"server_id,notnull"`
-	ProjectID uuid.UUID `bun:"project_id,notnull"`
+	ServerID  uuid.UUID `bun:"server_id,notnull,unique:l_openstack_server_to_project_key"`
+	ProjectID uuid.UUID `bun:"project_id,notnull,unique:l_openstack_server_to_project_key"`
 }
 
 // PortToServer represents a link table connecting Ports with Servers.
@@ -239,8 +289,58 @@ type PortToServer struct {
 	bun.BaseModel `bun:"table:l_openstack_port_to_server"`
 	coremodels.Model
 
-	PortID   uuid.UUID `bun:"port_id,notnull"`
-	ServerID uuid.UUID `bun:"server_id,notnull"`
+	PortID   uuid.UUID `bun:"port_id,notnull,unique:l_openstack_port_to_server_key"`
+	ServerID uuid.UUID `bun:"server_id,notnull,unique:l_openstack_port_to_server_key"`
+}
+
+// FloatingIPToServer represents a link table connecting Floating IPs with the
+// Servers they are associated with.
+type FloatingIPToServer struct {
+	bun.BaseModel `bun:"table:l_openstack_floating_ip_to_server"`
+	coremodels.Model
+
+	FloatingIPID uuid.UUID `bun:"floating_ip_id,notnull,unique:l_openstack_floating_ip_to_server_key"`
+	ServerID     uuid.UUID `bun:"server_id,notnull,unique:l_openstack_floating_ip_to_server_key"`
+}
+
+// FloatingIPToRouter represents a link table connecting Floating IPs with the
+// Routers, through which they are reachable.
+type FloatingIPToRouter struct {
+	bun.BaseModel `bun:"table:l_openstack_floating_ip_to_router"`
+	coremodels.Model
+
+	RouterID     uuid.UUID `bun:"router_id,notnull,unique:l_openstack_floating_ip_to_router_key"`
+	FloatingIPID uuid.UUID `bun:"floating_ip_id,notnull,unique:l_openstack_floating_ip_to_router_key"`
+}
+
+// FloatingIPToNetwork represents a link table connecting Floating IPs with the
+// external Networks they are allocated from.
+type FloatingIPToNetwork struct {
+	bun.BaseModel `bun:"table:l_openstack_floating_ip_to_network"`
+	coremodels.Model
+
+	NetworkID    uuid.UUID `bun:"network_id,notnull,unique:l_openstack_floating_ip_to_network_key"`
+	FloatingIPID uuid.UUID `bun:"floating_ip_id,notnull,unique:l_openstack_floating_ip_to_network_key"`
+}
+
+// VolumeToServer represents a link table connecting Volumes with the Servers
+// they are attached to.
+type VolumeToServer struct {
+	bun.BaseModel `bun:"table:l_openstack_volume_to_server"`
+	coremodels.Model
+
+	VolumeID uuid.UUID `bun:"volume_id,notnull,unique:l_openstack_volume_to_server_key"`
+	ServerID uuid.UUID `bun:"server_id,notnull,unique:l_openstack_volume_to_server_key"`
+}
+
+// ServerToImage represents a link table connecting Servers with the Images
+// they were booted from.
+type ServerToImage struct {
+	bun.BaseModel `bun:"table:l_openstack_server_to_image"`
+	coremodels.Model
+
+	ServerID uuid.UUID `bun:"server_id,notnull,unique:l_openstack_server_to_image_key"`
+	ImageID  uuid.UUID `bun:"image_id,notnull,unique:l_openstack_server_to_image_key"`
 }
 
 // ServerToNetwork represents a link table connecting Servers with Networks.
@@ -248,8 +348,8 @@ type ServerToNetwork struct {
 	bun.BaseModel `bun:"table:l_openstack_server_to_network"`
 	coremodels.Model
 
-	ServerID  uuid.UUID `bun:"server_id,notnull"`
-	NetworkID uuid.UUID `bun:"network_id,notnull"`
+	ServerID  uuid.UUID `bun:"server_id,notnull,unique:l_openstack_server_to_network_key"`
+	NetworkID uuid.UUID `bun:"network_id,notnull,unique:l_openstack_server_to_network_key"`
 }
 
 // NetworkToProject represents a link table connecting Networks with Projects.
@@ -257,8 +357,8 @@ type NetworkToProject struct {
 	bun.BaseModel `bun:"table:l_openstack_network_to_project"`
 	coremodels.Model
 
-	NetworkID uuid.UUID `bun:"network_id,notnull"`
-	ProjectID uuid.UUID `bun:"project_id,notnull"`
+	NetworkID uuid.UUID `bun:"network_id,notnull,unique:l_openstack_network_to_project_key"`
+	ProjectID uuid.UUID `bun:"project_id,notnull,unique:l_openstack_network_to_project_key"`
 }
 
 // Project represents an OpenStack Project.
@@ -281,22 +381,24 @@ type Port struct {
 	bun.BaseModel `bun:"table:openstack_port"`
 	coremodels.Model
 
-	PortID      string    `bun:"port_id,notnull,unique:openstack_port_key"`
-	Name        string    `bun:"name,notnull"`
-	ProjectID   string    `bun:"project_id,notnull,unique:openstack_port_key"`
-	NetworkID   string    `bun:"network_id,notnull,unique:openstack_port_key"`
-	DeviceID    string    `bun:"device_id,notnull"`
-	DeviceOwner string    `bun:"device_owner,notnull"`
-	Domain      string    `bun:"domain,notnull"`
-	Region      string    `bun:"region,notnull,unique:openstack_port_key"`
-	MacAddress  string    `bun:"mac_address,notnull"`
-	Status      string    `bun:"status,notnull"`
-	Description string    `bun:"description,notnull"`
-	TimeCreated time.Time `bun:"port_created_at,notnull"`
-	TimeUpdated time.Time `bun:"port_updated_at,notnull"`
-	Network     *Network  `bun:"rel:has-one,join:network_id=network_id,join:project_id=project_id"`
-	Project     *Project  `bun:"rel:has-one,join:project_id=project_id"`
-	Server      *Server   `bun:"rel:has-one,join:device_id=server_id,join:project_id=project_id"`
+	PortID         string            `bun:"port_id,notnull,unique:openstack_port_key"`
+	Name           string            `bun:"name,notnull"`
+	ProjectID      string            `bun:"project_id,notnull,unique:openstack_port_key"`
+	NetworkID      string            `bun:"network_id,notnull,unique:openstack_port_key"`
+	DeviceID       string            `bun:"device_id,notnull"`
+	DeviceOwner    string            `bun:"device_owner,notnull"`
+	Domain         string            `bun:"domain,notnull"`
+	Region         string            `bun:"region,notnull,unique:openstack_port_key"`
+	MacAddress     string            `bun:"mac_address,notnull"`
+	Status         string            `bun:"status,notnull"`
+	Description    string            `bun:"description,notnull"`
+	TimeCreated    time.Time         `bun:"port_created_at,notnull"`
+	TimeUpdated    time.Time         `bun:"port_updated_at,notnull"`
+	Tags           map[string]string `bun:"tags,type:jsonb,notnull"`
+	SecurityGroups []string          `bun:"security_groups,array,notnull"`
+	Network        *Network          `bun:"rel:has-one,join:network_id=network_id,join:project_id=project_id"`
+	Project        *Project          `bun:"rel:has-one,join:project_id=project_id"`
+	Server         *Server           `bun:"rel:has-one,join:device_id=server_id,join:project_id=project_id"`
 }
 
 // PortIP represents an OpenStack Port IP address.
@@ -431,6 +533,224 @@ type Volume struct {
 	Description       string    `bun:"description,notnull"`
 	TimeCreated       time.Time `bun:"volume_created_at,notnull"`
 	TimeUpdated       time.Time `bun:"volume_updated_at,notnull"`
+	ServerIDs         []string  `bun:"server_ids,array,notnull"`
+}
+
+// Image represents an OpenStack Image (Glance) image. The ProjectID specifies
+// the project, which owns the image.
+type Image struct {
+	bun.BaseModel `bun:"table:openstack_image"`
+	coremodels.Model
+
+	ImageID         string    `bun:"image_id,notnull,unique:openstack_image_key"`
+	Name            string    `bun:"name,notnull"`
+	ProjectID       string    `bun:"project_id,notnull,unique:openstack_image_key"`
+	Domain          string    `bun:"domain,notnull"`
+	Region          string    `bun:"region,notnull"`
+	Status          string    `bun:"status,notnull"`
+	Visibility      string    `bun:"visibility,notnull"`
+	Size            int64     `bun:"size,notnull"`
+	MinDisk         int       `bun:"min_disk,notnull"`
+	MinRAM          int       `bun:"min_ram,notnull"`
+	DiskFormat      string    `bun:"disk_format,notnull"`
+	ContainerFormat string    `bun:"container_format,notnull"`
+	TimeCreated     time.Time `bun:"image_created_at,notnull"`
+	TimeUpdated     time.Time `bun:"image_updated_at,notnull"`
+}
+
+// Share represents an OpenStack Shared File System (Manila) share.
+type Share struct {
+	bun.BaseModel `bun:"table:openstack_share"`
+	coremodels.Model
+
+	ShareID          string        `bun:"share_id,notnull,unique:openstack_share_key"`
+	Name             string        `bun:"name,notnull"`
+	ProjectID        string        `bun:"project_id,notnull,unique:openstack_share_key"`
+	Domain           string        `bun:"domain,notnull"`
+	Region           string        `bun:"region,notnull"`
+	Status           string        `bun:"status,notnull"`
+	Size             int           `bun:"size,notnull"`
+	ShareProto       string        `bun:"share_proto,notnull"`
+	ShareType        string        `bun:"share_type,notnull"`
+	ShareTypeName    string        `bun:"share_type_name,notnull"`
+	ShareNetworkID   string        `bun:"share_network_id,notnull"`
+	AvailabilityZone string        `bun:"availability_zone,notnull"`
+	IsPublic         bool          `bun:"is_public,notnull"`
+	Description      string        `bun:"description,notnull"`
+	TimeCreated      time.Time     `bun:"share_created_at,notnull"`
+	TimeUpdated      time.Time     `bun:"share_updated_at,nullzero"`
+	ShareNetwork     *ShareNetwork `bun:"rel:has-one,join:share_network_id=share_network_id,join:project_id=project_id"`
+}
+
+// ShareExportLocation represents an export location of an OpenStack share.
+type ShareExportLocation struct {
+	bun.BaseModel `bun:"table:openstack_share_export_location"`
+	coremodels.Model
+
+	ExportLocationID string `bun:"export_location_id,notnull,unique:openstack_share_export_location_key"`
+	ShareID          string `bun:"share_id,notnull,unique:openstack_share_export_location_key"`
+	ProjectID        string `bun:"project_id,notnull,unique:openstack_share_export_location_key"`
+	Path             string `bun:"path,notnull"`
+	ShareInstanceID  string `bun:"share_instance_id,notnull"`
+	IsAdminOnly      bool   `bun:"is_admin_only,notnull"`
+	Preferred        bool   `bun:"preferred,notnull"`
+	Share            *Share `bun:"rel:has-one,join:share_id=share_id,join:project_id=project_id"`
+}
+
+// ShareNetwork represents an OpenStack share network.
+type ShareNetwork struct {
+	bun.BaseModel `bun:"table:openstack_share_network"`
+	coremodels.Model
+
+	ShareNetworkID  string    `bun:"share_network_id,notnull,unique:openstack_share_network_key"`
+	Name            string    `bun:"name,notnull"`
+	ProjectID       string    `bun:"project_id,notnull,unique:openstack_share_network_key"`
+	Domain          string    `bun:"domain,notnull"`
+	Region          string    `bun:"region,notnull"`
+	NeutronNetID    string    `bun:"neutron_net_id,notnull"`
+	NeutronSubnetID string    `bun:"neutron_subnet_id,notnull"`
+	NetworkType     string    `bun:"network_type,notnull"`
+	CIDR            string    `bun:"cidr,notnull"`
+	IPVersion       int       `bun:"ip_version,notnull"`
+	Description     string    `bun:"description,notnull"`
+	TimeCreated     time.Time `bun:"share_network_created_at,notnull"`
+	TimeUpdated     time.Time `bun:"share_network_updated_at,nullzero"`
+}
+
+// ShareToShareNetwork represents a link table connecting Shares with Share
+// Networks.
+type ShareToShareNetwork struct {
+	bun.BaseModel `bun:"table:l_openstack_share_to_share_network"`
+	coremodels.Model
+
+	ShareID        uuid.UUID `bun:"share_id,notnull,unique:l_openstack_share_to_share_network_key"`
+	ShareNetworkID uuid.UUID `bun:"share_network_id,notnull,unique:l_openstack_share_to_share_network_key"`
+}
+
+// SecurityGroup represents an OpenStack security group.
+type SecurityGroup struct {
+	bun.BaseModel `bun:"table:openstack_security_group"`
+	coremodels.Model
+
+	SecurityGroupID string            `bun:"security_group_id,notnull,unique:openstack_security_group_key"`
+	Name            string            `bun:"name,notnull"`
+	ProjectID       string            `bun:"project_id,notnull,unique:openstack_security_group_key"`
+	Domain          string            `bun:"domain,notnull"`
+	Region          string            `bun:"region,notnull"`
+	Description     string            `bun:"description,notnull"`
+	Stateful        bool              `bun:"stateful,notnull"`
+	TimeCreated     time.Time         `bun:"security_group_created_at,nullzero"`
+	TimeUpdated     time.Time         `bun:"security_group_updated_at,nullzero"`
+	Tags            map[string]string `bun:"tags,type:jsonb,notnull"`
+	Project         *Project          `bun:"rel:has-one,join:project_id=project_id"`
+}
+
+// SecurityGroupRule represents a rule of an OpenStack security group.
+type SecurityGroupRule struct {
+	bun.BaseModel `bun:"table:openstack_security_group_rule"`
+	coremodels.Model
+
+	RuleID          string         `bun:"rule_id,notnull,unique:openstack_security_group_rule_key"`
+	SecurityGroupID string         `bun:"security_group_id,notnull"`
+	ProjectID       string         `bun:"project_id,notnull,unique:openstack_security_group_rule_key"`
+	Domain          string         `bun:"domain,notnull"`
+	Region          string         `bun:"region,notnull"`
+	Direction       string         `bun:"direction,notnull"`
+	EtherType       string         `bun:"ether_type,notnull"`
+	Protocol        string         `bun:"protocol,notnull"`
+	PortRangeMin    int            `bun:"port_range_min,notnull"`
+	PortRangeMax    int            `bun:"port_range_max,notnull"`
+	RemoteIPPrefix  string         `bun:"remote_ip_prefix,notnull"`
+	RemoteGroupID   string         `bun:"remote_group_id,notnull"`
+	Description     string         `bun:"description,notnull"`
+	TimeCreated     time.Time      `bun:"rule_created_at,nullzero"`
+	TimeUpdated     time.Time      `bun:"rule_updated_at,nullzero"`
+	SecurityGroup   *SecurityGroup `bun:"rel:has-one,join:security_group_id=security_group_id,join:project_id=project_id"`
+}
+
+// SecurityGroupRuleToGroup represents a link table connecting Security Group
+// Rules with Security Groups.
+type SecurityGroupRuleToGroup struct {
+	bun.BaseModel `bun:"table:l_openstack_security_group_rule_to_group"`
+	coremodels.Model
+
+	SecurityGroupID uuid.UUID `bun:"security_group_id,notnull,unique:l_openstack_security_group_rule_to_group_key"`
+	RuleID          uuid.UUID `bun:"rule_id,notnull,unique:l_openstack_security_group_rule_to_group_key"`
+}
+
+// PortToSecurityGroup represents a link table connecting Ports with Security
+// Groups.
+type PortToSecurityGroup struct {
+	bun.BaseModel `bun:"table:l_openstack_port_to_security_group"`
+	coremodels.Model
+
+	PortID          uuid.UUID `bun:"port_id,notnull,unique:l_openstack_port_to_security_group_key"`
+	SecurityGroupID uuid.UUID `bun:"security_group_id,notnull,unique:l_openstack_port_to_security_group_key"`
+}
+
+// QuotaUsageSample represents a sample of the usage of an OpenStack compute
+// quota of a project. Samples are never updated, so that they represent the
+// usage history of the quota.
+type QuotaUsageSample struct {
+	bun.BaseModel `bun:"table:openstack_quota_usage_sample"`
+	coremodels.Model
+
+	ProjectID  string  `bun:"project_id,notnull"`
+	Domain     string  `bun:"domain,notnull"`
+	Region     string  `bun:"region,notnull"`
+	Resource   string  `bun:"resource,notnull"`
+	InUse      int     `bun:"in_use,notnull"`
+	Reserved   int     `bun:"reserved,notnull"`
+	Limit      int     `bun:"quota_limit,notnull"`
+	UsageRatio float64 `bun:"usage_ratio,notnull"`
+}
+
+// Resource types of the exposure findings
+const (
+	ExposureResourceTypeServer       = "server"
+	ExposureResourceTypeLoadBalancer = "loadbalancer"
+)
+
+// Reasons of the exposure findings
+const (
+	// ExposureReasonFloatingIP specifies, that the resource is reachable
+	// via a floating IP.
+	ExposureReasonFloatingIP = "floating_ip"
+
+	// ExposureReasonOpenSecurityGroup specifies, that the resource is
+	// reachable via a floating IP, and has a security group allowing
+	// ingress traffic from any address.
+	ExposureReasonOpenSecurityGroup = "open_security_group"
+
+	// ExposureReasonExternalVIP specifies, that the VIP of the load
+	// balancer is allocated from an external network.
+	ExposureReasonExternalVIP = "external_vip"
+)
+
+// Severities of the exposure findings
+const (
+	ExposureSeverityLow      = "low"
+	ExposureSeverityMedium   = "medium"
+	ExposureSeverityHigh     = "high"
+	ExposureSeverityCritical = "critical"
+)
+
+// ExposureFinding represents a resource, which is exposed to the internet.
+// The findings are derived from the collected Floating IPs, Security Groups
+// and Load Balancers, and are recomputed on each run of the respective task.
+// Findings, which are no longer present are marked as deleted.
+type ExposureFinding struct {
+	bun.BaseModel `bun:"table:openstack_exposure_finding"`
+	coremodels.Model
+
+	ResourceID   string `bun:"resource_id,notnull,unique:openstack_exposure_finding_key"`
+	ResourceType string `bun:"resource_type,notnull,unique:openstack_exposure_finding_key"`
+	ProjectID    string `bun:"project_id,notnull,unique:openstack_exposure_finding_key"`
+	Reason       string `bun:"reason,notnull,unique:openstack_exposure_finding_key"`
+	PublicIP     net.IP `bun:"public_ip,notnull,type:inet,unique:openstack_exposure_finding_key"`
+	Domain       string `bun:"domain,notnull"`
+	Region       string `bun:"region,notnull"`
+	Severity     string `bun:"severity,notnull"`
 }
 
 func init() {
@@ -439,4 +759,10 @@ func init() {
 	for k, v := range models {
 		registry.ModelRegistry.MustRegister(k, v)
 	}
+
+	// Register the global ids of the models
+	globalid.Registry.MustRegister(ServerModelName, globalid.Spec{
+		Provider: "openstack",
+		Columns:  []string{"project_id", "server_id"},
+	})
 }
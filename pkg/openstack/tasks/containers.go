@@ -13,14 +13,15 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -69,6 +70,8 @@ func HandleCollectContainersTask(ctx context.Context, t *asynq.Task) error {
 func enqueueCollectContainers(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.ObjectStorageClientset.Length() == 0 {
 		logger.Warn("no OpenStack object storage clients found")
 
@@ -77,51 +80,40 @@ func enqueueCollectContainers(ctx context.Context) error {
 
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.ObjectStorageClientset.
-		Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
-			payload := CollectContainersPayload{
-				Scope: scope,
-			}
-			data, err := json.Marshal(payload)
-			if err != nil {
-				logger.Error(
-					"failed to marshal payload for OpenStack containers",
-					"project", scope.Project,
-					"domain", scope.Domain,
-					"region", scope.Region,
-					"reason", err,
-				)
-
-				return err
-			}
-
-			task := asynq.NewTask(TaskCollectContainers, data)
-			info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-			if err != nil {
-				logger.Error(
-					"failed to enqueue task",
-					"type", task.Type(),
-					"project", scope.Project,
-					"domain", scope.Domain,
-					"region", scope.Region,
-					"reason", err,
-				)
-
-				return err
-			}
-
-			logger.Info(
-				"enqueued task",
-				"type", task.Type(),
-				"id", info.ID,
-				"queue", info.Queue,
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.ObjectStorageClientset.Length())
+	err := openstackclients.ObjectStorageClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectContainersPayload{
+			Scope: scope,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack containers",
 				"project", scope.Project,
 				"domain", scope.Domain,
 				"region", scope.Region,
+				"reason", err,
 			)
 
-			return nil
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectContainers, data)
 		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectContainer collects the OpenStack Containers,
@@ -129,9 +121,9 @@ func enqueueCollectContainers(ctx context.Context) error {
 func collectContainers(ctx context.Context, payload CollectContainersPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.ObjectStorageClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.ObjectStorageClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -176,6 +168,9 @@ func collectContainers(ctx context.Context, payload CollectContainersPayload) er
 	err = containers.List(client.Client, nil).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				extractedContainers, err := containers.ExtractInfo(page)
 				if err != nil {
 					logger.Error(
@@ -221,14 +216,13 @@ func collectContainers(ctx context.Context, payload CollectContainersPayload) er
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, project_id) DO UPDATE").
-		Set("bytes = EXCLUDED.bytes").
-		Set("object_count = EXCLUDED.object_count").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, project_id) DO UPDATE").
+			Set("bytes = EXCLUDED.bytes").
+			Set("object_count = EXCLUDED.object_count").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -242,11 +236,6 @@ func collectContainers(ctx context.Context, payload CollectContainersPayload) er
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack containers",
 		"project", payload.Scope.Project,
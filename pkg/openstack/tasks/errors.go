@@ -6,18 +6,36 @@ package tasks
 
 import (
 	"errors"
-	"fmt"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 )
 
 // ErrClientNotFound is an error which is returned when an OpenStack client was not
 // found in the clientset registries.
-var ErrClientNotFound = errors.New("client not found")
+var ErrClientNotFound error = &asynqutils.CollectionError{
+	Provider: "openstack",
+	Err:      errors.New("client not found"),
+}
 
 // ErrInvalidScope is an error which is returned when a valid scope was not
 // specified in a task payload.
-var ErrInvalidScope = errors.New("invalid scope specified")
+var ErrInvalidScope error = &asynqutils.CollectionError{
+	Provider: "openstack",
+	Err:      errors.New("invalid scope specified"),
+}
+
+// ErrMaxObjectsExceeded is an error which is returned when a container has
+// more objects than the configured max number of objects to enumerate.
+var ErrMaxObjectsExceeded error = &asynqutils.CollectionError{
+	Provider: "openstack",
+	Err:      errors.New("max objects exceeded"),
+}
 
 // ClientNotFound wraps [ErrClientNotFound] with the given name.
 func ClientNotFound(name string) error {
-	return fmt.Errorf("%w: %s", ErrClientNotFound, name)
+	return &asynqutils.CollectionError{
+		Provider: "openstack",
+		Account:  name,
+		Err:      ErrClientNotFound,
+	}
 }
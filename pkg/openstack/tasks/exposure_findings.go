@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// TaskComputeExposureFindings is the name of the task for computing
+	// the OpenStack exposure findings.
+	TaskComputeExposureFindings = "openstack:task:compute-exposure-findings"
+)
+
+// anyAddressRule is the condition, which matches ingress security group rules
+// allowing traffic from any address. A rule without a remote IP prefix and
+// remote group matches any address as well.
+const anyAddressRule = `r.direction = 'ingress' AND (r.remote_ip_prefix IN ('0.0.0.0/0', '::/0') OR (r.remote_ip_prefix = '' AND r.remote_group_id = ''))`
+
+// anyPortRule is the condition, which matches security group rules allowing
+// traffic for any protocol, or for the whole port range.
+const anyPortRule = `r.protocol = '' OR (r.protocol IN ('tcp', 'udp') AND r.port_range_min = 0 AND r.port_range_max = 0) OR (r.port_range_min <= 1 AND r.port_range_max >= 65535)`
+
+// NewComputeExposureFindingsTask creates a new [asynq.Task] for computing the
+// OpenStack exposure findings.
+func NewComputeExposureFindingsTask() *asynq.Task {
+	return asynq.NewTask(TaskComputeExposureFindings, nil)
+}
+
+// HandleComputeExposureFindingsTask handles the task for computing the
+// OpenStack exposure findings. The findings are derived from the collected
+// Floating IPs, Security Groups and Load Balancers, and stored in the
+// [models.ExposureFinding] table. Findings, which were not derived by the
+// current run are marked as deleted.
+func HandleComputeExposureFindingsTask(ctx context.Context, _ *asynq.Task) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	// The timestamp is truncated to the precision of PostgreSQL, so that
+	// the findings of the current run can be compared with it.
+	now := time.Now().Truncate(time.Microsecond)
+	queries := []func(context.Context, bun.IDB) ([]models.ExposureFinding, error){
+		findServersWithOpenSecurityGroups,
+		findServersWithFloatingIPs,
+		findLoadBalancersWithFloatingIPs,
+		findLoadBalancersWithExternalVIPs,
+	}
+
+	findings := make([]models.ExposureFinding, 0)
+	for _, query := range queries {
+		items, err := query(ctx, db.DB)
+		if err != nil {
+			logger.Error(
+				"could not compute exposure findings",
+				"reason", err,
+			)
+
+			return err
+		}
+		findings = append(findings, items...)
+	}
+
+	severities := map[string]int{
+		models.ExposureSeverityLow:      0,
+		models.ExposureSeverityMedium:   0,
+		models.ExposureSeverityHigh:     0,
+		models.ExposureSeverityCritical: 0,
+	}
+	for i := range findings {
+		findings[i].LastSeenAt = now
+		severities[findings[i].Severity]++
+	}
+
+	_, err := dbutils.BulkInsert(ctx, db.DB, findings, func(q *bun.InsertQuery) *bun.InsertQuery {
+		q = q.On("CONFLICT (resource_id, resource_type, project_id, reason, public_ip) DO UPDATE")
+
+		return dbutils.UpsertAllColumns(q, findings).
+			Set("last_seen_at = EXCLUDED.last_seen_at").
+			Set("deleted_at = NULL").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert exposure findings into db",
+			"reason", err,
+		)
+
+		return err
+	}
+
+	// Findings, which were not seen by the current run have been resolved.
+	out, err := db.DB.NewUpdate().
+		Model((*models.ExposureFinding)(nil)).
+		Set("deleted_at = ?", now).
+		Where("deleted_at IS NULL").
+		Where("last_seen_at IS NULL OR last_seen_at < ?", now).
+		Exec(ctx)
+
+	if err != nil {
+		logger.Error(
+			"could not mark resolved exposure findings",
+			"reason", err,
+		)
+
+		return err
+	}
+
+	resolved, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	for severity, count := range severities {
+		metric := prometheus.MustNewConstMetric(
+			exposureFindingsDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			severity,
+		)
+		key := metrics.Key(TaskComputeExposureFindings, severity)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}
+
+	logger.Info(
+		"computed openstack exposure findings",
+		"count", len(findings),
+		"resolved", resolved,
+	)
+
+	return nil
+}
+
+// findServersWithOpenSecurityGroups returns the findings for Servers, which
+// are reachable via a Floating IP, and have a Security Group rule allowing
+// ingress traffic from any address. Rules allowing any port are critical.
+func findServersWithOpenSecurityGroups(ctx context.Context, db bun.IDB) ([]models.ExposureFinding, error) {
+	items := make([]models.ExposureFinding, 0)
+	err := db.NewSelect().
+		TableExpr("openstack_floating_ip AS fip").
+		Join("INNER JOIN openstack_port AS p").
+		JoinOn("p.port_id = fip.port_id").
+		JoinOn("p.region = fip.region").
+		Join("INNER JOIN openstack_server AS s").
+		JoinOn("s.server_id = p.device_id").
+		JoinOn("s.project_id = p.project_id").
+		Join("CROSS JOIN LATERAL unnest(p.security_groups) AS psg(security_group_id)").
+		Join("INNER JOIN openstack_security_group_rule AS r").
+		JoinOn("r.security_group_id = psg.security_group_id").
+		JoinOn("r.region = p.region").
+		ColumnExpr("s.server_id AS resource_id").
+		ColumnExpr("? AS resource_type", models.ExposureResourceTypeServer).
+		ColumnExpr("s.project_id AS project_id").
+		ColumnExpr("? AS reason", models.ExposureReasonOpenSecurityGroup).
+		ColumnExpr("fip.floating_ip AS public_ip").
+		ColumnExpr("s.domain AS domain").
+		ColumnExpr("s.region AS region").
+		ColumnExpr(
+			"CASE WHEN bool_or("+anyPortRule+") THEN ? ELSE ? END AS severity",
+			models.ExposureSeverityCritical,
+			models.ExposureSeverityHigh,
+		).
+		Where("fip.deleted_at IS NULL").
+		Where("s.deleted_at IS NULL").
+		Where("r.deleted_at IS NULL").
+		Where(anyAddressRule).
+		Group("s.server_id", "s.project_id", "s.domain", "s.region", "fip.floating_ip").
+		Scan(ctx, &items)
+
+	return items, err
+}
+
+// findServersWithFloatingIPs returns the findings for Servers, which are
+// reachable via a Floating IP.
+func findServersWithFloatingIPs(ctx context.Context, db bun.IDB) ([]models.ExposureFinding, error) {
+	items := make([]models.ExposureFinding, 0)
+	err := db.NewSelect().
+		Distinct().
+		TableExpr("openstack_floating_ip AS fip").
+		Join("INNER JOIN openstack_port AS p").
+		JoinOn("p.port_id = fip.port_id").
+		JoinOn("p.region = fip.region").
+		Join("INNER JOIN openstack_server AS s").
+		JoinOn("s.server_id = p.device_id").
+		JoinOn("s.project_id = p.project_id").
+		ColumnExpr("s.server_id AS resource_id").
+		ColumnExpr("? AS resource_type", models.ExposureResourceTypeServer).
+		ColumnExpr("s.project_id AS project_id").
+		ColumnExpr("? AS reason", models.ExposureReasonFloatingIP).
+		ColumnExpr("fip.floating_ip AS public_ip").
+		ColumnExpr("s.domain AS domain").
+		ColumnExpr("s.region AS region").
+		ColumnExpr("? AS severity", models.ExposureSeverityLow).
+		Where("fip.deleted_at IS NULL").
+		Where("s.deleted_at IS NULL").
+		Scan(ctx, &items)
+
+	return items, err
+}
+
+// findLoadBalancersWithFloatingIPs returns the findings for Load Balancers,
+// whose VIP port is associated with a Floating IP.
+func findLoadBalancersWithFloatingIPs(ctx context.Context, db bun.IDB) ([]models.ExposureFinding, error) {
+	items := make([]models.ExposureFinding, 0)
+	err := db.NewSelect().
+		Distinct().
+		TableExpr("openstack_floating_ip AS fip").
+		Join("INNER JOIN openstack_loadbalancer AS lb").
+		JoinOn("lb.vip_port_id = fip.port_id").
+		JoinOn("lb.region = fip.region").
+		ColumnExpr("lb.loadbalancer_id AS resource_id").
+		ColumnExpr("? AS resource_type", models.ExposureResourceTypeLoadBalancer).
+		ColumnExpr("lb.project_id AS project_id").
+		ColumnExpr("? AS reason", models.ExposureReasonFloatingIP).
+		ColumnExpr("fip.floating_ip AS public_ip").
+		ColumnExpr("lb.domain AS domain").
+		ColumnExpr("lb.region AS region").
+		ColumnExpr("? AS severity", models.ExposureSeverityMedium).
+		Where("fip.port_id <> ''").
+		Where("fip.deleted_at IS NULL").
+		Where("lb.deleted_at IS NULL").
+		Scan(ctx, &items)
+
+	return items, err
+}
+
+// findLoadBalancersWithExternalVIPs returns the findings for Load Balancers,
+// whose VIP is allocated from an external network.
+func findLoadBalancersWithExternalVIPs(ctx context.Context, db bun.IDB) ([]models.ExposureFinding, error) {
+	items := make([]models.ExposureFinding, 0)
+	external := db.NewSelect().
+		TableExpr("openstack_network AS n").
+		ColumnExpr("1").
+		Where("n.network_id = lb.vip_network_id").
+		Where("n.region = lb.region").
+		Where("n.external").
+		Where("n.deleted_at IS NULL")
+
+	err := db.NewSelect().
+		Distinct().
+		TableExpr("openstack_loadbalancer AS lb").
+		ColumnExpr("lb.loadbalancer_id AS resource_id").
+		ColumnExpr("? AS resource_type", models.ExposureResourceTypeLoadBalancer).
+		ColumnExpr("lb.project_id AS project_id").
+		ColumnExpr("? AS reason", models.ExposureReasonExternalVIP).
+		ColumnExpr("lb.vip_address::inet AS public_ip").
+		ColumnExpr("lb.domain AS domain").
+		ColumnExpr("lb.region AS region").
+		ColumnExpr("? AS severity", models.ExposureSeverityMedium).
+		Where("lb.vip_address <> ''").
+		Where("lb.deleted_at IS NULL").
+		Where("EXISTS (?)", external).
+		Scan(ctx, &items)
+
+	return items, err
+}
@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
+	"github.com/gardener/inventory/pkg/clients/db"
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/paginate"
+)
+
+const (
+	// TaskRefreshFloatingIPAssociations is the name of the task for
+	// refreshing the port associations of already collected OpenStack
+	// Floating IPs.
+	TaskRefreshFloatingIPAssociations = "openstack:task:refresh-floating-ip-associations"
+)
+
+// RefreshFloatingIPAssociationsPayload represents the payload, which specifies
+// the scope for refreshing the associations of OpenStack Floating IPs.
+type RefreshFloatingIPAssociationsPayload struct {
+	// Scope specifies the client scope to use for collection.
+	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+}
+
+// floatingIPAssociation represents the association of a Floating IP with a
+// port, which is used for updating the associations of existing Floating IPs.
+type floatingIPAssociation struct {
+	FloatingIPID string `bun:"floating_ip_id"`
+	ProjectID    string `bun:"project_id"`
+	PortID       string `bun:"port_id"`
+	RouterID     string `bun:"router_id"`
+	FixedIP      net.IP `bun:"fixed_ip,type:inet,nullzero"`
+}
+
+// floatingIPAssociationListOpts is a [floatingips.ListOptsBuilder], which
+// requests only the fields needed for refreshing the associations of Floating
+// IPs.
+type floatingIPAssociationListOpts struct{}
+
+// ToFloatingIPListQuery implements the [floatingips.ListOptsBuilder] interface.
+func (floatingIPAssociationListOpts) ToFloatingIPListQuery() (string, error) {
+	return "?fields=id&fields=tenant_id&fields=port_id&fields=router_id&fields=fixed_ip_address", nil
+}
+
+// NewRefreshFloatingIPAssociationsTask creates a new [asynq.Task] for
+// refreshing the associations of OpenStack Floating IPs, without specifying a
+// payload.
+func NewRefreshFloatingIPAssociationsTask() *asynq.Task {
+	return asynq.NewTask(TaskRefreshFloatingIPAssociations, nil)
+}
+
+// HandleRefreshFloatingIPAssociationsTask handles the task for refreshing the
+// associations of OpenStack Floating IPs.
+func HandleRefreshFloatingIPAssociationsTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// refreshing the associations for all configured clients.
+	data := t.Payload()
+	if data == nil {
+		return enqueueRefreshFloatingIPAssociations(ctx)
+	}
+
+	var payload RefreshFloatingIPAssociationsPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
+		return asynqutils.SkipRetry(ErrInvalidScope)
+	}
+
+	return refreshFloatingIPAssociations(ctx, payload)
+}
+
+// enqueueRefreshFloatingIPAssociations enqueues tasks for refreshing the
+// associations of OpenStack Floating IPs for all configured OpenStack network
+// clients by creating a payload with the respective client scope.
+func enqueueRefreshFloatingIPAssociations(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshDomainProjects(ctx)
+
+	if openstackclients.NetworkClientset.Length() == 0 {
+		logger.Warn("no OpenStack network clients found")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.NetworkClientset.Length())
+	err := openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := RefreshFloatingIPAssociationsPayload{
+			Scope: scope,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack floating IP associations",
+				"project", scope.Project,
+				"domain", scope.Domain,
+				"region", scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskRefreshFloatingIPAssociations, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// refreshFloatingIPAssociations updates the port associations of the
+// OpenStack Floating IPs, which have already been collected, using the client
+// associated with the client scope in the given payload. Only the `port_id',
+// `router_id' and `fixed_ip' columns of existing Floating IPs are updated, and
+// the reassignments are recorded as [auxmodels.ChangeEvent] items. Floating
+// IPs, which have not been collected yet are left to [TaskCollectFloatingIPs].
+func refreshFloatingIPAssociations(ctx context.Context, payload RefreshFloatingIPAssociationsPayload) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	client, err := getClient(ctx, openstackclients.NetworkClientset, payload.Scope)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(
+		"refreshing OpenStack floating IP associations",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+	)
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			floatingIPAssociationUpdatesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		key := metrics.Key(
+			TaskRefreshFloatingIPAssociations,
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	items := make([]floatingIPAssociation, 0)
+	fetch := openstackutils.PageFetcher(
+		client.Client,
+		floatingips.List(client.Client, floatingIPAssociationListOpts{}),
+		newFloatingIPPage,
+		floatingips.ExtractFloatingIPs,
+	)
+
+	err = paginate.Paginate(ctx, fetch, func(ip floatingips.FloatingIP) error {
+		// A disassociated floating IP has no fixed IP, in which case
+		// the fixed IP is cleared.
+		item := floatingIPAssociation{
+			FloatingIPID: ip.ID,
+			ProjectID:    ip.TenantID,
+			PortID:       ip.PortID,
+			RouterID:     ip.RouterID,
+			FixedIP:      net.ParseIP(ip.FixedIP),
+		}
+		items = append(items, item)
+
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(
+			"could not extract floating IP pages",
+			"reason", err,
+		)
+
+		return err
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	// The reassignments are determined within the same transaction as
+	// the update, so that they are recorded only once, and are not left
+	// to the next run of [TaskCollectFloatingIPs], which would not see
+	// any difference anymore.
+	fips := make([]models.FloatingIP, 0, len(items))
+	for _, item := range items {
+		fip := models.FloatingIP{
+			FloatingIPID: item.FloatingIPID,
+			ProjectID:    item.ProjectID,
+			PortID:       item.PortID,
+			RouterID:     item.RouterID,
+			FixedIP:      item.FixedIP,
+		}
+		fips = append(fips, fip)
+	}
+
+	var events []auxmodels.ChangeEvent
+	err = db.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		events, err = floatingIPChangeEvents(ctx, tx, fips)
+		if err != nil {
+			return err
+		}
+
+		out, err := tx.NewUpdate().
+			With("_data", tx.NewValues(&items)).
+			Model((*models.FloatingIP)(nil)).
+			TableExpr("_data").
+			Set("port_id = _data.port_id").
+			Set("router_id = _data.router_id").
+			Set("fixed_ip = _data.fixed_ip").
+			Where("?TableAlias.floating_ip_id = _data.floating_ip_id").
+			Where("?TableAlias.project_id = _data.project_id").
+			Where("(?TableAlias.port_id, ?TableAlias.router_id, ?TableAlias.fixed_ip) IS DISTINCT FROM (_data.port_id, _data.router_id, _data.fixed_ip)").
+			Exec(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		count, err = out.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		_, err = dbutils.BulkInsert(ctx, tx, events, func(q *bun.InsertQuery) *bun.InsertQuery {
+			return q
+		}, 0)
+
+		return err
+	})
+
+	if err != nil {
+		logger.Error(
+			"could not update floating IP associations",
+			"project", payload.Scope.Project,
+			"domain", payload.Scope.Domain,
+			"region", payload.Scope.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"refreshed openstack floating IP associations",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+		"count", count,
+		"changes", len(events),
+	)
+
+	return nil
+}
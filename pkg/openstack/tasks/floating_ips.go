@@ -7,27 +7,37 @@ package tasks
 import (
 	"context"
 	"encoding/json"
-	"net"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/paginate"
 )
 
 const (
 	// TaskCollectFloatingIPs is the name of the task for collecting OpenStack
 	// Floating IPs.
 	TaskCollectFloatingIPs = "openstack:task:collect-floating-ips"
+
+	// DefaultFloatingIPsBatchSize is the default number of OpenStack
+	// Floating IPs, which are fetched per page and flushed to the database
+	// at once.
+	DefaultFloatingIPsBatchSize = 1000
 )
 
 // CollectFloatingIPsPayload represents the payload, which specifies
@@ -35,6 +45,21 @@ const (
 type CollectFloatingIPsPayload struct {
 	// Scope specifies the client scope to use for collection.
 	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Concurrency specifies the max number of pages, which are processed
+	// concurrently. If not specified, pages are processed one at a time.
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency"`
+
+	// BatchSize specifies the max number of items, which are fetched per
+	// page and flushed to the database at once. If not specified,
+	// [DefaultFloatingIPsBatchSize] is used.
+	BatchSize int `json:"batch_size,omitempty" yaml:"batch_size"`
+
+	// ConflictStrategy specifies how floating IPs, which already exist in
+	// the database, are handled. Supported strategies are
+	// [dbutils.ConflictStrategyUpdate] (default) and
+	// [dbutils.ConflictStrategyIgnore].
+	ConflictStrategy string `json:"conflict_strategy,omitempty" yaml:"conflict_strategy"`
 }
 
 // NewCollectFloatingIPsTask creates a new [asynq.Task] for collecting OpenStack
@@ -49,7 +74,7 @@ func HandleCollectFloatingIPsTask(ctx context.Context, t *asynq.Task) error {
 	// collecting OpenStack Floating IPs for all configured clients.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectFloatingIPs(ctx)
+		return enqueueCollectFloatingIPs(ctx, CollectFloatingIPsPayload{})
 	}
 
 	var payload CollectFloatingIPsPayload
@@ -57,6 +82,15 @@ func HandleCollectFloatingIPsTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	if err := dbutils.ValidateConflictStrategy(payload.ConflictStrategy); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectFloatingIPs(ctx, payload)
+	}
+
 	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
 		return asynqutils.SkipRetry(ErrInvalidScope)
 	}
@@ -66,10 +100,12 @@ func HandleCollectFloatingIPsTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectFloatingIPs enqueues tasks for collecting OpenStack Floating IPs for
 // all configured OpenStack network clients by creating a payload with the respective
-// client scope.
-func enqueueCollectFloatingIPs(ctx context.Context) error {
+// client scope and the settings of the given payload.
+func enqueueCollectFloatingIPs(ctx context.Context, settings CollectFloatingIPsPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.NetworkClientset.Length() == 0 {
 		logger.Warn("no OpenStack network clients found")
 
@@ -78,10 +114,14 @@ func enqueueCollectFloatingIPs(ctx context.Context) error {
 
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
-		payload := CollectFloatingIPsPayload{
-			Scope: scope,
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.NetworkClientset.Length())
+	err := openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
 		}
+
+		payload := settings
+		payload.Scope = scope
 		data, err := json.Marshal(payload)
 		if err != nil {
 			logger.Error(
@@ -95,33 +135,24 @@ func enqueueCollectFloatingIPs(ctx context.Context) error {
 			return err
 		}
 
-		task := asynq.NewTask(TaskCollectFloatingIPs, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", scope.Project,
-				"domain", scope.Domain,
-				"region", scope.Region,
-				"reason", err,
-			)
-
-			return err
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", scope.Project,
-			"domain", scope.Domain,
-			"region", scope.Region,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectFloatingIPs, data)
+		})
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// newFloatingIPPage creates a [floatingips.FloatingIPPage] from the given page
+// result.
+func newFloatingIPPage(r pagination.PageResult) pagination.Page {
+	return floatingips.FloatingIPPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
 }
 
 // collectFloatingIPs collects the OpenStack Floating IPs,
@@ -129,9 +160,9 @@ func enqueueCollectFloatingIPs(ctx context.Context) error {
 func collectFloatingIPs(ctx context.Context, payload CollectFloatingIPsPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.NetworkClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.NetworkClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -141,12 +172,12 @@ func collectFloatingIPs(ctx context.Context, payload CollectFloatingIPsPayload)
 		"region", payload.Scope.Region,
 	)
 
-	var count int64
+	var count atomic.Int64
 	defer func() {
 		metric := prometheus.MustNewConstMetric(
 			floatingIPsDesc,
 			prometheus.GaugeValue,
-			float64(count),
+			float64(count.Load()),
 			payload.Scope.Project,
 			payload.Scope.Domain,
 			payload.Scope.Region,
@@ -160,120 +191,177 @@ func collectFloatingIPs(ctx context.Context, payload CollectFloatingIPsPayload)
 		metrics.DefaultCollector.AddMetric(key, metric)
 	}()
 
-	items := make([]models.FloatingIP, 0)
+	// The page size is limited to the batch size, so that items are
+	// flushed to the database as pages are fetched, instead of holding
+	// all items of large projects in memory.
+	batchSize := payload.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultFloatingIPsBatchSize
+	}
+	chunkSize := min(batchSize, dbutils.ChunkSize[models.FloatingIP](db.DB))
 
-	err := floatingips.List(client.Client, nil).
-		EachPage(ctx,
-			func(_ context.Context, page pagination.Page) (bool, error) {
-				floatingIPList, err := floatingips.ExtractFloatingIPs(page)
+	fetch := openstackutils.PageFetcher(
+		client.Client,
+		floatingips.List(client.Client, floatingips.ListOpts{Limit: batchSize}),
+		newFloatingIPPage,
+		floatingips.ExtractFloatingIPs,
+	)
 
-				if err != nil {
-					logger.Error(
-						"could not extract floating IPs pages",
-						"reason", err,
-					)
+	// The ids of the upserted items are collected for reconciling, once
+	// all pages have been processed.
+	var mu sync.Mutex
+	ids := make([]string, 0)
 
-					return false, err
-				}
+	// Each page is upserted separately, so that pages may be processed
+	// concurrently.
+	upsert := func(ctx context.Context, ips []floatingips.FloatingIP) error {
+		items, err := openstackutils.FloatingIPsToModels(ips, client.Domain, client.Region)
+		if err != nil {
+			logger.Warn(
+				"invalid floating IP addresses provided",
+				"project", payload.Scope.Project,
+				"domain", payload.Scope.Domain,
+				"region", payload.Scope.Region,
+				"reason", err,
+			)
+		}
 
-				for _, ip := range floatingIPList {
-					fixedIP := net.ParseIP(ip.FixedIP)
-					floatingIP := net.ParseIP(ip.FloatingIP)
-
-					if fixedIP == nil {
-						logger.Warn(
-							"Invalid fixed IP provided",
-							"fixed IP",
-							ip.FixedIP,
-						)
-
-						continue
-					}
-
-					if floatingIP == nil {
-						logger.Warn(
-							"Invalid floating IP provided",
-							"floating IP",
-							ip.FloatingIP,
-						)
-
-						continue
-					}
-
-					item := models.FloatingIP{
-						FloatingIPID:      ip.ID,
-						ProjectID:         ip.TenantID,
-						Domain:            client.Domain,
-						Region:            client.Region,
-						PortID:            ip.PortID,
-						FixedIP:           fixedIP,
-						RouterID:          ip.RouterID,
-						FloatingIP:        floatingIP,
-						FloatingNetworkID: ip.FloatingNetworkID,
-						Description:       ip.Description,
-						TimeCreated:       ip.CreatedAt,
-						TimeUpdated:       ip.UpdatedAt,
-					}
-					items = append(items, item)
+		if len(items) == 0 {
+			return nil
+		}
+
+		// The existing rows are compared with the collected items
+		// within the same transaction as the upsert, so that the
+		// recorded changes match the updated rows. Existing rows are
+		// not updated with the ignore strategy, so no changes are
+		// recorded in that case.
+		var n int64
+		err = db.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			var events []auxmodels.ChangeEvent
+			if payload.ConflictStrategy != dbutils.ConflictStrategyIgnore {
+				var err error
+				events, err = floatingIPChangeEvents(ctx, tx, items)
+				if err != nil {
+					return err
 				}
+			}
+
+			// Pages exceeding the batch size, e.g. when the API
+			// does not honor the limit, are inserted in chunks of
+			// up to batch size items, which also keeps a single
+			// query below the parameter limit.
+			inserted, err := dbutils.BulkInsert(ctx, tx, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+				return dbutils.OnConflict(q, "(floating_ip_id, project_id)", payload.ConflictStrategy, items).
+					Returning("id")
+			}, chunkSize)
+
+			if err != nil {
+				return err
+			}
+			n = inserted
+
+			if len(events) == 0 {
+				return nil
+			}
+
+			_, err = dbutils.BulkInsert(ctx, tx, events, func(q *bun.InsertQuery) *bun.InsertQuery {
+				return q
+			}, 0)
+
+			if err != nil {
+				return err
+			}
+
+			logger.Info(
+				"recorded floating IP changes",
+				"project", payload.Scope.Project,
+				"domain", payload.Scope.Domain,
+				"region", payload.Scope.Region,
+				"count", len(events),
+			)
 
-				return true, nil
-			})
+			return nil
+		})
 
-	if err != nil {
-		logger.Error(
-			"could not extract floating IP pages",
-			"reason", err,
-		)
+		if err != nil {
+			logger.Error(
+				"could not insert floating IPs into db",
+				"project", payload.Scope.Project,
+				"domain", payload.Scope.Domain,
+				"region", payload.Scope.Region,
+				"reason", err,
+			)
 
-		return err
-	}
+			return err
+		}
+		count.Add(n)
+
+		mu.Lock()
+		for _, item := range items {
+			ids = append(ids, item.FloatingIPID)
+		}
+		mu.Unlock()
 
-	if len(items) == 0 {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (floating_ip_id, project_id) DO UPDATE").
-		Set("domain = EXCLUDED.domain").
-		Set("region = EXCLUDED.region").
-		Set("port_id = EXCLUDED.port_id").
-		Set("fixed_ip = EXCLUDED.fixed_ip").
-		Set("router_id = EXCLUDED.router_id").
-		Set("floating_ip = EXCLUDED.floating_ip").
-		Set("floating_network_id = EXCLUDED.floating_network_id").
-		Set("description = EXCLUDED.description").
-		Set("ip_created_at = EXCLUDED.ip_created_at").
-		Set("ip_updated_at = EXCLUDED.ip_updated_at").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
-
-	if err != nil {
+	if err := paginate.PaginatePages(ctx, fetch, upsert, payload.Concurrency); err != nil {
 		logger.Error(
-			"could not insert floating IPs into db",
-			"project", payload.Scope.Project,
-			"domain", payload.Scope.Domain,
-			"region", payload.Scope.Region,
+			"could not extract floating IP pages",
 			"reason", err,
 		)
 
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack floating IPs",
 		"project", payload.Scope.Project,
 		"domain", payload.Scope.Domain,
 		"region", payload.Scope.Region,
-		"count", count,
+		"count", count.Load(),
 	)
 
-	return nil
+	return reconcileProject[models.FloatingIP](ctx, client, nil, "floating IPs", "floating_ip_id", ids)
+}
+
+// floatingIPChangeEvents returns the [auxmodels.ChangeEvent] items for the
+// given Floating IPs, which differ from the existing rows with the same
+// conflict columns. Floating IPs without an existing row are not reported.
+func floatingIPChangeEvents(ctx context.Context, db bun.IDB, items []models.FloatingIP) ([]auxmodels.ChangeEvent, error) {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.FloatingIPID)
+	}
+
+	existing := make([]models.FloatingIP, 0)
+	err := db.NewSelect().
+		Model(&existing).
+		Where("floating_ip_id IN (?)", bun.In(ids)).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		floatingIPID string
+		projectID    string
+	}
+	rows := make(map[key]models.FloatingIP, len(existing))
+	for _, row := range existing {
+		rows[key{row.FloatingIPID, row.ProjectID}] = row
+	}
+
+	now := time.Now()
+	events := make([]auxmodels.ChangeEvent, 0)
+	for _, item := range items {
+		row, ok := rows[key{item.FloatingIPID, item.ProjectID}]
+		if !ok {
+			continue
+		}
+		events = append(events, openstackutils.FloatingIPChanges(row, item, now)...)
+	}
+
+	return events, nil
 }
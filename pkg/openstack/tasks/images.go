@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/images"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// TaskCollectImages is the name of the task for collecting OpenStack
+	// Images.
+	TaskCollectImages = "openstack:task:collect-images"
+)
+
+// CollectImagesPayload represents the payload, which specifies where to
+// collect OpenStack Images from.
+type CollectImagesPayload struct {
+	// Scope specifies the client scope for which to collect.
+	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+}
+
+// NewCollectImagesTask creates a new [asynq.Task] for collecting OpenStack
+// Images, without specifying a payload.
+func NewCollectImagesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectImages, nil)
+}
+
+// HandleCollectImagesTask handles the task for collecting OpenStack Images.
+func HandleCollectImagesTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting OpenStack Images from all configured image clients.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectImages(ctx)
+	}
+
+	var payload CollectImagesPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
+		return asynqutils.SkipRetry(ErrInvalidScope)
+	}
+
+	return collectImages(ctx, payload)
+}
+
+// enqueueCollectImages enqueues tasks for collecting OpenStack Images from all
+// configured OpenStack image clients by creating a payload with the respective
+// client scope.
+func enqueueCollectImages(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshDomainProjects(ctx)
+
+	if openstackclients.ImageClientset.Length() == 0 {
+		logger.Warn("no OpenStack image clients found")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.ImageClientset.Length())
+	err := openstackclients.ImageClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectImagesPayload{
+			Scope: scope,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack images",
+				"project", scope.Project,
+				"domain", scope.Domain,
+				"region", scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectImages, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectImages collects the OpenStack Images, using the client associated
+// with the client scope in the given payload.
+//
+// The images, which are visible to the project, e.g. public and shared images,
+// are collected along with the images owned by the project, and are stored
+// with the project, which owns them.
+func collectImages(ctx context.Context, payload CollectImagesPayload) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	client, err := getClient(ctx, openstackclients.ImageClientset, payload.Scope)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(
+		"collecting OpenStack images",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+	)
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			imagesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		key := metrics.Key(
+			TaskCollectImages,
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	items := make([]models.Image, 0)
+	err = images.List(client.Client, images.ListOpts{}).
+		EachPage(ctx,
+			func(ctx context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
+				imageList, err := images.ExtractImages(page)
+				if err != nil {
+					logger.Error(
+						"could not extract image pages",
+						"reason", err,
+					)
+
+					return false, err
+				}
+
+				for _, i := range imageList {
+					item := models.Image{
+						ImageID:         i.ID,
+						Name:            i.Name,
+						ProjectID:       i.Owner,
+						Domain:          client.Domain,
+						Region:          client.Region,
+						Status:          string(i.Status),
+						Visibility:      string(i.Visibility),
+						Size:            i.SizeBytes,
+						MinDisk:         i.MinDiskGigabytes,
+						MinRAM:          i.MinRAMMegabytes,
+						DiskFormat:      i.DiskFormat,
+						ContainerFormat: i.ContainerFormat,
+						TimeCreated:     i.CreatedAt,
+						TimeUpdated:     i.UpdatedAt,
+					}
+					items = append(items, item)
+				}
+
+				return true, nil
+			})
+
+	if err != nil {
+		logger.Error(
+			"could not extract image pages",
+			"reason", err,
+		)
+
+		return err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ImageID)
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		q = q.On("CONFLICT (image_id, project_id) DO UPDATE")
+
+		return dbutils.UpsertAllColumns(q, items).
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert images into db",
+			"project", payload.Scope.Project,
+			"domain", payload.Scope.Domain,
+			"region", payload.Scope.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated openstack images",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+		"count", count,
+	)
+
+	return reconcileProject[models.Image](ctx, client, nil, "images", "image_id", ids)
+}
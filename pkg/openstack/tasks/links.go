@@ -7,14 +7,16 @@ package tasks
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
 	"github.com/gardener/inventory/pkg/openstack/models"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 // LinkSubnetsWithNetworks creates links between the OpenStack Subnets and Networks
-func LinkSubnetsWithNetworks(ctx context.Context, db *bun.DB) error {
+func LinkSubnetsWithNetworks(ctx context.Context, db bun.IDB) error {
 	var subnets []models.Subnet
 	err := db.NewSelect().
 		Model(&subnets).
@@ -38,6 +40,10 @@ func LinkSubnetsWithNetworks(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.SubnetToNetwork) []uuid.UUID {
+		return []uuid.UUID{l.SubnetID, l.NetworkID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (subnet_id, network_id) DO UPDATE").
@@ -54,6 +60,8 @@ func LinkSubnetsWithNetworks(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack subnets with networks", "count", count)
 
@@ -61,7 +69,7 @@ func LinkSubnetsWithNetworks(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkLoadBalancersWithSubnets creates links between the OpenStack LoadBalancers and Subnets
-func LinkLoadBalancersWithSubnets(ctx context.Context, db *bun.DB) error {
+func LinkLoadBalancersWithSubnets(ctx context.Context, db bun.IDB) error {
 	var loadbalancers []models.LoadBalancer
 	err := db.NewSelect().
 		Model(&loadbalancers).
@@ -85,6 +93,10 @@ func LinkLoadBalancersWithSubnets(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.LoadBalancerToSubnet) []uuid.UUID {
+		return []uuid.UUID{l.LoadBalancerID, l.SubnetID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (lb_id, subnet_id) DO UPDATE").
@@ -101,6 +113,8 @@ func LinkLoadBalancersWithSubnets(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack load balancers with subnets", "count", count)
 
@@ -108,7 +122,7 @@ func LinkLoadBalancersWithSubnets(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkServersWithProjects creates links between the OpenStack Servers and Projects
-func LinkServersWithProjects(ctx context.Context, db *bun.DB) error {
+func LinkServersWithProjects(ctx context.Context, db bun.IDB) error {
 	var servers []models.Server
 	err := db.NewSelect().
 		Model(&servers).
@@ -132,6 +146,10 @@ func LinkServersWithProjects(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.ServerToProject) []uuid.UUID {
+		return []uuid.UUID{l.ServerID, l.ProjectID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (server_id, project_id) DO UPDATE").
@@ -148,6 +166,8 @@ func LinkServersWithProjects(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack servers with projects", "count", count)
 
@@ -155,7 +175,7 @@ func LinkServersWithProjects(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkLoadBalancersWithProjects creates links between the OpenStack LoadBalancers and Projects
-func LinkLoadBalancersWithProjects(ctx context.Context, db *bun.DB) error {
+func LinkLoadBalancersWithProjects(ctx context.Context, db bun.IDB) error {
 	var loadbalancers []models.LoadBalancer
 	err := db.NewSelect().
 		Model(&loadbalancers).
@@ -179,6 +199,10 @@ func LinkLoadBalancersWithProjects(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.LoadBalancerToProject) []uuid.UUID {
+		return []uuid.UUID{l.LoadBalancerID, l.ProjectID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (lb_id, project_id) DO UPDATE").
@@ -195,6 +219,8 @@ func LinkLoadBalancersWithProjects(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack load balancers with projects", "count", count)
 
@@ -202,7 +228,7 @@ func LinkLoadBalancersWithProjects(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkLoadBalancersWithNetworks creates links between the OpenStack LoadBalancers and Networks
-func LinkLoadBalancersWithNetworks(ctx context.Context, db *bun.DB) error {
+func LinkLoadBalancersWithNetworks(ctx context.Context, db bun.IDB) error {
 	var loadbalancers []models.LoadBalancer
 	err := db.NewSelect().
 		Model(&loadbalancers).
@@ -226,6 +252,10 @@ func LinkLoadBalancersWithNetworks(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.LoadBalancerToNetwork) []uuid.UUID {
+		return []uuid.UUID{l.LoadBalancerID, l.NetworkID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (lb_id, network_id) DO UPDATE").
@@ -242,6 +272,8 @@ func LinkLoadBalancersWithNetworks(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack load balancers with networks", "count", count)
 
@@ -249,7 +281,7 @@ func LinkLoadBalancersWithNetworks(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkNetworksWithProjects creates links between the OpenStack Networks and Projects
-func LinkNetworksWithProjects(ctx context.Context, db *bun.DB) error {
+func LinkNetworksWithProjects(ctx context.Context, db bun.IDB) error {
 	var networks []models.Network
 	err := db.NewSelect().
 		Model(&networks).
@@ -273,6 +305,10 @@ func LinkNetworksWithProjects(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.NetworkToProject) []uuid.UUID {
+		return []uuid.UUID{l.NetworkID, l.ProjectID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (network_id, project_id) DO UPDATE").
@@ -289,6 +325,8 @@ func LinkNetworksWithProjects(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack networks with projects", "count", count)
 
@@ -296,7 +334,7 @@ func LinkNetworksWithProjects(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkSubnetsWithProjects creates links between the OpenStack Subnets and Projects
-func LinkSubnetsWithProjects(ctx context.Context, db *bun.DB) error {
+func LinkSubnetsWithProjects(ctx context.Context, db bun.IDB) error {
 	var subnets []models.Subnet
 	err := db.NewSelect().
 		Model(&subnets).
@@ -320,6 +358,10 @@ func LinkSubnetsWithProjects(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.SubnetToProject) []uuid.UUID {
+		return []uuid.UUID{l.SubnetID, l.ProjectID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (subnet_id, project_id) DO UPDATE").
@@ -336,6 +378,8 @@ func LinkSubnetsWithProjects(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack subnets with projects", "count", count)
 
@@ -343,7 +387,7 @@ func LinkSubnetsWithProjects(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkPortsWithServers creates links between the OpenStack Ports and Servers
-func LinkPortsWithServers(ctx context.Context, db *bun.DB) error {
+func LinkPortsWithServers(ctx context.Context, db bun.IDB) error {
 	var ports []models.Port
 	err := db.NewSelect().
 		Model(&ports).
@@ -368,6 +412,10 @@ func LinkPortsWithServers(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.PortToServer) []uuid.UUID {
+		return []uuid.UUID{l.PortID, l.ServerID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (port_id, server_id) DO UPDATE").
@@ -384,6 +432,8 @@ func LinkPortsWithServers(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack ports with servers", "count", count)
 
@@ -391,7 +441,7 @@ func LinkPortsWithServers(ctx context.Context, db *bun.DB) error {
 }
 
 // LinkServersWithNetworks creates links between the OpenStack Servers and Networks
-func LinkServersWithNetworks(ctx context.Context, db *bun.DB) error {
+func LinkServersWithNetworks(ctx context.Context, db bun.IDB) error {
 	var ports []models.Port
 	err := db.NewSelect().
 		Model(&ports).
@@ -417,6 +467,10 @@ func LinkServersWithNetworks(ctx context.Context, db *bun.DB) error {
 		return nil
 	}
 
+	dbutils.SortLinks(links, func(l models.ServerToNetwork) []uuid.UUID {
+		return []uuid.UUID{l.ServerID, l.NetworkID}
+	})
+
 	out, err := db.NewInsert().
 		Model(&links).
 		On("CONFLICT (server_id, network_id) DO UPDATE").
@@ -433,8 +487,427 @@ func LinkServersWithNetworks(ctx context.Context, db *bun.DB) error {
 		return err
 	}
 
+	dbutils.AddLinks(ctx, count)
+
 	logger := asynqutils.GetLogger(ctx)
 	logger.Info("linked openstack servers with networks", "count", count)
 
 	return nil
 }
+
+// LinkShareWithShareNetwork creates links between the OpenStack Shares and
+// Share Networks
+func LinkShareWithShareNetwork(ctx context.Context, db bun.IDB) error {
+	var shares []models.Share
+	err := db.NewSelect().
+		Model(&shares).
+		Relation("ShareNetwork").
+		Where("share_network.id IS NOT NULL").
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.ShareToShareNetwork, 0, len(shares))
+
+	for _, share := range shares {
+		links = append(links, models.ShareToShareNetwork{
+			ShareID:        share.ID,
+			ShareNetworkID: share.ShareNetwork.ID,
+		})
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.ShareToShareNetwork) []uuid.UUID {
+		return []uuid.UUID{l.ShareID, l.ShareNetworkID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (share_id, share_network_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked openstack shares with share networks", "count", count)
+
+	return nil
+}
+
+// LinkSecurityGroupRuleWithGroup creates links between the OpenStack Security
+// Group Rules and Security Groups.
+//
+// Rules, whose security group has not been collected yet are skipped, and are
+// linked by a subsequent link run.
+func LinkSecurityGroupRuleWithGroup(ctx context.Context, db bun.IDB) error {
+	var rules []models.SecurityGroupRule
+	err := db.NewSelect().
+		Model(&rules).
+		Relation("SecurityGroup").
+		Where("security_group.id IS NOT NULL").
+		Scan(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	links := make([]models.SecurityGroupRuleToGroup, 0, len(rules))
+	for _, rule := range rules {
+		links = append(links, models.SecurityGroupRuleToGroup{
+			SecurityGroupID: rule.SecurityGroup.ID,
+			RuleID:          rule.ID,
+		})
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.SecurityGroupRuleToGroup) []uuid.UUID {
+		return []uuid.UUID{l.SecurityGroupID, l.RuleID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (security_group_id, rule_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked openstack security group rules with security groups", "count", count)
+
+	return nil
+}
+
+// LinkPortWithSecurityGroup creates links between the OpenStack Ports and the
+// Security Groups associated with them. Ports with disabled port security have
+// no security groups, and are not linked.
+func LinkPortWithSecurityGroup(ctx context.Context, db bun.IDB) error {
+	links := make([]models.PortToSecurityGroup, 0)
+	err := db.NewSelect().
+		TableExpr("openstack_port AS p").
+		Join("CROSS JOIN LATERAL unnest(p.security_groups) AS psg(security_group_id)").
+		Join("INNER JOIN openstack_security_group AS sg").
+		JoinOn("sg.security_group_id = psg.security_group_id").
+		JoinOn("sg.region = p.region").
+		ColumnExpr("p.id AS port_id").
+		ColumnExpr("sg.id AS security_group_id").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.PortToSecurityGroup) []uuid.UUID {
+		return []uuid.UUID{l.PortID, l.SecurityGroupID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (port_id, security_group_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked openstack ports with security groups", "count", count)
+
+	return nil
+}
+
+// LinkFloatingIPWithServer creates links between the OpenStack Floating IPs and
+// the Servers they are associated with. The server of a floating IP is the
+// device of the port, to which the floating IP is attached.
+func LinkFloatingIPWithServer(ctx context.Context, db bun.IDB) error {
+	links := make([]models.FloatingIPToServer, 0)
+	err := db.NewSelect().
+		TableExpr("openstack_floating_ip AS fip").
+		Join("INNER JOIN openstack_port AS p").
+		JoinOn("p.port_id = fip.port_id").
+		JoinOn("p.region = fip.region").
+		Join("INNER JOIN openstack_server AS s").
+		JoinOn("s.server_id = p.device_id").
+		JoinOn("s.project_id = p.project_id").
+		ColumnExpr("fip.id AS floating_ip_id").
+		ColumnExpr("s.id AS server_id").
+		Where("fip.port_id <> ''").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.FloatingIPToServer) []uuid.UUID {
+		return []uuid.UUID{l.FloatingIPID, l.ServerID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (floating_ip_id, server_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked openstack floating ips with servers", "count", count)
+
+	return nil
+}
+
+// LinkFloatingIPWithRouter creates links between the OpenStack Floating IPs and
+// the Routers, through which they are reachable. Floating IPs, which are not
+// associated with a router, are skipped.
+func LinkFloatingIPWithRouter(ctx context.Context, db bun.IDB) error {
+	links := make([]models.FloatingIPToRouter, 0)
+	err := db.NewSelect().
+		TableExpr("openstack_floating_ip AS fip").
+		Join("INNER JOIN openstack_router AS r").
+		JoinOn("r.router_id = fip.router_id").
+		JoinOn("r.region = fip.region").
+		ColumnExpr("r.id AS router_id").
+		ColumnExpr("fip.id AS floating_ip_id").
+		Where("fip.router_id <> ''").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.FloatingIPToRouter) []uuid.UUID {
+		return []uuid.UUID{l.RouterID, l.FloatingIPID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (router_id, floating_ip_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked openstack floating ips with routers", "count", count)
+
+	return nil
+}
+
+// LinkFloatingIPWithNetwork creates links between the OpenStack Floating IPs
+// and the external Networks they are allocated from.
+func LinkFloatingIPWithNetwork(ctx context.Context, db bun.IDB) error {
+	links := make([]models.FloatingIPToNetwork, 0)
+	err := db.NewSelect().
+		TableExpr("openstack_floating_ip AS fip").
+		Join("INNER JOIN openstack_network AS n").
+		JoinOn("n.network_id = fip.floating_network_id").
+		JoinOn("n.region = fip.region").
+		ColumnExpr("n.id AS network_id").
+		ColumnExpr("fip.id AS floating_ip_id").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.FloatingIPToNetwork) []uuid.UUID {
+		return []uuid.UUID{l.NetworkID, l.FloatingIPID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (network_id, floating_ip_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked openstack floating ips with networks", "count", count)
+
+	return nil
+}
+
+// LinkVolumeWithServer creates links between the OpenStack Volumes and the
+// Servers they are attached to.
+func LinkVolumeWithServer(ctx context.Context, db bun.IDB) error {
+	links := make([]models.VolumeToServer, 0)
+	err := db.NewSelect().
+		TableExpr("openstack_volume AS v").
+		Join("CROSS JOIN LATERAL unnest(v.server_ids) AS vs(server_id)").
+		Join("INNER JOIN openstack_server AS s").
+		JoinOn("s.server_id = vs.server_id").
+		JoinOn("s.project_id = v.project_id").
+		ColumnExpr("v.id AS volume_id").
+		ColumnExpr("s.id AS server_id").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.VolumeToServer) []uuid.UUID {
+		return []uuid.UUID{l.VolumeID, l.ServerID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (volume_id, server_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked openstack volumes with servers", "count", count)
+
+	return nil
+}
+
+// LinkServerWithImage creates links between the OpenStack Servers and the
+// Images they were booted from.
+func LinkServerWithImage(ctx context.Context, db bun.IDB) error {
+	links := make([]models.ServerToImage, 0)
+	err := db.NewSelect().
+		TableExpr("openstack_server AS s").
+		Join("INNER JOIN openstack_image AS i").
+		JoinOn("i.image_id = s.image_id").
+		JoinOn("i.region = s.region").
+		ColumnExpr("s.id AS server_id").
+		ColumnExpr("i.id AS image_id").
+		Where("s.image_id <> ''").
+		Scan(ctx, &links)
+
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	dbutils.SortLinks(links, func(l models.ServerToImage) []uuid.UUID {
+		return []uuid.UUID{l.ServerID, l.ImageID}
+	})
+
+	out, err := db.NewInsert().
+		Model(&links).
+		On("CONFLICT (server_id, image_id) DO UPDATE").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("id").
+		Exec(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	count, err := out.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	dbutils.AddLinks(ctx, count)
+
+	logger := asynqutils.GetLogger(ctx)
+	logger.Info("linked openstack servers with images", "count", count)
+
+	return nil
+}
@@ -13,14 +13,15 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -69,57 +70,48 @@ func HandleCollectLoadBalancersTask(ctx context.Context, t *asynq.Task) error {
 func enqueueCollectLoadBalancers(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.LoadBalancerClientset.Length() == 0 {
 		logger.Warn("no OpenStack loadbalancer clients found")
 
 		return nil
 	}
 
-	return openstackclients.LoadBalancerClientset.
-		Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
-			payload := CollectLoadBalancersPayload{
-				Scope: scope,
-			}
-			data, err := json.Marshal(payload)
-			if err != nil {
-				logger.Error(
-					"failed to marshal payload for OpenStack load balancers",
-					"project", scope.Project,
-					"domain", scope.Domain,
-					"region", scope.Region,
-					"reason", err,
-				)
-
-				return err
-			}
-
-			task := asynq.NewTask(TaskCollectLoadBalancers, data)
-			info, err := asynqclient.Client.Enqueue(task)
-			if err != nil {
-				logger.Error(
-					"failed to enqueue task",
-					"type", task.Type(),
-					"project", scope.Project,
-					"domain", scope.Domain,
-					"region", scope.Region,
-					"reason", err,
-				)
-
-				return err
-			}
-
-			logger.Info(
-				"enqueued task",
-				"type", task.Type(),
-				"id", info.ID,
-				"queue", info.Queue,
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.LoadBalancerClientset.Length())
+	err := openstackclients.LoadBalancerClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectLoadBalancersPayload{
+			Scope: scope,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack load balancers",
 				"project", scope.Project,
 				"domain", scope.Domain,
 				"region", scope.Region,
+				"reason", err,
 			)
 
-			return nil
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectLoadBalancers, data)
 		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns)
 }
 
 // collectLoadBalancers collects the OpenStack LoadBalancers,
@@ -127,9 +119,9 @@ func enqueueCollectLoadBalancers(ctx context.Context) error {
 func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.LoadBalancerClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.LoadBalancerClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -161,9 +153,12 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 	items := make([]models.LoadBalancer, 0)
 	lbWithPoolItems := make([]models.LoadBalancerWithPool, 0)
 
-	err := loadbalancers.List(client.Client, nil).
+	err = loadbalancers.List(client.Client, nil).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				lbList, err := loadbalancers.ExtractLoadBalancers(page)
 
 				if err != nil {
@@ -190,19 +185,21 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 					}
 
 					item := models.LoadBalancer{
-						LoadBalancerID: lb.ID,
-						Name:           lb.Name,
-						ProjectID:      lb.ProjectID,
-						Domain:         client.Domain,
-						Region:         client.Region,
-						Status:         lb.OperatingStatus,
-						Description:    lb.Description,
-						Provider:       lb.Provider,
-						VipAddress:     lb.VipAddress,
-						VipNetworkID:   lb.VipNetworkID,
-						VipSubnetID:    lb.VipSubnetID,
-						TimeCreated:    lb.CreatedAt,
-						TimeUpdated:    lb.UpdatedAt,
+						LoadBalancerID:     lb.ID,
+						Name:               lb.Name,
+						ProjectID:          lb.ProjectID,
+						Domain:             client.Domain,
+						Region:             client.Region,
+						Status:             lb.OperatingStatus,
+						ProvisioningStatus: lb.ProvisioningStatus,
+						Description:        lb.Description,
+						Provider:           lb.Provider,
+						VipAddress:         lb.VipAddress,
+						VipNetworkID:       lb.VipNetworkID,
+						VipSubnetID:        lb.VipSubnetID,
+						VipPortID:          lb.VipPortID,
+						TimeCreated:        lb.CreatedAt,
+						TimeUpdated:        lb.UpdatedAt,
 					}
 
 					items = append(items, item)
@@ -220,27 +217,33 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 		return err
 	}
 
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.LoadBalancerID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.LoadBalancer](ctx, client, nil, "load balancers", "loadbalancer_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (loadbalancer_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("domain = EXCLUDED.domain").
-		Set("region = EXCLUDED.region").
-		Set("status = EXCLUDED.status").
-		Set("provider = EXCLUDED.provider").
-		Set("vip_address = EXCLUDED.vip_address").
-		Set("vip_network_id = EXCLUDED.vip_network_id").
-		Set("vip_subnet_id = EXCLUDED.vip_subnet_id").
-		Set("description = EXCLUDED.description").
-		Set("loadbalancer_created_at = EXCLUDED.loadbalancer_created_at").
-		Set("loadbalancer_updated_at = EXCLUDED.loadbalancer_updated_at").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (loadbalancer_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("region = EXCLUDED.region").
+			Set("status = EXCLUDED.status").
+			Set("provisioning_status = EXCLUDED.provisioning_status").
+			Set("provider = EXCLUDED.provider").
+			Set("vip_address = EXCLUDED.vip_address").
+			Set("vip_network_id = EXCLUDED.vip_network_id").
+			Set("vip_subnet_id = EXCLUDED.vip_subnet_id").
+			Set("vip_port_id = EXCLUDED.vip_port_id").
+			Set("description = EXCLUDED.description").
+			Set("loadbalancer_created_at = EXCLUDED.loadbalancer_created_at").
+			Set("loadbalancer_updated_at = EXCLUDED.loadbalancer_updated_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -254,11 +257,6 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack load balancers",
 		"project", payload.Scope.Project,
@@ -267,16 +265,19 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 		"count", count,
 	)
 
+	if err := reconcileProject[models.LoadBalancer](ctx, client, nil, "load balancers", "loadbalancer_id", ids); err != nil {
+		return err
+	}
+
 	if len(lbWithPoolItems) == 0 {
 		return nil
 	}
 
-	out, err = db.DB.NewInsert().
-		Model(&lbWithPoolItems).
-		On("CONFLICT (loadbalancer_id, pool_id, project_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, lbWithPoolItems, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (loadbalancer_id, pool_id, project_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -290,11 +291,6 @@ func collectLoadBalancers(ctx context.Context, payload CollectLoadBalancersPaylo
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack load balancers with pools",
 		"project", payload.Scope.Project,
@@ -14,7 +14,7 @@ var (
 	// serversDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Servers
 	serversDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_servers"),
+		"openstack_servers",
 		"A gauge which tracks the number of collected OpenStack Servers",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -23,7 +23,7 @@ var (
 	// networksDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Networks
 	networksDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_networks"),
+		"openstack_networks",
 		"A gauge which tracks the number of collected OpenStack Networks",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -32,7 +32,7 @@ var (
 	// subnetsDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Subnets
 	subnetsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_subnets"),
+		"openstack_subnets",
 		"A gauge which tracks the number of collected OpenStack Subnets",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -41,7 +41,7 @@ var (
 	// loadbalancersDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Loadbalancers
 	loadbalancersDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_loadbalancers"),
+		"openstack_loadbalancers",
 		"A gauge which tracks the number of collected OpenStack Loadbalancers",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -50,7 +50,7 @@ var (
 	// projectsDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Projects
 	projectsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_projects"),
+		"openstack_projects",
 		"A gauge which tracks the number of collected OpenStack Projects",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -59,16 +59,26 @@ var (
 	// floatingIPsDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Floating IPs
 	floatingIPsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_floating_ips"),
+		"openstack_floating_ips",
 		"A gauge which tracks the number of collected OpenStack Floating IPs",
 		[]string{"project", "domain", "region"},
 		nil,
 	)
 
+	// floatingIPAssociationUpdatesDesc is the descriptor for a metric,
+	// which tracks the number of OpenStack Floating IPs with updated
+	// port or router associations
+	floatingIPAssociationUpdatesDesc = prometheus.NewDesc(
+		"openstack_floating_ip_association_updates",
+		"A gauge which tracks the number of OpenStack Floating IPs with updated port or router associations",
+		[]string{"project", "domain", "region"},
+		nil,
+	)
+
 	// portsDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Ports
 	portsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_ports"),
+		"openstack_ports",
 		"A gauge which tracks the number of collected OpenStack Ports",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -77,7 +87,7 @@ var (
 	// routersDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Routers
 	routersDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_routers"),
+		"openstack_routers",
 		"A gauge which tracks the number of collected OpenStack Routers",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -86,16 +96,26 @@ var (
 	// objectsDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Objects
 	objectsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_objects"),
+		"openstack_objects",
 		"A gauge which tracks the number of collected OpenStack Objects",
 		[]string{"project", "domain", "region"},
 		nil,
 	)
 
+	// objectContainersSkippedDesc is the descriptor for a metric, which
+	// tracks the number of containers opted into object enumeration,
+	// which were skipped, e.g. because they exceed the max objects.
+	objectContainersSkippedDesc = prometheus.NewDesc(
+		"openstack_object_containers_skipped",
+		"A gauge which tracks the number of skipped containers opted into object enumeration",
+		[]string{"project", "domain", "region"},
+		nil,
+	)
+
 	// poolsDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Pools
 	poolsDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_pools"),
+		"openstack_pools",
 		"A gauge which tracks the number of collected OpenStack Pools",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -104,7 +124,7 @@ var (
 	// containersDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack Containers
 	containersDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_containers"),
+		"openstack_containers",
 		"A gauge which tracks the number of collected OpenStack Containers",
 		[]string{"project", "domain", "region"},
 		nil,
@@ -113,11 +133,74 @@ var (
 	// volumesDesc is the descriptor for a metric,
 	// which tracks the number of collected OpenStack volumes
 	volumesDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(metrics.Namespace, "", "openstack_volumes"),
+		"openstack_volumes",
 		"A gauge which tracks the number of collected OpenStack Volumes",
 		[]string{"project", "domain", "region"},
 		nil,
 	)
+
+	// imagesDesc is the descriptor for a metric,
+	// which tracks the number of collected OpenStack images
+	imagesDesc = prometheus.NewDesc(
+		"openstack_images",
+		"A gauge which tracks the number of collected OpenStack Images",
+		[]string{"project", "domain", "region"},
+		nil,
+	)
+
+	// sharesDesc is the descriptor for a metric,
+	// which tracks the number of collected OpenStack shares
+	sharesDesc = prometheus.NewDesc(
+		"openstack_shares",
+		"A gauge which tracks the number of collected OpenStack Shares",
+		[]string{"project", "domain", "region"},
+		nil,
+	)
+
+	// shareNetworksDesc is the descriptor for a metric,
+	// which tracks the number of collected OpenStack share networks
+	shareNetworksDesc = prometheus.NewDesc(
+		"openstack_share_networks",
+		"A gauge which tracks the number of collected OpenStack Share Networks",
+		[]string{"project", "domain", "region"},
+		nil,
+	)
+
+	// securityGroupsDesc is the descriptor for a metric,
+	// which tracks the number of collected OpenStack security groups
+	securityGroupsDesc = prometheus.NewDesc(
+		"openstack_security_groups",
+		"A gauge which tracks the number of collected OpenStack Security Groups",
+		[]string{"project", "domain", "region"},
+		nil,
+	)
+
+	// securityGroupRulesDesc is the descriptor for a metric,
+	// which tracks the number of collected OpenStack security group rules
+	securityGroupRulesDesc = prometheus.NewDesc(
+		"openstack_security_group_rules",
+		"A gauge which tracks the number of collected OpenStack Security Group Rules",
+		[]string{"project", "domain", "region"},
+		nil,
+	)
+
+	// quotaUsageRatioDesc is the descriptor for a metric,
+	// which tracks the usage ratio of OpenStack compute quotas
+	quotaUsageRatioDesc = prometheus.NewDesc(
+		"openstack_quota_usage_ratio",
+		"A gauge which tracks the usage ratio of OpenStack compute quotas",
+		[]string{"project_id", "resource", "domain", "region"},
+		nil,
+	)
+
+	// exposureFindingsDesc is the descriptor for a metric,
+	// which tracks the number of OpenStack exposure findings
+	exposureFindingsDesc = prometheus.NewDesc(
+		"openstack_exposure_findings",
+		"A gauge which tracks the number of OpenStack exposure findings",
+		[]string{"severity"},
+		nil,
+	)
 )
 
 func init() {
@@ -128,10 +211,19 @@ func init() {
 		loadbalancersDesc,
 		projectsDesc,
 		floatingIPsDesc,
+		floatingIPAssociationUpdatesDesc,
 		portsDesc,
 		routersDesc,
 		objectsDesc,
+		objectContainersSkippedDesc,
 		poolsDesc,
 		containersDesc,
+		imagesDesc,
+		sharesDesc,
+		shareNetworksDesc,
+		securityGroupsDesc,
+		securityGroupRulesDesc,
+		quotaUsageRatioDesc,
+		exposureFindingsDesc,
 	)
 }
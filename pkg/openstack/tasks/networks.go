@@ -9,18 +9,20 @@ import (
 	"encoding/json"
 
 	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/external"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/networks"
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -34,6 +36,11 @@ const (
 type CollectNetworksPayload struct {
 	// Scope specifies the client scope for which to collect.
 	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Filters optionally specifies the metadata or tags, which the
+	// collected resources must have. See [openstackutils.MatchFilters] for
+	// details. If empty, all resources are collected.
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters"`
 }
 
 // NewCollectNetworksTask creates a new [asynq.Task] for collecting OpenStack
@@ -48,7 +55,7 @@ func HandleCollectNetworksTask(ctx context.Context, t *asynq.Task) error {
 	// collecting OpenStack Networks for all configured clients.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectNetworks(ctx)
+		return enqueueCollectNetworks(ctx, nil)
 	}
 
 	var payload CollectNetworksPayload
@@ -56,19 +63,52 @@ func HandleCollectNetworksTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectNetworks(ctx, payload.Filters)
+	}
+
 	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
 		return asynqutils.SkipRetry(ErrInvalidScope)
 	}
 
-	return collectNetworks(ctx, payload)
+	if err := collectNetworks(ctx, payload); err != nil {
+		return err
+	}
+
+	if !asynqutils.CollectChildrenInline(TaskCollectNetworks) {
+		return nil
+	}
+
+	return collectNetworkChildren(ctx, payload)
+}
+
+// collectNetworkChildren collects the OpenStack Subnets from the same scope as
+// the networks and links them with their networks, without waiting for the
+// separate collection and link tasks.
+func collectNetworkChildren(ctx context.Context, payload CollectNetworksPayload) error {
+	subnetsPayload := CollectSubnetsPayload{
+		Scope: payload.Scope,
+	}
+	if err := collectSubnets(ctx, subnetsPayload); err != nil {
+		return err
+	}
+
+	linkFns := []dbutils.LinkFunction{
+		LinkSubnetsWithNetworks,
+	}
+
+	return dbutils.LinkObjects(ctx, db.DB, linkFns)
 }
 
 // enqueueCollectNetworks enqueues tasks for collecting OpenStack Networks from
 // all configured OpenStack network clients by creating a payload with the respective
-// client scope.
-func enqueueCollectNetworks(ctx context.Context) error {
+// client scope and the given filters.
+func enqueueCollectNetworks(ctx context.Context, filters map[string]string) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.NetworkClientset.Length() == 0 {
 		logger.Warn("no OpenStack network clients found")
 
@@ -77,9 +117,15 @@ func enqueueCollectNetworks(ctx context.Context) error {
 
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.NetworkClientset.Length())
+	err := openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
 		payload := CollectNetworksPayload{
-			Scope: scope,
+			Scope:   scope,
+			Filters: filters,
 		}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -94,33 +140,18 @@ func enqueueCollectNetworks(ctx context.Context) error {
 			return err
 		}
 
-		task := asynq.NewTask(TaskCollectNetworks, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", scope.Project,
-				"domain", scope.Domain,
-				"region", scope.Region,
-				"reason", err,
-			)
-
-			return err
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", scope.Project,
-			"domain", scope.Domain,
-			"region", scope.Region,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectNetworks, data)
+		})
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectNetworks collects the OpenStack Networks,
@@ -128,9 +159,9 @@ func enqueueCollectNetworks(ctx context.Context) error {
 func collectNetworks(ctx context.Context, payload CollectNetworksPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.NetworkClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.NetworkClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -151,7 +182,7 @@ func collectNetworks(ctx context.Context, payload CollectNetworksPayload) error
 			payload.Scope.Region,
 		)
 		key := metrics.Key(
-			TaskCollectServers,
+			TaskCollectNetworks,
 			payload.Scope.Project,
 			payload.Scope.Domain,
 			payload.Scope.Region,
@@ -161,10 +192,19 @@ func collectNetworks(ctx context.Context, payload CollectNetworksPayload) error
 
 	items := make([]models.Network, 0)
 
-	err := networks.List(client.Client, nil).
+	err = networks.List(client.Client, nil).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
-				networkList, err := networks.ExtractNetworks(page)
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
+				// The external flag is provided by the external
+				// network extension.
+				var networkList []struct {
+					networks.Network
+					external.NetworkExternalExt
+				}
+				err := networks.ExtractNetworksInto(page, &networkList)
 
 				if err != nil {
 					logger.Error(
@@ -176,6 +216,10 @@ func collectNetworks(ctx context.Context, payload CollectNetworksPayload) error
 				}
 
 				for _, n := range networkList {
+					if !openstackutils.MatchFilters(payload.Filters, nil, n.Tags) {
+						continue
+					}
+
 					item := models.Network{
 						NetworkID:   n.ID,
 						Name:        n.Name,
@@ -184,9 +228,11 @@ func collectNetworks(ctx context.Context, payload CollectNetworksPayload) error
 						Region:      client.Region,
 						Status:      n.Status,
 						Shared:      n.Shared,
+						External:    n.External,
 						Description: n.Description,
 						TimeCreated: n.CreatedAt,
 						TimeUpdated: n.UpdatedAt,
+						Tags:        openstackutils.TagsToMap(n.Tags),
 					}
 
 					items = append(items, item)
@@ -204,24 +250,30 @@ func collectNetworks(ctx context.Context, payload CollectNetworksPayload) error
 		return err
 	}
 
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.NetworkID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.Network](ctx, client, payload.Filters, "networks", "network_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (network_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("domain = EXCLUDED.domain").
-		Set("region = EXCLUDED.region").
-		Set("status = EXCLUDED.status").
-		Set("shared = EXCLUDED.shared").
-		Set("description = EXCLUDED.description").
-		Set("network_created_at = EXCLUDED.network_created_at").
-		Set("network_updated_at = EXCLUDED.network_updated_at").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (network_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("region = EXCLUDED.region").
+			Set("status = EXCLUDED.status").
+			Set("shared = EXCLUDED.shared").
+			Set("external = EXCLUDED.external").
+			Set("description = EXCLUDED.description").
+			Set("network_created_at = EXCLUDED.network_created_at").
+			Set("network_updated_at = EXCLUDED.network_updated_at").
+			Set("tags = EXCLUDED.tags").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -235,11 +287,6 @@ func collectNetworks(ctx context.Context, payload CollectNetworksPayload) error
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack networks",
 		"project", payload.Scope.Project,
@@ -248,5 +295,5 @@ func collectNetworks(ctx context.Context, payload CollectNetworksPayload) error
 		"count", count,
 	)
 
-	return nil
+	return reconcileProject[models.Network](ctx, client, payload.Filters, "networks", "network_id", ids)
 }
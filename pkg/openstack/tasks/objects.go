@@ -14,27 +14,50 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
 	// TaskCollectObjects is the name of the task for collecting OpenStack
 	// Objects.
 	TaskCollectObjects = "openstack:task:collect-objects"
+
+	// DefaultMaxObjects specifies the default max number of objects in a
+	// container, for which objects are enumerated.
+	DefaultMaxObjects = 10000
 )
 
+// ObjectEnumerationConfig opts a container into object enumeration.
+type ObjectEnumerationConfig struct {
+	// Name specifies the name of the container.
+	Name string `json:"name" yaml:"name"`
+
+	// MaxObjects specifies the max number of objects in the container.
+	// Containers with more objects are skipped. If not specified,
+	// [DefaultMaxObjects] is used.
+	MaxObjects int64 `json:"max_objects" yaml:"max_objects"`
+}
+
 // CollectObjectsPayload represents the payload, which specifies
 // where to collect OpenStack Objects from.
 type CollectObjectsPayload struct {
-	// Scope specifies the client scope for which to collect.
+	// Scope specifies the client scope for which to collect. If not
+	// specified, tasks are enqueued for all configured object storage
+	// clients.
 	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Containers specifies the containers, which are opted into object
+	// enumeration. Objects are never collected from containers, which
+	// are not explicitly listed here.
+	Containers []ObjectEnumerationConfig `json:"containers" yaml:"containers"`
 }
 
 // NewCollectObjectsTask creates a new [asynq.Task] for collecting OpenStack
@@ -44,17 +67,29 @@ func NewCollectObjectsTask() *asynq.Task {
 }
 
 // HandleCollectObjectsTask handles the task for collecting OpenStack Objects.
+//
+// Listing all objects of large containers is expensive, so objects are
+// collected only from the containers specified in the payload. The
+// container-level metadata is collected by [TaskCollectContainers].
 func HandleCollectObjectsTask(ctx context.Context, t *asynq.Task) error {
-	// If we were called without a payload, then we enqueue tasks for
-	// collecting OpenStack Objects from all configured object clients.
-	data := t.Payload()
-	if data == nil {
-		return enqueueCollectObjects(ctx)
+	var payload CollectObjectsPayload
+	if data := t.Payload(); data != nil {
+		if err := asynqutils.Unmarshal(data, &payload); err != nil {
+			return asynqutils.SkipRetry(err)
+		}
 	}
 
-	var payload CollectObjectsPayload
-	if err := asynqutils.Unmarshal(data, &payload); err != nil {
-		return asynqutils.SkipRetry(err)
+	if len(payload.Containers) == 0 {
+		logger := asynqutils.GetLogger(ctx)
+		logger.Info("no containers opted into object enumeration")
+
+		return nil
+	}
+
+	// If we were called without a scope, then we enqueue tasks for
+	// collecting OpenStack Objects from all configured object clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectObjects(ctx, payload.Containers)
 	}
 
 	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
@@ -66,10 +101,12 @@ func HandleCollectObjectsTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectObjects enqueues tasks for collecting OpenStack Objects from
 // all configured OpenStack Object clients by creating a payload with the respective
-// client scope.
-func enqueueCollectObjects(ctx context.Context) error {
+// client scope and the given containers.
+func enqueueCollectObjects(ctx context.Context, containers []ObjectEnumerationConfig) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.ObjectStorageClientset.Length() == 0 {
 		logger.Warn("no OpenStack object storage clients found")
 
@@ -78,51 +115,41 @@ func enqueueCollectObjects(ctx context.Context) error {
 
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.ObjectStorageClientset.
-		Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
-			payload := CollectObjectsPayload{
-				Scope: scope,
-			}
-			data, err := json.Marshal(payload)
-			if err != nil {
-				logger.Error(
-					"failed to marshal payload for OpenStack objects",
-					"project", scope.Project,
-					"domain", scope.Domain,
-					"region", scope.Region,
-					"reason", err,
-				)
-
-				return err
-			}
-
-			task := asynq.NewTask(TaskCollectObjects, data)
-			info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-			if err != nil {
-				logger.Error(
-					"failed to enqueue task",
-					"type", task.Type(),
-					"project", scope.Project,
-					"domain", scope.Domain,
-					"region", scope.Region,
-					"reason", err,
-				)
-
-				return err
-			}
-
-			logger.Info(
-				"enqueued task",
-				"type", task.Type(),
-				"id", info.ID,
-				"queue", info.Queue,
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.ObjectStorageClientset.Length())
+	err := openstackclients.ObjectStorageClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectObjectsPayload{
+			Scope:      scope,
+			Containers: containers,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack objects",
 				"project", scope.Project,
 				"domain", scope.Domain,
 				"region", scope.Region,
+				"reason", err,
 			)
 
-			return nil
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectObjects, data)
 		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectObject collects the OpenStack Objects,
@@ -130,9 +157,9 @@ func enqueueCollectObjects(ctx context.Context) error {
 func collectObjects(ctx context.Context, payload CollectObjectsPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.ObjectStorageClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.ObjectStorageClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -142,9 +169,8 @@ func collectObjects(ctx context.Context, payload CollectObjectsPayload) error {
 		"region", payload.Scope.Region,
 	)
 
-	containerNames := make([]string, 0)
-
 	var count int64
+	var skipped int
 	defer func() {
 		metric := prometheus.MustNewConstMetric(
 			objectsDesc,
@@ -161,14 +187,35 @@ func collectObjects(ctx context.Context, payload CollectObjectsPayload) error {
 			payload.Scope.Region,
 		)
 		metrics.DefaultCollector.AddMetric(key, metric)
-	}()
 
-	items := make([]models.Object, 0)
+		skippedMetric := prometheus.MustNewConstMetric(
+			objectContainersSkippedDesc,
+			prometheus.GaugeValue,
+			float64(skipped),
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		skippedKey := metrics.Key(
+			TaskCollectObjects,
+			"skipped",
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		metrics.DefaultCollector.AddMetric(skippedKey, skippedMetric)
+	}()
 
-	err := containers.List(client.Client, nil).
+	// Get the number of objects in each container from the container
+	// metadata, which is cheap compared to listing the objects.
+	objectCounts := make(map[string]int64)
+	err = containers.List(client.Client, nil).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
-				containerNameList, err := containers.ExtractNames(page)
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
+				containerList, err := containers.ExtractInfo(page)
 
 				if err != nil {
 					logger.Error(
@@ -178,7 +225,10 @@ func collectObjects(ctx context.Context, payload CollectObjectsPayload) error {
 
 					return false, err
 				}
-				containerNames = append(containerNames, containerNameList...)
+
+				for _, c := range containerList {
+					objectCounts[c.Name] = c.Count
+				}
 
 				return true, nil
 			})
@@ -192,62 +242,63 @@ func collectObjects(ctx context.Context, payload CollectObjectsPayload) error {
 		return err
 	}
 
-	for _, name := range containerNames {
-		err = objects.List(client.Client, name, nil).
-			EachPage(ctx,
-				func(_ context.Context, page pagination.Page) (bool, error) {
-					objectList, err := objects.ExtractInfo(page)
+	items := make([]models.Object, 0)
+	for _, container := range payload.Containers {
+		maxObjects := container.MaxObjects
+		if maxObjects <= 0 {
+			maxObjects = DefaultMaxObjects
+		}
 
-					if err != nil {
-						logger.Error(
-							"could not extract object pages",
-							"reason", err,
-						)
+		objectCount, ok := objectCounts[container.Name]
+		if !ok {
+			logger.Warn("container not found", "container", container.Name)
+			skipped++
+			asynqutils.AddSkipped(ctx, 1)
 
-						return false, err
-					}
+			continue
+		}
 
-					for _, o := range objectList {
-						item := models.Object{
-							Name:          o.Name,
-							ContainerName: name,
-							ProjectID:     client.Project,
-							ContentType:   o.ContentType,
-							LastModified:  o.LastModified,
-							IsLatest:      o.IsLatest,
-						}
-
-						items = append(items, item)
-					}
+		if objectCount > maxObjects {
+			logger.Warn(
+				"container exceeds max objects",
+				"container", container.Name,
+				"objects", objectCount,
+				"max_objects", maxObjects,
+			)
+			skipped++
+			asynqutils.AddSkipped(ctx, 1)
 
-					return true, nil
-				})
+			continue
+		}
 
+		containerItems, err := listContainerObjects(ctx, client, container.Name, maxObjects)
 		if err != nil {
 			logger.Warn(
-				"could not extract object pages",
-				"container",
-				name,
+				"could not list container objects",
+				"container", container.Name,
 				"reason", err,
 			)
+			skipped++
+			asynqutils.AddSkipped(ctx, 1)
 
 			continue
 		}
+
+		items = append(items, containerItems...)
 	}
 
 	if len(items) == 0 {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (name, container_name, project_id) DO UPDATE").
-		Set("content_type = EXCLUDED.content_type").
-		Set("last_modified = EXCLUDED.last_modified").
-		Set("is_latest = EXCLUDED.is_latest").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (name, container_name, project_id) DO UPDATE").
+			Set("content_type = EXCLUDED.content_type").
+			Set("last_modified = EXCLUDED.last_modified").
+			Set("is_latest = EXCLUDED.is_latest").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -261,11 +312,6 @@ func collectObjects(ctx context.Context, payload CollectObjectsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack objects",
 		"project", payload.Scope.Project,
@@ -276,3 +322,45 @@ func collectObjects(ctx context.Context, payload CollectObjectsPayload) error {
 
 	return nil
 }
+
+// listContainerObjects lists the objects of the given container. It returns
+// [ErrMaxObjectsExceeded], if the container has more than maxObjects objects,
+// e.g. when objects were added after the object count has been checked.
+func listContainerObjects(ctx context.Context, client openstackclients.Client[*gophercloud.ServiceClient], name string, maxObjects int64) ([]models.Object, error) {
+	items := make([]models.Object, 0)
+	err := objects.List(client.Client, name, nil).
+		EachPage(ctx,
+			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
+				objectList, err := objects.ExtractInfo(page)
+				if err != nil {
+					return false, err
+				}
+
+				for _, o := range objectList {
+					item := models.Object{
+						Name:          o.Name,
+						ContainerName: name,
+						ProjectID:     client.Project,
+						ContentType:   o.ContentType,
+						LastModified:  o.LastModified,
+						IsLatest:      o.IsLatest,
+					}
+					items = append(items, item)
+				}
+
+				if int64(len(items)) > maxObjects {
+					return false, ErrMaxObjectsExceeded
+				}
+
+				return true, nil
+			})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
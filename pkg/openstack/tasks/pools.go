@@ -13,8 +13,8 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	gardenerutils "github.com/gardener/inventory/pkg/gardener/utils"
@@ -22,6 +22,7 @@ import (
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -70,57 +71,48 @@ func HandleCollectPoolsTask(ctx context.Context, t *asynq.Task) error {
 func enqueueCollectPools(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.LoadBalancerClientset.Length() == 0 {
 		logger.Warn("no OpenStack loadbalancer clients found")
 
 		return nil
 	}
 
-	return openstackclients.LoadBalancerClientset.
-		Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
-			payload := CollectPoolsPayload{
-				Scope: scope,
-			}
-			data, err := json.Marshal(payload)
-			if err != nil {
-				logger.Error(
-					"failed to marshal payload for OpenStack pools",
-					"project", scope.Project,
-					"domain", scope.Domain,
-					"region", scope.Region,
-					"reason", err,
-				)
-
-				return err
-			}
-
-			task := asynq.NewTask(TaskCollectPools, data)
-			info, err := asynqclient.Client.Enqueue(task)
-			if err != nil {
-				logger.Error(
-					"failed to enqueue task",
-					"type", task.Type(),
-					"project", scope.Project,
-					"domain", scope.Domain,
-					"region", scope.Region,
-					"reason", err,
-				)
-
-				return err
-			}
-
-			logger.Info(
-				"enqueued task",
-				"type", task.Type(),
-				"id", info.ID,
-				"queue", info.Queue,
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.LoadBalancerClientset.Length())
+	err := openstackclients.LoadBalancerClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectPoolsPayload{
+			Scope: scope,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack pools",
 				"project", scope.Project,
 				"domain", scope.Domain,
 				"region", scope.Region,
+				"reason", err,
 			)
 
-			return nil
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectPools, data)
 		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns)
 }
 
 // collectPools collects the OpenStack Pools,
@@ -128,9 +120,9 @@ func enqueueCollectPools(ctx context.Context) error {
 func collectPools(ctx context.Context, payload CollectPoolsPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.LoadBalancerClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.LoadBalancerClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -162,9 +154,12 @@ func collectPools(ctx context.Context, payload CollectPoolsPayload) error {
 	poolItems := make([]models.Pool, 0)
 	memberItems := make([]models.PoolMember, 0)
 
-	err := pools.List(client.Client, nil).
+	err = pools.List(client.Client, nil).
 		EachPage(ctx,
 			func(ctx context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				extractedPools, err := pools.ExtractPools(page)
 
 				if err != nil {
@@ -183,6 +178,9 @@ func collectPools(ctx context.Context, payload CollectPoolsPayload) error {
 					err = pools.ListMembers(client.Client, pool.ID, nil).
 						EachPage(ctx,
 							func(ctx context.Context, page pagination.Page) (bool, error) {
+								asynqutils.AddPages(ctx, 1)
+								openstackutils.SamplePage(ctx, page)
+
 								extractedMembers, err := pools.ExtractMembers(page)
 
 								if err != nil {
@@ -264,15 +262,14 @@ func collectPools(ctx context.Context, payload CollectPoolsPayload) error {
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&poolItems).
-		On("CONFLICT (pool_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("subnet_id = EXCLUDED.subnet_id").
-		Set("description = EXCLUDED.description").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, poolItems, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (pool_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("subnet_id = EXCLUDED.subnet_id").
+			Set("description = EXCLUDED.description").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -286,11 +283,6 @@ func collectPools(ctx context.Context, payload CollectPoolsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack pools",
 		"project", payload.Scope.Project,
@@ -303,18 +295,17 @@ func collectPools(ctx context.Context, payload CollectPoolsPayload) error {
 		return nil
 	}
 
-	out, err = db.DB.NewInsert().
-		Model(&memberItems).
-		On("CONFLICT (member_id, pool_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("subnet_id = EXCLUDED.subnet_id").
-		Set("protocol_port = EXCLUDED.protocol_port").
-		Set("inferred_gardener_shoot = EXCLUDED.inferred_gardener_shoot").
-		Set("member_created_at = EXCLUDED.member_created_at").
-		Set("member_updated_at = EXCLUDED.member_updated_at").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	memberCount, err := dbutils.BulkInsert(ctx, db.DB, memberItems, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (member_id, pool_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("subnet_id = EXCLUDED.subnet_id").
+			Set("protocol_port = EXCLUDED.protocol_port").
+			Set("inferred_gardener_shoot = EXCLUDED.inferred_gardener_shoot").
+			Set("member_created_at = EXCLUDED.member_created_at").
+			Set("member_updated_at = EXCLUDED.member_updated_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -328,11 +319,6 @@ func collectPools(ctx context.Context, payload CollectPoolsPayload) error {
 		return err
 	}
 
-	memberCount, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack pool members",
 		"project", payload.Scope.Project,
@@ -14,14 +14,15 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -34,6 +35,11 @@ const (
 type CollectPortsPayload struct {
 	// Scope specifies the client scope for which to collect.
 	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Filters optionally specifies the metadata or tags, which the
+	// collected resources must have. See [openstackutils.MatchFilters] for
+	// details. If empty, all resources are collected.
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters"`
 }
 
 // NewCollectPortsTask creates a new [asynq.Task] for collecting OpenStack
@@ -48,7 +54,7 @@ func HandleCollectPortsTask(ctx context.Context, t *asynq.Task) error {
 	// collecting OpenStack Ports for all configured clients.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectPorts(ctx)
+		return enqueueCollectPorts(ctx, nil)
 	}
 
 	var payload CollectPortsPayload
@@ -56,6 +62,11 @@ func HandleCollectPortsTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectPorts(ctx, payload.Filters)
+	}
+
 	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
 		return asynqutils.SkipRetry(err)
 	}
@@ -65,10 +76,12 @@ func HandleCollectPortsTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectPorts enqueues tasks for collecting OpenStack Ports from
 // all configured OpenStack network clients by creating a payload with the respective
-// client scope.
-func enqueueCollectPorts(ctx context.Context) error {
+// client scope and the given filters.
+func enqueueCollectPorts(ctx context.Context, filters map[string]string) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.NetworkClientset.Length() == 0 {
 		logger.Warn("no OpenStack network clients found")
 
@@ -77,9 +90,15 @@ func enqueueCollectPorts(ctx context.Context) error {
 
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.NetworkClientset.Length())
+	err := openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
 		payload := CollectPortsPayload{
-			Scope: scope,
+			Scope:   scope,
+			Filters: filters,
 		}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -94,33 +113,18 @@ func enqueueCollectPorts(ctx context.Context) error {
 			return err
 		}
 
-		task := asynq.NewTask(TaskCollectPorts, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", scope.Project,
-				"domain", scope.Domain,
-				"region", scope.Region,
-				"reason", err,
-			)
-
-			return err
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", scope.Project,
-			"domain", scope.Domain,
-			"region", scope.Region,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectPorts, data)
+		})
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectPorts collects the OpenStack Ports,
@@ -128,9 +132,9 @@ func enqueueCollectPorts(ctx context.Context) error {
 func collectPorts(ctx context.Context, payload CollectPortsPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.NetworkClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.NetworkClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -162,9 +166,12 @@ func collectPorts(ctx context.Context, payload CollectPortsPayload) error {
 	items := make([]models.Port, 0)
 	portIPs := make([]models.PortIP, 0)
 
-	err := ports.List(client.Client, ports.ListOpts{}).
+	err = ports.List(client.Client, ports.ListOpts{}).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				portList, err := ports.ExtractPorts(page)
 				if err != nil {
 					logger.Error("failed to extract ports", "reason", err)
@@ -173,20 +180,33 @@ func collectPorts(ctx context.Context, payload CollectPortsPayload) error {
 				}
 
 				for _, port := range portList {
+					if !openstackutils.MatchFilters(payload.Filters, nil, port.Tags) {
+						continue
+					}
+
+					// Ports with disabled port security
+					// have no security groups
+					securityGroups := port.SecurityGroups
+					if securityGroups == nil {
+						securityGroups = make([]string, 0)
+					}
+
 					items = append(items, models.Port{
-						PortID:      port.ID,
-						Name:        port.Name,
-						ProjectID:   port.ProjectID,
-						Domain:      payload.Scope.Domain,
-						Region:      payload.Scope.Region,
-						NetworkID:   port.NetworkID,
-						DeviceID:    port.DeviceID,
-						DeviceOwner: port.DeviceOwner,
-						MacAddress:  port.MACAddress,
-						Status:      port.Status,
-						Description: port.Description,
-						TimeCreated: port.CreatedAt,
-						TimeUpdated: port.UpdatedAt,
+						PortID:         port.ID,
+						Name:           port.Name,
+						ProjectID:      port.ProjectID,
+						Domain:         payload.Scope.Domain,
+						Region:         payload.Scope.Region,
+						NetworkID:      port.NetworkID,
+						DeviceID:       port.DeviceID,
+						DeviceOwner:    port.DeviceOwner,
+						MacAddress:     port.MACAddress,
+						Status:         port.Status,
+						Description:    port.Description,
+						TimeCreated:    port.CreatedAt,
+						TimeUpdated:    port.UpdatedAt,
+						Tags:           openstackutils.TagsToMap(port.Tags),
+						SecurityGroups: securityGroups,
 					})
 
 					for _, fixedIP := range port.FixedIPs {
@@ -224,25 +244,31 @@ func collectPorts(ctx context.Context, payload CollectPortsPayload) error {
 		return err
 	}
 
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.PortID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.Port](ctx, client, payload.Filters, "ports", "port_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (port_id, project_id, network_id, region) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("domain = EXCLUDED.domain").
-		Set("device_id = EXCLUDED.device_id").
-		Set("device_owner = EXCLUDED.device_owner").
-		Set("mac_address = EXCLUDED.mac_address").
-		Set("status = EXCLUDED.status").
-		Set("description = EXCLUDED.description").
-		Set("port_created_at = EXCLUDED.port_created_at").
-		Set("port_updated_at = EXCLUDED.port_updated_at").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	portCount, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (port_id, project_id, network_id, region) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("device_id = EXCLUDED.device_id").
+			Set("device_owner = EXCLUDED.device_owner").
+			Set("mac_address = EXCLUDED.mac_address").
+			Set("status = EXCLUDED.status").
+			Set("description = EXCLUDED.description").
+			Set("port_created_at = EXCLUDED.port_created_at").
+			Set("port_updated_at = EXCLUDED.port_updated_at").
+			Set("tags = EXCLUDED.tags").
+			Set("security_groups = EXCLUDED.security_groups").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -256,11 +282,6 @@ func collectPorts(ctx context.Context, payload CollectPortsPayload) error {
 		return err
 	}
 
-	portCount, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack ports",
 		"project", payload.Scope.Project,
@@ -269,16 +290,19 @@ func collectPorts(ctx context.Context, payload CollectPortsPayload) error {
 		"count", portCount,
 	)
 
+	if err := reconcileProject[models.Port](ctx, client, payload.Filters, "ports", "port_id", ids); err != nil {
+		return err
+	}
+
 	if len(portIPs) == 0 {
 		return nil
 	}
 
-	out, err = db.DB.NewInsert().
-		Model(&portIPs).
-		On("CONFLICT (port_id, ip_address, subnet_id, project_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	ipCount, err := dbutils.BulkInsert(ctx, db.DB, portIPs, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (port_id, ip_address, subnet_id, project_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -292,11 +316,6 @@ func collectPorts(ctx context.Context, payload CollectPortsPayload) error {
 		return err
 	}
 
-	ipCount, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack port IPs",
 		"project", payload.Scope.Project,
@@ -13,14 +13,15 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -69,6 +70,8 @@ func HandleCollectProjectsTask(ctx context.Context, t *asynq.Task) error {
 func enqueueCollectProjects(ctx context.Context) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.IdentityClientset.Length() == 0 {
 		logger.Warn("no OpenStack identity clients found")
 
@@ -77,7 +80,12 @@ func enqueueCollectProjects(ctx context.Context) error {
 
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.IdentityClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.IdentityClientset.Length())
+	err := openstackclients.IdentityClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
 		payload := CollectProjectsPayload{
 			Scope: scope,
 		}
@@ -94,33 +102,18 @@ func enqueueCollectProjects(ctx context.Context) error {
 			return err
 		}
 
-		task := asynq.NewTask(TaskCollectProjects, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", scope.Project,
-				"domain", scope.Domain,
-				"region", scope.Region,
-				"reason", err,
-			)
-
-			return err
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", scope.Project,
-			"domain", scope.Domain,
-			"region", scope.Region,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectProjects, data)
+		})
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectProjects collects the OpenStack Projects,
@@ -128,9 +121,9 @@ func enqueueCollectProjects(ctx context.Context) error {
 func collectProjects(ctx context.Context, payload CollectProjectsPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.IdentityClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.IdentityClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -161,9 +154,12 @@ func collectProjects(ctx context.Context, payload CollectProjectsPayload) error
 
 	items := make([]models.Project, 0)
 
-	err := projects.ListAvailable(client.Client).
+	err = projects.ListAvailable(client.Client).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				projectList, err := projects.ExtractProjects(page)
 
 				if err != nil {
@@ -208,19 +204,18 @@ func collectProjects(ctx context.Context, payload CollectProjectsPayload) error
 		return nil
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("domain = EXCLUDED.domain").
-		Set("region = EXCLUDED.region").
-		Set("parent_id = EXCLUDED.parent_id").
-		Set("description = EXCLUDED.description").
-		Set("enabled = EXCLUDED.enabled").
-		Set("is_domain = EXCLUDED.is_domain").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("region = EXCLUDED.region").
+			Set("parent_id = EXCLUDED.parent_id").
+			Set("description = EXCLUDED.description").
+			Set("enabled = EXCLUDED.enabled").
+			Set("is_domain = EXCLUDED.is_domain").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -234,11 +229,6 @@ func collectProjects(ctx context.Context, payload CollectProjectsPayload) error
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack projects",
 		"project", payload.Scope.Project,
@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/quotasets"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// TaskCollectQuotaUsage is the name of the task for sampling the
+	// usage of OpenStack compute quotas.
+	TaskCollectQuotaUsage = "openstack:task:collect-quota-usage"
+)
+
+// CollectQuotaUsagePayload represents the payload, which specifies the scope
+// for sampling the usage of OpenStack compute quotas.
+type CollectQuotaUsagePayload struct {
+	// Scope specifies the client scope to use for collection.
+	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// MetricProjects specifies the projects, for which the quota usage
+	// ratio metric is reported, in order to keep the cardinality of the
+	// metric under control. If empty, the metric is reported for all
+	// projects. Samples are stored for all projects regardless.
+	MetricProjects []string `json:"metric_projects" yaml:"metric_projects"`
+}
+
+// NewCollectQuotaUsageTask creates a new [asynq.Task] for sampling the usage of
+// OpenStack compute quotas, without specifying a payload.
+func NewCollectQuotaUsageTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectQuotaUsage, nil)
+}
+
+// HandleCollectQuotaUsageTask handles the task for sampling the usage of
+// OpenStack compute quotas.
+func HandleCollectQuotaUsageTask(ctx context.Context, t *asynq.Task) error {
+	var payload CollectQuotaUsagePayload
+	if data := t.Payload(); data != nil {
+		if err := asynqutils.Unmarshal(data, &payload); err != nil {
+			return asynqutils.SkipRetry(err)
+		}
+	}
+
+	// If we were called without a scope, then we enqueue tasks for
+	// sampling the quota usage for all configured compute clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectQuotaUsage(ctx, payload.MetricProjects)
+	}
+
+	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
+		return asynqutils.SkipRetry(ErrInvalidScope)
+	}
+
+	return collectQuotaUsage(ctx, payload)
+}
+
+// enqueueCollectQuotaUsage enqueues tasks for sampling the usage of OpenStack
+// compute quotas for all configured OpenStack compute clients by creating a
+// payload with the respective client scope and the given metric projects.
+func enqueueCollectQuotaUsage(ctx context.Context, metricProjects []string) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshDomainProjects(ctx)
+
+	if openstackclients.ComputeClientset.Length() == 0 {
+		logger.Warn("no OpenStack compute clients found")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.ComputeClientset.Length())
+	err := openstackclients.ComputeClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectQuotaUsagePayload{
+			Scope:          scope,
+			MetricProjects: metricProjects,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack quota usage",
+				"project", scope.Project,
+				"domain", scope.Domain,
+				"region", scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectQuotaUsage, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// quotaUsageResources returns the sampled resources of the given
+// [quotasets.QuotaDetailSet] mapped by their names.
+func quotaUsageResources(set quotasets.QuotaDetailSet) map[string]quotasets.QuotaDetail {
+	resources := map[string]quotasets.QuotaDetail{
+		"cores":                set.Cores,
+		"ram":                  set.RAM,
+		"instances":            set.Instances,
+		"key_pairs":            set.KeyPairs,
+		"server_groups":        set.ServerGroups,
+		"server_group_members": set.ServerGroupMembers,
+	}
+
+	return resources
+}
+
+// collectQuotaUsage samples the usage of the OpenStack compute quotas, using
+// the client associated with the client scope in the given payload.
+//
+// The quotas are looked up by project id, which is resolved from the projects
+// collected by [TaskCollectProjects].
+func collectQuotaUsage(ctx context.Context, payload CollectQuotaUsagePayload) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	client, err := getClient(ctx, openstackclients.ComputeClientset, payload.Scope)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(
+		"collecting OpenStack quota usage",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+	)
+
+	var project models.Project
+	err = db.DB.NewSelect().
+		Model(&project).
+		Where("name = ?", payload.Scope.Project).
+		Where("domain = ?", payload.Scope.Domain).
+		Where("region = ?", payload.Scope.Region).
+		Limit(1).
+		Scan(ctx)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		logger.Warn(
+			"project not collected yet, skipping quota usage",
+			"project", payload.Scope.Project,
+			"domain", payload.Scope.Domain,
+			"region", payload.Scope.Region,
+		)
+
+		return nil
+	case err != nil:
+		return err
+	}
+
+	set, err := quotasets.GetDetail(ctx, client.Client, project.ProjectID).Extract()
+	if err != nil {
+		logger.Error(
+			"could not get quota usage",
+			"project", payload.Scope.Project,
+			"domain", payload.Scope.Domain,
+			"region", payload.Scope.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	reportMetrics := len(payload.MetricProjects) == 0 || slices.Contains(payload.MetricProjects, payload.Scope.Project)
+	items := make([]models.QuotaUsageSample, 0)
+	for resource, detail := range quotaUsageResources(set) {
+		// Non-positive limits stand for unlimited or disabled
+		// resources, for which there is no meaningful ratio.
+		var ratio float64
+		if detail.Limit > 0 {
+			ratio = float64(detail.InUse) / float64(detail.Limit)
+		}
+
+		item := models.QuotaUsageSample{
+			ProjectID:  project.ProjectID,
+			Domain:     client.Domain,
+			Region:     client.Region,
+			Resource:   resource,
+			InUse:      detail.InUse,
+			Reserved:   detail.Reserved,
+			Limit:      detail.Limit,
+			UsageRatio: ratio,
+		}
+		items = append(items, item)
+
+		if !reportMetrics || detail.Limit <= 0 {
+			continue
+		}
+
+		metric := prometheus.MustNewConstMetric(
+			quotaUsageRatioDesc,
+			prometheus.GaugeValue,
+			ratio,
+			project.ProjectID,
+			resource,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		key := metrics.Key(
+			TaskCollectQuotaUsage,
+			project.ProjectID,
+			resource,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}
+
+	// Samples are never updated, so that the table keeps the usage
+	// history of the quotas.
+	count, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert quota usage samples into db",
+			"project", payload.Scope.Project,
+			"domain", payload.Scope.Domain,
+			"region", payload.Scope.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated openstack quota usage samples",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+		"count", count,
+	)
+
+	return nil
+}
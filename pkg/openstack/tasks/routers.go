@@ -10,14 +10,15 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -31,6 +32,11 @@ const (
 type CollectRoutersPayload struct {
 	// Scope specifies the client scope for which to collect.
 	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Filters optionally specifies the metadata or tags, which the
+	// collected resources must have. See [openstackutils.MatchFilters] for
+	// details. If empty, all resources are collected.
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters"`
 }
 
 // NewCollectRoutersTask creates a new [asynq.Task] for collecting OpenStack
@@ -43,7 +49,7 @@ func NewCollectRoutersTask() *asynq.Task {
 func HandleCollectRoutersTask(ctx context.Context, t *asynq.Task) error {
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectRouters(ctx)
+		return enqueueCollectRouters(ctx, nil)
 	}
 
 	var payload CollectRoutersPayload
@@ -51,6 +57,11 @@ func HandleCollectRoutersTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectRouters(ctx, payload.Filters)
+	}
+
 	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
 		return asynqutils.SkipRetry(ErrInvalidScope)
 	}
@@ -60,19 +71,27 @@ func HandleCollectRoutersTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectRouters enqueues tasks for collecting OpenStack Routers from
 // all configured OpenStack projects by creating a payload with the respective
-// client scope
-func enqueueCollectRouters(ctx context.Context) error {
+// client scope and the given filters.
+func enqueueCollectRouters(ctx context.Context, filters map[string]string) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.NetworkClientset.Length() == 0 {
 		logger.Warn("no OpenStack network clients found")
 
 		return nil
 	}
 
-	return openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.NetworkClientset.Length())
+	err := openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
 		payload := CollectRoutersPayload{
-			Scope: scope,
+			Scope:   scope,
+			Filters: filters,
 		}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -87,33 +106,18 @@ func enqueueCollectRouters(ctx context.Context) error {
 			return err
 		}
 
-		task := asynq.NewTask(TaskCollectRouters, data)
-		info, err := asynqclient.Client.Enqueue(task)
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", scope.Project,
-				"domain", scope.Domain,
-				"region", scope.Region,
-				"reason", err,
-			)
-
-			return err
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", scope.Project,
-			"domain", scope.Domain,
-			"region", scope.Region,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectRouters, data)
+		})
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns)
 }
 
 // collectRouters collects the OpenStack Routers from the specified project,
@@ -121,9 +125,9 @@ func enqueueCollectRouters(ctx context.Context) error {
 func collectRouters(ctx context.Context, payload CollectRoutersPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.NetworkClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.NetworkClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -156,9 +160,12 @@ func collectRouters(ctx context.Context, payload CollectRoutersPayload) error {
 	items := make([]models.Router, 0)
 	externalIPs := make([]models.RouterExternalIP, 0)
 
-	err := routers.List(client.Client, routers.ListOpts{}).
+	err = routers.List(client.Client, routers.ListOpts{}).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				routerList, err := routers.ExtractRouters(page)
 				if err != nil {
 					logger.Error(
@@ -170,6 +177,10 @@ func collectRouters(ctx context.Context, payload CollectRoutersPayload) error {
 				}
 
 				for _, router := range routerList {
+					if !openstackutils.MatchFilters(payload.Filters, nil, router.Tags) {
+						continue
+					}
+
 					item := models.Router{
 						RouterID:          router.ID,
 						Name:              router.Name,
@@ -216,22 +227,26 @@ func collectRouters(ctx context.Context, payload CollectRoutersPayload) error {
 		return err
 	}
 
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.RouterID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.Router](ctx, client, payload.Filters, "routers", "router_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (router_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("domain = EXCLUDED.domain").
-		Set("region = EXCLUDED.region").
-		Set("status = EXCLUDED.status").
-		Set("description = EXCLUDED.description").
-		Set("external_network_id = EXCLUDED.external_network_id").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (router_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("region = EXCLUDED.region").
+			Set("status = EXCLUDED.status").
+			Set("description = EXCLUDED.description").
+			Set("external_network_id = EXCLUDED.external_network_id").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -245,11 +260,6 @@ func collectRouters(ctx context.Context, payload CollectRoutersPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack routers",
 		"project", payload.Scope.Project,
@@ -258,16 +268,19 @@ func collectRouters(ctx context.Context, payload CollectRoutersPayload) error {
 		"count", count,
 	)
 
+	if err := reconcileProject[models.Router](ctx, client, payload.Filters, "routers", "router_id", ids); err != nil {
+		return err
+	}
+
 	if len(externalIPs) == 0 {
 		return nil
 	}
 
-	out, err = db.DB.NewInsert().
-		Model(&externalIPs).
-		On("CONFLICT (router_id, external_ip, external_subnet_id, project_id) DO UPDATE").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	externalIPCount, err := dbutils.BulkInsert(ctx, db.DB, externalIPs, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (router_id, external_ip, external_subnet_id, project_id) DO UPDATE").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -281,11 +294,6 @@ func collectRouters(ctx context.Context, payload CollectRoutersPayload) error {
 		return err
 	}
 
-	externalIPCount, err := out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack router external IPs",
 		"project", payload.Scope.Project,
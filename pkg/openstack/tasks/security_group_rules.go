@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/paginate"
+)
+
+const (
+	// TaskCollectSecurityGroupRules is the name of the task for collecting
+	// OpenStack Security Group Rules.
+	TaskCollectSecurityGroupRules = "openstack:task:collect-security-group-rules"
+)
+
+// CollectSecurityGroupRulesPayload represents the payload, which specifies
+// the scope for collecting OpenStack Security Group Rules.
+type CollectSecurityGroupRulesPayload struct {
+	// Scope specifies the client scope to use for collection.
+	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Concurrency specifies the max number of pages, which are processed
+	// concurrently. If not specified, pages are processed one at a time.
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency"`
+}
+
+// NewCollectSecurityGroupRulesTask creates a new [asynq.Task] for collecting
+// OpenStack Security Group Rules, without specifying a payload.
+func NewCollectSecurityGroupRulesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectSecurityGroupRules, nil)
+}
+
+// HandleCollectSecurityGroupRulesTask handles the task for collecting
+// OpenStack Security Group Rules.
+func HandleCollectSecurityGroupRulesTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting OpenStack Security Group Rules for all configured clients.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectSecurityGroupRules(ctx, 0)
+	}
+
+	var payload CollectSecurityGroupRulesPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectSecurityGroupRules(ctx, payload.Concurrency)
+	}
+
+	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
+		return asynqutils.SkipRetry(ErrInvalidScope)
+	}
+
+	return collectSecurityGroupRules(ctx, payload)
+}
+
+// enqueueCollectSecurityGroupRules enqueues tasks for collecting OpenStack
+// Security Group Rules for all configured OpenStack network clients by creating
+// a payload with the respective client scope and the given concurrency.
+func enqueueCollectSecurityGroupRules(ctx context.Context, concurrency int) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshDomainProjects(ctx)
+
+	if openstackclients.NetworkClientset.Length() == 0 {
+		logger.Warn("no OpenStack network clients found")
+
+		return nil
+	}
+
+	// Security group rules are collected by the security groups
+	// collector, when it is configured to collect its children inline.
+	if asynqutils.CollectChildrenInline(TaskCollectSecurityGroups) {
+		logger.Info("OpenStack security group rules are collected inline with security groups, skipping")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.NetworkClientset.Length())
+	err := openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectSecurityGroupRulesPayload{
+			Scope:       scope,
+			Concurrency: concurrency,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack security group rules",
+				"project", scope.Project,
+				"domain", scope.Domain,
+				"region", scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectSecurityGroupRules, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// newSecurityGroupRulePage creates a [rules.SecGroupRulePage] from the given
+// page result.
+func newSecurityGroupRulePage(r pagination.PageResult) pagination.Page {
+	return rules.SecGroupRulePage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+}
+
+// collectSecurityGroupRules collects the OpenStack Security Group Rules,
+// using the client associated with the client scope in the given payload.
+func collectSecurityGroupRules(ctx context.Context, payload CollectSecurityGroupRulesPayload) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	client, err := getClient(ctx, openstackclients.NetworkClientset, payload.Scope)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(
+		"collecting OpenStack security group rules",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+	)
+
+	var count atomic.Int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			securityGroupRulesDesc,
+			prometheus.GaugeValue,
+			float64(count.Load()),
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		key := metrics.Key(
+			TaskCollectSecurityGroupRules,
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	fetch := openstackutils.PageFetcher(
+		client.Client,
+		rules.List(client.Client, rules.ListOpts{}),
+		newSecurityGroupRulePage,
+		rules.ExtractRules,
+	)
+
+	// The ids of the upserted items are collected for reconciling, once
+	// all pages have been processed.
+	var mu sync.Mutex
+	ids := make([]string, 0)
+
+	// Each page is upserted separately, so that pages may be processed
+	// concurrently.
+	upsert := func(ctx context.Context, secGroupRules []rules.SecGroupRule) error {
+		items := make([]models.SecurityGroupRule, 0, len(secGroupRules))
+		for _, rule := range secGroupRules {
+			item := models.SecurityGroupRule{
+				RuleID:          rule.ID,
+				SecurityGroupID: rule.SecGroupID,
+				ProjectID:       rule.ProjectID,
+				Domain:          client.Domain,
+				Region:          client.Region,
+				Direction:       rule.Direction,
+				EtherType:       rule.EtherType,
+				Protocol:        rule.Protocol,
+				PortRangeMin:    rule.PortRangeMin,
+				PortRangeMax:    rule.PortRangeMax,
+				RemoteIPPrefix:  rule.RemoteIPPrefix,
+				RemoteGroupID:   rule.RemoteGroupID,
+				Description:     rule.Description,
+				TimeCreated:     rule.CreatedAt,
+				TimeUpdated:     rule.UpdatedAt,
+			}
+			items = append(items, item)
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		n, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+			return q.On("CONFLICT (rule_id, project_id) DO UPDATE").
+				Set("security_group_id = EXCLUDED.security_group_id").
+				Set("domain = EXCLUDED.domain").
+				Set("region = EXCLUDED.region").
+				Set("direction = EXCLUDED.direction").
+				Set("ether_type = EXCLUDED.ether_type").
+				Set("protocol = EXCLUDED.protocol").
+				Set("port_range_min = EXCLUDED.port_range_min").
+				Set("port_range_max = EXCLUDED.port_range_max").
+				Set("remote_ip_prefix = EXCLUDED.remote_ip_prefix").
+				Set("remote_group_id = EXCLUDED.remote_group_id").
+				Set("description = EXCLUDED.description").
+				Set("rule_created_at = EXCLUDED.rule_created_at").
+				Set("rule_updated_at = EXCLUDED.rule_updated_at").
+				Set("updated_at = EXCLUDED.updated_at").
+				Returning("id")
+		}, 0)
+
+		if err != nil {
+			logger.Error(
+				"could not insert security group rules into db",
+				"project", payload.Scope.Project,
+				"domain", payload.Scope.Domain,
+				"region", payload.Scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		count.Add(n)
+
+		mu.Lock()
+		for _, item := range items {
+			ids = append(ids, item.RuleID)
+		}
+		mu.Unlock()
+
+		return nil
+	}
+
+	if err := paginate.PaginatePages(ctx, fetch, upsert, payload.Concurrency); err != nil {
+		logger.Error(
+			"could not extract security group rule pages",
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated openstack security group rules",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+		"count", count.Load(),
+	)
+
+	return reconcileProject[models.SecurityGroupRule](ctx, client, nil, "security group rules", "rule_id", ids)
+}
@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/paginate"
+)
+
+const (
+	// TaskCollectSecurityGroups is the name of the task for collecting OpenStack
+	// Security Groups.
+	TaskCollectSecurityGroups = "openstack:task:collect-security-groups"
+)
+
+// CollectSecurityGroupsPayload represents the payload, which specifies
+// the scope for collecting OpenStack Security Groups.
+type CollectSecurityGroupsPayload struct {
+	// Scope specifies the client scope to use for collection.
+	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Concurrency specifies the max number of pages, which are processed
+	// concurrently. If not specified, pages are processed one at a time.
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency"`
+
+	// Filters optionally specifies the metadata or tags, which the
+	// collected resources must have. See [openstackutils.MatchFilters] for
+	// details. If empty, all resources are collected.
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters"`
+}
+
+// NewCollectSecurityGroupsTask creates a new [asynq.Task] for collecting OpenStack
+// Security Groups, without specifying a payload.
+func NewCollectSecurityGroupsTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectSecurityGroups, nil)
+}
+
+// HandleCollectSecurityGroupsTask handles the task for collecting OpenStack Security Groups.
+func HandleCollectSecurityGroupsTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting OpenStack Security Groups for all configured clients.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectSecurityGroups(ctx, 0, nil)
+	}
+
+	var payload CollectSecurityGroupsPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectSecurityGroups(ctx, payload.Concurrency, payload.Filters)
+	}
+
+	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
+		return asynqutils.SkipRetry(ErrInvalidScope)
+	}
+
+	if err := collectSecurityGroups(ctx, payload); err != nil {
+		return err
+	}
+
+	if !asynqutils.CollectChildrenInline(TaskCollectSecurityGroups) {
+		return nil
+	}
+
+	return collectSecurityGroupChildren(ctx, payload)
+}
+
+// collectSecurityGroupChildren collects the OpenStack Security Group Rules from
+// the same scope as the security groups and links them with their groups,
+// without waiting for the separate collection and link tasks.
+func collectSecurityGroupChildren(ctx context.Context, payload CollectSecurityGroupsPayload) error {
+	rulesPayload := CollectSecurityGroupRulesPayload{
+		Scope:       payload.Scope,
+		Concurrency: payload.Concurrency,
+	}
+	if err := collectSecurityGroupRules(ctx, rulesPayload); err != nil {
+		return err
+	}
+
+	linkFns := []dbutils.LinkFunction{
+		LinkSecurityGroupRuleWithGroup,
+	}
+
+	return dbutils.LinkObjects(ctx, db.DB, linkFns)
+}
+
+// enqueueCollectSecurityGroups enqueues tasks for collecting OpenStack Security Groups for
+// all configured OpenStack network clients by creating a payload with the respective
+// client scope and the given concurrency and filters.
+func enqueueCollectSecurityGroups(ctx context.Context, concurrency int, filters map[string]string) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshDomainProjects(ctx)
+
+	if openstackclients.NetworkClientset.Length() == 0 {
+		logger.Warn("no OpenStack network clients found")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.NetworkClientset.Length())
+	err := openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectSecurityGroupsPayload{
+			Scope:       scope,
+			Concurrency: concurrency,
+			Filters:     filters,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack security groups",
+				"project", scope.Project,
+				"domain", scope.Domain,
+				"region", scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectSecurityGroups, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// newSecurityGroupPage creates a [groups.SecGroupPage] from the given page
+// result.
+func newSecurityGroupPage(r pagination.PageResult) pagination.Page {
+	return groups.SecGroupPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+}
+
+// collectSecurityGroups collects the OpenStack Security Groups,
+// using the client associated with the client scope in the given payload.
+func collectSecurityGroups(ctx context.Context, payload CollectSecurityGroupsPayload) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	client, err := getClient(ctx, openstackclients.NetworkClientset, payload.Scope)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(
+		"collecting OpenStack security groups",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+	)
+
+	var count atomic.Int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			securityGroupsDesc,
+			prometheus.GaugeValue,
+			float64(count.Load()),
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		key := metrics.Key(
+			TaskCollectSecurityGroups,
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	fetch := openstackutils.PageFetcher(
+		client.Client,
+		groups.List(client.Client, groups.ListOpts{}),
+		newSecurityGroupPage,
+		groups.ExtractGroups,
+	)
+
+	// The ids of the upserted items are collected for reconciling, once
+	// all pages have been processed.
+	var mu sync.Mutex
+	ids := make([]string, 0)
+
+	// Each page is upserted separately, so that pages may be processed
+	// concurrently.
+	upsert := func(ctx context.Context, secGroups []groups.SecGroup) error {
+		items := make([]models.SecurityGroup, 0, len(secGroups))
+		for _, group := range secGroups {
+			if !openstackutils.MatchFilters(payload.Filters, nil, group.Tags) {
+				continue
+			}
+
+			item := models.SecurityGroup{
+				SecurityGroupID: group.ID,
+				Name:            group.Name,
+				ProjectID:       group.ProjectID,
+				Domain:          client.Domain,
+				Region:          client.Region,
+				Description:     group.Description,
+				Stateful:        group.Stateful,
+				TimeCreated:     group.CreatedAt,
+				TimeUpdated:     group.UpdatedAt,
+				Tags:            openstackutils.TagsToMap(group.Tags),
+			}
+			items = append(items, item)
+
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		n, err := dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+			return q.On("CONFLICT (security_group_id, project_id) DO UPDATE").
+				Set("name = EXCLUDED.name").
+				Set("domain = EXCLUDED.domain").
+				Set("region = EXCLUDED.region").
+				Set("description = EXCLUDED.description").
+				Set("stateful = EXCLUDED.stateful").
+				Set("security_group_created_at = EXCLUDED.security_group_created_at").
+				Set("security_group_updated_at = EXCLUDED.security_group_updated_at").
+				Set("tags = EXCLUDED.tags").
+				Set("updated_at = EXCLUDED.updated_at").
+				Returning("id")
+		}, 0)
+
+		if err != nil {
+			logger.Error(
+				"could not insert security groups into db",
+				"project", payload.Scope.Project,
+				"domain", payload.Scope.Domain,
+				"region", payload.Scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		count.Add(n)
+
+		mu.Lock()
+		for _, item := range items {
+			ids = append(ids, item.SecurityGroupID)
+		}
+		mu.Unlock()
+
+		return nil
+	}
+
+	if err := paginate.PaginatePages(ctx, fetch, upsert, payload.Concurrency); err != nil {
+		logger.Error(
+			"could not extract security group pages",
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated openstack security groups",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+		"count", count.Load(),
+	)
+
+	return reconcileProject[models.SecurityGroup](ctx, client, payload.Filters, "security groups", "security_group_id", ids)
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// isProjectSelected returns true, if the project of the given client scope is
+// selected for collection via the configured include and exclude lists.
+// Projects, which are not selected are logged along with the reason.
+func isProjectSelected(ctx context.Context, scope openstackclients.ClientScope) bool {
+	ok, reason := openstackclients.ProjectSelection.Check(scope.Project)
+	if !ok {
+		logger := asynqutils.GetLogger(ctx)
+		logger.Info(
+			"skipping openstack project",
+			"project", scope.Project,
+			"domain", scope.Domain,
+			"region", scope.Region,
+			"reason", reason,
+		)
+	}
+
+	return ok
+}
@@ -13,14 +13,17 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/core/globalid"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+	"github.com/gardener/inventory/pkg/utils/ptr"
 )
 
 const (
@@ -34,6 +37,11 @@ const (
 type CollectServersPayload struct {
 	// Scope specifies the client scope for which to collect.
 	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Filters optionally specifies the metadata or tags, which the
+	// collected resources must have. See [openstackutils.MatchFilters] for
+	// details. If empty, all resources are collected.
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters"`
 }
 
 // NewCollectServersTask creates a new [asynq.Task] for collecting OpenStack
@@ -48,7 +56,7 @@ func HandleCollectServersTask(ctx context.Context, t *asynq.Task) error {
 	// collecting OpenStack Servers from all configured server clients.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectServers(ctx)
+		return enqueueCollectServers(ctx, nil)
 	}
 
 	var payload CollectServersPayload
@@ -56,6 +64,11 @@ func HandleCollectServersTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectServers(ctx, payload.Filters)
+	}
+
 	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
 		return asynqutils.SkipRetry(ErrInvalidScope)
 	}
@@ -65,10 +78,12 @@ func HandleCollectServersTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectServers enqueues tasks for collecting OpenStack Servers from
 // all configured OpenStack server clients by creating a payload with the respective
-// client scope.
-func enqueueCollectServers(ctx context.Context) error {
+// client scope and the given filters.
+func enqueueCollectServers(ctx context.Context, filters map[string]string) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.ComputeClientset.Length() == 0 {
 		logger.Warn("no OpenStack compute clients found")
 
@@ -77,9 +92,15 @@ func enqueueCollectServers(ctx context.Context) error {
 
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.ComputeClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.ComputeClientset.Length())
+	err := openstackclients.ComputeClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
 		payload := CollectServersPayload{
-			Scope: scope,
+			Scope:   scope,
+			Filters: filters,
 		}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -94,33 +115,18 @@ func enqueueCollectServers(ctx context.Context) error {
 			return err
 		}
 
-		task := asynq.NewTask(TaskCollectServers, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", scope.Project,
-				"domain", scope.Domain,
-				"region", scope.Region,
-				"reason", err,
-			)
-
-			return err
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", scope.Project,
-			"domain", scope.Domain,
-			"region", scope.Region,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectServers, data)
+		})
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectServer collects the OpenStack servers,
@@ -128,9 +134,9 @@ func enqueueCollectServers(ctx context.Context) error {
 func collectServers(ctx context.Context, payload CollectServersPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.ComputeClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.ComputeClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -161,9 +167,12 @@ func collectServers(ctx context.Context, payload CollectServersPayload) error {
 
 	items := make([]models.Server, 0)
 
-	err := servers.List(client.Client, nil).
+	err = servers.List(client.Client, nil).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				serverList, err := servers.ExtractServers(page)
 
 				if err != nil {
@@ -176,6 +185,10 @@ func collectServers(ctx context.Context, payload CollectServersPayload) error {
 				}
 
 				for _, s := range serverList {
+					if !openstackutils.MatchFilters(payload.Filters, s.Metadata, ptr.Value(s.Tags, nil)) {
+						continue
+					}
+
 					item := models.Server{
 						ServerID:         s.ID,
 						Name:             s.Name,
@@ -187,6 +200,7 @@ func collectServers(ctx context.Context, payload CollectServersPayload) error {
 						Status:           s.Status,
 						TimeCreated:      s.Created,
 						TimeUpdated:      s.Updated,
+						GlobalID:         globalid.New("openstack", s.TenantID, s.ID),
 					}
 
 					imageID, ok := s.Image["id"]
@@ -197,6 +211,17 @@ func collectServers(ctx context.Context, payload CollectServersPayload) error {
 						}
 					}
 
+					// Starting with microversion 2.47 the
+					// flavor is embedded in the server, and
+					// only its original name is provided
+					// instead of its id.
+					if flavorID, ok := s.Flavor["id"].(string); ok {
+						item.FlavorID = flavorID
+					}
+					if flavorName, ok := s.Flavor["original_name"].(string); ok {
+						item.FlavorName = flavorName
+					}
+
 					items = append(items, item)
 				}
 
@@ -212,25 +237,32 @@ func collectServers(ctx context.Context, payload CollectServersPayload) error {
 		return err
 	}
 
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ServerID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.Server](ctx, client, payload.Filters, "servers", "server_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (server_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("domain = EXCLUDED.domain").
-		Set("region = EXCLUDED.region").
-		Set("user_id = EXCLUDED.user_id").
-		Set("availability_zone = EXCLUDED.availability_zone").
-		Set("status = EXCLUDED.status").
-		Set("image_id = EXCLUDED.image_id").
-		Set("server_created_at = EXCLUDED.server_created_at").
-		Set("server_updated_at = EXCLUDED.server_updated_at").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (server_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("region = EXCLUDED.region").
+			Set("user_id = EXCLUDED.user_id").
+			Set("availability_zone = EXCLUDED.availability_zone").
+			Set("status = EXCLUDED.status").
+			Set("image_id = EXCLUDED.image_id").
+			Set("flavor_id = EXCLUDED.flavor_id").
+			Set("flavor_name = EXCLUDED.flavor_name").
+			Set("server_created_at = EXCLUDED.server_created_at").
+			Set("server_updated_at = EXCLUDED.server_updated_at").
+			Set("global_id = EXCLUDED.global_id").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -244,11 +276,6 @@ func collectServers(ctx context.Context, payload CollectServersPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack servers",
 		"project", payload.Scope.Project,
@@ -257,5 +284,5 @@ func collectServers(ctx context.Context, payload CollectServersPayload) error {
 		"count", count,
 	)
 
-	return nil
+	return reconcileProject[models.Server](ctx, client, payload.Filters, "servers", "server_id", ids)
 }
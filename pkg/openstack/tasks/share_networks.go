@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/sharedfilesystems/v2/sharenetworks"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// TaskCollectShareNetworks is the name of the task for collecting OpenStack
+	// Share Networks.
+	TaskCollectShareNetworks = "openstack:task:collect-share-networks"
+)
+
+// CollectShareNetworksPayload represents the payload, which specifies
+// where to collect OpenStack Share Networks from.
+type CollectShareNetworksPayload struct {
+	// Scope specifies the client scope for which to collect.
+	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+}
+
+// NewCollectShareNetworksTask creates a new [asynq.Task] for collecting OpenStack
+// Share Networks, without specifying a payload.
+func NewCollectShareNetworksTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectShareNetworks, nil)
+}
+
+// HandleCollectShareNetworksTask handles the task for collecting OpenStack Share Networks.
+func HandleCollectShareNetworksTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting OpenStack Share Networks from all configured shared file system clients.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectShareNetworks(ctx)
+	}
+
+	var payload CollectShareNetworksPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
+		return asynqutils.SkipRetry(ErrInvalidScope)
+	}
+
+	return collectShareNetworks(ctx, payload)
+}
+
+// enqueueCollectShareNetworks enqueues tasks for collecting OpenStack Share Networks from
+// all configured OpenStack shared file system clients by creating a payload with the respective
+// client scope.
+func enqueueCollectShareNetworks(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshDomainProjects(ctx)
+
+	if openstackclients.SharedFileSystemClientset.Length() == 0 {
+		logger.Warn("no OpenStack shared file system clients found")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.SharedFileSystemClientset.Length())
+	err := openstackclients.SharedFileSystemClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectShareNetworksPayload{
+			Scope: scope,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack share networks",
+				"project", scope.Project,
+				"domain", scope.Domain,
+				"region", scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectShareNetworks, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectShareNetworks collects the OpenStack Share Networks,
+// using the client associated with the client scope in the given payload.
+func collectShareNetworks(ctx context.Context, payload CollectShareNetworksPayload) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	client, err := getClient(ctx, openstackclients.SharedFileSystemClientset, payload.Scope)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(
+		"collecting OpenStack share networks",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+	)
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			shareNetworksDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		key := metrics.Key(
+			TaskCollectShareNetworks,
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	items := make([]models.ShareNetwork, 0)
+
+	err = sharenetworks.ListDetail(client.Client, nil).
+		EachPage(ctx,
+			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
+				shareNetworkList, err := sharenetworks.ExtractShareNetworks(page)
+
+				if err != nil {
+					logger.Error(
+						"could not extract share network pages",
+						"reason", err,
+					)
+
+					return false, err
+				}
+
+				for _, sn := range shareNetworkList {
+					item := models.ShareNetwork{
+						ShareNetworkID:  sn.ID,
+						Name:            sn.Name,
+						ProjectID:       sn.ProjectID,
+						Domain:          client.Domain,
+						Region:          client.Region,
+						NeutronNetID:    sn.NeutronNetID,
+						NeutronSubnetID: sn.NeutronSubnetID,
+						NetworkType:     sn.NetworkType,
+						CIDR:            sn.CIDR,
+						IPVersion:       sn.IPVersion,
+						Description:     sn.Description,
+						TimeCreated:     sn.CreatedAt,
+						TimeUpdated:     sn.UpdatedAt,
+					}
+
+					items = append(items, item)
+				}
+
+				return true, nil
+			})
+
+	if err != nil {
+		logger.Error(
+			"could not extract share network pages",
+			"reason", err,
+		)
+
+		return err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ShareNetworkID)
+	}
+
+	if len(items) == 0 {
+		return reconcileProject[models.ShareNetwork](ctx, client, nil, "share networks", "share_network_id", ids)
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (share_network_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("region = EXCLUDED.region").
+			Set("neutron_net_id = EXCLUDED.neutron_net_id").
+			Set("neutron_subnet_id = EXCLUDED.neutron_subnet_id").
+			Set("network_type = EXCLUDED.network_type").
+			Set("cidr = EXCLUDED.cidr").
+			Set("ip_version = EXCLUDED.ip_version").
+			Set("description = EXCLUDED.description").
+			Set("share_network_created_at = EXCLUDED.share_network_created_at").
+			Set("share_network_updated_at = EXCLUDED.share_network_updated_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert share networks into db",
+			"project", payload.Scope.Project,
+			"domain", payload.Scope.Domain,
+			"region", payload.Scope.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated openstack share networks",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+		"count", count,
+	)
+
+	return reconcileProject[models.ShareNetwork](ctx, client, nil, "share networks", "share_network_id", ids)
+}
@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/sharedfilesystems/v2/shares"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/clients/db"
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+const (
+	// TaskCollectShares is the name of the task for collecting OpenStack
+	// Shares.
+	TaskCollectShares = "openstack:task:collect-shares"
+)
+
+// CollectSharesPayload represents the payload, which specifies
+// where to collect OpenStack Shares from.
+type CollectSharesPayload struct {
+	// Scope specifies the client scope for which to collect.
+	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+}
+
+// NewCollectSharesTask creates a new [asynq.Task] for collecting OpenStack
+// Shares, without specifying a payload.
+func NewCollectSharesTask() *asynq.Task {
+	return asynq.NewTask(TaskCollectShares, nil)
+}
+
+// HandleCollectSharesTask handles the task for collecting OpenStack Shares.
+func HandleCollectSharesTask(ctx context.Context, t *asynq.Task) error {
+	// If we were called without a payload, then we enqueue tasks for
+	// collecting OpenStack Shares from all configured shared file system clients.
+	data := t.Payload()
+	if data == nil {
+		return enqueueCollectShares(ctx)
+	}
+
+	var payload CollectSharesPayload
+	if err := asynqutils.Unmarshal(data, &payload); err != nil {
+		return asynqutils.SkipRetry(err)
+	}
+
+	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
+		return asynqutils.SkipRetry(ErrInvalidScope)
+	}
+
+	return collectShares(ctx, payload)
+}
+
+// enqueueCollectShares enqueues tasks for collecting OpenStack Shares from
+// all configured OpenStack shared file system clients by creating a payload with the respective
+// client scope.
+func enqueueCollectShares(ctx context.Context) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	refreshDomainProjects(ctx)
+
+	if openstackclients.SharedFileSystemClientset.Length() == 0 {
+		logger.Warn("no OpenStack shared file system clients found")
+
+		return nil
+	}
+
+	queue := asynqutils.GetQueueName(ctx)
+
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.SharedFileSystemClientset.Length())
+	err := openstackclients.SharedFileSystemClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
+		payload := CollectSharesPayload{
+			Scope: scope,
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(
+				"failed to marshal payload for OpenStack shares",
+				"project", scope.Project,
+				"domain", scope.Domain,
+				"region", scope.Region,
+				"reason", err,
+			)
+
+			return err
+		}
+
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectShares, data)
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
+}
+
+// collectShares collects the OpenStack Shares and their export locations,
+// using the client associated with the client scope in the given payload.
+func collectShares(ctx context.Context, payload CollectSharesPayload) error {
+	logger := asynqutils.GetLogger(ctx)
+
+	client, err := getClient(ctx, openstackclients.SharedFileSystemClientset, payload.Scope)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(
+		"collecting OpenStack shares",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+	)
+
+	var count int64
+	defer func() {
+		metric := prometheus.MustNewConstMetric(
+			sharesDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		key := metrics.Key(
+			TaskCollectShares,
+			payload.Scope.Project,
+			payload.Scope.Domain,
+			payload.Scope.Region,
+		)
+		metrics.DefaultCollector.AddMetric(key, metric)
+	}()
+
+	shareItems := make([]models.Share, 0)
+	exportLocationItems := make([]models.ShareExportLocation, 0)
+
+	err = shares.ListDetail(client.Client, nil).
+		EachPage(ctx,
+			func(ctx context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
+				shareList, err := shares.ExtractShares(page)
+
+				if err != nil {
+					logger.Error(
+						"could not extract share pages",
+						"reason", err,
+					)
+
+					return false, err
+				}
+
+				for _, s := range shareList {
+					item := models.Share{
+						ShareID:          s.ID,
+						Name:             s.Name,
+						ProjectID:        s.ProjectID,
+						Domain:           client.Domain,
+						Region:           client.Region,
+						Status:           s.Status,
+						Size:             s.Size,
+						ShareProto:       s.ShareProto,
+						ShareType:        s.ShareType,
+						ShareTypeName:    s.ShareTypeName,
+						ShareNetworkID:   s.ShareNetworkID,
+						AvailabilityZone: s.AvailabilityZone,
+						IsPublic:         s.IsPublic,
+						Description:      s.Description,
+						TimeCreated:      s.CreatedAt,
+						TimeUpdated:      s.UpdatedAt,
+					}
+					shareItems = append(shareItems, item)
+
+					exportLocations, err := shares.ListExportLocations(ctx, client.Client, s.ID).Extract()
+					if err != nil {
+						// Simply log the error and keep going with
+						// the rest of the shares
+						logger.Warn(
+							"could not get share export locations",
+							"project", payload.Scope.Project,
+							"domain", payload.Scope.Domain,
+							"region", payload.Scope.Region,
+							"share_id", s.ID,
+							"reason", err,
+						)
+
+						continue
+					}
+
+					for _, el := range exportLocations {
+						item := models.ShareExportLocation{
+							ExportLocationID: el.ID,
+							ShareID:          s.ID,
+							ProjectID:        s.ProjectID,
+							Path:             el.Path,
+							ShareInstanceID:  el.ShareInstanceID,
+							IsAdminOnly:      el.IsAdminOnly,
+							Preferred:        el.Preferred,
+						}
+						exportLocationItems = append(exportLocationItems, item)
+					}
+				}
+
+				return true, nil
+			})
+
+	if err != nil {
+		logger.Error(
+			"could not extract share pages",
+			"reason", err,
+		)
+
+		return err
+	}
+
+	ids := make([]string, 0, len(shareItems))
+	for _, item := range shareItems {
+		ids = append(ids, item.ShareID)
+	}
+
+	if len(shareItems) == 0 {
+		return reconcileProject[models.Share](ctx, client, nil, "shares", "share_id", ids)
+	}
+
+	count, err = dbutils.BulkInsert(ctx, db.DB, shareItems, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (share_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("region = EXCLUDED.region").
+			Set("status = EXCLUDED.status").
+			Set("size = EXCLUDED.size").
+			Set("share_proto = EXCLUDED.share_proto").
+			Set("share_type = EXCLUDED.share_type").
+			Set("share_type_name = EXCLUDED.share_type_name").
+			Set("share_network_id = EXCLUDED.share_network_id").
+			Set("availability_zone = EXCLUDED.availability_zone").
+			Set("is_public = EXCLUDED.is_public").
+			Set("description = EXCLUDED.description").
+			Set("share_created_at = EXCLUDED.share_created_at").
+			Set("share_updated_at = EXCLUDED.share_updated_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert shares into db",
+			"project", payload.Scope.Project,
+			"domain", payload.Scope.Domain,
+			"region", payload.Scope.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated openstack shares",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+		"count", count,
+	)
+
+	if err := reconcileProject[models.Share](ctx, client, nil, "shares", "share_id", ids); err != nil {
+		return err
+	}
+
+	if len(exportLocationItems) == 0 {
+		return nil
+	}
+
+	exportLocationCount, err := dbutils.BulkInsert(ctx, db.DB, exportLocationItems, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (export_location_id, share_id, project_id) DO UPDATE").
+			Set("path = EXCLUDED.path").
+			Set("share_instance_id = EXCLUDED.share_instance_id").
+			Set("is_admin_only = EXCLUDED.is_admin_only").
+			Set("preferred = EXCLUDED.preferred").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
+
+	if err != nil {
+		logger.Error(
+			"could not insert share export locations into db",
+			"project", payload.Scope.Project,
+			"domain", payload.Scope.Domain,
+			"region", payload.Scope.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"populated openstack share export locations",
+		"project", payload.Scope.Project,
+		"domain", payload.Scope.Domain,
+		"region", payload.Scope.Region,
+		"count", exportLocationCount,
+	)
+
+	return nil
+}
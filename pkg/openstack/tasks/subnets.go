@@ -13,14 +13,15 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -34,6 +35,11 @@ const (
 type CollectSubnetsPayload struct {
 	// Scope specifies the client scope from which to collect.
 	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Filters optionally specifies the metadata or tags, which the
+	// collected resources must have. See [openstackutils.MatchFilters] for
+	// details. If empty, all resources are collected.
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters"`
 }
 
 // NewCollectSubnetsTask creates a new [asynq.Task] for collecting OpenStack
@@ -48,7 +54,7 @@ func HandleCollectSubnetsTask(ctx context.Context, t *asynq.Task) error {
 	// collecting OpenStack Subnets from all configured network clients.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectSubnets(ctx)
+		return enqueueCollectSubnets(ctx, nil)
 	}
 
 	var payload CollectSubnetsPayload
@@ -56,6 +62,11 @@ func HandleCollectSubnetsTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectSubnets(ctx, payload.Filters)
+	}
+
 	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
 		return asynqutils.SkipRetry(ErrInvalidScope)
 	}
@@ -65,21 +76,37 @@ func HandleCollectSubnetsTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectSubnets enqueues tasks for collecting OpenStack Subnets from
 // all configured OpenStack network clients by creating a payload with the respective
-// client scope.
-func enqueueCollectSubnets(ctx context.Context) error {
+// client scope and the given filters.
+func enqueueCollectSubnets(ctx context.Context, filters map[string]string) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.NetworkClientset.Length() == 0 {
 		logger.Warn("no OpenStack subnet clients found")
 
 		return nil
 	}
 
+	// Subnets are collected by the networks collector, when it is
+	// configured to collect its children inline.
+	if asynqutils.CollectChildrenInline(TaskCollectNetworks) {
+		logger.Info("OpenStack subnets are collected inline with networks, skipping")
+
+		return nil
+	}
+
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.NetworkClientset.Length())
+	err := openstackclients.NetworkClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
 		payload := CollectSubnetsPayload{
-			Scope: scope,
+			Scope:   scope,
+			Filters: filters,
 		}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -94,33 +121,18 @@ func enqueueCollectSubnets(ctx context.Context) error {
 			return err
 		}
 
-		task := asynq.NewTask(TaskCollectSubnets, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", scope.Project,
-				"domain", scope.Domain,
-				"region", scope.Region,
-				"reason", err,
-			)
-
-			return err
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", scope.Project,
-			"domain", scope.Domain,
-			"region", scope.Region,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectSubnets, data)
+		})
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectSubnets collects the OpenStack Subnets,
@@ -128,9 +140,9 @@ func enqueueCollectSubnets(ctx context.Context) error {
 func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.NetworkClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.NetworkClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -161,9 +173,12 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 
 	items := make([]models.Subnet, 0)
 
-	err := subnets.List(client.Client, nil).
+	err = subnets.List(client.Client, nil).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				subnetList, err := subnets.ExtractSubnets(page)
 
 				if err != nil {
@@ -176,6 +191,10 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 				}
 
 				for _, s := range subnetList {
+					if !openstackutils.MatchFilters(payload.Filters, nil, s.Tags) {
+						continue
+					}
+
 					item := models.Subnet{
 						SubnetID:     s.ID,
 						Name:         s.Name,
@@ -189,6 +208,7 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 						EnableDHCP:   s.EnableDHCP,
 						IPVersion:    s.IPVersion,
 						Description:  s.Description,
+						Tags:         openstackutils.TagsToMap(s.Tags),
 					}
 
 					items = append(items, item)
@@ -206,26 +226,31 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		return err
 	}
 
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.SubnetID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.Subnet](ctx, client, payload.Filters, "subnets", "subnet_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (subnet_id, project_id) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("domain = EXCLUDED.domain").
-		Set("region = EXCLUDED.region").
-		Set("network_id = EXCLUDED.network_id").
-		Set("gateway_ip = EXCLUDED.gateway_ip").
-		Set("cidr = EXCLUDED.cidr").
-		Set("subnet_pool_id = EXCLUDED.subnet_pool_id").
-		Set("enable_dhcp = EXCLUDED.enable_dhcp").
-		Set("ip_version = EXCLUDED.ip_version").
-		Set("description = EXCLUDED.description").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (subnet_id, project_id) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("domain = EXCLUDED.domain").
+			Set("region = EXCLUDED.region").
+			Set("network_id = EXCLUDED.network_id").
+			Set("gateway_ip = EXCLUDED.gateway_ip").
+			Set("cidr = EXCLUDED.cidr").
+			Set("subnet_pool_id = EXCLUDED.subnet_pool_id").
+			Set("enable_dhcp = EXCLUDED.enable_dhcp").
+			Set("ip_version = EXCLUDED.ip_version").
+			Set("description = EXCLUDED.description").
+			Set("tags = EXCLUDED.tags").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -239,11 +264,6 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack subnets",
 		"project", payload.Scope.Project,
@@ -252,5 +272,5 @@ func collectSubnets(ctx context.Context, payload CollectSubnetsPayload) error {
 		"count", count,
 	)
 
-	return nil
+	return reconcileProject[models.Subnet](ctx, client, payload.Filters, "subnets", "subnet_id", ids)
 }
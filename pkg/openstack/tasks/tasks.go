@@ -7,10 +7,13 @@ package tasks
 import (
 	"context"
 
+	"github.com/gophercloud/gophercloud/v2"
 	"github.com/hibiken/asynq"
 
 	"github.com/gardener/inventory/pkg/clients/db"
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/core/registry"
+	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
@@ -25,6 +28,120 @@ const (
 	TaskLinkAll = "openstack:task:link-all"
 )
 
+// refreshDomainProjects discovers the projects of the domain-scoped clients,
+// so that tasks are enqueued for projects, which have been added to a domain
+// since the last discovery. Failures to discover the projects are not fatal,
+// since tasks are still enqueued for the already known projects.
+func refreshDomainProjects(ctx context.Context) {
+	if err := openstackutils.RefreshDomainProjects(ctx); err != nil {
+		logger := asynqutils.GetLogger(ctx)
+		logger.Warn("failed to discover OpenStack projects", "reason", err)
+	}
+}
+
+// getClient returns the client for the given scope from the given clientset.
+//
+// When no client is found for a scope, which is derived from domain-scoped
+// credentials, the projects of the domain are discovered and the client is
+// looked up again. If the client is still not found, the returned error is
+// retried, since the project may be discovered later on. A missing client for
+// any other scope is not retried.
+func getClient(
+	ctx context.Context,
+	clientset *registry.Registry[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]],
+	scope openstackclients.ClientScope,
+) (openstackclients.Client[*gophercloud.ServiceClient], error) {
+	if client, ok := clientset.Get(scope); ok {
+		return client, nil
+	}
+
+	derived, err := openstackutils.RefreshScopeProjects(ctx, scope)
+	if !derived {
+		return openstackclients.Client[*gophercloud.ServiceClient]{}, asynqutils.SkipRetry(ClientNotFound(scope.Project))
+	}
+
+	if err != nil {
+		logger := asynqutils.GetLogger(ctx)
+		logger.Warn("failed to discover OpenStack projects", "reason", err)
+	}
+
+	client, ok := clientset.Get(scope)
+	if !ok {
+		return client, ClientNotFound(scope.Project)
+	}
+
+	return client, nil
+}
+
+// reconcileProject marks the resources of model T, which were collected with
+// the given client, as seen, and the ones, which have disappeared, as deleted.
+// The resources are scoped by the project of the client token and the region
+// of the client. The idColumn specifies the column, which contains the
+// collected ids, and the kind is used for logging.
+//
+// Collections filtered by metadata or tags do not cover the whole project,
+// and are not reconciled. Otherwise, it must be called only after the
+// collection of the whole project succeeded, since an empty list of ids marks
+// all resources of the project as deleted.
+func reconcileProject[T any](
+	ctx context.Context,
+	client openstackclients.Client[*gophercloud.ServiceClient],
+	filters map[string]string,
+	kind string,
+	idColumn string,
+	ids []string,
+) error {
+	if len(filters) > 0 {
+		return nil
+	}
+
+	logger := asynqutils.GetLogger(ctx)
+	projectID, err := openstackutils.TokenProjectID(client.Client.ProviderClient)
+	if err != nil {
+		logger.Error(
+			"could not get project of client token",
+			"kind", kind,
+			"project", client.Project,
+			"domain", client.Domain,
+			"region", client.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	scope := map[string]any{
+		"project_id": projectID,
+		"region":     client.Region,
+	}
+
+	result, err := dbutils.Reconcile[T](ctx, db.DB, scope, idColumn, ids)
+	if err != nil {
+		logger.Error(
+			"could not reconcile openstack resources",
+			"kind", kind,
+			"project", client.Project,
+			"domain", client.Domain,
+			"region", client.Region,
+			"reason", err,
+		)
+
+		return err
+	}
+
+	logger.Info(
+		"reconciled openstack resources",
+		"kind", kind,
+		"project", client.Project,
+		"domain", client.Domain,
+		"region", client.Region,
+		"seen", result.Seen,
+		"deleted", result.Deleted,
+	)
+
+	return nil
+}
+
 // HandleCollectAllTask is a handler, which enqueues tasks for collecting all
 // OpenStack objects.
 func HandleCollectAllTask(ctx context.Context, _ *asynq.Task) error {
@@ -44,9 +161,15 @@ func HandleCollectAllTask(ctx context.Context, _ *asynq.Task) error {
 		NewCollectPoolsTask,
 		NewCollectContainersTask,
 		NewCollectVolumesTask,
+		NewCollectImagesTask,
+		NewCollectSharesTask,
+		NewCollectShareNetworksTask,
+		NewCollectSecurityGroupsTask,
+		NewCollectSecurityGroupRulesTask,
+		NewCollectQuotaUsageTask,
 	}
 
-	return asynqutils.Enqueue(ctx, taskFns, asynq.Queue(queue))
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // HandleLinkAllTask is a handler, which establishes links between the various
@@ -62,6 +185,14 @@ func HandleLinkAllTask(ctx context.Context, _ *asynq.Task) error {
 		LinkLoadBalancersWithNetworks,
 		LinkNetworksWithProjects,
 		LinkSubnetsWithProjects,
+		LinkShareWithShareNetwork,
+		LinkSecurityGroupRuleWithGroup,
+		LinkPortWithSecurityGroup,
+		LinkFloatingIPWithServer,
+		LinkFloatingIPWithRouter,
+		LinkFloatingIPWithNetwork,
+		LinkVolumeWithServer,
+		LinkServerWithImage,
 	}
 
 	return dbutils.LinkObjects(ctx, db.DB, linkFns)
@@ -75,6 +206,7 @@ func init() {
 	registry.TaskRegistry.MustRegister(TaskCollectLoadBalancers, asynq.HandlerFunc(HandleCollectLoadBalancersTask))
 	registry.TaskRegistry.MustRegister(TaskCollectSubnets, asynq.HandlerFunc(HandleCollectSubnetsTask))
 	registry.TaskRegistry.MustRegister(TaskCollectFloatingIPs, asynq.HandlerFunc(HandleCollectFloatingIPsTask))
+	registry.TaskRegistry.MustRegister(TaskRefreshFloatingIPAssociations, asynq.HandlerFunc(HandleRefreshFloatingIPAssociationsTask))
 	registry.TaskRegistry.MustRegister(TaskCollectProjects, asynq.HandlerFunc(HandleCollectProjectsTask))
 	registry.TaskRegistry.MustRegister(TaskCollectRouters, asynq.HandlerFunc(HandleCollectRoutersTask))
 	registry.TaskRegistry.MustRegister(TaskCollectPorts, asynq.HandlerFunc(HandleCollectPortsTask))
@@ -82,6 +214,53 @@ func init() {
 	registry.TaskRegistry.MustRegister(TaskCollectPools, asynq.HandlerFunc(HandleCollectPoolsTask))
 	registry.TaskRegistry.MustRegister(TaskCollectContainers, asynq.HandlerFunc(HandleCollectContainersTask))
 	registry.TaskRegistry.MustRegister(TaskCollectVolumes, asynq.HandlerFunc(HandleCollectVolumesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectImages, asynq.HandlerFunc(HandleCollectImagesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectShares, asynq.HandlerFunc(HandleCollectSharesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectShareNetworks, asynq.HandlerFunc(HandleCollectShareNetworksTask))
+	registry.TaskRegistry.MustRegister(TaskCollectSecurityGroups, asynq.HandlerFunc(HandleCollectSecurityGroupsTask))
+	registry.TaskRegistry.MustRegister(TaskCollectSecurityGroupRules, asynq.HandlerFunc(HandleCollectSecurityGroupRulesTask))
+	registry.TaskRegistry.MustRegister(TaskCollectQuotaUsage, asynq.HandlerFunc(HandleCollectQuotaUsageTask))
 	registry.TaskRegistry.MustRegister(TaskCollectAll, asynq.HandlerFunc(HandleCollectAllTask))
 	registry.TaskRegistry.MustRegister(TaskLinkAll, asynq.HandlerFunc(HandleLinkAllTask))
+	registry.TaskRegistry.MustRegister(TaskComputeExposureFindings, asynq.HandlerFunc(HandleComputeExposureFindingsTask))
+
+	// Collection ordering
+	registry.TaskGraph.MustAdd(TaskCollectProjects)
+	registry.TaskGraph.MustAdd(TaskCollectNetworks, TaskCollectProjects)
+	registry.TaskGraph.MustAdd(TaskCollectSubnets, TaskCollectNetworks)
+	registry.TaskGraph.MustAdd(TaskCollectServers, TaskCollectProjects)
+	registry.TaskGraph.MustAdd(TaskCollectPorts, TaskCollectServers, TaskCollectNetworks)
+	registry.TaskGraph.MustAdd(TaskCollectFloatingIPs, TaskCollectPorts)
+	registry.TaskGraph.MustAdd(TaskCollectRouters, TaskCollectNetworks)
+	registry.TaskGraph.MustAdd(TaskCollectLoadBalancers, TaskCollectSubnets)
+	registry.TaskGraph.MustAdd(TaskCollectPools, TaskCollectLoadBalancers)
+	registry.TaskGraph.MustAdd(TaskCollectVolumes, TaskCollectProjects)
+	registry.TaskGraph.MustAdd(TaskCollectImages, TaskCollectProjects)
+	registry.TaskGraph.MustAdd(TaskCollectObjects, TaskCollectContainers)
+	registry.TaskGraph.MustAdd(TaskCollectContainers, TaskCollectProjects)
+	registry.TaskGraph.MustAdd(TaskCollectShareNetworks, TaskCollectProjects)
+	registry.TaskGraph.MustAdd(TaskCollectShares, TaskCollectShareNetworks)
+	registry.TaskGraph.MustAdd(TaskCollectSecurityGroups, TaskCollectProjects)
+	registry.TaskGraph.MustAdd(TaskCollectSecurityGroupRules, TaskCollectSecurityGroups)
+	registry.TaskGraph.MustAdd(TaskCollectQuotaUsage, TaskCollectProjects)
+	registry.TaskGraph.MustAdd(
+		TaskLinkAll,
+		TaskCollectSubnets,
+		TaskCollectFloatingIPs,
+		TaskCollectRouters,
+		TaskCollectPools,
+		TaskCollectVolumes,
+		TaskCollectImages,
+		TaskCollectObjects,
+		TaskCollectShares,
+		TaskCollectSecurityGroups,
+		TaskCollectSecurityGroupRules,
+	)
+	registry.TaskGraph.MustAdd(
+		TaskComputeExposureFindings,
+		TaskCollectFloatingIPs,
+		TaskCollectLoadBalancers,
+		TaskCollectSecurityGroups,
+		TaskCollectSecurityGroupRules,
+	)
 }
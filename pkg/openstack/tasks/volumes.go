@@ -13,14 +13,15 @@ import (
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	"github.com/hibiken/asynq"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
 
-	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
 	"github.com/gardener/inventory/pkg/metrics"
 	"github.com/gardener/inventory/pkg/openstack/models"
 	openstackutils "github.com/gardener/inventory/pkg/openstack/utils"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
 )
 
 const (
@@ -34,6 +35,11 @@ const (
 type CollectVolumesPayload struct {
 	// Scope specifies the client scope for which to collect.
 	Scope openstackclients.ClientScope `json:"scope" yaml:"scope"`
+
+	// Filters optionally specifies the metadata or tags, which the
+	// collected resources must have. See [openstackutils.MatchFilters] for
+	// details. If empty, all resources are collected.
+	Filters map[string]string `json:"filters,omitempty" yaml:"filters"`
 }
 
 // NewCollectVolumesTask creates a new [asynq.Task] for collecting OpenStack
@@ -48,7 +54,7 @@ func HandleCollectVolumesTask(ctx context.Context, t *asynq.Task) error {
 	// collecting OpenStack Volumes from all configured volume clients.
 	data := t.Payload()
 	if data == nil {
-		return enqueueCollectVolumes(ctx)
+		return enqueueCollectVolumes(ctx, nil)
 	}
 
 	var payload CollectVolumesPayload
@@ -56,6 +62,11 @@ func HandleCollectVolumesTask(ctx context.Context, t *asynq.Task) error {
 		return asynqutils.SkipRetry(err)
 	}
 
+	// A payload without a scope configures the tasks for all clients.
+	if payload.Scope == (openstackclients.ClientScope{}) {
+		return enqueueCollectVolumes(ctx, payload.Filters)
+	}
+
 	if err := openstackutils.IsValidProjectScope(payload.Scope); err != nil {
 		return asynqutils.SkipRetry(ErrInvalidScope)
 	}
@@ -65,10 +76,12 @@ func HandleCollectVolumesTask(ctx context.Context, t *asynq.Task) error {
 
 // enqueueCollectVolumes enqueues tasks for collecting OpenStack Volumes from
 // all configured OpenStack volume clients by creating a payload with the respective
-// client scope.
-func enqueueCollectVolumes(ctx context.Context) error {
+// client scope and the given filters.
+func enqueueCollectVolumes(ctx context.Context, filters map[string]string) error {
 	logger := asynqutils.GetLogger(ctx)
 
+	refreshDomainProjects(ctx)
+
 	if openstackclients.BlockStorageClientset.Length() == 0 {
 		logger.Warn("no OpenStack blockstorage clients found")
 
@@ -77,9 +90,15 @@ func enqueueCollectVolumes(ctx context.Context) error {
 
 	queue := asynqutils.GetQueueName(ctx)
 
-	return openstackclients.BlockStorageClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+	taskFns := make([]asynqutils.TaskConstructor, 0, openstackclients.BlockStorageClientset.Length())
+	err := openstackclients.BlockStorageClientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		if !isProjectSelected(ctx, scope) {
+			return nil
+		}
+
 		payload := CollectVolumesPayload{
-			Scope: scope,
+			Scope:   scope,
+			Filters: filters,
 		}
 		data, err := json.Marshal(payload)
 		if err != nil {
@@ -94,33 +113,18 @@ func enqueueCollectVolumes(ctx context.Context) error {
 			return err
 		}
 
-		task := asynq.NewTask(TaskCollectVolumes, data)
-		info, err := asynqclient.Client.Enqueue(task, asynq.Queue(queue))
-		if err != nil {
-			logger.Error(
-				"failed to enqueue task",
-				"type", task.Type(),
-				"project", scope.Project,
-				"domain", scope.Domain,
-				"region", scope.Region,
-				"reason", err,
-			)
-
-			return err
-		}
-
-		logger.Info(
-			"enqueued task",
-			"type", task.Type(),
-			"id", info.ID,
-			"queue", info.Queue,
-			"project", scope.Project,
-			"domain", scope.Domain,
-			"region", scope.Region,
-		)
+		taskFns = append(taskFns, func() *asynq.Task {
+			return asynq.NewTask(TaskCollectVolumes, data)
+		})
 
 		return nil
 	})
+
+	if err != nil {
+		return err
+	}
+
+	return asynqutils.EnqueueBatch(ctx, taskFns, asynq.Queue(queue))
 }
 
 // collectVolumes collects the OpenStack Volumes,
@@ -128,9 +132,9 @@ func enqueueCollectVolumes(ctx context.Context) error {
 func collectVolumes(ctx context.Context, payload CollectVolumesPayload) error {
 	logger := asynqutils.GetLogger(ctx)
 
-	client, ok := openstackclients.BlockStorageClientset.Get(payload.Scope)
-	if !ok {
-		return asynqutils.SkipRetry(ClientNotFound(payload.Scope.Project))
+	client, err := getClient(ctx, openstackclients.BlockStorageClientset, payload.Scope)
+	if err != nil {
+		return err
 	}
 
 	logger.Info(
@@ -161,9 +165,12 @@ func collectVolumes(ctx context.Context, payload CollectVolumesPayload) error {
 
 	items := make([]models.Volume, 0)
 
-	err := volumes.List(client.Client, nil).
+	err = volumes.List(client.Client, nil).
 		EachPage(ctx,
 			func(_ context.Context, page pagination.Page) (bool, error) {
+				asynqutils.AddPages(ctx, 1)
+				openstackutils.SamplePage(ctx, page)
+
 				volumeList, err := volumes.ExtractVolumes(page)
 
 				if err != nil {
@@ -176,6 +183,17 @@ func collectVolumes(ctx context.Context, payload CollectVolumesPayload) error {
 				}
 
 				for _, v := range volumeList {
+					if !openstackutils.MatchFilters(payload.Filters, v.Metadata, nil) {
+						continue
+					}
+
+					serverIDs := make([]string, 0, len(v.Attachments))
+					for _, attachment := range v.Attachments {
+						if attachment.ServerID != "" {
+							serverIDs = append(serverIDs, attachment.ServerID)
+						}
+					}
+
 					item := models.Volume{
 						Name:              v.Name,
 						VolumeID:          v.ID,
@@ -195,6 +213,7 @@ func collectVolumes(ctx context.Context, payload CollectVolumesPayload) error {
 						Description:       v.Description,
 						TimeCreated:       v.CreatedAt,
 						TimeUpdated:       v.UpdatedAt,
+						ServerIDs:         serverIDs,
 					}
 
 					items = append(items, item)
@@ -212,30 +231,35 @@ func collectVolumes(ctx context.Context, payload CollectVolumesPayload) error {
 		return err
 	}
 
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.VolumeID)
+	}
+
 	if len(items) == 0 {
-		return nil
+		return reconcileProject[models.Volume](ctx, client, payload.Filters, "volumes", "volume_id", ids)
 	}
 
-	out, err := db.DB.NewInsert().
-		Model(&items).
-		On("CONFLICT (volume_id, project_id, domain, region) DO UPDATE").
-		Set("name = EXCLUDED.name").
-		Set("user_id = EXCLUDED.user_id").
-		Set("availability_zone = EXCLUDED.availability_zone").
-		Set("size = EXCLUDED.size").
-		Set("volume_type = EXCLUDED.volume_type").
-		Set("status = EXCLUDED.status").
-		Set("replication_status = EXCLUDED.replication_status").
-		Set("bootable = EXCLUDED.bootable").
-		Set("encrypted = EXCLUDED.encrypted").
-		Set("multi_attach = EXCLUDED.multi_attach").
-		Set("snapshot_id = EXCLUDED.snapshot_id").
-		Set("description = EXCLUDED.description").
-		Set("volume_created_at = EXCLUDED.volume_created_at").
-		Set("volume_updated_at = EXCLUDED.volume_updated_at").
-		Set("updated_at = EXCLUDED.updated_at").
-		Returning("id").
-		Exec(ctx)
+	count, err = dbutils.BulkInsert(ctx, db.DB, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return q.On("CONFLICT (volume_id, project_id, domain, region) DO UPDATE").
+			Set("name = EXCLUDED.name").
+			Set("user_id = EXCLUDED.user_id").
+			Set("availability_zone = EXCLUDED.availability_zone").
+			Set("size = EXCLUDED.size").
+			Set("volume_type = EXCLUDED.volume_type").
+			Set("status = EXCLUDED.status").
+			Set("replication_status = EXCLUDED.replication_status").
+			Set("bootable = EXCLUDED.bootable").
+			Set("encrypted = EXCLUDED.encrypted").
+			Set("multi_attach = EXCLUDED.multi_attach").
+			Set("snapshot_id = EXCLUDED.snapshot_id").
+			Set("description = EXCLUDED.description").
+			Set("volume_created_at = EXCLUDED.volume_created_at").
+			Set("volume_updated_at = EXCLUDED.volume_updated_at").
+			Set("server_ids = EXCLUDED.server_ids").
+			Set("updated_at = EXCLUDED.updated_at").
+			Returning("id")
+	}, 0)
 
 	if err != nil {
 		logger.Error(
@@ -249,11 +273,6 @@ func collectVolumes(ctx context.Context, payload CollectVolumesPayload) error {
 		return err
 	}
 
-	count, err = out.RowsAffected()
-	if err != nil {
-		return err
-	}
-
 	logger.Info(
 		"populated openstack volumes",
 		"project", payload.Scope.Project,
@@ -262,5 +281,5 @@ func collectVolumes(ctx context.Context, payload CollectVolumesPayload) error {
 		"count", count,
 	)
 
-	return nil
+	return reconcileProject[models.Volume](ctx, client, payload.Filters, "volumes", "volume_id", ids)
 }
@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/projects"
+	tokens3 "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
+
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+)
+
+// DefaultDomainDiscoveryInterval specifies the default min interval between
+// discovering the projects of a domain via [RefreshDomainProjects].
+const DefaultDomainDiscoveryInterval = 5 * time.Minute
+
+// ErrNotDomainScoped is an error, which is returned when a client, which is
+// expected to be domain-scoped, has been authenticated with a token, which is
+// not scoped to a domain.
+var ErrNotDomainScoped = errors.New("token is not scoped to a domain")
+
+// domainDiscovery tracks when the projects of each domain-scoped credentials
+// have last been discovered by [RefreshDomainProjects].
+var domainDiscovery = struct {
+	sync.Mutex
+	lastRun map[string]time.Time
+}{
+	lastRun: make(map[string]time.Time),
+}
+
+// DomainDiscoveryResult provides the result of discovering the projects of a
+// domain.
+type DomainDiscoveryResult struct {
+	// Projects is the number of enabled projects in the domain.
+	Projects int
+
+	// Added is the number of projects, for which clients have been added.
+	Added int
+
+	// Skipped is the number of projects, which are not accessible with the
+	// domain-scoped credentials.
+	Skipped int
+
+	// Removed is the number of projects, whose clients have been removed,
+	// because they are no longer part of the domain.
+	Removed int
+}
+
+// ListDomainProjects returns the enabled projects in the domain of the given
+// provider client, which is expected to be authenticated with a domain-scoped
+// token.
+func ListDomainProjects(ctx context.Context, providerClient *gophercloud.ProviderClient) ([]projects.Project, error) {
+	authResult, ok := providerClient.GetAuthResult().(tokens3.CreateResult)
+	if !ok {
+		return nil, errors.New("unexpected authentication result")
+	}
+
+	domain, err := authResult.ExtractDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	if domain == nil {
+		return nil, ErrNotDomainScoped
+	}
+
+	identityClient, err := openstack.NewIdentityV3(providerClient, gophercloud.EndpointOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := true
+	opts := projects.ListOpts{
+		DomainID: domain.ID,
+		Enabled:  &enabled,
+	}
+
+	pages, err := projects.List(identityClient, opts).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects.ExtractProjects(pages)
+}
+
+// NewProjectProviderClient returns a new provider client, which is scoped to
+// the project with the given id. The project-scoped token is requested by
+// rescoping the token of the given domain-scoped provider client, so that the
+// domain-scoped credentials are not used again for each project.
+//
+// The returned provider client shares the HTTP client of the domain-scoped
+// provider client, and re-authenticates by rescoping the current token of the
+// domain-scoped provider client.
+func NewProjectProviderClient(ctx context.Context, domainClient *gophercloud.ProviderClient, projectID string) (*gophercloud.ProviderClient, error) {
+	rescope := func(ctx context.Context) (*gophercloud.ProviderClient, error) {
+		client, err := openstack.NewClient(domainClient.IdentityEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		client.HTTPClient = domainClient.HTTPClient
+		client.UserAgent = domainClient.UserAgent
+
+		token := domainClient.Token()
+		opts := &tokens3.AuthOptions{
+			TokenID: token,
+			Scope: tokens3.Scope{
+				ProjectID: projectID,
+			},
+		}
+		err = openstack.AuthenticateV3(ctx, client, opts, gophercloud.EndpointOpts{})
+		if !gophercloud.ResponseCodeIs(err, http.StatusUnauthorized) {
+			return client, err
+		}
+
+		// The domain-scoped token has expired, so re-authenticate the
+		// domain-scoped provider client and try once more.
+		if err := domainClient.Reauthenticate(ctx, token); err != nil {
+			return nil, err
+		}
+		opts.TokenID = domainClient.Token()
+		err = openstack.AuthenticateV3(ctx, client, opts, gophercloud.EndpointOpts{})
+
+		return client, err
+	}
+
+	client, err := rescope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client.ReauthFunc = func(ctx context.Context) error {
+		other, err := rescope(ctx)
+		if err != nil {
+			return err
+		}
+		client.CopyTokenFrom(other)
+
+		return nil
+	}
+
+	return client, nil
+}
+
+// DiscoverDomainProjects discovers the projects in the domain of the given
+// domain-scoped client, and registers project-scoped clients for each of the
+// client services. The project-scoped clients are registered with named
+// credentials called `<name>/<project>'.
+//
+// Clients are created only for projects, which are not known yet. Projects,
+// which cannot be accessed with the domain-scoped credentials, are skipped with
+// a warning. Clients of projects, which are no longer part of the domain, are
+// removed.
+func DiscoverDomainProjects(ctx context.Context, dc openstackclients.DomainClient) (DomainDiscoveryResult, error) {
+	var result DomainDiscoveryResult
+
+	items, err := ListDomainProjects(ctx, dc.ProviderClient)
+	if err != nil {
+		return result, fmt.Errorf("cannot list projects for credentials %s: %w", dc.NamedCredentials, err)
+	}
+	result.Projects = len(items)
+
+	prefix := dc.NamedCredentials + "/"
+	known := make(map[string]struct{}, len(items))
+	for _, project := range items {
+		scope := openstackclients.ClientScope{
+			NamedCredentials: prefix + project.Name,
+			Project:          project.Name,
+			Domain:           dc.Domain,
+			Region:           dc.Region,
+		}
+		known[scope.NamedCredentials] = struct{}{}
+
+		if isRegistered(dc.Services, scope) {
+			continue
+		}
+
+		providerClient, err := NewProjectProviderClient(ctx, dc.ProviderClient, project.ID)
+		if err != nil {
+			slog.Warn(
+				"skipping inaccessible OpenStack project",
+				"credentials", dc.NamedCredentials,
+				"domain", dc.Domain,
+				"project", project.Name,
+				"reason", err,
+			)
+			result.Skipped++
+
+			continue
+		}
+
+		for _, svc := range dc.Services {
+			serviceClient, err := svc.NewClient(providerClient, gophercloud.EndpointOpts{
+				Region: dc.Region,
+			})
+			if err != nil {
+				return result, fmt.Errorf("unable to create client for %s service with credentials %s: %w", svc.Name, scope.NamedCredentials, err)
+			}
+
+			// Optional services, which are not available in the
+			// cloud are skipped.
+			if serviceClient == nil {
+				continue
+			}
+
+			client := openstackclients.Client[*gophercloud.ServiceClient]{
+				ClientScope: scope,
+				Client:      serviceClient,
+			}
+			svc.Clientset.Overwrite(scope, client)
+		}
+		result.Added++
+	}
+
+	// Remove the clients of projects, which are no longer part of the
+	// domain.
+	removed := make(map[string]struct{})
+	for _, svc := range dc.Services {
+		stale := make([]openstackclients.ClientScope, 0)
+		_ = svc.Clientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+			if !strings.HasPrefix(scope.NamedCredentials, prefix) {
+				return nil
+			}
+			if _, ok := known[scope.NamedCredentials]; !ok {
+				stale = append(stale, scope)
+			}
+
+			return nil
+		})
+
+		for _, scope := range stale {
+			svc.Clientset.Unregister(scope)
+			removed[scope.NamedCredentials] = struct{}{}
+		}
+	}
+	result.Removed = len(removed)
+
+	return result, nil
+}
+
+// isRegistered returns true, if clients for the given scope are registered
+// for any of the given services.
+func isRegistered(services []openstackclients.Service, scope openstackclients.ClientScope) bool {
+	for _, svc := range services {
+		if svc.Clientset.Exists(scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RefreshDomainProjects discovers the projects of all registered domain-scoped
+// clients via [DiscoverDomainProjects], so that projects added to a domain are
+// picked up without restarting the worker. The projects of each domain-scoped
+// client are discovered at most once per [DefaultDomainDiscoveryInterval].
+func RefreshDomainProjects(ctx context.Context) error {
+	errs := make([]error, 0)
+	_ = openstackclients.DomainClientset.Range(func(name string, dc openstackclients.DomainClient) error {
+		if err := refreshDomain(ctx, dc); err != nil {
+			errs = append(errs, err)
+		}
+
+		return nil
+	})
+
+	return errors.Join(errs...)
+}
+
+// RefreshScopeProjects discovers the projects of the domain-scoped client, from
+// which clients for the given scope are derived, e.g. when no client for the
+// scope has been found. It returns false, if the scope is not derived from any
+// of the registered domain-scoped clients.
+//
+// The projects of the domain-scoped client are discovered at most once per
+// [DefaultDomainDiscoveryInterval].
+func RefreshScopeProjects(ctx context.Context, scope openstackclients.ClientScope) (bool, error) {
+	var (
+		domainClient openstackclients.DomainClient
+		found        bool
+	)
+	_ = openstackclients.DomainClientset.Range(func(name string, dc openstackclients.DomainClient) error {
+		if strings.HasPrefix(scope.NamedCredentials, name+"/") && scope.Domain == dc.Domain && scope.Region == dc.Region {
+			domainClient = dc
+			found = true
+		}
+
+		return nil
+	})
+
+	if !found {
+		return false, nil
+	}
+
+	return true, refreshDomain(ctx, domainClient)
+}
+
+// refreshDomain discovers the projects of the given domain-scoped client, unless
+// they have been discovered within the last [DefaultDomainDiscoveryInterval].
+func refreshDomain(ctx context.Context, dc openstackclients.DomainClient) error {
+	name := dc.NamedCredentials
+	domainDiscovery.Lock()
+	lastRun := domainDiscovery.lastRun[name]
+	if time.Since(lastRun) < DefaultDomainDiscoveryInterval {
+		domainDiscovery.Unlock()
+
+		return nil
+	}
+	domainDiscovery.lastRun[name] = time.Now()
+	domainDiscovery.Unlock()
+
+	result, err := DiscoverDomainProjects(ctx, dc)
+	if err != nil {
+		// Retry on the next refresh
+		domainDiscovery.Lock()
+		domainDiscovery.lastRun[name] = lastRun
+		domainDiscovery.Unlock()
+
+		return err
+	}
+
+	slog.Info(
+		"discovered OpenStack projects",
+		"credentials", name,
+		"domain", dc.Domain,
+		"projects", result.Projects,
+		"added", result.Added,
+		"skipped", result.Skipped,
+		"removed", result.Removed,
+	)
+
+	return nil
+}
@@ -0,0 +1,454 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+
+	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
+	"github.com/gardener/inventory/pkg/core/registry"
+	"github.com/gardener/inventory/pkg/openstack/utils"
+)
+
+const (
+	fakeDomainID   = "domain-id"
+	fakeDomainName = "domain"
+	fakeRegion     = "r1"
+)
+
+// fakeKeystone is a minimal Keystone v3 API, which issues domain-scoped tokens
+// via password authentication, and project-scoped tokens by rescoping a
+// domain-scoped token.
+type fakeKeystone struct {
+	sync.Mutex
+	server *httptest.Server
+
+	// projects maps project ids to project names
+	projects map[string]string
+
+	// denied specifies the ids of projects, which cannot be accessed
+	denied map[string]bool
+
+	passwordAuths int
+	rescopes      map[string]int
+}
+
+func newFakeKeystone(t *testing.T) *fakeKeystone {
+	t.Helper()
+
+	k := &fakeKeystone{
+		projects: make(map[string]string),
+		denied:   make(map[string]bool),
+		rescopes: make(map[string]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v3/auth/tokens", k.handleTokens)
+	mux.HandleFunc("GET /v3/projects", k.handleProjects)
+	k.server = httptest.NewServer(mux)
+	t.Cleanup(k.server.Close)
+
+	return k
+}
+
+func (k *fakeKeystone) setProjects(projects map[string]string) {
+	k.Lock()
+	defer k.Unlock()
+	k.projects = projects
+}
+
+func (k *fakeKeystone) counters() (int, map[string]int) {
+	k.Lock()
+	defer k.Unlock()
+	rescopes := make(map[string]int, len(k.rescopes))
+	for id, count := range k.rescopes {
+		rescopes[id] = count
+	}
+
+	return k.passwordAuths, rescopes
+}
+
+func (k *fakeKeystone) catalog() []map[string]any {
+	endpoint := func(url string) []map[string]any {
+		return []map[string]any{
+			{
+				"id":        "endpoint",
+				"interface": "public",
+				"region":    fakeRegion,
+				"region_id": fakeRegion,
+				"url":       url,
+			},
+		}
+	}
+
+	return []map[string]any{
+		{"type": "identity", "name": "keystone", "endpoints": endpoint(k.server.URL + "/v3/")},
+		{"type": "compute", "name": "nova", "endpoints": endpoint(k.server.URL + "/compute/v2.1/")},
+	}
+}
+
+func (k *fakeKeystone) handleTokens(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Auth struct {
+			Identity struct {
+				Methods []string `json:"methods"`
+			} `json:"identity"`
+			Scope struct {
+				Project *struct {
+					ID string `json:"id"`
+				} `json:"project"`
+			} `json:"scope"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Auth.Identity.Methods) != 1 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+
+		return
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	token := map[string]any{
+		"expires_at": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"catalog":    k.catalog(),
+	}
+
+	switch req.Auth.Identity.Methods[0] {
+	case "password":
+		k.passwordAuths++
+		token["domain"] = map[string]any{"id": fakeDomainID, "name": fakeDomainName}
+	case "token":
+		if req.Auth.Scope.Project == nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+
+			return
+		}
+		projectID := req.Auth.Scope.Project.ID
+		k.rescopes[projectID]++
+		if k.denied[projectID] {
+			http.Error(w, "forbidden", http.StatusForbidden)
+
+			return
+		}
+		token["project"] = map[string]any{
+			"id":     projectID,
+			"name":   k.projects[projectID],
+			"domain": map[string]any{"id": fakeDomainID, "name": fakeDomainName},
+		}
+	default:
+		http.Error(w, "bad request", http.StatusBadRequest)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Subject-Token", fmt.Sprintf("token-%d", k.passwordAuths+len(k.rescopes)))
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]any{"token": token})
+}
+
+func (k *fakeKeystone) handleProjects(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("domain_id") != fakeDomainID {
+		http.Error(w, "unexpected domain", http.StatusBadRequest)
+
+		return
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	items := make([]map[string]any, 0, len(k.projects))
+	for id, name := range k.projects {
+		items = append(items, map[string]any{
+			"id":        id,
+			"name":      name,
+			"domain_id": fakeDomainID,
+			"enabled":   true,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"projects": items,
+		"links":    map[string]any{"next": nil},
+	})
+}
+
+func (k *fakeKeystone) newDomainClient(t *testing.T, name string, clientset *registry.Registry[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]]) openstackclients.DomainClient {
+	t.Helper()
+
+	opts := gophercloud.AuthOptions{
+		IdentityEndpoint: k.server.URL + "/v3/",
+		Username:         "user",
+		Password:         "password",
+		DomainName:       fakeDomainName,
+		Scope: &gophercloud.AuthScope{
+			DomainName: fakeDomainName,
+		},
+	}
+	providerClient, err := openstack.AuthenticatedClient(t.Context(), opts)
+	if err != nil {
+		t.Fatalf("unable to authenticate: %s", err)
+	}
+
+	return openstackclients.DomainClient{
+		NamedCredentials: name,
+		Domain:           fakeDomainName,
+		Region:           fakeRegion,
+		ProviderClient:   providerClient,
+		Services: []openstackclients.Service{
+			{
+				Name:      "compute",
+				Clientset: clientset,
+				NewClient: openstack.NewComputeV2,
+			},
+		},
+	}
+}
+
+func registeredScopes(clientset *registry.Registry[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]]) map[string]string {
+	scopes := make(map[string]string)
+	_ = clientset.Range(func(scope openstackclients.ClientScope, _ openstackclients.Client[*gophercloud.ServiceClient]) error {
+		scopes[scope.NamedCredentials] = scope.Project
+
+		return nil
+	})
+
+	return scopes
+}
+
+func TestDiscoverDomainProjects(t *testing.T) {
+	keystone := newFakeKeystone(t)
+	keystone.setProjects(map[string]string{
+		"p1": "one",
+		"p2": "two",
+		"p3": "denied",
+	})
+	keystone.denied["p3"] = true
+
+	clientset := registry.New[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]]()
+	dc := keystone.newDomainClient(t, "creds", clientset)
+
+	testCases := []struct {
+		desc         string
+		projects     map[string]string
+		wantResult   utils.DomainDiscoveryResult
+		wantScopes   map[string]string
+		wantRescopes map[string]int
+	}{
+		{
+			desc: "initial discovery",
+			wantResult: utils.DomainDiscoveryResult{
+				Projects: 3,
+				Added:    2,
+				Skipped:  1,
+			},
+			wantScopes: map[string]string{
+				"creds/one": "one",
+				"creds/two": "two",
+			},
+			wantRescopes: map[string]int{"p1": 1, "p2": 1, "p3": 1},
+		},
+		{
+			desc: "known projects are not rescoped again",
+			wantResult: utils.DomainDiscoveryResult{
+				Projects: 3,
+				Skipped:  1,
+			},
+			wantScopes: map[string]string{
+				"creds/one": "one",
+				"creds/two": "two",
+			},
+			wantRescopes: map[string]int{"p1": 1, "p2": 1, "p3": 2},
+		},
+		{
+			desc: "added and removed projects",
+			projects: map[string]string{
+				"p1": "one",
+				"p4": "four",
+			},
+			wantResult: utils.DomainDiscoveryResult{
+				Projects: 2,
+				Added:    1,
+				Removed:  1,
+			},
+			wantScopes: map[string]string{
+				"creds/one":  "one",
+				"creds/four": "four",
+			},
+			wantRescopes: map[string]int{"p1": 1, "p2": 1, "p3": 2, "p4": 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.projects != nil {
+				keystone.setProjects(tc.projects)
+			}
+
+			result, err := utils.DiscoverDomainProjects(t.Context(), dc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if result != tc.wantResult {
+				t.Fatalf("want result %+v, got %+v", tc.wantResult, result)
+			}
+
+			scopes := registeredScopes(clientset)
+			if len(scopes) != len(tc.wantScopes) {
+				t.Fatalf("want scopes %v, got %v", tc.wantScopes, scopes)
+			}
+			for name, project := range tc.wantScopes {
+				if scopes[name] != project {
+					t.Fatalf("want scopes %v, got %v", tc.wantScopes, scopes)
+				}
+			}
+
+			passwordAuths, rescopes := keystone.counters()
+			if passwordAuths != 1 {
+				t.Fatalf("want a single password authentication, got %d", passwordAuths)
+			}
+			for id, want := range tc.wantRescopes {
+				if rescopes[id] != want {
+					t.Fatalf("want %d rescopes of project %s, got %d", want, id, rescopes[id])
+				}
+			}
+		})
+	}
+}
+
+func TestListDomainProjectsNotDomainScoped(t *testing.T) {
+	keystone := newFakeKeystone(t)
+	keystone.setProjects(map[string]string{"p1": "one"})
+
+	clientset := registry.New[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]]()
+	dc := keystone.newDomainClient(t, "creds", clientset)
+
+	projectClient, err := utils.NewProjectProviderClient(t.Context(), dc.ProviderClient, "p1")
+	if err != nil {
+		t.Fatalf("unable to rescope token: %s", err)
+	}
+
+	_, err = utils.ListDomainProjects(t.Context(), projectClient)
+	if !errors.Is(err, utils.ErrNotDomainScoped) {
+		t.Fatalf("want error %v, got %v", utils.ErrNotDomainScoped, err)
+	}
+}
+
+func TestTokenProjectID(t *testing.T) {
+	keystone := newFakeKeystone(t)
+	keystone.setProjects(map[string]string{"p1": "one"})
+
+	clientset := registry.New[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]]()
+	dc := keystone.newDomainClient(t, "creds", clientset)
+
+	_, err := utils.TokenProjectID(dc.ProviderClient)
+	if !errors.Is(err, utils.ErrNotProjectScoped) {
+		t.Fatalf("want error %v, got %v", utils.ErrNotProjectScoped, err)
+	}
+
+	projectClient, err := utils.NewProjectProviderClient(t.Context(), dc.ProviderClient, "p1")
+	if err != nil {
+		t.Fatalf("unable to rescope token: %s", err)
+	}
+
+	projectID, err := utils.TokenProjectID(projectClient)
+	if err != nil {
+		t.Fatalf("unable to get project of token: %s", err)
+	}
+
+	if projectID != "p1" {
+		t.Fatalf("want project id p1, got %s", projectID)
+	}
+}
+
+func TestRefreshDomainProjects(t *testing.T) {
+	keystone := newFakeKeystone(t)
+	keystone.setProjects(map[string]string{"p1": "one"})
+
+	clientset := registry.New[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]]()
+	dc := keystone.newDomainClient(t, "refresh-creds", clientset)
+	openstackclients.DomainClientset.Overwrite(dc.NamedCredentials, dc)
+	t.Cleanup(func() {
+		openstackclients.DomainClientset.Unregister(dc.NamedCredentials)
+	})
+
+	if err := utils.RefreshDomainProjects(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The second refresh happens within the discovery interval, so new
+	// projects are not discovered yet.
+	keystone.setProjects(map[string]string{"p1": "one", "p2": "two"})
+	if err := utils.RefreshDomainProjects(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	scopes := registeredScopes(clientset)
+	want := map[string]string{"refresh-creds/one": "one"}
+	if len(scopes) != len(want) || scopes["refresh-creds/one"] != "one" {
+		t.Fatalf("want scopes %v, got %v", want, scopes)
+	}
+}
+
+func TestRefreshScopeProjects(t *testing.T) {
+	keystone := newFakeKeystone(t)
+	keystone.setProjects(map[string]string{"p1": "one"})
+
+	clientset := registry.New[openstackclients.ClientScope, openstackclients.Client[*gophercloud.ServiceClient]]()
+	dc := keystone.newDomainClient(t, "scope-creds", clientset)
+	openstackclients.DomainClientset.Overwrite(dc.NamedCredentials, dc)
+	t.Cleanup(func() {
+		openstackclients.DomainClientset.Unregister(dc.NamedCredentials)
+	})
+
+	// Scopes, which are not derived from domain-scoped credentials, do not
+	// trigger a discovery.
+	other := openstackclients.ClientScope{
+		NamedCredentials: "other-creds",
+		Project:          "one",
+		Domain:           fakeDomainName,
+		Region:           fakeRegion,
+	}
+	derived, err := utils.RefreshScopeProjects(t.Context(), other)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if derived {
+		t.Fatal("want scope not to be derived from domain-scoped credentials")
+	}
+	if scopes := registeredScopes(clientset); len(scopes) != 0 {
+		t.Fatalf("want no scopes, got %v", scopes)
+	}
+
+	scope := openstackclients.ClientScope{
+		NamedCredentials: "scope-creds/one",
+		Project:          "one",
+		Domain:           fakeDomainName,
+		Region:           fakeRegion,
+	}
+	derived, err = utils.RefreshScopeProjects(t.Context(), scope)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !derived {
+		t.Fatal("want scope to be derived from domain-scoped credentials")
+	}
+	if !clientset.Exists(scope) {
+		t.Fatalf("want client for scope %v, got %v", scope, registeredScopes(clientset))
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	tokens3 "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
+	"github.com/hibiken/asynq"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// federatedExchangeTimeout specifies the timeout for exchanging an OIDC token
+// for a Keystone token.
+const federatedExchangeTimeout = 30 * time.Second
+
+// ErrFederatedAuth is an error, which is returned when an OIDC token could not
+// be exchanged for a Keystone token.
+var ErrFederatedAuth = errors.New("federated authentication failed")
+
+// FederatedAuthError wraps [ErrFederatedAuth] along with the HTTP status code
+// returned by Keystone during the token exchange.
+type FederatedAuthError struct {
+	// StatusCode is the HTTP status code returned by Keystone.
+	StatusCode int
+}
+
+// Error implements the [error] interface.
+func (e *FederatedAuthError) Error() string {
+	return fmt.Sprintf("%s: keystone responded with status %d", ErrFederatedAuth, e.StatusCode)
+}
+
+// Unwrap returns [ErrFederatedAuth].
+func (e *FederatedAuthError) Unwrap() error {
+	return ErrFederatedAuth
+}
+
+// FederatedAuthOptions provides the options for authenticating against
+// Keystone using federated authentication, where an OIDC token issued by an
+// external identity provider is exchanged for an unscoped Keystone token,
+// which is then used to request a scoped token.
+//
+// FederatedAuthOptions implements the [tokens3.AuthOptionsBuilder] interface.
+// The OIDC token is read and exchanged each time a token is requested, so
+// that re-authentication after the Keystone token has expired picks up a
+// rotated OIDC token as well.
+type FederatedAuthOptions struct {
+	// IdentityEndpoint specifies the Keystone endpoint.
+	IdentityEndpoint string
+
+	// IdentityProvider specifies the name of the identity provider
+	// registered in Keystone.
+	IdentityProvider string
+
+	// Protocol specifies the name of the federation protocol registered in
+	// Keystone for the identity provider, e.g. `openid'.
+	Protocol string
+
+	// TokenFile specifies the path to the file containing the OIDC token.
+	TokenFile string
+
+	// Scope specifies the scope of the requested token.
+	Scope gophercloud.AuthScope
+
+	// federationURL is the URL at which OIDC tokens are exchanged.
+	federationURL string
+
+	// httpClient is the client used for exchanging tokens.
+	httpClient *http.Client
+}
+
+var _ tokens3.AuthOptionsBuilder = &FederatedAuthOptions{}
+
+// NewFederatedProviderClient creates a new [gophercloud.ProviderClient], which
+// is authenticated using federated authentication. Re-authentication is
+// enabled, so that requests failing with 401 Unauthorized mid-collection are
+// retried with a fresh token.
+func NewFederatedProviderClient(ctx context.Context, opts FederatedAuthOptions) (*gophercloud.ProviderClient, error) {
+	client, err := openstack.NewClient(opts.IdentityEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.federationURL = fmt.Sprintf(
+		"%sv3/OS-FEDERATION/identity_providers/%s/protocols/%s/auth",
+		client.IdentityBase,
+		url.PathEscape(opts.IdentityProvider),
+		url.PathEscape(opts.Protocol),
+	)
+	opts.httpClient = &client.HTTPClient
+
+	if err := openstack.AuthenticateV3(ctx, client, &opts, gophercloud.EndpointOpts{}); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// exchangeToken exchanges the OIDC token for an unscoped Keystone token.
+func (opts *FederatedAuthOptions) exchangeToken() (string, error) {
+	rawToken, err := os.ReadFile(filepath.Clean(opts.TokenFile))
+	if err != nil {
+		return "", fmt.Errorf("unable to read oidc token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(rawToken))
+	if token == "" {
+		return "", fmt.Errorf("empty oidc token in %s", opts.TokenFile)
+	}
+
+	// The builder interface does not provide a context, so we use a
+	// dedicated timeout for the exchange.
+	ctx, cancel := context.WithTimeout(context.Background(), federatedExchangeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, opts.federationURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := opts.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", &FederatedAuthError{StatusCode: resp.StatusCode}
+	}
+
+	unscoped := resp.Header.Get("X-Subject-Token")
+	if unscoped == "" {
+		return "", fmt.Errorf("%w: no token returned by keystone", ErrFederatedAuth)
+	}
+
+	return unscoped, nil
+}
+
+// ToTokenV3CreateMap implements the [tokens3.AuthOptionsBuilder] interface.
+func (opts *FederatedAuthOptions) ToTokenV3CreateMap(scope map[string]any) (map[string]any, error) {
+	unscoped, err := opts.exchangeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	authOpts := gophercloud.AuthOptions{TokenID: unscoped}
+
+	return authOpts.ToTokenV3CreateMap(scope)
+}
+
+// ToTokenV3HeadersMap implements the [tokens3.AuthOptionsBuilder] interface.
+func (opts *FederatedAuthOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
+	return nil, nil
+}
+
+// ToTokenV3ScopeMap implements the [tokens3.AuthOptionsBuilder] interface.
+func (opts *FederatedAuthOptions) ToTokenV3ScopeMap() (map[string]any, error) {
+	scope := opts.Scope
+	authOpts := gophercloud.AuthOptions{Scope: &scope}
+
+	return authOpts.ToTokenV3ScopeMap()
+}
+
+// CanReauth implements the [tokens3.AuthOptionsBuilder] interface.
+func (opts *FederatedAuthOptions) CanReauth() bool {
+	return true
+}
+
+// IsPermanentAuthError returns true, if the given error is caused by an
+// authentication failure, which is not resolved by retrying, e.g. Keystone
+// rejected the OIDC token, or the request failed with 401 Unauthorized even
+// after re-authenticating.
+//
+// Transient failures during re-authentication, e.g. network errors or server
+// errors returned by Keystone, are not considered permanent.
+func IsPermanentAuthError(err error) bool {
+	var reauthErr *gophercloud.ErrUnableToReauthenticate
+	if errors.As(err, &reauthErr) {
+		var fedErr *FederatedAuthError
+		if errors.As(reauthErr.ErrReauth, &fedErr) {
+			return fedErr.StatusCode >= 400 && fedErr.StatusCode < 500
+		}
+
+		return gophercloud.ResponseCodeIs(reauthErr.ErrReauth, http.StatusUnauthorized)
+	}
+
+	var afterReauthErr *gophercloud.ErrErrorAfterReauthentication
+	if errors.As(err, &afterReauthErr) {
+		return gophercloud.ResponseCodeIs(afterReauthErr.ErrOriginal, http.StatusUnauthorized)
+	}
+
+	return false
+}
+
+// NewAuthErrorMiddleware returns a new [asynq.MiddlewareFunc], which prevents
+// tasks failing with a permanent OpenStack authentication error from being
+// retried.
+func NewAuthErrorMiddleware() asynq.MiddlewareFunc {
+	middleware := func(handler asynq.Handler) asynq.Handler {
+		mw := func(ctx context.Context, task *asynq.Task) error {
+			err := handler.ProcessTask(ctx, task)
+			if err == nil || !IsPermanentAuthError(err) {
+				return err
+			}
+
+			logger := asynqutils.GetLogger(ctx)
+			logger.Error("openstack authentication failed, will not retry", "reason", err)
+
+			return asynqutils.SkipRetry(err)
+		}
+
+		return asynq.HandlerFunc(mw)
+	}
+
+	return asynq.MiddlewareFunc(middleware)
+}
@@ -0,0 +1,448 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+
+	"github.com/gardener/inventory/pkg/openstack/utils"
+)
+
+const (
+	fakeIdentityProvider = "idp"
+	fakeProjectID        = "p1"
+	fakeProjectName      = "one"
+)
+
+// fakeFederatedKeystone is a minimal Keystone v3 API, which exchanges OIDC
+// tokens for unscoped tokens, and issues project-scoped tokens by rescoping an
+// unscoped token. It also serves a compute endpoint, which rejects revoked
+// tokens with 401 Unauthorized.
+type fakeFederatedKeystone struct {
+	sync.Mutex
+	server *httptest.Server
+
+	// oidcToken is the OIDC token accepted by the federation endpoint
+	oidcToken string
+
+	// exchangeStatus specifies the status code returned by the federation
+	// endpoint, if non-zero
+	exchangeStatus int
+
+	// unauthorized specifies whether the compute endpoint rejects all
+	// tokens
+	unauthorized bool
+
+	// unscoped contains the issued unscoped tokens
+	unscoped map[string]bool
+
+	// scoped contains the issued project-scoped tokens, which are not
+	// revoked
+	scoped map[string]bool
+
+	// scopes contains the project names requested when rescoping
+	scopes []string
+
+	exchanges int
+}
+
+func newFakeFederatedKeystone(t *testing.T, oidcToken string) *fakeFederatedKeystone {
+	t.Helper()
+
+	k := &fakeFederatedKeystone{
+		oidcToken: oidcToken,
+		unscoped:  make(map[string]bool),
+		scoped:    make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		fmt.Sprintf("POST /v3/OS-FEDERATION/identity_providers/%s/protocols/openid/auth", fakeIdentityProvider),
+		k.handleFederation,
+	)
+	mux.HandleFunc("POST /v3/auth/tokens", k.handleTokens)
+	mux.HandleFunc("GET /compute/servers", k.handleServers)
+	k.server = httptest.NewServer(mux)
+	t.Cleanup(k.server.Close)
+
+	return k
+}
+
+func (k *fakeFederatedKeystone) handleFederation(w http.ResponseWriter, r *http.Request) {
+	k.Lock()
+	defer k.Unlock()
+
+	k.exchanges++
+	if k.exchangeStatus != 0 {
+		http.Error(w, http.StatusText(k.exchangeStatus), k.exchangeStatus)
+
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+k.oidcToken {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	token := fmt.Sprintf("unscoped-%d", k.exchanges)
+	k.unscoped[token] = true
+	w.Header().Set("X-Subject-Token", token)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (k *fakeFederatedKeystone) handleTokens(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Auth struct {
+			Identity struct {
+				Methods []string `json:"methods"`
+				Token   struct {
+					ID string `json:"id"`
+				} `json:"token"`
+			} `json:"identity"`
+			Scope struct {
+				Project *struct {
+					Name string `json:"name"`
+				} `json:"project"`
+			} `json:"scope"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Auth.Identity.Methods) != 1 || req.Auth.Identity.Methods[0] != "token" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+
+		return
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	if !k.unscoped[req.Auth.Identity.Token.ID] {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	if req.Auth.Scope.Project == nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+
+		return
+	}
+	k.scopes = append(k.scopes, req.Auth.Scope.Project.Name)
+
+	endpoints := []map[string]any{
+		{
+			"id":        "endpoint",
+			"interface": "public",
+			"region":    fakeRegion,
+			"region_id": fakeRegion,
+			"url":       k.server.URL + "/compute/",
+		},
+	}
+	token := map[string]any{
+		"expires_at": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"catalog": []map[string]any{
+			{"type": "compute", "name": "nova", "endpoints": endpoints},
+		},
+		"project": map[string]any{
+			"id":     fakeProjectID,
+			"name":   req.Auth.Scope.Project.Name,
+			"domain": map[string]any{"id": fakeDomainID, "name": fakeDomainName},
+		},
+	}
+
+	id := fmt.Sprintf("scoped-%d", len(k.scopes))
+	k.scoped[id] = true
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Subject-Token", id)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]any{"token": token})
+}
+
+func (k *fakeFederatedKeystone) handleServers(w http.ResponseWriter, r *http.Request) {
+	k.Lock()
+	defer k.Unlock()
+
+	if k.unauthorized || !k.scoped[r.Header.Get("X-Auth-Token")] {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"servers": []any{}})
+}
+
+// revoke revokes all issued tokens and rotates the accepted OIDC token.
+func (k *fakeFederatedKeystone) revoke(oidcToken string) {
+	k.Lock()
+	defer k.Unlock()
+
+	k.oidcToken = oidcToken
+	k.unscoped = make(map[string]bool)
+	k.scoped = make(map[string]bool)
+}
+
+func (k *fakeFederatedKeystone) setExchangeStatus(status int) {
+	k.Lock()
+	defer k.Unlock()
+	k.exchangeStatus = status
+}
+
+func (k *fakeFederatedKeystone) setUnauthorized(unauthorized bool) {
+	k.Lock()
+	defer k.Unlock()
+	k.unauthorized = unauthorized
+}
+
+func (k *fakeFederatedKeystone) counters() (int, []string) {
+	k.Lock()
+	defer k.Unlock()
+
+	return k.exchanges, append([]string(nil), k.scopes...)
+}
+
+func (k *fakeFederatedKeystone) authOptions(tokenFile string) utils.FederatedAuthOptions {
+	return utils.FederatedAuthOptions{
+		IdentityEndpoint: k.server.URL + "/v3/",
+		IdentityProvider: fakeIdentityProvider,
+		Protocol:         "openid",
+		TokenFile:        tokenFile,
+		Scope: gophercloud.AuthScope{
+			ProjectName: fakeProjectName,
+			DomainName:  fakeDomainName,
+		},
+	}
+}
+
+func writeTokenFile(t *testing.T, path, token string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		t.Fatalf("unable to write token file: %s", err)
+	}
+}
+
+func listServers(t *testing.T, providerClient *gophercloud.ProviderClient) error {
+	t.Helper()
+
+	client, err := openstack.NewComputeV2(providerClient, gophercloud.EndpointOpts{Region: fakeRegion})
+	if err != nil {
+		t.Fatalf("unable to create compute client: %s", err)
+	}
+
+	var out map[string]any
+	_, err = client.Get(t.Context(), client.ServiceURL("servers"), &out, nil)
+
+	return err
+}
+
+func TestNewFederatedProviderClient(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	writeTokenFile(t, tokenFile, "oidc-1")
+	keystone := newFakeFederatedKeystone(t, "oidc-1")
+
+	providerClient, err := utils.NewFederatedProviderClient(t.Context(), keystone.authOptions(tokenFile))
+	if err != nil {
+		t.Fatalf("unable to authenticate: %s", err)
+	}
+
+	exchanges, scopes := keystone.counters()
+	if exchanges != 1 {
+		t.Fatalf("want a single token exchange, got %d", exchanges)
+	}
+	if len(scopes) != 1 || scopes[0] != fakeProjectName {
+		t.Fatalf("want a single rescope to project %s, got %v", fakeProjectName, scopes)
+	}
+
+	projectID, err := utils.TokenProjectID(providerClient)
+	if err != nil {
+		t.Fatalf("unable to get project of token: %s", err)
+	}
+	if projectID != fakeProjectID {
+		t.Fatalf("want project id %s, got %s", fakeProjectID, projectID)
+	}
+
+	if err := listServers(t, providerClient); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestNewFederatedProviderClientRejected(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	writeTokenFile(t, tokenFile, "invalid")
+	keystone := newFakeFederatedKeystone(t, "oidc-1")
+
+	_, err := utils.NewFederatedProviderClient(t.Context(), keystone.authOptions(tokenFile))
+	if !errors.Is(err, utils.ErrFederatedAuth) {
+		t.Fatalf("want error %v, got %v", utils.ErrFederatedAuth, err)
+	}
+
+	var fedErr *utils.FederatedAuthError
+	if !errors.As(err, &fedErr) || fedErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("want federated auth error with status %d, got %v", http.StatusUnauthorized, err)
+	}
+}
+
+func TestFederatedReauthentication(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		exchangeStatus int
+		unauthorized   bool
+		wantErr        bool
+		wantPermanent  bool
+		wantExchanges  int
+	}{
+		{
+			desc:          "reauth with rotated oidc token",
+			wantExchanges: 2,
+		},
+		{
+			desc:           "oidc token rejected during reauth",
+			exchangeStatus: http.StatusUnauthorized,
+			wantErr:        true,
+			wantPermanent:  true,
+			wantExchanges:  2,
+		},
+		{
+			desc:           "keystone unavailable during reauth",
+			exchangeStatus: http.StatusServiceUnavailable,
+			wantErr:        true,
+			wantPermanent:  false,
+			wantExchanges:  2,
+		},
+		{
+			desc:          "unauthorized after reauth",
+			unauthorized:  true,
+			wantErr:       true,
+			wantPermanent: true,
+			wantExchanges: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tokenFile := filepath.Join(t.TempDir(), "token")
+			writeTokenFile(t, tokenFile, "oidc-1")
+			keystone := newFakeFederatedKeystone(t, "oidc-1")
+
+			providerClient, err := utils.NewFederatedProviderClient(t.Context(), keystone.authOptions(tokenFile))
+			if err != nil {
+				t.Fatalf("unable to authenticate: %s", err)
+			}
+
+			// Revoke the issued tokens and rotate the OIDC token, so
+			// that the next request is rejected and re-authentication
+			// has to pick up the new OIDC token.
+			keystone.revoke("oidc-2")
+			writeTokenFile(t, tokenFile, "oidc-2")
+			keystone.setExchangeStatus(tc.exchangeStatus)
+			keystone.setUnauthorized(tc.unauthorized)
+
+			err = listServers(t, providerClient)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("want error %t, got %v", tc.wantErr, err)
+			}
+
+			if got := utils.IsPermanentAuthError(err); got != tc.wantPermanent {
+				t.Fatalf("want permanent auth error %t, got %t (%v)", tc.wantPermanent, got, err)
+			}
+
+			exchanges, _ := keystone.counters()
+			if exchanges != tc.wantExchanges {
+				t.Fatalf("want %d token exchanges, got %d", tc.wantExchanges, exchanges)
+			}
+		})
+	}
+}
+
+func TestIsPermanentAuthError(t *testing.T) {
+	unauthorized := gophercloud.ErrUnexpectedResponseCode{Actual: http.StatusUnauthorized}
+	serverError := gophercloud.ErrUnexpectedResponseCode{Actual: http.StatusInternalServerError}
+
+	testCases := []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{
+			desc: "nil error",
+			err:  nil,
+			want: false,
+		},
+		{
+			desc: "plain error",
+			err:  errors.New("boom"),
+			want: false,
+		},
+		{
+			desc: "unauthorized without reauth",
+			err:  unauthorized,
+			want: false,
+		},
+		{
+			desc: "oidc token rejected during reauth",
+			err: &gophercloud.ErrUnableToReauthenticate{
+				ErrOriginal: unauthorized,
+				ErrReauth:   &utils.FederatedAuthError{StatusCode: http.StatusUnauthorized},
+			},
+			want: true,
+		},
+		{
+			desc: "keystone unavailable during reauth",
+			err: &gophercloud.ErrUnableToReauthenticate{
+				ErrOriginal: unauthorized,
+				ErrReauth:   &utils.FederatedAuthError{StatusCode: http.StatusServiceUnavailable},
+			},
+			want: false,
+		},
+		{
+			desc: "rescope rejected during reauth",
+			err: &gophercloud.ErrUnableToReauthenticate{
+				ErrOriginal: unauthorized,
+				ErrReauth:   unauthorized,
+			},
+			want: true,
+		},
+		{
+			desc: "network error during reauth",
+			err: &gophercloud.ErrUnableToReauthenticate{
+				ErrOriginal: unauthorized,
+				ErrReauth:   errors.New("connection refused"),
+			},
+			want: false,
+		},
+		{
+			desc: "unauthorized after reauth",
+			err:  fmt.Errorf("list servers: %w", &gophercloud.ErrErrorAfterReauthentication{ErrOriginal: unauthorized}),
+			want: true,
+		},
+		{
+			desc: "server error after reauth",
+			err:  &gophercloud.ErrErrorAfterReauthentication{ErrOriginal: serverError},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := utils.IsPermanentAuthError(tc.err); got != tc.want {
+				t.Fatalf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
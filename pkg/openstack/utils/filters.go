@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"slices"
+)
+
+// MatchFilters reports whether a resource with the given metadata and tags
+// matches all of the filters.
+//
+// A filter matches, if the metadata contains the key with the given value, or
+// if the resource has a tag in the form of `key=value`. A filter with an empty
+// value matches, if the metadata contains the key, or if the resource has a
+// tag equal to the key. Empty filters match all resources.
+func MatchFilters(filters map[string]string, metadata map[string]string, tags []string) bool {
+	for key, value := range filters {
+		if v, ok := metadata[key]; ok && (value == "" || v == value) {
+			continue
+		}
+
+		tag := key
+		if value != "" {
+			tag = key + "=" + value
+		}
+
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gardener/inventory/pkg/openstack/utils"
+)
+
+func TestMatchFilters(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		filters  map[string]string
+		metadata map[string]string
+		tags     []string
+		wanted   bool
+	}{
+		{
+			desc:    "no filters match all",
+			filters: nil,
+			tags:    nil,
+			wanted:  true,
+		},
+		{
+			desc:     "match by metadata",
+			filters:  map[string]string{"gardener.cloud/managed": "true"},
+			metadata: map[string]string{"gardener.cloud/managed": "true"},
+			wanted:   true,
+		},
+		{
+			desc:     "metadata with different value",
+			filters:  map[string]string{"gardener.cloud/managed": "true"},
+			metadata: map[string]string{"gardener.cloud/managed": "false"},
+			wanted:   false,
+		},
+		{
+			desc:    "match by key=value tag",
+			filters: map[string]string{"gardener.cloud/managed": "true"},
+			tags:    []string{"foo", "gardener.cloud/managed=true"},
+			wanted:  true,
+		},
+		{
+			desc:    "empty value matches by key",
+			filters: map[string]string{"kubernetes.io-cluster-foo": ""},
+			tags:    []string{"kubernetes.io-cluster-foo"},
+			wanted:  true,
+		},
+		{
+			desc:     "all filters must match",
+			filters:  map[string]string{"owner": "team-a", "env": "prod"},
+			metadata: map[string]string{"owner": "team-a"},
+			tags:     []string{"env=dev"},
+			wanted:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := utils.MatchFilters(tc.filters, tc.metadata, tc.tags)
+			if got != tc.wanted {
+				t.Fatalf("want %t got %t", tc.wanted, got)
+			}
+		})
+	}
+}
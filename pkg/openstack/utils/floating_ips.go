@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
+	"github.com/gardener/inventory/pkg/openstack/models"
+)
+
+// ErrInvalidIPAddress is an error, which is returned when an IP address cannot
+// be parsed.
+var ErrInvalidIPAddress = errors.New("invalid ip address")
+
+// AddressFamily returns the address family of the given IP address, which is
+// either [models.AddressFamilyIPv4] or [models.AddressFamilyIPv6].
+func AddressFamily(ip net.IP) string {
+	if ip.To4() != nil {
+		return models.AddressFamilyIPv4
+	}
+
+	return models.AddressFamilyIPv6
+}
+
+// FloatingIPsToModels converts the given OpenStack floating IPs of the given
+// domain and region to [models.FloatingIP] items.
+//
+// Floating IPs, which are not associated with a port have no fixed IP, and are
+// converted with a nil fixed IP, which is stored as NULL. Floating IPs with an
+// invalid floating IP address are skipped, while invalid fixed IP addresses are
+// treated as missing. In both cases an error wrapping [ErrInvalidIPAddress] is
+// returned along with the converted items, so that the invalid addresses can
+// be reported by the caller.
+func FloatingIPsToModels(ips []floatingips.FloatingIP, domain, region string) ([]models.FloatingIP, error) {
+	items := make([]models.FloatingIP, 0, len(ips))
+	var errs []error
+	for _, ip := range ips {
+		floatingIP := net.ParseIP(ip.FloatingIP)
+		if floatingIP == nil {
+			errs = append(errs, fmt.Errorf("%w: floating ip %q of %s", ErrInvalidIPAddress, ip.FloatingIP, ip.ID))
+
+			continue
+		}
+
+		var fixedIP net.IP
+		if ip.FixedIP != "" {
+			fixedIP = net.ParseIP(ip.FixedIP)
+			if fixedIP == nil {
+				errs = append(errs, fmt.Errorf("%w: fixed ip %q of %s", ErrInvalidIPAddress, ip.FixedIP, ip.ID))
+			}
+		}
+
+		item := models.FloatingIP{
+			FloatingIPID:      ip.ID,
+			ProjectID:         ip.TenantID,
+			Domain:            domain,
+			Region:            region,
+			PortID:            ip.PortID,
+			FixedIP:           fixedIP,
+			RouterID:          ip.RouterID,
+			FloatingIP:        floatingIP,
+			AddressFamily:     AddressFamily(floatingIP),
+			FloatingNetworkID: ip.FloatingNetworkID,
+			Description:       ip.Description,
+			TimeCreated:       ip.CreatedAt,
+			TimeUpdated:       ip.UpdatedAt,
+			Tags:              TagsToMap(ip.Tags),
+		}
+		items = append(items, item)
+	}
+
+	return items, errors.Join(errs...)
+}
+
+// FloatingIPChanges returns the [auxmodels.ChangeEvent] items for the tracked
+// fields of the given floating IP, which differ between the existing and the
+// collected item, i.e. reassignments of the port, router or fixed IP. A nil
+// fixed IP is reported as an empty value.
+func FloatingIPChanges(existing, collected models.FloatingIP, at time.Time) []auxmodels.ChangeEvent {
+	fields := []struct {
+		name     string
+		oldValue string
+		newValue string
+	}{
+		{"port_id", existing.PortID, collected.PortID},
+		{"router_id", existing.RouterID, collected.RouterID},
+		{"fixed_ip", ipString(existing.FixedIP), ipString(collected.FixedIP)},
+	}
+
+	events := make([]auxmodels.ChangeEvent, 0)
+	for _, field := range fields {
+		if field.oldValue == field.newValue {
+			continue
+		}
+
+		event := auxmodels.ChangeEvent{
+			ResourceID:   collected.FloatingIPID,
+			ResourceType: models.FloatingIPModelName,
+			Field:        field.name,
+			OldValue:     field.oldValue,
+			NewValue:     field.newValue,
+			ChangedAt:    at,
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// ipString returns the string representation of the given IP address, or an
+// empty string, if the address is nil.
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+
+	return ip.String()
+}
@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	"github.com/gardener/inventory/pkg/openstack/models"
+	"github.com/gardener/inventory/pkg/openstack/utils"
+	dbutils "github.com/gardener/inventory/pkg/utils/db"
+)
+
+func TestFloatingIPsToModels(t *testing.T) {
+	ips := []floatingips.FloatingIP{
+		{
+			ID:         "attached",
+			FloatingIP: "203.0.113.10",
+			FixedIP:    "10.0.0.10",
+			PortID:     "port-1",
+		},
+		{
+			ID:         "unattached",
+			FloatingIP: "203.0.113.11",
+		},
+		{
+			ID:         "attached-ipv6",
+			FloatingIP: "2001:db8::11",
+			FixedIP:    "fd00::11",
+			PortID:     "port-2",
+		},
+		{
+			ID:         "malformed-fixed-ip",
+			FloatingIP: "203.0.113.12",
+			FixedIP:    "10.0.0.300",
+			PortID:     "port-3",
+		},
+		{
+			ID:         "malformed-floating-ip",
+			FloatingIP: "not-an-ip",
+			FixedIP:    "10.0.0.13",
+		},
+	}
+
+	type wantItem struct {
+		fixedIP       net.IP
+		addressFamily string
+	}
+
+	wanted := map[string]wantItem{
+		"attached": {
+			fixedIP:       net.ParseIP("10.0.0.10"),
+			addressFamily: models.AddressFamilyIPv4,
+		},
+		"unattached": {
+			fixedIP:       nil,
+			addressFamily: models.AddressFamilyIPv4,
+		},
+		"attached-ipv6": {
+			fixedIP:       net.ParseIP("fd00::11"),
+			addressFamily: models.AddressFamilyIPv6,
+		},
+		"malformed-fixed-ip": {
+			fixedIP:       nil,
+			addressFamily: models.AddressFamilyIPv4,
+		},
+	}
+
+	items, err := utils.FloatingIPsToModels(ips, "domain", "region")
+	if !errors.Is(err, utils.ErrInvalidIPAddress) {
+		t.Fatalf("want error %v, got %v", utils.ErrInvalidIPAddress, err)
+	}
+
+	if len(items) != len(wanted) {
+		t.Fatalf("want %d items, got %d", len(wanted), len(items))
+	}
+
+	for _, item := range items {
+		want, ok := wanted[item.FloatingIPID]
+		if !ok {
+			t.Fatalf("unexpected item %s", item.FloatingIPID)
+		}
+
+		if !item.FixedIP.Equal(want.fixedIP) || (item.FixedIP == nil) != (want.fixedIP == nil) {
+			t.Fatalf("%s: want fixed ip %v, got %v", item.FloatingIPID, want.fixedIP, item.FixedIP)
+		}
+
+		if item.AddressFamily != want.addressFamily {
+			t.Fatalf("%s: want address family %s, got %s", item.FloatingIPID, want.addressFamily, item.AddressFamily)
+		}
+
+		if item.Domain != "domain" || item.Region != "region" {
+			t.Fatalf("%s: unexpected domain and region %s/%s", item.FloatingIPID, item.Domain, item.Region)
+		}
+	}
+}
+
+func TestFloatingIPsToModelsValid(t *testing.T) {
+	ips := []floatingips.FloatingIP{
+		{ID: "unattached", FloatingIP: "203.0.113.11"},
+	}
+
+	items, err := utils.FloatingIPsToModels(ips, "domain", "region")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(items) != 1 || items[0].FixedIP != nil {
+		t.Fatalf("want a single item with nil fixed ip, got %v", items)
+	}
+}
+
+// TestFloatingIPsToModelsPersisted verifies that the converted floating IPs
+// are stored as expected, i.e. unattached floating IPs are stored without a
+// fixed IP, and the ones with a malformed floating IP address are skipped. The
+// test is skipped, unless the test database is configured via
+// [dbtest.EnvDSN].
+func TestFloatingIPsToModelsPersisted(t *testing.T) {
+	db := dbtest.New(t)
+	ctx := t.Context()
+
+	ips := []floatingips.FloatingIP{
+		{
+			ID:         "attached",
+			TenantID:   "project-1",
+			FloatingIP: "203.0.113.10",
+			FixedIP:    "10.0.0.10",
+			PortID:     "port-1",
+		},
+		{
+			ID:         "unattached",
+			TenantID:   "project-1",
+			FloatingIP: "203.0.113.11",
+		},
+		{
+			ID:         "malformed-floating-ip",
+			TenantID:   "project-1",
+			FloatingIP: "not-an-ip",
+			FixedIP:    "10.0.0.13",
+		},
+	}
+
+	items, err := utils.FloatingIPsToModels(ips, "domain", "region")
+	if !errors.Is(err, utils.ErrInvalidIPAddress) {
+		t.Fatalf("want error %v, got %v", utils.ErrInvalidIPAddress, err)
+	}
+
+	_, err = dbutils.BulkInsert(ctx, db, items, func(q *bun.InsertQuery) *bun.InsertQuery {
+		return dbutils.OnConflict(q, "(floating_ip_id, project_id)", dbutils.ConflictStrategyUpdate, items)
+	}, 0)
+	if err != nil {
+		t.Fatalf("unable to insert floating ips: %s", err)
+	}
+
+	count, err := db.NewSelect().Model((*models.FloatingIP)(nil)).Count(ctx)
+	if err != nil {
+		t.Fatalf("unable to count floating ips: %s", err)
+	}
+	if count != 2 {
+		t.Fatalf("want 2 floating ips, got %d", count)
+	}
+
+	var attached models.FloatingIP
+	if err := db.NewSelect().Model(&attached).Where("floating_ip_id = ?", "attached").Scan(ctx); err != nil {
+		t.Fatalf("unable to select attached floating ip: %s", err)
+	}
+	if !attached.FixedIP.Equal(net.ParseIP("10.0.0.10")) || attached.PortID != "port-1" {
+		t.Fatalf("want fixed ip 10.0.0.10 of port-1, got %v of %s", attached.FixedIP, attached.PortID)
+	}
+
+	exists, err := db.NewSelect().
+		Model((*models.FloatingIP)(nil)).
+		Where("floating_ip_id = ?", "unattached").
+		Where("fixed_ip IS NULL").
+		Exists(ctx)
+	if err != nil {
+		t.Fatalf("unable to select unattached floating ip: %s", err)
+	}
+	if !exists {
+		t.Fatal("want unattached floating ip without fixed ip")
+	}
+
+	exists, err = db.NewSelect().
+		Model((*models.FloatingIP)(nil)).
+		Where("floating_ip_id = ?", "malformed-floating-ip").
+		Exists(ctx)
+	if err != nil {
+		t.Fatalf("unable to select malformed floating ip: %s", err)
+	}
+	if exists {
+		t.Fatal("want malformed floating ip to be skipped")
+	}
+}
+
+func TestFloatingIPChanges(t *testing.T) {
+	existing := models.FloatingIP{
+		FloatingIPID: "fip-1",
+		PortID:       "port-1",
+		RouterID:     "router-1",
+		FixedIP:      net.ParseIP("10.0.0.10"),
+		Description:  "foo",
+	}
+
+	testCases := []struct {
+		desc      string
+		collected models.FloatingIP
+		want      map[string][2]string
+	}{
+		{
+			desc:      "unchanged",
+			collected: existing,
+			want:      map[string][2]string{},
+		},
+		{
+			desc: "untracked field changed",
+			collected: models.FloatingIP{
+				FloatingIPID: "fip-1",
+				PortID:       "port-1",
+				RouterID:     "router-1",
+				FixedIP:      net.ParseIP("10.0.0.10"),
+				Description:  "bar",
+			},
+			want: map[string][2]string{},
+		},
+		{
+			desc: "reassigned",
+			collected: models.FloatingIP{
+				FloatingIPID: "fip-1",
+				PortID:       "port-2",
+				RouterID:     "router-1",
+				FixedIP:      net.ParseIP("10.0.0.20"),
+			},
+			want: map[string][2]string{
+				"port_id":  {"port-1", "port-2"},
+				"fixed_ip": {"10.0.0.10", "10.0.0.20"},
+			},
+		},
+		{
+			desc: "detached",
+			collected: models.FloatingIP{
+				FloatingIPID: "fip-1",
+			},
+			want: map[string][2]string{
+				"port_id":   {"port-1", ""},
+				"router_id": {"router-1", ""},
+				"fixed_ip":  {"10.0.0.10", ""},
+			},
+		},
+	}
+
+	at := time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC)
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			events := utils.FloatingIPChanges(existing, tc.collected, at)
+			if len(events) != len(tc.want) {
+				t.Fatalf("want %d events, got %d", len(tc.want), len(events))
+			}
+
+			for _, event := range events {
+				want, ok := tc.want[event.Field]
+				if !ok {
+					t.Fatalf("unexpected change of field %s", event.Field)
+				}
+
+				if event.OldValue != want[0] || event.NewValue != want[1] {
+					t.Fatalf("%s: want %q -> %q, got %q -> %q", event.Field, want[0], want[1], event.OldValue, event.NewValue)
+				}
+
+				if event.ResourceID != "fip-1" || event.ResourceType != models.FloatingIPModelName || !event.ChangedAt.Equal(at) {
+					t.Fatalf("%s: unexpected event %v", event.Field, event)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gardener/inventory/pkg/tracing"
+	commonutils "github.com/gardener/inventory/pkg/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+	"github.com/gardener/inventory/pkg/utils/paginate"
+)
+
+// PageFetcher returns a [paginate.FetchFunc], which fetches a single page of
+// an OpenStack collection at a time. The token of a page is its URL.
+//
+// The first page is fetched using the given pager, while subsequent pages are
+// fetched from the URL of the next page, which is advertised by the previous
+// page. The createPage func must be the one used by the pager, and extract
+// returns the items of a page.
+//
+// The raw items of the fetched pages are sampled via [SamplePage]. Fetching a
+// page is retried on transient errors, e.g. throttling or 5xx responses, using
+// [commonutils.DefaultRetryOptions]. Each attempt to fetch a page is traced
+// with a separate span.
+func PageFetcher[T any](
+	client *gophercloud.ServiceClient,
+	pager pagination.Pager,
+	createPage func(r pagination.PageResult) pagination.Page,
+	extract func(page pagination.Page) ([]T, error),
+) paginate.FetchFunc[T] {
+	fetch := func(ctx context.Context, token string) ([]T, string, error) {
+		p := pager
+		if token != "" {
+			p = pagination.NewPager(client, token, createPage)
+		}
+
+		ctx, span := tracing.Start(
+			ctx,
+			"openstack.page",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.Bool("page.first", token == "")),
+		)
+
+		var items []T
+		var next string
+		err := p.EachPage(ctx, func(_ context.Context, page pagination.Page) (bool, error) {
+			var err error
+			items, err = extract(page)
+			if err != nil {
+				return false, err
+			}
+
+			SamplePage(ctx, page)
+
+			next, err = page.NextPageURL()
+			if err != nil {
+				return false, err
+			}
+
+			// Stop after the first page, the rest of the pages
+			// are fetched by the paginator.
+			return false, nil
+		})
+
+		span.SetAttributes(attribute.Int("page.items", len(items)))
+		tracing.End(span, err)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return items, next, nil
+	}
+
+	return paginate.RetryFetch(fetch, commonutils.DefaultRetryOptions)
+}
+
+// SamplePage captures the raw items of the given page via
+// [asynqutils.SampleRaw], if raw sampling is enabled for the task associated
+// with the given context.
+func SamplePage(ctx context.Context, page pagination.Page) {
+	if !asynqutils.IsSamplingRaw(ctx) {
+		return
+	}
+
+	for _, item := range rawPageItems(page.GetBody()) {
+		asynqutils.SampleRaw(ctx, item)
+	}
+}
+
+// rawPageItems returns the raw items from the given page body. The items of
+// an OpenStack collection are provided as a list under a resource-specific
+// key, e.g. `floatingips', next to an optional list of links, while the
+// Object Storage API provides the items as a top-level list.
+func rawPageItems(body any) []any {
+	var m map[string]any
+	switch v := body.(type) {
+	case []any:
+		return v
+	case map[string]any:
+		m = v
+	default:
+		return nil
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		if key == "links" || strings.HasSuffix(key, "_links") {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if items, ok := m[key].([]any); ok {
+			return items
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,10 @@ package utils
 import (
 	"context"
 	"errors"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	tokens3 "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
 
 	"github.com/gardener/inventory/pkg/clients/db"
 	openstackclients "github.com/gardener/inventory/pkg/clients/openstack"
@@ -17,6 +21,11 @@ import (
 // [models.Project] project by client scope doesn't find a match.
 var ErrNoProjectMatchingScope = errors.New("no project matching scope found")
 
+// ErrNotProjectScoped is an error, which is returned when a client, which is
+// expected to be project-scoped, has been authenticated with a token, which is
+// not scoped to a project.
+var ErrNotProjectScoped = errors.New("token is not scoped to a project")
+
 // IsValidDomainScope can be used to check the scope fields are set for usage
 // on the domain level.
 func IsValidDomainScope(scope openstackclients.ClientScope) error {
@@ -78,3 +87,36 @@ func MatchScopeToProject(scope openstackclients.ClientScope, projects []models.P
 
 	return models.Project{}, ErrNoProjectMatchingScope
 }
+
+// TagsToMap converts the given OpenStack tags to a map. OpenStack tags are
+// plain strings, so tags in the form of `key=value' are split into a key and
+// value, and any other tags are mapped to an empty value.
+func TagsToMap(tags []string) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, "=")
+		result[key] = value
+	}
+
+	return result
+}
+
+// TokenProjectID returns the id of the project, to which the token of the
+// given provider client is scoped.
+func TokenProjectID(providerClient *gophercloud.ProviderClient) (string, error) {
+	authResult, ok := providerClient.GetAuthResult().(tokens3.CreateResult)
+	if !ok {
+		return "", errors.New("unexpected authentication result")
+	}
+
+	project, err := authResult.ExtractProject()
+	if err != nil {
+		return "", err
+	}
+
+	if project == nil {
+		return "", ErrNotProjectScoped
+	}
+
+	return project.ID, nil
+}
@@ -7,9 +7,12 @@ package asynq
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/goccy/go-yaml"
 	"github.com/hibiken/asynq"
@@ -95,15 +98,67 @@ func GetQueueName(ctx context.Context) string {
 	return config.DefaultQueueName
 }
 
-// NewRedisClientOptFromConfig returns an [asynq.RedisClientOpt] from the
-// provided [config.RedisConfig] configuration.
-func NewRedisClientOptFromConfig(conf config.RedisConfig) asynq.RedisClientOpt {
-	// TODO: Handle authentication, TLS, etc.
-	opts := asynq.RedisClientOpt{
-		Addr: conf.Endpoint,
+// ErrUnknownRedisMode is an error, which is returned when an unknown Redis
+// mode is configured.
+var ErrUnknownRedisMode = errors.New("unknown redis mode")
+
+// ErrInvalidRedisConfig is an error, which is returned when the Redis settings
+// do not match the configured Redis mode.
+var ErrInvalidRedisConfig = errors.New("invalid redis config")
+
+// ValidateRedisConfig validates that only the settings of the configured Redis
+// mode are populated, and that the settings required by the mode are present.
+func ValidateRedisConfig(conf config.RedisConfig) error {
+	hasStandalone := conf.Endpoint != ""
+	hasFailover := conf.Failover.MasterName != "" || len(conf.Failover.SentinelAddrs) > 0
+	hasCluster := len(conf.Cluster.Addrs) > 0
+
+	switch conf.Mode {
+	case "", config.RedisModeStandalone:
+		if hasFailover || hasCluster {
+			return fmt.Errorf("%w: only endpoint may be set in %s mode", ErrInvalidRedisConfig, config.RedisModeStandalone)
+		}
+	case config.RedisModeFailover:
+		if hasStandalone || hasCluster {
+			return fmt.Errorf("%w: only failover settings may be set in %s mode", ErrInvalidRedisConfig, conf.Mode)
+		}
+		if conf.Failover.MasterName == "" || len(conf.Failover.SentinelAddrs) == 0 {
+			return fmt.Errorf("%w: master name and sentinel addresses are required in %s mode", ErrInvalidRedisConfig, conf.Mode)
+		}
+	case config.RedisModeCluster:
+		if hasStandalone || hasFailover {
+			return fmt.Errorf("%w: only cluster settings may be set in %s mode", ErrInvalidRedisConfig, conf.Mode)
+		}
+		if !hasCluster {
+			return fmt.Errorf("%w: cluster addresses are required in %s mode", ErrInvalidRedisConfig, conf.Mode)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownRedisMode, conf.Mode)
 	}
 
-	return opts
+	return nil
+}
+
+// NewRedisConnOptFromConfig returns an [asynq.RedisConnOpt] for the mode
+// configured in the provided [config.RedisConfig] configuration. The
+// configuration is expected to be validated via [ValidateRedisConfig].
+func NewRedisConnOptFromConfig(conf config.RedisConfig) asynq.RedisConnOpt {
+	// TODO: Handle authentication, TLS, etc.
+	switch conf.Mode {
+	case config.RedisModeFailover:
+		return asynq.RedisFailoverClientOpt{
+			MasterName:    conf.Failover.MasterName,
+			SentinelAddrs: conf.Failover.SentinelAddrs,
+		}
+	case config.RedisModeCluster:
+		return asynq.RedisClusterClientOpt{
+			Addrs: conf.Cluster.Addrs,
+		}
+	default:
+		return asynq.RedisClientOpt{
+			Addr: conf.Endpoint,
+		}
+	}
 }
 
 // TaskConstructor is a function which creates and returns a new [asynq.Task].
@@ -114,7 +169,7 @@ func Enqueue(ctx context.Context, items []TaskConstructor, opts ...asynq.Option)
 	logger := GetLogger(ctx)
 	for _, fn := range items {
 		task := fn()
-		info, err := asynqclient.Client.Enqueue(task, opts...)
+		info, err := EnqueueChild(ctx, task, opts...)
 		if err != nil {
 			logger.Error(
 				"failed to enqueue task",
@@ -135,3 +190,164 @@ func Enqueue(ctx context.Context, items []TaskConstructor, opts ...asynq.Option)
 
 	return nil
 }
+
+// DefaultEnqueueBatchConcurrency specifies the default number of tasks, which
+// are enqueued concurrently by [EnqueueBatch].
+const DefaultEnqueueBatchConcurrency = 16
+
+// EnqueueBatch enqueues the tasks produced by the given task constructors,
+// similar to [Enqueue]. Up to [DefaultEnqueueBatchConcurrency] tasks are
+// enqueued concurrently, in order to reduce the time spent in Redis
+// round-trips when enqueueing a large number of tasks.
+//
+// Unlike [Enqueue], EnqueueBatch does not stop at the first failure. All tasks
+// are attempted, and the errors of the failed ones are joined via
+// [errors.Join] and returned.
+//
+// Each enqueued task is logged at debug level, while a summary of the batch is
+// logged at info level. Failed tasks are logged at error level along with
+// their payload, which identifies e.g. the account or project of the task.
+func EnqueueBatch(ctx context.Context, items []TaskConstructor, opts ...asynq.Option) error {
+	logger := GetLogger(ctx)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, DefaultEnqueueBatchConcurrency)
+	for _, fn := range items {
+		task := fn()
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			info, err := EnqueueChild(ctx, task, opts...)
+			if err != nil {
+				logger.Error(
+					"failed to enqueue task",
+					"type", task.Type(),
+					"payload", string(task.Payload()),
+					"reason", err,
+				)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", task.Type(), err))
+				mu.Unlock()
+
+				return
+			}
+
+			logger.Debug(
+				"enqueued task",
+				"type", task.Type(),
+				"id", info.ID,
+				"queue", info.Queue,
+			)
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		logger.Error(
+			"failed to enqueue tasks",
+			"total", len(items),
+			"failed", len(errs),
+		)
+
+		return errors.Join(errs...)
+	}
+
+	logger.Info("enqueued tasks", "count", len(items))
+
+	return nil
+}
+
+// EnqueueChild enqueues the given task on behalf of the task, which is being
+// processed with the given context, e.g. when fanning out collection tasks
+// for all known accounts or projects.
+//
+// The child task is enqueued with a task id, which is derived from the id of
+// the parent task, and the type and payload of the child task. When the parent
+// task is retried after a partial enqueue failure, child tasks which have
+// already been enqueued by a previous attempt, and are still pending, scheduled,
+// retrying or being processed, are not enqueued again. In such cases the info of
+// the existing child task is returned.
+//
+// Completed tasks are not retained, so their task ids are released once they
+// have been processed. Child tasks, which have already completed by the time
+// the parent task is retried, are enqueued again.
+//
+// If queue routing is enabled, the task is enqueued in the queue of the route
+// matching the task, instead of the queue of the parent task. See
+// [ConfigureQueueRouting] for more details.
+//
+// If sharding is enabled, the task is enqueued in the shard queue of the
+// account or project it is scoped to. See [ConfigureSharding] for more details.
+//
+// If the context is not associated with a task, the task is enqueued as is.
+//
+// Transient Redis connection errors are retried with backoff. If Redis remains
+// unavailable, the returned error wraps [ErrRedisUnavailable].
+func EnqueueChild(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	queue := queueFromOptions(opts)
+	if routed := RouteQueue(task.Type(), queue); routed != queue {
+		opts = append(opts, asynq.Queue(routed))
+	}
+
+	if key := ShardKey(task.Payload()); key != "" {
+		opts = append(opts, asynq.Queue(ShardQueue(queueFromOptions(opts), key)))
+	}
+
+	parentID := GetTaskID(ctx)
+	if parentID == "" {
+		return enqueue(ctx, task, opts...)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(task.Type()))
+	h.Write(task.Payload())
+	childID := fmt.Sprintf("%s:%x", parentID, h.Sum(nil)[:16])
+
+	opts = append(opts, asynq.TaskID(childID))
+	info, err := enqueue(ctx, task, opts...)
+	if !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return info, err
+	}
+
+	// The task has already been enqueued by a previous attempt of the
+	// parent task.
+	queue = queueFromOptions(opts)
+	if asynqclient.Inspector != nil {
+		existing, err := asynqclient.Inspector.GetTaskInfo(queue, childID)
+		if err == nil {
+			return existing, nil
+		}
+	}
+
+	existing := &asynq.TaskInfo{
+		ID:      childID,
+		Queue:   queue,
+		Type:    task.Type(),
+		Payload: task.Payload(),
+	}
+
+	return existing, nil
+}
+
+// queueFromOptions returns the queue specified by the given options, or
+// [config.DefaultQueueName] if no queue is specified. When multiple queue
+// options are specified, the last one takes precedence.
+func queueFromOptions(opts []asynq.Option) string {
+	queue := config.DefaultQueueName
+	for _, opt := range opts {
+		if opt.Type() == asynq.QueueOpt {
+			queue = opt.Value().(string) // nolint: forcetypeassert
+		}
+	}
+
+	return queue
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestValidateRedisConfig(t *testing.T) {
+	failover := config.RedisFailoverConfig{
+		MasterName:    "mymaster",
+		SentinelAddrs: []string{"sentinel-0:26379", "sentinel-1:26379"},
+	}
+	cluster := config.RedisClusterConfig{
+		Addrs: []string{"redis-0:6379", "redis-1:6379"},
+	}
+
+	testCases := []struct {
+		desc   string
+		conf   config.RedisConfig
+		wanted error
+	}{
+		{
+			desc:   "default mode",
+			conf:   config.RedisConfig{Endpoint: "localhost:6379"},
+			wanted: nil,
+		},
+		{
+			desc:   "standalone mode",
+			conf:   config.RedisConfig{Mode: config.RedisModeStandalone, Endpoint: "localhost:6379"},
+			wanted: nil,
+		},
+		{
+			desc:   "standalone mode with cluster settings",
+			conf:   config.RedisConfig{Endpoint: "localhost:6379", Cluster: cluster},
+			wanted: asynqutils.ErrInvalidRedisConfig,
+		},
+		{
+			desc:   "failover mode",
+			conf:   config.RedisConfig{Mode: config.RedisModeFailover, Failover: failover},
+			wanted: nil,
+		},
+		{
+			desc: "failover mode without master name",
+			conf: config.RedisConfig{
+				Mode:     config.RedisModeFailover,
+				Failover: config.RedisFailoverConfig{SentinelAddrs: failover.SentinelAddrs},
+			},
+			wanted: asynqutils.ErrInvalidRedisConfig,
+		},
+		{
+			desc:   "failover mode with endpoint",
+			conf:   config.RedisConfig{Mode: config.RedisModeFailover, Endpoint: "localhost:6379", Failover: failover},
+			wanted: asynqutils.ErrInvalidRedisConfig,
+		},
+		{
+			desc:   "cluster mode",
+			conf:   config.RedisConfig{Mode: config.RedisModeCluster, Cluster: cluster},
+			wanted: nil,
+		},
+		{
+			desc:   "cluster mode without addresses",
+			conf:   config.RedisConfig{Mode: config.RedisModeCluster},
+			wanted: asynqutils.ErrInvalidRedisConfig,
+		},
+		{
+			desc:   "cluster mode with failover settings",
+			conf:   config.RedisConfig{Mode: config.RedisModeCluster, Cluster: cluster, Failover: failover},
+			wanted: asynqutils.ErrInvalidRedisConfig,
+		},
+		{
+			desc:   "unknown mode",
+			conf:   config.RedisConfig{Mode: "unknown"},
+			wanted: asynqutils.ErrUnknownRedisMode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := asynqutils.ValidateRedisConfig(tc.conf)
+			if !errors.Is(err, tc.wanted) {
+				t.Fatalf("want error %v, got %v", tc.wanted, err)
+			}
+		})
+	}
+}
+
+func TestNewRedisConnOptFromConfig(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		conf   config.RedisConfig
+		wanted asynq.RedisConnOpt
+	}{
+		{
+			desc:   "default mode",
+			conf:   config.RedisConfig{Endpoint: "localhost:6379"},
+			wanted: asynq.RedisClientOpt{Addr: "localhost:6379"},
+		},
+		{
+			desc: "failover mode",
+			conf: config.RedisConfig{
+				Mode: config.RedisModeFailover,
+				Failover: config.RedisFailoverConfig{
+					MasterName:    "mymaster",
+					SentinelAddrs: []string{"sentinel-0:26379"},
+				},
+			},
+			wanted: asynq.RedisFailoverClientOpt{
+				MasterName:    "mymaster",
+				SentinelAddrs: []string{"sentinel-0:26379"},
+			},
+		},
+		{
+			desc: "cluster mode",
+			conf: config.RedisConfig{
+				Mode:    config.RedisModeCluster,
+				Cluster: config.RedisClusterConfig{Addrs: []string{"redis-0:6379"}},
+			},
+			wanted: asynq.RedisClusterClientOpt{Addrs: []string{"redis-0:6379"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := asynqutils.NewRedisConnOptFromConfig(tc.conf)
+			if !reflect.DeepEqual(got, tc.wanted) {
+				t.Fatalf("want %#v, got %#v", tc.wanted, got)
+			}
+		})
+	}
+}
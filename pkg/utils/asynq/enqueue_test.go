@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hibiken/asynq"
+
+	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestEnqueueBatch(t *testing.T) {
+	// Use a closed client, so that each enqueue fails without being
+	// retried.
+	client := asynq.NewClient(asynq.RedisClientOpt{Addr: "127.0.0.1:0"})
+	if err := client.Close(); err != nil {
+		t.Fatalf("failed to close client: %s", err)
+	}
+	defer func(c *asynq.Client) { asynqclient.Client = c }(asynqclient.Client)
+	asynqclient.Client = client
+
+	if err := asynqutils.EnqueueBatch(context.Background(), nil); err != nil {
+		t.Fatalf("want no error for empty batch, got %s", err)
+	}
+
+	taskFns := []asynqutils.TaskConstructor{
+		func() *asynq.Task { return asynq.NewTask("task:a", nil) },
+		func() *asynq.Task { return asynq.NewTask("task:b", nil) },
+		func() *asynq.Task { return asynq.NewTask("task:c", nil) },
+	}
+
+	err := asynqutils.EnqueueBatch(context.Background(), taskFns)
+	if err == nil {
+		t.Fatal("want error, got nil")
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("want joined errors, got %T", err)
+	}
+
+	if got := len(joined.Unwrap()); got != len(taskFns) {
+		t.Fatalf("want %d errors, got %d", len(taskFns), got)
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hibiken/asynq"
+)
+
+// CollectionError is an error, which is returned by task handlers, and carries
+// the details about the failed collection, e.g. the provider, account and
+// region. The Retriable field specifies whether the task may be retried, which
+// is used by [NewCollectionErrorMiddleware] in order to decide whether to skip
+// the retries of the task.
+type CollectionError struct {
+	// Provider specifies the name of the provider, e.g. aws, gcp, etc.
+	Provider string
+
+	// Account specifies the account, e.g. the AWS account id, GCP project
+	// or OpenStack project, for which the collection failed.
+	Account string
+
+	// Region specifies the region, for which the collection failed.
+	Region string
+
+	// Retriable specifies whether the task may be retried.
+	Retriable bool
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the [error] interface.
+func (e *CollectionError) Error() string {
+	msg := "collection failed"
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+
+	details := make([]string, 0)
+	if e.Account != "" {
+		details = append(details, "account "+e.Account)
+	}
+	if e.Region != "" {
+		details = append(details, "region "+e.Region)
+	}
+
+	if len(details) == 0 {
+		return msg
+	}
+
+	return msg + ": " + strings.Join(details, ", ")
+}
+
+// Unwrap returns the underlying error.
+func (e *CollectionError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetriable returns true, if the given error may be retried. Errors wrapping
+// [asynq.SkipRetry] and errors wrapping a [CollectionError], which is not
+// retriable are permanent. Any other error is considered retriable.
+func IsRetriable(err error) bool {
+	if errors.Is(err, asynq.SkipRetry) {
+		return false
+	}
+
+	var collectionErr *CollectionError
+	if errors.As(err, &collectionErr) {
+		return collectionErr.Retriable
+	}
+
+	return true
+}
+
+// NewCollectionErrorMiddleware returns a new [asynq.MiddlewareFunc], which
+// prevents tasks failing with a permanent [CollectionError] from being
+// retried.
+func NewCollectionErrorMiddleware() asynq.MiddlewareFunc {
+	middleware := func(handler asynq.Handler) asynq.Handler {
+		mw := func(ctx context.Context, task *asynq.Task) error {
+			err := handler.ProcessTask(ctx, task)
+			if err == nil || IsRetriable(err) || errors.Is(err, asynq.SkipRetry) {
+				return err
+			}
+
+			return SkipRetry(err)
+		}
+
+		return asynq.HandlerFunc(mw)
+	}
+
+	return asynq.MiddlewareFunc(middleware)
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hibiken/asynq"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestCollectionError(t *testing.T) {
+	errNotFound := &asynqutils.CollectionError{
+		Provider: "aws",
+		Err:      errors.New("client not found"),
+	}
+
+	testCases := []struct {
+		desc          string
+		err           error
+		wantMessage   string
+		wantRetriable bool
+	}{
+		{
+			desc:          "sentinel",
+			err:           errNotFound,
+			wantMessage:   "client not found",
+			wantRetriable: false,
+		},
+		{
+			desc: "wrapped sentinel with details",
+			err: &asynqutils.CollectionError{
+				Provider: "aws",
+				Account:  "123456789012",
+				Region:   "eu-west-1",
+				Err:      errNotFound,
+			},
+			wantMessage:   "client not found: account 123456789012, region eu-west-1",
+			wantRetriable: false,
+		},
+		{
+			desc: "retriable error",
+			err: fmt.Errorf("describe instances: %w", &asynqutils.CollectionError{
+				Provider:  "aws",
+				Region:    "eu-west-1",
+				Retriable: true,
+				Err:       errors.New("throttled"),
+			}),
+			wantMessage:   "describe instances: throttled: region eu-west-1",
+			wantRetriable: true,
+		},
+		{
+			desc:          "retriable error with skip retry",
+			err:           asynqutils.SkipRetry(&asynqutils.CollectionError{Retriable: true, Err: errors.New("boom")}),
+			wantMessage:   "boom (skip retry for the task)",
+			wantRetriable: false,
+		},
+		{
+			desc:          "generic error",
+			err:           errors.New("boom"),
+			wantMessage:   "boom",
+			wantRetriable: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.wantMessage {
+				t.Fatalf("want message %q, got %q", tc.wantMessage, got)
+			}
+			if got := asynqutils.IsRetriable(tc.err); got != tc.wantRetriable {
+				t.Fatalf("want retriable %t, got %t", tc.wantRetriable, got)
+			}
+		})
+	}
+
+	// The sentinel can still be matched, when wrapped with details.
+	err := &asynqutils.CollectionError{Account: "foo", Err: errNotFound}
+	if !errors.Is(err, errNotFound) {
+		t.Fatalf("want error matching %v, got %v", errNotFound, err)
+	}
+}
+
+func TestCollectionErrorMiddleware(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		err           error
+		wantSkipRetry bool
+	}{
+		{
+			desc:          "no error",
+			err:           nil,
+			wantSkipRetry: false,
+		},
+		{
+			desc:          "permanent collection error",
+			err:           &asynqutils.CollectionError{Err: errors.New("no region specified")},
+			wantSkipRetry: true,
+		},
+		{
+			desc:          "retriable collection error",
+			err:           &asynqutils.CollectionError{Retriable: true, Err: errors.New("throttled")},
+			wantSkipRetry: false,
+		},
+		{
+			desc:          "generic error",
+			err:           errors.New("boom"),
+			wantSkipRetry: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			handler := asynq.HandlerFunc(func(context.Context, *asynq.Task) error {
+				return tc.err
+			})
+			mw := asynqutils.NewCollectionErrorMiddleware()
+			err := mw(handler).ProcessTask(context.Background(), asynq.NewTask("test", nil))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("want error %v, got %v", tc.err, err)
+			}
+			if got := errors.Is(err, asynq.SkipRetry); got != tc.wantSkipRetry {
+				t.Fatalf("want skip retry %t, got %t", tc.wantSkipRetry, got)
+			}
+		})
+	}
+}
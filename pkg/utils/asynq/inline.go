@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import "sync"
+
+// inlineChildren contains the task types of parent collectors, which collect
+// and link their children within the same task.
+var inlineChildren = struct {
+	sync.RWMutex
+	tasks map[string]struct{}
+}{
+	tasks: make(map[string]struct{}),
+}
+
+// ConfigureInlineChildren configures the task types of parent collectors,
+// which collect and link their children within the same task, instead of
+// relying on separate collection and link tasks.
+func ConfigureInlineChildren(tasks []string) {
+	inlineChildren.Lock()
+	defer inlineChildren.Unlock()
+
+	clear(inlineChildren.tasks)
+	for _, task := range tasks {
+		inlineChildren.tasks[task] = struct{}{}
+	}
+}
+
+// CollectChildrenInline returns true, if the parent collector with the given
+// task type is configured to collect and link its children inline.
+func CollectChildrenInline(taskType string) bool {
+	inlineChildren.RLock()
+	defer inlineChildren.RUnlock()
+
+	_, ok := inlineChildren.tasks[taskType]
+
+	return ok
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"testing"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestCollectChildrenInline(t *testing.T) {
+	asynqutils.ConfigureInlineChildren([]string{"test:task:collect-parents"})
+	defer asynqutils.ConfigureInlineChildren(nil)
+
+	if !asynqutils.CollectChildrenInline("test:task:collect-parents") {
+		t.Fatal("want children collected inline for configured task")
+	}
+
+	if asynqutils.CollectChildrenInline("test:task:collect-others") {
+		t.Fatal("want children not collected inline for unconfigured task")
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hibiken/asynq"
+
+	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
+)
+
+// ErrNoInspector is returned when the asynq inspector has not been configured.
+var ErrNoInspector = errors.New("no asynq inspector configured")
+
+// inspectPageSize specifies the page size used when listing tasks.
+const inspectPageSize = 100
+
+// HasUnfinishedTasks returns true, if any of the tasks with the given ids, or
+// any of their descendants enqueued via [EnqueueChild], is still pending,
+// active, scheduled or waiting to be retried in any of the known queues.
+func HasUnfinishedTasks(ids ...string) (bool, error) {
+	if asynqclient.Inspector == nil {
+		return false, ErrNoInspector
+	}
+
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	queues, err := asynqclient.Inspector.Queues()
+	if err != nil {
+		return false, err
+	}
+
+	inspector := asynqclient.Inspector
+	listFns := []func(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error){
+		inspector.ListPendingTasks,
+		inspector.ListActiveTasks,
+		inspector.ListScheduledTasks,
+		inspector.ListRetryTasks,
+	}
+
+	for _, queue := range queues {
+		for _, listFn := range listFns {
+			for page := 1; ; page++ {
+				items, err := listFn(queue, asynq.Page(page), asynq.PageSize(inspectPageSize))
+				if err != nil {
+					return false, err
+				}
+
+				for _, item := range items {
+					if isTaskOrDescendant(item.ID, ids) {
+						return true, nil
+					}
+				}
+
+				if len(items) < inspectPageSize {
+					break
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// isTaskOrDescendant returns true, if the given task id matches any of the
+// given ids, or has been derived from any of them by [EnqueueChild].
+func isTaskOrDescendant(id string, ids []string) bool {
+	for _, item := range ids {
+		if id == item || strings.HasPrefix(id, item+":") {
+			return true
+		}
+	}
+
+	return false
+}
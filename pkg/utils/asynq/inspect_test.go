@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"errors"
+	"testing"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestHasUnfinishedTasksWithoutInspector(t *testing.T) {
+	_, err := asynqutils.HasUnfinishedTasks("task-id")
+	if !errors.Is(err, asynqutils.ErrNoInspector) {
+		t.Fatalf("want error %v, got %v", asynqutils.ErrNoInspector, err)
+	}
+}
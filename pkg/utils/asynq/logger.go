@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/hibiken/asynq"
+)
+
+// Logger is an [asynq.Logger], which logs the messages of the asynq servers
+// and schedulers via a [slog.Logger], so that they use the configured log
+// format and level.
+type Logger struct {
+	logger *slog.Logger
+}
+
+var _ asynq.Logger = &Logger{}
+
+// NewLogger returns a new [Logger], which logs via the given [slog.Logger].
+func NewLogger(logger *slog.Logger) *Logger {
+	return &Logger{logger: logger}
+}
+
+// Debug implements the [asynq.Logger] interface.
+func (l *Logger) Debug(args ...any) {
+	l.logger.Debug(fmt.Sprint(args...))
+}
+
+// Info implements the [asynq.Logger] interface.
+func (l *Logger) Info(args ...any) {
+	l.logger.Info(fmt.Sprint(args...))
+}
+
+// Warn implements the [asynq.Logger] interface.
+func (l *Logger) Warn(args ...any) {
+	l.logger.Warn(fmt.Sprint(args...))
+}
+
+// Error implements the [asynq.Logger] interface.
+func (l *Logger) Error(args ...any) {
+	l.logger.Error(fmt.Sprint(args...))
+}
+
+// Fatal implements the [asynq.Logger] interface. It logs the message at error
+// level and exits the process with status 1.
+func (l *Logger) Fatal(args ...any) {
+	l.logger.Error(fmt.Sprint(args...))
+	os.Exit(1)
+}
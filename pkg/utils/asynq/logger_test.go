@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger := asynqutils.NewLogger(slog.New(handler))
+
+	testCases := []struct {
+		desc      string
+		log       func(args ...any)
+		args      []any
+		wantLevel string
+		wantMsg   string
+	}{
+		{
+			desc:      "debug is filtered",
+			log:       logger.Debug,
+			args:      []any{"foo"},
+			wantLevel: "",
+		},
+		{
+			desc:      "info",
+			log:       logger.Info,
+			args:      []any{"starting ", "server"},
+			wantLevel: "INFO",
+			wantMsg:   "starting server",
+		},
+		{
+			desc:      "warn",
+			log:       logger.Warn,
+			args:      []any{"retry ", 3},
+			wantLevel: "WARN",
+			wantMsg:   "retry 3",
+		},
+		{
+			desc:      "error",
+			log:       logger.Error,
+			args:      []any{"failed"},
+			wantLevel: "ERROR",
+			wantMsg:   "failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			buf.Reset()
+			tc.log(tc.args...)
+			if tc.wantLevel == "" {
+				if buf.Len() != 0 {
+					t.Fatalf("want no log event, got %s", buf.String())
+				}
+
+				return
+			}
+
+			var event struct {
+				Level string `json:"level"`
+				Msg   string `json:"msg"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &event); err != nil {
+				t.Fatalf("cannot unmarshal log event: %s", err)
+			}
+
+			if event.Level != tc.wantLevel || event.Msg != tc.wantMsg {
+				t.Fatalf("want %s %q, got %s %q", tc.wantLevel, tc.wantMsg, event.Level, event.Msg)
+			}
+		})
+	}
+}
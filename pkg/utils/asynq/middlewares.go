@@ -14,6 +14,7 @@ import (
 	"github.com/hibiken/asynq"
 
 	"github.com/gardener/inventory/pkg/metrics"
+	"github.com/gardener/inventory/pkg/utils"
 )
 
 // NewLoggerMiddleware returns a new [asynq.MiddlewareFunc], which embeds a
@@ -55,11 +56,15 @@ func NewMeasuringMiddleware() asynq.MiddlewareFunc {
 	middleware := func(handler asynq.Handler) asynq.Handler {
 		mw := func(ctx context.Context, task *asynq.Task) error {
 			logger := GetLogger(ctx)
-			logger.Info("received task")
+			logger.Info("received task", "payload_size", len(task.Payload()))
 			start := time.Now()
 			err := handler.ProcessTask(ctx, task)
 			elapsed := time.Since(start)
-			logger.Info("task finished", "duration", elapsed)
+			if err != nil {
+				logger.Info("task finished", "duration", elapsed, "status", "failed", "reason", ErrorReason(err))
+			} else {
+				logger.Info("task finished", "duration", elapsed, "status", "ok")
+			}
 
 			return err
 		}
@@ -87,12 +92,21 @@ func NewMetricsMiddleware() asynq.MiddlewareFunc {
 				// OK
 				metrics.TaskSuccessfulTotal.WithLabelValues(taskName, queueName).Inc()
 				metrics.TaskDurationSeconds.WithLabelValues(taskName, queueName).Observe(elapsed.Seconds())
+				if IsCollectionTask(taskName) {
+					provider := TaskProvider(taskName)
+					if provider == "" {
+						provider = "aux"
+					}
+					metrics.CollectionDurationSeconds.WithLabelValues(taskName, provider).Observe(elapsed.Seconds())
+				}
 			case errors.Is(err, asynq.SkipRetry):
 				// Skipped
 				metrics.TaskSkippedTotal.WithLabelValues(taskName, queueName).Inc()
+				metrics.TaskErrorsTotal.WithLabelValues(taskName, ErrorReason(err)).Inc()
 			default:
 				// Failed
 				metrics.TaskFailedTotal.WithLabelValues(taskName, queueName).Inc()
+				metrics.TaskErrorsTotal.WithLabelValues(taskName, ErrorReason(err)).Inc()
 			}
 
 			return err
@@ -103,3 +117,29 @@ func NewMetricsMiddleware() asynq.MiddlewareFunc {
 
 	return asynq.MiddlewareFunc(middleware)
 }
+
+// Reasons of task errors reported by [ErrorReason].
+const (
+	ErrorReasonCanceled         = "canceled"
+	ErrorReasonDeadlineExceeded = "deadline_exceeded"
+	ErrorReasonTransient        = "transient"
+	ErrorReasonSkipRetry        = "skip_retry"
+	ErrorReasonOther            = "other"
+)
+
+// ErrorReason classifies the given task error into one of a small set of
+// reasons, which are suitable for use as a metric label.
+func ErrorReason(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorReasonCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorReasonDeadlineExceeded
+	case utils.IsTransientError(err):
+		return ErrorReasonTransient
+	case errors.Is(err, asynq.SkipRetry):
+		return ErrorReasonSkipRetry
+	default:
+		return ErrorReasonOther
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestErrorReason(t *testing.T) {
+	testCases := []struct {
+		desc string
+		err  error
+		want string
+	}{
+		{
+			desc: "cancelled context",
+			err:  fmt.Errorf("request failed: %w", context.Canceled),
+			want: asynqutils.ErrorReasonCanceled,
+		},
+		{
+			desc: "deadline exceeded",
+			err:  context.DeadlineExceeded,
+			want: asynqutils.ErrorReasonDeadlineExceeded,
+		},
+		{
+			desc: "connection reset",
+			err:  fmt.Errorf("read: %w", syscall.ECONNRESET),
+			want: asynqutils.ErrorReasonTransient,
+		},
+		{
+			desc: "skip retry",
+			err:  asynqutils.SkipRetry(errors.New("client not found")),
+			want: asynqutils.ErrorReasonSkipRetry,
+		},
+		{
+			desc: "generic error",
+			err:  errors.New("boom"),
+			want: asynqutils.ErrorReasonOther,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := asynqutils.ErrorReason(tc.err)
+			if got != tc.want {
+				t.Fatalf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/gardener/inventory/pkg/core/config"
+)
+
+// ForceTaskIDPrefix is the prefix of the ids of tasks, which bypass the minimum
+// collection interval. Since the ids of child tasks are derived from the id of
+// their parent, all tasks enqueued by a forced task are forced as well.
+const ForceTaskIDPrefix = "force-"
+
+// IntervalStore keeps track of the last successful collection of a scope by a
+// given task type.
+type IntervalStore interface {
+	// LastCollected returns when the scope was last collected by the
+	// given task type, or the zero [time.Time], if the scope has not been
+	// collected yet.
+	LastCollected(ctx context.Context, taskType, scope string) (time.Time, error)
+
+	// MarkCollected records that the scope was collected by the given
+	// task type at the given time.
+	MarkCollected(ctx context.Context, taskType, scope string, at time.Time) error
+}
+
+// ScopeKey returns the key, which identifies the scope collected by the given
+// task. Tasks of the same type with the same payload collect the same scope.
+// The trace context propagated via the payload is not part of the scope.
+func ScopeKey(task *asynq.Task) string {
+	sum := sha256.Sum256(StripTraceContext(task.Payload()))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// IsForced returns true, if the task associated with the given context
+// bypasses the minimum collection interval.
+func IsForced(ctx context.Context) bool {
+	return strings.HasPrefix(GetTaskID(ctx), ForceTaskIDPrefix)
+}
+
+// NewMinIntervalMiddleware returns a new [asynq.MiddlewareFunc], which skips
+// tasks of the types specified in the given config, if their scope has been
+// collected within the configured minimum interval.
+//
+// Successful collections are recorded in the given [IntervalStore]. Forced
+// tasks (see [IsForced]) are always processed. Failing to look up the last
+// collection does not prevent the task from being processed.
+func NewMinIntervalMiddleware(conf config.MinIntervalsConfig, store IntervalStore) asynq.MiddlewareFunc {
+	middleware := func(handler asynq.Handler) asynq.Handler {
+		mw := func(ctx context.Context, task *asynq.Task) error {
+			interval, ok := conf.Tasks[task.Type()]
+			if !ok || interval <= 0 {
+				return handler.ProcessTask(ctx, task)
+			}
+
+			logger := GetLogger(ctx)
+			scope := ScopeKey(task)
+			if !IsForced(ctx) {
+				lastCollected, err := store.LastCollected(ctx, task.Type(), scope)
+				switch {
+				case err != nil:
+					logger.Warn("failed to get last collection time", "reason", err)
+				case !lastCollected.IsZero() && time.Since(lastCollected) < interval:
+					logger.Info(
+						"skipping task within min collection interval",
+						"last_collected", lastCollected,
+						"min_interval", interval,
+					)
+
+					return nil
+				}
+			}
+
+			startedAt := time.Now()
+			if err := handler.ProcessTask(ctx, task); err != nil {
+				return err
+			}
+
+			if err := store.MarkCollected(ctx, task.Type(), scope, startedAt); err != nil {
+				logger.Warn("failed to record collection time", "reason", err)
+			}
+
+			return nil
+		}
+
+		return asynq.HandlerFunc(mw)
+	}
+
+	return asynq.MiddlewareFunc(middleware)
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// memoryIntervalStore is an in-memory [asynqutils.IntervalStore].
+type memoryIntervalStore map[string]time.Time
+
+func (s memoryIntervalStore) LastCollected(_ context.Context, taskType, scope string) (time.Time, error) {
+	return s[taskType+"/"+scope], nil
+}
+
+func (s memoryIntervalStore) MarkCollected(_ context.Context, taskType, scope string, at time.Time) error {
+	s[taskType+"/"+scope] = at
+
+	return nil
+}
+
+func TestMinIntervalMiddleware(t *testing.T) {
+	errTask := errors.New("task failed")
+	testCases := []struct {
+		desc     string
+		taskType string
+		payload  []string
+		failing  bool
+		want     int
+	}{
+		{
+			desc:     "same scope collected within interval",
+			taskType: "test:task:guarded",
+			payload:  []string{`{"region":"a"}`, `{"region":"a"}`},
+			want:     1,
+		},
+		{
+			desc:     "different scopes",
+			taskType: "test:task:guarded",
+			payload:  []string{`{"region":"a"}`, `{"region":"b"}`},
+			want:     2,
+		},
+		{
+			desc:     "failed collections are not recorded",
+			taskType: "test:task:guarded",
+			payload:  []string{`{"region":"a"}`, `{"region":"a"}`},
+			failing:  true,
+			want:     2,
+		},
+		{
+			desc:     "task not configured with interval",
+			taskType: "test:task:other",
+			payload:  []string{`{"region":"a"}`, `{"region":"a"}`},
+			want:     2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			conf := config.MinIntervalsConfig{
+				IsEnabled: true,
+				Tasks: map[string]time.Duration{
+					"test:task:guarded": time.Hour,
+				},
+			}
+
+			calls := 0
+			handler := func(_ context.Context, _ *asynq.Task) error {
+				calls++
+				if tc.failing {
+					return errTask
+				}
+
+				return nil
+			}
+
+			mw := asynqutils.NewMinIntervalMiddleware(conf, memoryIntervalStore{})
+			for _, payload := range tc.payload {
+				task := asynq.NewTask(tc.taskType, []byte(payload))
+				err := mw(asynq.HandlerFunc(handler)).ProcessTask(context.Background(), task)
+				if tc.failing && !errors.Is(err, errTask) {
+					t.Fatalf("want error %v, got %v", errTask, err)
+				}
+				if !tc.failing && err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if calls != tc.want {
+				t.Fatalf("want %d handler calls, got %d", tc.want, calls)
+			}
+		})
+	}
+}
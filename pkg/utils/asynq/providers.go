@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gardener/inventory/pkg/core/config"
+)
+
+// providerTaskPrefixes maps the names of the supported providers to the prefix
+// of the task types handling the provider. Tasks, which do not match any of the
+// prefixes, e.g. the auxiliary tasks, are not provider-specific.
+var providerTaskPrefixes = map[string]string{
+	"aws":       "aws:",
+	"azure":     "az:",
+	"gardener":  "g:",
+	"gcp":       "gcp:",
+	"openstack": "openstack:",
+}
+
+// Providers returns the sorted names of the supported providers.
+func Providers() []string {
+	names := make([]string, 0, len(providerTaskPrefixes))
+	for name := range providerTaskPrefixes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// ValidateProviders returns an error, if any of the given provider names is
+// not supported.
+func ValidateProviders(providers []string) error {
+	for _, name := range providers {
+		if _, ok := providerTaskPrefixes[name]; !ok {
+			return fmt.Errorf("unknown provider %q, supported providers are %s", name, strings.Join(Providers(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// TaskProvider returns the name of the provider handled by the given task
+// type, or an empty string, if the task is not provider-specific.
+func TaskProvider(taskType string) string {
+	for name, prefix := range providerTaskPrefixes {
+		if strings.HasPrefix(taskType, prefix) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// IsCollectionTask returns true, if the given task type collects resources,
+// e.g. `aws:task:collect-instances'.
+func IsCollectionTask(taskType string) bool {
+	return strings.Contains(taskType, ":task:collect-")
+}
+
+// IsProviderSelected returns true, if the given provider is part of the
+// selected providers. An empty selection selects all providers.
+func IsProviderSelected(provider string, selected []string) bool {
+	return len(selected) == 0 || slices.Contains(selected, provider)
+}
+
+// IsTaskSelected returns true, if the given task type should be handled by a
+// worker, which processes the selected providers only. Tasks, which are not
+// provider-specific, are always selected.
+func IsTaskSelected(taskType string, selected []string) bool {
+	provider := TaskProvider(taskType)
+
+	return provider == "" || IsProviderSelected(provider, selected)
+}
+
+// queueProvider returns the name of the provider, which is dedicated to the
+// given queue, or an empty string, if the queue is shared. A queue is dedicated
+// to a provider, if its base name is the name of the provider, or starts with
+// the name or task prefix of the provider, e.g. `openstack' or
+// `openstack:collect'.
+func queueProvider(queue string) string {
+	base := BaseQueueName(queue)
+	for name, prefix := range providerTaskPrefixes {
+		if base == name || strings.HasPrefix(base, name+":") || strings.HasPrefix(base, prefix) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// FilterProviderQueues returns the queues, which should be processed by a
+// worker handling the selected providers only. Queues dedicated to providers,
+// which are not selected, are dropped.
+//
+// Shared queues, e.g. the default queue, are kept, since they carry the tasks,
+// which are not provider-specific. See [ValidateProviderQueues] for the routing
+// required in order to keep the tasks of the other providers out of them.
+func FilterProviderQueues(queues map[string]int, selected []string) map[string]int {
+	if len(selected) == 0 {
+		return queues
+	}
+
+	result := make(map[string]int, len(queues))
+	for queue, priority := range queues {
+		provider := queueProvider(queue)
+		if provider != "" && !IsProviderSelected(provider, selected) {
+			continue
+		}
+		result[queue] = priority
+	}
+
+	return result
+}
+
+// ValidateProviderQueues returns an error, if a worker handling the selected
+// providers only would process tasks of the other providers, for which it has
+// no handlers. The given queues are the queues processed by the worker, as
+// returned by [FilterProviderQueues].
+//
+// Shared queues carry the tasks of all providers, unless the tasks are routed
+// elsewhere. A worker, which processes shared queues, therefore requires the
+// tasks of each provider, which is not selected, to be routed via the given
+// queue routing config to queues, which are not processed by the worker.
+func ValidateProviderQueues(queues map[string]int, selected []string, conf config.QueueRoutingConfig) error {
+	if len(selected) == 0 {
+		return nil
+	}
+
+	isProcessed := func(queue string) bool {
+		base := BaseQueueName(queue)
+		for q := range queues {
+			if BaseQueueName(q) == base {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	shared := make([]string, 0)
+	for queue := range queues {
+		if queueProvider(queue) == "" {
+			shared = append(shared, queue)
+		}
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+	slices.Sort(shared)
+
+	for _, provider := range Providers() {
+		if IsProviderSelected(provider, selected) {
+			continue
+		}
+
+		route, ok := conf.Providers[provider]
+		if !conf.IsEnabled || !ok || route.Queue == "" {
+			return fmt.Errorf(
+				"worker processes shared queues %s, but tasks of provider %q are not routed to a dedicated queue",
+				strings.Join(shared, ", "),
+				provider,
+			)
+		}
+
+		if isProcessed(route.Queue) {
+			return fmt.Errorf("tasks of provider %q are routed to queue %q, which is processed by the worker", provider, route.Queue)
+		}
+	}
+
+	for taskType, route := range conf.Tasks {
+		provider := TaskProvider(taskType)
+		if provider == "" || IsProviderSelected(provider, selected) || route.Queue == "" {
+			continue
+		}
+
+		if isProcessed(route.Queue) {
+			return fmt.Errorf("task %q is routed to queue %q, which is processed by the worker", taskType, route.Queue)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestIsTaskSelected(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		taskType string
+		selected []string
+		want     bool
+	}{
+		{
+			desc:     "all providers selected",
+			taskType: "openstack:task:collect-servers",
+			selected: nil,
+			want:     true,
+		},
+		{
+			desc:     "selected provider",
+			taskType: "aws:task:collect-instances",
+			selected: []string{"aws", "gcp"},
+			want:     true,
+		},
+		{
+			desc:     "provider not selected",
+			taskType: "openstack:task:collect-servers",
+			selected: []string{"aws", "gcp"},
+			want:     false,
+		},
+		{
+			desc:     "azure task prefix",
+			taskType: "az:task:collect-vms",
+			selected: []string{"azure"},
+			want:     true,
+		},
+		{
+			desc:     "auxiliary task",
+			taskType: "aux:task:housekeeper",
+			selected: []string{"aws"},
+			want:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := asynqutils.IsTaskSelected(tc.taskType, tc.selected)
+			if got != tc.want {
+				t.Fatalf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsCollectionTask(t *testing.T) {
+	if !asynqutils.IsCollectionTask("gcp:task:collect-instances") {
+		t.Fatal("want collection task")
+	}
+
+	if asynqutils.IsCollectionTask("gcp:task:link-all") {
+		t.Fatal("want non-collection task")
+	}
+}
+
+func TestFilterProviderQueues(t *testing.T) {
+	queues := map[string]int{
+		"default":           1,
+		"aws":               2,
+		"openstack":         3,
+		"openstack:shard-1": 3,
+		"gcp:collect":       4,
+	}
+
+	got := asynqutils.FilterProviderQueues(queues, []string{"aws", "gcp"})
+	want := map[string]int{
+		"default":     1,
+		"aws":         2,
+		"gcp:collect": 4,
+	}
+
+	if !maps.Equal(got, want) {
+		t.Fatalf("want queues %v, got %v", want, got)
+	}
+
+	if got := asynqutils.FilterProviderQueues(queues, nil); !maps.Equal(got, queues) {
+		t.Fatalf("want all queues, got %v", got)
+	}
+}
+
+func TestValidateProviders(t *testing.T) {
+	if err := asynqutils.ValidateProviders([]string{"aws", "openstack"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := asynqutils.ValidateProviders([]string{"aws", "alibaba"}); err == nil {
+		t.Fatal("want error for unknown provider")
+	}
+}
+
+func TestValidateProviderQueues(t *testing.T) {
+	dedicatedRoutes := config.QueueRoutingConfig{
+		IsEnabled: true,
+		Providers: map[string]config.QueueRouteConfig{
+			"aws":       {Queue: "aws"},
+			"azure":     {Queue: "azure"},
+			"gardener":  {Queue: "gardener"},
+			"gcp":       {Queue: "gcp"},
+			"openstack": {Queue: "openstack"},
+		},
+	}
+
+	testCases := []struct {
+		desc     string
+		queues   map[string]int
+		selected []string
+		conf     config.QueueRoutingConfig
+		wantErr  bool
+	}{
+		{
+			desc:    "all providers selected",
+			queues:  map[string]int{"default": 1},
+			wantErr: false,
+		},
+		{
+			desc:     "dedicated queues only",
+			queues:   map[string]int{"aws": 1, "aws:shard-1": 1},
+			selected: []string{"aws"},
+			wantErr:  false,
+		},
+		{
+			desc:     "shared queue without routing",
+			queues:   map[string]int{"default": 1, "aws": 1},
+			selected: []string{"aws"},
+			wantErr:  true,
+		},
+		{
+			desc:     "shared queue with routing disabled",
+			queues:   map[string]int{"default": 1, "aws": 1},
+			selected: []string{"aws"},
+			conf: config.QueueRoutingConfig{
+				Providers: dedicatedRoutes.Providers,
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "shared queue with all other providers routed",
+			queues:   map[string]int{"default": 1, "aws": 1},
+			selected: []string{"aws"},
+			conf:     dedicatedRoutes,
+			wantErr:  false,
+		},
+		{
+			desc:     "shared queue with a provider not routed",
+			queues:   map[string]int{"default": 1, "aws": 1},
+			selected: []string{"aws"},
+			conf: config.QueueRoutingConfig{
+				IsEnabled: true,
+				Providers: map[string]config.QueueRouteConfig{
+					"azure":     {Queue: "azure"},
+					"gardener":  {Queue: "gardener"},
+					"openstack": {Queue: "openstack"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "provider routed to a processed queue",
+			queues:   map[string]int{"default": 1, "aws": 1, "slow": 1},
+			selected: []string{"aws"},
+			conf: config.QueueRoutingConfig{
+				IsEnabled: true,
+				Providers: map[string]config.QueueRouteConfig{
+					"azure":     {Queue: "azure"},
+					"gardener":  {Queue: "gardener"},
+					"gcp":       {Queue: "slow"},
+					"openstack": {Queue: "openstack"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "task routed to a processed queue",
+			queues:   map[string]int{"default": 1, "aws": 1},
+			selected: []string{"aws"},
+			conf: config.QueueRoutingConfig{
+				IsEnabled: true,
+				Tasks: map[string]config.QueueRouteConfig{
+					"gcp:task:collect-projects": {Queue: "default"},
+				},
+				Providers: dedicatedRoutes.Providers,
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "task of a selected provider routed to a processed queue",
+			queues:   map[string]int{"default": 1, "aws": 1},
+			selected: []string{"aws"},
+			conf: config.QueueRoutingConfig{
+				IsEnabled: true,
+				Tasks: map[string]config.QueueRouteConfig{
+					"aws:task:collect-regions": {Queue: "default"},
+				},
+				Providers: dedicatedRoutes.Providers,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := asynqutils.ValidateProviderQueues(tc.queues, tc.selected, tc.conf)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("want error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/gardener/inventory/pkg/core/config"
+)
+
+// DefaultRawSampleSize is the default max number of items, for which the raw
+// provider response is sampled per task run.
+const DefaultRawSampleSize = 5
+
+// rawSamplerKey is the key used to store a [rawSampler] in a
+// [context.Context].
+type rawSamplerKey struct{}
+
+// rawSampler captures the raw provider responses for the first items
+// collected by a single task run.
+type rawSampler struct {
+	sync.Mutex
+
+	taskType  string
+	taskID    string
+	size      int
+	outputDir string
+	sampled   int
+}
+
+// NewRawSamplesMiddleware returns a new [asynq.MiddlewareFunc], which enables
+// sampling of raw provider responses via [SampleRaw] for the task types
+// specified in the given config.
+func NewRawSamplesMiddleware(conf config.RawSamplesConfig) asynq.MiddlewareFunc {
+	size := conf.Size
+	if size <= 0 {
+		size = DefaultRawSampleSize
+	}
+
+	middleware := func(handler asynq.Handler) asynq.Handler {
+		mw := func(ctx context.Context, task *asynq.Task) error {
+			if !slices.Contains(conf.Tasks, task.Type()) {
+				return handler.ProcessTask(ctx, task)
+			}
+
+			taskID, _ := asynq.GetTaskID(ctx)
+			sampler := &rawSampler{
+				taskType:  task.Type(),
+				taskID:    taskID,
+				size:      size,
+				outputDir: conf.OutputDir,
+			}
+			newCtx := context.WithValue(ctx, rawSamplerKey{}, sampler)
+
+			return handler.ProcessTask(newCtx, task)
+		}
+
+		return asynq.HandlerFunc(mw)
+	}
+
+	return asynq.MiddlewareFunc(middleware)
+}
+
+// IsSamplingRaw returns true, if raw provider responses are still being
+// sampled for the task associated with the given context. Callers may use it
+// in order to avoid the cost of preparing raw items, which won't be sampled.
+func IsSamplingRaw(ctx context.Context) bool {
+	sampler, ok := ctx.Value(rawSamplerKey{}).(*rawSampler)
+	if !ok {
+		return false
+	}
+
+	sampler.Lock()
+	defer sampler.Unlock()
+
+	return sampler.sampled < sampler.size
+}
+
+// SampleRaw captures the given raw provider response item as JSON, if
+// sampling is enabled for the task associated with the given context, and
+// the sample size for the current run has not been reached yet.
+//
+// The sample is either logged, or written to a file in the configured output
+// directory. Failing to capture a sample is logged, but otherwise ignored, so
+// that sampling never fails a collection.
+func SampleRaw(ctx context.Context, item any) {
+	sampler, ok := ctx.Value(rawSamplerKey{}).(*rawSampler)
+	if !ok {
+		return
+	}
+
+	sampler.Lock()
+	if sampler.sampled >= sampler.size {
+		sampler.Unlock()
+
+		return
+	}
+	sampler.sampled++
+	index := sampler.sampled
+	sampler.Unlock()
+
+	logger := GetLogger(ctx)
+	data, err := json.Marshal(item)
+	if err != nil {
+		logger.Warn("failed to marshal raw sample", "index", index, "reason", err)
+
+		return
+	}
+
+	if sampler.outputDir == "" {
+		logger.Info("raw response sample", "index", index, "raw", string(data))
+
+		return
+	}
+
+	name := RawSampleFileName(sampler.taskType, sampler.taskID, index)
+	path := filepath.Join(sampler.outputDir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		logger.Warn("failed to write raw sample", "index", index, "path", path, "reason", err)
+
+		return
+	}
+
+	logger.Info("raw response sample written", "index", index, "path", path)
+}
+
+// RawSampleFileName returns the name of the file, in which the raw sample with
+// the given index of the given task is written.
+//
+// Task types, and the ids of child tasks, contain colons, which are not valid
+// in file names on some platforms. Any character other than letters, digits,
+// dots, dashes and underscores is replaced with a dash, so that the name is
+// valid and cannot escape the output directory.
+func RawSampleFileName(taskType, taskID string, index int) string {
+	name := fmt.Sprintf("%s_%s_%d.json", taskType, taskID, index)
+	sanitize := func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}
+
+	return strings.Map(sanitize, name)
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestRawSamplesMiddleware(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		taskType string
+		items    int
+		want     int
+	}{
+		{
+			desc:     "sampled task with fewer items than sample size",
+			taskType: "test:task:sampled",
+			items:    1,
+			want:     1,
+		},
+		{
+			desc:     "sampled task with more items than sample size",
+			taskType: "test:task:sampled",
+			items:    5,
+			want:     2,
+		},
+		{
+			desc:     "task not configured for sampling",
+			taskType: "test:task:other",
+			items:    5,
+			want:     0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			conf := config.RawSamplesConfig{
+				IsEnabled: true,
+				Tasks:     []string{"test:task:sampled"},
+				Size:      2,
+				OutputDir: dir,
+			}
+
+			handler := func(ctx context.Context, _ *asynq.Task) error {
+				for i := range tc.items {
+					asynqutils.SampleRaw(ctx, map[string]int{"item": i})
+				}
+
+				return nil
+			}
+
+			mw := asynqutils.NewRawSamplesMiddleware(conf)
+			task := asynq.NewTask(tc.taskType, nil)
+			if err := mw(asynq.HandlerFunc(handler)).ProcessTask(context.Background(), task); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("cannot read output dir: %s", err)
+			}
+
+			if len(entries) != tc.want {
+				t.Fatalf("want %d samples, got %d", tc.want, len(entries))
+			}
+		})
+	}
+}
+
+func TestSampleRawWithoutMiddleware(t *testing.T) {
+	// Sampling outside of the middleware is a no-op
+	ctx := context.Background()
+	if asynqutils.IsSamplingRaw(ctx) {
+		t.Fatal("want sampling to be disabled")
+	}
+	asynqutils.SampleRaw(ctx, "item")
+}
+
+func TestRawSampleFileName(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		taskType string
+		taskID   string
+		index    int
+		want     string
+	}{
+		{
+			desc:     "task type with colons",
+			taskType: "openstack:task:collect-servers",
+			taskID:   "0f8fad5b-d9cb-469f-a165-70867728950e",
+			index:    1,
+			want:     "openstack-task-collect-servers_0f8fad5b-d9cb-469f-a165-70867728950e_1.json",
+		},
+		{
+			desc:     "child task id with colons",
+			taskType: "aws:task:collect-instances",
+			taskID:   "parent:aws:task:collect-instances:eu-west-1",
+			index:    2,
+			want:     "aws-task-collect-instances_parent-aws-task-collect-instances-eu-west-1_2.json",
+		},
+		{
+			desc:     "task id with path separators",
+			taskType: "test:task:sampled",
+			taskID:   "../../etc/passwd",
+			index:    3,
+			want:     "test-task-sampled_..-..-etc-passwd_3.json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := asynqutils.RawSampleFileName(tc.taskType, tc.taskID, tc.index)
+			if got != tc.want {
+				t.Fatalf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/uptrace/bun"
+)
+
+// Statuses of a [TaskResult].
+const (
+	// TaskStatusSucceeded is the status of a task, which completed
+	// successfully.
+	TaskStatusSucceeded = "succeeded"
+
+	// TaskStatusFailed is the status of a task, which returned an error.
+	TaskStatusFailed = "failed"
+)
+
+// TaskResult represents the structured result of a task, which is captured by
+// the middleware returned by [NewResultMiddleware].
+type TaskResult struct {
+	// TaskID specifies the id of the task.
+	TaskID string `json:"task_id"`
+
+	// TaskName specifies the name of the task.
+	TaskName string `json:"task_name"`
+
+	// Queue specifies the queue from which the task was processed.
+	Queue string `json:"queue"`
+
+	// Provider specifies the provider handled by the task, if any.
+	Provider string `json:"provider,omitempty"`
+
+	// Account specifies the account, project, subscription or seed the
+	// task is scoped to, if any.
+	Account string `json:"account,omitempty"`
+
+	// Region specifies the region the task is scoped to, if any.
+	Region string `json:"region,omitempty"`
+
+	// StartedAt specifies when the task handler was started.
+	StartedAt time.Time `json:"started_at"`
+
+	// CompletedAt specifies when the task handler completed.
+	CompletedAt time.Time `json:"completed_at"`
+
+	// Duration specifies the duration of the task handler.
+	Duration time.Duration `json:"duration"`
+
+	// Rows specifies the number of rows inserted or updated by the task.
+	Rows int64 `json:"rows"`
+
+	// Pages specifies the number of pages fetched from the API by the
+	// task.
+	Pages int64 `json:"pages"`
+
+	// Skipped specifies the number of items skipped by the task.
+	Skipped int64 `json:"skipped"`
+
+	// Status specifies the status of the task, which is either
+	// [TaskStatusSucceeded] or [TaskStatusFailed].
+	Status string `json:"status"`
+
+	// Error specifies the error returned by the task handler, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ResultSink persists the result of a task.
+type ResultSink func(ctx context.Context, result TaskResult) error
+
+// resultKey is the key used to store a [resultRecorder] in a
+// [context.Context].
+type resultKey struct{}
+
+// resultRecorder accumulates the counters of a task result while the task is
+// being processed.
+type resultRecorder struct {
+	rows    atomic.Int64
+	pages   atomic.Int64
+	skipped atomic.Int64
+}
+
+// getResultRecorder returns the [resultRecorder] from the given context, if
+// found, or nil otherwise.
+func getResultRecorder(ctx context.Context) *resultRecorder {
+	recorder, ok := ctx.Value(resultKey{}).(*resultRecorder)
+	if !ok {
+		return nil
+	}
+
+	return recorder
+}
+
+// AddRows adds the given number of inserted or updated rows to the result of
+// the task associated with the context.
+func AddRows(ctx context.Context, n int64) {
+	if recorder := getResultRecorder(ctx); recorder != nil {
+		recorder.rows.Add(n)
+	}
+}
+
+// AddPages adds the given number of fetched pages to the result of the task
+// associated with the context.
+func AddPages(ctx context.Context, n int64) {
+	if recorder := getResultRecorder(ctx); recorder != nil {
+		recorder.pages.Add(n)
+	}
+}
+
+// AddSkipped adds the given number of skipped items to the result of the task
+// associated with the context.
+func AddSkipped(ctx context.Context, n int64) {
+	if recorder := getResultRecorder(ctx); recorder != nil {
+		recorder.skipped.Add(n)
+	}
+}
+
+// NewResultMiddleware returns a new [asynq.MiddlewareFunc], which captures the
+// structured result of tasks and passes it to the given sinks. If storeInAsynq
+// is true, the result is also written to the result field of the asynq task.
+//
+// The recorded rows of successful tasks, which are scoped to an account, are
+// checked for unexpected zero-row collections via [CheckZeroRows].
+func NewResultMiddleware(storeInAsynq bool, sinks ...ResultSink) asynq.MiddlewareFunc {
+	middleware := func(handler asynq.Handler) asynq.Handler {
+		mw := func(ctx context.Context, task *asynq.Task) error {
+			recorder := &resultRecorder{}
+			newCtx := context.WithValue(ctx, resultKey{}, recorder)
+
+			start := time.Now()
+			err := handler.ProcessTask(newCtx, task)
+			end := time.Now()
+
+			taskID, _ := asynq.GetTaskID(ctx)
+			result := TaskResult{
+				TaskID:      taskID,
+				TaskName:    task.Type(),
+				Queue:       GetQueueName(ctx),
+				Provider:    TaskProvider(task.Type()),
+				Account:     ShardKey(task.Payload()),
+				Region:      PayloadRegion(task.Payload()),
+				StartedAt:   start,
+				CompletedAt: end,
+				Duration:    end.Sub(start),
+				Rows:        recorder.rows.Load(),
+				Pages:       recorder.pages.Load(),
+				Skipped:     recorder.skipped.Load(),
+				Status:      TaskStatusSucceeded,
+			}
+			if err != nil {
+				result.Status = TaskStatusFailed
+				result.Error = err.Error()
+			}
+
+			// The rows are checked before the result is
+			// persisted, so that the run is not compared with
+			// itself.
+			if err == nil && result.Account != "" {
+				scope := []string{result.Account}
+				if result.Region != "" {
+					scope = append(scope, result.Region)
+				}
+				CheckZeroRows(ctx, result.TaskName, result.Account, result.Region, int(result.Rows), scope...)
+			}
+
+			logger := GetLogger(ctx)
+			if storeInAsynq && task.ResultWriter() != nil {
+				data, mErr := json.Marshal(result)
+				if mErr == nil {
+					_, mErr = task.ResultWriter().Write(data)
+				}
+				if mErr != nil {
+					logger.Warn("failed to store task result", "reason", mErr)
+				}
+			}
+
+			for _, sink := range sinks {
+				if sErr := sink(ctx, result); sErr != nil {
+					logger.Warn("failed to persist task result", "reason", sErr)
+				}
+			}
+
+			return err
+		}
+
+		return asynq.HandlerFunc(mw)
+	}
+
+	return asynq.MiddlewareFunc(middleware)
+}
+
+// resultQueryHook is a [bun.QueryHook], which adds the rows affected by
+// INSERT and UPDATE queries to the result of the task associated with the
+// query context.
+type resultQueryHook struct{}
+
+var _ bun.QueryHook = (*resultQueryHook)(nil)
+
+// NewResultQueryHook returns a new [bun.QueryHook], which records the number of
+// rows inserted or updated by task handlers.
+func NewResultQueryHook() bun.QueryHook {
+	return &resultQueryHook{}
+}
+
+// BeforeQuery implements the [bun.QueryHook] interface.
+func (h *resultQueryHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery implements the [bun.QueryHook] interface.
+func (h *resultQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	if event.Err != nil || event.Result == nil {
+		return
+	}
+
+	switch event.Operation() {
+	case "INSERT", "UPDATE":
+		count, err := event.Result.RowsAffected()
+		if err == nil {
+			AddRows(ctx, count)
+		}
+	}
+}
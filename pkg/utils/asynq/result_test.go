@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestResultMiddleware(t *testing.T) {
+	errTask := errors.New("task failed")
+	handler := func(ctx context.Context, _ *asynq.Task) error {
+		asynqutils.AddPages(ctx, 2)
+		asynqutils.AddRows(ctx, 10)
+		asynqutils.AddRows(ctx, 5)
+		asynqutils.AddSkipped(ctx, 1)
+
+		return errTask
+	}
+
+	var result asynqutils.TaskResult
+	sink := func(_ context.Context, r asynqutils.TaskResult) error {
+		result = r
+
+		return nil
+	}
+
+	mw := asynqutils.NewResultMiddleware(false, sink)
+	payload := []byte(`{"account_id": "123", "region": "eu-west-1"}`)
+	task := asynq.NewTask("aws:task:collect-vpcs", payload)
+	err := mw(asynq.HandlerFunc(handler)).ProcessTask(context.Background(), task)
+	if !errors.Is(err, errTask) {
+		t.Fatalf("want error %v, got %v", errTask, err)
+	}
+
+	if result.TaskName != "aws:task:collect-vpcs" {
+		t.Fatalf("want task name aws:task:collect-vpcs, got %s", result.TaskName)
+	}
+
+	if result.Provider != "aws" || result.Account != "123" || result.Region != "eu-west-1" {
+		t.Fatalf("want aws/123/eu-west-1, got %s/%s/%s", result.Provider, result.Account, result.Region)
+	}
+
+	if result.Rows != 15 || result.Pages != 2 || result.Skipped != 1 {
+		t.Fatalf("want 15 rows, 2 pages, 1 skipped, got %d rows, %d pages, %d skipped", result.Rows, result.Pages, result.Skipped)
+	}
+
+	if result.Status != asynqutils.TaskStatusFailed {
+		t.Fatalf("want status %s, got %s", asynqutils.TaskStatusFailed, result.Status)
+	}
+
+	if result.Error != errTask.Error() {
+		t.Fatalf("want error %q, got %q", errTask.Error(), result.Error)
+	}
+}
+
+func TestResultMiddlewareZeroRows(t *testing.T) {
+	const taskType = "aws:task:collect-vpcs"
+	store := fakeRowCountStore{
+		rows: map[string]int64{
+			taskType + "/123/eu-west-1": 10,
+		},
+	}
+	items := []config.ZeroRowAlertConfig{
+		{Task: taskType},
+	}
+	asynqutils.ConfigureZeroRowAlerts(items, store)
+	defer asynqutils.ConfigureZeroRowAlerts(nil, nil)
+
+	testCases := []struct {
+		desc   string
+		rows   int64
+		err    error
+		wanted float64
+	}{
+		{
+			desc:   "non-zero rows",
+			rows:   5,
+			wanted: 0,
+		},
+		{
+			desc:   "failed task",
+			rows:   0,
+			err:    errors.New("task failed"),
+			wanted: 0,
+		},
+		{
+			desc:   "zero rows after non-zero rows",
+			rows:   0,
+			wanted: 1,
+		},
+	}
+
+	counter := metrics.UnexpectedZeroRowsTotal.WithLabelValues(taskType, "123/eu-west-1")
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			handler := func(ctx context.Context, _ *asynq.Task) error {
+				asynqutils.AddRows(ctx, tc.rows)
+
+				return tc.err
+			}
+
+			before := testutil.ToFloat64(counter)
+			mw := asynqutils.NewResultMiddleware(false)
+			payload := []byte(`{"account_id": "123", "region": "eu-west-1"}`)
+			task := asynq.NewTask(taskType, payload)
+			_ = mw(asynq.HandlerFunc(handler)).ProcessTask(context.Background(), task)
+
+			if got := testutil.ToFloat64(counter) - before; got != tc.wanted {
+				t.Fatalf("want %v unexpected zero-row collections, got %v", tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestResultCountersWithoutMiddleware(t *testing.T) {
+	// Recording results outside of the middleware is a no-op
+	ctx := context.Background()
+	asynqutils.AddRows(ctx, 1)
+	asynqutils.AddPages(ctx, 1)
+	asynqutils.AddSkipped(ctx, 1)
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	asynqclient "github.com/gardener/inventory/pkg/clients/asynq"
+	"github.com/gardener/inventory/pkg/metrics"
+)
+
+// ErrRedisUnavailable is an error, which is returned when a task could not be
+// enqueued, because Redis remained unavailable after retrying.
+//
+// Callers may check for this error in order to decide whether to abort, or to
+// defer the enqueueing of the remaining tasks.
+var ErrRedisUnavailable = errors.New("redis unavailable")
+
+// enqueueRetry specifies the settings for retrying the enqueueing of tasks
+// after transient Redis connection errors.
+var enqueueRetry = struct {
+	// attempts specifies the max number of attempts.
+	attempts int
+
+	// backoff specifies the delay before the first retry, which is doubled
+	// on each subsequent retry.
+	backoff time.Duration
+
+	// maxBackoff specifies the max delay between retries.
+	maxBackoff time.Duration
+}{
+	attempts:   5,
+	backoff:    250 * time.Millisecond,
+	maxBackoff: 5 * time.Second,
+}
+
+// IsTransientRedisError returns true, if the given error is caused by a
+// transient Redis connection error, e.g. the connection has been refused or
+// reset, and the operation may succeed when retried.
+func IsTransientRedisError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// enqueue enqueues the given task via [asynqclient.Client]. Transient Redis
+// connection errors are retried with exponential backoff, and each retry is
+// tracked by the [metrics.EnqueueRetriesTotal] metric. If Redis remains
+// unavailable after all attempts, the returned error wraps
+// [ErrRedisUnavailable].
+//
+// The trace context from the given context is propagated via the payload of
+// the task, see [InjectTraceContext].
+func enqueue(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	logger := GetLogger(ctx)
+	if payload := InjectTraceContext(ctx, task.Payload()); !bytes.Equal(payload, task.Payload()) {
+		task = asynq.NewTask(task.Type(), payload)
+	}
+
+	backoff := enqueueRetry.backoff
+	for attempt := 1; ; attempt++ {
+		info, err := asynqclient.Client.EnqueueContext(ctx, task, opts...)
+		if !IsTransientRedisError(err) {
+			return info, err
+		}
+
+		if attempt >= enqueueRetry.attempts {
+			return nil, fmt.Errorf("%w: %w", ErrRedisUnavailable, err)
+		}
+
+		logger.Warn(
+			"retrying enqueue of task",
+			"type", task.Type(),
+			"attempt", attempt,
+			"backoff", backoff,
+			"reason", err,
+		)
+		metrics.EnqueueRetriesTotal.WithLabelValues(task.Type()).Inc()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrRedisUnavailable, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, enqueueRetry.maxBackoff)
+	}
+}
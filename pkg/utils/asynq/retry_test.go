@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestIsTransientRedisError(t *testing.T) {
+	connRefused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+	testCases := []struct {
+		desc   string
+		err    error
+		wanted bool
+	}{
+		{
+			desc:   "no error",
+			err:    nil,
+			wanted: false,
+		},
+		{
+			desc:   "connection refused",
+			err:    fmt.Errorf("redis command error: %w", connRefused),
+			wanted: true,
+		},
+		{
+			desc:   "connection closed",
+			err:    fmt.Errorf("redis command error: %w", io.EOF),
+			wanted: true,
+		},
+		{
+			desc:   "context deadline exceeded",
+			err:    fmt.Errorf("redis command error: %w", context.DeadlineExceeded),
+			wanted: false,
+		},
+		{
+			desc:   "other error",
+			err:    errors.New("task already exists"),
+			wanted: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := asynqutils.IsTransientRedisError(tc.err); got != tc.wanted {
+				t.Fatalf("wanted %t got %t", tc.wanted, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/gardener/inventory/pkg/core/config"
+)
+
+// routing holds the queue routing settings configured via
+// [ConfigureQueueRouting].
+var routing struct {
+	sync.RWMutex
+	conf config.QueueRoutingConfig
+}
+
+// ConfigureQueueRouting configures the routing of tasks to queues based on
+// their task type or provider.
+func ConfigureQueueRouting(conf config.QueueRoutingConfig) {
+	routing.Lock()
+	defer routing.Unlock()
+
+	routing.conf = conf
+}
+
+// RouteQueue returns the queue, to which tasks of the given type are routed. A
+// route for the task type takes precedence over a route for the provider of the
+// task. If routing is disabled, or no route matches, the fallback queue is
+// returned.
+func RouteQueue(taskType string, fallback string) string {
+	routing.RLock()
+	defer routing.RUnlock()
+
+	if !routing.conf.IsEnabled {
+		return fallback
+	}
+
+	if route, ok := routing.conf.Tasks[taskType]; ok && route.Queue != "" {
+		return route.Queue
+	}
+
+	provider := TaskProvider(taskType)
+	if route, ok := routing.conf.Providers[provider]; ok && provider != "" && route.Queue != "" {
+		return route.Queue
+	}
+
+	return fallback
+}
+
+// WithRoutedQueues returns the given worker queues along with the queues of
+// the configured routes, so that workers process the tasks routed to them.
+// Queues, which are already part of the worker queues, keep their priority.
+// If the same queue is used by multiple routes, the highest priority is used.
+func WithRoutedQueues(queues map[string]int, conf config.QueueRoutingConfig) map[string]int {
+	if !conf.IsEnabled {
+		return queues
+	}
+
+	result := maps.Clone(queues)
+	if len(result) == 0 {
+		result = map[string]int{config.DefaultQueueName: 1}
+	}
+
+	routed := make(map[string]int)
+	routes := make([]config.QueueRouteConfig, 0, len(conf.Tasks)+len(conf.Providers))
+	for _, route := range conf.Tasks {
+		routes = append(routes, route)
+	}
+	for _, route := range conf.Providers {
+		routes = append(routes, route)
+	}
+
+	for _, route := range routes {
+		if route.Queue == "" {
+			continue
+		}
+		priority := max(route.Priority, 1)
+		routed[route.Queue] = max(routed[route.Queue], priority)
+	}
+
+	for queue, priority := range routed {
+		if _, ok := result[queue]; !ok {
+			result[queue] = priority
+		}
+	}
+
+	return result
+}
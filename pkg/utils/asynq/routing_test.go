@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestRouteQueue(t *testing.T) {
+	conf := config.QueueRoutingConfig{
+		IsEnabled: true,
+		Tasks: map[string]config.QueueRouteConfig{
+			"aws:task:collect-regions": {Queue: "critical", Priority: 10},
+		},
+		Providers: map[string]config.QueueRouteConfig{
+			"aws":       {Queue: "aws", Priority: 1},
+			"openstack": {Queue: "openstack", Priority: 6},
+		},
+	}
+
+	defer asynqutils.ConfigureQueueRouting(config.QueueRoutingConfig{})
+	asynqutils.ConfigureQueueRouting(conf)
+
+	testCases := []struct {
+		desc     string
+		taskType string
+		want     string
+	}{
+		{
+			desc:     "task type route takes precedence",
+			taskType: "aws:task:collect-regions",
+			want:     "critical",
+		},
+		{
+			desc:     "provider route",
+			taskType: "aws:task:collect-instances",
+			want:     "aws",
+		},
+		{
+			desc:     "another provider route",
+			taskType: "openstack:task:collect-servers",
+			want:     "openstack",
+		},
+		{
+			desc:     "no matching route",
+			taskType: "gcp:task:collect-instances",
+			want:     "default",
+		},
+		{
+			desc:     "auxiliary task",
+			taskType: "aux:task:housekeeper",
+			want:     "default",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := asynqutils.RouteQueue(tc.taskType, "default")
+			if got != tc.want {
+				t.Fatalf("want queue %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	asynqutils.ConfigureQueueRouting(config.QueueRoutingConfig{})
+	if got := asynqutils.RouteQueue("aws:task:collect-instances", "default"); got != "default" {
+		t.Fatalf("want default queue when routing is disabled, got %s", got)
+	}
+}
+
+func TestWithRoutedQueues(t *testing.T) {
+	conf := config.QueueRoutingConfig{
+		IsEnabled: true,
+		Tasks: map[string]config.QueueRouteConfig{
+			"aws:task:collect-regions": {Queue: "aws", Priority: 3},
+		},
+		Providers: map[string]config.QueueRouteConfig{
+			"aws":       {Queue: "aws", Priority: 1},
+			"openstack": {Queue: "openstack", Priority: 6},
+			"gcp":       {Queue: "default", Priority: 9},
+		},
+	}
+
+	got := asynqutils.WithRoutedQueues(nil, conf)
+	want := map[string]int{
+		"default":   1,
+		"aws":       3,
+		"openstack": 6,
+	}
+	if !maps.Equal(got, want) {
+		t.Fatalf("want queues %v, got %v", want, got)
+	}
+
+	queues := map[string]int{"default": 2, "openstack": 1}
+	got = asynqutils.WithRoutedQueues(queues, conf)
+	want = map[string]int{
+		"default":   2,
+		"aws":       3,
+		"openstack": 1,
+	}
+	if !maps.Equal(got, want) {
+		t.Fatalf("want queues %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sync"
+
+	"github.com/gardener/inventory/pkg/core/config"
+)
+
+// shardSuffixRegex matches the suffix of shard queue names.
+var shardSuffixRegex = regexp.MustCompile(`:shard-\d+$`)
+
+// sharding holds the sharding settings configured via [ConfigureSharding].
+var sharding struct {
+	sync.RWMutex
+	shards int
+}
+
+// ConfigureSharding configures the distribution of fan-out tasks to shard
+// queues. When sharding is disabled, tasks are enqueued in the queue of the
+// parent task, and are distributed by asynq as usual.
+func ConfigureSharding(conf config.ShardingConfig) {
+	sharding.Lock()
+	defer sharding.Unlock()
+
+	sharding.shards = 0
+	if conf.IsEnabled && conf.Shards > 1 {
+		sharding.shards = conf.Shards
+	}
+}
+
+// shardCount returns the number of configured shards, or zero if sharding is
+// disabled.
+func shardCount() int {
+	sharding.RLock()
+	defer sharding.RUnlock()
+
+	return sharding.shards
+}
+
+// ShardQueueName returns the name of the shard queue with the given index.
+func ShardQueueName(queue string, shard int) string {
+	return fmt.Sprintf("%s:shard-%d", BaseQueueName(queue), shard)
+}
+
+// BaseQueueName returns the name of the queue without any shard suffix.
+func BaseQueueName(queue string) string {
+	return shardSuffixRegex.ReplaceAllString(queue, "")
+}
+
+// ShardQueue returns the shard queue for the given key, e.g. an account or
+// project id. If sharding is disabled, or the key is empty, the queue is
+// returned as is.
+func ShardQueue(queue string, key string) string {
+	shards := shardCount()
+	if shards == 0 || key == "" {
+		return queue
+	}
+
+	return ShardQueueName(queue, ShardIndex(key, shards))
+}
+
+// ShardIndex returns the shard in the range [0, shards) for the given key,
+// using jump consistent hashing [1]. When the number of shards changes from n
+// to n+1, only 1/(n+1) of the keys are moved to a different shard.
+//
+// [1]: https://arxiv.org/abs/1406.2294
+func ShardIndex(key string, shards int) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	k := h.Sum64()
+
+	var b, j int64 = -1, 0
+	for j < int64(shards) {
+		b = j
+		k = k*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((k>>33)+1)))
+	}
+
+	return int(b)
+}
+
+// shardKeyPayload represents the fields of a task payload, which are used to
+// derive the shard key and the region of the task.
+type shardKeyPayload struct {
+	AccountID      string `json:"account_id"`
+	ProjectID      string `json:"project_id"`
+	SubscriptionID string `json:"subscription_id"`
+	Seed           string `json:"seed"`
+	Region         string `json:"region"`
+	Scope          struct {
+		Project string
+		Region  string
+	} `json:"scope"`
+}
+
+// ShardKey returns the shard key for the given task payload, which is the
+// account, project, subscription or seed the task is scoped to. An empty
+// string is returned if the payload is not scoped.
+func ShardKey(payload []byte) string {
+	if len(payload) == 0 {
+		return ""
+	}
+
+	var p shardKeyPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return ""
+	}
+
+	for _, key := range []string{p.AccountID, p.ProjectID, p.SubscriptionID, p.Scope.Project, p.Seed} {
+		if key != "" {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// PayloadRegion returns the region the given task payload is scoped to. An
+// empty string is returned if the payload is not scoped to a region.
+func PayloadRegion(payload []byte) string {
+	if len(payload) == 0 {
+		return ""
+	}
+
+	var p shardKeyPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return ""
+	}
+
+	if p.Region != "" {
+		return p.Region
+	}
+
+	return p.Scope.Region
+}
+
+// ExpandShardQueues expands each of the given queues into its shard queues,
+// keeping the priority of the queue. If owned is not empty, only the given
+// shards are included. The queues are returned as is, if sharding is disabled.
+func ExpandShardQueues(queues map[string]int, conf config.ShardingConfig) map[string]int {
+	if !conf.IsEnabled || conf.Shards <= 1 {
+		return queues
+	}
+
+	owned := conf.OwnedShards
+	if len(owned) == 0 {
+		for i := range conf.Shards {
+			owned = append(owned, i)
+		}
+	}
+
+	result := make(map[string]int, len(queues)*(len(owned)+1))
+	for queue, priority := range queues {
+		// Keep the base queue, so that tasks enqueued by the
+		// scheduler or the CLI are still processed.
+		result[queue] = priority
+		for _, shard := range owned {
+			if shard < 0 || shard >= conf.Shards {
+				continue
+			}
+			result[ShardQueueName(queue, shard)] = priority
+		}
+	}
+
+	return result
+}
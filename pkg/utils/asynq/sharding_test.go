@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestShardIndex(t *testing.T) {
+	const shards = 8
+	moved := 0
+	total := 1000
+	for i := range total {
+		key := fmt.Sprintf("account-%d", i)
+		idx := asynqutils.ShardIndex(key, shards)
+		if idx < 0 || idx >= shards {
+			t.Fatalf("shard index %d out of range for %s", idx, key)
+		}
+
+		if again := asynqutils.ShardIndex(key, shards); again != idx {
+			t.Fatalf("shard index for %s is not deterministic: %d != %d", key, idx, again)
+		}
+
+		// Keys either stay on their shard, or move to the new shard
+		// when scaling up.
+		next := asynqutils.ShardIndex(key, shards+1)
+		if next != idx {
+			if next != shards {
+				t.Fatalf("key %s moved from shard %d to %d", key, idx, next)
+			}
+			moved++
+		}
+	}
+
+	// Roughly 1/(n+1) of the keys are expected to move
+	if moved == 0 || moved > total/4 {
+		t.Fatalf("unexpected number of moved keys: %d", moved)
+	}
+}
+
+func TestShardQueue(t *testing.T) {
+	defer asynqutils.ConfigureSharding(config.ShardingConfig{})
+
+	asynqutils.ConfigureSharding(config.ShardingConfig{})
+	if got := asynqutils.ShardQueue("default", "account-1"); got != "default" {
+		t.Fatalf("want default queue when sharding is disabled, got %s", got)
+	}
+
+	asynqutils.ConfigureSharding(config.ShardingConfig{IsEnabled: true, Shards: 4})
+	want := asynqutils.ShardQueueName("default", asynqutils.ShardIndex("account-1", 4))
+	if got := asynqutils.ShardQueue("default", "account-1"); got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+
+	// Tasks enqueued from a shard queue stay on the shard of their key
+	if got := asynqutils.ShardQueue(want, "account-1"); got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+
+	if got := asynqutils.ShardQueue("default", ""); got != "default" {
+		t.Fatalf("want default queue for empty key, got %s", got)
+	}
+}
+
+func TestShardKey(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		payload string
+		wanted  string
+	}{
+		{desc: "empty payload", payload: "", wanted: ""},
+		{desc: "account id", payload: `{"region": "eu-west-1", "account_id": "123"}`, wanted: "123"},
+		{desc: "project id", payload: `{"project_id": "my-project"}`, wanted: "my-project"},
+		{desc: "subscription id", payload: `{"subscription_id": "sub-1"}`, wanted: "sub-1"},
+		{desc: "openstack scope", payload: `{"scope": {"Project": "os-project"}}`, wanted: "os-project"},
+		{desc: "not scoped", payload: `{"name": "foo"}`, wanted: ""},
+		{desc: "invalid payload", payload: `not json`, wanted: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := asynqutils.ShardKey([]byte(tc.payload)); got != tc.wanted {
+				t.Fatalf("want %q, got %q", tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestPayloadRegion(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		payload string
+		wanted  string
+	}{
+		{desc: "empty payload", payload: "", wanted: ""},
+		{desc: "region", payload: `{"region": "eu-west-1", "account_id": "123"}`, wanted: "eu-west-1"},
+		{desc: "openstack scope", payload: `{"scope": {"Project": "os-project", "Region": "eu-de-1"}}`, wanted: "eu-de-1"},
+		{desc: "not scoped", payload: `{"project_id": "my-project"}`, wanted: ""},
+		{desc: "invalid payload", payload: `not json`, wanted: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := asynqutils.PayloadRegion([]byte(tc.payload)); got != tc.wanted {
+				t.Fatalf("want %q, got %q", tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestExpandShardQueues(t *testing.T) {
+	queues := map[string]int{"default": 2}
+
+	got := asynqutils.ExpandShardQueues(queues, config.ShardingConfig{})
+	if !maps.Equal(got, queues) {
+		t.Fatalf("want %v, got %v", queues, got)
+	}
+
+	conf := config.ShardingConfig{
+		IsEnabled:   true,
+		Shards:      4,
+		OwnedShards: []int{1, 3},
+	}
+	wanted := map[string]int{
+		"default":         2,
+		"default:shard-1": 2,
+		"default:shard-3": 2,
+	}
+	got = asynqutils.ExpandShardQueues(queues, conf)
+	if !maps.Equal(got, wanted) {
+		t.Fatalf("want %v, got %v", wanted, got)
+	}
+}
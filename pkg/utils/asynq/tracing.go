@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gardener/inventory/pkg/tracing"
+)
+
+// TraceContextKey is the key of the task payload field, which carries the
+// trace context of the task, which enqueued the task.
+//
+// Asynq does not support task metadata, so the trace context is propagated as
+// part of JSON object payloads. Payload structs ignore the field when
+// unmarshaling.
+const TraceContextKey = "_trace_context"
+
+// InjectTraceContext returns the given payload with the trace context from the
+// given context added to it. The payload is returned as is, if there is no
+// trace context to propagate, or if the payload is not a JSON object.
+func InjectTraceContext(ctx context.Context, payload []byte) []byte {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return payload
+	}
+
+	fields, ok := payloadFields(payload)
+	if !ok {
+		return payload
+	}
+
+	value, err := json.Marshal(carrier)
+	if err != nil {
+		return payload
+	}
+	fields[TraceContextKey] = value
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+
+	return data
+}
+
+// ExtractTraceContext returns a copy of the given context, which carries the
+// trace context from the given payload, if any.
+func ExtractTraceContext(ctx context.Context, payload []byte) context.Context {
+	fields, ok := payloadFields(payload)
+	if !ok {
+		return ctx
+	}
+
+	value, ok := fields[TraceContextKey]
+	if !ok {
+		return ctx
+	}
+
+	var carrier propagation.MapCarrier
+	if err := json.Unmarshal(value, &carrier); err != nil {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// StripTraceContext returns the given payload without the trace context added
+// by [InjectTraceContext], e.g. in order to identify the tasks with the same
+// payload regardless of the trace they belong to.
+func StripTraceContext(payload []byte) []byte {
+	fields, ok := payloadFields(payload)
+	if !ok {
+		return payload
+	}
+
+	if _, ok := fields[TraceContextKey]; !ok {
+		return payload
+	}
+	delete(fields, TraceContextKey)
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+
+	return data
+}
+
+// payloadFields returns the fields of the given payload, if the payload is a
+// JSON object.
+func payloadFields(payload []byte) (map[string]json.RawMessage, bool) {
+	if !bytes.HasPrefix(bytes.TrimSpace(payload), []byte("{")) {
+		return nil, false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, false
+	}
+
+	return fields, true
+}
+
+// NewTracingMiddleware returns a new [asynq.MiddlewareFunc], which starts a
+// span for each processed task. The span is a child of the span, which
+// enqueued the task, if the payload carries its trace context.
+func NewTracingMiddleware() asynq.MiddlewareFunc {
+	middleware := func(handler asynq.Handler) asynq.Handler {
+		mw := func(ctx context.Context, task *asynq.Task) error {
+			ctx = ExtractTraceContext(ctx, task.Payload())
+			ctx, span := tracing.Start(
+				ctx,
+				task.Type(),
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.String("task.id", GetTaskID(ctx)),
+					attribute.String("task.queue", GetQueueName(ctx)),
+					attribute.String("task.name", task.Type()),
+				),
+			)
+
+			err := handler.ProcessTask(ctx, task)
+			tracing.End(span, err)
+
+			return err
+		}
+
+		return asynq.HandlerFunc(mw)
+	}
+
+	return asynq.MiddlewareFunc(middleware)
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+func TestTraceContextPropagation(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	testCases := []struct {
+		desc       string
+		ctx        context.Context
+		payload    []byte
+		wantInject bool
+	}{
+		{
+			desc:       "nil payload",
+			ctx:        ctx,
+			payload:    nil,
+			wantInject: false,
+		},
+		{
+			desc:       "yaml payload",
+			ctx:        ctx,
+			payload:    []byte("project_id: foo"),
+			wantInject: false,
+		},
+		{
+			desc:       "no trace context",
+			ctx:        context.Background(),
+			payload:    []byte(`{"project_id":"foo"}`),
+			wantInject: false,
+		},
+		{
+			desc:       "json payload",
+			ctx:        ctx,
+			payload:    []byte(`{"project_id":"foo"}`),
+			wantInject: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			payload := asynqutils.InjectTraceContext(tc.ctx, tc.payload)
+			if !tc.wantInject {
+				if string(payload) != string(tc.payload) {
+					t.Fatalf("want payload %q, got %q", tc.payload, payload)
+				}
+
+				return
+			}
+
+			var fields struct {
+				ProjectID string `json:"project_id"`
+			}
+			if err := json.Unmarshal(payload, &fields); err != nil {
+				t.Fatalf("cannot unmarshal payload: %s", err)
+			}
+			if fields.ProjectID != "foo" {
+				t.Fatalf("want project_id foo, got %q", fields.ProjectID)
+			}
+
+			got := trace.SpanContextFromContext(asynqutils.ExtractTraceContext(context.Background(), payload))
+			if got.TraceID() != traceID || got.SpanID() != spanID || !got.IsRemote() {
+				t.Fatalf("want remote span context %s/%s, got %s/%s", traceID, spanID, got.TraceID(), got.SpanID())
+			}
+
+			stripped := asynqutils.StripTraceContext(payload)
+			if string(stripped) != string(tc.payload) {
+				t.Fatalf("want stripped payload %q, got %q", tc.payload, stripped)
+			}
+		})
+	}
+}
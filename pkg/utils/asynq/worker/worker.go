@@ -9,12 +9,14 @@ import (
 	"log/slog"
 	"net/http"
 	"runtime"
+	"time"
 
 	"github.com/hibiken/asynq"
 
 	"github.com/gardener/inventory/pkg/core/config"
 	"github.com/gardener/inventory/pkg/core/registry"
 	"github.com/gardener/inventory/pkg/metrics"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 )
 
 // Option is a function, which configures the [Worker].
@@ -29,6 +31,9 @@ type Worker struct {
 	metricsAddr   string
 	metricsPath   string
 	metricsServer *http.Server
+	providers     []string
+
+	shutdownTimeout time.Duration
 }
 
 // WithLogLevel is an [Option], which configures the log level of the [Worker].
@@ -40,6 +45,16 @@ func WithLogLevel(level asynq.LogLevel) Option {
 	return opt
 }
 
+// WithLogger is an [Option], which configures the [Worker] to use the
+// specified [asynq.Logger].
+func WithLogger(logger asynq.Logger) Option {
+	opt := func(conf *asynq.Config) {
+		conf.Logger = logger
+	}
+
+	return opt
+}
+
 // WithErrorHandler is an [Option], which configures the [Worker] to use the
 // specified [asynq.ErrorHandler].
 func WithErrorHandler(handler asynq.ErrorHandler) Option {
@@ -52,7 +67,7 @@ func WithErrorHandler(handler asynq.ErrorHandler) Option {
 
 // NewFromConfig creates a new [Worker] based on the provided
 // [config.WorkerConfig] spec.
-func NewFromConfig(ctx context.Context, r asynq.RedisClientOpt, conf config.WorkerConfig, opts ...Option) *Worker {
+func NewFromConfig(ctx context.Context, r asynq.RedisConnOpt, conf config.WorkerConfig, opts ...Option) *Worker {
 	concurrency := conf.Concurrency
 	if concurrency <= 0 {
 		concurrency = runtime.NumCPU()
@@ -62,15 +77,23 @@ func NewFromConfig(ctx context.Context, r asynq.RedisClientOpt, conf config.Work
 		config.DefaultQueueName: 1,
 	}
 
-	queues := conf.Queues
+	// Queues dedicated to providers, which are not handled by the
+	// worker, are ignored.
+	queues := asynqutils.FilterProviderQueues(conf.Queues, conf.Providers)
 	if len(queues) == 0 {
 		queues = defaultQueues
 	}
 
+	shutdownTimeout := conf.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = config.DefaultShutdownTimeout
+	}
+
 	asynqConfig := asynq.Config{
-		Concurrency:    concurrency,
-		Queues:         queues,
-		StrictPriority: conf.StrictPriority,
+		Concurrency:     concurrency,
+		Queues:          asynqutils.ExpandShardQueues(queues, conf.Sharding),
+		StrictPriority:  conf.StrictPriority,
+		ShutdownTimeout: shutdownTimeout,
 	}
 
 	for _, opt := range opts {
@@ -97,6 +120,9 @@ func NewFromConfig(ctx context.Context, r asynq.RedisClientOpt, conf config.Work
 		metricsAddr:   metricsAddr,
 		metricsPath:   metricsPath,
 		metricsServer: metricsServer,
+		providers:     conf.Providers,
+
+		shutdownTimeout: shutdownTimeout,
 	}
 
 	return worker
@@ -114,9 +140,13 @@ func (w *Worker) Handle(pattern string, handler asynq.Handler) {
 }
 
 // HandlersFromRegistry registers task handlers with the [Worker] multiplexer
-// using the given registry.
+// using the given registry. If the [Worker] is configured to handle specific
+// providers only, the handlers of other providers are not registered.
 func (w *Worker) HandlersFromRegistry(reg *registry.Registry[string, asynq.Handler]) {
 	_ = reg.Range(func(pattern string, handler asynq.Handler) error {
+		if !asynqutils.IsTaskSelected(pattern, w.providers) {
+			return nil
+		}
 		w.Handle(pattern, handler)
 
 		return nil
@@ -125,6 +155,10 @@ func (w *Worker) HandlersFromRegistry(reg *registry.Registry[string, asynq.Handl
 
 // Run starts the task processing by calling [asynq.Server.Start] and blocks
 // until an OS signal is received.
+//
+// On SIGTERM or SIGINT the server stops accepting new tasks and waits for the
+// in-flight tasks to complete within the configured shutdown timeout, before
+// returning.
 func (w *Worker) Run() error {
 	go func() {
 		slog.Info(
@@ -141,11 +175,14 @@ func (w *Worker) Run() error {
 }
 
 // Shutdown gracefully shuts down the server by calling [asynq.Server.Shutdown].
+// It is safe to call Shutdown after [Worker.Run] has returned.
 func (w *Worker) Shutdown() {
 	w.asynqServer.Shutdown()
 
 	slog.Info("shutting down metrics server")
-	if err := w.metricsServer.Shutdown(context.Background()); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), w.shutdownTimeout)
+	defer cancel()
+	if err := w.metricsServer.Shutdown(ctx); err != nil {
 		slog.Error("failed to gracefully shutdown metrics server", "reason", err)
 	}
 }
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	"github.com/gardener/inventory/pkg/metrics"
+)
+
+// RowCountStore provides the number of rows collected by the previous runs of
+// a task.
+type RowCountStore interface {
+	// LastRows returns the number of rows collected by the last successful
+	// run of the given task type for the given account and region. The
+	// returned bool is false, if no successful run has been recorded yet.
+	LastRows(ctx context.Context, taskType, account, region string) (int64, bool, error)
+}
+
+// zeroRowsDetector keeps track of the task types, for which unexpected
+// zero-row collections are reported.
+type zeroRowsDetector struct {
+	sync.Mutex
+
+	// allowed maps configured task types to the set of scopes, which may
+	// legitimately return zero rows.
+	allowed map[string]map[string]struct{}
+
+	// store provides the number of rows collected by previous runs.
+	store RowCountStore
+}
+
+// defaultZeroRowsDetector is the detector used by [ConfigureZeroRowAlerts]
+// and [CheckZeroRows].
+var defaultZeroRowsDetector = &zeroRowsDetector{
+	allowed: make(map[string]map[string]struct{}),
+}
+
+// ConfigureZeroRowAlerts configures the task types for which unexpected
+// zero-row collections are reported by [CheckZeroRows]. The number of rows
+// collected by the previous runs of the tasks is looked up in the given
+// [RowCountStore].
+func ConfigureZeroRowAlerts(items []config.ZeroRowAlertConfig, store RowCountStore) {
+	d := defaultZeroRowsDetector
+	d.Lock()
+	defer d.Unlock()
+
+	clear(d.allowed)
+	d.store = store
+	for _, item := range items {
+		scopes, ok := d.allowed[item.Task]
+		if !ok {
+			scopes = make(map[string]struct{})
+			d.allowed[item.Task] = scopes
+		}
+		for _, scope := range item.AllowedScopes {
+			scopes[scope] = struct{}{}
+		}
+	}
+}
+
+// CheckZeroRows checks the number of rows collected by the given task type for
+// the given account and region. When the collection returned zero rows, while
+// the last successful run of the task for the same account and region returned
+// a non-zero number of rows, an error is logged and the
+// [metrics.UnexpectedZeroRowsTotal] metric is incremented, unless the scope is
+// allowed to be empty. Task types, which are not configured via
+// [ConfigureZeroRowAlerts] are ignored.
+//
+// The account and region are expected to match the ones recorded for the task
+// in the collection runs, e.g. as returned by [ShardKey] and [PayloadRegion]
+// for the task payload. CheckZeroRows is called for all successful tasks by the
+// middleware returned by [NewResultMiddleware] with the recorded rows and the
+// account and region as scope.
+//
+// CheckZeroRows reports whether an unexpected zero-row collection has been
+// detected.
+func CheckZeroRows(ctx context.Context, taskType, account, region string, count int, scope ...string) bool {
+	d := defaultZeroRowsDetector
+	scopeName := strings.Join(scope, "/")
+
+	d.Lock()
+	allowed, ok := d.allowed[taskType]
+	store := d.store
+	d.Unlock()
+
+	if !ok || store == nil || count > 0 {
+		return false
+	}
+
+	if _, ok := allowed[scopeName]; ok {
+		return false
+	}
+
+	logger := GetLogger(ctx)
+	lastRows, found, err := store.LastRows(ctx, taskType, account, region)
+	if err != nil {
+		logger.Warn(
+			"cannot check for unexpected zero rows",
+			"scope", scopeName,
+			"reason", err,
+		)
+
+		return false
+	}
+
+	if !found || lastRows == 0 {
+		return false
+	}
+
+	logger.Error(
+		"collection unexpectedly returned zero rows",
+		"scope", scopeName,
+		"last_rows", lastRows,
+	)
+	metrics.UnexpectedZeroRowsTotal.WithLabelValues(taskType, scopeName).Inc()
+
+	return true
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package asynq_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// fakeRowCountStore is an [asynqutils.RowCountStore], which returns the rows
+// of the last successful runs from a map keyed by task type, account and
+// region.
+type fakeRowCountStore struct {
+	rows map[string]int64
+	err  error
+}
+
+func (s fakeRowCountStore) LastRows(_ context.Context, taskType, account, region string) (int64, bool, error) {
+	if s.err != nil {
+		return 0, false, s.err
+	}
+
+	rows, ok := s.rows[taskType+"/"+account+"/"+region]
+
+	return rows, ok, nil
+}
+
+func TestCheckZeroRows(t *testing.T) {
+	store := fakeRowCountStore{
+		rows: map[string]int64{
+			"test:task:collect/project-a/region-1":     10,
+			"test:task:collect/project-b/region-1":     0,
+			"test:task:collect/empty-project/region-1": 5,
+		},
+	}
+	items := []config.ZeroRowAlertConfig{
+		{
+			Task:          "test:task:collect",
+			AllowedScopes: []string{"empty-project"},
+		},
+	}
+	defer asynqutils.ConfigureZeroRowAlerts(nil, nil)
+
+	ctx := context.Background()
+	testCases := []struct {
+		desc     string
+		store    asynqutils.RowCountStore
+		taskType string
+		account  string
+		region   string
+		count    int
+		wanted   bool
+	}{
+		{
+			desc:     "unconfigured task type",
+			store:    store,
+			taskType: "test:task:unknown",
+			account:  "project-a",
+			region:   "region-1",
+			count:    0,
+			wanted:   false,
+		},
+		{
+			desc:     "non-zero rows",
+			store:    store,
+			taskType: "test:task:collect",
+			account:  "project-a",
+			region:   "region-1",
+			count:    10,
+			wanted:   false,
+		},
+		{
+			desc:     "zero rows after non-zero rows",
+			store:    store,
+			taskType: "test:task:collect",
+			account:  "project-a",
+			region:   "region-1",
+			count:    0,
+			wanted:   true,
+		},
+		{
+			desc:     "zero rows in another region",
+			store:    store,
+			taskType: "test:task:collect",
+			account:  "project-a",
+			region:   "region-2",
+			count:    0,
+			wanted:   false,
+		},
+		{
+			desc:     "zero rows after zero rows",
+			store:    store,
+			taskType: "test:task:collect",
+			account:  "project-b",
+			region:   "region-1",
+			count:    0,
+			wanted:   false,
+		},
+		{
+			desc:     "zero rows for allowed scope",
+			store:    store,
+			taskType: "test:task:collect",
+			account:  "empty-project",
+			region:   "region-1",
+			count:    0,
+			wanted:   false,
+		},
+		{
+			desc:     "store failure",
+			store:    fakeRowCountStore{err: errors.New("database unavailable")},
+			taskType: "test:task:collect",
+			account:  "project-a",
+			region:   "region-1",
+			count:    0,
+			wanted:   false,
+		},
+		{
+			desc:     "no store configured",
+			store:    nil,
+			taskType: "test:task:collect",
+			account:  "project-a",
+			region:   "region-1",
+			count:    0,
+			wanted:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			asynqutils.ConfigureZeroRowAlerts(items, tc.store)
+			got := asynqutils.CheckZeroRows(ctx, tc.taskType, tc.account, tc.region, tc.count, tc.account)
+			if got != tc.wanted {
+				t.Fatalf("want %v, got %v", tc.wanted, got)
+			}
+		})
+	}
+}
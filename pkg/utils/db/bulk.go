@@ -0,0 +1,351 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gardener/inventory/pkg/tracing"
+)
+
+// MaxQueryParams is the max number of parameters supported by a single query
+// when using the extended protocol of PostgreSQL.
+const MaxQueryParams = 65535
+
+// ChunkSize returns the max number of rows of the given model type, which can
+// be inserted by a single query without exceeding [MaxQueryParams]. The chunk
+// size is derived from the number of columns of the model, so that it adapts
+// to wide tables.
+func ChunkSize[T any](db bun.IDB) int {
+	typ := reflect.TypeFor[T]()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	columns := len(db.Dialect().Tables().Get(typ).Fields)
+	if columns == 0 {
+		return MaxQueryParams
+	}
+
+	return MaxQueryParams / columns
+}
+
+// tableName returns the name of the table of the given model type.
+func tableName[T any](db bun.IDB) string {
+	typ := reflect.TypeFor[T]()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return db.Dialect().Tables().Get(typ).Name
+}
+
+// SortByUniqueKey sorts the given items in-place by the values of the columns
+// of the unique constraints of their model. Models without unique constraints
+// are not sorted.
+//
+// Upserting rows in a consistent order ensures that concurrent transactions
+// upserting the same rows acquire row locks in the same order, which prevents
+// deadlocks between them. See [SortLinks] for more details.
+func SortByUniqueKey[T any](db bun.IDB, items []T) {
+	typ := reflect.TypeFor[T]()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	table := db.Dialect().Tables().Get(typ)
+	names := slices.Sorted(maps.Keys(table.Unique))
+	fields := make([]*schema.Field, 0)
+	for _, name := range names {
+		for _, field := range table.Unique[name] {
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		va := reflect.Indirect(reflect.ValueOf(a))
+		vb := reflect.Indirect(reflect.ValueOf(b))
+		for _, field := range fields {
+			if c := compareValues(field.Value(va), field.Value(vb)); c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	})
+}
+
+// compareValues compares the given values of the same type. Values of types,
+// which are not ordered, are compared by their string representation, which
+// still yields a consistent order.
+func compareValues(a, b reflect.Value) int {
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Compare(b.Interface().(time.Time))
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return cmp.Compare(boolToInt(!a.IsNil()), boolToInt(!b.IsNil()))
+		}
+
+		return compareValues(a.Elem(), b.Elem())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	case reflect.Array, reflect.Slice:
+		if a.Type().Elem().Kind() == reflect.Uint8 {
+			return bytes.Compare(bytesOf(a), bytesOf(b))
+		}
+	}
+
+	return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+}
+
+// bytesOf returns the bytes of the given byte array or slice, e.g. a UUID or
+// an IP address.
+func bytesOf(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+
+	return b
+}
+
+// boolToInt returns 1 for true, and 0 for false.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// BulkInsert inserts the given items in chunks of up to chunkSize rows, so that
+// the number of parameters of a single query stays below [MaxQueryParams]. If
+// chunkSize is not positive, the chunk size is derived via [ChunkSize].
+//
+// The apply func configures the insert query of each chunk, e.g. by specifying
+// the ON CONFLICT clause. All chunks are inserted within a single transaction
+// using the statement timeout for write operations, see [RunWithWriteTimeout],
+// and the total number of affected rows is returned. The insert of each chunk
+// is traced with a separate span.
+//
+// The items are sorted in-place via [SortByUniqueKey] before inserting them,
+// so that concurrent upserts of the same rows do not deadlock.
+func BulkInsert[T any](
+	ctx context.Context,
+	db bun.IDB,
+	items []T,
+	apply func(q *bun.InsertQuery) *bun.InsertQuery,
+	chunkSize int,
+) (int64, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	if chunkSize <= 0 {
+		chunkSize = ChunkSize[T](db)
+	}
+
+	SortByUniqueKey(db, items)
+
+	table := tableName[T](db)
+	var count int64
+	err := RunWithWriteTimeout(ctx, db, func(ctx context.Context, tx bun.Tx) error {
+		for start := 0; start < len(items); start += chunkSize {
+			chunk := items[start:min(start+chunkSize, len(items))]
+			spanCtx, span := tracing.Start(
+				ctx,
+				"db.insert "+table,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("db.system", "postgresql"),
+					attribute.String("db.collection.name", table),
+					attribute.Int("db.rows", len(chunk)),
+				),
+			)
+			out, err := tx.NewInsert().
+				Model(&chunk).
+				Apply(apply).
+				Exec(spanCtx)
+
+			tracing.End(span, err)
+			if err != nil {
+				return err
+			}
+
+			n, err := out.RowsAffected()
+			if err != nil {
+				return err
+			}
+			count += n
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// immutableColumns specifies the columns, which are not updated by
+// [UpsertAllColumns]. The last_seen_at and deleted_at columns are maintained
+// by [Reconcile].
+var immutableColumns = []string{
+	"created_at",
+	"last_seen_at",
+	"deleted_at",
+}
+
+// UpsertColumns returns the columns of the given model, which are updated on
+// conflict by [UpsertAllColumns]. The primary keys, the columns of unique
+// constraints and the immutable columns such as created_at are excluded.
+func UpsertColumns(db bun.IDB, model any) []string {
+	typ := reflect.TypeOf(model)
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+
+	table := db.Dialect().Tables().Get(typ)
+	keys := make(map[string]bool)
+	for _, fields := range table.Unique {
+		for _, field := range fields {
+			keys[field.Name] = true
+		}
+	}
+
+	columns := make([]string, 0, len(table.DataFields))
+	for _, field := range table.DataFields {
+		if keys[field.Name] || slices.Contains(immutableColumns, field.Name) {
+			continue
+		}
+		columns = append(columns, field.Name)
+	}
+
+	return columns
+}
+
+// UpsertAllColumns configures the given insert query to update all columns of
+// the given model on conflict, as returned by [UpsertColumns], so that
+// collectors don't have to list the columns by hand. The query is expected to
+// specify the conflict target via ON CONFLICT ... DO UPDATE.
+func UpsertAllColumns(q *bun.InsertQuery, model any) *bun.InsertQuery {
+	for _, column := range UpsertColumns(q.DB(), model) {
+		q = q.Set(column + " = EXCLUDED." + column)
+	}
+
+	return q
+}
+
+// UnchangedRowRefreshInterval specifies the interval after which conflicting
+// rows are updated by [UpdateChanged], even if none of their columns has
+// changed. This keeps the updated_at column of rows, which are still being
+// collected, within the retention of the housekeeper.
+const UnchangedRowRefreshInterval = 6 * time.Hour
+
+// UpdateChanged returns a function, which configures an insert query with
+// ON CONFLICT ... DO UPDATE to update the given columns along with the
+// updated_at column. Conflicting rows are updated only when any of the given
+// columns has changed, so that the updated_at column of unchanged rows, on
+// which incremental link runs rely, is preserved. Unchanged rows are refreshed
+// once per [UnchangedRowRefreshInterval].
+//
+// Unchanged rows are not affected by the query, so the number of affected rows
+// is not the number of upserted rows.
+func UpdateChanged(columns ...string) func(q *bun.InsertQuery) *bun.InsertQuery {
+	return func(q *bun.InsertQuery) *bun.InsertQuery {
+		current := make([]string, 0, len(columns))
+		excluded := make([]string, 0, len(columns))
+		for _, column := range columns {
+			q = q.Set(column + " = EXCLUDED." + column)
+			current = append(current, "?TableAlias."+column)
+			excluded = append(excluded, "EXCLUDED."+column)
+		}
+		q = q.Set("updated_at = EXCLUDED.updated_at")
+
+		changed := fmt.Sprintf(
+			"(%s) IS DISTINCT FROM (%s)",
+			strings.Join(current, ", "),
+			strings.Join(excluded, ", "),
+		)
+
+		return q.Where(changed+" OR ?TableAlias.updated_at < ?", time.Now().Add(-UnchangedRowRefreshInterval))
+	}
+}
+
+// Conflict strategies, which specify how rows conflicting with existing rows
+// are handled by [OnConflict].
+const (
+	// ConflictStrategyUpdate updates the existing rows. This is the
+	// default strategy.
+	ConflictStrategyUpdate = "update"
+
+	// ConflictStrategyIgnore keeps the existing rows as they are, e.g.
+	// when backfilling rows, which should not overwrite enriched data.
+	ConflictStrategyIgnore = "ignore"
+)
+
+// ErrUnknownConflictStrategy is an error, which is returned when an unknown
+// conflict strategy is specified.
+var ErrUnknownConflictStrategy = errors.New("unknown conflict strategy")
+
+// ValidateConflictStrategy validates the given conflict strategy. An empty
+// strategy is valid and stands for [ConflictStrategyUpdate].
+func ValidateConflictStrategy(strategy string) error {
+	switch strategy {
+	case "", ConflictStrategyUpdate, ConflictStrategyIgnore:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownConflictStrategy, strategy)
+	}
+}
+
+// OnConflict configures the ON CONFLICT clause of the given insert query for
+// the given conflict target, e.g. "(name, project_id)", using the given
+// strategy. With [ConflictStrategyIgnore] conflicting rows are skipped,
+// otherwise all columns of the given model are updated via [UpsertAllColumns].
+func OnConflict(q *bun.InsertQuery, target, strategy string, model any) *bun.InsertQuery {
+	if strategy == ConflictStrategyIgnore {
+		return q.On("CONFLICT " + target + " DO NOTHING")
+	}
+
+	q = q.On("CONFLICT " + target + " DO UPDATE")
+
+	return UpsertAllColumns(q, model)
+}
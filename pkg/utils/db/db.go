@@ -5,15 +5,27 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
 
 	"github.com/gardener/inventory/pkg/core/config"
+	"github.com/gardener/inventory/pkg/metrics"
 	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
 )
 
@@ -21,11 +33,47 @@ import (
 // empty.
 var ErrInvalidDSN = errors.New("invalid or missing database configuration")
 
+const (
+	// DefaultReadStatementTimeout is the default statement timeout for
+	// read operations.
+	DefaultReadStatementTimeout = 5 * time.Minute
+
+	// DefaultWriteStatementTimeout is the default statement timeout for
+	// write operations.
+	DefaultWriteStatementTimeout = 2 * time.Minute
+
+	// sqlStateQueryCanceled is the SQLSTATE code reported by PostgreSQL
+	// when a statement has been canceled, e.g. due to statement_timeout.
+	sqlStateQueryCanceled = "57014"
+
+	// sqlStateDeadlockDetected is the SQLSTATE code reported by PostgreSQL
+	// when a transaction has been aborted in order to resolve a deadlock.
+	sqlStateDeadlockDetected = "40P01"
+
+	// DefaultDeadlockRetries specifies the max number of attempts for
+	// running a link function, which is aborted because of a deadlock.
+	DefaultDeadlockRetries = 3
+)
+
+// StatementTimeouts specifies the statement timeouts for read and write
+// operations.
+type StatementTimeouts struct {
+	// Read specifies the statement timeout for read operations.
+	Read time.Duration
+
+	// Write specifies the statement timeout for write operations.
+	Write time.Duration
+}
+
+// statementTimeoutsKey is the context key for the [StatementTimeouts] used by
+// [RunWithReadTimeout] and [RunWithWriteTimeout].
+type statementTimeoutsKey struct{}
+
 // NewFromConfig creates a new [bun.DB] based on the provided
 // [config.DatabaseConfig] spec.
 func NewFromConfig(conf config.DatabaseConfig) (*bun.DB, error) {
-	if conf.DSN == "" {
-		return nil, ErrInvalidDSN
+	if err := ValidateDSN(conf.DSN); err != nil {
+		return nil, err
 	}
 
 	pgdb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(conf.DSN)))
@@ -34,19 +82,337 @@ func NewFromConfig(conf config.DatabaseConfig) (*bun.DB, error) {
 	return db, nil
 }
 
+// ValidateDSN validates that the given DSN is a URL, which is supported by the
+// PostgreSQL driver.
+func ValidateDSN(dsn string) error {
+	if dsn == "" {
+		return ErrInvalidDSN
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDSN, err)
+	}
+
+	switch u.Scheme {
+	case "postgres", "postgresql":
+		return nil
+	case "unix":
+		if u.Path == "" {
+			return fmt.Errorf("%w: unix socket requires a path", ErrInvalidDSN)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported scheme %q", ErrInvalidDSN, u.Scheme)
+	}
+}
+
+// WithStatementTimeouts returns a new [context.Context], which carries the
+// given [StatementTimeouts]. Zero values are replaced with the respective
+// default timeouts.
+func WithStatementTimeouts(ctx context.Context, timeouts StatementTimeouts) context.Context {
+	if timeouts.Read <= 0 {
+		timeouts.Read = DefaultReadStatementTimeout
+	}
+	if timeouts.Write <= 0 {
+		timeouts.Write = DefaultWriteStatementTimeout
+	}
+
+	return context.WithValue(ctx, statementTimeoutsKey{}, timeouts)
+}
+
+// GetStatementTimeouts returns the [StatementTimeouts] from the given
+// [context.Context], or the default timeouts, if the context does not carry
+// any.
+func GetStatementTimeouts(ctx context.Context) StatementTimeouts {
+	timeouts, ok := ctx.Value(statementTimeoutsKey{}).(StatementTimeouts)
+	if !ok {
+		return StatementTimeouts{
+			Read:  DefaultReadStatementTimeout,
+			Write: DefaultWriteStatementTimeout,
+		}
+	}
+
+	return timeouts
+}
+
+// NewStatementTimeoutsMiddleware returns a new [asynq.MiddlewareFunc], which
+// configures the statement timeouts of the given [config.DatabaseConfig] for
+// the task handlers via [WithStatementTimeouts].
+func NewStatementTimeoutsMiddleware(conf config.DatabaseConfig) asynq.MiddlewareFunc {
+	timeouts := StatementTimeouts{
+		Read:  conf.ReadStatementTimeout,
+		Write: conf.WriteStatementTimeout,
+	}
+
+	middleware := func(handler asynq.Handler) asynq.Handler {
+		mw := func(ctx context.Context, task *asynq.Task) error {
+			return handler.ProcessTask(WithStatementTimeouts(ctx, timeouts), task)
+		}
+
+		return asynq.HandlerFunc(mw)
+	}
+
+	return asynq.MiddlewareFunc(middleware)
+}
+
+// RunWithStatementTimeout runs the given function within a transaction, in
+// which the statement timeout is set to the given value via SET LOCAL. The
+// timeout applies only to the statements executed by the function using the
+// provided transaction.
+func RunWithStatementTimeout(ctx context.Context, db bun.IDB, timeout time.Duration, fn func(ctx context.Context, tx bun.Tx) error) error {
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SET LOCAL statement_timeout = ?", timeout.Milliseconds()); err != nil {
+			return err
+		}
+
+		return fn(ctx, tx)
+	})
+}
+
+// RunWithReadTimeout runs the given function within a transaction using the
+// statement timeout for read operations, as returned by
+// [GetStatementTimeouts].
+func RunWithReadTimeout(ctx context.Context, db bun.IDB, fn func(ctx context.Context, tx bun.Tx) error) error {
+	return RunWithStatementTimeout(ctx, db, GetStatementTimeouts(ctx).Read, fn)
+}
+
+// RunWithWriteTimeout runs the given function within a transaction using the
+// statement timeout for write operations, as returned by
+// [GetStatementTimeouts].
+func RunWithWriteTimeout(ctx context.Context, db bun.IDB, fn func(ctx context.Context, tx bun.Tx) error) error {
+	return RunWithStatementTimeout(ctx, db, GetStatementTimeouts(ctx).Write, fn)
+}
+
+// linkTx is a [bun.Tx] used by [LinkObjects], which switches the statement
+// timeout of the transaction from the read timeout to the write timeout, once
+// the link function starts creating write queries. This way the read timeout
+// applies to the queries, which select the objects to link, and the write
+// timeout applies to the queries, which upsert the links.
+type linkTx struct {
+	bun.Tx
+
+	ctx   context.Context
+	write time.Duration
+
+	// isWriting specifies whether the write timeout is in effect.
+	isWriting bool
+
+	// err is the error, if any, returned when switching the timeout.
+	err error
+}
+
+// useWriteTimeout switches the statement timeout of the transaction to the
+// write timeout, if not done already.
+func (tx *linkTx) useWriteTimeout() {
+	if tx.isWriting {
+		return
+	}
+
+	tx.isWriting = true
+	_, tx.err = tx.Tx.ExecContext(tx.ctx, "SET LOCAL statement_timeout = ?", tx.write.Milliseconds())
+}
+
+// NewInsert implements the [bun.IDB] interface.
+func (tx *linkTx) NewInsert() *bun.InsertQuery {
+	tx.useWriteTimeout()
+
+	return tx.Tx.NewInsert()
+}
+
+// NewUpdate implements the [bun.IDB] interface.
+func (tx *linkTx) NewUpdate() *bun.UpdateQuery {
+	tx.useWriteTimeout()
+
+	return tx.Tx.NewUpdate()
+}
+
+// NewDelete implements the [bun.IDB] interface.
+func (tx *linkTx) NewDelete() *bun.DeleteQuery {
+	tx.useWriteTimeout()
+
+	return tx.Tx.NewDelete()
+}
+
+// sqlStateError is implemented by errors, which carry the fields of a
+// PostgreSQL error response, e.g. [pgdriver.Error].
+type sqlStateError interface {
+	error
+	Field(k byte) string
+}
+
+// sqlState returns the SQLSTATE code of the given error, or an empty string
+// if the error is not a PostgreSQL error.
+func sqlState(err error) string {
+	var pgErr sqlStateError
+	if !errors.As(err, &pgErr) {
+		return ""
+	}
+
+	return pgErr.Field('C')
+}
+
+// IsStatementTimeout returns true, if the given error was caused by a
+// statement, which has been canceled by the database server, e.g. because it
+// exceeded the statement timeout. Such errors are transient and the
+// operation can be retried.
+func IsStatementTimeout(err error) bool {
+	return sqlState(err) == sqlStateQueryCanceled
+}
+
+// IsDeadlock returns true, if the given error was caused by a transaction,
+// which has been aborted by the database server in order to resolve a
+// deadlock. The aborted transaction can be retried.
+func IsDeadlock(err error) bool {
+	return sqlState(err) == sqlStateDeadlockDetected
+}
+
+// RetryOnDeadlock calls fn up to the given number of attempts, for as long as
+// it fails because of a deadlock. The onDeadlock function, if not nil, is
+// called after each deadlock.
+func RetryOnDeadlock(ctx context.Context, attempts int, fn func() error, onDeadlock func(err error)) error {
+	var err error
+	for range max(attempts, 1) {
+		err = fn()
+		if !IsDeadlock(err) {
+			return err
+		}
+
+		if onDeadlock != nil {
+			onDeadlock(err)
+		}
+
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
 // LinkFunction is a function, which establishes relationships between models.
-type LinkFunction func(ctx context.Context, db *bun.DB) error
+// Link functions report the number of established links via [AddLinks].
+type LinkFunction func(ctx context.Context, db bun.IDB) error
+
+// linkRecorderKey is the context key for the [linkRecorder] of the link
+// function being executed by [LinkObjects].
+type linkRecorderKey struct{}
+
+// linkRecorder records the number of links established by a link function.
+type linkRecorder struct {
+	name  string
+	count atomic.Int64
+}
+
+// AddLinks adds the given number of established links to the link function
+// associated with the context.
+func AddLinks(ctx context.Context, n int64) {
+	if recorder, ok := ctx.Value(linkRecorderKey{}).(*linkRecorder); ok {
+		recorder.count.Add(n)
+	}
+}
+
+// LinkFunctionName returns the name of the given [LinkFunction], relative to
+// the pkg directory of the module, e.g. aws/tasks.LinkInstancesWithRegion.
+func LinkFunctionName(fn LinkFunction) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "unknown"
+	}
+
+	return strings.TrimPrefix(f.Name(), "github.com/gardener/inventory/pkg/")
+}
+
+// setLinkFunctionName sets the name of the link function associated with the
+// context. It is used by link function wrappers, so that the links are
+// reported under the name of the wrapped link function.
+func setLinkFunctionName(ctx context.Context, fn LinkFunction) {
+	if recorder, ok := ctx.Value(linkRecorderKey{}).(*linkRecorder); ok {
+		recorder.name = LinkFunctionName(fn)
+	}
+}
 
-// LinkObjects links objects by using the provided [LinkFunction] items.
+// LinkObjects links objects by using the provided [LinkFunction] items. Each
+// link function is executed within a separate transaction. The statement
+// timeout for read operations applies to the queries selecting the objects to
+// link, so that a pathological join cannot hold a database connection
+// indefinitely, and the statement timeout for write operations applies to the
+// queries upserting the links.
+//
+// The number of links established by each successful link function is
+// reported via the [metrics.LinksTotal] metric.
+//
+// A link function, which is aborted because of a deadlock, is retried up to
+// [DefaultDeadlockRetries] times. Each deadlock is reported via the
+// [metrics.LinkDeadlocksTotal] metric.
+//
+// Errors returned by link functions are logged, and the rest of the link
+// functions are executed. If any of the link functions fails due to a
+// statement timeout, an error is returned, so that the task can be retried.
 func LinkObjects(ctx context.Context, db *bun.DB, items []LinkFunction) error {
 	logger := asynqutils.GetLogger(ctx)
+	var timeoutErrs []error
 	for _, linkFunc := range items {
-		if err := linkFunc(ctx, db); err != nil {
-			logger.Error("failed to link objects", "reason", err)
+		recorder := &linkRecorder{name: LinkFunctionName(linkFunc)}
+		linkCtx := context.WithValue(ctx, linkRecorderKey{}, recorder)
+		err := RetryOnDeadlock(ctx, DefaultDeadlockRetries, func() error {
+			recorder.count.Store(0)
+
+			return RunWithReadTimeout(linkCtx, db, func(ctx context.Context, tx bun.Tx) error {
+				ltx := &linkTx{
+					Tx:    tx,
+					ctx:   ctx,
+					write: GetStatementTimeouts(ctx).Write,
+				}
+				err := linkFunc(ctx, ltx)
+				if ltx.err != nil {
+					return ltx.err
+				}
+
+				return err
+			})
+		}, func(err error) {
+			logger.Warn("link function aborted because of a deadlock", "link_type", recorder.name, "reason", err)
+			metrics.LinkDeadlocksTotal.WithLabelValues(recorder.name).Inc()
+		})
+
+		if err != nil {
+			logger.Error("failed to link objects", "link_type", recorder.name, "reason", err)
+			if IsStatementTimeout(err) {
+				timeoutErrs = append(timeoutErrs, err)
+			}
 
 			continue
 		}
+
+		metrics.LinksTotal.WithLabelValues(recorder.name).Set(float64(recorder.count.Load()))
 	}
 
-	return nil
+	return errors.Join(timeoutErrs...)
+}
+
+// SortLinks sorts the given link models in-place by the key returned from
+// keyFunc. The key is expected to contain the values of the conflict key
+// columns of the link table in the same order as they are used in the ON
+// CONFLICT clause.
+//
+// Inserting links in a consistent order ensures that concurrent transactions
+// upserting into the same link table acquire row locks in the same order,
+// which prevents deadlocks between them.
+//
+// Links inserted via [BulkInsert] need not be sorted beforehand, since
+// [BulkInsert] sorts them by the unique constraints of their model.
+func SortLinks[T any](items []T, keyFunc func(item T) []uuid.UUID) {
+	slices.SortFunc(items, func(a, b T) int {
+		keyA := keyFunc(a)
+		keyB := keyFunc(b)
+		for i := range min(len(keyA), len(keyB)) {
+			if c := bytes.Compare(keyA[i][:], keyB[i][:]); c != 0 {
+				return c
+			}
+		}
+
+		return len(keyA) - len(keyB)
+	})
 }
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// ForeignKey represents a foreign key constraint between two tables.
+type ForeignKey struct {
+	// Table specifies the table, which defines the constraint, e.g. a link
+	// table.
+	Table string `bun:"table_name"`
+
+	// ReferencedTable specifies the table, which is referenced by the
+	// constraint.
+	ReferencedTable string `bun:"referenced_table_name"`
+}
+
+// GetForeignKeys returns the foreign key constraints between the tables in the
+// current schema.
+func GetForeignKeys(ctx context.Context, db bun.IDB) ([]ForeignKey, error) {
+	items := make([]ForeignKey, 0)
+	err := db.NewSelect().
+		TableExpr("pg_constraint AS c").
+		Join("INNER JOIN pg_class AS t ON t.oid = c.conrelid").
+		Join("INNER JOIN pg_class AS r ON r.oid = c.confrelid").
+		Join("INNER JOIN pg_namespace AS n ON n.oid = t.relnamespace").
+		ColumnExpr("t.relname AS table_name").
+		ColumnExpr("r.relname AS referenced_table_name").
+		Where("c.contype = 'f'").
+		Where("n.nspname = current_schema()").
+		Scan(ctx, &items)
+
+	return items, err
+}
+
+// DeletionOrder sorts the given tables in an order, which is safe for deleting
+// rows from them. Tables, which reference other tables via the given foreign
+// keys, e.g. link tables, come before the tables they reference, so that
+// rows are deleted without leaving orphan join rows behind.
+//
+// The relative order of independent tables is preserved, and duplicate tables
+// are returned only once. Tables, which are part of a reference cycle, are
+// appended in their original order.
+func DeletionOrder(items []string, foreignKeys []ForeignKey) []string {
+	known := make(map[string]struct{}, len(items))
+	tables := make([]string, 0, len(items))
+	for _, table := range items {
+		if _, ok := known[table]; ok {
+			continue
+		}
+		known[table] = struct{}{}
+		tables = append(tables, table)
+	}
+
+	// Number of tables still referencing a given table
+	referencedBy := make(map[string]int)
+	references := make(map[string][]string)
+	for _, fk := range foreignKeys {
+		if fk.Table == fk.ReferencedTable {
+			continue
+		}
+		if _, ok := known[fk.Table]; !ok {
+			continue
+		}
+		if _, ok := known[fk.ReferencedTable]; !ok {
+			continue
+		}
+		referencedBy[fk.ReferencedTable]++
+		references[fk.Table] = append(references[fk.Table], fk.ReferencedTable)
+	}
+
+	result := make([]string, 0, len(tables))
+	done := make(map[string]struct{}, len(tables))
+	for len(result) < len(tables) {
+		progress := false
+		for _, table := range tables {
+			if _, ok := done[table]; ok {
+				continue
+			}
+			if referencedBy[table] > 0 {
+				continue
+			}
+
+			done[table] = struct{}{}
+			result = append(result, table)
+			progress = true
+			for _, ref := range references[table] {
+				referencedBy[ref]--
+			}
+		}
+
+		if progress {
+			continue
+		}
+
+		// Reference cycle, append the remaining tables as they are
+		for _, table := range tables {
+			if _, ok := done[table]; !ok {
+				done[table] = struct{}{}
+				result = append(result, table)
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	auxmodels "github.com/gardener/inventory/pkg/auxiliary/models"
+	"github.com/gardener/inventory/pkg/core/config"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+const (
+	// DefaultFullRebuildInterval is the default interval after which all
+	// links of a relationship are rebuilt, when incremental link runs are
+	// enabled.
+	DefaultFullRebuildInterval = 24 * time.Hour
+
+	// incrementalLinkOverlap specifies how far back before the last link
+	// run source rows are processed again. This accounts for rows, which
+	// were updated by transactions, which were still in progress during the
+	// last link run.
+	incrementalLinkOverlap = 15 * time.Minute
+)
+
+// linkSinceKey is the context key, which specifies the time since which link
+// functions process source rows.
+type linkSinceKey struct{}
+
+// incrementalLinks specifies the settings used by [Incremental].
+var incrementalLinks = struct {
+	sync.Mutex
+	conf config.IncrementalLinksConfig
+}{}
+
+// ConfigureIncrementalLinks configures the settings used by [Incremental].
+func ConfigureIncrementalLinks(conf config.IncrementalLinksConfig) {
+	if conf.FullRebuildInterval <= 0 {
+		conf.FullRebuildInterval = DefaultFullRebuildInterval
+	}
+
+	incrementalLinks.Lock()
+	defer incrementalLinks.Unlock()
+	incrementalLinks.conf = conf
+}
+
+// LinkSince returns the time since which the link function should process
+// source rows. A zero time means that all source rows should be processed.
+func LinkSince(ctx context.Context) time.Time {
+	since, _ := ctx.Value(linkSinceKey{}).(time.Time)
+
+	return since
+}
+
+// UpdatedSince returns a function, which restricts a select query to the rows
+// updated since the time returned by [LinkSince]. The rows of the given joined
+// relations are considered as well, so that links are updated when either side
+// of the relationship has changed. The query is not modified, when all source
+// rows should be processed.
+func UpdatedSince(ctx context.Context, relations ...string) func(q *bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		since := LinkSince(ctx)
+		if since.IsZero() {
+			return q
+		}
+
+		return q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			q = q.Where("?TableAlias.updated_at >= ?", since)
+			for _, relation := range relations {
+				q = q.WhereOr("?.updated_at >= ?", bun.Ident(relation), since)
+			}
+
+			return q
+		})
+	}
+}
+
+// Incremental wraps the given [LinkFunction], so that it processes only the
+// source rows updated since the last successful run of the relationship with
+// the given name, when incremental link runs are enabled. The link function is
+// expected to restrict its source rows via [UpdatedSince].
+//
+// All links are rebuilt on the first run of a relationship, after the
+// configured full rebuild interval has passed, or when full rebuilds are
+// forced via the configuration.
+func Incremental(name string, fn LinkFunction) LinkFunction {
+	return func(ctx context.Context, db bun.IDB) error {
+		setLinkFunctionName(ctx, fn)
+
+		incrementalLinks.Lock()
+		conf := incrementalLinks.conf
+		incrementalLinks.Unlock()
+
+		if !conf.IsEnabled {
+			return fn(ctx, db)
+		}
+
+		// Use the database time in order to be consistent with the
+		// timestamps of the source rows.
+		var now time.Time
+		if err := db.NewSelect().ColumnExpr("now()").Scan(ctx, &now); err != nil {
+			return err
+		}
+
+		var run auxmodels.LinkRun
+		err := db.NewSelect().
+			Model(&run).
+			Where("name = ?", name).
+			Scan(ctx)
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			run = auxmodels.LinkRun{Name: name}
+		case err != nil:
+			return err
+		}
+
+		var since time.Time
+		isFull := conf.ForceFullRebuild || run.LastFullRunAt.IsZero() || now.Sub(run.LastFullRunAt) >= conf.FullRebuildInterval
+		if !isFull {
+			since = run.LastRunAt.Add(-incrementalLinkOverlap)
+		}
+
+		logger := asynqutils.GetLogger(ctx)
+		logger.Debug("linking relationship", "name", name, "full", isFull, "since", since)
+
+		if err := fn(context.WithValue(ctx, linkSinceKey{}, since), db); err != nil {
+			return err
+		}
+
+		run.LastRunAt = now
+		run.UpdatedAt = now
+		if isFull {
+			run.LastFullRunAt = now
+		}
+
+		_, err = db.NewInsert().
+			Model(&run).
+			On("CONFLICT (name) DO UPDATE").
+			Set("last_run_at = EXCLUDED.last_run_at").
+			Set("last_full_run_at = EXCLUDED.last_full_run_at").
+			Set("updated_at = EXCLUDED.updated_at").
+			Exec(ctx)
+
+		return err
+	}
+}
+
+// LastSuccessfulRun returns the time when the last successful run of the task
+// with the given name, account and region was started, as recorded in the
+// collection runs. A zero time is returned, if no successful run has been
+// recorded.
+func LastSuccessfulRun(ctx context.Context, db bun.IDB, taskName, account, region string) (time.Time, error) {
+	var run auxmodels.CollectionRun
+	err := db.NewSelect().
+		Model(&run).
+		Where("task_name = ?", taskName).
+		Where("account = ?", account).
+		Where("region = ?", region).
+		Where("status = ?", asynqutils.TaskStatusSucceeded).
+		Order("completed_at DESC").
+		Limit(1).
+		Scan(ctx)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return time.Time{}, nil
+	case err != nil:
+		return time.Time{}, err
+	}
+
+	return run.StartedAt, nil
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ErrEmptyScope is returned by [Reconcile], when no scope is specified, in
+// order to prevent marking the records of all scopes as deleted.
+var ErrEmptyScope = errors.New("reconcile scope is empty")
+
+// ReconcileResult provides the number of records updated by [Reconcile].
+type ReconcileResult struct {
+	// Seen is the number of records marked as seen.
+	Seen int64
+
+	// Deleted is the number of records marked as deleted.
+	Deleted int64
+}
+
+// Reconcile updates the soft-delete columns of the records of model T, which
+// belong to the given scope, after a successful collection. The scope maps
+// column names to values, e.g. the account id and region of a collection.
+//
+// Records, whose idColumn is one of the seen ids have their last_seen_at set
+// to the current time, and their deleted_at cleared, in case they reappeared.
+// The remaining records of the scope, which are not yet marked as deleted,
+// have their deleted_at set to the current time.
+//
+// An empty list of seen ids marks all records of the scope as deleted, so
+// Reconcile must be called only after the collection of the whole scope
+// succeeded.
+func Reconcile[T any, K any](
+	ctx context.Context,
+	db bun.IDB,
+	scope map[string]any,
+	idColumn string,
+	seen []K,
+) (ReconcileResult, error) {
+	return reconcile[T](ctx, db, scope, "?", []any{bun.Ident(idColumn)}, bun.In(seen), len(seen))
+}
+
+// ReconcileKeys is like [Reconcile], but identifies the records of the scope
+// by the values of multiple columns, e.g. the name and zone of a disk, which
+// is unique only within its zone. Each of the seen keys provides the values of
+// the idColumns in the same order.
+func ReconcileKeys[T any](
+	ctx context.Context,
+	db bun.IDB,
+	scope map[string]any,
+	idColumns []string,
+	seen [][]any,
+) (ReconcileResult, error) {
+	placeholders := make([]string, 0, len(idColumns))
+	idents := make([]any, 0, len(idColumns))
+	for _, column := range idColumns {
+		placeholders = append(placeholders, "?")
+		idents = append(idents, bun.Ident(column))
+	}
+	idExpr := "(" + strings.Join(placeholders, ", ") + ")"
+
+	return reconcile[T](ctx, db, scope, idExpr, idents, bun.In(seen), len(seen))
+}
+
+// reconcile implements [Reconcile] and [ReconcileKeys] for the given id
+// expression and its arguments, and the seen ids formatted via [bun.In].
+func reconcile[T any](
+	ctx context.Context,
+	db bun.IDB,
+	scope map[string]any,
+	idExpr string,
+	idArgs []any,
+	seen any,
+	numSeen int,
+) (ReconcileResult, error) {
+	var result ReconcileResult
+	if len(scope) == 0 {
+		return result, ErrEmptyScope
+	}
+
+	now := time.Now()
+	withScope := func(q *bun.UpdateQuery) *bun.UpdateQuery {
+		for _, column := range slices.Sorted(maps.Keys(scope)) {
+			q = q.Where("? = ?", bun.Ident(column), scope[column])
+		}
+
+		return q
+	}
+	seenArgs := append(slices.Clone(idArgs), seen)
+
+	err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if numSeen > 0 {
+			out, err := tx.NewUpdate().
+				Model((*T)(nil)).
+				Set("last_seen_at = ?", now).
+				Set("deleted_at = NULL").
+				Where(idExpr+" IN (?)", seenArgs...).
+				Apply(withScope).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+			if result.Seen, err = out.RowsAffected(); err != nil {
+				return err
+			}
+		}
+
+		q := tx.NewUpdate().
+			Model((*T)(nil)).
+			Set("deleted_at = ?", now).
+			Where("deleted_at IS NULL").
+			Apply(withScope)
+		if numSeen > 0 {
+			q = q.Where(idExpr+" NOT IN (?)", seenArgs...)
+		}
+
+		out, err := q.Exec(ctx)
+		if err != nil {
+			return err
+		}
+		result.Deleted, err = out.RowsAffected()
+
+		return err
+	})
+
+	return result, err
+}
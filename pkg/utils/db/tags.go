@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	// TagsColumn is the name of the column, which holds the generic tag
+	// set of a resource.
+	TagsColumn = "tags"
+
+	// promotedTagColumnPrefix is the prefix of the columns, which hold
+	// the values of promoted tag keys.
+	promotedTagColumnPrefix = "tag_"
+)
+
+// ErrInvalidPromotedTag is an error, which is returned when a promoted tag key
+// does not contain any characters allowed in a column name.
+var ErrInvalidPromotedTag = errors.New("invalid promoted tag key")
+
+// ErrPromotedTagCollision is an error, which is returned when a promoted tag
+// key is sanitized to the name of a column, which is used by another key.
+var ErrPromotedTagCollision = errors.New("promoted tag column collision")
+
+// invalidColumnCharsRegex matches the characters, which are not allowed in
+// the name of a promoted tag column.
+var invalidColumnCharsRegex = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// PromotedTagColumn returns the name of the column, which holds the value of
+// the given promoted tag key, e.g. `tag_owner' for the `owner' key.
+func PromotedTagColumn(key string) string {
+	name := invalidColumnCharsRegex.ReplaceAllString(strings.ToLower(key), "_")
+
+	return promotedTagColumnPrefix + strings.Trim(name, "_")
+}
+
+// promotedTagComment returns the comment of the column, which holds the value
+// of the given promoted tag key. The comment records the key, from which the
+// column was created.
+func promotedTagComment(key string) string {
+	return "promoted tag: " + key
+}
+
+// promotedTagColumn represents an existing promoted tag column.
+type promotedTagColumn struct {
+	// Comment specifies the comment of the column.
+	Comment sql.NullString `bun:"comment"`
+
+	// HasKey specifies whether the generation expression refers to the
+	// promoted tag key.
+	HasKey bool `bun:"has_key"`
+}
+
+// PromotedTagColumns returns the columns of the given promoted tag keys, keyed
+// by the name of the column. It returns an error, if a key is invalid, or if
+// the names of multiple keys are sanitized to the same column, e.g.
+// `cost-center' and `cost_center'.
+func PromotedTagColumns(keys []string) (map[string]string, error) {
+	columns := make(map[string]string, len(keys))
+	for _, key := range keys {
+		column := PromotedTagColumn(key)
+		if column == promotedTagColumnPrefix {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPromotedTag, key)
+		}
+
+		if other, ok := columns[column]; ok && other != key {
+			return nil, fmt.Errorf("%w: %q and %q are promoted to %s", ErrPromotedTagCollision, other, key, column)
+		}
+		columns[column] = key
+	}
+
+	return columns, nil
+}
+
+// PromoteTags materializes the values of the given tag keys from the generic
+// tag set of the table into separate indexed columns, so that filtering by
+// them is fast. The columns are generated by the database from the tag set,
+// and are therefore populated whenever the tags of a resource are collected.
+//
+// The key of each promoted column is recorded in the comment of the column.
+// PromoteTags returns an error without changing the table, if a column for a
+// key already exists, but was not created for the same key.
+func PromoteTags(ctx context.Context, db bun.IDB, table string, keys []string) error {
+	columns, err := PromotedTagColumns(keys)
+	if err != nil {
+		return err
+	}
+
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, column := range slices.Sorted(maps.Keys(columns)) {
+			key := columns[column]
+			var existing []promotedTagColumn
+			err := tx.NewRaw(
+				"SELECT col_description(a.attrelid, a.attnum) AS comment, position(quote_literal(?) IN coalesce(pg_get_expr(d.adbin, d.adrelid), '')) > 0 AS has_key "+
+					"FROM pg_attribute AS a LEFT JOIN pg_attrdef AS d ON d.adrelid = a.attrelid AND d.adnum = a.attnum "+
+					"WHERE a.attrelid = ?::regclass AND a.attname = ? AND NOT a.attisdropped",
+				key,
+				table,
+				column,
+			).Scan(ctx, &existing)
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", table, err)
+			}
+
+			if len(existing) > 0 {
+				c := existing[0]
+				if c.Comment.Valid && c.Comment.String == promotedTagComment(key) {
+					continue
+				}
+
+				// Columns promoted before the key was recorded in
+				// the comment are matched by the generation
+				// expression instead.
+				if c.Comment.Valid || !c.HasKey {
+					return fmt.Errorf("%w: column %s of table %s exists, but was not created for key %q", ErrPromotedTagCollision, column, table, key)
+				}
+			} else {
+				_, err = tx.NewRaw(
+					"ALTER TABLE ? ADD COLUMN ? VARCHAR GENERATED ALWAYS AS (? ->> ?) STORED",
+					bun.Ident(table),
+					bun.Ident(column),
+					bun.Ident(TagsColumn),
+					key,
+				).Exec(ctx)
+
+				if err != nil {
+					return fmt.Errorf("%s: %w", table, err)
+				}
+			}
+
+			_, err = tx.NewRaw(
+				"COMMENT ON COLUMN ?.? IS ?",
+				bun.Ident(table),
+				bun.Ident(column),
+				promotedTagComment(key),
+			).Exec(ctx)
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", table, err)
+			}
+
+			index := fmt.Sprintf("%s_%s_idx", table, column)
+			_, err = tx.NewRaw(
+				"CREATE INDEX IF NOT EXISTS ? ON ? (?)",
+				bun.Ident(index),
+				bun.Ident(table),
+				bun.Ident(column),
+			).Exec(ctx)
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", table, err)
+			}
+		}
+
+		return nil
+	})
+}
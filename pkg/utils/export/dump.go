@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+// ManifestFile is the name of the manifest file, which is written by [Dump].
+const ManifestFile = "manifest.json"
+
+// updatedAtColumn is the column, which is used for filtering records updated
+// after a given timestamp.
+const updatedAtColumn = "updated_at"
+
+// ErrUnknownTable is returned when filtering tables, which are not backed by a
+// registered model.
+var ErrUnknownTable = errors.New("unknown table")
+
+// Table describes a table backed by a model from the
+// [registry.ModelRegistry].
+type Table struct {
+	// Name is the name of the table.
+	Name string
+
+	// Model is the name of the model.
+	Model string
+
+	// Value is the model as registered in the [registry.ModelRegistry].
+	Value any
+}
+
+// Manifest describes the files written by [Dump].
+type Manifest struct {
+	// ExportedAt specifies when the export has been started.
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Since specifies the timestamp after which records have been updated
+	// in order to be exported, if set.
+	Since *time.Time `json:"since,omitempty"`
+
+	// Tables provides the exported tables.
+	Tables []ManifestEntry `json:"tables"`
+}
+
+// ManifestEntry describes a single exported table.
+type ManifestEntry struct {
+	// Table is the name of the table.
+	Table string `json:"table"`
+
+	// Model is the name of the model.
+	Model string `json:"model"`
+
+	// File is the name of the NDJSON file, relative to the manifest.
+	File string `json:"file"`
+
+	// Count is the number of exported records.
+	Count int `json:"count"`
+}
+
+// DumpOptions provides the options for [Dump].
+type DumpOptions struct {
+	// Tables specifies the tables to export. If empty, all tables are
+	// exported.
+	Tables []string
+
+	// Since, if set, restricts the export to records updated at or after
+	// the given timestamp. Tables without an `updated_at' column are
+	// exported in full.
+	Since time.Time
+
+	// FlushInterval specifies the number of records after which buffered
+	// data is flushed to the files.
+	FlushInterval int
+}
+
+// Tables returns the tables of the models from the [registry.ModelRegistry],
+// sorted by name.
+func Tables(db *bun.DB) ([]Table, error) {
+	result := make([]Table, 0, registry.ModelRegistry.Length())
+	walker := func(name string, model any) error {
+		table := db.Table(reflect.TypeOf(model))
+		item := Table{
+			Name:  table.Name,
+			Model: name,
+			Value: model,
+		}
+		result = append(result, item)
+
+		return nil
+	}
+
+	if err := registry.ModelRegistry.Range(walker); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(result, func(a, b Table) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+// FilterTables returns the tables with the given names, preserving the order
+// of the given tables. If no names are specified, all tables are returned.
+// [ErrUnknownTable] is returned for names, which do not refer to any of the
+// given tables.
+func FilterTables(tables []Table, names []string) ([]Table, error) {
+	if len(names) == 0 {
+		return tables, nil
+	}
+
+	for _, name := range names {
+		found := slices.ContainsFunc(tables, func(t Table) bool {
+			return t.Name == name
+		})
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTable, name)
+		}
+	}
+
+	result := make([]Table, 0, len(names))
+	for _, t := range tables {
+		if slices.Contains(names, t.Name) {
+			result = append(result, t)
+		}
+	}
+
+	return result, nil
+}
+
+// Dump exports the records of the given tables to the dir directory, which is
+// created, if it does not exist. The records of each table are streamed to a
+// separate NDJSON file named after the table, and a [ManifestFile] describing
+// the exported files is written last.
+func Dump(ctx context.Context, db *bun.DB, tables []Table, dir string, opts DumpOptions) (Manifest, error) {
+	manifest := Manifest{
+		ExportedAt: time.Now().UTC(),
+		Tables:     make([]ManifestEntry, 0, len(tables)),
+	}
+	if !opts.Since.IsZero() {
+		since := opts.Since.UTC()
+		manifest.Since = &since
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return manifest, err
+	}
+
+	for _, table := range tables {
+		entry, err := dumpTable(ctx, db, table, dir, opts)
+		if err != nil {
+			return manifest, fmt.Errorf("cannot export table %s: %w", table.Name, err)
+		}
+		manifest.Tables = append(manifest.Tables, entry)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+
+	path := filepath.Join(dir, ManifestFile)
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return manifest, err
+	}
+
+	return manifest, nil
+}
+
+// dumpTable exports the records of the given table to an NDJSON file in the
+// dir directory.
+func dumpTable(ctx context.Context, db *bun.DB, table Table, dir string, opts DumpOptions) (ManifestEntry, error) {
+	entry := ManifestEntry{
+		Table: table.Name,
+		Model: table.Model,
+		File:  table.Name + ".ndjson",
+	}
+
+	f, err := os.Create(filepath.Join(dir, entry.File))
+	if err != nil {
+		return entry, err
+	}
+	defer f.Close() // nolint: errcheck
+
+	w := NewNDJSONWriter(f, opts.FlushInterval)
+	err = Model(ctx, db, table.Value, w, UpdatedSince(db, table.Value, opts.Since))
+	if err != nil {
+		return entry, err
+	}
+	entry.Count = w.Count()
+
+	return entry, f.Close()
+}
+
+// UpdatedSince returns a function, which restricts the select query of
+// [Model] to records updated at or after the given timestamp. The query is
+// left unchanged, if the timestamp is zero, or the model has no `updated_at'
+// column.
+func UpdatedSince(db *bun.DB, model any, since time.Time) func(q *bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		if since.IsZero() || !db.Table(reflect.TypeOf(model)).HasField(updatedAtColumn) {
+			return q
+		}
+
+		return q.Where("?TableAlias.? >= ?", bun.Ident(updatedAtColumn), since)
+	}
+}
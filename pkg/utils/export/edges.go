@@ -0,0 +1,341 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+
+	"github.com/gardener/inventory/pkg/core/registry"
+)
+
+// linkTablePrefix is the prefix of the tables, which link models with each
+// other.
+const linkTablePrefix = "l_"
+
+// softDeleteColumn is the column, which marks soft-deleted records.
+const softDeleteColumn = "deleted_at"
+
+// Edge represents a single edge of the relationship graph, i.e. a row of a
+// link table.
+type Edge struct {
+	// SourceModel is the name of the source model.
+	SourceModel string `json:"source_model"`
+
+	// SourceID is the id of the source record.
+	SourceID uuid.UUID `json:"source_id"`
+
+	// Relationship is the name of the link model.
+	Relationship string `json:"relationship"`
+
+	// TargetModel is the name of the target model.
+	TargetModel string `json:"target_model"`
+
+	// TargetID is the id of the target record.
+	TargetID uuid.UUID `json:"target_id"`
+}
+
+// Relationship describes a link table, which connects two models.
+type Relationship struct {
+	// Name is the name of the link model.
+	Name string
+
+	// Table is the name of the link table.
+	Table string
+
+	// SourceModel is the name of the source model.
+	SourceModel string
+
+	// SourceTable is the name of the table of the source model.
+	SourceTable string
+
+	// SourceColumn is the column of the link table, which refers to the
+	// source model.
+	SourceColumn string
+
+	// TargetModel is the name of the target model.
+	TargetModel string
+
+	// TargetTable is the name of the table of the target model.
+	TargetTable string
+
+	// TargetColumn is the column of the link table, which refers to the
+	// target model.
+	TargetColumn string
+}
+
+// EdgeWriter writes the edges of the relationship graph.
+type EdgeWriter interface {
+	// WriteEdge writes the given edge.
+	WriteEdge(e Edge) error
+
+	// Flush writes any buffered data to the underlying writer.
+	Flush() error
+}
+
+// WriteEdge implements the [EdgeWriter] interface.
+func (w *NDJSONWriter) WriteEdge(e Edge) error {
+	return w.Write(e)
+}
+
+// CSVEdgeWriter writes edges as CSV records to an underlying writer, flushing
+// buffered data periodically.
+type CSVEdgeWriter struct {
+	cw            *csv.Writer
+	flushInterval int
+	count         int
+}
+
+// NewCSVEdgeWriter creates a new [CSVEdgeWriter], which flushes buffered data
+// to w after each flushInterval number of edges. If flushInterval is not a
+// positive number, the [DefaultFlushInterval] is used.
+func NewCSVEdgeWriter(w io.Writer, flushInterval int) *CSVEdgeWriter {
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+
+	cw := &CSVEdgeWriter{
+		cw:            csv.NewWriter(w),
+		flushInterval: flushInterval,
+	}
+
+	return cw
+}
+
+// csvEdgeHeader is the header of the CSV records written by [CSVEdgeWriter].
+var csvEdgeHeader = []string{
+	"source_model",
+	"source_id",
+	"relationship",
+	"target_model",
+	"target_id",
+}
+
+// WriteEdge implements the [EdgeWriter] interface. The header is written
+// before the first edge.
+func (w *CSVEdgeWriter) WriteEdge(e Edge) error {
+	if w.count == 0 {
+		if err := w.cw.Write(csvEdgeHeader); err != nil {
+			return err
+		}
+	}
+
+	record := []string{
+		e.SourceModel,
+		e.SourceID.String(),
+		e.Relationship,
+		e.TargetModel,
+		e.TargetID.String(),
+	}
+	if err := w.cw.Write(record); err != nil {
+		return err
+	}
+
+	w.count++
+	if w.count%w.flushInterval == 0 {
+		return w.Flush()
+	}
+
+	return nil
+}
+
+// Flush implements the [EdgeWriter] interface.
+func (w *CSVEdgeWriter) Flush() error {
+	w.cw.Flush()
+
+	return w.cw.Error()
+}
+
+// Count returns the number of edges written so far.
+func (w *CSVEdgeWriter) Count() int {
+	return w.count
+}
+
+// foreignKey represents a foreign key column of a link table.
+type foreignKey struct {
+	Column       string `bun:"column_name"`
+	ForeignTable string `bun:"foreign_table"`
+}
+
+// Relationships returns the relationships between the models from the
+// [registry.ModelRegistry], sorted by name. The link models are discovered
+// from the registry, and the models they connect are discovered from the
+// foreign keys of the link tables, so that new relationships are covered
+// automatically.
+//
+// The source of a relationship is the model referred to by the first foreign
+// key column of the link model, and the target is the model referred to by the
+// second one. Link tables, which don't refer to exactly two registered models
+// are skipped.
+func Relationships(ctx context.Context, db *bun.DB) ([]Relationship, error) {
+	// Map the tables to model names, and collect the link models
+	tableModels := make(map[string]string)
+	links := make(map[string]*schema.Table)
+	walker := func(name string, model any) error {
+		table := db.Table(reflect.TypeOf(model))
+		tableModels[table.Name] = name
+		if strings.HasPrefix(table.Name, linkTablePrefix) {
+			links[name] = table
+		}
+
+		return nil
+	}
+
+	if err := registry.ModelRegistry.Range(walker); err != nil {
+		return nil, err
+	}
+
+	result := make([]Relationship, 0, len(links))
+	for name, table := range links {
+		keys, err := foreignKeys(ctx, db, table.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Order the foreign keys by the order of the fields in the
+		// link model.
+		position := func(column string) int {
+			return slices.IndexFunc(table.Fields, func(f *schema.Field) bool {
+				return f.Name == column
+			})
+		}
+		slices.SortFunc(keys, func(a, b foreignKey) int {
+			return cmp.Compare(position(a.Column), position(b.Column))
+		})
+
+		if len(keys) != 2 {
+			continue
+		}
+
+		sourceModel, sourceOK := tableModels[keys[0].ForeignTable]
+		targetModel, targetOK := tableModels[keys[1].ForeignTable]
+		if !sourceOK || !targetOK {
+			continue
+		}
+
+		rel := Relationship{
+			Name:         name,
+			Table:        table.Name,
+			SourceModel:  sourceModel,
+			SourceTable:  keys[0].ForeignTable,
+			SourceColumn: keys[0].Column,
+			TargetModel:  targetModel,
+			TargetTable:  keys[1].ForeignTable,
+			TargetColumn: keys[1].Column,
+		}
+		result = append(result, rel)
+	}
+
+	slices.SortFunc(result, func(a, b Relationship) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+// Edges streams the edges of the given relationships to the [EdgeWriter]. Rows
+// of link tables, which are soft-deleted or refer to soft-deleted records are
+// skipped.
+func Edges(ctx context.Context, db *bun.DB, relationships []Relationship, w EdgeWriter) error {
+	for _, rel := range relationships {
+		if err := relationshipEdges(ctx, db, rel, w); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// relationshipEdges streams the edges of the given relationship to the
+// [EdgeWriter].
+func relationshipEdges(ctx context.Context, db *bun.DB, rel Relationship, w EdgeWriter) error {
+	query := db.NewSelect().
+		TableExpr("? AS l", bun.Ident(rel.Table)).
+		ColumnExpr("l.? AS source_id", bun.Ident(rel.SourceColumn)).
+		ColumnExpr("l.? AS target_id", bun.Ident(rel.TargetColumn))
+
+	if hasColumn(ctx, db, rel.Table, softDeleteColumn) {
+		query = query.Where("l.? IS NULL", bun.Ident(softDeleteColumn))
+	}
+
+	if hasColumn(ctx, db, rel.SourceTable, softDeleteColumn) {
+		query = query.
+			Join("INNER JOIN ? AS s", bun.Ident(rel.SourceTable)).
+			JoinOn("s.id = l.?", bun.Ident(rel.SourceColumn)).
+			Where("s.? IS NULL", bun.Ident(softDeleteColumn))
+	}
+
+	if hasColumn(ctx, db, rel.TargetTable, softDeleteColumn) {
+		query = query.
+			Join("INNER JOIN ? AS t", bun.Ident(rel.TargetTable)).
+			JoinOn("t.id = l.?", bun.Ident(rel.TargetColumn)).
+			Where("t.? IS NULL", bun.Ident(softDeleteColumn))
+	}
+
+	rows, err := query.Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close() // nolint: errcheck
+
+	for rows.Next() {
+		edge := Edge{
+			SourceModel:  rel.SourceModel,
+			Relationship: rel.Name,
+			TargetModel:  rel.TargetModel,
+		}
+		if err := rows.Scan(&edge.SourceID, &edge.TargetID); err != nil {
+			return err
+		}
+
+		if err := w.WriteEdge(edge); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// foreignKeys returns the foreign key columns of the given table.
+func foreignKeys(ctx context.Context, db bun.IDB, table string) ([]foreignKey, error) {
+	keys := make([]foreignKey, 0)
+	err := db.NewSelect().
+		TableExpr("information_schema.table_constraints AS tc").
+		Join("INNER JOIN information_schema.key_column_usage AS kcu").
+		JoinOn("kcu.constraint_name = tc.constraint_name").
+		JoinOn("kcu.table_schema = tc.table_schema").
+		Join("INNER JOIN information_schema.constraint_column_usage AS ccu").
+		JoinOn("ccu.constraint_name = tc.constraint_name").
+		JoinOn("ccu.table_schema = tc.table_schema").
+		ColumnExpr("kcu.column_name AS column_name").
+		ColumnExpr("ccu.table_name AS foreign_table").
+		Where("tc.constraint_type = ?", "FOREIGN KEY").
+		Where("tc.table_name = ?", table).
+		Where("tc.table_schema = current_schema()").
+		Scan(ctx, &keys)
+
+	return keys, err
+}
+
+// hasColumn returns true, if the given table has the given column.
+func hasColumn(ctx context.Context, db *bun.DB, table, column string) bool {
+	exists, err := db.NewSelect().
+		TableExpr("information_schema.columns").
+		Where("table_schema = current_schema()").
+		Where("table_name = ?", table).
+		Where("column_name = ?", column).
+		Exists(ctx)
+
+	return err == nil && exists
+}
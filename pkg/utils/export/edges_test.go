@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/gardener/inventory/pkg/utils/export"
+)
+
+func testEdge() export.Edge {
+	edge := export.Edge{
+		SourceModel:  "aws:model:vpc",
+		SourceID:     uuid.New(),
+		Relationship: "aws:model:link_vpc_to_subnet",
+		TargetModel:  "aws:model:subnet",
+		TargetID:     uuid.New(),
+	}
+
+	return edge
+}
+
+func TestCSVEdgeWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := export.NewCSVEdgeWriter(&buf, 2)
+
+	edges := make([]export.Edge, 0)
+	for range 3 {
+		edge := testEdge()
+		edges = append(edges, edge)
+		if err := w.WriteEdge(edge); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Header and the first two edges are flushed after the first flush
+	// interval
+	if got := strings.Count(buf.String(), "\n"); got != 3 {
+		t.Fatalf("want 3 flushed lines, got %d", got)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Count() != 3 {
+		t.Fatalf("want count 3, got %d", w.Count())
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 4 {
+		t.Fatalf("want 4 records, got %d", len(records))
+	}
+
+	header := strings.Join(records[0], ",")
+	if header != "source_model,source_id,relationship,target_model,target_id" {
+		t.Fatalf("unexpected header %q", header)
+	}
+
+	for i, edge := range edges {
+		want := []string{
+			edge.SourceModel,
+			edge.SourceID.String(),
+			edge.Relationship,
+			edge.TargetModel,
+			edge.TargetID.String(),
+		}
+		if got := records[i+1]; strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("want record %v, got %v", want, got)
+		}
+	}
+}
+
+func TestNDJSONWriterWriteEdge(t *testing.T) {
+	var buf bytes.Buffer
+	var w export.EdgeWriter = export.NewNDJSONWriter(&buf, 0)
+
+	edge := testEdge()
+	if err := w.WriteEdge(edge); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"source_model": edge.SourceModel,
+		"source_id":    edge.SourceID.String(),
+		"relationship": edge.Relationship,
+		"target_model": edge.TargetModel,
+		"target_id":    edge.TargetID.String(),
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("want %s=%q, got %q", k, v, got[k])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/uptrace/bun"
+)
+
+// DefaultFlushInterval specifies the default number of records after which the
+// [NDJSONWriter] flushes buffered data to the underlying writer.
+const DefaultFlushInterval = 1000
+
+// NDJSONWriter encodes records as newline-delimited JSON to an underlying
+// writer, flushing buffered data periodically, so that memory usage remains
+// bounded regardless of the number of records being written.
+type NDJSONWriter struct {
+	bw            *bufio.Writer
+	encoder       *json.Encoder
+	flushInterval int
+	count         int
+}
+
+// NewNDJSONWriter creates a new [NDJSONWriter], which flushes buffered data
+// to w after each flushInterval number of records. If flushInterval is not a
+// positive number, the [DefaultFlushInterval] is used.
+func NewNDJSONWriter(w io.Writer, flushInterval int) *NDJSONWriter {
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+
+	bw := bufio.NewWriter(w)
+	nw := &NDJSONWriter{
+		bw:            bw,
+		encoder:       json.NewEncoder(bw),
+		flushInterval: flushInterval,
+	}
+
+	return nw
+}
+
+// Write encodes the given record as a single line of JSON.
+func (w *NDJSONWriter) Write(v any) error {
+	if err := w.encoder.Encode(v); err != nil {
+		return err
+	}
+
+	w.count++
+	if w.count%w.flushInterval == 0 {
+		return w.bw.Flush()
+	}
+
+	return nil
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *NDJSONWriter) Flush() error {
+	return w.bw.Flush()
+}
+
+// Count returns the number of records written so far.
+func (w *NDJSONWriter) Count() int {
+	return w.count
+}
+
+// Model streams the records of the given model to the [NDJSONWriter]. The
+// records are read from the database row-by-row using a cursor, instead of
+// loading the whole table into memory. The model is expected to be a pointer
+// to a struct, as registered in the [registry.ModelRegistry].
+//
+// An optional function may be provided, which modifies the base select query,
+// e.g. for filtering records.
+func Model(ctx context.Context, db *bun.DB, model any, w *NDJSONWriter, opts ...func(q *bun.SelectQuery) *bun.SelectQuery) error {
+	query := db.NewSelect().Model(model)
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	rows, err := query.Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close() // nolint: errcheck
+
+	modelType := reflect.TypeOf(model).Elem()
+	for rows.Next() {
+		item := reflect.New(modelType).Interface()
+		if err := db.ScanRow(ctx, rows, item); err != nil {
+			return err
+		}
+
+		if err := w.Write(item); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
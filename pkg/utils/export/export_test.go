@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/uptrace/bun"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	"github.com/gardener/inventory/pkg/utils/export"
+)
+
+type testRecord struct {
+	ID   int    `json:"id"`
+	Data string `json:"data"`
+}
+
+// countingWriter discards the written data and keeps track of the number of
+// bytes written.
+type countingWriter struct {
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+
+	return len(p), nil
+}
+
+// peakHeapWriter is a [countingWriter], which samples the heap usage on each
+// write, and keeps track of the peak usage.
+type peakHeapWriter struct {
+	countingWriter
+	peak uint64
+}
+
+func (w *peakHeapWriter) Write(p []byte) (int, error) {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	w.peak = max(w.peak, stats.HeapInuse)
+
+	return w.countingWriter.Write(p)
+}
+
+// testExportRecord is the model used for testing exports from the database.
+type testExportRecord struct {
+	bun.BaseModel `bun:"table:test_export_record"`
+
+	ID   int64  `bun:"id,pk"`
+	Data string `bun:"data,notnull"`
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := export.NewNDJSONWriter(&buf, 2)
+
+	for i := range 5 {
+		if err := w.Write(testRecord{ID: i, Data: "foo"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Records are flushed only after each flush interval
+	if got := strings.Count(buf.String(), "\n"); got != 4 {
+		t.Fatalf("want 4 flushed records, got %d", got)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Count() != 5 {
+		t.Fatalf("want count 5, got %d", w.Count())
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	i := 0
+	for scanner.Scan() {
+		var record testRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		if record.ID != i {
+			t.Fatalf("want record id %d, got %d", i, record.ID)
+		}
+		i++
+	}
+
+	if i != 5 {
+		t.Fatalf("want 5 records, got %d", i)
+	}
+}
+
+func TestNDJSONWriterMemoryBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping memory budget test in short mode")
+	}
+
+	const (
+		// Total size of the exported data is ~256 MiB, which is
+		// well above the memory budget.
+		numRecords = 256 * 1024
+		recordSize = 1024
+		budget     = 32 * 1024 * 1024
+	)
+
+	data := strings.Repeat("x", recordSize)
+	out := &countingWriter{}
+	w := export.NewNDJSONWriter(out, export.DefaultFlushInterval)
+
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	baseline := stats.HeapInuse
+	peak := baseline
+
+	for i := range numRecords {
+		if err := w.Write(testRecord{ID: i, Data: data}); err != nil {
+			t.Fatal(err)
+		}
+
+		if i%8192 == 0 {
+			runtime.ReadMemStats(&stats)
+			peak = max(peak, stats.HeapInuse)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.n < numRecords*recordSize {
+		t.Fatalf("want at least %d bytes written, got %d", numRecords*recordSize, out.n)
+	}
+
+	if peak-baseline > budget {
+		t.Fatalf("heap usage of %d bytes exceeds budget of %d bytes", peak-baseline, budget)
+	}
+}
+
+// TestModelMemoryBudget verifies that exporting a table, which is larger than
+// the memory budget, streams the records from the database. The test is
+// skipped, unless the test database is configured via [dbtest.EnvDSN].
+func TestModelMemoryBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping memory budget test in short mode")
+	}
+
+	const (
+		// Total size of the exported data is ~128 MiB, which is
+		// well above the memory budget.
+		numRecords = 128 * 1024
+		recordSize = 1024
+		budget     = 32 * 1024 * 1024
+	)
+
+	db := dbtest.New(t)
+	ctx := t.Context()
+
+	if _, err := db.NewCreateTable().Model((*testExportRecord)(nil)).Exec(ctx); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	// The records are generated by the database, so that seeding the
+	// table does not affect the heap usage of the test.
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO ? (id, data) SELECT g, repeat('x', ?) FROM generate_series(1, ?) AS g",
+		bun.Ident("test_export_record"), recordSize, numRecords,
+	)
+	if err != nil {
+		t.Fatalf("unable to seed table: %s", err)
+	}
+
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	baseline := stats.HeapInuse
+
+	out := &peakHeapWriter{peak: baseline}
+	w := export.NewNDJSONWriter(out, export.DefaultFlushInterval)
+	if err := export.Model(ctx, db, &testExportRecord{}, w); err != nil {
+		t.Fatalf("unable to export model: %s", err)
+	}
+
+	if w.Count() != numRecords {
+		t.Fatalf("want %d records, got %d", numRecords, w.Count())
+	}
+
+	if out.n < numRecords*recordSize {
+		t.Fatalf("want at least %d bytes written, got %d", numRecords*recordSize, out.n)
+	}
+
+	if out.peak-baseline > budget {
+		t.Fatalf("heap usage of %d bytes exceeds budget of %d bytes", out.peak-baseline, budget)
+	}
+}
+
+func TestFilterTables(t *testing.T) {
+	tables := []export.Table{
+		{Name: "aws_instance", Model: "aws:model:instance"},
+		{Name: "aws_vpc", Model: "aws:model:vpc"},
+		{Name: "gcp_disk", Model: "gcp:model:disk"},
+	}
+
+	testCases := []struct {
+		desc    string
+		names   []string
+		want    []string
+		wantErr error
+	}{
+		{
+			desc:  "no names",
+			names: nil,
+			want:  []string{"aws_instance", "aws_vpc", "gcp_disk"},
+		},
+		{
+			desc:  "preserves table order",
+			names: []string{"gcp_disk", "aws_instance"},
+			want:  []string{"aws_instance", "gcp_disk"},
+		},
+		{
+			desc:    "unknown table",
+			names:   []string{"aws_vpc", "foo"},
+			wantErr: export.ErrUnknownTable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result, err := export.FilterTables(tables, tc.names)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			got := make([]string, 0, len(result))
+			for _, table := range result {
+				got = append(got, table.Name)
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("want tables %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// DefaultImportBatchSize specifies the default number of records, which are
+// upserted at once by [Import].
+const DefaultImportBatchSize = 1000
+
+// ErrInvalidManifest is returned when the manifest of an export cannot be
+// imported.
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// ImportOptions provides the options for [Import].
+type ImportOptions struct {
+	// BatchSize specifies the number of records, which are upserted at
+	// once. If not a positive number, the [DefaultImportBatchSize] is
+	// used.
+	BatchSize int
+}
+
+// idMap maps the ids of exported records to the ids of the stored records,
+// where they differ.
+type idMap map[uuid.UUID]uuid.UUID
+
+// ImportEntry pairs an entry of a [Manifest] with the table it is imported
+// into.
+type ImportEntry struct {
+	ManifestEntry
+
+	// Value is the model as registered in the [registry.ModelRegistry].
+	Value any
+}
+
+// ReadManifest reads the [ManifestFile] from the given export directory.
+func ReadManifest(dir string) (Manifest, error) {
+	var manifest Manifest
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return manifest, err
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	return manifest, nil
+}
+
+// ValidateManifest validates the given manifest against the tables backed by
+// registered models, and returns the entries in the order in which they
+// should be imported. [ErrInvalidManifest] is returned, if an entry refers to
+// a table without a corresponding model, to a different model, or to a file
+// outside of the export directory.
+//
+// Link tables refer to the records of other tables, so they are ordered after
+// all other tables.
+func ValidateManifest(manifest Manifest, tables []Table) ([]ImportEntry, error) {
+	result := make([]ImportEntry, 0, len(manifest.Tables))
+	for _, entry := range manifest.Tables {
+		idx := slices.IndexFunc(tables, func(t Table) bool {
+			return t.Name == entry.Table
+		})
+		if idx == -1 {
+			return nil, fmt.Errorf("%w: no model for table %s", ErrInvalidManifest, entry.Table)
+		}
+
+		table := tables[idx]
+		if entry.Model != "" && entry.Model != table.Model {
+			return nil, fmt.Errorf("%w: table %s refers to model %s instead of %s", ErrInvalidManifest, entry.Table, entry.Model, table.Model)
+		}
+
+		if entry.File == "" || !filepath.IsLocal(entry.File) {
+			return nil, fmt.Errorf("%w: invalid file %q for table %s", ErrInvalidManifest, entry.File, entry.Table)
+		}
+
+		item := ImportEntry{
+			ManifestEntry: entry,
+			Value:         table.Value,
+		}
+		result = append(result, item)
+	}
+
+	isLink := func(e ImportEntry) bool {
+		return strings.HasPrefix(e.Table, linkTablePrefix)
+	}
+	slices.SortStableFunc(result, func(a, b ImportEntry) int {
+		switch {
+		case isLink(a) == isLink(b):
+			return 0
+		case isLink(a):
+			return 1
+		default:
+			return -1
+		}
+	})
+
+	return result, nil
+}
+
+// Import upserts the records from the NDJSON files of the given entries in
+// the dir directory. Records conflicting with existing ones on the unique key
+// of their model are updated, so that importing the same export multiple
+// times is idempotent.
+//
+// Updated records keep the id of the existing record, which differs from the
+// exported id, when the record has been re-created in either database. The ids
+// of the records of tables referred to by foreign keys, e.g. of link tables,
+// are tracked, so that the foreign key columns of the records imported
+// afterwards are remapped to the ids of the stored records.
+//
+// After each table, its id sequence, if any, is advanced to the highest id, so
+// that records created afterwards do not conflict with the imported ones.
+//
+// Records are read from the files one by one and upserted in batches, so that
+// importing large files does not load the whole file into memory.
+func Import(ctx context.Context, db *bun.DB, dir string, entries []ImportEntry, opts ImportOptions) error {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultImportBatchSize
+	}
+
+	keys := make(map[string][]foreignKey, len(entries))
+	ids := make(map[string]idMap)
+	for _, entry := range entries {
+		tableKeys, err := foreignKeys(ctx, db, entry.Table)
+		if err != nil {
+			return fmt.Errorf("cannot get foreign keys of table %s: %w", entry.Table, err)
+		}
+
+		keys[entry.Table] = tableKeys
+		for _, key := range tableKeys {
+			ids[key.ForeignTable] = make(idMap)
+		}
+	}
+
+	for _, entry := range entries {
+		if err := importTable(ctx, db, dir, entry, opts, keys[entry.Table], ids); err != nil {
+			return fmt.Errorf("cannot import table %s: %w", entry.Table, err)
+		}
+
+		if err := resetSequence(ctx, db, entry.Table); err != nil {
+			return fmt.Errorf("cannot reset sequence of table %s: %w", entry.Table, err)
+		}
+	}
+
+	return nil
+}
+
+// importTable upserts the records from the NDJSON file of the given entry. The
+// foreign key columns of the records are remapped using the given ids, which
+// are updated with the ids of the stored records, if the table is tracked.
+func importTable(ctx context.Context, db *bun.DB, dir string, entry ImportEntry, opts ImportOptions, keys []foreignKey, ids map[string]idMap) error {
+	f, err := os.Open(filepath.Join(dir, entry.File))
+	if err != nil {
+		return err
+	}
+	defer f.Close() // nolint: errcheck
+
+	modelType := reflect.TypeOf(entry.Value).Elem()
+	table := db.Table(modelType)
+	decoder := json.NewDecoder(bufio.NewReader(f))
+	batch := reflect.MakeSlice(reflect.SliceOf(modelType), 0, opts.BatchSize)
+	for {
+		item := reflect.New(modelType)
+		err := decoder.Decode(item.Interface())
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		remapIDs(table, item.Elem(), keys, ids)
+		batch = reflect.Append(batch, item.Elem())
+		if batch.Len() < opts.BatchSize {
+			continue
+		}
+
+		if err := upsertBatch(ctx, db, table, batch, ids[entry.Table]); err != nil {
+			return err
+		}
+		batch = batch.Slice(0, 0)
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return upsertBatch(ctx, db, table, batch, ids[entry.Table])
+}
+
+// remapIDs replaces the ids in the foreign key columns of the given record
+// with the ids of the stored records, where they differ.
+func remapIDs(table *schema.Table, strct reflect.Value, keys []foreignKey, ids map[string]idMap) {
+	for _, key := range keys {
+		field, ok := table.FieldMap[key.Column]
+		if !ok || len(ids[key.ForeignTable]) == 0 {
+			continue
+		}
+
+		value := field.Value(strct)
+		id, ok := value.Interface().(uuid.UUID)
+		if !ok {
+			continue
+		}
+
+		if storedID, ok := ids[key.ForeignTable][id]; ok {
+			value.Set(reflect.ValueOf(storedID))
+		}
+	}
+}
+
+// resetSequence advances the sequence of the id column of the given table, if
+// any, to the highest id of the table.
+func resetSequence(ctx context.Context, db *bun.DB, table string) error {
+	var sequence sql.NullString
+	err := db.NewSelect().
+		ColumnExpr("pg_get_serial_sequence(?, 'id')", table).
+		Scan(ctx, &sequence)
+	if err != nil {
+		return err
+	}
+
+	if !sequence.Valid {
+		return nil
+	}
+
+	_, err = db.NewSelect().
+		ColumnExpr("setval(?, max(id))", sequence.String).
+		TableExpr("?", bun.Ident(table)).
+		Exec(ctx)
+
+	return err
+}
+
+// upsertBatch upserts the given slice of records of the table. If ids is not
+// nil, the ids of the stored records, which differ from the ids of the given
+// records, are added to it.
+func upsertBatch(ctx context.Context, db *bun.DB, table *schema.Table, batch reflect.Value, ids idMap) error {
+	items := reflect.New(batch.Type())
+	items.Elem().Set(batch)
+
+	conflictColumns := ConflictColumns(table)
+	idents := make([]string, 0, len(conflictColumns))
+	for _, column := range conflictColumns {
+		idents = append(idents, string(db.Formatter().AppendIdent(nil, column)))
+	}
+
+	query := db.NewInsert().
+		Model(items.Interface()).
+		On("CONFLICT (?) DO UPDATE", bun.Safe(strings.Join(idents, ", ")))
+
+	for _, field := range table.Fields {
+		if field.IsPK || field.Name == "created_at" || slices.Contains(conflictColumns, field.Name) {
+			continue
+		}
+		query = query.Set("? = EXCLUDED.?", bun.Ident(field.Name), bun.Ident(field.Name))
+	}
+
+	// The conflicting records keep their id, so the stored ids are
+	// matched with the given records via the conflict columns.
+	if ids == nil || slices.Equal(conflictColumns, []string{"id"}) {
+		_, err := query.Exec(ctx)
+
+		return err
+	}
+
+	stored := reflect.New(batch.Type())
+	_, err := query.
+		Returning("id, ?", bun.Safe(strings.Join(idents, ", "))).
+		Exec(ctx, stored.Interface())
+	if err != nil {
+		return err
+	}
+
+	key := func(strct reflect.Value) string {
+		var b []byte
+		for _, column := range conflictColumns {
+			b = table.FieldMap[column].AppendValue(db.Formatter(), b, strct)
+			b = append(b, ',')
+		}
+
+		return string(b)
+	}
+
+	storedIDs := make(map[string]uuid.UUID, stored.Elem().Len())
+	for i := range stored.Elem().Len() {
+		strct := stored.Elem().Index(i)
+		if id, ok := recordID(table, strct); ok {
+			storedIDs[key(strct)] = id
+		}
+	}
+
+	for i := range batch.Len() {
+		strct := batch.Index(i)
+		id, ok := recordID(table, strct)
+		if !ok || id == uuid.Nil {
+			continue
+		}
+
+		if storedID, ok := storedIDs[key(strct)]; ok && storedID != id {
+			ids[id] = storedID
+		}
+	}
+
+	return nil
+}
+
+// recordID returns the id of the given record of the table.
+func recordID(table *schema.Table, strct reflect.Value) (uuid.UUID, bool) {
+	field, ok := table.FieldMap["id"]
+	if !ok {
+		return uuid.UUID{}, false
+	}
+
+	id, ok := field.Value(strct).Interface().(uuid.UUID)
+
+	return id, ok
+}
+
+// ConflictColumns returns the columns, which are used for detecting
+// conflicting records of the given table. These are the columns of the unique
+// key of the table, if it has exactly one, or the primary key columns
+// otherwise.
+func ConflictColumns(table *schema.Table) []string {
+	// Fields with an unnamed unique tag are unique on their own, while the
+	// fields of a named unique tag form a composite key.
+	keys := make([][]*schema.Field, 0, len(table.Unique))
+	for name, fields := range table.Unique {
+		if name != "" {
+			keys = append(keys, fields)
+			continue
+		}
+		for _, field := range fields {
+			keys = append(keys, []*schema.Field{field})
+		}
+	}
+
+	fields := table.PKs
+	if len(keys) == 1 {
+		fields = keys[0]
+	}
+
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, field.Name)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
@@ -0,0 +1,331 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export_test
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	"github.com/gardener/inventory/internal/pkg/dbtest"
+	awsmodels "github.com/gardener/inventory/pkg/aws/models"
+	coremodels "github.com/gardener/inventory/pkg/core/models"
+	gcpmodels "github.com/gardener/inventory/pkg/gcp/models"
+	openstackmodels "github.com/gardener/inventory/pkg/openstack/models"
+	"github.com/gardener/inventory/pkg/utils/export"
+)
+
+type uniqueModel struct {
+	bun.BaseModel `bun:"table:unique_model"`
+	coremodels.Model
+
+	Name   string `bun:"name,notnull,unique:unique_model_key"`
+	Region string `bun:"region,notnull,unique:unique_model_key"`
+	Data   string `bun:"data,notnull"`
+}
+
+type unnamedUniqueModel struct {
+	bun.BaseModel `bun:"table:unnamed_unique_model"`
+	coremodels.Model
+
+	Name string `bun:"name,notnull,unique"`
+}
+
+type multiUniqueModel struct {
+	bun.BaseModel `bun:"table:multi_unique_model"`
+	coremodels.Model
+
+	Name string `bun:"name,notnull,unique"`
+	Key  string `bun:"key,notnull,unique"`
+}
+
+func TestConflictColumns(t *testing.T) {
+	tables := pgdialect.New().Tables()
+
+	testCases := []struct {
+		desc  string
+		model any
+		want  []string
+	}{
+		{
+			desc:  "single unique key",
+			model: &uniqueModel{},
+			want:  []string{"name", "region"},
+		},
+		{
+			desc:  "unnamed unique key",
+			model: &unnamedUniqueModel{},
+			want:  []string{"name"},
+		},
+		{
+			desc:  "multiple unique keys",
+			model: &multiUniqueModel{},
+			want:  []string{"id"},
+		},
+		{
+			desc:  "openstack link",
+			model: &openstackmodels.FloatingIPToServer{},
+			want:  []string{"floating_ip_id", "server_id"},
+		},
+		{
+			desc:  "gcp project",
+			model: &gcpmodels.Project{},
+			want:  []string{"project_id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			table := tables.Get(reflect.TypeOf(tc.model))
+			got := export.ConflictColumns(table)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("want columns %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateManifest(t *testing.T) {
+	tables := []export.Table{
+		{Name: "aws_instance", Model: "aws:model:instance"},
+		{Name: "aws_vpc", Model: "aws:model:vpc"},
+		{Name: "l_aws_vpc_to_instance", Model: "aws:model:link_vpc_to_instance"},
+	}
+
+	testCases := []struct {
+		desc    string
+		entries []export.ManifestEntry
+		want    []string
+		wantErr error
+	}{
+		{
+			desc: "link tables are imported last",
+			entries: []export.ManifestEntry{
+				{Table: "l_aws_vpc_to_instance", Model: "aws:model:link_vpc_to_instance", File: "l_aws_vpc_to_instance.ndjson"},
+				{Table: "aws_vpc", Model: "aws:model:vpc", File: "aws_vpc.ndjson"},
+				{Table: "aws_instance", Model: "aws:model:instance", File: "aws_instance.ndjson"},
+			},
+			want: []string{"aws_vpc", "aws_instance", "l_aws_vpc_to_instance"},
+		},
+		{
+			desc: "table without model",
+			entries: []export.ManifestEntry{
+				{Table: "foo", Model: "foo:model:bar", File: "foo.ndjson"},
+			},
+			wantErr: export.ErrInvalidManifest,
+		},
+		{
+			desc: "model mismatch",
+			entries: []export.ManifestEntry{
+				{Table: "aws_vpc", Model: "aws:model:instance", File: "aws_vpc.ndjson"},
+			},
+			wantErr: export.ErrInvalidManifest,
+		},
+		{
+			desc: "file outside of export directory",
+			entries: []export.ManifestEntry{
+				{Table: "aws_vpc", Model: "aws:model:vpc", File: "../aws_vpc.ndjson"},
+			},
+			wantErr: export.ErrInvalidManifest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			manifest := export.Manifest{Tables: tc.entries}
+			result, err := export.ValidateManifest(manifest, tables)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			got := make([]string, 0, len(result))
+			for _, entry := range result {
+				got = append(got, entry.Table)
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("want tables %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestImport verifies that importing an export into a database, which already
+// contains some of the records with different ids, remaps the ids of the link
+// tables to the stored records. The test is skipped, unless the test database
+// is configured via [dbtest.EnvDSN].
+func TestImport(t *testing.T) {
+	testDB := dbtest.New(t)
+	ctx := t.Context()
+
+	vpc := awsmodels.VPC{Name: "exported", VpcID: "vpc-1", AccountID: "111111111111", RegionName: "eu-west-1"}
+	instance := awsmodels.Instance{InstanceID: "i-1", AccountID: "111111111111", VpcID: "vpc-1", RegionName: "eu-west-1"}
+	for _, model := range []any{&vpc, &instance} {
+		if _, err := testDB.NewInsert().Model(model).Returning("id").Exec(ctx); err != nil {
+			t.Fatalf("unable to insert %T: %s", model, err)
+		}
+	}
+
+	link := awsmodels.VPCToInstance{VpcID: vpc.ID, InstanceID: instance.ID}
+	if _, err := testDB.NewInsert().Model(&link).Exec(ctx); err != nil {
+		t.Fatalf("unable to insert link: %s", err)
+	}
+
+	allTables, err := export.Tables(testDB)
+	if err != nil {
+		t.Fatalf("unable to get tables: %s", err)
+	}
+	names := []string{"aws_instance", "aws_vpc", "l_aws_vpc_to_instance"}
+	tables, err := export.FilterTables(allTables, names)
+	if err != nil {
+		t.Fatalf("unable to filter tables: %s", err)
+	}
+
+	dir := t.TempDir()
+	if _, err := export.Dump(ctx, testDB, tables, dir, export.DumpOptions{}); err != nil {
+		t.Fatalf("unable to export tables: %s", err)
+	}
+
+	// Re-create the VPC with a different id, as if it had been collected
+	// separately in the target database
+	for _, name := range names {
+		if _, err := testDB.NewDelete().TableExpr("?", bun.Ident(name)).Where("TRUE").Exec(ctx); err != nil {
+			t.Fatalf("unable to delete records of %s: %s", name, err)
+		}
+	}
+	existing := awsmodels.VPC{Name: "existing", VpcID: "vpc-1", AccountID: "111111111111", RegionName: "eu-west-1"}
+	if _, err := testDB.NewInsert().Model(&existing).Returning("id").Exec(ctx); err != nil {
+		t.Fatalf("unable to insert vpc: %s", err)
+	}
+	if existing.ID == vpc.ID {
+		t.Fatal("want a different id for the existing vpc")
+	}
+
+	manifest, err := export.ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("unable to read manifest: %s", err)
+	}
+	entries, err := export.ValidateManifest(manifest, allTables)
+	if err != nil {
+		t.Fatalf("unable to validate manifest: %s", err)
+	}
+
+	// Importing twice must yield the same result
+	for range 2 {
+		if err := export.Import(ctx, testDB, dir, entries, export.ImportOptions{BatchSize: 1}); err != nil {
+			t.Fatalf("unable to import: %s", err)
+		}
+	}
+
+	var gotVPC awsmodels.VPC
+	if err := testDB.NewSelect().Model(&gotVPC).Where("vpc_id = ?", "vpc-1").Scan(ctx); err != nil {
+		t.Fatalf("unable to select vpc: %s", err)
+	}
+	if gotVPC.ID != existing.ID || gotVPC.Name != "exported" {
+		t.Fatalf("want vpc %s named exported, got %s named %s", existing.ID, gotVPC.ID, gotVPC.Name)
+	}
+
+	var links []awsmodels.VPCToInstance
+	if err := testDB.NewSelect().Model(&links).Scan(ctx); err != nil {
+		t.Fatalf("unable to select links: %s", err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("want 1 link, got %d", len(links))
+	}
+	if links[0].VpcID != existing.ID || links[0].InstanceID != instance.ID {
+		t.Fatalf("want link %s -> %s, got %s -> %s", existing.ID, instance.ID, links[0].VpcID, links[0].InstanceID)
+	}
+}
+
+// TestImportExistingLinks verifies that importing links into a database, in
+// which the linked records and the links already exist with different ids,
+// updates the existing links instead of violating the unique constraint of the
+// link table. The test is skipped, unless the test database is configured via
+// [dbtest.EnvDSN].
+func TestImportExistingLinks(t *testing.T) {
+	testDB := dbtest.New(t)
+	ctx := t.Context()
+
+	insertLinked := func() (openstackmodels.Server, openstackmodels.FloatingIP) {
+		server := openstackmodels.Server{ServerID: "server-1", ProjectID: "project-1"}
+		fip := openstackmodels.FloatingIP{FloatingIPID: "fip-1", ProjectID: "project-1", FloatingIP: net.ParseIP("203.0.113.1")}
+		for _, model := range []any{&server, &fip} {
+			if _, err := testDB.NewInsert().Model(model).Returning("id").Exec(ctx); err != nil {
+				t.Fatalf("unable to insert %T: %s", model, err)
+			}
+		}
+
+		link := openstackmodels.FloatingIPToServer{FloatingIPID: fip.ID, ServerID: server.ID}
+		if _, err := testDB.NewInsert().Model(&link).Exec(ctx); err != nil {
+			t.Fatalf("unable to insert link: %s", err)
+		}
+
+		return server, fip
+	}
+
+	exportedServer, _ := insertLinked()
+
+	allTables, err := export.Tables(testDB)
+	if err != nil {
+		t.Fatalf("unable to get tables: %s", err)
+	}
+	names := []string{"openstack_server", "openstack_floating_ip", "l_openstack_floating_ip_to_server"}
+	tables, err := export.FilterTables(allTables, names)
+	if err != nil {
+		t.Fatalf("unable to filter tables: %s", err)
+	}
+
+	dir := t.TempDir()
+	if _, err := export.Dump(ctx, testDB, tables, dir, export.DumpOptions{}); err != nil {
+		t.Fatalf("unable to export tables: %s", err)
+	}
+
+	// Re-create the records and the link with different ids, as if they
+	// had been collected separately in the target database
+	for _, name := range names {
+		if _, err := testDB.NewDelete().TableExpr("?", bun.Ident(name)).Where("TRUE").Exec(ctx); err != nil {
+			t.Fatalf("unable to delete records of %s: %s", name, err)
+		}
+	}
+	server, fip := insertLinked()
+	if server.ID == exportedServer.ID {
+		t.Fatal("want a different id for the existing server")
+	}
+
+	manifest, err := export.ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("unable to read manifest: %s", err)
+	}
+	entries, err := export.ValidateManifest(manifest, allTables)
+	if err != nil {
+		t.Fatalf("unable to validate manifest: %s", err)
+	}
+
+	for range 2 {
+		if err := export.Import(ctx, testDB, dir, entries, export.ImportOptions{}); err != nil {
+			t.Fatalf("unable to import: %s", err)
+		}
+	}
+
+	var links []openstackmodels.FloatingIPToServer
+	if err := testDB.NewSelect().Model(&links).Scan(ctx); err != nil {
+		t.Fatalf("unable to select links: %s", err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("want 1 link, got %d", len(links))
+	}
+	if links[0].FloatingIPID != fip.ID || links[0].ServerID != server.ID {
+		t.Fatalf("want link %s -> %s, got %s -> %s", fip.ID, server.ID, links[0].FloatingIPID, links[0].ServerID)
+	}
+}
@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package paginate provides a generic paginator, which is shared by the
+// collectors of the various providers.
+package paginate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gardener/inventory/pkg/utils"
+	asynqutils "github.com/gardener/inventory/pkg/utils/asynq"
+)
+
+// ErrMaxPagesExceeded is returned when the number of fetched pages exceeds the
+// configured bound.
+var ErrMaxPagesExceeded = errors.New("max number of pages exceeded")
+
+// ErrRepeatedToken is returned when the API returns the token of a page, which
+// has already been fetched. Such misbehaving APIs would otherwise cause the
+// paginator to loop forever.
+var ErrRepeatedToken = errors.New("repeated page token")
+
+// FetchFunc fetches the page identified by the given token, and returns the
+// items of the page along with the token of the next page. An empty token
+// refers to the first page. An empty next token signals that there are no more
+// pages.
+type FetchFunc[T any] func(ctx context.Context, token string) ([]T, string, error)
+
+// ItemFunc is called for each item of a fetched page. Returning an error stops
+// the pagination.
+type ItemFunc[T any] func(item T) error
+
+// PageFunc is called with the items of each fetched page. Returning an error
+// stops the pagination.
+type PageFunc[T any] func(ctx context.Context, items []T) error
+
+// Options provides the options of the paginator.
+type Options struct {
+	// MaxPages specifies the max number of pages to fetch. Zero means no
+	// bound.
+	MaxPages int
+
+	// Token specifies the token of the first page to fetch, e.g. when
+	// resuming a previously interrupted pagination.
+	Token string
+
+	// Checkpoint, if set, is called with the token of the next page, after
+	// all items of a page have been processed.
+	Checkpoint func(token string)
+}
+
+// Option is a function, which configures the [Options] of the paginator.
+type Option func(o *Options)
+
+// WithMaxPages configures the paginator to fetch at most n pages.
+func WithMaxPages(n int) Option {
+	opt := func(o *Options) {
+		o.MaxPages = n
+	}
+
+	return opt
+}
+
+// WithToken configures the paginator to start from the page with the given
+// token.
+func WithToken(token string) Option {
+	opt := func(o *Options) {
+		o.Token = token
+	}
+
+	return opt
+}
+
+// WithCheckpoint configures the paginator to call f with the token of the next
+// page, after each processed page.
+func WithCheckpoint(f func(token string)) Option {
+	opt := func(o *Options) {
+		o.Checkpoint = f
+	}
+
+	return opt
+}
+
+// RetryFetch returns a [FetchFunc], which retries fetching a page with the
+// given [FetchFunc] on transient errors, e.g. throttling or timeouts, according
+// to the given [utils.RetryOptions]. Permanent errors are returned immediately.
+//
+// RetryFetch is the only retry mechanism of the paginator. It should not be
+// used with clients, which retry failed requests on their own, e.g. the
+// clients of the AWS SDK, in order to avoid multiplying the attempts.
+func RetryFetch[T any](fetch FetchFunc[T], opts utils.RetryOptions) FetchFunc[T] {
+	retryFetch := func(ctx context.Context, token string) ([]T, string, error) {
+		var items []T
+		var next string
+		retryOpts := opts
+		retryOpts.OnRetry = func(attempt int, err error) {
+			asynqutils.GetLogger(ctx).Warn(
+				"retrying to fetch page after transient error",
+				"attempt", attempt,
+				"reason", err,
+			)
+		}
+
+		err := utils.WithRetry(ctx, func(ctx context.Context) error {
+			var err error
+			items, next, err = fetch(ctx, token)
+
+			return err
+		}, retryOpts)
+
+		return items, next, err
+	}
+
+	return retryFetch
+}
+
+// Paginate fetches the pages using the given [FetchFunc], and calls the
+// [ItemFunc] for each item of the fetched pages. Pagination stops when there
+// are no more pages, the context is cancelled, or an error occurs.
+//
+// The number of fetched pages is added to the result of the task associated
+// with the context.
+func Paginate[T any](ctx context.Context, fetch FetchFunc[T], fn ItemFunc[T], opts ...Option) error {
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	onPage := func(items []T) error {
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return paginate(ctx, fetch, onPage, options)
+}
+
+// PaginatePages fetches the pages using the given [FetchFunc], and calls the
+// [PageFunc] with the items of each fetched page.
+//
+// Pages are fetched one after another, since the token of a page is known only
+// after the previous page has been fetched. The fetched pages are processed by
+// up to concurrency workers at the same time, so that processing a page, e.g.
+// upserting its items, overlaps with fetching the next pages. The [PageFunc]
+// must be safe for concurrent use, when concurrency is greater than 1. A
+// non-positive concurrency is treated as 1.
+//
+// Since pages may complete out of order, the checkpoint configured via
+// [WithCheckpoint] is not called, when concurrency is greater than 1.
+func PaginatePages[T any](ctx context.Context, fetch FetchFunc[T], fn PageFunc[T], concurrency int, opts ...Option) error {
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if concurrency <= 1 {
+		onPage := func(items []T) error {
+			return fn(ctx, items)
+		}
+
+		return paginate(ctx, fetch, onPage, options)
+	}
+	options.Checkpoint = nil
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	pages := make(chan []T)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for items := range pages {
+				if err := fn(ctx, items); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	onPage := func(items []T) error {
+		select {
+		case pages <- items:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	err := paginate(ctx, fetch, onPage, options)
+	close(pages)
+	wg.Wait()
+
+	// The error of a worker takes precedence over the cancellation it
+	// caused.
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return err
+}
+
+// paginate fetches the pages using the given [FetchFunc], and calls onPage
+// with the items of each fetched page.
+func paginate[T any](ctx context.Context, fetch FetchFunc[T], onPage func(items []T) error, options Options) error {
+	seen := make(map[string]struct{})
+	token := options.Token
+	for pages := 0; ; pages++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if options.MaxPages > 0 && pages >= options.MaxPages {
+			return fmt.Errorf("%w: %d", ErrMaxPagesExceeded, options.MaxPages)
+		}
+
+		items, next, err := fetch(ctx, token)
+		if err != nil {
+			return err
+		}
+
+		asynqutils.AddPages(ctx, 1)
+		if err := onPage(items); err != nil {
+			return err
+		}
+
+		if options.Checkpoint != nil {
+			options.Checkpoint(next)
+		}
+
+		if next == "" {
+			return nil
+		}
+
+		seen[token] = struct{}{}
+		if _, ok := seen[next]; ok {
+			return fmt.Errorf("%w: %s", ErrRepeatedToken, next)
+		}
+		token = next
+	}
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package paginate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gardener/inventory/pkg/utils"
+	"github.com/gardener/inventory/pkg/utils/paginate"
+)
+
+// newFetchFunc returns a [paginate.FetchFunc], which returns the given pages.
+// The token of a page is its index.
+func newFetchFunc(pages [][]int) paginate.FetchFunc[int] {
+	fetch := func(_ context.Context, token string) ([]int, string, error) {
+		idx := 0
+		if token != "" {
+			idx, _ = strconv.Atoi(token)
+		}
+
+		next := ""
+		if idx+1 < len(pages) {
+			next = strconv.Itoa(idx + 1)
+		}
+
+		return pages[idx], next, nil
+	}
+
+	return fetch
+}
+
+func TestPaginate(t *testing.T) {
+	pages := [][]int{{1, 2}, {3}, {4, 5}}
+
+	testCases := []struct {
+		desc    string
+		opts    []paginate.Option
+		wanted  []int
+		wantErr error
+	}{
+		{
+			desc:   "all pages",
+			wanted: []int{1, 2, 3, 4, 5},
+		},
+		{
+			desc:   "resume from token",
+			opts:   []paginate.Option{paginate.WithToken("1")},
+			wanted: []int{3, 4, 5},
+		},
+		{
+			desc:    "max pages exceeded",
+			opts:    []paginate.Option{paginate.WithMaxPages(2)},
+			wanted:  []int{1, 2, 3},
+			wantErr: paginate.ErrMaxPagesExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := make([]int, 0)
+			err := paginate.Paginate(
+				context.Background(),
+				newFetchFunc(pages),
+				func(item int) error {
+					got = append(got, item)
+
+					return nil
+				},
+				tc.opts...,
+			)
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+
+			if !slices.Equal(got, tc.wanted) {
+				t.Fatalf("want %v, got %v", tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestPaginateRepeatedToken(t *testing.T) {
+	fetch := func(_ context.Context, _ string) ([]int, string, error) {
+		return []int{1}, "same", nil
+	}
+
+	err := paginate.Paginate(context.Background(), fetch, func(int) error { return nil })
+	if !errors.Is(err, paginate.ErrRepeatedToken) {
+		t.Fatalf("want error %v, got %v", paginate.ErrRepeatedToken, err)
+	}
+}
+
+func TestPaginateRetryFetch(t *testing.T) {
+	errTransient := errors.New("transient error")
+	errPermanent := errors.New("permanent error")
+
+	testCases := []struct {
+		desc         string
+		maxAttempts  int
+		err          error
+		failures     int
+		wantErr      error
+		wantAttempts int
+	}{
+		{
+			desc:         "transient error within max attempts",
+			maxAttempts:  3,
+			err:          errTransient,
+			failures:     2,
+			wantAttempts: 3,
+		},
+		{
+			desc:         "transient error exceeding max attempts",
+			maxAttempts:  2,
+			err:          errTransient,
+			failures:     2,
+			wantErr:      errTransient,
+			wantAttempts: 2,
+		},
+		{
+			desc:         "permanent error",
+			maxAttempts:  3,
+			err:          errPermanent,
+			failures:     1,
+			wantErr:      errPermanent,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			attempts := 0
+			fetch := func(_ context.Context, _ string) ([]int, string, error) {
+				attempts++
+				if attempts <= tc.failures {
+					return nil, "", tc.err
+				}
+
+				return []int{1}, "", nil
+			}
+
+			opts := utils.RetryOptions{
+				MaxAttempts: tc.maxAttempts,
+				IsTransient: func(err error) bool { return errors.Is(err, errTransient) },
+			}
+			noop := func(int) error { return nil }
+			err := paginate.Paginate(context.Background(), paginate.RetryFetch(fetch, opts), noop)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+
+			if attempts != tc.wantAttempts {
+				t.Fatalf("want %d attempts, got %d", tc.wantAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestPaginateCheckpoint(t *testing.T) {
+	checkpoints := make([]string, 0)
+	err := paginate.Paginate(
+		context.Background(),
+		newFetchFunc([][]int{{1}, {2}, {3}}),
+		func(int) error { return nil },
+		paginate.WithCheckpoint(func(token string) {
+			checkpoints = append(checkpoints, token)
+		}),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wanted := []string{"1", "2", ""}
+	if !slices.Equal(checkpoints, wanted) {
+		t.Fatalf("want %v, got %v", wanted, checkpoints)
+	}
+}
+
+func TestPaginateCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := paginate.Paginate(ctx, newFetchFunc([][]int{{1}}), func(int) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestPaginatePages(t *testing.T) {
+	pages := [][]int{{1, 2}, {3}, {4, 5}, {6}}
+
+	for _, concurrency := range []int{0, 1, 3} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			var mu sync.Mutex
+			got := make([]int, 0)
+			err := paginate.PaginatePages(
+				context.Background(),
+				newFetchFunc(pages),
+				func(_ context.Context, items []int) error {
+					mu.Lock()
+					defer mu.Unlock()
+					got = append(got, items...)
+
+					return nil
+				},
+				concurrency,
+			)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			slices.Sort(got)
+			wanted := []int{1, 2, 3, 4, 5, 6}
+			if !slices.Equal(got, wanted) {
+				t.Fatalf("want %v, got %v", wanted, got)
+			}
+		})
+	}
+}
+
+func TestPaginatePagesError(t *testing.T) {
+	errPage := errors.New("page failed")
+	fn := func(_ context.Context, items []int) error {
+		if items[0] == 3 {
+			return errPage
+		}
+
+		return nil
+	}
+
+	err := paginate.PaginatePages(context.Background(), newFetchFunc([][]int{{1}, {2}, {3}, {4}}), fn, 2)
+	if !errors.Is(err, errPage) {
+		t.Fatalf("want error %v, got %v", errPage, err)
+	}
+}
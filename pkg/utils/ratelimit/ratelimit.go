@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit provides token-bucket rate limiters for the HTTP requests
+// sent by the API clients of the various providers.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Registry provides rate limiters, which are shared by the clients using the
+// same key, e.g. the clients of the various services of a provider, which are
+// configured with the same credentials.
+type Registry struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewRegistry creates a new empty [Registry].
+func NewRegistry() *Registry {
+	r := &Registry{
+		limiters: make(map[string]*rate.Limiter),
+	}
+
+	return r
+}
+
+// DefaultRegistry is the default [Registry].
+var DefaultRegistry = NewRegistry()
+
+// Key returns the key of the limiter for the given provider and account.
+func Key(provider, account string) string {
+	return fmt.Sprintf("%s/%s", provider, account)
+}
+
+// Get returns the limiter for the given key, creating it with the given
+// number of requests per second and burst size, if it does not exist yet. If
+// burst is not a positive number, it defaults to the ceiling of the requests
+// per second. A nil limiter is returned, if requestsPerSecond is not a
+// positive number, i.e. requests are not limited.
+func (r *Registry) Get(key string, requestsPerSecond float64, burst int) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	if burst <= 0 {
+		burst = int(math.Ceil(requestsPerSecond))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limiter, ok := r.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+		r.limiters[key] = limiter
+	}
+
+	return limiter
+}
+
+// Transport is an [http.RoundTripper], which waits for the limiter before
+// sending each request via the base transport.
+type Transport struct {
+	// Base is the underlying transport. If nil, [http.DefaultTransport]
+	// is used.
+	Base http.RoundTripper
+
+	// Limiter is the rate limiter to wait for.
+	Limiter *rate.Limiter
+}
+
+// RoundTrip implements the [http.RoundTripper] interface. Waiting for the
+// limiter is aborted, when the context of the request is done.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(req)
+}
+
+// Doer sends HTTP requests, e.g. an [http.Client].
+type Doer interface {
+	// Do sends the given request.
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client is a [Doer], which waits for the limiter before sending each request
+// via the base [Doer].
+type Client struct {
+	// Base is the underlying [Doer]. If nil, [http.DefaultClient] is
+	// used.
+	Base Doer
+
+	// Limiter is the rate limiter to wait for.
+	Limiter *rate.Limiter
+}
+
+// Do implements the [Doer] interface. Waiting for the limiter is aborted,
+// when the context of the request is done.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if err := c.Limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	base := c.Base
+	if base == nil {
+		base = http.DefaultClient
+	}
+
+	return base.Do(req)
+}
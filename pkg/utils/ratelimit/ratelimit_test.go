@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"golang.org/x/time/rate"
+
+	"github.com/gardener/inventory/pkg/utils/ratelimit"
+)
+
+func TestRegistryGet(t *testing.T) {
+	r := ratelimit.NewRegistry()
+
+	if limiter := r.Get("aws/foo", 0, 10); limiter != nil {
+		t.Fatal("want no limiter without requests per second")
+	}
+
+	limiter := r.Get("aws/foo", 2.5, 0)
+	if limiter == nil {
+		t.Fatal("want limiter, got nil")
+	}
+
+	if limiter.Limit() != rate.Limit(2.5) || limiter.Burst() != 3 {
+		t.Fatalf("want limit 2.5 and burst 3, got %v and %d", limiter.Limit(), limiter.Burst())
+	}
+
+	if other := r.Get("aws/foo", 10, 10); other != limiter {
+		t.Fatal("want the same limiter for the same key")
+	}
+
+	if other := r.Get("gcp/foo", 2.5, 0); other == limiter {
+		t.Fatal("want a different limiter for a different key")
+	}
+}
+
+// doerFunc is a [ratelimit.Doer], which calls the function.
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClientDo(t *testing.T) {
+	calls := 0
+	base := doerFunc(func(_ *http.Request) (*http.Response, error) {
+		calls++
+
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	// A single token, which is never refilled
+	limiter := rate.NewLimiter(rate.Limit(1e-9), 1)
+	client := &ratelimit.Client{Base: base, Limiter: limiter}
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Do(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The context is cancelled before a token becomes available
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Do(req.WithContext(ctx)); err == nil {
+		t.Fatal("want error for cancelled request, got nil")
+	}
+
+	if calls != 1 {
+		t.Fatalf("want 1 call, got %d", calls)
+	}
+}
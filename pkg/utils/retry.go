@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryOptions provides the options for [WithRetry].
+type RetryOptions struct {
+	// MaxAttempts specifies the max number of attempts, including the
+	// first one. A non-positive value is treated as a single attempt.
+	MaxAttempts int
+
+	// BaseDelay specifies the delay before the first retry, which is
+	// doubled with each subsequent retry.
+	BaseDelay time.Duration
+
+	// MaxDelay specifies the max delay between retries. Zero means no
+	// bound.
+	MaxDelay time.Duration
+
+	// IsTransient classifies errors as transient. If not specified,
+	// [IsTransientError] is used.
+	IsTransient func(err error) bool
+
+	// OnRetry, if set, is called before each retry with the number of the
+	// failed attempt and its error.
+	OnRetry func(attempt int, err error)
+}
+
+// DefaultRetryOptions are the default [RetryOptions] used when calling cloud
+// APIs.
+var DefaultRetryOptions = RetryOptions{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// sqlStateQueryCanceled is the SQLSTATE code of PostgreSQL errors, which are
+// returned for statements canceled by the database server, e.g. because they
+// exceeded the statement timeout.
+const sqlStateQueryCanceled = "57014"
+
+// throttlingErrorCodes are the API error codes, which signal that a request
+// has been throttled.
+var throttlingErrorCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestThrottled":                       true,
+	"RequestThrottledException":              true,
+	"RequestLimitExceeded":                   true,
+	"TooManyRequestsException":               true,
+	"ProvisionedThroughputExceededException": true,
+	"SlowDown":                               true,
+}
+
+// IsTransientError returns true, if the given error is likely to go away when
+// retrying the request, e.g. the request has been throttled, timed out, or the
+// connection has been reset. Database statements, which exceeded the statement
+// timeout, are transient as well. All other errors are considered permanent.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Cancellation by the caller is never transient
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	// Errors returned by the AWS SDK provide the HTTP status code via
+	// HTTPStatusCode, while the ones returned by gophercloud provide it
+	// via GetStatusCode.
+	var awsStatusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &awsStatusErr) && isTransientStatusCode(awsStatusErr.HTTPStatusCode()) {
+		return true
+	}
+
+	var statusErr interface{ GetStatusCode() int }
+	if errors.As(err, &statusErr) && isTransientStatusCode(statusErr.GetStatusCode()) {
+		return true
+	}
+
+	var apiErr interface{ ErrorCode() string }
+	if errors.As(err, &apiErr) && throttlingErrorCodes[apiErr.ErrorCode()] {
+		return true
+	}
+
+	// PostgreSQL errors provide the SQLSTATE code via Field('C')
+	var pgErr interface{ Field(k byte) string }
+	if errors.As(err, &pgErr) && pgErr.Field('C') == sqlStateQueryCanceled {
+		return true
+	}
+
+	return false
+}
+
+// isTransientStatusCode returns true, if the given HTTP status code signals a
+// transient error.
+func isTransientStatusCode(code int) bool {
+	return code == http.StatusTooManyRequests ||
+		code == http.StatusRequestTimeout ||
+		code >= http.StatusInternalServerError
+}
+
+// WithRetry calls fn until it succeeds, returns a permanent error, or the max
+// number of attempts is reached. Transient errors are retried with exponential
+// backoff and jitter, according to the given options.
+//
+// The error of the last attempt is returned as is, so that callers can decide
+// how to handle it, e.g. by skipping the retry of permanent errors via
+// asynq.SkipRetry.
+func WithRetry(ctx context.Context, fn func(ctx context.Context) error, opts RetryOptions) error {
+	isTransient := opts.IsTransient
+	if isTransient == nil {
+		isTransient = IsTransientError
+	}
+
+	delay := opts.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= opts.MaxAttempts || !isTransient(err) {
+			return err
+		}
+
+		// The deadline of the caller has been reached, so there is
+		// no point in retrying.
+		if ctx.Err() != nil {
+			return err
+		}
+
+		if opts.OnRetry != nil {
+			opts.OnRetry(attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(jitter(delay)):
+		}
+
+		delay *= 2
+		if opts.MaxDelay > 0 {
+			delay = min(delay, opts.MaxDelay)
+		}
+	}
+}
+
+// jitter returns a random duration in the range [d/2, d), so that retries of
+// concurrent callers are spread out.
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+
+	half := d / 2
+
+	return half + rand.N(d-half) // nolint: gosec
+}
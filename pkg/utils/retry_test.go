@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gardener/inventory/pkg/utils"
+)
+
+// statusError is an error, which provides an HTTP status code.
+type statusError int
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("status %d", int(e))
+}
+
+func (e statusError) GetStatusCode() int {
+	return int(e)
+}
+
+// apiError is an error, which provides an API error code.
+type apiError string
+
+func (e apiError) Error() string {
+	return string(e)
+}
+
+func (e apiError) ErrorCode() string {
+	return string(e)
+}
+
+// pgError is an error, which provides the SQLSTATE code of a PostgreSQL error.
+type pgError string
+
+func (e pgError) Error() string {
+	return "pg error " + string(e)
+}
+
+func (e pgError) Field(k byte) string {
+	if k == 'C' {
+		return string(e)
+	}
+
+	return ""
+}
+
+func TestIsTransientError(t *testing.T) {
+	testCases := []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{
+			desc: "nil error",
+			err:  nil,
+			want: false,
+		},
+		{
+			desc: "generic error",
+			err:  errors.New("boom"),
+			want: false,
+		},
+		{
+			desc: "cancelled context",
+			err:  context.Canceled,
+			want: false,
+		},
+		{
+			desc: "deadline exceeded",
+			err:  fmt.Errorf("request failed: %w", context.DeadlineExceeded),
+			want: true,
+		},
+		{
+			desc: "connection reset",
+			err:  fmt.Errorf("read: %w", syscall.ECONNRESET),
+			want: true,
+		},
+		{
+			desc: "too many requests",
+			err:  statusError(429),
+			want: true,
+		},
+		{
+			desc: "service unavailable",
+			err:  fmt.Errorf("request failed: %w", statusError(503)),
+			want: true,
+		},
+		{
+			desc: "not found",
+			err:  statusError(404),
+			want: false,
+		},
+		{
+			desc: "throttling error code",
+			err:  apiError("RequestLimitExceeded"),
+			want: true,
+		},
+		{
+			desc: "unauthorized error code",
+			err:  apiError("UnauthorizedOperation"),
+			want: false,
+		},
+		{
+			desc: "statement timeout",
+			err:  fmt.Errorf("insert failed: %w", pgError("57014")),
+			want: true,
+		},
+		{
+			desc: "unique violation",
+			err:  pgError("23505"),
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := utils.IsTransientError(tc.err)
+			if got != tc.want {
+				t.Fatalf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	errTransient := statusError(503)
+	errPermanent := errors.New("permanent")
+
+	testCases := []struct {
+		desc      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			desc:      "success on first attempt",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			desc:      "success after transient errors",
+			errs:      []error{errTransient, errTransient, nil},
+			wantCalls: 3,
+		},
+		{
+			desc:      "permanent error is not retried",
+			errs:      []error{errPermanent},
+			wantCalls: 1,
+			wantErr:   errPermanent,
+		},
+		{
+			desc:      "max attempts reached",
+			errs:      []error{errTransient, errTransient, errTransient, nil},
+			wantCalls: 3,
+			wantErr:   errTransient,
+		},
+	}
+
+	opts := utils.RetryOptions{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    2 * time.Millisecond,
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			calls := 0
+			fn := func(_ context.Context) error {
+				err := tc.errs[calls]
+				calls++
+
+				return err
+			}
+
+			err := utils.WithRetry(context.Background(), fn, opts)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+
+			if calls != tc.wantCalls {
+				t.Fatalf("want %d calls, got %d", tc.wantCalls, calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import "slices"
+
+// Reasons reported by [Selection.Check] for items, which are not selected.
+const (
+	// SelectionReasonExcluded is reported for items, which are in the
+	// exclude list.
+	SelectionReasonExcluded = "excluded"
+
+	// SelectionReasonNotIncluded is reported for items, which are not in
+	// the non-empty include list.
+	SelectionReasonNotIncluded = "not included"
+)
+
+// Selection specifies the items, e.g. projects or accounts, which are selected
+// for collection, by means of an include and an exclude list.
+type Selection struct {
+	// Include specifies the items to select. If empty, all items, which
+	// are not excluded are selected.
+	Include []string
+
+	// Exclude specifies the items, which are never selected. The exclude
+	// list takes precedence over the include list.
+	Exclude []string
+}
+
+// Check returns true, if the given item is selected. Otherwise, it returns
+// false along with the reason why the item is not selected.
+func (s Selection) Check(item string) (bool, string) {
+	if slices.Contains(s.Exclude, item) {
+		return false, SelectionReasonExcluded
+	}
+
+	if len(s.Include) > 0 && !slices.Contains(s.Include, item) {
+		return false, SelectionReasonNotIncluded
+	}
+
+	return true, ""
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gardener/inventory/pkg/utils"
+)
+
+func TestSelectionCheck(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		selection  utils.Selection
+		item       string
+		wantOK     bool
+		wantReason string
+	}{
+		{
+			desc:      "empty selection",
+			selection: utils.Selection{},
+			item:      "foo",
+			wantOK:    true,
+		},
+		{
+			desc:      "included item",
+			selection: utils.Selection{Include: []string{"foo", "bar"}},
+			item:      "foo",
+			wantOK:    true,
+		},
+		{
+			desc:       "item not in include list",
+			selection:  utils.Selection{Include: []string{"bar"}},
+			item:       "foo",
+			wantOK:     false,
+			wantReason: utils.SelectionReasonNotIncluded,
+		},
+		{
+			desc:       "excluded item",
+			selection:  utils.Selection{Exclude: []string{"foo"}},
+			item:       "foo",
+			wantOK:     false,
+			wantReason: utils.SelectionReasonExcluded,
+		},
+		{
+			desc: "exclude wins over include",
+			selection: utils.Selection{
+				Include: []string{"foo"},
+				Exclude: []string{"foo"},
+			},
+			item:       "foo",
+			wantOK:     false,
+			wantReason: utils.SelectionReasonExcluded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ok, reason := tc.selection.Check(tc.item)
+			if ok != tc.wantOK || reason != tc.wantReason {
+				t.Fatalf("want (%t, %q), got (%t, %q)", tc.wantOK, tc.wantReason, ok, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ListenAndServe serves HTTP requests using the given [http.Server] on the given
+// listener, until the context is canceled or SIGTERM or SIGINT is received. The
+// server is then gracefully shut down, waiting for the in-flight requests to
+// complete within the given shutdown timeout.
+//
+// If the listener is nil, the server listens on its configured address.
+func ListenAndServe(ctx context.Context, srv *http.Server, l net.Listener, shutdownTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		if l == nil {
+			errCh <- srv.ListenAndServe()
+
+			return
+		}
+		errCh <- srv.Serve(l)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down server", "address", srv.Addr, "timeout", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
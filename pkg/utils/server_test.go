@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gardener/inventory/pkg/utils"
+)
+
+func TestListenAndServe(t *testing.T) {
+	testCases := []struct {
+		desc string
+		stop func(cancel context.CancelFunc) error
+	}{
+		{
+			desc: "signal",
+			stop: func(_ context.CancelFunc) error {
+				return syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+			},
+		},
+		{
+			desc: "context canceled",
+			stop: func(cancel context.CancelFunc) error {
+				cancel()
+
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %s", err)
+			}
+
+			started := make(chan struct{})
+			mux := http.NewServeMux()
+			mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			mux.HandleFunc("/slow", func(w http.ResponseWriter, _ *http.Request) {
+				close(started)
+				time.Sleep(200 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			})
+			srv := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- utils.ListenAndServe(ctx, srv, l, 5*time.Second)
+			}()
+
+			url := "http://" + l.Addr().String()
+			resp, err := http.Get(url + "/ok")
+			if err != nil {
+				t.Fatalf("server is not serving: %s", err)
+			}
+			_ = resp.Body.Close()
+
+			// The in-flight request is expected to complete,
+			// while the server is shutting down.
+			slowCh := make(chan int, 1)
+			go func() {
+				resp, err := http.Get(url + "/slow")
+				if err != nil {
+					slowCh <- 0
+
+					return
+				}
+				_ = resp.Body.Close()
+				slowCh <- resp.StatusCode
+			}()
+
+			<-started
+			if err := tc.stop(cancel); err != nil {
+				t.Fatalf("failed to stop server: %s", err)
+			}
+
+			select {
+			case err := <-errCh:
+				if err != nil {
+					t.Fatalf("want clean stop, got %s", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("server did not stop")
+			}
+
+			if code := <-slowCh; code != http.StatusOK {
+				t.Fatalf("want in-flight request to complete with %d, got %d", http.StatusOK, code)
+			}
+		})
+	}
+}